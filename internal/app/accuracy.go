@@ -0,0 +1,161 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// MajorDirectoryShare is the share of the changed lines (in percent) above
+// which a directory is expected to be described by the message body.
+const MajorDirectoryShare = 25
+
+// bulletModuleRegex matches a body bullet that names its modules, e.g.
+// "- auth: add token refresh" or "- api, ui: rename the user field".
+var bulletModuleRegex = regexp.MustCompile(`^[-*•]\s+([a-z0-9_][\w./-]*(?:\s*,\s*[a-z0-9_][\w./-]*)*):\s`)
+
+// accuracyReport maps the modules named by the body bullets to the changed paths.
+type accuracyReport struct {
+	Modules     []string // Modules named by the bullets
+	Unknown     []string // Modules without changes
+	Directories []string // Directories with a major share of the changed lines
+	Omitted     []string // Major directories no bullet describes
+}
+
+// Score returns the share (in percent) of modules that match the changes and
+// major directories the body describes.
+func (r *accuracyReport) Score() int {
+	total := len(r.Modules) + len(r.Directories)
+	if total == 0 {
+		return 100
+	}
+	accurate := total - len(r.Unknown) - len(r.Omitted)
+	return accurate * 100 / total
+}
+
+// checkAccuracy cross-checks the modules named by the body bullets against
+// the changed paths. It returns nil when generation.check_accuracy is off or
+// the body names no modules, as then there is no mapping to check.
+func (s *CommitService) checkAccuracy(response *ai.GenerateResponse, chunks []git.DiffChunk) *accuracyReport {
+	if s.config == nil || !s.config.Generation.CheckAccuracy || response == nil {
+		return nil
+	}
+
+	body := response.Body
+	if body == "" && response.RawText != "" {
+		body = message.NewCommitMessage(response.RawText).Body
+	}
+	modules := bulletModules(body)
+	if len(modules) == 0 {
+		return nil
+	}
+
+	report := &accuracyReport{Modules: modules}
+	for _, module := range modules {
+		if !slices.ContainsFunc(chunks, func(chunk git.DiffChunk) bool { return moduleMatchesPath(module, chunk.FilePath) }) {
+			report.Unknown = append(report.Unknown, module)
+		}
+	}
+
+	for _, dir := range majorDirectories(chunks) {
+		report.Directories = append(report.Directories, dir)
+		described := slices.ContainsFunc(chunks, func(chunk git.DiffChunk) bool {
+			return path.Dir(chunk.FilePath) == dir && slices.ContainsFunc(modules, func(module string) bool {
+				return moduleMatchesPath(module, chunk.FilePath)
+			})
+		})
+		if !described {
+			report.Omitted = append(report.Omitted, dir)
+		}
+	}
+	return report
+}
+
+// showAccuracy shows the accuracy score, and each module without changes
+// and each major directory left out as a warning.
+func (s *CommitService) showAccuracy(report *accuracyReport) {
+	if report == nil {
+		return
+	}
+	s.uiManager.ShowInfo(i18n.T("commit.info.accuracy", report.Score(),
+		len(report.Modules)-len(report.Unknown), len(report.Modules),
+		len(report.Directories)-len(report.Omitted), len(report.Directories)))
+	for _, module := range report.Unknown {
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.accuracy_unknown", module)))
+	}
+	for _, dir := range report.Omitted {
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.accuracy_omitted", dir)))
+	}
+}
+
+// bulletModules returns the modules named by the body bullets, in order and
+// without duplicates. Bullets named after a commit type, such as
+// "- chore: update dependencies", describe no module and are skipped.
+func bulletModules(body string) []string {
+	var modules []string
+	for _, line := range strings.Split(body, "\n") {
+		matches := bulletModuleRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if matches == nil {
+			continue
+		}
+		for _, module := range strings.Split(matches[1], ",") {
+			module = strings.Trim(strings.TrimSpace(module), "/")
+			if module != "" && !message.IsValidCommitType(module) && !slices.Contains(modules, module) {
+				modules = append(modules, module)
+			}
+		}
+	}
+	return modules
+}
+
+// moduleMatchesPath reports whether a module names a changed path: a path
+// module such as "pkg/ai" is a part of it, and a plain module such as "user"
+// is a directory of it or starts its file name, e.g. "UserView.tsx".
+func moduleMatchesPath(module, filePath string) bool {
+	module = strings.ToLower(module)
+	filePath = strings.ToLower(filePath)
+	if strings.Contains(module, "/") {
+		return filePath == module || strings.HasPrefix(filePath, module+"/") || strings.Contains(filePath, "/"+module+"/")
+	}
+
+	segments := strings.Split(filePath, "/")
+	for _, dir := range segments[:len(segments)-1] {
+		if dir == module {
+			return true
+		}
+	}
+	return strings.HasPrefix(segments[len(segments)-1], module)
+}
+
+// majorDirectories returns the directories, other than the repository root,
+// with at least MajorDirectoryShare percent of the changed lines.
+func majorDirectories(chunks []git.DiffChunk) []string {
+	lines := make(map[string]int)
+	var dirs []string
+	total := 0
+	for _, chunk := range chunks {
+		changed := chunk.Additions + chunk.Deletions
+		total += changed
+		dir := path.Dir(chunk.FilePath)
+		if _, ok := lines[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		lines[dir] += changed
+	}
+
+	var major []string
+	for _, dir := range dirs {
+		if dir != "." && total > 0 && lines[dir]*100 >= total*MajorDirectoryShare {
+			major = append(major, dir)
+		}
+	}
+	return major
+}
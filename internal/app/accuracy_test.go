@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestBulletModules(t *testing.T) {
+	body := "Reworks login.\n\n- auth: add token refresh\n- api, ui: rename the user field\n* pkg/ai/: trim prompts\n" +
+		"- chore: update dependencies\n- auth: drop the old cookie\n- Note: not a module\nplain: text"
+
+	assert.Equal(t, []string{"auth", "api", "ui", "pkg/ai"}, bulletModules(body))
+	assert.Nil(t, bulletModules("Fixes the timeout."))
+}
+
+func TestModuleMatchesPath(t *testing.T) {
+	tests := []struct {
+		module   string
+		path     string
+		expected bool
+	}{
+		{"db", "internal/pkg/db/mysql.go", true},
+		{"user", "frontend/src/UserView.tsx", true},
+		{"config", "config.go", true},
+		{"pkg/ai", "internal/pkg/ai/prompt.go", true},
+		{"pkg/ai", "pkg/ai/prompt.go", true},
+		{"ai", "internal/pkg/mail/send.go", false},
+		{"pkg/ai", "internal/pkg/aide/x.go", false},
+		{"billing", "internal/auth/token.go", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, moduleMatchesPath(tt.module, tt.path), "%s in %s", tt.module, tt.path)
+	}
+}
+
+func TestMajorDirectories(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "internal/auth/token.go", Additions: 40},
+		{FilePath: "internal/auth/session.go", Additions: 10},
+		{FilePath: "internal/ui/login.go", Additions: 30},
+		{FilePath: "docs/auth.md", Additions: 5},
+		{FilePath: "README.md", Additions: 15},
+	}
+
+	assert.Equal(t, []string{"internal/auth", "internal/ui"}, majorDirectories(chunks))
+	assert.Nil(t, majorDirectories([]git.DiffChunk{{FilePath: "x/a.go"}}))
+}
+
+func TestCheckAccuracy(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "internal/auth/token.go", Additions: 40},
+		{FilePath: "internal/ui/login.go", Additions: 30},
+		{FilePath: "README.md", Additions: 5},
+	}
+	enabled := &config.Config{Generation: config.GenerationConfig{CheckAccuracy: true}}
+
+	t.Run("reports unknown modules and omitted directories", func(t *testing.T) {
+		service := NewCommitService(nil, nil, nil, nil, nil, enabled)
+		response := &ai.GenerateResponse{RawText: "feat(auth): add token refresh\n\n- auth: refresh tokens before expiry\n- billing: charge per session"}
+
+		report := service.checkAccuracy(response, chunks)
+		require.NotNil(t, report)
+		assert.Equal(t, []string{"auth", "billing"}, report.Modules)
+		assert.Equal(t, []string{"billing"}, report.Unknown)
+		assert.Equal(t, []string{"internal/auth", "internal/ui"}, report.Directories)
+		assert.Equal(t, []string{"internal/ui"}, report.Omitted)
+		assert.Equal(t, 50, report.Score())
+	})
+
+	t.Run("accurate body", func(t *testing.T) {
+		service := NewCommitService(nil, nil, nil, nil, nil, enabled)
+		response := &ai.GenerateResponse{Body: "- auth: refresh tokens\n- ui: show the session timer"}
+
+		report := service.checkAccuracy(response, chunks)
+		require.NotNil(t, report)
+		assert.Empty(t, report.Unknown)
+		assert.Empty(t, report.Omitted)
+		assert.Equal(t, 100, report.Score())
+	})
+
+	t.Run("nothing to check", func(t *testing.T) {
+		service := NewCommitService(nil, nil, nil, nil, nil, enabled)
+		assert.Nil(t, service.checkAccuracy(&ai.GenerateResponse{Body: "Refreshes tokens."}, chunks))
+
+		disabled := NewCommitService(nil, nil, nil, nil, nil, &config.Config{})
+		assert.Nil(t, disabled.checkAccuracy(&ai.GenerateResponse{Body: "- billing: x"}, chunks))
+	})
+}
+
+func TestShowAccuracy(t *testing.T) {
+	uiManager := &MockUIManager{}
+	service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+	uiManager.On("ShowInfo", "Body accuracy: 50% (1/2 modules match the changes, 1/2 major directories described)").Return().Once()
+	uiManager.On("ShowError", errors.New(`body mentions module "billing", which has no changes`)).Return().Once()
+	uiManager.On("ShowError", errors.New("body does not describe the changes in internal/ui")).Return().Once()
+
+	service.showAccuracy(&accuracyReport{
+		Modules:     []string{"auth", "billing"},
+		Unknown:     []string{"billing"},
+		Directories: []string{"internal/auth", "internal/ui"},
+		Omitted:     []string{"internal/ui"},
+	})
+	service.showAccuracy(nil)
+	uiManager.AssertExpectations(t)
+}
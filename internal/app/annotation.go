@@ -0,0 +1,63 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Annotation levels, the GitHub Actions workflow commands that create them.
+const (
+	AnnotationError   = "error"
+	AnnotationWarning = "warning"
+	AnnotationNotice  = "notice"
+)
+
+// Annotation is a GitHub Actions workflow command, such as
+// "::error title=...::message", which the runner shows as an annotation on
+// the run, or on a file line when File is set.
+type Annotation struct {
+	Level   string
+	Title   string
+	File    string
+	Line    int
+	Message string
+}
+
+// String returns the workflow command, escaped as the runner expects so that
+// multi-line messages stay on one line.
+func (a Annotation) String() string {
+	var params []string
+	if a.File != "" {
+		params = append(params, "file="+escapeAnnotationProperty(a.File))
+		if a.Line > 0 {
+			params = append(params, fmt.Sprintf("line=%d", a.Line))
+		}
+	}
+	if a.Title != "" {
+		params = append(params, "title="+escapeAnnotationProperty(a.Title))
+	}
+
+	command := "::" + a.Level
+	if len(params) > 0 {
+		command += " " + strings.Join(params, ",")
+	}
+	return command + "::" + escapeAnnotationData(a.Message)
+}
+
+// escapeAnnotationData escapes a workflow command message.
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a workflow command parameter value, which
+// also ends at a comma or colon.
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// IsReportFormat reports whether the output format is read by tools rather
+// than people: nothing is prompted for or displayed besides the report.
+func IsReportFormat(format string) bool {
+	return format == OutputFormatJSON || format == OutputFormatGitHub
+}
@@ -0,0 +1,50 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotation_String(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation Annotation
+		want       string
+	}{
+		{
+			name:       "message only",
+			annotation: Annotation{Level: AnnotationNotice, Message: "3 commits checked"},
+			want:       "::notice::3 commits checked",
+		},
+		{
+			name:       "multi-line message",
+			annotation: Annotation{Level: AnnotationNotice, Title: "Commit message", Message: "feat: a\r\n\n- 100% done"},
+			want:       "::notice title=Commit message::feat: a%0D%0A%0A- 100%25 done",
+		},
+		{
+			name:       "file and title with separators",
+			annotation: Annotation{Level: AnnotationError, Title: "Commit abc1234: wip, part 1", File: "msg.txt", Line: 1, Message: "type: missing commit type"},
+			want:       "::error file=msg.txt,line=1,title=Commit abc1234%3A wip%2C part 1::type: missing commit type",
+		},
+		{
+			name:       "line without file",
+			annotation: Annotation{Level: AnnotationWarning, Line: 3, Message: "long subject"},
+			want:       "::warning::long subject",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.annotation.String())
+		})
+	}
+}
+
+func TestIsReportFormat(t *testing.T) {
+	assert.True(t, IsReportFormat(OutputFormatJSON))
+	assert.True(t, IsReportFormat(OutputFormatGitHub))
+	assert.False(t, IsReportFormat(OutputFormatText))
+	assert.False(t, IsReportFormat(""))
+}
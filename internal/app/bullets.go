@@ -0,0 +1,106 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// bulletRegex matches the first line of a body bullet, e.g. "- auth: ...",
+// capturing its indentation.
+var bulletRegex = regexp.MustCompile(`^(\s*)[-*•]\s+\S`)
+
+// bodyBlock is a line of the message body, or a bullet with the lines
+// continuing it, such as wrapped text and nested bullets.
+type bodyBlock struct {
+	text   string
+	bullet bool
+}
+
+// splitBodyBullets splits a body into blocks. A bullet runs until the next
+// bullet of the same or lesser indentation, or the first line that is not
+// indented further than the bullet.
+func splitBodyBullets(body string) []bodyBlock {
+	var blocks []bodyBlock
+	indent := -1 // Indentation of the open bullet, -1 if there is none
+	for _, line := range strings.Split(body, "\n") {
+		lineIndent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if match := bulletRegex.FindStringSubmatch(line); match != nil && (indent < 0 || len(match[1]) <= indent) {
+			blocks = append(blocks, bodyBlock{text: line, bullet: true})
+			indent = len(match[1])
+			continue
+		}
+		if indent >= 0 && strings.TrimSpace(line) != "" && lineIndent > indent {
+			blocks[len(blocks)-1].text += "\n" + line
+			continue
+		}
+		blocks = append(blocks, bodyBlock{text: line})
+		indent = -1
+	}
+	return blocks
+}
+
+// bodyBullets returns the bullets of the blocks.
+func bodyBullets(blocks []bodyBlock) []string {
+	var bullets []string
+	for _, block := range blocks {
+		if block.bullet {
+			bullets = append(bullets, block.text)
+		}
+	}
+	return bullets
+}
+
+// joinBodyBullets rejoins the blocks, leaving out the bullets not kept. A
+// paragraph emptied by dropping its bullets leaves no extra blank lines.
+func joinBodyBullets(blocks []bodyBlock, keep []bool) string {
+	var lines []string
+	i := 0
+	for _, block := range blocks {
+		if block.bullet {
+			kept := keep[i]
+			i++
+			if !kept {
+				continue
+			}
+		}
+		// Collapse the blank lines left behind
+		if strings.TrimSpace(block.text) == "" && (len(lines) == 0 || strings.TrimSpace(lines[len(lines)-1]) == "") {
+			continue
+		}
+		lines = append(lines, block.text)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// pickBullets lets the user drop bullets from the body of the message and
+// returns the message with the remaining bullets. Returns nil if the user
+// backed out.
+func (s *CommitService) pickBullets(response *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	msg := message.NewCommitMessage(s.formatCommitMessage(response))
+	blocks := splitBodyBullets(msg.Body)
+	bullets := bodyBullets(blocks)
+	if len(bullets) == 0 {
+		return nil, errors.New("the message body has no bullets")
+	}
+
+	keep, err := s.uiManager.SelectBullets(bullets)
+	if err != nil || keep == nil {
+		return nil, err
+	}
+	if len(keep) != len(bullets) {
+		return nil, errors.New("bullet selection does not match the bullets")
+	}
+
+	msg.Body = joinBodyBullets(blocks, keep)
+	return &ai.GenerateResponse{
+		Subject: msg.FormatSubject(),
+		Body:    msg.Body,
+		Footer:  msg.Footer,
+		RawText: msg.Format(),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBodyBullets(t *testing.T) {
+	body := "Rework token refresh.\n\n- auth: refresh tokens before they expire\n  so requests do not fail\n- api: retry once on 401\n  - keep the original error\n* update tests\n\nThe old refresh loop is gone."
+
+	blocks := splitBodyBullets(body)
+	assert.Equal(t, []string{
+		"- auth: refresh tokens before they expire\n  so requests do not fail",
+		"- api: retry once on 401\n  - keep the original error",
+		"* update tests",
+	}, bodyBullets(blocks))
+
+	// Keeping everything gives back the body
+	assert.Equal(t, body, joinBodyBullets(blocks, []bool{true, true, true}))
+}
+
+func TestJoinBodyBullets(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		keep []bool
+		want string
+	}{
+		{
+			name: "drop one",
+			body: "- auth: refresh tokens\n- update tests\n- api: retry on 401",
+			keep: []bool{true, false, true},
+			want: "- auth: refresh tokens\n- api: retry on 401",
+		},
+		{
+			name: "emptied paragraph",
+			body: "Intro.\n\n- update tests\n\nOutro.",
+			keep: []bool{false},
+			want: "Intro.\n\nOutro.",
+		},
+		{
+			name: "drop all",
+			body: "- update tests\n- fix typo",
+			keep: []bool{false, false},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, joinBodyBullets(splitBodyBullets(tt.body), tt.keep))
+		})
+	}
+}
+
+func TestGenerateAndCommit_PickBullets(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{{FilePath: "auth.go", ChangeType: git.ChangeTypeModified, Content: "+a"}}
+	response := &ai.GenerateResponse{
+		Subject: "feat(auth): refresh tokens",
+		Body:    "- auth: refresh tokens before they expire\n- update tests",
+		Footer:  "Refs: #12",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil).Maybe()
+	gitClient.On("Commit", mock.Anything, mock.Anything).Return(nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+	aiProvider.On("Name").Return("mock").Maybe()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionPickBullets, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+	uiManager.On("SelectBullets", []string{"- auth: refresh tokens before they expire", "- update tests"}).Return([]bool{true, false}, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	require.NoError(t, err)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "feat(auth): refresh tokens\n\n- auth: refresh tokens before they expire\n\nRefs: #12")
+}
+
+func TestPickBullets_NoBullets(t *testing.T) {
+	service := NewCommitService(&MockGitClient{}, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	_, err := service.pickBullets(&ai.GenerateResponse{Subject: "fix: typo", Body: "Just a paragraph."})
+	assert.Error(t, err)
+}
+
+func TestPickBullets_BackOut(t *testing.T) {
+	uiManager := &MockUIManager{}
+	uiManager.On("SelectBullets", mock.Anything).Return(nil, nil)
+	service := NewCommitService(&MockGitClient{}, &MockAIProvider{}, &MockDiffProcessor{}, uiManager, nil, &config.Config{})
+
+	picked, err := service.pickBullets(&ai.GenerateResponse{Subject: "fix: typo", Body: "- one\n- two"})
+	assert.NoError(t, err)
+	assert.Nil(t, picked)
+}
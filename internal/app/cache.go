@@ -0,0 +1,40 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// cachedResponse returns the message stored in the response cache. Entries
+// loaded from the cache file hold the message as JSON.
+func cachedResponse(value interface{}) (*ai.GenerateResponse, bool) {
+	switch v := value.(type) {
+	case *ai.GenerateResponse:
+		return v, true
+	case json.RawMessage:
+		var response ai.GenerateResponse
+		if err := json.Unmarshal(v, &response); err != nil {
+			apperrors.Debug("Ignoring a cached response: %v", err)
+			return nil, false
+		}
+		return &response, true
+	}
+	return nil, false
+}
+
+// saveCache writes the response cache to cache.file_path, if one is set.
+func (s *CommitService) saveCache() {
+	if s.config == nil || s.config.Cache.FilePath == "" {
+		return
+	}
+	saver, ok := s.cache.(interface{ Save(path string) error })
+	if !ok {
+		return
+	}
+	if err := saver.Save(s.config.Cache.FilePath); err != nil {
+		apperrors.Warn("Failed to save the response cache: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestResponseCache_KeptBetweenRuns(t *testing.T) {
+	cfg := &config.Config{Cache: config.CacheConfig{
+		Enabled:    true,
+		MaxEntries: 10,
+		TTLMinutes: 60,
+		FilePath:   filepath.Join(t.TempDir(), "cache.json"),
+	}}
+	response := &ai.GenerateResponse{Subject: "feat: add cache", Body: "- keep responses"}
+
+	first := NewCommitService(nil, nil, nil, nil, nil, cfg)
+	first.cache.Set("key", response, 0)
+	first.saveCache()
+
+	second := NewCommitService(nil, nil, nil, nil, nil, cfg)
+	value, ok := second.cache.Get("key")
+	require.True(t, ok)
+	cached, ok := cachedResponse(value)
+	require.True(t, ok)
+	assert.Equal(t, response, cached)
+
+	_, ok = cachedResponse("not a response")
+	assert.False(t, ok)
+}
@@ -0,0 +1,137 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// CompareProvider is one of the providers compared with --compare.
+type CompareProvider struct {
+	Provider ai.Provider
+	// Model is the model the provider uses, recorded in history if its
+	// message is chosen; empty if it uses the provider's default.
+	Model string
+}
+
+// candidate is the result of one compared provider.
+type candidate struct {
+	provider CompareProvider
+	response *ai.GenerateResponse
+	err      error
+}
+
+// SetCompare makes the first message of the session come from all the
+// providers at once: the user picks one of their messages, and its provider
+// generates the rest of the session. Fewer than two providers compare nothing.
+func (s *CommitService) SetCompare(providers []CompareProvider) {
+	if len(providers) < 2 {
+		s.compare = nil
+		return
+	}
+	s.compare = providers
+}
+
+// requestMessage sends the final request for the commit message. While
+// providers are being compared it goes to all of them concurrently; their
+// results are kept for pickCandidate and the first message is returned.
+func (s *CommitService) requestMessage(ctx context.Context, req *ai.GenerateRequest) (*ai.GenerateResponse, error) {
+	if len(s.compare) == 0 {
+		response, err := s.aiProvider.GenerateCommitMessage(ctx, req)
+		s.usage.record(response)
+		return response, err
+	}
+
+	candidates := make([]candidate, len(s.compare))
+	var wg sync.WaitGroup
+	for i, provider := range s.compare {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Providers may fill in request defaults, so each gets its own copy
+			r := *req
+			response, err := provider.Provider.GenerateCommitMessage(ctx, &r)
+			s.usage.record(response)
+			if err == nil && response == nil {
+				err = errors.New("empty response")
+			}
+			candidates[i] = candidate{provider: provider, response: response, err: err}
+		}()
+	}
+	wg.Wait()
+	s.candidates = candidates
+
+	for _, c := range candidates {
+		if c.err == nil {
+			return c.response, nil
+		}
+	}
+	return nil, candidates[0].err
+}
+
+// pickCandidate lets the user choose among the messages of the compared
+// providers and makes the chosen provider generate the rest of the session.
+// response is the first message after the checks of generateCommitMessage,
+// which stands in for it; the others get the requested type and scope
+// applied. Without a comparison, response is returned unchanged.
+func (s *CommitService) pickCandidate(response *ai.GenerateResponse, intent ai.Intent) (*ai.GenerateResponse, error) {
+	candidates := s.candidates
+	s.candidates = nil
+	if len(candidates) == 0 {
+		return response, nil
+	}
+
+	var options []ui.Candidate
+	var providers []CompareProvider
+	for _, c := range candidates {
+		name := c.provider.Provider.Name()
+		s.compared = append(s.compared, name)
+		if c.err != nil {
+			s.uiManager.ShowError(errors.New(i18n.T("commit.warning.compare", name, c.err)))
+			continue
+		}
+
+		message := c.response
+		switch {
+		case len(options) == 0:
+			message = response
+		case intent.Check(message.Subject) != nil:
+			message = intent.Apply(message)
+		}
+		options = append(options, ui.Candidate{Provider: name, Message: message})
+		providers = append(providers, c.provider)
+	}
+	s.compare = nil
+
+	idx := 0
+	if len(options) > 1 {
+		var err error
+		idx, err = s.uiManager.SelectCandidate(options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select provider: %w", err)
+		}
+		// Backing out keeps the first message
+		if idx < 0 || idx >= len(options) {
+			idx = 0
+		}
+	}
+
+	s.aiProvider = providers[idx].Provider
+	s.model = providers[idx].Model
+	return options[idx].Message, nil
+}
+
+// modelName returns the model generating the messages, for the cache key and
+// history: the configured one, or that of the provider picked in a comparison.
+func (s *CommitService) modelName() string {
+	if s.compared != nil || s.config == nil {
+		return s.model
+	}
+	return s.config.Provider.Model
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func newCompareProvider(name string) *MockAIProvider {
+	provider := &MockAIProvider{}
+	provider.On("Name").Return(name)
+	return provider
+}
+
+func TestSetCompare_NeedsTwoProviders(t *testing.T) {
+	service := NewCommitService(&MockGitClient{}, &MockAIProvider{}, nil, &MockUIManager{}, nil, &config.Config{})
+
+	service.SetCompare([]CompareProvider{{Provider: newCompareProvider("openai")}})
+	assert.Nil(t, service.compare)
+}
+
+func TestCompare_PicksProvider(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Provider.Model = "gpt-4o-mini"
+	openai, ollama := newCompareProvider("openai"), newCompareProvider("ollama")
+	uiManager := &MockUIManager{}
+	service := NewCommitService(&MockGitClient{}, openai, nil, uiManager, nil, cfg)
+	service.SetCompare([]CompareProvider{
+		{Provider: openai, Model: "gpt-4o-mini"},
+		{Provider: ollama, Model: "llama3"},
+	})
+
+	openai.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "feat: add login"}, nil).Once()
+	ollama.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{
+		Subject: "feat: add login form",
+		Usage:   &ai.Usage{PromptTokens: 900, CompletionTokens: 30},
+	}, nil).Once()
+	uiManager.On("SelectCandidate", mock.MatchedBy(func(candidates []ui.Candidate) bool {
+		return len(candidates) == 2 && candidates[0].Provider == "openai" && candidates[1].Provider == "ollama"
+	})).Return(1, nil).Once()
+
+	first, err := service.requestMessage(context.Background(), &ai.GenerateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "feat: add login", first.Subject, "the first provider's message stands in until one is picked")
+
+	picked, err := service.pickCandidate(first, ai.Intent{})
+	require.NoError(t, err)
+	assert.Equal(t, "feat: add login form", picked.Subject)
+	assert.Equal(t, []string{"openai", "ollama"}, service.compared)
+	assert.Equal(t, "llama3", service.modelName())
+	assert.Equal(t, 900, service.usage.take().PromptTokens)
+
+	// The rest of the session uses the chosen provider alone
+	ollama.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "feat: add sign-in form"}, nil).Once()
+	next, err := service.requestMessage(context.Background(), &ai.GenerateRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "feat: add sign-in form", next.Subject)
+	openai.AssertNumberOfCalls(t, "GenerateCommitMessage", 1)
+
+	same, err := service.pickCandidate(next, ai.Intent{})
+	require.NoError(t, err)
+	assert.Same(t, next, same)
+}
+
+func TestCompare_FailedProvider(t *testing.T) {
+	openai, ollama := newCompareProvider("openai"), newCompareProvider("ollama")
+	uiManager := &MockUIManager{}
+	service := NewCommitService(&MockGitClient{}, openai, nil, uiManager, nil, &config.Config{})
+	service.SetCompare([]CompareProvider{{Provider: openai}, {Provider: ollama}})
+
+	openai.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited"))
+	ollama.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "fix: handle nil config"}, nil)
+	uiManager.On("ShowError", mock.MatchedBy(func(err error) bool {
+		return strings.Contains(err.Error(), "openai") && strings.Contains(err.Error(), "rate limited")
+	})).Return().Once()
+
+	response, err := service.requestMessage(context.Background(), &ai.GenerateRequest{})
+	require.NoError(t, err)
+
+	picked, err := service.pickCandidate(response, ai.Intent{})
+	require.NoError(t, err)
+	assert.Equal(t, "fix: handle nil config", picked.Subject)
+	assert.Same(t, ollama, service.aiProvider)
+	uiManager.AssertNotCalled(t, "SelectCandidate", mock.Anything)
+	uiManager.AssertExpectations(t)
+}
+
+func TestCompare_AllProvidersFail(t *testing.T) {
+	openai, ollama := newCompareProvider("openai"), newCompareProvider("ollama")
+	service := NewCommitService(&MockGitClient{}, openai, nil, &MockUIManager{}, nil, &config.Config{})
+	service.SetCompare([]CompareProvider{{Provider: openai}, {Provider: ollama}})
+
+	openai.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("invalid API key"))
+	ollama.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	_, err := service.requestMessage(context.Background(), &ai.GenerateRequest{})
+	assert.ErrorContains(t, err, "invalid API key")
+}
+
+func TestCompare_AppliesIntent(t *testing.T) {
+	openai, ollama := newCompareProvider("openai"), newCompareProvider("ollama")
+	uiManager := &MockUIManager{}
+	service := NewCommitService(&MockGitClient{}, openai, nil, uiManager, nil, &config.Config{})
+	service.SetCompare([]CompareProvider{{Provider: openai}, {Provider: ollama}})
+
+	openai.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "fix(auth): refresh token"}, nil)
+	ollama.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "feat: refresh token"}, nil)
+	uiManager.On("SelectCandidate", mock.Anything).Return(1, nil)
+
+	response, err := service.requestMessage(context.Background(), &ai.GenerateRequest{})
+	require.NoError(t, err)
+
+	picked, err := service.pickCandidate(response, ai.Intent{Type: "fix", Scope: "auth"})
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): refresh token", picked.Subject)
+}
@@ -0,0 +1,73 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"errors"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// InitialConcurrentGroups is the number of file groups summarized at once
+// at the start of two-phase processing.
+const InitialConcurrentGroups = 2
+
+// MaxConcurrentGroups is the maximum number of concurrent AI calls.
+const MaxConcurrentGroups = 8
+
+// FastGroupResponse is the time under which every summary of a batch must
+// arrive for the next batch to run more groups at once.
+const FastGroupResponse = 5 * time.Second
+
+// maxGroupBackoff caps the wait between batches after rate limiting.
+const maxGroupBackoff = 30 * time.Second
+
+// groupConcurrency adapts the number of file groups summarized at once. It
+// doubles while the provider answers quickly, which lets local models that
+// are not rate limited work through large diffs, and halves with an
+// exponential wait whenever the provider rate limits.
+type groupConcurrency struct {
+	limit   int
+	backoff time.Duration
+}
+
+func newGroupConcurrency() *groupConcurrency {
+	return &groupConcurrency{limit: InitialConcurrentGroups}
+}
+
+// adjust updates the limit after a batch and returns how long to wait before
+// the next one. slowest is the longest time a summary of the batch took to
+// arrive. A batch answered entirely from the cache changes nothing.
+func (c *groupConcurrency) adjust(requested, rateLimited bool, slowest time.Duration) time.Duration {
+	if !requested {
+		return 0
+	}
+
+	previous := c.limit
+	if rateLimited {
+		c.limit = max(c.limit/2, 1)
+		c.backoff = min(max(c.backoff*2, time.Second), maxGroupBackoff)
+		apperrors.Debug("Rate limited, summarizing %d groups at once after %v", c.limit, c.backoff)
+		return c.backoff
+	}
+
+	c.backoff = 0
+	if slowest < FastGroupResponse {
+		c.limit = min(c.limit*2, MaxConcurrentGroups)
+	}
+	if c.limit != previous {
+		apperrors.Debug("Summarizing %d groups at once", c.limit)
+	}
+	return 0
+}
+
+// isRateLimited reports whether the provider rejected a request for rate
+// limiting, however deeply the error is wrapped.
+func isRateLimited(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if appErr, ok := err.(*apperrors.AppError); ok && appErr.Code == apperrors.ErrRateLimited {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestGroupConcurrency(t *testing.T) {
+	c := newGroupConcurrency()
+	assert.Equal(t, InitialConcurrentGroups, c.limit)
+
+	// Fast responses double the limit up to the maximum
+	for _, want := range []int{4, 8, 8} {
+		assert.Zero(t, c.adjust(true, false, time.Second))
+		assert.Equal(t, want, c.limit)
+	}
+
+	// Slow responses and cached batches keep it
+	assert.Zero(t, c.adjust(true, false, FastGroupResponse))
+	assert.Zero(t, c.adjust(false, false, 0))
+	assert.Equal(t, 8, c.limit)
+
+	// Rate limiting halves it and backs off longer each time
+	assert.Equal(t, time.Second, c.adjust(true, true, time.Second))
+	assert.Equal(t, 4, c.limit)
+	assert.Equal(t, 2*time.Second, c.adjust(true, true, time.Second))
+	assert.Equal(t, 2, c.limit)
+	for range 10 {
+		c.adjust(true, true, time.Second)
+	}
+	assert.Equal(t, 1, c.limit)
+	assert.Equal(t, maxGroupBackoff, c.backoff)
+
+	// The backoff resets once the provider answers again
+	assert.Zero(t, c.adjust(true, false, time.Second))
+	assert.Equal(t, 2, c.limit)
+	assert.Equal(t, time.Second, c.adjust(true, true, time.Second))
+}
+
+func TestIsRateLimited(t *testing.T) {
+	assert.True(t, isRateLimited(apperrors.NewRateLimitError(0)))
+	assert.True(t, isRateLimited(fmt.Errorf("summary failed: %w", apperrors.NewAIProviderError("openai", apperrors.NewRateLimitError(time.Second)))))
+	assert.False(t, isRateLimited(apperrors.NewTimeoutError(context.DeadlineExceeded)))
+	assert.False(t, isRateLimited(nil))
+}
+
+func TestSummarizeGroup_CountsRateLimits(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, apperrors.NewRateLimitError(0))
+	aiProvider.On("Name").Return("mock").Maybe()
+	service := NewCommitService(&MockGitClient{}, aiProvider, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	group := fileGroup{chunks: []git.DiffChunk{{FilePath: "a.go", Content: "+a"}}, files: []string{"a.go"}}
+	_, _, degraded, err := service.summarizeGroup(context.Background(), group, GroupFailureList)
+
+	assert.NoError(t, err)
+	assert.True(t, degraded)
+	assert.Equal(t, int32(1), service.rateLimits.Load())
+}
@@ -0,0 +1,158 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// Output formats for dry-run results. GitHub writes GitHub Actions workflow
+// commands, which annotate the run a check is part of.
+const (
+	OutputFormatText   = "text"
+	OutputFormatJSON   = "json"
+	OutputFormatGitHub = "github"
+)
+
+// dryRunReport describes the commit a dry run would have made.
+type dryRunReport struct {
+	Message  string       `json:"message"`
+	Breaking bool         `json:"breaking"`
+	Files    []dryRunFile `json:"files"`
+	Stats    dryRunStats  `json:"stats"`
+}
+
+// dryRunFile is a file that would be committed.
+type dryRunFile struct {
+	Path      string `json:"path"`
+	OldPath   string `json:"old_path,omitempty"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Binary    bool   `json:"binary,omitempty"`
+}
+
+// dryRunStats are the totals over all files that would be committed.
+type dryRunStats struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// reportDryRun outputs what would have been committed: the message and file
+// list as text, both as a JSON document, or the message as GitHub Actions
+// annotations. In the text format, an output file receives only the message
+// so it can be passed to git commit -F.
+func (s *CommitService) reportDryRun(opts *CommitOptions, commitMsg string, diffStats *git.DiffStats) error {
+	report := newDryRunReport(commitMsg, diffStats)
+
+	if opts.OutputFormat == OutputFormatGitHub {
+		annotations := report.annotations()
+		if opts.OutputFile != "" {
+			return s.writeToFile(opts.OutputFile, annotations)
+		}
+		s.uiManager.ShowInfo(strings.TrimSuffix(annotations, "\n"))
+		return nil
+	}
+
+	if opts.OutputFormat == OutputFormatJSON {
+		data, err := report.toJSON()
+		if err != nil {
+			return err
+		}
+		if opts.OutputFile != "" {
+			return s.writeToFile(opts.OutputFile, data+"\n")
+		}
+		s.uiManager.ShowInfo(data)
+		return nil
+	}
+
+	// Message already displayed, list the files it describes
+	s.uiManager.ShowInfo(report.fileList())
+	if opts.OutputFile != "" {
+		return s.writeToFile(opts.OutputFile, commitMsg)
+	}
+	s.uiManager.ShowSuccess(i18n.T("commit.success.dry_run"))
+	return nil
+}
+
+// newDryRunReport builds the report from the message and the full staged
+// diff, including lock and generated files left out of the prompt.
+func newDryRunReport(commitMsg string, stats *git.DiffStats) *dryRunReport {
+	report := &dryRunReport{
+		Message:  commitMsg,
+		Breaking: message.NewCommitMessage(commitMsg).IsBreaking(),
+		Files:    []dryRunFile{},
+	}
+	if stats == nil {
+		return report
+	}
+
+	for _, chunk := range stats.Chunks {
+		report.Files = append(report.Files, dryRunFile{
+			Path:      chunk.FilePath,
+			OldPath:   chunk.OldPath,
+			Status:    chunk.ChangeType.String(),
+			Additions: chunk.Additions,
+			Deletions: chunk.Deletions,
+			Binary:    chunk.IsBinary,
+		})
+	}
+	report.Stats = dryRunStats{
+		Files:     stats.TotalFiles,
+		Additions: stats.TotalAdditions,
+		Deletions: stats.TotalDeletions,
+	}
+	return report
+}
+
+// toJSON returns the report as an indented JSON document.
+func (r *dryRunReport) toJSON() (string, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode dry-run report: %w", err)
+	}
+	return string(data), nil
+}
+
+// annotations returns the message as a notice annotation titled with the
+// totals, after a warning for each way it strays from Conventional Commits.
+func (r *dryRunReport) annotations() string {
+	var sb strings.Builder
+	for _, warning := range message.NewCommitMessage(r.Message).ValidateWithWarnings().Warnings {
+		sb.WriteString(Annotation{Level: AnnotationWarning, Title: "Commit message", Message: warning}.String())
+		sb.WriteString("\n")
+	}
+
+	title := fmt.Sprintf("Commit message (%d files, +%d -%d)", r.Stats.Files, r.Stats.Additions, r.Stats.Deletions)
+	if r.Breaking {
+		title += ", breaking change"
+	}
+	sb.WriteString(Annotation{Level: AnnotationNotice, Title: title, Message: r.Message}.String())
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// fileList returns the files and totals as text, one file per line.
+func (r *dryRunReport) fileList() string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T("commit.dry_run.files", r.Stats.Files, r.Stats.Additions, r.Stats.Deletions))
+	sb.WriteString("\n")
+	for _, file := range r.Files {
+		path := file.Path
+		if file.OldPath != "" && file.OldPath != file.Path {
+			path = file.OldPath + " -> " + file.Path
+		}
+		if file.Binary {
+			fmt.Fprintf(&sb, "  %-10s %s (binary)\n", file.Status, path)
+			continue
+		}
+		fmt.Fprintf(&sb, "  %-10s %s (+%d -%d)\n", file.Status, path, file.Additions, file.Deletions)
+	}
+	return sb.String()
+}
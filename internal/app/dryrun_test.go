@@ -0,0 +1,125 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testDryRunStats() *git.DiffStats {
+	return &git.DiffStats{
+		TotalFiles:     3,
+		TotalAdditions: 12,
+		TotalDeletions: 4,
+		Chunks: []git.DiffChunk{
+			{FilePath: "auth/login.go", ChangeType: git.ChangeTypeModified, Additions: 10, Deletions: 4},
+			{FilePath: "auth/session.go", OldPath: "auth/token.go", ChangeType: git.ChangeTypeRenamed, Additions: 2},
+			{FilePath: "logo.png", ChangeType: git.ChangeTypeAdded, IsBinary: true},
+		},
+	}
+}
+
+func TestDryRunReport_FileList(t *testing.T) {
+	report := newDryRunReport("fix(auth): 修复登录超时", testDryRunStats())
+
+	list := report.fileList()
+
+	assert.Contains(t, list, "Files to be committed (3, +12 -4):")
+	assert.Contains(t, list, "modified   auth/login.go (+10 -4)")
+	assert.Contains(t, list, "renamed    auth/token.go -> auth/session.go (+2 -0)")
+	assert.Contains(t, list, "added      logo.png (binary)")
+}
+
+func TestReportDryRun_JSON(t *testing.T) {
+	uiManager := &MockUIManager{}
+	service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+
+	var output string
+	uiManager.On("ShowInfo", mock.Anything).Run(func(args mock.Arguments) {
+		output = args.String(0)
+	}).Return()
+
+	err := service.reportDryRun(&CommitOptions{DryRun: true, OutputFormat: OutputFormatJSON}, "fix(auth): 修复登录超时", testDryRunStats())
+	require.NoError(t, err)
+
+	var report dryRunReport
+	require.NoError(t, json.Unmarshal([]byte(output), &report))
+	assert.Equal(t, "fix(auth): 修复登录超时", report.Message)
+	assert.False(t, report.Breaking)
+	assert.Equal(t, dryRunStats{Files: 3, Additions: 12, Deletions: 4}, report.Stats)
+	require.Len(t, report.Files, 3)
+	assert.Equal(t, dryRunFile{Path: "auth/session.go", OldPath: "auth/token.go", Status: "renamed", Additions: 2}, report.Files[1])
+	assert.True(t, report.Files[2].Binary)
+
+	// Only the report is written, so the output can be piped to other tools
+	uiManager.AssertNotCalled(t, "ShowSuccess", mock.Anything)
+}
+
+func TestReportDryRun_GitHub(t *testing.T) {
+	uiManager := &MockUIManager{}
+	service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+
+	var output string
+	uiManager.On("ShowInfo", mock.Anything).Run(func(args mock.Arguments) {
+		output = args.String(0)
+	}).Return()
+
+	message := "feat(auth)!: " + strings.Repeat("a", 100) + "\n\n- auth/login.go: 100% coverage"
+	err := service.reportDryRun(&CommitOptions{DryRun: true, OutputFormat: OutputFormatGitHub}, message, testDryRunStats())
+	require.NoError(t, err)
+
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "::warning title=Commit message::subject line exceeds"), lines[0])
+	assert.Equal(t, "::notice title=Commit message (3 files%2C +12 -4)%2C breaking change::feat(auth)!: "+strings.Repeat("a", 100)+"%0A%0A- auth/login.go: 100%25 coverage", lines[1])
+	uiManager.AssertNotCalled(t, "ShowSuccess", mock.Anything)
+}
+
+func TestDryRunReport_Breaking(t *testing.T) {
+	assert.True(t, newDryRunReport("feat(api)!: remove v1 endpoints", nil).Breaking)
+	assert.True(t, newDryRunReport("feat: move config\n\nBREAKING CHANGE: config.yaml is now gitsage.yaml", nil).Breaking)
+	assert.False(t, newDryRunReport("feat: add export", nil).Breaking)
+}
+
+func TestReportDryRun_OutputFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("text writes the message only", func(t *testing.T) {
+		uiManager := &MockUIManager{}
+		service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+		uiManager.On("ShowInfo", mock.Anything).Return()
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		path := filepath.Join(dir, "msg.txt")
+
+		err := service.reportDryRun(&CommitOptions{DryRun: true, OutputFile: path}, "fix: x", testDryRunStats())
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "fix: x", string(data))
+	})
+
+	t.Run("json writes the report", func(t *testing.T) {
+		uiManager := &MockUIManager{}
+		service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		path := filepath.Join(dir, "report.json")
+
+		err := service.reportDryRun(&CommitOptions{DryRun: true, OutputFile: path, OutputFormat: OutputFormatJSON}, "fix: x", testDryRunStats())
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "{"))
+		assert.Contains(t, string(data), `"old_path": "auth/token.go"`)
+		uiManager.AssertNotCalled(t, "ShowInfo", mock.Anything)
+	})
+}
@@ -0,0 +1,79 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// Duplicate check modes for subjects that repeat a recent commit.
+const (
+	DuplicateCheckWarn       = "warn"
+	DuplicateCheckRegenerate = "regenerate"
+	DuplicateCheckOff        = "off"
+)
+
+// ParseDuplicateCheck parses a duplicate check mode. An empty name yields DuplicateCheckWarn.
+func ParseDuplicateCheck(name string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(name)); mode {
+	case "":
+		return DuplicateCheckWarn, nil
+	case DuplicateCheckWarn, DuplicateCheckRegenerate, DuplicateCheckOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown duplicate check %q (valid: warn, regenerate, off)", name)
+	}
+}
+
+// duplicateCheck returns the configured duplicate check mode.
+func (s *CommitService) duplicateCheck() string {
+	if s.config == nil {
+		return DuplicateCheckWarn
+	}
+	// Invalid modes are rejected when the config is loaded
+	mode, err := ParseDuplicateCheck(s.config.Generation.DuplicateCheck)
+	if err != nil {
+		return DuplicateCheckWarn
+	}
+	return mode
+}
+
+// duplicateSubject returns the recent commit subject the given subject
+// repeats, or an empty string. Case, spacing and a trailing period are ignored.
+func duplicateSubject(subject string, recentCommits []string) string {
+	normalized := normalizeSubject(subject)
+	if normalized == "" {
+		return ""
+	}
+	for _, recent := range recentCommits {
+		if normalizeSubject(recent) == normalized {
+			return recent
+		}
+	}
+	return ""
+}
+
+// normalizeSubject folds the differences that don't make two subjects distinct.
+func normalizeSubject(subject string) string {
+	subject = strings.Join(strings.Fields(strings.ToLower(subject)), " ")
+	return strings.TrimRight(subject, ".。")
+}
+
+// duplicateFeedback is the rejection sent back to the AI when the subject
+// repeats a recent commit.
+func duplicateFeedback(duplicate string) string {
+	return fmt.Sprintf("> Rejected: the title repeats the recent commit %q. Describe what is specific to this change, e.g. the package, version or file it affects.", duplicate)
+}
+
+// warnDuplicate warns when the subject repeats a recent commit.
+func (s *CommitService) warnDuplicate(subject string, recentCommits []string) {
+	if s.duplicateCheck() == DuplicateCheckOff {
+		return
+	}
+	if duplicate := duplicateSubject(subject, recentCommits); duplicate != "" {
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.duplicate", duplicate)))
+	}
+}
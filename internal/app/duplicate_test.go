@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseDuplicateCheck(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", DuplicateCheckWarn, false},
+		{"warn", DuplicateCheckWarn, false},
+		{" Regenerate ", DuplicateCheckRegenerate, false},
+		{"off", DuplicateCheckOff, false},
+		{"block", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuplicateCheck(tt.input)
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+			continue
+		}
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestDuplicateSubject(t *testing.T) {
+	recent := []string{"chore(deps): bump golang.org/x/net", "feat: add login page."}
+
+	tests := []struct {
+		name    string
+		subject string
+		want    string
+	}{
+		{"exact", "chore(deps): bump golang.org/x/net", recent[0]},
+		{"case and spacing", "Chore(deps):  bump golang.org/x/net ", recent[0]},
+		{"trailing period", "feat: add login page", recent[1]},
+		{"distinct", "chore(deps): bump golang.org/x/net to 0.30.0", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, duplicateSubject(tt.subject, recent))
+		})
+	}
+}
+
+func TestGenerateAndCommit_DuplicateSubject(t *testing.T) {
+	recent := []string{"chore(deps): bump golang.org/x/net"}
+	duplicate := &ai.GenerateResponse{Subject: recent[0], RawText: recent[0]}
+	distinct := &ai.GenerateResponse{Subject: "chore(deps): bump golang.org/x/net to 0.30.0", RawText: "chore(deps): bump golang.org/x/net to 0.30.0"}
+
+	run := func(t *testing.T, mode string, setup func(*MockAIProvider, *MockUIManager)) {
+		gitClient := &MockGitClient{}
+		aiProvider := &MockAIProvider{}
+		diffProcessor := &MockDiffProcessor{}
+		uiManager := &MockUIManager{}
+		spinner := &MockSpinner{}
+		cfg := &config.Config{Generation: config.GenerationConfig{RecentCommits: 1, DuplicateCheck: mode}}
+		service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+		chunks := []git.DiffChunk{{FilePath: "go.mod", ChangeType: git.ChangeTypeModified, Content: "diff"}}
+		gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+		gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+		gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+		gitClient.On("GetRecentCommits", mock.Anything, 1).Return(recent, nil)
+		diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}, nil)
+		uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+		uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+		uiManager.On("PromptAction").Return(ui.ActionCancel, nil)
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		spinner.On("Start").Return()
+		spinner.On("Stop").Return()
+		setup(aiProvider, uiManager)
+
+		err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+		assert.NoError(t, err)
+		aiProvider.AssertExpectations(t)
+		uiManager.AssertExpectations(t)
+	}
+
+	isDuplicateWarning := func(err error) bool {
+		return strings.Contains(err.Error(), recent[0])
+	}
+
+	t.Run("warn", func(t *testing.T) {
+		run(t, DuplicateCheckWarn, func(aiProvider *MockAIProvider, uiManager *MockUIManager) {
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(duplicate, nil).Once()
+			uiManager.On("ShowError", mock.MatchedBy(isDuplicateWarning)).Return().Once()
+		})
+	})
+
+	t.Run("regenerate", func(t *testing.T) {
+		run(t, DuplicateCheckRegenerate, func(aiProvider *MockAIProvider, uiManager *MockUIManager) {
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+				return req.PreviousAttempt == ""
+			})).Return(duplicate, nil).Once()
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+				return strings.Contains(req.PreviousAttempt, "repeats the recent commit")
+			})).Return(distinct, nil).Once()
+		})
+	})
+
+	t.Run("off", func(t *testing.T) {
+		run(t, DuplicateCheckOff, func(aiProvider *MockAIProvider, uiManager *MockUIManager) {
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(duplicate, nil).Once()
+		})
+	})
+}
@@ -0,0 +1,43 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// getExamples returns the few-shot examples for the prompt: the configured
+// ones followed by the repository's, capped to the configured size.
+// Nothing is returned when few-shot examples are off for the provider, or in
+// stats-only mode, whose prompts have no diff to compare with the examples'.
+func (s *CommitService) getExamples(ctx context.Context) []ai.Example {
+	if s.config == nil || s.statsOnly {
+		return nil
+	}
+	// Invalid modes are rejected when the config is loaded
+	mode, err := ai.ParseFewShotMode(s.config.Generation.FewShot)
+	if err != nil || !mode.Enabled(s.config.Provider.Name) {
+		return nil
+	}
+
+	configured := s.config.Generation.Examples
+	if root, err := s.gitClient.GetRepoRoot(ctx); err != nil {
+		apperrors.Debug("Failed to find repository root: %v", err)
+	} else if root != "" {
+		repoExamples, err := config.LoadExamplesFile(filepath.Join(root, config.RepoExamplesFile))
+		if err != nil {
+			apperrors.Warn("Ignoring %s: %v", config.RepoExamplesFile, err)
+		}
+		configured = append(configured[:len(configured):len(configured)], repoExamples...)
+	}
+
+	examples := make([]ai.Example, 0, len(configured))
+	for _, example := range configured {
+		examples = append(examples, ai.Example{Diff: example.Diff, Message: example.Message})
+	}
+	return ai.CapExamples(examples, s.config.Generation.FewShotMaxBytes)
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExamples(t *testing.T) {
+	root := t.TempDir()
+	repoFile := "examples:\n  - diff: \"+b\"\n    message: \"fix: b\"\n"
+	require.NoError(t, os.WriteFile(filepath.Join(root, config.RepoExamplesFile), []byte(repoFile), 0644))
+
+	newService := func(provider, mode string) *CommitService {
+		cfg := &config.Config{
+			Provider: config.ProviderConfig{Name: provider},
+			Generation: config.GenerationConfig{
+				FewShot:  mode,
+				Examples: []config.Example{{Diff: "+a", Message: "feat: a"}},
+			},
+		}
+		return NewCommitService(&MockGitClient{RepoRoot: root}, nil, nil, nil, nil, cfg)
+	}
+
+	t.Run("config then repository", func(t *testing.T) {
+		got := newService("ollama", "auto").getExamples(context.Background())
+		assert.Equal(t, []ai.Example{{Diff: "+a", Message: "feat: a"}, {Diff: "+b", Message: "fix: b"}}, got)
+	})
+
+	t.Run("auto skips hosted providers", func(t *testing.T) {
+		assert.Empty(t, newService("openai", "auto").getExamples(context.Background()))
+	})
+
+	t.Run("always", func(t *testing.T) {
+		assert.Len(t, newService("openai", "always").getExamples(context.Background()), 2)
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		service := newService("ollama", "auto")
+		service.config.Generation.FewShotMaxBytes = 10
+		assert.Equal(t, []ai.Example{{Diff: "+a", Message: "feat: a"}}, service.getExamples(context.Background()))
+	})
+}
@@ -0,0 +1,65 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// now returns the current time; tests replace it for a fixed {date}.
+var now = time.Now
+
+// applyFooterTemplate adds the configured generation.footer_template to the
+// message footer, with its variables resolved for this commit. The message
+// is returned unchanged when no template is configured.
+func (s *CommitService) applyFooterTemplate(ctx context.Context, response *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	if s.config == nil || strings.TrimSpace(s.config.Generation.FooterTemplate) == "" {
+		return response, nil
+	}
+	template := s.config.Generation.FooterTemplate
+
+	vars := message.FooterVars{Date: now()}
+	// An unborn branch has no name to resolve, so it is only looked up when used
+	if strings.Contains(template, "{branch}") {
+		branch, err := s.gitClient.GetCurrentBranch(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current branch: %w", err)
+		}
+		vars.Branch = branch
+	}
+	if strings.Contains(template, "{version-from-file:") {
+		root, err := s.gitClient.GetRepoRoot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find repository root: %w", err)
+		}
+		vars.ReadFile = func(path string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(root, path))
+		}
+	}
+
+	footer, err := message.ExpandFooterTemplate(template, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return withFooter(message.NewCommitMessage(s.formatCommitMessage(response)), footer), nil
+}
+
+// withFooter returns the message with the lines of footer added to its
+// footer, as a response.
+func withFooter(msg *message.CommitMessage, footer string) *ai.GenerateResponse {
+	msg.AppendFooter(footer)
+	return &ai.GenerateResponse{
+		Subject: msg.FormatSubject(),
+		Body:    msg.Body,
+		Footer:  msg.Footer,
+		RawText: msg.Format(),
+	}
+}
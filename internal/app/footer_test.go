@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestApplyFooterTemplate(t *testing.T) {
+	defer func(orig func() time.Time) { now = orig }(now)
+	now = func() time.Time { return time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC) }
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "VERSION"), []byte("1.4.2\n"), 0644))
+	response := &ai.GenerateResponse{Subject: "feat(auth): add login", Body: "- auth: add form", Footer: "Refs: #12"}
+
+	newService := func(template string) (*CommitService, *MockGitClient) {
+		gitClient := &MockGitClient{RepoRoot: root}
+		cfg := &config.Config{}
+		cfg.Generation.FooterTemplate = template
+		return NewCommitService(gitClient, &MockAIProvider{}, nil, &MockUIManager{}, nil, cfg), gitClient
+	}
+
+	t.Run("no template", func(t *testing.T) {
+		service, _ := newService("")
+		got, err := service.applyFooterTemplate(context.Background(), response)
+		require.NoError(t, err)
+		assert.Same(t, response, got)
+	})
+
+	t.Run("resolves the variables", func(t *testing.T) {
+		service, gitClient := newService("Build: {branch}@{date}\nVersion: {version-from-file:VERSION}")
+		gitClient.On("GetCurrentBranch", mock.Anything).Return("main", nil)
+
+		got, err := service.applyFooterTemplate(context.Background(), response)
+		require.NoError(t, err)
+		assert.Equal(t, "feat(auth): add login", got.Subject)
+		assert.Equal(t, "- auth: add form", got.Body)
+		assert.Equal(t, "Refs: #12\nBuild: main@2025-06-01\nVersion: 1.4.2", got.Footer)
+		assert.Equal(t, "feat(auth): add login\n\n- auth: add form\n\nRefs: #12\nBuild: main@2025-06-01\nVersion: 1.4.2", service.formatCommitMessage(got))
+	})
+
+	t.Run("branch is only looked up when used", func(t *testing.T) {
+		service, gitClient := newService("Date: {date}")
+
+		got, err := service.applyFooterTemplate(context.Background(), response)
+		require.NoError(t, err)
+		assert.Equal(t, "Refs: #12\nDate: 2025-06-01", got.Footer)
+		gitClient.AssertNotCalled(t, "GetCurrentBranch", mock.Anything)
+	})
+
+	t.Run("unresolved branch", func(t *testing.T) {
+		service, gitClient := newService("Build: {branch}")
+		gitClient.On("GetCurrentBranch", mock.Anything).Return("", errors.New("unborn branch"))
+
+		_, err := service.applyFooterTemplate(context.Background(), response)
+		assert.ErrorContains(t, err, "failed to get current branch")
+	})
+
+	t.Run("missing version file", func(t *testing.T) {
+		service, _ := newService("Version: {version-from-file:package.json}")
+
+		_, err := service.applyFooterTemplate(context.Background(), response)
+		assert.ErrorContains(t, err, "failed to read version from package.json")
+	})
+}
@@ -0,0 +1,57 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// MessageRequest describes the changes GenerateMessage writes a message for.
+type MessageRequest struct {
+	Chunks []git.DiffChunk
+	// RecentCommits are subjects of the latest commits, most recent first.
+	RecentCommits []string
+	// CommitTemplate is a commit.template the message must follow.
+	CommitTemplate string
+	// Context is the developer's explanation of why the change was made.
+	Context string
+	// Intent is the commit type and scope the message must use, if any.
+	Intent  ai.Intent
+	NoCache bool
+}
+
+// GenerateMessage generates a commit message for the given changes without
+// reading the repository or asking the user. Lock files are filtered and
+// large diffs summarized as in GenerateAndCommit.
+func (s *CommitService) GenerateMessage(ctx context.Context, req *MessageRequest) (*ai.GenerateResponse, error) {
+	if err := req.Intent.Validate(); err != nil {
+		return nil, err
+	}
+	if len(req.Chunks) == 0 {
+		return nil, fmt.Errorf("no changes to describe")
+	}
+
+	processedDiff, err := s.diffProcessor.Process(ctx, req.Chunks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process diff: %w", err)
+	}
+	if len(processedDiff.Chunks) == 0 {
+		return nil, fmt.Errorf("no changes to describe after filtering lock files")
+	}
+
+	return s.generateCommitMessage(ctx, processedDiff, newDiffStats(req.Chunks), req.RecentCommits,
+		req.CommitTemplate, "", "", req.Context, req.Intent, req.NoCache)
+}
+
+// newDiffStats returns the statistics of the given changes.
+func newDiffStats(chunks []git.DiffChunk) *git.DiffStats {
+	stats := &git.DiffStats{TotalFiles: len(chunks), Chunks: chunks}
+	for _, chunk := range chunks {
+		stats.TotalAdditions += chunk.Additions
+		stats.TotalDeletions += chunk.Deletions
+	}
+	return stats
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGenerateMessage(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	service := NewCommitService(nil, aiProvider, diffProcessor, ui.NewSilentManager(), nil, nil)
+
+	chunks := []git.DiffChunk{{FilePath: "auth.go", ChangeType: git.ChangeTypeModified, Content: "diff", Additions: 3, Deletions: 1}}
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}, nil)
+	expected := &ai.GenerateResponse{Subject: "fix(auth): refresh expired tokens"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Context == "tokens expired mid-session" &&
+			req.Intent.Scope == "auth" &&
+			req.DiffStats.TotalAdditions == 3 && req.DiffStats.TotalDeletions == 1 &&
+			len(req.RecentCommits) == 1
+	})).Return(expected, nil).Once()
+
+	response, err := service.GenerateMessage(context.Background(), &MessageRequest{
+		Chunks:        chunks,
+		RecentCommits: []string{"feat(auth): add login"},
+		Context:       "tokens expired mid-session",
+		Intent:        ai.Intent{Scope: "auth"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, response)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateMessage_NoChanges(t *testing.T) {
+	diffProcessor := &MockDiffProcessor{}
+	service := NewCommitService(nil, &MockAIProvider{}, diffProcessor, ui.NewSilentManager(), nil, nil)
+
+	_, err := service.GenerateMessage(context.Background(), &MessageRequest{})
+	assert.Error(t, err)
+
+	// Lock files only
+	chunks := []git.DiffChunk{{FilePath: "go.sum", IsLockFile: true}}
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{}, nil)
+	_, err = service.GenerateMessage(context.Background(), &MessageRequest{Chunks: chunks})
+	assert.ErrorContains(t, err, "lock files")
+}
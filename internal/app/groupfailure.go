@@ -0,0 +1,92 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Group failure policies for file groups that fail to summarize in two-phase generation.
+const (
+	GroupFailureList  = "list"
+	GroupFailureSplit = "split"
+	GroupFailureSkip  = "skip"
+	GroupFailureAbort = "abort"
+)
+
+// ParseGroupFailure parses a group failure policy. An empty name yields GroupFailureList.
+func ParseGroupFailure(name string) (string, error) {
+	switch policy := strings.ToLower(strings.TrimSpace(name)); policy {
+	case "":
+		return GroupFailureList, nil
+	case GroupFailureList, GroupFailureSplit, GroupFailureSkip, GroupFailureAbort:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown group failure policy %q (valid: list, split, skip, abort)", name)
+	}
+}
+
+// groupFailure returns the configured group failure policy.
+func (s *CommitService) groupFailure() string {
+	if s.config == nil {
+		return GroupFailureList
+	}
+	// Invalid policies are rejected when the config is loaded
+	policy, err := ParseGroupFailure(s.config.Generation.GroupFailure)
+	if err != nil {
+		return GroupFailureList
+	}
+	return policy
+}
+
+// summarizeGroup summarizes a file group and applies the group failure
+// policy if that fails. degraded reports whether the summary stands in for
+// what the AI would have written; only "abort" returns an error.
+func (s *CommitService) summarizeGroup(ctx context.Context, group fileGroup, policy string) (summary string, cached, degraded bool, err error) {
+	summary, cached, err = s.summarizeFileGroupCached(ctx, group)
+	if err == nil {
+		return summary, cached, false, nil
+	}
+	if ctx.Err() != nil {
+		return "", false, false, ctx.Err()
+	}
+	if isRateLimited(err) {
+		s.rateLimits.Add(1)
+	}
+	apperrors.Warn("Failed to summarize %s: %v", strings.Join(group.files, ", "), err)
+
+	switch policy {
+	case GroupFailureAbort:
+		return "", false, false, fmt.Errorf("failed to summarize %s: %w", strings.Join(group.files, ", "), err)
+	case GroupFailureSkip:
+		return fmt.Sprintf("- %d files changed (not summarized)", len(group.files)), false, true, nil
+	case GroupFailureSplit:
+		// Retry each half on its own, down to single files
+		if len(group.chunks) > 1 {
+			mid := len(group.chunks) / 2
+			var parts []string
+			for _, half := range []fileGroup{
+				{chunks: group.chunks[:mid], files: group.files[:mid]},
+				{chunks: group.chunks[mid:], files: group.files[mid:]},
+			} {
+				part, _, partDegraded, err := s.summarizeGroup(ctx, half, policy)
+				if err != nil {
+					return "", false, false, err
+				}
+				parts = append(parts, part)
+				degraded = degraded || partDegraded
+			}
+			return strings.Join(parts, "\n"), false, degraded, nil
+		}
+	}
+
+	// List the files without an AI summary
+	var files []string
+	for _, c := range group.chunks {
+		files = append(files, fmt.Sprintf("- %s (+%d -%d)", c.FilePath, c.Additions, c.Deletions))
+	}
+	return strings.Join(files, "\n"), false, true, nil
+}
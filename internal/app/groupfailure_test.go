@@ -0,0 +1,105 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParseGroupFailure(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", GroupFailureList, false},
+		{"list", GroupFailureList, false},
+		{" Split ", GroupFailureSplit, false},
+		{"skip", GroupFailureSkip, false},
+		{"abort", GroupFailureAbort, false},
+		{"retry", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGroupFailure(tt.input)
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+			continue
+		}
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestSummarizeGroup_Policies(t *testing.T) {
+	group := fileGroup{
+		chunks: []git.DiffChunk{
+			{FilePath: "a.go", Content: "+a", Additions: 1},
+			{FilePath: "b.go", Content: "+b", Additions: 2, Deletions: 1},
+		},
+		files: []string{"a.go", "b.go"},
+	}
+
+	// The group as a whole fails; each file on its own succeeds
+	newService := func() *CommitService {
+		aiProvider := &MockAIProvider{}
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return strings.Contains(req.CustomPrompt, "a.go") && strings.Contains(req.CustomPrompt, "b.go")
+		})).Return(nil, errors.New("context length exceeded"))
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return strings.Contains(req.CustomPrompt, "a.go")
+		})).Return(&ai.GenerateResponse{RawText: "- a.go: 改动 a"}, nil)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return strings.Contains(req.CustomPrompt, "b.go")
+		})).Return(&ai.GenerateResponse{RawText: "- b.go: 改动 b"}, nil)
+		return NewCommitService(nil, aiProvider, nil, nil, nil, &config.Config{})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		summary, _, degraded, err := newService().summarizeGroup(context.Background(), group, GroupFailureList)
+		assert.NoError(t, err)
+		assert.True(t, degraded)
+		assert.Equal(t, "- a.go (+1 -0)\n- b.go (+2 -1)", summary)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		summary, _, degraded, err := newService().summarizeGroup(context.Background(), group, GroupFailureSplit)
+		assert.NoError(t, err)
+		assert.False(t, degraded, "both halves were summarized")
+		assert.Equal(t, "- a.go: 改动 a\n- b.go: 改动 b", summary)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		summary, _, degraded, err := newService().summarizeGroup(context.Background(), group, GroupFailureSkip)
+		assert.NoError(t, err)
+		assert.True(t, degraded)
+		assert.Equal(t, "- 2 files changed (not summarized)", summary)
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		_, _, _, err := newService().summarizeGroup(context.Background(), group, GroupFailureAbort)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "a.go, b.go")
+	})
+}
+
+func TestSummarizeGroup_SplitSingleFile(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited"))
+	service := NewCommitService(nil, aiProvider, nil, nil, nil, &config.Config{})
+
+	group := fileGroup{chunks: []git.DiffChunk{{FilePath: "a.go", Content: "+a", Additions: 1}}, files: []string{"a.go"}}
+	summary, _, degraded, err := service.summarizeGroup(context.Background(), group, GroupFailureSplit)
+	assert.NoError(t, err)
+	assert.True(t, degraded)
+	assert.Equal(t, "- a.go (+1 -0)", summary)
+}
@@ -0,0 +1,29 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// checkProviderHealth pings the provider before the first generation and
+// caches the result for the session, so regenerating does not ping again and
+// an unreachable provider fails every attempt immediately.
+func (s *CommitService) checkProviderHealth(ctx context.Context) error {
+	if s.config == nil || !s.config.Provider.HealthCheck {
+		return nil
+	}
+	checker, ok := s.aiProvider.(ai.HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	s.healthOnce.Do(func() {
+		s.healthErr = checker.HealthCheck(ctx)
+		if s.healthErr != nil && s.pullMissingModel(ctx, s.healthErr) {
+			s.healthErr = checker.HealthCheck(ctx)
+		}
+	})
+	return s.healthErr
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// healthCheckingProvider is a MockAIProvider that also implements ai.HealthChecker.
+type healthCheckingProvider struct {
+	MockAIProvider
+}
+
+func (m *healthCheckingProvider) HealthCheck(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestCheckProviderHealth(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		provider := &healthCheckingProvider{}
+		service := NewCommitService(nil, provider, nil, nil, nil, &config.Config{})
+
+		assert.NoError(t, service.checkProviderHealth(context.Background()))
+		provider.AssertNotCalled(t, "HealthCheck", mock.Anything)
+	})
+
+	t.Run("provider without check", func(t *testing.T) {
+		cfg := &config.Config{Provider: config.ProviderConfig{HealthCheck: true}}
+		service := NewCommitService(nil, &MockAIProvider{}, nil, nil, nil, cfg)
+
+		assert.NoError(t, service.checkProviderHealth(context.Background()))
+	})
+
+	t.Run("result cached for the session", func(t *testing.T) {
+		provider := &healthCheckingProvider{}
+		cfg := &config.Config{Provider: config.ProviderConfig{HealthCheck: true}}
+		service := NewCommitService(nil, provider, nil, nil, nil, cfg)
+		notRunning := apperrors.NewNetworkError(assert.AnError)
+		provider.On("HealthCheck", mock.Anything).Return(notRunning).Once()
+
+		assert.ErrorIs(t, service.checkProviderHealth(context.Background()), notRunning)
+		assert.ErrorIs(t, service.checkProviderHealth(context.Background()), notRunning)
+		provider.AssertNumberOfCalls(t, "HealthCheck", 1)
+	})
+}
+
+func TestGenerateAndCommit_UnhealthyProviderFailsFast(t *testing.T) {
+	gitClient := &MockGitClient{}
+	provider := &healthCheckingProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{Provider: config.ProviderConfig{HealthCheck: true}}
+
+	service := NewCommitService(gitClient, provider, diffProcessor, uiManager, nil, cfg)
+
+	chunks := []git.DiffChunk{{FilePath: "main.go", ChangeType: git.ChangeTypeModified, Content: "diff"}}
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+	provider.On("HealthCheck", mock.Anything).Return(apperrors.NewNetworkError(assert.AnError))
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.Equal(t, 3, apperrors.GetExitCode(err))
+	provider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+}
+
+var _ ai.HealthChecker = (*healthCheckingProvider)(nil)
@@ -0,0 +1,162 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// HookTimeout is how long a hook command may run before it is stopped.
+const HookTimeout = 30 * time.Second
+
+// runPostCommitHooks runs the hooks.post_commit commands after a successful
+// commit. Hooks are notifications: a failing hook is reported, and never
+// fails the commit that already happened.
+func (s *CommitService) runPostCommitHooks(ctx context.Context, commitMsg string) {
+	if s.config == nil || len(s.config.Hooks.PostCommit) == 0 {
+		return
+	}
+
+	sha, err := s.gitClient.GetHeadCommit(ctx)
+	if err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.warning.hook"), err))
+		return
+	}
+	branch, err := s.gitClient.GetCurrentBranch(ctx)
+	if err != nil {
+		apperrors.Debug("Branch for hooks unknown: %v", err)
+	}
+	dir, err := s.gitClient.GetRepoRoot(ctx)
+	if err != nil {
+		apperrors.Debug("Running hooks in the current directory: %v", err)
+	}
+
+	subject, _, _ := strings.Cut(commitMsg, "\n")
+	values := map[string]string{
+		"sha":       sha,
+		"short_sha": sha[:min(len(sha), 7)],
+		"subject":   subject,
+		"message":   commitMsg,
+		"branch":    branch,
+	}
+	env := make([]string, 0, len(values))
+	for name, value := range values {
+		env = append(env, hookEnv(name)+"="+value)
+	}
+	for _, command := range s.config.Hooks.PostCommit {
+		apperrors.Debug("Running post-commit hook: %s", command)
+		if err := runHook(ctx, dir, expandHook(command, values), env); err != nil {
+			s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.warning.hook"), err))
+		}
+	}
+}
+
+// hookEnv returns the environment variable holding the value of the {name}
+// placeholder, e.g. GITSAGE_SUBJECT for {subject}.
+func hookEnv(name string) string {
+	return "GITSAGE_" + strings.ToUpper(name)
+}
+
+// expandHook replaces the {name} placeholders of command with references to
+// the environment variables holding the values. The shell expands variables
+// after parsing the command, so a message cannot inject commands.
+func expandHook(command string, values map[string]string) string {
+	if runtime.GOOS == "windows" {
+		return expandCmdHook(command, values)
+	}
+	return expandShellHook(command, values)
+}
+
+// expandShellHook expands the placeholders for sh. Each is replaced by a
+// double-quoted variable reference, closing and reopening the quotes it sits
+// in, so the value stays one argument wherever it is placed.
+func expandShellHook(command string, values map[string]string) string {
+	var sb strings.Builder
+	var quote byte // ' or " while inside quotes
+	for i := 0; i < len(command); i++ {
+		c := command[i]
+		switch {
+		case c == '\\' && quote != '\'' && i+1 < len(command):
+			sb.WriteByte(c)
+			i++
+			c = command[i]
+		case (c == '\'' || c == '"') && (quote == 0 || quote == c):
+			if quote == 0 {
+				quote = c
+			} else {
+				quote = 0
+			}
+		case c == '{':
+			if name, ok := hookPlaceholder(command[i:], values); ok {
+				ref := "${" + hookEnv(name) + "}"
+				switch quote {
+				case 0:
+					ref = `"` + ref + `"`
+				case '\'':
+					ref = `'"` + ref + `"'`
+				}
+				sb.WriteString(ref)
+				i += len(name) + 1
+				continue
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// expandCmdHook expands the placeholders for cmd.exe, which runs hooks with
+// delayed expansion: !VAR! is expanded after the command is parsed, unlike
+// %VAR%, so the value is not read as part of the command.
+func expandCmdHook(command string, values map[string]string) string {
+	pairs := make([]string, 0, 2*len(values))
+	for name := range values {
+		pairs = append(pairs, "{"+name+"}", "!"+hookEnv(name)+"!")
+	}
+	return strings.NewReplacer(pairs...).Replace(command)
+}
+
+// hookPlaceholder returns the name of the known placeholder s starts with.
+func hookPlaceholder(s string, values map[string]string) (string, bool) {
+	end := strings.IndexByte(s, '}')
+	if !strings.HasPrefix(s, "{") || end < 0 {
+		return "", false
+	}
+	name := s[1:end]
+	_, ok := values[name]
+	return name, ok
+}
+
+// runHook runs command with the shell in dir, adding env to its environment.
+// Its output is passed through so that the user sees what the hook reports.
+func runHook(ctx context.Context, dir, command string, env []string) error {
+	ctx, cancel := context.WithTimeout(ctx, HookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/V:ON", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%q timed out after %v", command, HookTimeout)
+		}
+		return fmt.Errorf("%q failed: %w", command, err)
+	}
+	return nil
+}
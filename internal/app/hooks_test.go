@@ -0,0 +1,110 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandShellHook(t *testing.T) {
+	values := map[string]string{"sha": "abc123", "subject": "fix: x", "branch": "main"}
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{`notify {sha} {subject} {unknown}`, `notify "${GITSAGE_SHA}" "${GITSAGE_SUBJECT}" {unknown}`},
+		{`notify-send "Committed on {branch}" {subject}`, `notify-send "Committed on ${GITSAGE_BRANCH}" "${GITSAGE_SUBJECT}"`},
+		{`echo '{subject}' "a \" {sha}"`, `echo ''"${GITSAGE_SUBJECT}"'' "a \" ${GITSAGE_SHA}"`},
+		{`echo \{sha} {`, `echo \{sha} {`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, expandShellHook(tt.command, values), tt.command)
+	}
+}
+
+func TestExpandCmdHook(t *testing.T) {
+	values := map[string]string{"subject": "fix: x & del *", "branch": "main"}
+
+	assert.Equal(t, `notify "Committed on !GITSAGE_BRANCH!" "!GITSAGE_SUBJECT!"`,
+		expandCmdHook(`notify "Committed on {branch}" "{subject}"`, values))
+}
+
+func TestRunPostCommitHooks_NoInjection(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh")
+	}
+	root := t.TempDir()
+	gitClient := &MockGitClient{RepoRoot: root}
+	gitClient.On("GetHeadCommit", mock.Anything).Return("0123456789abcdef", nil)
+	gitClient.On("GetCurrentBranch", mock.Anything).Return("$(touch branch-ran)", nil)
+
+	cfg := &config.Config{Hooks: config.HooksConfig{PostCommit: []string{
+		`printf '%s\n' "Committed on {branch}: {subject}" '{subject}' > notified.txt`,
+	}}}
+	service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, cfg)
+
+	subject := "fix: \"$(touch subject-ran)\" `touch backtick-ran` '$HOME'"
+	service.runPostCommitHooks(context.Background(), subject)
+
+	data, err := os.ReadFile(filepath.Join(root, "notified.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Committed on $(touch branch-ran): "+subject+"\n"+subject+"\n", string(data))
+	for _, name := range []string{"branch-ran", "subject-ran", "backtick-ran"} {
+		assert.NoFileExists(t, filepath.Join(root, name))
+	}
+}
+
+func TestRunPostCommitHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh")
+	}
+	root := t.TempDir()
+	gitClient := &MockGitClient{RepoRoot: root}
+	uiManager := &MockUIManager{}
+	gitClient.On("GetHeadCommit", mock.Anything).Return("0123456789abcdef", nil)
+	gitClient.On("GetCurrentBranch", mock.Anything).Return("main", nil)
+	uiManager.On("ShowError", mock.Anything).Return().Once()
+
+	cfg := &config.Config{Hooks: config.HooksConfig{PostCommit: []string{
+		// Relative paths resolve against the repository root
+		`printf '%s\n' {short_sha} {branch} {subject} > notified.txt`,
+		"exit 3",
+		`printf '%s' {message} > message.txt`,
+		`printf '%s' "$GITSAGE_SHORT_SHA" > env.txt`,
+	}}}
+	service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, uiManager, nil, cfg)
+
+	service.runPostCommitHooks(context.Background(), "feat(api): add `users` endpoint\n\nBody line.")
+
+	data, err := os.ReadFile(filepath.Join(root, "notified.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456\nmain\nfeat(api): add `users` endpoint\n", string(data))
+
+	// A failing hook is reported and the next ones still run
+	uiManager.AssertNumberOfCalls(t, "ShowError", 1)
+	data, err = os.ReadFile(filepath.Join(root, "message.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "feat(api): add `users` endpoint\n\nBody line.", string(data))
+
+	// The values are also in the environment of the hooks
+	data, err = os.ReadFile(filepath.Join(root, "env.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456", string(data))
+}
+
+func TestRunPostCommitHooks_None(t *testing.T) {
+	gitClient := &MockGitClient{}
+	service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	// Without hooks the commit is not even looked up
+	service.runPostCommitHooks(context.Background(), "fix: x")
+	gitClient.AssertNotCalled(t, "GetHeadCommit", mock.Anything)
+}
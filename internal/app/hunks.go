@@ -0,0 +1,153 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// MaxFilePieces is the maximum number of pieces a large file diff is split into,
+// which bounds the AI calls spent on a single file.
+const MaxFilePieces = 8
+
+// summarizeLargeFile summarizes a file diff too large for a single request.
+// The diff is split into pieces along hunk boundaries (map), each piece is
+// summarized, and the partial summaries are merged into one line (reduce).
+func (s *CommitService) summarizeLargeFile(ctx context.Context, chunk git.DiffChunk) (string, error) {
+	pieces := splitDiffIntoPieces(chunk.Content, MaxGroupSize, MaxFilePieces)
+
+	partials := make([]string, 0, len(pieces))
+	for i, piece := range pieces {
+		prompt := fmt.Sprintf(`以下是文件 %s（%s，+%d -%d）改动的第 %d/%d 部分，用 1-2 句话概括这部分改了什么（中文）:
+
+%s
+
+只输出概括，不要解释`, chunk.FilePath, chunk.ChangeType, chunk.Additions, chunk.Deletions, i+1, len(pieces), piece)
+
+		summary, err := s.summarize(ctx, prompt)
+		if err != nil {
+			return "", err
+		}
+		partials = append(partials, fmt.Sprintf("%d. %s", i+1, summary))
+	}
+
+	prompt := fmt.Sprintf(`以下是文件 %s 各部分改动的摘要，将它们合并为对整个文件改动的一句话描述（不超过40字，中文）:
+
+%s
+
+格式:
+- %s: 改动描述`, chunk.FilePath, strings.Join(partials, "\n"), chunk.FilePath)
+
+	return s.summarize(ctx, prompt)
+}
+
+// summarize sends a summarization prompt and returns the trimmed reply.
+func (s *CommitService) summarize(ctx context.Context, prompt string) (string, error) {
+	resp, err := s.aiProvider.GenerateCommitMessage(ctx, &ai.GenerateRequest{
+		CustomPrompt: prompt,
+		Redact:       s.pathRedactor(),
+	})
+	if err != nil {
+		return "", err
+	}
+	s.usage.record(resp)
+
+	summary := strings.TrimSpace(resp.RawText)
+	if summary == "" {
+		summary = resp.Subject
+	}
+	return summary, nil
+}
+
+// splitDiffIntoPieces splits a file diff into at most maxPieces pieces of about
+// maxSize bytes. Pieces break at hunk boundaries ("@@" lines) where possible;
+// a hunk larger than a piece is split between lines. The file header before
+// the first hunk is dropped since the caller names the file.
+func splitDiffIntoPieces(content string, maxSize, maxPieces int) []string {
+	hunks := splitHunks(content)
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	// Grow the piece size rather than exceed the piece limit; packing along
+	// hunk boundaries leaves gaps, so repack until the pieces fit
+	size := maxSize
+	if limit := (len(content) + maxPieces - 1) / maxPieces; limit > size {
+		size = limit
+	}
+
+	pieces := packHunks(hunks, size)
+	for len(pieces) > maxPieces {
+		size += size / 4
+		pieces = packHunks(hunks, size)
+	}
+
+	return pieces
+}
+
+// packHunks packs hunks into pieces of at most size bytes, splitting a hunk
+// larger than a piece between lines.
+func packHunks(hunks []string, size int) []string {
+	var pieces []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			pieces = append(pieces, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, hunk := range hunks {
+		if current.Len()+len(hunk) > size {
+			flush()
+		}
+		if len(hunk) <= size {
+			current.WriteString(hunk)
+			continue
+		}
+
+		// Split an oversized hunk between lines
+		for _, line := range strings.SplitAfter(hunk, "\n") {
+			if current.Len()+len(line) > size {
+				flush()
+			}
+			current.WriteString(line)
+		}
+	}
+	flush()
+
+	return pieces
+}
+
+// splitHunks splits a file diff into hunks, each starting with its "@@" line.
+// A diff without hunk headers is returned as a single hunk.
+func splitHunks(content string) []string {
+	var hunks []string
+	var current strings.Builder
+	inHunk := false
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			if inHunk && current.Len() > 0 {
+				hunks = append(hunks, current.String())
+			}
+			current.Reset()
+			inHunk = true
+		}
+		if inHunk {
+			current.WriteString(line)
+		}
+	}
+	if current.Len() > 0 {
+		hunks = append(hunks, current.String())
+	}
+
+	if len(hunks) == 0 && strings.TrimSpace(content) != "" {
+		return []string{content}
+	}
+	return hunks
+}
@@ -0,0 +1,118 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// buildHunkDiff builds a file diff with n hunks of the given number of added lines.
+func buildHunkDiff(n, lines int) string {
+	var sb strings.Builder
+	sb.WriteString("diff --git a/gen.go b/gen.go\n--- a/gen.go\n+++ b/gen.go\n")
+	for i := 0; i < n; i++ {
+		sb.WriteString(fmt.Sprintf("@@ -%d,0 +%d,%d @@\n", i*lines+1, i*lines+1, lines))
+		for j := 0; j < lines; j++ {
+			sb.WriteString(fmt.Sprintf("+line %d of hunk %d\n", j, i))
+		}
+	}
+	return sb.String()
+}
+
+func TestSplitHunks(t *testing.T) {
+	hunks := splitHunks(buildHunkDiff(3, 2))
+
+	assert.Len(t, hunks, 3)
+	for _, hunk := range hunks {
+		assert.True(t, strings.HasPrefix(hunk, "@@"))
+	}
+	assert.NotContains(t, hunks[0], "diff --git")
+
+	// A diff without hunk headers is kept whole
+	assert.Equal(t, []string{"Binary files differ\n"}, splitHunks("Binary files differ\n"))
+	assert.Empty(t, splitHunks(""))
+}
+
+func TestSplitDiffIntoPieces(t *testing.T) {
+	t.Run("packs hunks up to the piece size", func(t *testing.T) {
+		content := buildHunkDiff(20, 20)
+		pieces := splitDiffIntoPieces(content, MaxGroupSize, MaxFilePieces)
+
+		assert.Greater(t, len(pieces), 1)
+		assert.LessOrEqual(t, len(pieces), MaxFilePieces)
+		for _, piece := range pieces {
+			assert.LessOrEqual(t, len(piece), MaxGroupSize)
+			assert.True(t, strings.HasPrefix(piece, "@@"))
+		}
+	})
+
+	t.Run("grows pieces to respect the limit", func(t *testing.T) {
+		content := buildHunkDiff(200, 20)
+		pieces := splitDiffIntoPieces(content, MaxGroupSize, MaxFilePieces)
+
+		assert.LessOrEqual(t, len(pieces), MaxFilePieces)
+		assert.Contains(t, strings.Join(pieces, ""), "+line 19 of hunk 199\n")
+	})
+
+	t.Run("splits an oversized hunk between lines", func(t *testing.T) {
+		content := buildHunkDiff(1, 500)
+		pieces := splitDiffIntoPieces(content, MaxGroupSize, MaxFilePieces)
+
+		assert.Greater(t, len(pieces), 1)
+		for _, piece := range pieces {
+			assert.True(t, strings.HasSuffix(piece, "\n"))
+		}
+		assert.Equal(t, splitHunks(content)[0], strings.Join(pieces, ""))
+	})
+}
+
+func TestSummarizeFileGroup_LargeFile(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	service := NewCommitService(&MockGitClient{}, aiProvider, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	chunk := git.DiffChunk{FilePath: "gen.go", ChangeType: git.ChangeTypeModified, Content: buildHunkDiff(20, 20), Additions: 400}
+	pieces := splitDiffIntoPieces(chunk.Content, MaxGroupSize, MaxFilePieces)
+
+	isMerge := func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "各部分改动的摘要")
+	}
+	isPiece := func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "概括这部分改了什么")
+	}
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(isPiece)).
+		Return(&ai.GenerateResponse{RawText: "adds generated lines"}, nil).Times(len(pieces))
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return isMerge(req) && strings.Contains(req.CustomPrompt, fmt.Sprintf("%d. adds generated lines", len(pieces)))
+	})).Return(&ai.GenerateResponse{RawText: "- gen.go: regenerate code"}, nil).Once()
+
+	summary, err := service.summarizeFileGroup(context.Background(), fileGroup{chunks: []git.DiffChunk{chunk}, files: []string{chunk.FilePath}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "- gen.go: regenerate code", summary)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestSummarizeLargeFile_Error(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	service := NewCommitService(&MockGitClient{}, aiProvider, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	chunk := git.DiffChunk{FilePath: "gen.go", ChangeType: git.ChangeTypeModified, Content: buildHunkDiff(20, 20)}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).
+		Return(nil, errors.New("api error")).Once()
+
+	_, err := service.summarizeLargeFile(context.Background(), chunk)
+
+	assert.Error(t, err)
+	aiProvider.AssertExpectations(t)
+}
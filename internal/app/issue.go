@@ -0,0 +1,151 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// IssuePlatformAuto detects the hosting platform from the origin remote.
+const IssuePlatformAuto = "auto"
+
+// issueTemplateDefault is the key of issues.templates used for remotes on
+// an unrecognized platform.
+const issueTemplateDefault = "default"
+
+// defaultIssueTemplates are the footers closing an issue in each platform's
+// linking syntax, and the plain Conventional Commits reference otherwise.
+var defaultIssueTemplates = map[string]string{
+	git.PlatformGitHub:    "Closes #{issue}",
+	git.PlatformGitLab:    "Closes #{issue}",
+	git.PlatformGitea:     "Closes #{issue}",
+	git.PlatformBitbucket: "Fixes #{issue}",
+	issueTemplateDefault:  "Refs: #{issue}",
+}
+
+// ValidateIssues returns an error if the issue settings are invalid: a
+// branch pattern that does not compile, an unknown platform, or a template
+// for an unknown platform or without {issue}.
+func ValidateIssues(issues config.IssuesConfig) error {
+	if _, err := regexp.Compile(issues.BranchPattern); err != nil {
+		return fmt.Errorf("invalid branch_pattern: %w", err)
+	}
+	switch issues.Platform {
+	case "", IssuePlatformAuto, git.PlatformGitHub, git.PlatformGitLab, git.PlatformGitea, git.PlatformBitbucket:
+	default:
+		return fmt.Errorf("unknown platform %q (valid: auto, github, gitlab, gitea, bitbucket)", issues.Platform)
+	}
+	for platform, template := range issues.Templates {
+		if _, ok := defaultIssueTemplates[platform]; !ok {
+			return fmt.Errorf("template for unknown platform %q (valid: github, gitlab, gitea, bitbucket, default)", platform)
+		}
+		if !strings.Contains(template, "{issue}") {
+			return fmt.Errorf("template for %s does not contain {issue}", platform)
+		}
+	}
+	return nil
+}
+
+// applyIssueFooter adds a footer referencing the issue to the message, in the
+// linking syntax of the repository's hosting platform. The issue is the one
+// given, or else the one issues.branch_pattern finds in the branch name. The
+// message is returned unchanged when there is no issue or the message already
+// references it.
+func (s *CommitService) applyIssueFooter(ctx context.Context, issue string, response *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	if s.config == nil {
+		return response, nil
+	}
+
+	issue = strings.TrimLeft(strings.TrimSpace(issue), "#")
+	if issue == "" {
+		issue = s.branchIssue(ctx)
+	}
+	if issue == "" {
+		return response, nil
+	}
+
+	msg := message.NewCommitMessage(s.formatCommitMessage(response))
+	if referencesIssue(msg, issue) {
+		return response, nil
+	}
+
+	platform, err := s.issuePlatform(ctx)
+	if err != nil {
+		return nil, err
+	}
+	template, ok := s.config.Issues.Templates[platform]
+	if !ok {
+		template = defaultIssueTemplates[platform]
+	}
+
+	return withFooter(msg, strings.ReplaceAll(template, "{issue}", issue)), nil
+}
+
+// branchIssue returns the issue issues.branch_pattern finds in the current
+// branch name, or an empty string. The first commit of a repository is made
+// on an unborn branch whose name cannot be resolved, so it has no issue.
+func (s *CommitService) branchIssue(ctx context.Context) string {
+	if s.config.Issues.BranchPattern == "" {
+		return ""
+	}
+	// Invalid patterns are rejected when the config is loaded
+	pattern, err := regexp.Compile(s.config.Issues.BranchPattern)
+	if err != nil {
+		return ""
+	}
+
+	branch, err := s.gitClient.GetCurrentBranch(ctx)
+	if err != nil {
+		return ""
+	}
+	matches := pattern.FindStringSubmatch(branch)
+	switch {
+	case matches == nil:
+		return ""
+	case len(matches) > 1:
+		return matches[1]
+	default:
+		return matches[0]
+	}
+}
+
+// issuePlatform returns the configured hosting platform, or the one of the
+// origin remote, falling back to the default template's key.
+func (s *CommitService) issuePlatform(ctx context.Context) (string, error) {
+	if platform := s.config.Issues.Platform; platform != "" && platform != IssuePlatformAuto {
+		return platform, nil
+	}
+
+	remoteURL, err := s.gitClient.GetRemoteURL(ctx, "origin")
+	if err != nil {
+		return "", fmt.Errorf("failed to get origin remote: %w", err)
+	}
+	if platform := git.DetectPlatform(remoteURL); platform != "" {
+		return platform, nil
+	}
+	return issueTemplateDefault, nil
+}
+
+// referencesIssue reports whether a footer of the message already names the
+// issue, e.g. "Refs: #12" for issue 12 or "Refs: PROJ-12" for issue PROJ-12.
+// Numbers only count with the "#" or "!" they are referenced with.
+func referencesIssue(msg *message.CommitMessage, issue string) bool {
+	prefix := `(^|[^\w-])`
+	if strings.Trim(issue, "0123456789") == "" {
+		prefix = `[#!]`
+	}
+	pattern := regexp.MustCompile(prefix + regexp.QuoteMeta(issue) + `($|[^\w-])`)
+	for _, footer := range msg.Footers() {
+		if pattern.MatchString(footer.Value) {
+			return true
+		}
+	}
+	return false
+}
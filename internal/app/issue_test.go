@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestApplyIssueFooter(t *testing.T) {
+	response := &ai.GenerateResponse{Subject: "fix(auth): reject expired tokens", Body: "- auth: check expiry"}
+
+	newService := func(issues config.IssuesConfig) (*CommitService, *MockGitClient) {
+		gitClient := &MockGitClient{}
+		cfg := &config.Config{Issues: issues}
+		return NewCommitService(gitClient, &MockAIProvider{}, nil, &MockUIManager{}, nil, cfg), gitClient
+	}
+
+	t.Run("no issue", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{})
+
+		got, err := service.applyIssueFooter(context.Background(), "", response)
+		require.NoError(t, err)
+		assert.Same(t, response, got)
+		gitClient.AssertNotCalled(t, "GetCurrentBranch", mock.Anything)
+	})
+
+	t.Run("platform syntax from origin", func(t *testing.T) {
+		tests := []struct {
+			remoteURL string
+			want      string
+		}{
+			{"git@github.com:acme/app.git", "Closes #12"},
+			{"https://gitlab.com/acme/app.git", "Closes #12"},
+			{"https://bitbucket.org/acme/app.git", "Fixes #12"},
+			{"https://git.example.com/acme/app.git", "Refs: #12"},
+			{"", "Refs: #12"},
+		}
+		for _, tt := range tests {
+			service, gitClient := newService(config.IssuesConfig{Platform: IssuePlatformAuto})
+			gitClient.On("GetRemoteURL", mock.Anything, "origin").Return(tt.remoteURL, nil)
+
+			got, err := service.applyIssueFooter(context.Background(), "#12", response)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Footer, tt.remoteURL)
+			assert.Equal(t, "fix(auth): reject expired tokens\n\n- auth: check expiry\n\n"+tt.want, got.RawText)
+		}
+	})
+
+	t.Run("configured platform and template", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{
+			Platform:  "gitlab",
+			Templates: map[string]string{"gitlab": "Closes !{issue}"},
+		})
+
+		got, err := service.applyIssueFooter(context.Background(), "34", response)
+		require.NoError(t, err)
+		assert.Equal(t, "Closes !34", got.Footer)
+		gitClient.AssertNotCalled(t, "GetRemoteURL", mock.Anything, mock.Anything)
+	})
+
+	t.Run("issue from branch", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{BranchPattern: `^\w+/(\d+)-`, Platform: "github"})
+		gitClient.On("GetCurrentBranch", mock.Anything).Return("fix/128-token-expiry", nil)
+
+		got, err := service.applyIssueFooter(context.Background(), "", response)
+		require.NoError(t, err)
+		assert.Equal(t, "Closes #128", got.Footer)
+	})
+
+	t.Run("branch without issue", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{BranchPattern: `PROJ-\d+`})
+		gitClient.On("GetCurrentBranch", mock.Anything).Return("main", nil)
+
+		got, err := service.applyIssueFooter(context.Background(), "", response)
+		require.NoError(t, err)
+		assert.Same(t, response, got)
+	})
+
+	t.Run("unborn branch", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{BranchPattern: `(\d+)`})
+		gitClient.On("GetCurrentBranch", mock.Anything).Return("", errors.New("unknown revision HEAD"))
+
+		got, err := service.applyIssueFooter(context.Background(), "", response)
+		require.NoError(t, err)
+		assert.Same(t, response, got)
+	})
+
+	t.Run("already referenced", func(t *testing.T) {
+		service, _ := newService(config.IssuesConfig{Platform: "github"})
+		referenced := &ai.GenerateResponse{Subject: response.Subject, Footer: "Refs: #12"}
+
+		got, err := service.applyIssueFooter(context.Background(), "12", referenced)
+		require.NoError(t, err)
+		assert.Same(t, referenced, got)
+
+		got, err = service.applyIssueFooter(context.Background(), "2", referenced)
+		require.NoError(t, err)
+		assert.Equal(t, "Refs: #12\nCloses #2", got.Footer)
+	})
+
+	t.Run("remote lookup fails", func(t *testing.T) {
+		service, gitClient := newService(config.IssuesConfig{})
+		gitClient.On("GetRemoteURL", mock.Anything, "origin").Return("", errors.New("not a git repository"))
+
+		_, err := service.applyIssueFooter(context.Background(), "12", response)
+		assert.ErrorContains(t, err, "failed to get origin remote")
+	})
+}
+
+func TestValidateIssues(t *testing.T) {
+	assert.NoError(t, ValidateIssues(config.IssuesConfig{}))
+	assert.NoError(t, ValidateIssues(config.IssuesConfig{
+		BranchPattern: `(\d+)`,
+		Platform:      "gitea",
+		Templates:     map[string]string{"gitea": "Fixes #{issue}", "default": "Refs: {issue}"},
+	}))
+
+	assert.ErrorContains(t, ValidateIssues(config.IssuesConfig{BranchPattern: "("}), "branch_pattern")
+	assert.ErrorContains(t, ValidateIssues(config.IssuesConfig{Platform: "sourcehut"}), "unknown platform")
+	assert.ErrorContains(t, ValidateIssues(config.IssuesConfig{Templates: map[string]string{"jira": "{issue}"}}), "unknown platform")
+	assert.ErrorContains(t, ValidateIssues(config.IssuesConfig{Templates: map[string]string{"github": "Closes it"}}), "{issue}")
+}
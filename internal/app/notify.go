@@ -0,0 +1,28 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// notify is a variable to allow mocking in tests.
+var notify = ui.Notify
+
+// notifyIfSlow sends a desktop notification when generating the message took
+// at least ui.notify_after_seconds, so that the user can switch away while a
+// slow model runs. Notifications are best effort.
+func (s *CommitService) notifyIfSlow(elapsed time.Duration) {
+	if s.config == nil || s.config.UI.NotifyAfterSeconds <= 0 {
+		return
+	}
+	if elapsed < time.Duration(s.config.UI.NotifyAfterSeconds)*time.Second {
+		return
+	}
+	if err := notify("GitSage", i18n.T("commit.notify.ready")); err != nil {
+		apperrors.Debug("Desktop notification not sent: %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyIfSlow(t *testing.T) {
+	var sent []string
+	original := notify
+	notify = func(title, message string) error {
+		sent = append(sent, title+": "+message)
+		return errors.New("no notify-send")
+	}
+	t.Cleanup(func() { notify = original })
+
+	service := NewCommitService(&MockGitClient{}, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil,
+		&config.Config{UI: config.UIConfig{NotifyAfterSeconds: 10}})
+
+	service.notifyIfSlow(9 * time.Second)
+	assert.Empty(t, sent)
+
+	// A failing notification is not reported to the user
+	service.notifyIfSlow(10 * time.Second)
+	assert.Equal(t, []string{"GitSage: Commit message ready for review"}, sent)
+
+	service.config.UI.NotifyAfterSeconds = 0
+	service.notifyIfSlow(time.Hour)
+	assert.Len(t, sent, 1)
+}
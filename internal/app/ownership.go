@@ -0,0 +1,140 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/owners"
+)
+
+// Ownership modes for naming the team areas of the changed files.
+const (
+	OwnershipOff     = "off"
+	OwnershipContext = "context"
+	OwnershipScope   = "scope"
+)
+
+// ParseOwnershipMode parses an ownership mode. An empty name yields OwnershipOff.
+func ParseOwnershipMode(name string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(name)); mode {
+	case "":
+		return OwnershipOff, nil
+	case OwnershipOff, OwnershipContext, OwnershipScope:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown ownership mode %q (valid: off, context, scope)", name)
+	}
+}
+
+// containerDirs are top-level directories that hold the areas of a
+// repository rather than being one, so a file's area is the directory below.
+var containerDirs = map[string]bool{
+	"src": true, "lib": true, "libs": true, "internal": true, "pkg": true, "cmd": true,
+	"app": true, "apps": true, "packages": true, "services": true, "modules": true,
+}
+
+// ownerArea is a team area owning some of the changed files.
+type ownerArea struct {
+	name   string
+	owners []string
+	files  int
+}
+
+// ownership returns the team areas owning the changed files, one
+// "area (owners): N files" line each with the largest first, as
+// generation.ownership asks. In scope mode, the area owning every file is
+// also returned as the scope to use, unless the intent requires one.
+func (s *CommitService) ownership(ctx context.Context, chunks []git.DiffChunk, intent ai.Intent) ([]string, string) {
+	if s.config == nil {
+		return nil, ""
+	}
+	mode, err := ParseOwnershipMode(s.config.Generation.Ownership)
+	if err != nil || mode == OwnershipOff {
+		return nil, ""
+	}
+
+	var codeowners *owners.File
+	if root, err := s.gitClient.GetRepoRoot(ctx); err == nil && root != "" {
+		if codeowners, err = owners.Load(root); err != nil {
+			apperrors.Debug("Ignoring CODEOWNERS: %v", err)
+		}
+	}
+
+	var areas []*ownerArea
+	index := make(map[string]*ownerArea)
+	owned := 0
+	for _, chunk := range chunks {
+		name, fileOwners := s.fileArea(codeowners, chunk.FilePath)
+		if name == "" {
+			continue
+		}
+		owned++
+		area, ok := index[name]
+		if !ok {
+			area = &ownerArea{name: name, owners: fileOwners}
+			index[name] = area
+			areas = append(areas, area)
+		}
+		area.files++
+	}
+	sort.SliceStable(areas, func(i, j int) bool { return areas[i].files > areas[j].files })
+
+	lines := make([]string, 0, len(areas))
+	for _, area := range areas {
+		if len(area.owners) > 0 {
+			lines = append(lines, fmt.Sprintf("%s (%s): %d files", area.name, strings.Join(area.owners, " "), area.files))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: %d files", area.name, area.files))
+		}
+	}
+
+	scope := ""
+	if mode == OwnershipScope && intent.Scope == "" && len(areas) == 1 && owned == len(chunks) {
+		// Area names with parentheses cannot be a scope
+		if (ai.Intent{Scope: areas[0].name}).Validate() == nil {
+			scope = areas[0].name
+		}
+	}
+	return lines, scope
+}
+
+// fileArea returns the team area of a file and its owners: the area of its
+// CODEOWNERS owner, else the scope of the generation.scope_rules rule
+// matching it, else its top-level directory, or the one below a container
+// such as src or services. Files at the root have no area.
+func (s *CommitService) fileArea(codeowners *owners.File, filePath string) (string, []string) {
+	if fileOwners := codeowners.Owners(filePath); len(fileOwners) > 0 {
+		return owners.Area(fileOwners[0]), fileOwners
+	}
+	if _, scope, ok := matchScopeRule(s.config.Generation.ScopeRules, filePath); ok {
+		return scope, nil
+	}
+
+	segments := strings.Split(filePath, "/")
+	switch {
+	case len(segments) > 2 && containerDirs[segments[0]]:
+		return segments[1], nil
+	case len(segments) > 1:
+		return segments[0], nil
+	}
+	return "", nil
+}
+
+// ownershipRequirement returns the requirement line of the two-phase prompt
+// naming the team areas of the changed files, or an empty string.
+func (s *CommitService) ownershipRequirement() string {
+	switch {
+	case len(s.ownerAreas) == 0:
+		return ""
+	case s.areaScope != "":
+		return fmt.Sprintf("\n- 改动的文件都属于团队领域 %s（来自 CODEOWNERS 和目录结构），除非要求了其他 scope，否则使用 %q 作为 scope", s.ownerAreas[0], s.areaScope)
+	default:
+		return fmt.Sprintf("\n- 改动的文件属于以下团队领域（来自 CODEOWNERS 和目录结构），在正文中加一行说明受影响的领域（例如 \"- 影响 payments 团队领域\"）: %s", strings.Join(s.ownerAreas, "; "))
+	}
+}
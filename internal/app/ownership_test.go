@@ -0,0 +1,85 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParseOwnershipMode(t *testing.T) {
+	for name, want := range map[string]string{"": OwnershipOff, "off": OwnershipOff, " Context ": OwnershipContext, "scope": OwnershipScope} {
+		mode, err := ParseOwnershipMode(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, mode)
+	}
+	_, err := ParseOwnershipMode("team")
+	assert.ErrorContains(t, err, "unknown ownership mode")
+}
+
+func TestOwnership(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("/services/payments/ @acme/payments-team\n"), 0644))
+
+	chunks := func(paths ...string) []git.DiffChunk {
+		var chunks []git.DiffChunk
+		for _, path := range paths {
+			chunks = append(chunks, git.DiffChunk{FilePath: path})
+		}
+		return chunks
+	}
+	newService := func(mode string) *CommitService {
+		gitClient := &MockGitClient{RepoRoot: root}
+		cfg := &config.Config{Generation: config.GenerationConfig{
+			Ownership:  mode,
+			ScopeRules: []config.ScopeRule{{Path: "web", Scope: "frontend"}},
+		}}
+		return NewCommitService(gitClient, &MockAIProvider{}, nil, &MockUIManager{}, nil, cfg)
+	}
+	ctx := context.Background()
+
+	t.Run("off", func(t *testing.T) {
+		areas, scope := newService(OwnershipOff).ownership(ctx, chunks("services/payments/charge.go"), ai.Intent{})
+		assert.Nil(t, areas)
+		assert.Empty(t, scope)
+	})
+
+	t.Run("areas from CODEOWNERS, scope rules and directories", func(t *testing.T) {
+		areas, scope := newService(OwnershipContext).ownership(ctx, chunks(
+			"services/payments/charge.go",
+			"web/app.ts",
+			"services/payments/refund.go",
+			"internal/billing/invoice.go",
+			"README.md",
+		), ai.Intent{})
+		assert.Equal(t, []string{
+			"payments (@acme/payments-team): 2 files",
+			"frontend: 1 files",
+			"billing: 1 files",
+		}, areas)
+		assert.Empty(t, scope)
+	})
+
+	t.Run("scope of the single area", func(t *testing.T) {
+		service := newService(OwnershipScope)
+		paths := chunks("services/payments/charge.go", "services/payments/refund.go")
+
+		areas, scope := service.ownership(ctx, paths, ai.Intent{})
+		assert.Equal(t, []string{"payments (@acme/payments-team): 2 files"}, areas)
+		assert.Equal(t, "payments", scope)
+
+		_, scope = service.ownership(ctx, paths, ai.Intent{Scope: "billing"})
+		assert.Empty(t, scope, "a required scope wins")
+
+		_, scope = service.ownership(ctx, append(paths, chunks("README.md")...), ai.Intent{})
+		assert.Empty(t, scope, "files outside the area")
+	})
+}
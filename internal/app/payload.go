@@ -0,0 +1,95 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// confirmPayload shows what is about to be sent to the AI provider: the
+// files with their size, the staged files that are left out, and the
+// redactions applied. Unless skipConfirm is set, it asks whether to send it.
+// Returns false if the user declined.
+func (s *CommitService) confirmPayload(staged []git.DiffChunk, planned *processor.ProcessedDiff, skipConfirm bool) (bool, error) {
+	s.uiManager.ShowInfo(s.describePayload(staged, planned))
+	if skipConfirm {
+		return true, nil
+	}
+	return s.uiManager.PromptConfirm(i18n.T("commit.confirm.payload"))
+}
+
+// SetConfirmPayload makes the next flow show what it is about to send and
+// ask before its first request, as CommitOptions.ShowPayload does for
+// commits. The commands set it on the first use of a provider.
+func (s *CommitService) SetConfirmPayload(confirm bool) {
+	s.payloadPending = confirm
+}
+
+// confirmPendingPayload shows the payload described by describe and asks
+// whether to send it, once, if SetConfirmPayload was set. Returns false if
+// the user declined.
+func (s *CommitService) confirmPendingPayload(describe func() string) (bool, error) {
+	if !s.payloadPending {
+		return true, nil
+	}
+	s.payloadPending = false
+	s.uiManager.ShowInfo(describe())
+	return s.uiManager.PromptConfirm(i18n.T("commit.confirm.payload"))
+}
+
+// describePayload lists the files of the planned diff with their change type
+// and the bytes of content sent, marking those whose content was replaced by
+// the diff processor, followed by the staged files that are not sent at all
+// and the redactions applied to the whole prompt.
+func (s *CommitService) describePayload(staged []git.DiffChunk, planned *processor.ProcessedDiff) string {
+	original := make(map[string]string, len(staged))
+	for _, chunk := range staged {
+		original[chunk.FilePath] = chunk.Content
+	}
+
+	totalSize := 0
+	for _, chunk := range planned.Chunks {
+		totalSize += len(chunk.Content)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("payload.title", s.aiProvider.Name(), len(planned.Chunks), formatSize(totalSize)))
+	sb.WriteString("\n")
+
+	sent := make(map[string]bool, len(planned.Chunks))
+	for _, chunk := range processor.SortChunks(planned.Chunks) {
+		sent[chunk.FilePath] = true
+		// In stats-only mode no content is sent, which is stated once below
+		content, ok := original[chunk.FilePath]
+		if ok && !s.statsOnly && content != chunk.Content {
+			sb.WriteString(i18n.T("payload.file.replaced", chunk.FilePath, chunk.ChangeType, formatSize(len(chunk.Content))))
+		} else {
+			sb.WriteString(i18n.T("payload.file", chunk.FilePath, chunk.ChangeType, formatSize(len(chunk.Content))))
+		}
+		sb.WriteString("\n")
+	}
+
+	var omitted []string
+	for _, chunk := range processor.SortChunks(staged) {
+		if !sent[chunk.FilePath] {
+			omitted = append(omitted, chunk.FilePath)
+		}
+	}
+	if len(omitted) > 0 {
+		sb.WriteString(i18n.T("payload.omitted", strings.Join(omitted, ", ")))
+		sb.WriteString("\n")
+	}
+
+	if s.statsOnly {
+		sb.WriteString(i18n.T("payload.stats_only"))
+		sb.WriteString("\n")
+	}
+	if s.config != nil && s.config.Security.RedactPaths {
+		sb.WriteString(i18n.T("payload.redact_paths"))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+func TestDescribePayload(t *testing.T) {
+	staged := []git.DiffChunk{
+		{FilePath: "token.go", ChangeType: git.ChangeTypeModified, Content: "+check expiry"},
+		{FilePath: "go.sum", ChangeType: git.ChangeTypeModified, Content: "+github.com/a/b v1.0.0 h1:...", IsLockFile: true},
+		{FilePath: "package-lock.json", ChangeType: git.ChangeTypeModified, Content: "{}", IsLockFile: true},
+	}
+	planned := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "token.go", ChangeType: git.ChangeTypeModified, Content: "+check expiry"},
+		{FilePath: "go.sum", ChangeType: git.ChangeTypeModified, Content: "1 package"},
+	}}
+
+	newService := func(cfg *config.Config) *CommitService {
+		provider := &MockAIProvider{}
+		provider.On("Name").Return("openai")
+		return NewCommitService(nil, provider, nil, &MockUIManager{}, nil, cfg)
+	}
+
+	t.Run("files, omissions and replacements", func(t *testing.T) {
+		got := newService(&config.Config{}).describePayload(staged, planned)
+		assert.Equal(t, "Sent to openai: 2 files, 22 B\n"+
+			"  - go.sum (modified, 9 B, summarized or redacted)\n"+
+			"  - token.go (modified, 13 B)\n"+
+			"Not sent: package-lock.json\n", got)
+	})
+
+	t.Run("stats-only and path redaction", func(t *testing.T) {
+		service := newService(&config.Config{
+			Generation: config.GenerationConfig{StatsOnly: true},
+			Security:   config.SecurityConfig{RedactPaths: true},
+		})
+		got := service.describePayload(staged, withoutContent(planned))
+		assert.Contains(t, got, "  - go.sum (modified, 0 B)\n")
+		assert.Contains(t, got, "(stats-only)")
+		assert.Contains(t, got, "(security.redact_paths)")
+	})
+}
+
+func TestConfirmPayload(t *testing.T) {
+	planned := &processor.ProcessedDiff{Chunks: []git.DiffChunk{{FilePath: "main.go", Content: "+x"}}}
+
+	newService := func() (*CommitService, *MockUIManager) {
+		provider := &MockAIProvider{}
+		provider.On("Name").Return("openai")
+		uiManager := &MockUIManager{}
+		uiManager.On("ShowInfo", mock.Anything).Return().Once()
+		return NewCommitService(nil, provider, nil, uiManager, nil, &config.Config{}), uiManager
+	}
+
+	t.Run("declined", func(t *testing.T) {
+		service, uiManager := newService()
+		uiManager.On("PromptConfirm", "Send this to the AI provider?").Return(false, nil).Once()
+
+		confirmed, err := service.confirmPayload(planned.Chunks, planned, false)
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("pending payload is confirmed once", func(t *testing.T) {
+		service, uiManager := newService()
+		uiManager.On("PromptConfirm", "Send this to the AI provider?").Return(true, nil).Once()
+		describe := func() string { return "payload" }
+
+		confirmed, err := service.confirmPendingPayload(describe)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+
+		service.SetConfirmPayload(true)
+		confirmed, err = service.confirmPendingPayload(describe)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		confirmed, err = service.confirmPendingPayload(describe)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertNumberOfCalls(t, "ShowInfo", 1)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("shown without asking", func(t *testing.T) {
+		service, uiManager := newService()
+
+		confirmed, err := service.confirmPayload(planned.Chunks, planned, true)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertNotCalled(t, "PromptConfirm", mock.Anything)
+	})
+}
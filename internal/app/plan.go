@@ -0,0 +1,208 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// useTwoPhase reports whether the diff, with the examples and context sent
+// along with it, is too large for the provider's model to take in one
+// request, so that it is summarized per group before generating the message.
+func (s *CommitService) useTwoPhase(processedDiff *processor.ProcessedDiff) bool {
+	return !s.fitsContext(&ai.GenerateRequest{
+		DiffChunks:      processedDiff.Chunks,
+		Examples:        s.examples,
+		SquashedCommits: s.squashed,
+		UnstagedFiles:   s.unstaged,
+		Stack:           s.stack,
+		SensitiveFiles:  s.sensitive,
+		OwnerAreas:      s.ownerAreas,
+	})
+}
+
+// fitsContext reports whether the request and a reply of up to max_tokens
+// fit in the provider's context window, estimated in tokens. Without a known
+// window, the diff must be within ai.DefaultDiffBudget.
+func (s *CommitService) fitsContext(req *ai.GenerateRequest) bool {
+	return ai.CapabilitiesOf(s.aiProvider).FitsContext(req, s.replyTokens())
+}
+
+// replyTokens returns the max_tokens limit on replies.
+func (s *CommitService) replyTokens() int {
+	if s.config != nil && s.config.Provider.MaxTokens > 0 {
+		return s.config.Provider.MaxTokens
+	}
+	return ai.DefaultMaxTokens
+}
+
+// explainPlan describes how the diff would be sent to the AI: in a single
+// request, or as file groups summarized before the final generation.
+func (s *CommitService) explainPlan(processedDiff *processor.ProcessedDiff) string {
+	totalSize := 0
+	for _, chunk := range processedDiff.Chunks {
+		totalSize += len(chunk.Content)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T("plan.title", len(processedDiff.Chunks), formatSize(totalSize)))
+	sb.WriteString("\n")
+
+	if !s.useTwoPhase(processedDiff) {
+		sb.WriteString(i18n.T("plan.direct"))
+		sb.WriteString("\n")
+		for _, chunk := range processor.SortChunksByWeight(processedDiff.Chunks, s.pathWeights()) {
+			sb.WriteString(i18n.T("plan.file", chunk.FilePath, formatSize(len(chunk.Content))))
+			sb.WriteString("\n")
+		}
+		sb.WriteString(s.explainUsage(1, totalSize))
+		return sb.String()
+	}
+
+	groups := s.groupFilesBySize(processedDiff.Chunks)
+	sb.WriteString(i18n.T("plan.two_phase", len(groups)))
+	sb.WriteString("\n")
+
+	// One request per group or piece, then the final one
+	requests := 1
+
+	for i, group := range groups {
+		size := 0
+		for _, chunk := range group.chunks {
+			size += len(chunk.Content)
+		}
+
+		if pieces := groupRequests(group); pieces > 1 {
+			sb.WriteString(i18n.T("plan.group.pieces", i+1, formatSize(size), pieces))
+		} else {
+			sb.WriteString(i18n.T("plan.group", i+1, len(group.chunks), formatSize(size)))
+		}
+		requests += groupRequests(group)
+		sb.WriteString("\n")
+
+		for _, chunk := range group.chunks {
+			sb.WriteString(i18n.T("plan.file", chunk.FilePath, formatSize(len(chunk.Content))))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString(s.explainUsage(requests, totalSize))
+	return sb.String()
+}
+
+// groupRequests returns the number of requests summarizing a group takes:
+// one, or one per piece of a file too large for a single request.
+func groupRequests(group fileGroup) int {
+	if len(group.chunks) == 1 && len(group.chunks[0].Content) > MaxGroupSize {
+		return len(splitDiffIntoPieces(group.chunks[0].Content, MaxGroupSize, MaxFilePieces))
+	}
+	return 1
+}
+
+// explainUsage estimates the tokens sent and received for a diff of
+// totalSize bytes in the given number of requests, and their cost if the
+// provider's prices are known.
+func (s *CommitService) explainUsage(requests, totalSize int) string {
+	estimate := s.estimateUsage(requests, totalSize)
+
+	usage := i18n.T("plan.usage", requests, estimate.inputTokens, estimate.outputTokens) + "\n"
+	if estimate.priced {
+		usage += i18n.T("plan.cost", estimate.cost) + "\n"
+	}
+	return usage
+}
+
+// planEstimate is the expected size and cost of generating a message.
+type planEstimate struct {
+	files        int
+	size         int
+	requests     int
+	inputTokens  int
+	outputTokens int
+	// cost is only known if priced is set.
+	cost   float64
+	priced bool
+}
+
+// estimatePlan estimates the requests generating a message for the diff makes.
+func (s *CommitService) estimatePlan(processedDiff *processor.ProcessedDiff) planEstimate {
+	totalSize := 0
+	for _, chunk := range processedDiff.Chunks {
+		totalSize += len(chunk.Content)
+	}
+
+	requests := 1
+	if s.useTwoPhase(processedDiff) {
+		for _, group := range s.groupFilesBySize(processedDiff.Chunks) {
+			requests += groupRequests(group)
+		}
+	}
+
+	estimate := s.estimateUsage(requests, totalSize)
+	estimate.files = len(processedDiff.Chunks)
+	return estimate
+}
+
+// estimateUsage estimates the tokens of a diff of totalSize bytes sent in
+// the given number of requests. Replies are counted at the max_tokens limit.
+func (s *CommitService) estimateUsage(requests, totalSize int) planEstimate {
+	estimate := planEstimate{
+		size:         totalSize,
+		requests:     requests,
+		inputTokens:  ai.EstimateTokens(totalSize),
+		outputTokens: requests * s.replyTokens(),
+	}
+	if capabilities := ai.CapabilitiesOf(s.aiProvider); capabilities.HasPricing() {
+		estimate.cost = capabilities.EstimateCost(estimate.inputTokens, estimate.outputTokens)
+		estimate.priced = true
+	}
+	return estimate
+}
+
+// confirmBudget shows the estimate of the requests about to be sent when it
+// exceeds the configured budget, and asks whether to send them. Within the
+// budget, the estimate is only shown with budget.show_estimate.
+// Returns false if the user declined.
+func (s *CommitService) confirmBudget(processedDiff *processor.ProcessedDiff) (bool, error) {
+	if s.config == nil {
+		return true, nil
+	}
+	estimate := s.estimatePlan(processedDiff)
+	over := s.overBudget(estimate)
+	if !over && !s.config.Budget.ShowEstimate {
+		return true, nil
+	}
+
+	summary := i18n.T("plan.estimate", estimate.files, formatSize(estimate.size), estimate.requests, estimate.inputTokens)
+	if estimate.priced {
+		summary += i18n.T("plan.estimate.cost", estimate.cost)
+	}
+	s.uiManager.ShowInfo(summary)
+	if !over {
+		return true, nil
+	}
+	return s.uiManager.PromptConfirm(i18n.T("commit.confirm.budget"))
+}
+
+// overBudget reports whether the estimate exceeds any limit of the budget.
+func (s *CommitService) overBudget(estimate planEstimate) bool {
+	if s.config == nil {
+		return false
+	}
+	budget := s.config.Budget
+	return (budget.MaxRequests > 0 && estimate.requests > budget.MaxRequests) ||
+		(budget.MaxTokens > 0 && estimate.inputTokens > budget.MaxTokens) ||
+		(budget.MaxCost > 0 && estimate.priced && estimate.cost > budget.MaxCost)
+}
+
+// formatSize formats a size in bytes for display.
+func formatSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
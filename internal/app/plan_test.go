@@ -0,0 +1,319 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+func TestGroupFilesBySize_Deterministic(t *testing.T) {
+	service := NewCommitService(nil, nil, nil, nil, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "c.go", Content: strings.Repeat("c", 3*1024)},
+		{FilePath: "a.go", Content: strings.Repeat("a", 2*1024)},
+		{FilePath: "big.go", Content: strings.Repeat("b", 5*1024)},
+		{FilePath: "b.go", Content: strings.Repeat("b", 1024)},
+	}
+	reversed := []git.DiffChunk{chunks[3], chunks[2], chunks[1], chunks[0]}
+
+	groups := service.groupFilesBySize(chunks)
+
+	assert.Equal(t, groups, service.groupFilesBySize(reversed))
+	assert.Equal(t, []string{"a.go", "b.go"}, groups[0].files)
+	assert.Equal(t, []string{"big.go"}, groups[1].files)
+	assert.Equal(t, []string{"c.go"}, groups[2].files)
+}
+
+func TestGroupFilesBySize_PathWeights(t *testing.T) {
+	cfg := &config.Config{Git: config.GitConfig{PathWeights: []config.PathWeight{
+		{Path: "internal/**", Weight: "high"},
+		{Path: "docs/**", Weight: "low"},
+	}}}
+	service := NewCommitService(nil, nil, nil, nil, nil, cfg)
+
+	groups := service.groupFilesBySize([]git.DiffChunk{
+		{FilePath: "docs/guide.md", Content: strings.Repeat("d", 3*1024)},
+		{FilePath: "cmd/main.go", Content: strings.Repeat("m", 3*1024)},
+		{FilePath: "internal/core.go", Content: strings.Repeat("c", 3*1024)},
+	})
+
+	// The important files are summarized first, so they survive stopping early
+	assert.Equal(t, []string{"internal/core.go"}, groups[0].files)
+	assert.Equal(t, []string{"cmd/main.go"}, groups[1].files)
+	assert.Equal(t, []string{"docs/guide.md"}, groups[2].files)
+}
+
+func TestExplainPlan(t *testing.T) {
+	service := NewCommitService(nil, nil, nil, nil, nil, &config.Config{})
+
+	t.Run("single request", func(t *testing.T) {
+		plan := service.explainPlan(&processor.ProcessedDiff{Chunks: []git.DiffChunk{
+			{FilePath: "main.go", Content: "small change"},
+		}})
+
+		assert.Contains(t, plan, "Generation plan: 1 files, 12 B")
+		assert.Contains(t, plan, "Single request")
+		assert.Contains(t, plan, "  - main.go (12 B)")
+	})
+
+	t.Run("two-phase", func(t *testing.T) {
+		plan := service.explainPlan(&processor.ProcessedDiff{Chunks: []git.DiffChunk{
+			{FilePath: "gen.go", Content: buildHunkDiff(40, 20)},
+			{FilePath: "a.go", Content: strings.Repeat("a", 2*1024)},
+		}})
+
+		assert.Contains(t, plan, "Two-phase: 2 groups")
+		assert.Contains(t, plan, "Group 1: 1 files, 2.0 KB")
+		assert.Contains(t, plan, "Group 2: 1 file,")
+		assert.Contains(t, plan, "pieces")
+		assert.Less(t, strings.Index(plan, "a.go"), strings.Index(plan, "gen.go"))
+	})
+}
+
+// capableProvider is a MockAIProvider reporting fixed capabilities.
+type capableProvider struct {
+	*MockAIProvider
+	capabilities ai.Capabilities
+}
+
+func (p *capableProvider) Capabilities() ai.Capabilities {
+	return p.capabilities
+}
+
+func TestUseTwoPhase_Capabilities(t *testing.T) {
+	diff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "a.go", Content: strings.Repeat("a", 8*1024)},
+		{FilePath: "b.go", Content: strings.Repeat("b", 8*1024)},
+	}}
+
+	unknown := NewCommitService(nil, &MockAIProvider{}, nil, nil, nil, &config.Config{})
+	assert.True(t, unknown.useTwoPhase(diff), "16KB exceeds the default threshold")
+
+	large := NewCommitService(nil, &capableProvider{&MockAIProvider{}, ai.Capabilities{MaxContextTokens: 128000}}, nil, nil, nil, &config.Config{})
+	assert.False(t, large.useTwoPhase(diff), "16KB fits a 128K context")
+
+	small := NewCommitService(nil, &capableProvider{&MockAIProvider{}, ai.Capabilities{MaxContextTokens: 4096}}, nil, nil, nil, &config.Config{})
+	assert.True(t, small.useTwoPhase(diff), "16KB does not fit a 4K context")
+
+	// A single large file is summarized too
+	single := &processor.ProcessedDiff{Chunks: []git.DiffChunk{{FilePath: "a.go", Content: strings.Repeat("a", 16*1024)}}}
+	assert.True(t, unknown.useTwoPhase(single))
+
+	// Examples sent along with the diff count towards the window
+	withExamples := NewCommitService(nil, &capableProvider{&MockAIProvider{}, ai.Capabilities{MaxContextTokens: 16385}}, nil, nil, nil, &config.Config{})
+	assert.False(t, withExamples.useTwoPhase(diff), "16KB fits a 16K context")
+	withExamples.examples = []ai.Example{{Diff: strings.Repeat("e", 40*1024)}}
+	assert.True(t, withExamples.useTwoPhase(diff), "16KB and 40KB of examples do not fit a 16K context")
+}
+
+func TestExplainPlan_Usage(t *testing.T) {
+	diff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{{FilePath: "main.go", Content: strings.Repeat("x", 4000)}}}
+	cfg := &config.Config{Provider: config.ProviderConfig{MaxTokens: 300}}
+
+	plan := NewCommitService(nil, &MockAIProvider{}, nil, nil, nil, cfg).explainPlan(diff)
+	assert.Contains(t, plan, "Estimated usage: 1 requests, ~1000 input tokens, up to 300 output tokens")
+	assert.NotContains(t, plan, "Estimated cost")
+
+	priced := &capableProvider{&MockAIProvider{}, ai.Capabilities{InputCostPer1K: 0.001, OutputCostPer1K: 0.002}}
+	plan = NewCommitService(nil, priced, nil, nil, nil, cfg).explainPlan(diff)
+	assert.Contains(t, plan, "Estimated cost: up to $0.0016")
+}
+
+func TestGenerateAndCommit_ExplainPlan(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 12}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1}, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowInfo", mock.MatchedBy(func(plan string) bool {
+		return strings.Contains(plan, "test.go")
+	})).Return().Once()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{ExplainPlan: true})
+
+	assert.NoError(t, err)
+	uiManager.AssertExpectations(t)
+	aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+}
+
+func TestConfirmBudget(t *testing.T) {
+	// Ten 5 KB files are summarized in ten groups, then merged: 11 requests
+	var chunks []git.DiffChunk
+	for i := 0; i < 10; i++ {
+		chunks = append(chunks, git.DiffChunk{FilePath: fmt.Sprintf("f%d.go", i), Content: strings.Repeat("x", 5*1024)})
+	}
+	large := &processor.ProcessedDiff{Chunks: chunks}
+	small := &processor.ProcessedDiff{Chunks: []git.DiffChunk{{FilePath: "main.go", Content: "small change"}}}
+
+	newService := func(budget config.BudgetConfig) (*CommitService, *MockUIManager) {
+		uiManager := &MockUIManager{}
+		return NewCommitService(nil, &MockAIProvider{}, nil, uiManager, nil, &config.Config{Budget: budget}), uiManager
+	}
+
+	t.Run("within budget", func(t *testing.T) {
+		service, uiManager := newService(config.BudgetConfig{MaxRequests: 10})
+		confirmed, err := service.confirmBudget(small)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertNotCalled(t, "ShowInfo", mock.Anything)
+	})
+
+	t.Run("above the request budget", func(t *testing.T) {
+		service, uiManager := newService(config.BudgetConfig{MaxRequests: 10})
+		uiManager.On("ShowInfo", "Estimate: 10 files, 50.0 KB, 11 requests, ~12800 input tokens").Return().Once()
+		uiManager.On("PromptConfirm", mock.Anything).Return(false, nil).Once()
+
+		confirmed, err := service.confirmBudget(large)
+		assert.NoError(t, err)
+		assert.False(t, confirmed)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("above the token budget", func(t *testing.T) {
+		service, uiManager := newService(config.BudgetConfig{MaxTokens: 10000})
+		uiManager.On("ShowInfo", mock.Anything).Return().Once()
+		uiManager.On("PromptConfirm", mock.Anything).Return(true, nil).Once()
+
+		confirmed, err := service.confirmBudget(large)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("above the cost budget", func(t *testing.T) {
+		uiManager := &MockUIManager{}
+		priced := &capableProvider{&MockAIProvider{}, ai.Capabilities{InputCostPer1K: 0.01, OutputCostPer1K: 0.03}}
+		service := NewCommitService(nil, priced, nil, uiManager, nil, &config.Config{Budget: config.BudgetConfig{MaxCost: 0.05}})
+		uiManager.On("ShowInfo", mock.MatchedBy(func(estimate string) bool {
+			return strings.HasSuffix(estimate, ", up to $0.2930")
+		})).Return().Once()
+		uiManager.On("PromptConfirm", mock.Anything).Return(true, nil).Once()
+
+		_, err := service.confirmBudget(large)
+		assert.NoError(t, err)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("unknown cost is not over budget", func(t *testing.T) {
+		service, uiManager := newService(config.BudgetConfig{MaxCost: 0.01})
+		confirmed, err := service.confirmBudget(large)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertNotCalled(t, "ShowInfo", mock.Anything)
+	})
+
+	t.Run("estimate shown within budget", func(t *testing.T) {
+		service, uiManager := newService(config.BudgetConfig{ShowEstimate: true})
+		uiManager.On("ShowInfo", "Estimate: 1 files, 12 B, 1 requests, ~3 input tokens").Return().Once()
+
+		confirmed, err := service.confirmBudget(small)
+		assert.NoError(t, err)
+		assert.True(t, confirmed)
+		uiManager.AssertNotCalled(t, "PromptConfirm", mock.Anything)
+	})
+}
+
+func TestGenerateAndCommit_BudgetDeclined(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{Budget: config.BudgetConfig{MaxTokens: 1}})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 12}
+
+	gitClient.On("GetGitDir", mock.Anything).Return(t.TempDir(), nil)
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1}, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowInfo", mock.Anything).Return().Once()
+	uiManager.On("PromptConfirm", mock.Anything).Return(false, nil).Once()
+	uiManager.On("ShowSuccess", "Commit cancelled; nothing was sent to the AI provider").Return().Once()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	uiManager.AssertExpectations(t)
+	aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.0 KB", formatSize(1024))
+	assert.Equal(t, "10.5 KB", formatSize(10*1024+512))
+}
+
+func TestGenerateCommitMessage_TruncatedPromptFallsBackToTwoPhase(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	progress := &MockProgressSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{{FilePath: "a.go", Content: "+a"}, {FilePath: "b.go", Content: "+b"}}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}
+
+	truncated := &ai.ContextTruncatedError{PromptTokens: 3000, EvaluatedTokens: 2048}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.CustomPrompt == ""
+	})).Return(nil, fmt.Errorf("ollama: %w", truncated)).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "简要描述")
+	})).Return(&ai.GenerateResponse{RawText: "- a.go, b.go: 改动"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "根据以下文件改动摘要")
+	})).Return(&ai.GenerateResponse{Subject: "feat: update a and b", RawText: "feat: update a and b"}, nil).Once()
+	aiProvider.On("Name").Return("ollama").Maybe()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowProgressSpinner", mock.Anything, 1).Return(progress)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+	progress.On("Start").Return()
+	progress.On("Stop").Return()
+	progress.On("SetCurrent", mock.Anything).Return()
+	progress.On("SetCurrentFile", mock.Anything).Return()
+
+	response, err := service.generateCommitMessage(context.Background(), processedDiff, &git.DiffStats{TotalFiles: 2}, nil, "", "", "", "", ai.Intent{}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "feat: update a and b", response.Subject)
+	aiProvider.AssertExpectations(t)
+}
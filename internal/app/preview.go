@@ -0,0 +1,36 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// confirmCommitPreview shows the commit as "git log -1 --stat" will show it
+// once made, author and date included, and asks whether to make it. Returns
+// true without asking when ui.commit_preview is off.
+func (s *CommitService) confirmCommitPreview(ctx context.Context, commitMsg string, diffStats *git.DiffStats) (bool, error) {
+	if s.config == nil || !s.config.UI.CommitPreview {
+		return true, nil
+	}
+
+	author, err := s.gitClient.GetAuthorIdent(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get commit author: %w", err)
+	}
+
+	s.uiManager.ShowInfo(ui.FormatCommitPreview(ui.CommitPreview{
+		Author:  *author,
+		Message: commitMsg,
+		Stats:   diffStats,
+	}))
+	confirmed, err := s.uiManager.PromptConfirm(i18n.T("commit.confirm.preview"))
+	if err != nil {
+		return false, fmt.Errorf("failed to confirm commit: %w", err)
+	}
+	return confirmed, nil
+}
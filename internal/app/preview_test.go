@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestGenerateAndCommit_CommitPreview(t *testing.T) {
+	newService := func(confirmed bool) (*CommitService, *MockGitClient, *MockUIManager) {
+		gitClient := &MockGitClient{}
+		aiProvider := &MockAIProvider{}
+		diffProcessor := &MockDiffProcessor{}
+		uiManager := &MockUIManager{}
+		spinner := &MockSpinner{}
+
+		chunks := []git.DiffChunk{{FilePath: "auth.go", Additions: 3, Content: "+check()"}}
+		author := &git.Ident{Name: "Jane Doe", Email: "jane@example.com", When: time.Unix(1700000000, 0).UTC()}
+		gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+		gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+		gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, TotalAdditions: 3, Chunks: chunks}, nil)
+		gitClient.On("GetAuthorIdent", mock.Anything).Return(author, nil)
+		gitClient.On("HasRemote", mock.Anything).Return(false, nil).Maybe()
+		diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).
+			Return(&ai.GenerateResponse{Subject: "fix(auth): check tokens", RawText: "fix(auth): check tokens"}, nil)
+		uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+		uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+		uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+		uiManager.On("ShowInfo", mock.MatchedBy(func(preview string) bool {
+			return strings.Contains(preview, "Author: Jane Doe <jane@example.com>") &&
+				strings.Contains(preview, "    fix(auth): check tokens") &&
+				strings.Contains(preview, " auth.go | 3 +++")
+		})).Return().Once()
+		uiManager.On("PromptConfirm", "Make this commit?").Return(confirmed, nil).Once()
+		spinner.On("Start").Return()
+		spinner.On("Stop").Return()
+
+		cfg := &config.Config{UI: config.UIConfig{CommitPreview: true}}
+		return NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg), gitClient, uiManager
+	}
+
+	t.Run("declined", func(t *testing.T) {
+		service, gitClient, uiManager := newService(false)
+		uiManager.On("ShowSuccess", "Commit cancelled after the preview; run with --resume to commit this message later").Return().Once()
+
+		require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+		gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		service, gitClient, uiManager := newService(true)
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		gitClient.On("Commit", mock.Anything, "fix(auth): check tokens").Return(nil).Once()
+
+		require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+		gitClient.AssertExpectations(t)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("not shown for a dry run", func(t *testing.T) {
+		service, gitClient, uiManager := newService(true)
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		uiManager.On("ShowInfo", mock.Anything).Return().Maybe()
+
+		require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true}))
+		gitClient.AssertNotCalled(t, "GetAuthorIdent", mock.Anything)
+		uiManager.AssertNotCalled(t, "PromptConfirm", "Make this commit?")
+	})
+}
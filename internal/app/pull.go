@@ -0,0 +1,59 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// pullMissingModel offers to pull the model when err says the provider does
+// not have it, and pulls it showing the progress if the user agrees. It is
+// offered once per session. Returns true if the model was pulled, so the
+// request that failed can be made again.
+func (s *CommitService) pullMissingModel(ctx context.Context, err error) bool {
+	puller, ok := s.aiProvider.(ai.ModelPuller)
+	if !ok || s.pullOffered || !ai.IsModelNotFound(err) {
+		return false
+	}
+	s.pullOffered = true
+
+	model := puller.PullModelName()
+	confirmed, confirmErr := s.uiManager.PromptConfirm(i18n.T("model.confirm.pull", model))
+	if confirmErr != nil || !confirmed {
+		return false
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("model.spinner.pull", model))
+	spinner.Start()
+	err = puller.PullModel(ctx, func(progress ai.PullProgress) {
+		spinner.UpdateText(formatPullProgress(model, progress))
+	})
+	spinner.Stop()
+	if err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("model.error.pull", model), err))
+		return false
+	}
+
+	s.uiManager.ShowSuccess(i18n.T("model.success.pulled", model))
+	return true
+}
+
+// formatPullProgress describes a pull status update, with the share of the
+// layer downloaded so far, e.g. "codellama: pulling 6a0746a1ec1a 25% (1.0 GB / 3.8 GB)".
+func formatPullProgress(model string, progress ai.PullProgress) string {
+	text := fmt.Sprintf("%s: %s", model, progress.Status)
+	if progress.Total > 0 {
+		text += fmt.Sprintf(" %d%% (%s / %s)", progress.Completed*100/progress.Total,
+			formatGigabytes(progress.Completed), formatGigabytes(progress.Total))
+	}
+	return text
+}
+
+// formatGigabytes formats a byte count in decimal gigabytes, the unit the
+// ollama CLI sizes models in.
+func formatGigabytes(bytes int64) string {
+	return fmt.Sprintf("%.1f GB", float64(bytes)/1e9)
+}
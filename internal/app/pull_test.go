@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// pullingProvider is a health checking MockAIProvider that also implements ai.ModelPuller.
+type pullingProvider struct {
+	healthCheckingProvider
+}
+
+func (m *pullingProvider) PullModelName() string {
+	return "qwen2.5-coder:7b"
+}
+
+func (m *pullingProvider) PullModel(ctx context.Context, progress func(ai.PullProgress)) error {
+	args := m.Called(ctx)
+	progress(ai.PullProgress{Status: "pulling 6a0746a1ec1a", Total: 4e9, Completed: 1e9})
+	return args.Error(0)
+}
+
+func TestCheckProviderHealth_PullsMissingModel(t *testing.T) {
+	missing := &ai.OllamaAPIError{StatusCode: http.StatusNotFound, Message: `model "qwen2.5-coder:7b" not found`}
+
+	newService := func(confirmed bool) (*CommitService, *pullingProvider, *MockUIManager) {
+		provider := &pullingProvider{}
+		uiManager := &MockUIManager{}
+		spinner := &MockSpinner{}
+		provider.On("HealthCheck", mock.Anything).Return(missing).Once()
+		uiManager.On("PromptConfirm", "Model qwen2.5-coder:7b is not pulled in Ollama. Pull it now?").Return(confirmed, nil).Once()
+		uiManager.On("ShowSpinner", "Pulling qwen2.5-coder:7b...").Return(spinner)
+		uiManager.On("ShowSuccess", "Pulled qwen2.5-coder:7b").Return()
+		spinner.On("Start").Return()
+		spinner.On("Stop").Return()
+		spinner.On("UpdateText", "qwen2.5-coder:7b: pulling 6a0746a1ec1a 25% (1.0 GB / 4.0 GB)").Return()
+
+		cfg := &config.Config{Provider: config.ProviderConfig{HealthCheck: true}}
+		return NewCommitService(nil, provider, nil, uiManager, nil, cfg), provider, uiManager
+	}
+
+	t.Run("pulled", func(t *testing.T) {
+		service, provider, uiManager := newService(true)
+		provider.On("PullModel", mock.Anything).Return(nil).Once()
+		provider.On("HealthCheck", mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, service.checkProviderHealth(context.Background()))
+		provider.AssertExpectations(t)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		service, provider, _ := newService(false)
+
+		assert.ErrorIs(t, service.checkProviderHealth(context.Background()), missing)
+		provider.AssertNotCalled(t, "PullModel", mock.Anything)
+	})
+
+	t.Run("pull fails", func(t *testing.T) {
+		service, provider, uiManager := newService(true)
+		provider.On("PullModel", mock.Anything).Return(errors.New("file does not exist")).Once()
+		uiManager.On("ShowError", mock.Anything).Return().Once()
+
+		assert.ErrorIs(t, service.checkProviderHealth(context.Background()), missing)
+		provider.AssertNumberOfCalls(t, "HealthCheck", 1)
+	})
+}
+
+func TestPullMissingModel_OfferedOnce(t *testing.T) {
+	provider := &pullingProvider{}
+	uiManager := &MockUIManager{}
+	uiManager.On("PromptConfirm", mock.Anything).Return(false, nil).Once()
+	service := NewCommitService(nil, provider, nil, uiManager, nil, &config.Config{})
+	missing := &ai.OllamaAPIError{StatusCode: http.StatusNotFound}
+
+	assert.False(t, service.pullMissingModel(context.Background(), errors.New("connection refused")))
+	assert.False(t, service.pullMissingModel(context.Background(), missing))
+	assert.False(t, service.pullMissingModel(context.Background(), missing))
+	uiManager.AssertNumberOfCalls(t, "PromptConfirm", 1)
+}
+
+func TestFormatPullProgress(t *testing.T) {
+	assert.Equal(t, "codellama: pulling manifest", formatPullProgress("codellama", ai.PullProgress{Status: "pulling manifest"}))
+	assert.Equal(t, "codellama: pulling 3a43f93b78ec 50% (1.9 GB / 3.8 GB)",
+		formatPullProgress("codellama", ai.PullProgress{Status: "pulling 3a43f93b78ec", Total: 3.8e9, Completed: 1.9e9}))
+}
+
+var _ ai.ModelPuller = (*pullingProvider)(nil)
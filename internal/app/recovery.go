@@ -0,0 +1,71 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// RecoveryFileName is the file in the git directory holding the message being
+// worked on, so it survives a crash or a failing editor. Like git's
+// COMMIT_EDITMSG it lives in .git and is never committed.
+const RecoveryFileName = "GITSAGE_EDITMSG"
+
+// recoveryPath returns the path of the recovery file, or an empty string if
+// the git directory cannot be determined.
+func (s *CommitService) recoveryPath(ctx context.Context) string {
+	gitDir, err := s.gitClient.GetGitDir(ctx)
+	if err != nil || gitDir == "" {
+		apperrors.Debug("Message recovery disabled: %v", err)
+		return ""
+	}
+	return filepath.Join(gitDir, RecoveryFileName)
+}
+
+// loadRecovery returns the message saved by an earlier run.
+func loadRecovery(path string) (*ai.GenerateResponse, error) {
+	if path == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidArguments, "cannot resume: git directory not found")
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, apperrors.New(apperrors.ErrInvalidArguments, "no message to resume: "+RecoveryFileName+" not found")
+	}
+	if err != nil {
+		return nil, apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to read "+RecoveryFileName)
+	}
+
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, apperrors.New(apperrors.ErrInvalidArguments, "no message to resume: "+RecoveryFileName+" is empty")
+	}
+	return ai.ParseCommitMessage(text).ToGenerateResponse(text), nil
+}
+
+// saveRecovery persists the message being worked on. Recovery is best effort,
+// so failures are logged and never interrupt the workflow.
+func (s *CommitService) saveRecovery(response *ai.GenerateResponse) {
+	if s.recoveryFile == "" || response == nil {
+		return
+	}
+	if err := writeFile(s.recoveryFile, []byte(s.formatCommitMessage(response)+"\n"), 0600); err != nil {
+		apperrors.Debug("Failed to save %s: %v", RecoveryFileName, err)
+	}
+}
+
+// clearRecovery removes the recovery file once the message was committed or
+// the user gave it up.
+func (s *CommitService) clearRecovery() {
+	if s.recoveryFile == "" {
+		return
+	}
+	if err := os.Remove(s.recoveryFile); err != nil && !os.IsNotExist(err) {
+		apperrors.Debug("Failed to remove %s: %v", RecoveryFileName, err)
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRecovery(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, RecoveryFileName)
+
+	_, err := loadRecovery(path)
+	assert.ErrorContains(t, err, "no message to resume")
+	assert.Equal(t, 1, apperrors.GetExitCode(err))
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0600))
+	_, err = loadRecovery(path)
+	assert.ErrorContains(t, err, "is empty")
+
+	require.NoError(t, os.WriteFile(path, []byte("fix(auth): refresh expired tokens\n\nRetry once on 401.\n"), 0600))
+	response, err := loadRecovery(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): refresh expired tokens", response.Subject)
+	assert.Equal(t, "Retry once on 401.", response.Body)
+}
+
+// newRecoveryTestService returns a service whose git directory is a temp dir,
+// with one staged file and no history.
+func newRecoveryTestService(t *testing.T) (*CommitService, *MockGitClient, *MockAIProvider, *MockUIManager, string) {
+	gitClient := &MockGitClient{GitDir: t.TempDir()}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	chunks := []git.DiffChunk{{FilePath: "auth.go", ChangeType: git.ChangeTypeModified, Content: "diff"}}
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil).Maybe()
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+	uiManager.On("ShowSuccess", mock.Anything).Maybe()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+	return service, gitClient, aiProvider, uiManager, filepath.Join(gitClient.GitDir, RecoveryFileName)
+}
+
+func TestGenerateAndCommit_RecoveryAfterFailedCommit(t *testing.T) {
+	service, gitClient, aiProvider, uiManager, path := newRecoveryTestService(t)
+	response := &ai.GenerateResponse{Subject: "fix(auth): refresh expired tokens", RawText: "fix(auth): refresh expired tokens"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	gitClient.On("Commit", mock.Anything, "fix(auth): refresh expired tokens").Return(errors.New("hook failed")).Once()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+	require.Error(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): refresh expired tokens\n", string(data))
+
+	// Resuming commits the saved message without calling the AI again
+	service, gitClient, aiProvider, uiManager, _ = newRecoveryTestService(t)
+	gitClient.GitDir = filepath.Dir(path)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	gitClient.On("Commit", mock.Anything, "fix(auth): refresh expired tokens").Return(nil).Once()
+
+	err = service.GenerateAndCommit(context.Background(), &CommitOptions{Resume: true})
+	require.NoError(t, err)
+	aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+	assert.NoFileExists(t, path)
+}
+
+func TestGenerateAndCommit_CancelClearsRecovery(t *testing.T) {
+	service, gitClient, aiProvider, uiManager, path := newRecoveryTestService(t)
+	response := &ai.GenerateResponse{Subject: "feat: add login", RawText: "feat: add login"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil)
+
+	require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+	assert.NoFileExists(t, path)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
+
+func TestGenerateAndCommit_ResumeWithoutMessage(t *testing.T) {
+	service, gitClient, _, _, _ := newRecoveryTestService(t)
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{Resume: true})
+	assert.ErrorContains(t, err, "no message to resume")
+	gitClient.AssertNotCalled(t, "HasStagedChanges", mock.Anything)
+}
+
+func TestGenerateAndCommit_DryRunKeepsNoRecovery(t *testing.T) {
+	service, _, aiProvider, uiManager, path := newRecoveryTestService(t)
+	response := &ai.GenerateResponse{Subject: "feat: add login", RawText: "feat: add login"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowInfo", mock.Anything).Maybe()
+
+	require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true}))
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,34 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"os"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/security"
+)
+
+// pathRedactor returns the function that hides local paths in prompts when
+// security.redact_paths is enabled, or nil. The repository root and home
+// directory are looked up once per session.
+func (s *CommitService) pathRedactor() func(string) string {
+	if s.config == nil || !s.config.Security.RedactPaths {
+		return nil
+	}
+
+	s.redactOnce.Do(func() {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			apperrors.Debug("Not redacting the home directory: %v", err)
+		}
+		var repoRoot string
+		if s.gitClient != nil {
+			if repoRoot, err = s.gitClient.GetRepoRoot(context.Background()); err != nil {
+				apperrors.Debug("Not redacting the repository root: %v", err)
+			}
+		}
+		s.redact = security.NewPathRedactor(homeDir, repoRoot).Redact
+	})
+	return s.redact
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestPathRedactor(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	root := filepath.Join(home, "src", "repo")
+
+	t.Run("disabled", func(t *testing.T) {
+		service := NewCommitService(&MockGitClient{RepoRoot: root}, nil, nil, nil, nil, &config.Config{})
+		assert.Nil(t, service.pathRedactor())
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg := &config.Config{Security: config.SecurityConfig{RedactPaths: true}}
+		service := NewCommitService(&MockGitClient{RepoRoot: root}, nil, nil, nil, nil, cfg)
+		redact := service.pathRedactor()
+		if assert.NotNil(t, redact) {
+			assert.Equal(t, "./main.go", redact(root+"/main.go"))
+			assert.Equal(t, "~/.bashrc", redact(home+"/.bashrc"))
+		}
+	})
+}
+
+func TestSummarize_RedactsPaths(t *testing.T) {
+	cfg := &config.Config{Security: config.SecurityConfig{RedactPaths: true}}
+	aiProvider := &MockAIProvider{}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Redact != nil && req.Redact("/src/repo/a.go") == "./a.go"
+	})).Return(&ai.GenerateResponse{RawText: "- a.go: 改动"}, nil)
+	service := NewCommitService(&MockGitClient{RepoRoot: "/src/repo"}, aiProvider, nil, nil, nil, cfg)
+
+	summary, err := service.summarize(context.Background(), "summarize /src/repo/a.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "- a.go: 改动", summary)
+	aiProvider.AssertExpectations(t)
+}
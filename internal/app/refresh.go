@@ -0,0 +1,59 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// stagedChanges is a snapshot of the staged diff and its processed form.
+type stagedChanges struct {
+	chunks    []git.DiffChunk
+	processed *processor.ProcessedDiff
+	stats     *git.DiffStats
+}
+
+// refreshStagedChanges re-reads the staged diff after the user staged or
+// unstaged files while the message was shown. It returns nil if the diff is
+// the same as current. File groups whose diff did not change keep their
+// summaries, so only the changed ones are summarized again.
+func (s *CommitService) refreshStagedChanges(ctx context.Context, current []git.DiffChunk) (*stagedChanges, error) {
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.retrieving"))
+	spinner.Start()
+	defer spinner.Stop()
+
+	chunks, err := s.gitClient.GetStagedDiff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sameChanges(chunks, current) {
+		return nil, nil
+	}
+
+	processed, err := s.diffProcessor.Process(ctx, chunks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process diff: %w", err)
+	}
+	if len(processed.Chunks) == 0 {
+		return nil, fmt.Errorf("no changes to commit after filtering lock files")
+	}
+
+	return &stagedChanges{chunks: chunks, processed: processed, stats: newDiffStats(chunks)}, nil
+}
+
+// sameChanges reports whether two diffs change the same files in the same way.
+func sameChanges(a, b []git.DiffChunk) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].FilePath != b[i].FilePath || a[i].OldPath != b[i].OldPath || a[i].Content != b[i].Content {
+			return false
+		}
+	}
+	return true
+}
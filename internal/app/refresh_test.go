@@ -0,0 +1,100 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestSameChanges(t *testing.T) {
+	a := []git.DiffChunk{{FilePath: "a.go", Content: "+x"}}
+
+	assert.True(t, sameChanges(a, []git.DiffChunk{{FilePath: "a.go", Content: "+x"}}))
+	assert.False(t, sameChanges(a, []git.DiffChunk{{FilePath: "a.go", Content: "+y"}}))
+	assert.False(t, sameChanges(a, append(a, git.DiffChunk{FilePath: "b.go"})))
+	assert.False(t, sameChanges(a, []git.DiffChunk{{FilePath: "a.go", OldPath: "old.go", Content: "+x"}}))
+}
+
+// setupRefreshTest prepares a commit run whose staged diff is read twice:
+// first as staged, then as refreshed.
+func setupRefreshTest(staged, refreshed []git.DiffChunk) (*CommitService, *MockGitClient, *MockAIProvider, *MockDiffProcessor, *MockUIManager) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(staged, nil).Once()
+	gitClient.On("GetStagedDiff", mock.Anything).Return(refreshed, nil).Once()
+	gitClient.On("GetDiffStats", mock.Anything).Return(newDiffStats(staged), nil)
+	diffProcessor.On("Process", mock.Anything, staged).Return(&processor.ProcessedDiff{Chunks: staged}, nil).Once()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	return service, gitClient, aiProvider, diffProcessor, uiManager
+}
+
+func TestGenerateAndCommit_Refresh(t *testing.T) {
+	staged := []git.DiffChunk{{FilePath: "a.go", ChangeType: git.ChangeTypeModified, Content: "+a"}}
+	refreshed := append(staged, git.DiffChunk{FilePath: "b.go", ChangeType: git.ChangeTypeAdded, Content: "+b"})
+	service, _, aiProvider, diffProcessor, uiManager := setupRefreshTest(staged, refreshed)
+
+	first := &ai.GenerateResponse{Subject: "feat: update a"}
+	second := &ai.GenerateResponse{Subject: "feat: update a and add b"}
+	diffProcessor.On("Process", mock.Anything, refreshed).Return(&processor.ProcessedDiff{Chunks: refreshed}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return len(req.DiffChunks) == 1
+	})).Return(first, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return len(req.DiffChunks) == 2 && req.PreviousAttempt == "" && req.DiffStats.TotalFiles == 2
+	})).Return(second, nil).Once()
+
+	uiManager.On("DisplayMessage", first).Return(nil).Once()
+	uiManager.On("DisplayComparison", first, second).Return(nil).Once()
+	uiManager.On("ShowInfo", mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, "2 files")
+	})).Return().Once()
+	uiManager.On("PromptAction").Return(ui.ActionRefresh, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil).Once()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+	diffProcessor.AssertExpectations(t)
+	uiManager.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_RefreshUnchanged(t *testing.T) {
+	staged := []git.DiffChunk{{FilePath: "a.go", ChangeType: git.ChangeTypeModified, Content: "+a"}}
+	service, gitClient, aiProvider, _, uiManager := setupRefreshTest(staged, staged)
+
+	response := &ai.GenerateResponse{Subject: "feat: update a"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil).Once()
+	uiManager.On("DisplayMessage", response).Return(nil).Once()
+	uiManager.On("ShowInfo", "Staged changes are unchanged").Return().Once()
+	uiManager.On("PromptAction").Return(ui.ActionRefresh, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil).Once()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	// The message is kept and nothing is generated again
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+	uiManager.AssertExpectations(t)
+	gitClient.AssertNumberOfCalls(t, "GetStagedDiff", 2)
+}
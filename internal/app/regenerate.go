@@ -0,0 +1,36 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// escalate applies the regeneration schedule to a request for the final
+// message. Each regeneration raises the temperature by the configured step,
+// up to the cap, and after the configured number of regenerations the
+// escalation model is used. The first attempt keeps the provider's settings.
+func (s *CommitService) escalate(req *ai.GenerateRequest) {
+	if s.config == nil || s.regeneration <= 0 {
+		return
+	}
+	schedule := s.config.Generation.Regenerate
+
+	if schedule.TemperatureStep > 0 {
+		base := s.config.Provider.Temperature
+		if base == 0 {
+			base = ai.DefaultTemperature
+		}
+		temperature := base + schedule.TemperatureStep*float32(s.regeneration)
+		if schedule.MaxTemperature > 0 && temperature > schedule.MaxTemperature {
+			temperature = max(schedule.MaxTemperature, base)
+		}
+		req.Temperature = temperature
+	}
+
+	if schedule.Model != "" && s.regeneration >= schedule.ModelAfter {
+		req.Model = schedule.Model
+	}
+
+	apperrors.Debug("Regeneration %d: temperature %.2f, model %q", s.regeneration, req.Temperature, req.Model)
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEscalate(t *testing.T) {
+	schedule := config.RegenerateConfig{TemperatureStep: 0.2, MaxTemperature: 1.0, Model: "gpt-4o", ModelAfter: 2}
+
+	tests := []struct {
+		name            string
+		base            float32
+		regeneration    int
+		schedule        config.RegenerateConfig
+		wantTemperature float32
+		wantModel       string
+	}{
+		{"first attempt keeps provider settings", 0.2, 0, schedule, 0, ""},
+		{"first regeneration", 0.2, 1, schedule, 0.4, ""},
+		{"model switch", 0.2, 2, schedule, 0.6, "gpt-4o"},
+		{"capped", 0.2, 10, schedule, 1.0, "gpt-4o"},
+		{"default base temperature", 0, 1, schedule, ai.DefaultTemperature + 0.2, ""},
+		{"base above cap is kept", 1.2, 1, schedule, 1.2, ""},
+		{"escalation off", 0.2, 3, config.RegenerateConfig{}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Provider:   config.ProviderConfig{Temperature: tt.base},
+				Generation: config.GenerationConfig{Regenerate: tt.schedule},
+			}
+			service := NewCommitService(nil, nil, nil, nil, nil, cfg)
+			service.regeneration = tt.regeneration
+
+			req := &ai.GenerateRequest{}
+			service.escalate(req)
+
+			assert.InDelta(t, tt.wantTemperature, req.Temperature, 1e-6)
+			assert.Equal(t, tt.wantModel, req.Model)
+		})
+	}
+}
+
+func TestGenerateAndCommit_RegenerateEscalates(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		Provider: config.ProviderConfig{Temperature: 0.2},
+		Generation: config.GenerationConfig{
+			Regenerate: config.RegenerateConfig{TemperatureStep: 0.3, MaxTemperature: 1.0},
+		},
+	}
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+	chunks := []git.DiffChunk{{FilePath: "main.go", ChangeType: git.ChangeTypeModified, Content: "diff"}}
+	first := &ai.GenerateResponse{Subject: "feat: add a", RawText: "feat: add a"}
+	second := &ai.GenerateResponse{Subject: "feat: add b", RawText: "feat: add b"}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Temperature == 0
+	})).Return(first, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Temperature > 0.49 && req.Temperature < 0.51
+	})).Return(second, nil).Once()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", first).Return(nil)
+	uiManager.On("DisplayComparison", first, second).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil).Once()
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai/aitest"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replayChunks returns the changes of the replay fixture: a small fix, or a
+// refactoring of four 3KB files, too large for one request.
+func replayChunks(large bool) []git.DiffChunk {
+	if !large {
+		return []git.DiffChunk{{
+			FilePath:   "auth/token.go",
+			ChangeType: git.ChangeTypeModified,
+			Content:    "@@ -10,3 +10,3 @@\n-\tif expired {\n+\tif expired || revoked {\n",
+			Additions:  1,
+			Deletions:  1,
+		}}
+	}
+
+	var chunks []git.DiffChunk
+	for _, name := range []string{"store/cache.go", "store/disk.go", "store/memory.go", "store/store.go"} {
+		var content strings.Builder
+		for i := 0; content.Len() < 3*1024; i++ {
+			fmt.Fprintf(&content, "+func (s *Store) Get%d(key string) (Entry, bool) { return s.backend.Get(key) }\n", i)
+		}
+		chunks = append(chunks, git.DiffChunk{
+			FilePath:   name,
+			ChangeType: git.ChangeTypeModified,
+			Content:    content.String(),
+			Additions:  strings.Count(content.String(), "\n"),
+		})
+	}
+	return chunks
+}
+
+// TestGenerateMessage_Replay generates messages end to end, from the diff
+// processing to the parsed reply, against responses recorded from Ollama.
+// Changes to the requests sent require recording testdata/replay.jsonl
+// again with GITSAGE_RECORD.
+func TestGenerateMessage_Replay(t *testing.T) {
+	provider := aitest.Replay(t, filepath.Join("testdata", "replay.jsonl"))
+	service := NewCommitService(nil, provider, processor.NewProcessor(), ui.NewSilentManager(), nil, &config.Config{})
+
+	response, err := service.GenerateMessage(context.Background(), &MessageRequest{Chunks: replayChunks(false)})
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): reject revoked tokens", response.Subject)
+
+	// Summarized per file before the message is generated
+	response, err = service.GenerateMessage(context.Background(), &MessageRequest{Chunks: replayChunks(true)})
+	require.NoError(t, err)
+	assert.Equal(t, "refactor(store): route lookups through the backend", response.Subject)
+}
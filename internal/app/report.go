@@ -0,0 +1,207 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// MaxReportCommits is the maximum number of commits sent to the AI for a work report.
+const MaxReportCommits = 200
+
+// DefaultReportSince is the start of the period a work report covers by default.
+const DefaultReportSince = "yesterday"
+
+// ReportAuthorMe stands for the configured git user.email of each repository.
+const ReportAuthorMe = "me"
+
+// ReportOptions contains options for the work report workflow.
+type ReportOptions struct {
+	// Since is the start of the period, in any form "git log --since"
+	// understands; empty uses DefaultReportSince.
+	Since string
+	// Author selects the commits like "git log --author"; ReportAuthorMe or
+	// empty uses each repository's user.email.
+	Author string
+	// Repos are the repositories the commits are gathered from; empty uses
+	// the current repository.
+	Repos []ReportRepo
+	// OutputFile receives the report instead of stdout.
+	OutputFile string
+}
+
+// ReportRepo is a repository a work report gathers commits from.
+type ReportRepo struct {
+	// Name is the project name the commits are grouped under; empty uses the
+	// name of the repository's root directory.
+	Name string
+	Git  git.Client
+}
+
+// reportCommit is a commit in a work report.
+type reportCommit struct {
+	project string
+	scope   string
+	commit  git.AuthoredCommit
+}
+
+// GenerateReport gathers the author's commits since opts.Since across the
+// repositories, groups them by project and scope, and has the AI write a
+// Markdown work report from them for stand-ups or timesheets.
+func (s *CommitService) GenerateReport(ctx context.Context, opts *ReportOptions) error {
+	if opts == nil {
+		opts = &ReportOptions{}
+	}
+	since := opts.Since
+	if since == "" {
+		since = DefaultReportSince
+	}
+	repos := opts.Repos
+	if len(repos) == 0 {
+		repos = []ReportRepo{{Git: s.gitClient}}
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("report.spinner.collecting"))
+	spinner.Start()
+	commits, err := s.collectReportCommits(ctx, repos, since, opts.Author)
+	spinner.Stop()
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("no commits since %s", since))
+	}
+
+	confirmed, err := s.confirmPendingPayload(func() string {
+		return i18n.T("payload.commits", s.aiProvider.Name(), min(len(commits), MaxReportCommits))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to confirm payload: %w", err)
+	}
+	if !confirmed {
+		s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+		return nil
+	}
+
+	if err := s.checkProviderHealth(ctx); err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+	spinner = s.uiManager.ShowSpinner(i18n.T("report.spinner.generating"))
+	spinner.Start()
+	report, err := s.summarize(ctx, buildReportPrompt(since, commits))
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+	if report == "" {
+		return fmt.Errorf("failed to generate report: the AI returned an empty report")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if opts.OutputFile != "" {
+		if err := writeFile(opts.OutputFile, []byte(report+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write to file %s: %w", opts.OutputFile, err)
+		}
+		s.uiManager.ShowSuccess(i18n.T("report.success.written", opts.OutputFile))
+		return nil
+	}
+	s.uiManager.ShowInfo(report)
+	return nil
+}
+
+// collectReportCommits returns the author's commits in each repository,
+// sorted by project and scope, most recent first within a scope.
+func (s *CommitService) collectReportCommits(ctx context.Context, repos []ReportRepo, since, author string) ([]reportCommit, error) {
+	var commits []reportCommit
+	for _, repo := range repos {
+		name := repo.Name
+		if name == "" {
+			root, err := repo.Git.GetRepoRoot(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find repository root: %w", err)
+			}
+			name = filepath.Base(root)
+		}
+
+		repoAuthor := author
+		if repoAuthor == "" || repoAuthor == ReportAuthorMe {
+			email, err := repo.Git.GetUserEmail(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read user.email of %s: %w", name, err)
+			}
+			if email == "" {
+				return nil, apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("user.email is not set in %s; pass --author", name))
+			}
+			repoAuthor = email
+		}
+
+		authored, err := repo.Git.GetAuthoredCommits(ctx, since, repoAuthor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commits of %s: %w", name, err)
+		}
+		for _, commit := range authored {
+			commits = append(commits, reportCommit{
+				project: name,
+				scope:   message.NewCommitMessage(commit.Message).Scope,
+				commit:  commit,
+			})
+		}
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		if commits[i].project != commits[j].project {
+			return commits[i].project < commits[j].project
+		}
+		return commits[i].scope < commits[j].scope
+	})
+	return commits, nil
+}
+
+// buildReportPrompt builds the prompt asking for a Markdown work report
+// from the commits, listed by project and scope.
+func buildReportPrompt(since string, commits []reportCommit) string {
+	var list strings.Builder
+	var project, scope string
+	for i, c := range commits {
+		if i == MaxReportCommits {
+			list.WriteString(fmt.Sprintf("... and %d more commits\n", len(commits)-MaxReportCommits))
+			break
+		}
+		newProject := i == 0 || c.project != project
+		if newProject {
+			project = c.project
+			list.WriteString(fmt.Sprintf("Project %s:\n", project))
+		}
+		if newProject || c.scope != scope {
+			scope = c.scope
+			label := scope
+			if label == "" {
+				label = "(no scope)"
+			}
+			list.WriteString(fmt.Sprintf("  %s:\n", label))
+		}
+		subject, _, _ := strings.Cut(c.commit.Message, "\n")
+		list.WriteString(fmt.Sprintf("    - %s (%s)\n", subject, c.commit.Date.Format("2006-01-02")))
+	}
+
+	return fmt.Sprintf(`You are writing a developer's work report covering their commits since %s.
+
+[[COMMITS BY PROJECT AND SCOPE]]
+%s
+[[INSTRUCTION]]
+Write the report in Markdown, suitable for a stand-up or a timesheet.
+Use one "## " heading per project and "- " bullets under it, merging related commits into one bullet that describes the outcome rather than the code.
+Mention fixes and features before maintenance work, and leave out trivial changes such as formatting.
+Do not invent work that is not in the list. Output only the report.`,
+		since, list.String())
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func newReportService(t *testing.T, gitClient *MockGitClient) (*CommitService, *MockAIProvider, *MockUIManager) {
+	t.Helper()
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	return NewCommitService(gitClient, aiProvider, nil, uiManager, nil, &config.Config{}), aiProvider, uiManager
+}
+
+func TestGenerateReport(t *testing.T) {
+	day := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	t.Run("groups commits by project and scope", func(t *testing.T) {
+		gitClient := &MockGitClient{RepoRoot: "/src/api"}
+		gitClient.On("GetUserEmail", mock.Anything).Return("me@example.com", nil)
+		gitClient.On("GetAuthoredCommits", mock.Anything, "yesterday", "me@example.com").Return([]git.AuthoredCommit{
+			{Hash: "c3", Date: day, Message: "fix(auth): refresh expired tokens"},
+			{Hash: "c2", Date: day, Message: "docs: update readme"},
+			{Hash: "c1", Date: day, Message: "feat(auth): add login\n\nBody text."},
+		}, nil)
+		other := &MockGitClient{}
+		other.On("GetUserEmail", mock.Anything).Return("me@example.com", nil)
+		other.On("GetAuthoredCommits", mock.Anything, "yesterday", "me@example.com").Return([]git.AuthoredCommit{
+			{Hash: "d1", Date: day, Message: "feat(ui): dark mode"},
+		}, nil)
+
+		service, aiProvider, uiManager := newReportService(t, gitClient)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return strings.Contains(req.CustomPrompt, "commits since yesterday") &&
+				strings.Contains(req.CustomPrompt, "Project api:\n  (no scope):\n    - docs: update readme (2025-06-02)\n"+
+					"  auth:\n    - fix(auth): refresh expired tokens (2025-06-02)\n    - feat(auth): add login (2025-06-02)\n"+
+					"Project web:\n  ui:\n    - feat(ui): dark mode (2025-06-02)\n")
+		})).Return(&ai.GenerateResponse{RawText: "## api\n- Login with token refresh"}, nil).Once()
+		uiManager.On("ShowInfo", "## api\n- Login with token refresh").Return().Once()
+
+		err := service.GenerateReport(context.Background(), &ReportOptions{
+			Repos: []ReportRepo{{Name: "web", Git: other}, {Git: gitClient}},
+		})
+		require.NoError(t, err)
+		aiProvider.AssertExpectations(t)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("explicit author", func(t *testing.T) {
+		gitClient := &MockGitClient{RepoRoot: "/src/api"}
+		gitClient.On("GetAuthoredCommits", mock.Anything, "1 week ago", "alice").Return([]git.AuthoredCommit{
+			{Hash: "c1", Date: day, Message: "fix: typo"},
+		}, nil)
+		service, aiProvider, uiManager := newReportService(t, gitClient)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{RawText: "- Fixed a typo"}, nil)
+		uiManager.On("ShowInfo", mock.Anything).Return()
+
+		require.NoError(t, service.GenerateReport(context.Background(), &ReportOptions{Since: "1 week ago", Author: "alice"}))
+		gitClient.AssertNotCalled(t, "GetUserEmail", mock.Anything)
+	})
+
+	t.Run("writes the report to a file", func(t *testing.T) {
+		gitClient := &MockGitClient{RepoRoot: "/src/api"}
+		gitClient.On("GetUserEmail", mock.Anything).Return("me@example.com", nil)
+		gitClient.On("GetAuthoredCommits", mock.Anything, mock.Anything, mock.Anything).Return([]git.AuthoredCommit{
+			{Hash: "c1", Date: day, Message: "fix: typo"},
+		}, nil)
+		service, aiProvider, uiManager := newReportService(t, gitClient)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{RawText: "- Fixed a typo"}, nil)
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		path := filepath.Join(t.TempDir(), "report.md")
+
+		require.NoError(t, service.GenerateReport(context.Background(), &ReportOptions{OutputFile: path}))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "- Fixed a typo\n", string(data))
+		uiManager.AssertNotCalled(t, "ShowInfo", mock.Anything)
+	})
+
+	t.Run("user.email not set", func(t *testing.T) {
+		gitClient := &MockGitClient{RepoRoot: "/src/api"}
+		gitClient.On("GetUserEmail", mock.Anything).Return("", nil)
+		service, _, _ := newReportService(t, gitClient)
+
+		err := service.GenerateReport(context.Background(), nil)
+		require.True(t, apperrors.IsAppError(err))
+		assert.Equal(t, apperrors.ErrInvalidArguments, apperrors.GetAppError(err).Code)
+		assert.ErrorContains(t, err, "user.email is not set in api")
+	})
+
+	t.Run("no commits", func(t *testing.T) {
+		gitClient := &MockGitClient{RepoRoot: "/src/api"}
+		gitClient.On("GetAuthoredCommits", mock.Anything, "yesterday", "alice").Return([]git.AuthoredCommit{}, nil)
+		service, _, _ := newReportService(t, gitClient)
+
+		err := service.GenerateReport(context.Background(), &ReportOptions{Author: "alice"})
+		require.True(t, apperrors.IsAppError(err))
+		assert.ErrorContains(t, err, "no commits since yesterday")
+	})
+}
+
+func TestBuildReportPrompt_TruncatesCommits(t *testing.T) {
+	commits := make([]reportCommit, MaxReportCommits+5)
+	for i := range commits {
+		commits[i] = reportCommit{project: "api", commit: git.AuthoredCommit{Message: "fix: x"}}
+	}
+
+	prompt := buildReportPrompt("yesterday", commits)
+	assert.Equal(t, MaxReportCommits, strings.Count(prompt, "    - fix: x"))
+	assert.Contains(t, prompt, "... and 5 more commits")
+}
@@ -0,0 +1,62 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// checkRepoState refuses to commit in the middle of an operation git has
+// stopped in, or adapts to it. During a rebase, cherry-pick, revert or merge
+// the accepted message is written where the operation's continue command
+// reads it, instead of being committed on top of the operation. A bisect,
+// "git am" or apply-backend rebase takes no message, so nothing is generated.
+// Dry runs commit nothing and are always allowed.
+func (s *CommitService) checkRepoState(ctx context.Context, opts *CommitOptions) error {
+	if opts.DryRun {
+		return nil
+	}
+	gitDir, err := s.gitClient.GetGitDir(ctx)
+	if err != nil || gitDir == "" {
+		apperrors.Debug("Repository state unknown: %v", err)
+		return nil
+	}
+
+	state := git.DetectState(gitDir)
+	if state == git.StateNone {
+		return nil
+	}
+	continueCmd := git.ContinueCommand(state)
+
+	messageFile := git.MessageFile(gitDir, state)
+	if messageFile == "" {
+		return apperrors.New(apperrors.ErrInvalidArguments,
+			fmt.Sprintf("git %s is in progress and takes no commit message; run '%s' before using gitsage", state, continueCmd))
+	}
+	// Squashing and splitting make commits of their own, which would land in
+	// the middle of the operation
+	if opts.SquashBase != "" || opts.Split {
+		return apperrors.New(apperrors.ErrInvalidArguments,
+			fmt.Sprintf("git %s is in progress; run '%s' before squashing or splitting commits", state, continueCmd))
+	}
+
+	s.messageFile = messageFile
+	s.continueCmd = continueCmd
+	s.uiManager.ShowInfo(i18n.T("commit.info.in_progress", string(state), continueCmd))
+	return nil
+}
+
+// writeOperationMessage hands the message to the operation in progress.
+func (s *CommitService) writeOperationMessage(commitMsg string) error {
+	if err := writeFile(s.messageFile, []byte(commitMsg+"\n"), 0644); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to write the message to "+s.messageFile)
+	}
+	s.clearRecovery()
+	s.accepted = true
+	s.uiManager.ShowSuccess(i18n.T("commit.success.operation_message", s.continueCmd))
+	return nil
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndCommit_RebaseInProgress(t *testing.T) {
+	service, gitClient, aiProvider, uiManager, _ := newRecoveryTestService(t)
+	require.NoError(t, os.Mkdir(filepath.Join(gitClient.GitDir, "rebase-merge"), 0755))
+
+	response := &ai.GenerateResponse{Subject: "fix(auth): refresh expired tokens", RawText: "fix(auth): refresh expired tokens"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowInfo", "git rebase is in progress: the message will be written for 'git rebase --continue' instead of being committed").Return().Once()
+
+	require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+
+	// The message goes where "git rebase --continue" reads it, nothing is committed
+	data, err := os.ReadFile(filepath.Join(gitClient.GitDir, "rebase-merge", "message"))
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): refresh expired tokens\n", string(data))
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+	gitClient.AssertNotCalled(t, "HasRemote", mock.Anything)
+	uiManager.AssertCalled(t, "ShowSuccess", "Message saved; run 'git rebase --continue' to commit it")
+	assert.NoFileExists(t, filepath.Join(gitClient.GitDir, RecoveryFileName))
+}
+
+func TestGenerateAndCommit_CherryPickInProgress(t *testing.T) {
+	service, gitClient, aiProvider, uiManager, _ := newRecoveryTestService(t)
+	require.NoError(t, os.WriteFile(filepath.Join(gitClient.GitDir, "CHERRY_PICK_HEAD"), []byte("abc\n"), 0644))
+
+	response := &ai.GenerateResponse{Subject: "fix(auth): refresh expired tokens", RawText: "fix(auth): refresh expired tokens"}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowInfo", mock.Anything).Return()
+
+	require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+
+	data, err := os.ReadFile(filepath.Join(gitClient.GitDir, "MERGE_MSG"))
+	require.NoError(t, err)
+	assert.Equal(t, "fix(auth): refresh expired tokens\n", string(data))
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
+
+func TestGenerateAndCommit_Refused(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		opts  *CommitOptions
+		error string
+	}{
+		{name: "bisect", file: "BISECT_LOG", opts: &CommitOptions{}, error: "run 'git bisect reset'"},
+		{name: "am", file: "rebase-apply/applying", opts: &CommitOptions{}, error: "git am is in progress"},
+		{name: "split during rebase", file: "rebase-merge/message", opts: &CommitOptions{Split: true}, error: "before squashing or splitting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitClient := &MockGitClient{GitDir: t.TempDir()}
+			path := filepath.Join(gitClient.GitDir, tt.file)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, nil, 0644))
+			service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, nil)
+
+			// Nothing is generated for a message that cannot be used
+			err := service.GenerateAndCommit(context.Background(), tt.opts)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+}
+
+func TestGenerateAndCommit_DryRunDuringBisect(t *testing.T) {
+	gitClient := &MockGitClient{GitDir: t.TempDir()}
+	require.NoError(t, os.WriteFile(filepath.Join(gitClient.GitDir, "BISECT_LOG"), nil, 0644))
+	service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, nil)
+
+	assert.NoError(t, service.checkRepoState(context.Background(), &CommitOptions{DryRun: true}))
+	assert.Empty(t, service.messageFile)
+}
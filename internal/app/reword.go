@@ -0,0 +1,110 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// RewordSuggestion is a message suggested in place of the message of an
+// existing commit.
+type RewordSuggestion struct {
+	Hash string
+	// Message is the suggested message; empty if Err is set.
+	Message string
+	Err     error
+}
+
+// SuggestRewords generates a Conventional Commits message for each commit
+// from its diff, with its current message as the author's intent. Nothing is
+// rewritten. A commit without a suggestion, such as a root commit, has the
+// reason in its Err; only a canceled context fails the whole run. If the
+// user declines sending the commits, there are no suggestions.
+func (s *CommitService) SuggestRewords(ctx context.Context, commits []git.RangeCommit) ([]RewordSuggestion, error) {
+	confirmed, err := s.confirmPendingPayload(func() string {
+		return i18n.T("payload.commit_diffs", s.aiProvider.Name(), len(commits))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm payload: %w", err)
+	}
+	if !confirmed {
+		s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+		return nil, nil
+	}
+
+	if err := s.checkProviderHealth(ctx); err != nil {
+		return nil, fmt.Errorf("failed to suggest messages: %w", err)
+	}
+
+	var suggestions []RewordSuggestion
+	for _, commit := range commits {
+		suggestion := RewordSuggestion{Hash: commit.Hash}
+		suggestion.Message, suggestion.Err = s.suggestReword(ctx, commit)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+// suggestReword generates a message for one commit and checks that it is a
+// valid one.
+func (s *CommitService) suggestReword(ctx context.Context, commit git.RangeCommit) (string, error) {
+	chunks, err := s.gitClient.GetCommitDiff(ctx, commit.Hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	response, err := s.GenerateMessage(ctx, &MessageRequest{
+		Chunks:  chunks,
+		Context: "The commit's current message, rewritten to follow Conventional Commits:\n" + commit.Message,
+		NoCache: true,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	suggested := s.formatCommitMessage(response)
+	if err := message.NewCommitMessage(suggested).Validate(); err != nil {
+		return "", fmt.Errorf("the suggested message is not valid either: %w", err)
+	}
+	return suggested, nil
+}
+
+// RewordPlan returns a shell script that adds an "amend!" commit for each
+// suggestion, for "git rebase -i --autosquash" to reword the commits with.
+// Suggestions without a message are left out; the script is empty if none
+// has one. Running it changes no files: the commits are empty.
+func RewordPlan(suggestions []RewordSuggestion) string {
+	var plan strings.Builder
+	oldest := ""
+	for _, suggestion := range suggestions {
+		if suggestion.Message == "" {
+			continue
+		}
+		if oldest == "" {
+			oldest = suggestion.Hash
+		}
+		// Autosquash finds the commit by the hash after "amend!", and
+		// rewords it with the rest of the message
+		fmt.Fprintf(&plan, "\ngit commit --quiet --allow-empty --only --no-verify -F - <<'GITSAGE_EOF'\namend! %s\n\n%s\nGITSAGE_EOF\n",
+			suggestion.Hash, suggestion.Message)
+	}
+	if oldest == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+# Rewords suggested by gitsage lint-history. Review the messages, run this
+# script to add an "amend!" commit for each, then apply them with:
+#
+#   git rebase -i --autosquash %s^
+set -e
+%s`, oldest, plan.String())
+}
@@ -0,0 +1,62 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestSuggestRewords(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, ui.NewSilentManager(), nil, nil)
+
+	chunks := []git.DiffChunk{{FilePath: "api/users.go", ChangeType: git.ChangeTypeModified, Content: "diff", Additions: 4}}
+	gitClient.On("GetCommitDiff", mock.Anything, "aaa").Return(chunks, nil)
+	gitClient.On("GetCommitDiff", mock.Anything, "bbb").Return(nil, errors.New("unknown revision bbb^"))
+	gitClient.On("GetCommitDiff", mock.Anything, "ccc").Return(chunks, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.HasSuffix(req.Context, "\npaginate users")
+	})).Return(&ai.GenerateResponse{Subject: "feat(api): paginate users", Body: "- api/users.go: add page parameter"}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "still not conventional"}, nil)
+
+	suggestions, err := service.SuggestRewords(context.Background(), []git.RangeCommit{
+		{Hash: "aaa", Message: "paginate users"},
+		{Hash: "bbb", Message: "initial"},
+		{Hash: "ccc", Message: "wip"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 3)
+	assert.Equal(t, RewordSuggestion{Hash: "aaa", Message: "feat(api): paginate users\n\n- api/users.go: add page parameter"}, suggestions[0])
+	assert.ErrorContains(t, suggestions[1].Err, "unknown revision")
+	assert.ErrorContains(t, suggestions[2].Err, "not valid")
+	assert.Empty(t, suggestions[2].Message)
+}
+
+func TestRewordPlan(t *testing.T) {
+	assert.Empty(t, RewordPlan([]RewordSuggestion{{Hash: "aaa", Err: errors.New("failed")}}))
+
+	plan := RewordPlan([]RewordSuggestion{
+		{Hash: "aaa", Err: errors.New("failed")},
+		{Hash: "bbb", Message: "fix: b"},
+		{Hash: "ccc", Message: "feat: c\n\n- c"},
+	})
+
+	assert.Contains(t, plan, "git rebase -i --autosquash bbb^\n")
+	assert.Contains(t, plan, "<<'GITSAGE_EOF'\namend! bbb\n\nfix: b\nGITSAGE_EOF\n")
+	assert.Contains(t, plan, "<<'GITSAGE_EOF'\namend! ccc\n\nfeat: c\n\n- c\nGITSAGE_EOF\n")
+	assert.NotContains(t, plan, "aaa")
+}
@@ -0,0 +1,205 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// Risk levels of a commit, from the points its risk factors add up to.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// RiskFooterToken is the token of the footer recording the risk level.
+const RiskFooterToken = "Risk"
+
+// DefaultCriticalPatterns are path globs of files whose changes are risky
+// beyond their size: database migrations and schemas, and build and
+// dependency manifests. Security-sensitive files count as critical too.
+var DefaultCriticalPatterns = []string{
+	"migrations/**",
+	"migrate/**",
+	"*.sql",
+	"go.mod",
+	"package.json",
+	"Makefile",
+	"*.tf",
+}
+
+// testPatterns are path globs of test files.
+var testPatterns = []string{
+	"*_test.*",
+	"*.test.*",
+	"*.spec.*",
+	"test_*.py",
+	"*Test.java",
+	"*Tests.cs",
+	"test/**",
+	"tests/**",
+	"__tests__/**",
+	"spec/**",
+}
+
+// codeExtensions are the extensions of source files, whose changes are
+// expected to come with tests.
+var codeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".rb": true, ".rs": true, ".c": true, ".cc": true,
+	".cpp": true, ".h": true, ".cs": true, ".php": true, ".swift": true, ".scala": true,
+}
+
+// riskReport is the risk level of a commit and the factors that raised it.
+type riskReport struct {
+	Level   string
+	Reasons []string
+}
+
+// ValidateRisk returns an error if the risk settings are invalid: a negative
+// threshold, a high threshold below the medium one, or a malformed critical
+// path glob.
+func ValidateRisk(risk config.RiskConfig) error {
+	for _, pair := range []struct {
+		name         string
+		medium, high int
+	}{
+		{"lines", risk.MediumLines, risk.HighLines},
+		{"files", risk.MediumFiles, risk.HighFiles},
+	} {
+		if pair.medium < 0 || pair.high < 0 {
+			return fmt.Errorf("medium_%s and high_%s must not be negative", pair.name, pair.name)
+		}
+		if pair.medium > 0 && pair.high > 0 && pair.high < pair.medium {
+			return fmt.Errorf("high_%s (%d) is below medium_%s (%d)", pair.name, pair.high, pair.name, pair.medium)
+		}
+	}
+	for _, pattern := range risk.CriticalPaths {
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/**"), ""); err != nil {
+			return fmt.Errorf("invalid critical path %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// assessRisk estimates how risky the commit is to review. Crossing the medium
+// or high size threshold, in lines or in files, adds one or two points;
+// changing a critical file and changing code without tests add one each.
+// One or two points are a medium risk, three or more a high one. Returns nil
+// when the risk is disabled.
+func (s *CommitService) assessRisk(chunks []git.DiffChunk) *riskReport {
+	if s.config == nil || (!s.config.Risk.Enabled && !s.config.Risk.Footer) {
+		return nil
+	}
+	risk := s.config.Risk
+
+	lines, files := 0, 0
+	var critical []string
+	code, tests := false, false
+	criticalPatterns := append(append(append([]string{}, DefaultCriticalPatterns...), DefaultSensitivePatterns...), risk.CriticalPaths...)
+	for _, chunk := range chunks {
+		files++
+		// Generated files are summarized, not reviewed line by line
+		if !chunk.IsGenerated {
+			lines += chunk.Additions + chunk.Deletions
+		}
+		if matchesAny(criticalPatterns, chunk.FilePath) {
+			critical = append(critical, chunk.FilePath)
+		}
+		switch {
+		case matchesAny(testPatterns, chunk.FilePath):
+			tests = true
+		case codeExtensions[path.Ext(chunk.FilePath)] && chunk.ChangeType != git.ChangeTypeDeleted:
+			code = true
+		}
+	}
+
+	report := &riskReport{}
+	points := 0
+	if level := thresholdPoints(lines, risk.MediumLines, risk.HighLines); level > 0 {
+		points += level
+		report.Reasons = append(report.Reasons, i18n.T("risk.lines", lines))
+	}
+	if level := thresholdPoints(files, risk.MediumFiles, risk.HighFiles); level > 0 {
+		points += level
+		report.Reasons = append(report.Reasons, i18n.T("risk.files", files))
+	}
+	if len(critical) > 0 {
+		points++
+		report.Reasons = append(report.Reasons, i18n.T("risk.critical", strings.Join(critical, ", ")))
+	}
+	if code && !tests {
+		points++
+		report.Reasons = append(report.Reasons, i18n.T("risk.untested"))
+	}
+
+	switch {
+	case points >= 3:
+		report.Level = RiskHigh
+	case points > 0:
+		report.Level = RiskMedium
+	default:
+		report.Level = RiskLow
+	}
+	return report
+}
+
+// thresholdPoints returns 2 if value reaches the high threshold, 1 if it
+// reaches the medium one and 0 otherwise. A zero threshold is disabled.
+func thresholdPoints(value, medium, high int) int {
+	switch {
+	case high > 0 && value >= high:
+		return 2
+	case medium > 0 && value >= medium:
+		return 1
+	}
+	return 0
+}
+
+// matchesAny reports whether filePath matches one of the patterns, as
+// processor.MatchPattern does.
+func matchesAny(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		if processor.MatchPattern(pattern, filePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// showRisk displays the risk level and what raised it, when risk.enabled is set.
+func (s *CommitService) showRisk(report *riskReport) {
+	if report == nil || !s.config.Risk.Enabled {
+		return
+	}
+	if len(report.Reasons) == 0 {
+		s.uiManager.ShowInfo(i18n.T("commit.info.risk", report.Level))
+		return
+	}
+	s.uiManager.ShowInfo(i18n.T("commit.info.risk.reasons", report.Level, strings.Join(report.Reasons, "; ")))
+}
+
+// applyRiskFooter adds a "Risk: <level>" footer to the message when
+// risk.footer is set and the message has none yet.
+func (s *CommitService) applyRiskFooter(response *ai.GenerateResponse, chunks []git.DiffChunk) *ai.GenerateResponse {
+	if s.config == nil || !s.config.Risk.Footer {
+		return response
+	}
+	report := s.assessRisk(chunks)
+	msg := message.NewCommitMessage(s.formatCommitMessage(response))
+	for _, footer := range msg.Footers() {
+		if strings.EqualFold(footer.Token, RiskFooterToken) {
+			return response
+		}
+	}
+	return withFooter(msg, RiskFooterToken+": "+report.Level)
+}
@@ -0,0 +1,90 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestAssessRisk(t *testing.T) {
+	risk := config.RiskConfig{Enabled: true, MediumLines: 200, HighLines: 800, MediumFiles: 10, HighFiles: 30}
+	newService := func(risk config.RiskConfig) *CommitService {
+		return NewCommitService(nil, &MockAIProvider{}, nil, &MockUIManager{}, nil, &config.Config{Risk: risk})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, newService(config.RiskConfig{}).assessRisk([]git.DiffChunk{{FilePath: "main.go", Additions: 1000}}))
+	})
+
+	t.Run("small tested change", func(t *testing.T) {
+		report := newService(risk).assessRisk([]git.DiffChunk{
+			{FilePath: "api/token.go", Additions: 10},
+			{FilePath: "api/token_test.go", Additions: 20},
+			{FilePath: "README.md", Additions: 2},
+		})
+		assert.Equal(t, RiskLow, report.Level)
+		assert.Empty(t, report.Reasons)
+	})
+
+	t.Run("untested code", func(t *testing.T) {
+		report := newService(risk).assessRisk([]git.DiffChunk{{FilePath: "api/handler.go", Additions: 10}})
+		assert.Equal(t, &riskReport{Level: RiskMedium, Reasons: []string{"code changed without tests"}}, report)
+	})
+
+	t.Run("large critical change", func(t *testing.T) {
+		chunks := []git.DiffChunk{
+			{FilePath: "db/migrations/001_users.sql", Additions: 500},
+			{FilePath: "api/users_test.go", Additions: 300},
+			{FilePath: "api/gen.pb.go", Additions: 5000, IsGenerated: true},
+		}
+		report := newService(risk).assessRisk(chunks)
+		assert.Equal(t, RiskHigh, report.Level)
+		assert.Equal(t, []string{"800 changed lines", "critical files: db/migrations/001_users.sql"}, report.Reasons)
+	})
+
+	t.Run("many files and configured critical paths", func(t *testing.T) {
+		var chunks []git.DiffChunk
+		for i := 0; i < 12; i++ {
+			chunks = append(chunks, git.DiffChunk{FilePath: fmt.Sprintf("docs/page%d.md", i), Additions: 1})
+		}
+		assert.Equal(t, RiskMedium, newService(risk).assessRisk(chunks).Level)
+
+		withCritical := risk
+		withCritical.CriticalPaths = []string{"docs/**"}
+		report := newService(withCritical).assessRisk(chunks)
+		assert.Equal(t, RiskMedium, report.Level)
+		assert.Len(t, report.Reasons, 2)
+	})
+}
+
+func TestApplyRiskFooter(t *testing.T) {
+	chunks := []git.DiffChunk{{FilePath: "api/handler.go", Additions: 10}}
+	response := &ai.GenerateResponse{Subject: "fix(api): handle empty body", Body: "- api: return 400"}
+
+	service := NewCommitService(nil, &MockAIProvider{}, nil, &MockUIManager{}, nil, &config.Config{Risk: config.RiskConfig{Footer: true}})
+	got := service.applyRiskFooter(response, chunks)
+	assert.Equal(t, "Risk: medium", got.Footer)
+	assert.Equal(t, "fix(api): handle empty body\n\n- api: return 400\n\nRisk: medium", got.RawText)
+
+	// A message that records its risk already is kept
+	assert.Same(t, got, service.applyRiskFooter(got, chunks))
+
+	service = NewCommitService(nil, &MockAIProvider{}, nil, &MockUIManager{}, nil, &config.Config{Risk: config.RiskConfig{Enabled: true}})
+	assert.Same(t, response, service.applyRiskFooter(response, chunks))
+}
+
+func TestValidateRisk(t *testing.T) {
+	assert.NoError(t, ValidateRisk(config.RiskConfig{}))
+	assert.NoError(t, ValidateRisk(config.RiskConfig{MediumLines: 200, HighLines: 800, CriticalPaths: []string{"db/**", "*.sql"}}))
+	assert.NoError(t, ValidateRisk(config.RiskConfig{HighFiles: 5}))
+
+	assert.ErrorContains(t, ValidateRisk(config.RiskConfig{MediumLines: -1}), "negative")
+	assert.ErrorContains(t, ValidateRisk(config.RiskConfig{MediumFiles: 30, HighFiles: 10}), "high_files (10) is below medium_files (30)")
+	assert.ErrorContains(t, ValidateRisk(config.RiskConfig{CriticalPaths: []string{"[db"}}), "invalid critical path")
+}
@@ -0,0 +1,229 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/history"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// Score modes for grading accepted messages.
+const (
+	ScoreHeuristic = "heuristic"
+	ScoreModel     = "model"
+	ScoreOff       = "off"
+)
+
+// ParseScoreMode parses a score mode. An empty name yields ScoreHeuristic.
+func ParseScoreMode(name string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(name)); mode {
+	case "":
+		return ScoreHeuristic, nil
+	case ScoreHeuristic, ScoreModel, ScoreOff:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown score mode %q (valid: heuristic, model, off)", name)
+	}
+}
+
+// scoreMode returns the configured score mode.
+func (s *CommitService) scoreMode() string {
+	if s.config == nil {
+		return ScoreHeuristic
+	}
+	// Invalid modes are rejected when the config is loaded
+	mode, err := ParseScoreMode(s.config.Generation.Score)
+	if err != nil {
+		return ScoreHeuristic
+	}
+	return mode
+}
+
+// Subject lengths, in characters of the formatted subject, that score full
+// marks for length.
+const (
+	minGoodSubject = 15
+	maxGoodSubject = 72
+)
+
+// vagueWords say nothing about what a change does; a description made of
+// them alone is not specific.
+var vagueWords = map[string]bool{
+	"update": true, "updates": true, "updated": true, "change": true, "changes": true, "changed": true,
+	"fix": true, "fixes": true, "fixed": true, "bug": true, "bugs": true, "issue": true, "issues": true,
+	"misc": true, "stuff": true, "wip": true, "minor": true, "small": true, "some": true, "various": true,
+	"improve": true, "improvements": true, "tweak": true, "tweaks": true, "cleanup": true, "refactor": true,
+	"code": true, "file": true, "files": true, "things": true, "the": true, "a": true, "and": true,
+	"更新": true, "修改": true, "修复": true, "问题": true, "优化": true, "代码": true, "调整": true, "杂项": true,
+}
+
+// scoreMessage grades the accepted message for the history, or returns nil
+// when scoring is off. Model scoring falls back to the heuristic when the
+// reply cannot be read.
+func (s *CommitService) scoreMessage(ctx context.Context, commitMsg string, chunks []git.DiffChunk) *history.Score {
+	switch s.scoreMode() {
+	case ScoreOff:
+		return nil
+	case ScoreModel:
+		score, err := s.modelScore(ctx, commitMsg, chunks)
+		if err == nil {
+			return score
+		}
+		apperrors.Warn("Failed to score the message with the model, using the heuristic: %v", err)
+	}
+	return heuristicScore(commitMsg, chunks)
+}
+
+// heuristicScore grades a message with local checks: a description that
+// names what changed, a type matching the kind of files changed and a subject
+// of readable length.
+func heuristicScore(commitMsg string, chunks []git.DiffChunk) *history.Score {
+	msg := message.NewCommitMessage(commitMsg)
+	score := &history.Score{
+		Method:      ScoreHeuristic,
+		Specificity: specificityScore(msg, chunks),
+		Type:        typeScore(msg, chunks),
+		Length:      lengthScore(msg),
+	}
+	score.Total = (score.Specificity + score.Type + score.Length) / 3
+	return score
+}
+
+// specificityScore penalizes short and vague descriptions, descriptions that
+// name none of the changed files or directories, and larger changes without
+// a body.
+func specificityScore(msg *message.CommitMessage, chunks []git.DiffChunk) int {
+	score := 100
+	description := strings.ToLower(strings.TrimRight(msg.Subject, ".。"))
+	if utf8.RuneCountInString(description) < 10 {
+		score -= 30
+	}
+
+	vague := true
+	for _, word := range strings.Fields(description) {
+		if !vagueWords[word] {
+			vague = false
+			break
+		}
+	}
+	if vague {
+		score -= 50
+	}
+
+	if len(chunks) > 0 && !mentionsChange(strings.ToLower(msg.Scope+" "+description), chunks) {
+		score -= 20
+	}
+	if len(chunks) > 3 && !msg.HasBody() {
+		score -= 20
+	}
+	return max(score, 0)
+}
+
+// mentionsChange reports whether text names one of the changed files or the
+// directories holding them.
+func mentionsChange(text string, chunks []git.DiffChunk) bool {
+	for _, chunk := range chunks {
+		file := strings.ToLower(chunk.FilePath)
+		names := []string{strings.TrimSuffix(path.Base(file), path.Ext(file))}
+		for dir := path.Dir(file); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			names = append(names, path.Base(dir))
+		}
+		for _, name := range names {
+			if len(name) > 2 && strings.Contains(text, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeScore gives no marks without a valid type, and partial marks for a
+// type that contradicts the kind of files changed, e.g. "feat" for
+// documentation only.
+func typeScore(msg *message.CommitMessage, chunks []git.DiffChunk) int {
+	if !message.IsValidCommitType(msg.Type) {
+		return 0
+	}
+	if category := ai.CategoryType(chunks); category != "" && category != msg.Type && msg.Type != "chore" {
+		return 40
+	}
+	return 100
+}
+
+// lengthScore gives full marks to subjects of minGoodSubject to
+// maxGoodSubject characters, and takes 5 marks for each body line much
+// longer than a subject.
+func lengthScore(msg *message.CommitMessage) int {
+	score := 100
+	switch length := msg.SubjectLength(); {
+	case length < minGoodSubject:
+		score = length * 100 / minGoodSubject
+	case length > maxGoodSubject:
+		score -= (length - maxGoodSubject) * 3
+	}
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if utf8.RuneCountInString(line) > message.MaxSubjectLength {
+			score -= 5
+		}
+	}
+	return max(score, 0)
+}
+
+// modelScoreRegex matches a criterion and its grade in the reply to the
+// scoring prompt, e.g. "specificity: 80".
+var modelScoreRegex = regexp.MustCompile(`(?i)\b(specificity|type|length)\b\W{0,5}(\d{1,3})`)
+
+// modelScore asks the provider to grade the message against the changed files.
+func (s *CommitService) modelScore(ctx context.Context, commitMsg string, chunks []git.DiffChunk) (*history.Score, error) {
+	var files strings.Builder
+	for _, chunk := range chunks {
+		fmt.Fprintf(&files, "- %s (%s, +%d -%d)\n", chunk.FilePath, chunk.ChangeType, chunk.Additions, chunk.Deletions)
+	}
+	prompt := fmt.Sprintf(`Grade this commit message for the changed files below, from 0 to 100 on each criterion:
+- specificity: the description says what changed and where, not just "update code"
+- type: the Conventional Commits type fits the change
+- length: the subject is concise but complete and the body is not padded
+
+Commit message:
+%s
+
+Changed files:
+%s
+Reply with exactly three lines and nothing else:
+specificity: <0-100>
+type: <0-100>
+length: <0-100>`, commitMsg, files.String())
+
+	reply, err := s.summarize(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	grades := map[string]int{}
+	for _, match := range modelScoreRegex.FindAllStringSubmatch(reply, -1) {
+		grade, _ := strconv.Atoi(match[2])
+		grades[strings.ToLower(match[1])] = min(grade, 100)
+	}
+	if len(grades) != 3 {
+		return nil, fmt.Errorf("unexpected reply %q", reply)
+	}
+
+	score := &history.Score{
+		Method:      ScoreModel,
+		Specificity: grades["specificity"],
+		Type:        grades["type"],
+		Length:      grades["length"],
+	}
+	score.Total = (score.Specificity + score.Type + score.Length) / 3
+	return score, nil
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseScoreMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", ScoreHeuristic, false},
+		{"heuristic", ScoreHeuristic, false},
+		{" Model ", ScoreModel, false},
+		{"off", ScoreOff, false},
+		{"llm", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseScoreMode(tt.input)
+		if tt.wantErr {
+			assert.Error(t, err, tt.input)
+			continue
+		}
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+}
+
+func TestHeuristicScore(t *testing.T) {
+	auth := []git.DiffChunk{{FilePath: "internal/auth/token.go"}}
+	docs := []git.DiffChunk{{FilePath: "README.md"}}
+
+	tests := []struct {
+		name    string
+		message string
+		chunks  []git.DiffChunk
+		want    [3]int // specificity, type, length
+	}{
+		{"specific", "fix(auth): refresh expired tokens before retrying", auth, [3]int{100, 100, 100}},
+		{"vague", "fix: update code", auth, [3]int{30, 100, 100}},
+		{"no type", "Refresh expired tokens in the auth client", auth, [3]int{100, 0, 100}},
+		{"wrong type", "feat: describe the readme install steps", docs, [3]int{100, 40, 100}},
+		{"short", "fix: auth", auth, [3]int{70, 100, 60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := heuristicScore(tt.message, tt.chunks)
+			assert.Equal(t, ScoreHeuristic, score.Method)
+			assert.Equal(t, tt.want, [3]int{score.Specificity, score.Type, score.Length})
+			assert.Equal(t, (tt.want[0]+tt.want[1]+tt.want[2])/3, score.Total)
+		})
+	}
+}
+
+func TestHeuristicScore_LargeChangeWithoutBody(t *testing.T) {
+	chunks := []git.DiffChunk{{FilePath: "auth/a.go"}, {FilePath: "auth/b.go"}, {FilePath: "auth/c.go"}, {FilePath: "auth/d.go"}}
+
+	without := heuristicScore("refactor(auth): split the token client", chunks)
+	with := heuristicScore("refactor(auth): split the token client\n\nMove refreshing into its own type.", chunks)
+	assert.Equal(t, 80, without.Specificity)
+	assert.Equal(t, 100, with.Specificity)
+}
+
+func TestScoreMessage(t *testing.T) {
+	chunks := []git.DiffChunk{{FilePath: "auth.go", ChangeType: git.ChangeTypeModified}}
+	msg := "fix(auth): refresh expired tokens"
+
+	t.Run("off", func(t *testing.T) {
+		service := NewCommitService(&MockGitClient{}, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil,
+			&config.Config{Generation: config.GenerationConfig{Score: ScoreOff}})
+		assert.Nil(t, service.scoreMessage(context.Background(), msg, chunks))
+	})
+
+	t.Run("model", func(t *testing.T) {
+		aiProvider := &MockAIProvider{}
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return req.CustomPrompt != ""
+		})).Return(&ai.GenerateResponse{RawText: "Specificity: 90\nType: 100\nLength: 140"}, nil)
+		service := NewCommitService(&MockGitClient{}, aiProvider, &MockDiffProcessor{}, &MockUIManager{}, nil,
+			&config.Config{Generation: config.GenerationConfig{Score: ScoreModel}})
+
+		score := service.scoreMessage(context.Background(), msg, chunks)
+		assert.Equal(t, ScoreModel, score.Method)
+		assert.Equal(t, [4]int{90, 100, 100, 96}, [4]int{score.Specificity, score.Type, score.Length, score.Total})
+	})
+
+	t.Run("model falls back", func(t *testing.T) {
+		for _, reply := range []struct {
+			resp *ai.GenerateResponse
+			err  error
+		}{
+			{&ai.GenerateResponse{RawText: "Looks good to me"}, nil},
+			{nil, errors.New("rate limited")},
+		} {
+			aiProvider := &MockAIProvider{}
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(reply.resp, reply.err)
+			service := NewCommitService(&MockGitClient{}, aiProvider, &MockDiffProcessor{}, &MockUIManager{}, nil,
+				&config.Config{Generation: config.GenerationConfig{Score: ScoreModel}})
+
+			score := service.scoreMessage(context.Background(), msg, chunks)
+			assert.Equal(t, ScoreHeuristic, score.Method)
+		}
+	})
+}
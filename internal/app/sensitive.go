@@ -0,0 +1,47 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// DefaultSensitivePatterns are path globs of security-sensitive files:
+// authentication and crypto code, container images, CI workflows and IAM
+// policies. Patterns follow processor.MatchPattern.
+var DefaultSensitivePatterns = []string{
+	"auth/**",
+	"*auth*",
+	"crypto/**",
+	"*crypto*",
+	"security/**",
+	"Dockerfile",
+	"*.dockerfile",
+	".github/workflows/**",
+	".gitlab-ci.yml",
+	"Jenkinsfile",
+	"*iam*.json",
+	"*iam*.tf",
+	"*policy*.json",
+}
+
+// sensitiveFiles returns the paths of the chunks that match the default or
+// configured security-sensitive patterns, in chunk order. Renames match by
+// either path. Nothing is returned when security.sensitive_check is off.
+func (s *CommitService) sensitiveFiles(chunks []git.DiffChunk) []string {
+	if s.config == nil || !s.config.Security.SensitiveCheck {
+		return nil
+	}
+
+	patterns := append(append([]string{}, DefaultSensitivePatterns...), s.config.Security.SensitivePatterns...)
+	var files []string
+	for _, chunk := range chunks {
+		for _, pattern := range patterns {
+			if processor.MatchPattern(pattern, chunk.FilePath) || chunk.OldPath != "" && processor.MatchPattern(pattern, chunk.OldPath) {
+				files = append(files, chunk.FilePath)
+				break
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestSensitiveFiles(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "internal/auth/token.go"},
+		{FilePath: "README.md"},
+		{FilePath: "Dockerfile"},
+		{FilePath: ".github/workflows/ci.yml"},
+		{FilePath: "deploy/iam-role.json"},
+		{FilePath: "pkg/hash.go", OldPath: "pkg/crypto_util.go"},
+		{FilePath: "infra/secrets.tf"},
+	}
+
+	enabled := &config.Config{Security: config.SecurityConfig{SensitiveCheck: true}}
+	assert.Equal(t,
+		[]string{"internal/auth/token.go", "Dockerfile", ".github/workflows/ci.yml", "deploy/iam-role.json", "pkg/hash.go"},
+		NewCommitService(nil, nil, nil, nil, nil, enabled).sensitiveFiles(chunks))
+
+	custom := &config.Config{Security: config.SecurityConfig{SensitiveCheck: true, SensitivePatterns: []string{"infra/**"}}}
+	assert.Contains(t, NewCommitService(nil, nil, nil, nil, nil, custom).sensitiveFiles(chunks), "infra/secrets.tf")
+
+	assert.Nil(t, NewCommitService(nil, nil, nil, nil, nil, &config.Config{}).sensitiveFiles(chunks))
+}
+
+func TestGenerateAndCommit_SensitiveFiles(t *testing.T) {
+	newService := func(confirmed bool) (*CommitService, *MockGitClient, *MockAIProvider) {
+		gitClient := &MockGitClient{}
+		aiProvider := &MockAIProvider{}
+		diffProcessor := &MockDiffProcessor{}
+		uiManager := &MockUIManager{}
+		spinner := &MockSpinner{}
+
+		chunks := []git.DiffChunk{{FilePath: "internal/auth/token.go", Content: "+check()"}}
+		gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+		gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+		gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1}, nil)
+		gitClient.On("HasRemote", mock.Anything).Return(false, nil).Maybe()
+		diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks, TotalSize: 8}, nil)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return assert.ObjectsAreEqual([]string{"internal/auth/token.go"}, req.SensitiveFiles)
+		})).Return(&ai.GenerateResponse{Subject: "fix(auth): check tokens", RawText: "fix(auth): check tokens"}, nil).Once()
+		uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+		uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+		uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+		uiManager.On("PromptConfirm", "Security-sensitive files changed: internal/auth/token.go. Commit anyway?").Return(confirmed, nil).Once()
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		spinner.On("Start").Return()
+		spinner.On("Stop").Return()
+
+		cfg := &config.Config{Security: config.SecurityConfig{SensitiveCheck: true}}
+		return NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg), gitClient, aiProvider
+	}
+
+	t.Run("declined", func(t *testing.T) {
+		service, gitClient, aiProvider := newService(false)
+
+		require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+		gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+		aiProvider.AssertExpectations(t)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		service, gitClient, _ := newService(true)
+		gitClient.On("Commit", mock.Anything, "fix(auth): check tokens").Return(nil).Once()
+
+		require.NoError(t, service.GenerateAndCommit(context.Background(), &CommitOptions{}))
+		gitClient.AssertExpectations(t)
+	})
+}
@@ -3,16 +3,22 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gitsage/gitsage/internal/pkg/ai"
 	"github.com/gitsage/gitsage/internal/pkg/cache"
 	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
 	"github.com/gitsage/gitsage/internal/pkg/git"
 	"github.com/gitsage/gitsage/internal/pkg/history"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 	"github.com/gitsage/gitsage/internal/pkg/message"
 	"github.com/gitsage/gitsage/internal/pkg/processor"
 	"github.com/gitsage/gitsage/internal/pkg/ui"
@@ -27,9 +33,6 @@ const MaxRegenerationAttempts = 5
 // MaxGroupSize is the maximum size (in bytes) for a group of files to be summarized together.
 const MaxGroupSize = 4 * 1024 // 4KB per group
 
-// MaxConcurrentGroups is the maximum number of concurrent AI calls.
-const MaxConcurrentGroups = 2
-
 // CommitOptions contains options for the commit workflow.
 type CommitOptions struct {
 	DryRun       bool
@@ -37,6 +40,33 @@ type CommitOptions struct {
 	SkipConfirm  bool
 	CustomPrompt string
 	NoCache      bool
+	ExplainPlan  bool
+	// Intent is the commit type and scope the generated message must use.
+	Intent ai.Intent
+	// Context is the developer's explanation of why the change was made.
+	Context string
+	// Issue is the issue the commit is for, referenced in the footer in the
+	// hosting platform's syntax; empty uses issues.branch_pattern.
+	Issue string
+	// OutputFormat is OutputFormatText, OutputFormatJSON or
+	// OutputFormatGitHub; JSON reports the message and file set of a dry run
+	// as a single document, and GitHub as a workflow command annotation.
+	OutputFormat string
+	// Resume starts from the message saved in RecoveryFileName instead of
+	// generating a new one.
+	Resume bool
+	// SquashBase describes the commits since the branch diverged from this
+	// base as one squashed commit; the git client must diff the same range.
+	SquashBase string
+	// Split makes one commit per package matched by generation.scope_rules,
+	// with the cross-package changes committed last.
+	Split bool
+	// StatsOnly sends only the changed paths, change types and line counts
+	// to the AI, as generation.stats_only does.
+	StatsOnly bool
+	// ShowPayload shows the files, sizes and redactions of what is sent to
+	// the AI and, unless SkipConfirm is set, asks before sending it.
+	ShowPayload bool
 }
 
 // CommitService orchestrates the commit message generation workflow.
@@ -48,6 +78,37 @@ type CommitService struct {
 	historyMgr    history.Manager
 	config        *config.Config
 	cache         cache.Manager
+	preset        ai.Preset
+	statsOnly     bool
+	critic        ai.Provider
+	recoveryFile  string
+	messageFile   string
+	continueCmd   string
+	healthOnce    sync.Once
+	healthErr     error
+	pullOffered   bool
+	examples      []ai.Example
+	regeneration  int
+	squashed      []string
+	unstaged      []string
+	stack         string
+	sensitive     []string
+	ownerAreas    []string
+	areaScope     string
+	accepted      bool
+	deferPush     bool
+	summaries     summaryCache
+	usage         usageRecorder
+	compare       []CompareProvider
+	candidates    []candidate
+	compared      []string
+	model         string
+	stopRequested atomic.Bool
+	rateLimits    atomic.Int32
+	redactOnce    sync.Once
+	redact        func(string) string
+	// payloadPending is set until the first payload of a flow is confirmed
+	payloadPending bool
 }
 
 // NewCommitService creates a new CommitService with the given dependencies.
@@ -70,7 +131,21 @@ func NewCommitService(
 		if maxEntries <= 0 {
 			maxEntries = cache.DefaultMaxEntries
 		}
-		cacheManager = cache.NewLRUCache(maxEntries, ttl)
+		lru := cache.NewLRUCache(maxEntries, ttl)
+		if cfg.Cache.FilePath != "" {
+			if err := lru.Load(cfg.Cache.FilePath); err != nil {
+				apperrors.Debug("Ignoring the response cache file: %v", err)
+			}
+		}
+		cacheManager = lru
+	}
+
+	// Invalid presets are rejected when the config is loaded; fall back to the default here
+	preset := ai.PresetStandard
+	if cfg != nil {
+		if p, err := ai.ParsePreset(cfg.Generation.Preset); err == nil {
+			preset = p
+		}
 	}
 
 	return &CommitService{
@@ -81,6 +156,8 @@ func NewCommitService(
 		historyMgr:    historyMgr,
 		config:        cfg,
 		cache:         cacheManager,
+		preset:        preset,
+		statsOnly:     cfg != nil && cfg.Generation.StatsOnly,
 	}
 }
 
@@ -90,12 +167,46 @@ func (s *CommitService) GenerateAndCommit(ctx context.Context, opts *CommitOptio
 	if opts == nil {
 		opts = &CommitOptions{}
 	}
+	if opts.StatsOnly {
+		s.statsOnly = true
+	}
+
+	// Load the message to resume first so a missing one fails fast
+	recoveryFile := s.recoveryPath(ctx)
+	var resumed *ai.GenerateResponse
+	if opts.Resume {
+		var err error
+		if resumed, err = loadRecovery(recoveryFile); err != nil {
+			return err
+		}
+	}
+	// Dry runs never commit, so their messages are not kept for recovery
+	if !opts.DryRun {
+		s.recoveryFile = recoveryFile
+	}
+	if err := s.checkRepoState(ctx, opts); err != nil {
+		return err
+	}
+
+	if opts.SquashBase != "" {
+		squashed, err := s.gitClient.GetCommitMessages(ctx, opts.SquashBase)
+		if err != nil {
+			return fmt.Errorf("failed to read commits to squash: %w", err)
+		}
+		if len(squashed) == 0 {
+			return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("no commits to squash since %s", opts.SquashBase))
+		}
+		s.squashed = squashed
+	}
 
 	// Step 1: Check for staged changes
 	hasChanges, err := s.gitClient.HasStagedChanges(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to check staged changes: %w", err)
 	}
+	if !hasChanges && opts.SquashBase != "" {
+		return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("the commits since %s change nothing", opts.SquashBase))
+	}
 	if !hasChanges {
 		// Check if there are unstaged changes that can be added
 		hasUnstaged, err := s.gitClient.HasUnstagedChanges(ctx)
@@ -106,28 +217,13 @@ func (s *CommitService) GenerateAndCommit(ctx context.Context, opts *CommitOptio
 			return fmt.Errorf("no changes found. Nothing to commit")
 		}
 
-		// Ask user if they want to auto-add all changes
-		confirmed, err := s.uiManager.PromptConfirm("No staged changes found. Run 'git add .' to stage all changes?")
-		if err != nil {
-			return fmt.Errorf("failed to prompt user: %w", err)
-		}
-		if !confirmed {
-			return fmt.Errorf("no staged changes. Use 'git add' to stage changes before generating a commit message")
+		if err := s.stageSelectedFiles(ctx); err != nil {
+			return err
 		}
-
-		// Execute git add .
-		spinner := s.uiManager.ShowSpinner("Staging all changes...")
-		spinner.Start()
-		if err := s.gitClient.AddAll(ctx); err != nil {
-			spinner.Stop()
-			return fmt.Errorf("failed to stage changes: %w", err)
-		}
-		spinner.Stop()
-		s.uiManager.ShowSuccess("All changes staged")
 	}
 
 	// Step 2: Get diff and stats
-	spinner := s.uiManager.ShowSpinner("Retrieving staged changes...")
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.retrieving"))
 	spinner.Start()
 
 	diffChunks, err := s.gitClient.GetStagedDiff(ctx)
@@ -145,7 +241,7 @@ func (s *CommitService) GenerateAndCommit(ctx context.Context, opts *CommitOptio
 	spinner.Stop()
 
 	// Step 3: Process diff (filter lock files, chunk if needed)
-	spinner = s.uiManager.ShowSpinner("Processing diff...")
+	spinner = s.uiManager.ShowSpinner(i18n.T("commit.spinner.processing"))
 	spinner.Start()
 
 	processedDiff, err := s.diffProcessor.Process(ctx, diffChunks)
@@ -161,37 +257,154 @@ func (s *CommitService) GenerateAndCommit(ctx context.Context, opts *CommitOptio
 		return fmt.Errorf("no changes to commit after filtering lock files")
 	}
 
+	// The plan and budget count what is sent, which in stats-only mode is
+	// one short request
+	planned := processedDiff
+	if s.statsOnly {
+		planned = withoutContent(processedDiff)
+	}
+
+	// Show how the diff would be sent to the AI without calling it
+	if opts.ExplainPlan {
+		s.uiManager.ShowInfo(s.explainPlan(planned))
+		return nil
+	}
+
+	// A huge diff is not sent off in dozens of requests without asking. JSON
+	// and GitHub output keep stdout for the report and are never prompted
+	if resumed == nil && !IsReportFormat(opts.OutputFormat) {
+		confirmed, err := s.confirmBudget(planned)
+		if err != nil {
+			return fmt.Errorf("failed to confirm request budget: %w", err)
+		}
+		if !confirmed {
+			s.uiManager.ShowSuccess(i18n.T("commit.success.budget_declined"))
+			return nil
+		}
+	}
+	if opts.ShowPayload && resumed == nil && !IsReportFormat(opts.OutputFormat) {
+		confirmed, err := s.confirmPayload(diffChunks, planned, opts.SkipConfirm)
+		if err != nil {
+			return fmt.Errorf("failed to confirm payload: %w", err)
+		}
+		if !confirmed {
+			s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+			return nil
+		}
+	}
+
+	// Recent commit subjects give the AI context on ongoing work. When
+	// squashing they are the squashed commits, which are passed in full instead
+	var recentCommits []string
+	if opts.SquashBase == "" {
+		recentCommits = s.getRecentCommits(ctx)
+	}
+	commitTemplate := s.getCommitTemplate(ctx)
+	s.examples = s.getExamples(ctx)
+	s.unstaged = s.getUnstagedContext(ctx, diffChunks)
+	s.stack = s.getStack(ctx)
+
+	if opts.Split && s.config != nil {
+		if groups := partitionByScope(s.config.Generation.ScopeRules, diffChunks); len(groups) > 1 {
+			return s.commitSplit(ctx, opts, groups, recentCommits, commitTemplate)
+		}
+	}
+
 	// Step 4-7: Generate, display, handle action loop with regeneration support
-	return s.generateAndHandleLoop(ctx, opts, processedDiff, diffStats)
+	return s.generateAndHandleLoop(ctx, opts, processedDiff, diffStats, recentCommits, commitTemplate, diffChunks, resumed)
 }
 
 // generateAndHandleLoop handles the generate → display → action loop with regeneration support.
+// A resumed message is shown first in place of a generated one.
 func (s *CommitService) generateAndHandleLoop(
 	ctx context.Context,
 	opts *CommitOptions,
 	processedDiff *processor.ProcessedDiff,
 	diffStats *git.DiffStats,
+	recentCommits []string,
+	commitTemplate string,
+	stagedChunks []git.DiffChunk,
+	resumed *ai.GenerateResponse,
 ) error {
 	var previousAttempt string
+	var previous *ai.GenerateResponse
+	var attempts []*ai.GenerateResponse
 	regenerationCount := 0
 
+	// Refreshing picks up files staged while the message is shown. Split
+	// commits stage each group themselves, so the staging area is not re-read
+	var refresh func() bool
+	if !opts.Split {
+		refresh = func() bool {
+			changes, err := s.refreshStagedChanges(ctx, stagedChunks)
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.refresh"), err))
+				return false
+			}
+			if changes == nil {
+				s.uiManager.ShowInfo(i18n.T("commit.info.refresh_unchanged"))
+				return false
+			}
+			stagedChunks, processedDiff, diffStats = changes.chunks, changes.processed, changes.stats
+			s.unstaged = s.getUnstagedContext(ctx, stagedChunks)
+			s.uiManager.ShowInfo(i18n.T("commit.info.refreshed", len(stagedChunks)))
+			return true
+		}
+	}
+
 	for {
 		// Step 4: Generate commit message via AI
-		response, err := s.generateCommitMessage(ctx, processedDiff, diffStats, opts.CustomPrompt, previousAttempt, opts.NoCache)
-		if err != nil {
-			return fmt.Errorf("failed to generate commit message: %w", err)
+		response := resumed
+		resumed = nil
+		var err error
+		if response == nil {
+			if err := s.checkProviderHealth(ctx); err != nil {
+				return fmt.Errorf("failed to generate commit message: %w", err)
+			}
+			s.regeneration = regenerationCount
+			started := time.Now()
+			response, err = s.generateCommitMessage(ctx, processedDiff, diffStats, recentCommits, commitTemplate, opts.CustomPrompt, previousAttempt, opts.Context, opts.Intent, opts.NoCache)
+			if err != nil {
+				if s.pullMissingModel(ctx, err) {
+					continue
+				}
+				return fmt.Errorf("failed to generate commit message: %w", err)
+			}
+			s.notifyIfSlow(time.Since(started))
+			if response, err = s.pickCandidate(response, opts.Intent); err != nil {
+				return err
+			}
 		}
-
-		// Step 5: Display in interactive UI
-		if err := s.uiManager.DisplayMessage(response); err != nil {
+		attempts = append(attempts, response)
+		s.saveRecovery(response)
+
+		// Optional critic pass flags claims the diff does not support
+		issues := s.verifyMessage(ctx, processedDiff, response)
+
+		// Step 5: Display in interactive UI, side by side with the attempt it replaces.
+		// JSON and GitHub output keep stdout for the report, which includes the message
+		switch {
+		case IsReportFormat(opts.OutputFormat):
+		case previous != nil:
+			err = s.uiManager.DisplayComparison(previous, response)
+		default:
+			err = s.uiManager.DisplayMessage(response)
+		}
+		if err != nil {
 			return fmt.Errorf("failed to display message: %w", err)
 		}
 
 		// Validate and show warnings
-		s.validateAndWarn(response)
+		s.validateAndWarn(s.stripTemplateComments(response, commitTemplate))
+		s.warnDuplicate(response.Subject, recentCommits)
+		s.showCritique(issues)
+		if !IsReportFormat(opts.OutputFormat) {
+			s.showAccuracy(s.checkAccuracy(response, processedDiff.Chunks))
+			s.showRisk(s.assessRisk(processedDiff.Chunks))
+		}
 
 		// Step 6: Handle user action
-		action, err := s.uiManager.PromptAction()
+		action, response, err := s.promptAction(ctx, formatDiffForPreview(stagedChunks), attempts, refresh)
 		if err != nil {
 			return fmt.Errorf("failed to get user action: %w", err)
 		}
@@ -199,103 +412,409 @@ func (s *CommitService) generateAndHandleLoop(
 		switch action {
 		case ui.ActionAccept:
 			// Step 7: Execute commit or save to file
-			return s.handleAccept(ctx, opts, response, processedDiff)
+			return s.handleAccept(ctx, opts, s.stripTemplateComments(response, commitTemplate), processedDiff, diffStats)
 
 		case ui.ActionEdit:
-			editedResponse, err := s.uiManager.EditMessage(response)
+			editedResponse, err := s.editMessage(response)
 			if err != nil {
-				s.uiManager.ShowError(fmt.Errorf("failed to edit message: %w", err))
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.edit"), err))
 				continue
 			}
-			return s.handleAccept(ctx, opts, editedResponse, processedDiff)
+			if editedResponse == nil {
+				// Like git, an empty message aborts the commit
+				s.clearRecovery()
+				s.uiManager.ShowSuccess(i18n.T("commit.success.empty_message"))
+				return nil
+			}
+			return s.handleAccept(ctx, opts, s.stripTemplateComments(editedResponse, commitTemplate), processedDiff, diffStats)
+
+		case ui.ActionEditSubject:
+			editedResponse, err := s.editSubject(response)
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.edit"), err))
+				continue
+			}
+			if editedResponse == nil {
+				s.clearRecovery()
+				s.uiManager.ShowSuccess(i18n.T("commit.success.empty_message"))
+				return nil
+			}
+			return s.handleAccept(ctx, opts, s.stripTemplateComments(editedResponse, commitTemplate), processedDiff, diffStats)
 
 		case ui.ActionRegenerate:
 			regenerationCount++
 			if regenerationCount >= MaxRegenerationAttempts {
-				s.uiManager.ShowError(fmt.Errorf("maximum regeneration attempts (%d) reached", MaxRegenerationAttempts))
+				s.uiManager.ShowError(errors.New(i18n.T("commit.error.max_regenerations", MaxRegenerationAttempts)))
 				return fmt.Errorf("maximum regeneration attempts reached")
 			}
 			// Track previous attempt for context
 			previousAttempt = s.formatResponseForContext(response)
+			previous = response
+			continue
+
+		case ui.ActionRefresh:
+			// The changed diff gets a new message rather than a revision
+			// of the current one, which is shown for comparison
+			previousAttempt = ""
+			previous = response
 			continue
 
 		case ui.ActionCancel:
-			s.uiManager.ShowSuccess("Commit cancelled")
+			s.clearRecovery()
+			s.uiManager.ShowSuccess(i18n.T("commit.success.cancelled"))
 			return nil
 		}
 	}
 }
 
+// editMessage opens the editor and returns the edited message. If the result is
+// not a valid conventional commit, the user confirms it or edits it again.
+// Returns nil if the user cleared the message.
+func (s *CommitService) editMessage(response *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	for {
+		edited, err := s.uiManager.EditMessage(response)
+		if err != nil {
+			return nil, err
+		}
+
+		text := strings.TrimSpace(s.formatCommitMessage(edited))
+		if text == "" {
+			return nil, nil
+		}
+
+		validationErr := message.NewCommitMessage(text).Validate()
+		if validationErr == nil {
+			return edited, nil
+		}
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.invalid_edit", validationErr)))
+
+		commitAnyway, err := s.uiManager.PromptConfirm(i18n.T("commit.confirm.invalid_edit"))
+		if err != nil {
+			return nil, err
+		}
+		if commitAnyway {
+			return edited, nil
+		}
+		response = edited
+	}
+}
+
+// editSubject lets the user change just the subject line and returns the
+// message with the new subject. Like editMessage, an invalid result is used
+// only after confirmation. Returns nil if the user cleared the subject.
+func (s *CommitService) editSubject(response *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	for {
+		subject, err := s.uiManager.EditSubject(messageSubject(response))
+		if err != nil {
+			return nil, err
+		}
+		subject = strings.TrimSpace(subject)
+		if subject == "" {
+			return nil, nil
+		}
+
+		edited := withSubject(response, subject)
+		validationErr := message.NewCommitMessage(s.formatCommitMessage(edited)).Validate()
+		if validationErr == nil {
+			return edited, nil
+		}
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.invalid_edit", validationErr)))
+
+		commitAnyway, err := s.uiManager.PromptConfirm(i18n.T("commit.confirm.invalid_edit"))
+		if err != nil {
+			return nil, err
+		}
+		if commitAnyway {
+			return edited, nil
+		}
+		response = edited
+	}
+}
+
+// messageSubject returns the subject line of the message.
+func messageSubject(response *ai.GenerateResponse) string {
+	if response.Subject != "" {
+		return response.Subject
+	}
+	subject, _, _ := strings.Cut(strings.TrimSpace(response.RawText), "\n")
+	return subject
+}
+
+// withSubject returns a copy of the message with its subject line replaced,
+// keeping the body and footer.
+func withSubject(response *ai.GenerateResponse, subject string) *ai.GenerateResponse {
+	edited := *response
+	if edited.Subject != "" || strings.TrimSpace(edited.RawText) == "" {
+		edited.Subject = subject
+		return &edited
+	}
+	// Messages kept as raw text only have the subject on their first line
+	_, rest, found := strings.Cut(strings.TrimSpace(edited.RawText), "\n")
+	edited.RawText = subject
+	if found {
+		edited.RawText += "\n" + rest
+	}
+	return &edited
+}
+
+// promptAction prompts for the next action. Viewing the staged diff and going
+// back to an earlier attempt are handled here, prompting again afterwards.
+// Refresh is returned only if refresh reports that the staged changes
+// changed; a nil refresh means refreshing is not available. Translating
+// replaces the message with its translation, and picking bullets with the
+// message without the dropped ones, which the user may accept.
+// Returns the action along with the attempt it applies to.
+func (s *CommitService) promptAction(ctx context.Context, stagedDiff string, attempts []*ai.GenerateResponse, refresh func() bool) (ui.Action, *ai.GenerateResponse, error) {
+	current := attempts[len(attempts)-1]
+
+	for {
+		action, err := s.uiManager.PromptAction()
+		if err != nil {
+			return action, current, err
+		}
+
+		switch action {
+		case ui.ActionViewDiff:
+			if err := s.uiManager.ShowDiff(stagedDiff); err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.show_diff"), err))
+			}
+
+		case ui.ActionPickAttempt:
+			if len(attempts) < 2 {
+				s.uiManager.ShowError(errors.New(i18n.T("commit.error.no_attempts")))
+				continue
+			}
+
+			idx, err := s.uiManager.SelectAttempt(attempts)
+			if err != nil {
+				return action, current, fmt.Errorf("failed to select attempt: %w", err)
+			}
+			if idx < 0 || idx >= len(attempts) {
+				continue
+			}
+
+			current = attempts[idx]
+			if err := s.uiManager.DisplayMessage(current); err != nil {
+				return action, current, fmt.Errorf("failed to display message: %w", err)
+			}
+			s.validateAndWarn(current)
+
+		case ui.ActionRefresh:
+			if refresh == nil {
+				s.uiManager.ShowError(errors.New(i18n.T("commit.error.refresh_unavailable")))
+				continue
+			}
+			if refresh() {
+				return action, current, nil
+			}
+
+		case ui.ActionTranslate:
+			language := s.translationLanguage()
+			if language == "" {
+				s.uiManager.ShowError(errors.New(i18n.T("commit.error.translate_unset")))
+				continue
+			}
+			translated, err := s.translateMessage(ctx, current, language)
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.translate"), err))
+				continue
+			}
+			if err := s.uiManager.DisplayComparison(current, translated); err != nil {
+				return action, current, fmt.Errorf("failed to display message: %w", err)
+			}
+			current = translated
+			s.validateAndWarn(current)
+
+		case ui.ActionPickBullets:
+			picked, err := s.pickBullets(current)
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.pick_bullets"), err))
+				continue
+			}
+			if picked == nil {
+				continue
+			}
+			current = picked
+			if err := s.uiManager.DisplayMessage(current); err != nil {
+				return action, current, fmt.Errorf("failed to display message: %w", err)
+			}
+			s.validateAndWarn(current)
+
+		default:
+			return action, current, nil
+		}
+	}
+}
+
+// formatDiffForPreview joins the raw staged diff of all files, including lock
+// files and large files that were filtered or summarized for the AI.
+func formatDiffForPreview(chunks []git.DiffChunk) string {
+	var sb strings.Builder
+	for _, chunk := range chunks {
+		sb.WriteString(chunk.Content)
+		if !strings.HasSuffix(chunk.Content, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
 // generateCommitMessage generates a commit message using the AI provider.
 // For large diffs with multiple files, uses two-phase processing for better results.
 func (s *CommitService) generateCommitMessage(
 	ctx context.Context,
 	processedDiff *processor.ProcessedDiff,
 	diffStats *git.DiffStats,
+	recentCommits []string,
+	commitTemplate string,
 	customPrompt string,
 	previousAttempt string,
+	userContext string,
+	intent ai.Intent,
 	noCache bool,
 ) (*ai.GenerateResponse, error) {
-	// Generate cache key from diff content
-	var diffContent strings.Builder
-	for _, chunk := range processedDiff.Chunks {
-		diffContent.WriteString(chunk.Content)
-	}
+	s.sensitive = s.sensitiveFiles(processedDiff.Chunks)
+	s.ownerAreas, s.areaScope = s.ownership(ctx, processedDiff.Chunks, intent)
 
-	// Check cache if enabled and not bypassed
+	// Check cache if enabled and not bypassed. Compared providers are always asked
 	cacheKey := ""
-	if s.cache != nil && !noCache && previousAttempt == "" {
+	if s.cache != nil && !noCache && previousAttempt == "" && len(s.compare) == 0 {
+		// The diff is keyed by its per-file hashes, so paths count too
 		cacheKey = cache.GenerateCacheKey(
-			diffContent.String(),
+			compositeKey(processedDiff.Chunks),
 			s.aiProvider.Name(),
-			s.config.Provider.Model,
-			customPrompt,
+			s.modelName(),
+			string(s.preset)+"|"+strconv.FormatBool(s.statsOnly)+"|"+strings.Join(recentCommits, "\n")+"|"+commitTemplate+"|"+customPrompt+"|"+userContext+"|"+intent.Type+"("+intent.Scope+")|"+strings.Join(s.squashed, "\x00")+"|"+strings.Join(s.unstaged, "\n")+"|"+s.stack+"|"+strings.Join(s.sensitive, "\n")+"|"+strings.Join(s.ownerAreas, "\n")+"|"+s.areaScope,
 		)
 
+		// Hits and misses are kept with the entries
+		defer s.saveCache()
 		if cached, ok := s.cache.Get(cacheKey); ok {
-			if response, ok := cached.(*ai.GenerateResponse); ok {
+			if response, ok := cachedResponse(cached); ok {
 				return response, nil
 			}
 		}
 	}
 
-	totalSize := diffContent.Len()
-	fileCount := len(processedDiff.Chunks)
-
-	var response *ai.GenerateResponse
-	var err error
-
-	// Decision: use two-phase processing for large diffs with multiple files
-	if totalSize > 10*1024 && fileCount > 1 {
-		// Two-phase processing has its own progress UI
-		response, err = s.generateWithTwoPhase(ctx, processedDiff, diffStats, previousAttempt)
-	} else {
-		// Direct processing: show simple spinner
-		spinner := s.uiManager.ShowSpinner("Generating commit message...")
-		spinner.Start()
-		defer spinner.Stop()
+	// Only the paths and line counts are sent in stats-only mode. The cache
+	// key above still tells apart diffs with the same counts
+	if s.statsOnly {
+		processedDiff = withoutContent(processedDiff)
+	}
 
+	// A message generated after the summaries were stopped early does not
+	// describe every file, so it is not cached for the full diff
+	partial := false
+	generate := func(previousAttempt string) (*ai.GenerateResponse, error) {
 		req := &ai.GenerateRequest{
 			DiffChunks:      processedDiff.Chunks,
 			DiffStats:       diffStats,
 			CustomPrompt:    customPrompt,
 			PreviousAttempt: previousAttempt,
+			Preset:          s.preset,
+			RecentCommits:   recentCommits,
+			CommitTemplate:  commitTemplate,
+			Context:         userContext,
+			Intent:          intent,
+			Examples:        s.examples,
+			SquashedCommits: s.squashed,
+			UnstagedFiles:   s.unstaged,
+			Stack:           s.stack,
+			SensitiveFiles:  s.sensitive,
+			OwnerAreas:      s.ownerAreas,
+			AreaScope:       s.areaScope,
+			StatsOnly:       s.statsOnly,
+			Redact:          s.pathRedactor(),
 		}
-		response, err = s.aiProvider.GenerateCommitMessage(ctx, req)
+
+		// Decision: summarize per group first when the request does not fit
+		// the model's context window
+		if !s.fitsContext(req) {
+			// Two-phase processing has its own progress UI
+			response, stopped, err := s.generateWithTwoPhase(ctx, processedDiff, diffStats, recentCommits, commitTemplate, previousAttempt, userContext, intent)
+			partial = partial || stopped
+			return response, err
+		}
+
+		// Direct processing: show simple spinner
+		spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.generating"))
+		spinner.Start()
+
+		s.escalate(req)
+		response, err := s.requestMessage(ctx, req)
+		spinner.Stop()
+
+		// The model cut a prompt too large for its context window, which
+		// the window size did not predict
+		if ai.IsContextTruncated(err) {
+			apperrors.Warn("%v; summarizing the files in groups instead", err)
+			response, stopped, err := s.generateWithTwoPhase(ctx, processedDiff, diffStats, recentCommits, commitTemplate, previousAttempt, userContext, intent)
+			partial = partial || stopped
+			return response, err
+		}
+		return response, err
 	}
 
+	response, err := generate(previousAttempt)
 	if err != nil {
 		return nil, err
 	}
 
+	// A message that ignores the requested type or scope is rejected: the model
+	// gets one retry with the deviation as feedback, then the subject is rewritten
+	if deviation := intent.Check(response.Subject); deviation != nil {
+		apperrors.Debug("Rejected generated message: %v", deviation)
+		feedback := s.formatResponseForContext(response) + "\n\n> Rejected: " + deviation.Error() + ". " + intent.Instruction()
+		response, err = generate(feedback)
+		if err != nil {
+			return nil, err
+		}
+		if deviation := intent.Check(response.Subject); deviation != nil {
+			apperrors.Debug("Rewriting subject after repeated deviation: %v", deviation)
+			response = intent.Apply(response)
+		}
+	}
+
+	// A subject repeating a recent commit, as in a series of dependency bumps,
+	// gets one retry with the duplicate as feedback; a remaining one is warned about
+	if s.duplicateCheck() == DuplicateCheckRegenerate {
+		if duplicate := duplicateSubject(response.Subject, recentCommits); duplicate != "" {
+			apperrors.Debug("Rejected duplicate subject: %s", duplicate)
+			retry, err := generate(s.formatResponseForContext(response) + "\n\n" + duplicateFeedback(duplicate))
+			if err != nil {
+				return nil, err
+			}
+			// The retry must still honor the requested type and scope
+			if intent.Check(retry.Subject) != nil {
+				retry = intent.Apply(retry)
+			}
+			response = retry
+		}
+	}
+
 	// Store in cache if enabled
-	if s.cache != nil && cacheKey != "" && response != nil {
+	if s.cache != nil && cacheKey != "" && response != nil && !partial {
 		s.cache.Set(cacheKey, response, 0)
 	}
 
 	return response, nil
 }
 
+// getRecentCommits returns the configured number of recent commit subjects.
+// The log is only context for the prompt, so failures are logged and ignored.
+func (s *CommitService) getRecentCommits(ctx context.Context) []string {
+	if s.config == nil || s.config.Generation.RecentCommits <= 0 {
+		return nil
+	}
+
+	commits, err := s.gitClient.GetRecentCommits(ctx, s.config.Generation.RecentCommits)
+	if err != nil {
+		apperrors.Debug("Failed to read recent commits: %v", err)
+		return nil
+	}
+	return commits
+}
+
 // fileGroup represents a group of files to be summarized together.
 type fileGroup struct {
 	chunks []git.DiffChunk
@@ -305,34 +824,72 @@ type fileGroup struct {
 // generateWithTwoPhase implements two-phase processing for large diffs.
 // Phase 1: Group small files together, then summarize each group
 // Phase 2: Generate final commit message from summaries
+// It reports whether the summaries were stopped before covering every file.
 func (s *CommitService) generateWithTwoPhase(
 	ctx context.Context,
 	processedDiff *processor.ProcessedDiff,
 	diffStats *git.DiffStats,
+	recentCommits []string,
+	commitTemplate string,
 	previousAttempt string,
-) (*ai.GenerateResponse, error) {
+	userContext string,
+	intent ai.Intent,
+) (*ai.GenerateResponse, bool, error) {
+	summaries, stopped, err := s.summarizeChunks(ctx, processedDiff.Chunks)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Phase 2: Generate final commit message
+	finalSpinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.generating"))
+	finalSpinner.Start()
+	defer finalSpinner.Stop()
+
+	response, err := s.generateFromSummaries(ctx, summaries, diffStats, recentCommits, commitTemplate, previousAttempt, userContext, intent)
+	return response, stopped, err
+}
+
+// StopSummarizing asks the file group summaries in progress to stop after
+// the current batch, so the message is generated from those collected so far.
+// It is safe to call from another goroutine, such as the UI's key handler.
+func (s *CommitService) StopSummarizing() {
+	s.stopRequested.Store(true)
+}
+
+// summarizeChunks is phase 1 of two-phase processing: it groups the files
+// and summarizes them in batches of concurrent groups, adapting the batch
+// size to how fast the provider answers and backing off when it rate limits.
+// A group that fails to summarize is handled by the group failure policy.
+// After StopSummarizing, the groups not yet started are only counted and
+// the returned flag is set.
+func (s *CommitService) summarizeChunks(ctx context.Context, chunks []git.DiffChunk) ([]string, bool, error) {
 	// Step 1: Group files by size to minimize API calls
-	groups := s.groupFilesBySize(processedDiff.Chunks)
+	groups := s.groupFilesBySize(chunks)
+	policy := s.groupFailure()
+	s.stopRequested.Store(false)
+	s.rateLimits.Store(0)
 
 	// Create progress spinner
-	progress := s.uiManager.ShowProgressSpinner("Analyzing files", len(groups))
+	progress := s.uiManager.ShowProgressSpinner(i18n.T("commit.spinner.analyzing"), len(groups))
 	progress.Start()
 	defer progress.Stop()
 
-	// Step 2: Process groups in batches (MaxConcurrentGroups at a time)
+	// Step 2: Process groups in batches, as many at a time as the provider keeps up with
+	concurrency := newGroupConcurrency()
 	summaries := make([]string, len(groups))
 	completed := 0
+	var degraded []string
 
-	for batchStart := 0; batchStart < len(groups); batchStart += MaxConcurrentGroups {
-		batchEnd := batchStart + MaxConcurrentGroups
-		if batchEnd > len(groups) {
-			batchEnd = len(groups)
-		}
+	for batchStart, batchEnd := 0, 0; batchStart < len(groups); batchStart = batchEnd {
+		batchEnd = min(batchStart+concurrency.limit, len(groups))
 
 		type result struct {
-			index   int
-			summary string
-			err     error
+			index    int
+			summary  string
+			cached   bool
+			degraded bool
+			elapsed  time.Duration
+			err      error
 		}
 		batchLen := batchEnd - batchStart
 		resultChan := make(chan result, batchLen)
@@ -353,46 +910,81 @@ func (s *CommitService) generateWithTwoPhase(
 			idx := i
 			group := groups[i]
 			go func() {
-				summary, err := s.summarizeFileGroup(ctx, group)
-				resultChan <- result{index: idx, summary: summary, err: err}
+				start := time.Now()
+				summary, cached, degraded, err := s.summarizeGroup(ctx, group, policy)
+				resultChan <- result{index: idx, summary: summary, cached: cached, degraded: degraded, elapsed: time.Since(start), err: err}
 			}()
 		}
 
 		// Wait for batch to complete
+		requested := false
+		var slowest time.Duration
+		var batchErr error
 		for j := 0; j < batchLen; j++ {
 			r := <-resultChan
 			completed++
 			progress.SetCurrent(completed)
-
+			if !r.cached {
+				requested = true
+				slowest = max(slowest, r.elapsed)
+			}
 			if r.err != nil {
-				// Fallback: list files without AI summary
-				var files []string
-				for _, c := range groups[r.index].chunks {
-					files = append(files, fmt.Sprintf("- %s (+%d -%d)", c.FilePath, c.Additions, c.Deletions))
+				if batchErr == nil {
+					batchErr = r.err
 				}
-				summaries[r.index] = strings.Join(files, "\n")
-			} else {
-				summaries[r.index] = r.summary
+				continue
+			}
+			if r.degraded {
+				degraded = append(degraded, fmt.Sprintf("group %d (%s)", r.index+1, strings.Join(groups[r.index].files, ", ")))
 			}
+			summaries[r.index] = r.summary
+		}
+		if batchErr != nil {
+			return nil, false, batchErr
 		}
 
-		// Delay between batches
-		if batchEnd < len(groups) {
-			time.Sleep(1 * time.Second)
+		// Stop early on request, keeping the summaries of finished batches
+		if batchEnd < len(groups) && s.stopRequested.Load() {
+			remaining := 0
+			for _, group := range groups[batchEnd:] {
+				remaining += len(group.files)
+			}
+			apperrors.Debug("Stopped summarizing with %d of %d groups done", batchEnd, len(groups))
+			reportDegraded(degraded, len(groups))
+			return append(summaries[:batchEnd], fmt.Sprintf("- %d more files changed", remaining)), true, nil
+		}
+
+		// Back off before the next batch if the provider rate limited
+		delay := concurrency.adjust(requested, s.rateLimits.Swap(0) > 0, slowest)
+		if batchEnd < len(groups) && delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, false, ctx.Err()
+			}
 		}
 	}
 
-	// Phase 2: Generate final commit message
-	progress.Stop()
-	finalSpinner := s.uiManager.ShowSpinner("Generating commit message...")
-	finalSpinner.Start()
-	defer finalSpinner.Stop()
+	reportDegraded(degraded, len(groups))
+	return summaries, false, nil
+}
 
-	return s.generateFromSummaries(ctx, summaries, diffStats, previousAttempt)
+// reportDegraded logs the file groups whose summary was replaced by the
+// group failure policy, so verbose output shows the message may be incomplete.
+func reportDegraded(degraded []string, total int) {
+	if len(degraded) == 0 {
+		return
+	}
+	apperrors.Warn("%d of %d file groups were not fully summarized; the message may be incomplete: %s",
+		len(degraded), total, strings.Join(degraded, "; "))
 }
 
 // groupFilesBySize groups files together until each group reaches MaxGroupSize.
+// Files are taken in path weight and path order, so the same diff always
+// yields the same groups and the important files are summarized first.
 func (s *CommitService) groupFilesBySize(chunks []git.DiffChunk) []fileGroup {
+	chunks = processor.SortChunksByWeight(chunks, s.pathWeights())
+
 	var groups []fileGroup
 	var currentGroup fileGroup
 	currentSize := 0
@@ -439,6 +1031,11 @@ func (s *CommitService) groupFilesBySize(chunks []git.DiffChunk) []fileGroup {
 
 // summarizeFileGroup generates a summary for a group of files.
 func (s *CommitService) summarizeFileGroup(ctx context.Context, group fileGroup) (string, error) {
+	// A file too large for one request is summarized piece by piece
+	if len(group.chunks) == 1 && len(group.chunks[0].Content) > MaxGroupSize {
+		return s.summarizeLargeFile(ctx, group.chunks[0])
+	}
+
 	var sb strings.Builder
 
 	// Build combined diff content
@@ -459,21 +1056,7 @@ func (s *CommitService) summarizeFileGroup(ctx context.Context, group fileGroup)
 格式:
 - 文件名: 改动描述`, sb.String())
 
-	req := &ai.GenerateRequest{
-		CustomPrompt: prompt,
-	}
-
-	resp, err := s.aiProvider.GenerateCommitMessage(ctx, req)
-	if err != nil {
-		return "", err
-	}
-
-	summary := strings.TrimSpace(resp.RawText)
-	if summary == "" {
-		summary = resp.Subject
-	}
-
-	return summary, nil
+	return s.summarize(ctx, prompt)
 }
 
 // generateFromSummaries generates the final commit message from file summaries.
@@ -481,7 +1064,11 @@ func (s *CommitService) generateFromSummaries(
 	ctx context.Context,
 	summaries []string,
 	diffStats *git.DiffStats,
+	recentCommits []string,
+	commitTemplate string,
 	previousAttempt string,
+	userContext string,
+	intent ai.Intent,
 ) (*ai.GenerateResponse, error) {
 	// Filter empty summaries
 	var validSummaries []string
@@ -499,33 +1086,80 @@ func (s *CommitService) generateFromSummaries(
 
 各文件改动:
 %s
-
+%s
+%s
+%s
+%s
+%s
+%s
 %s
 
 要求:
-1. Subject 格式: <type>(<scope>): <简短描述>（不超过50字）
-2. Body 必须包含：按模块/目录分组列出主要改动，每个模块一行，格式如：
-   - 模块名: 具体功能描述
-3. 如果有多个模块，都要列出
-4. 只输出 commit message，不要解释`,
+%s`,
 		diffStats.TotalFiles,
 		diffStats.TotalAdditions,
 		diffStats.TotalDeletions,
 		strings.Join(validSummaries, "\n"),
+		func() string {
+			if len(s.squashed) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("\n以下提交将被压缩为一个提交，请结合提交信息中的意图与实际改动，为整体变更写一条 commit message，省略被后续提交撤销或修复的中间步骤:\n---\n%s\n", strings.Join(s.squashed, "\n---\n"))
+		}(),
+		func() string {
+			if userContext == "" {
+				return ""
+			}
+			return fmt.Sprintf("\n开发者说明的改动意图（以此为准，并在 commit message 中体现）:\n%s\n", userContext)
+		}(),
+		func() string {
+			if len(s.sensitive) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("\n以下文件属于安全敏感文件，请在正文中单独说明其改动的安全影响（即使改动很小）:\n- %s\n", strings.Join(s.sensitive, "\n- "))
+		}(),
+		func() string {
+			if len(s.unstaged) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("\n以下是未暂存或未跟踪的文件，它们不属于本次提交，仅用于理解正在进行的工作，不要在 commit message 中描述:\n- %s\n", strings.Join(s.unstaged, "\n- "))
+		}(),
+		func() string {
+			if len(recentCommits) == 0 {
+				return ""
+			}
+			return fmt.Sprintf("\n当前分支最近的提交（保持风格一致，不要重复这些标题）:\n- %s\n", strings.Join(recentCommits, "\n- "))
+		}(),
+		func() string {
+			if commitTemplate == "" {
+				return ""
+			}
+			return fmt.Sprintf("\n本仓库要求 commit message 遵循以下模板，请按模板结构填写并替换占位内容，以 # 开头的行原样保留:\n%s\n", commitTemplate)
+		}(),
 		func() string {
 			if previousAttempt != "" {
 				return fmt.Sprintf("\n上次生成的不满意，请重新生成:\n%s", previousAttempt)
 			}
 			return ""
 		}(),
+		func() string {
+			requirements := s.preset.SummaryRequirements() + s.ownershipRequirement()
+			if intent.IsZero() {
+				return requirements
+			}
+			return requirements + "\n- " + intent.Instruction()
+		}(),
 	)
 
 	req := &ai.GenerateRequest{
 		CustomPrompt: prompt,
 		DiffStats:    diffStats,
+		Stack:        s.stack,
+		Redact:       s.pathRedactor(),
 	}
+	s.escalate(req)
 
-	return s.aiProvider.GenerateCommitMessage(ctx, req)
+	return s.requestMessage(ctx, req)
 }
 
 // validateAndWarn validates the commit message and shows warnings if needed.
@@ -551,7 +1185,12 @@ func (s *CommitService) validateAndWarn(response *ai.GenerateResponse) {
 
 	// Show warnings (but not errors - those would prevent commit)
 	for _, warning := range result.Warnings {
-		s.uiManager.ShowError(fmt.Errorf("warning: %s", warning))
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning", warning)))
+	}
+
+	// A breaking change is easy to miss in a long message, point it out
+	if cm.IsBreaking() {
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.breaking", cm.BreakingChange())))
 	}
 }
 
@@ -561,47 +1200,111 @@ func (s *CommitService) handleAccept(
 	opts *CommitOptions,
 	response *ai.GenerateResponse,
 	processedDiff *processor.ProcessedDiff,
+	diffStats *git.DiffStats,
 ) error {
+	// Nothing is committed, written or recorded after cancellation, even if
+	// the user accepted in a prompt that was still open when Ctrl+C arrived
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	response, err := s.applyFooterTemplate(ctx, response)
+	if err != nil {
+		return fmt.Errorf("failed to apply footer template: %w", err)
+	}
+	if response, err = s.applyIssueFooter(ctx, opts.Issue, response); err != nil {
+		return fmt.Errorf("failed to add issue footer: %w", err)
+	}
+	response = s.applyRiskFooter(response, processedDiff.Chunks)
+
+	// Changes to security-sensitive files are committed only after confirmation
+	if sensitive := s.sensitiveFiles(processedDiff.Chunks); !opts.DryRun && len(sensitive) > 0 {
+		confirmed, err := s.uiManager.PromptConfirm(i18n.T("commit.confirm.sensitive", strings.Join(sensitive, ", ")))
+		if err != nil {
+			return fmt.Errorf("failed to confirm commit: %w", err)
+		}
+		if !confirmed {
+			s.saveRecovery(response)
+			s.uiManager.ShowSuccess(i18n.T("commit.success.sensitive_declined"))
+			return nil
+		}
+	}
+
 	// Format the commit message
 	commitMsg := s.formatCommitMessage(response)
 
+	// The commit preview is shown only for commits made right away
+	if !opts.DryRun && s.messageFile == "" {
+		confirmed, err := s.confirmCommitPreview(ctx, commitMsg, diffStats)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			s.saveRecovery(response)
+			s.uiManager.ShowSuccess(i18n.T("commit.success.preview_declined"))
+			return nil
+		}
+	}
+
 	// Save to history if enabled
 	if s.historyMgr != nil && s.config != nil && s.config.History.Enabled {
 		entry := &history.Entry{
 			Message:     commitMsg,
 			DiffSummary: processedDiff.Summary,
 			Provider:    s.aiProvider.Name(),
-			Model:       s.config.Provider.Model,
+			Model:       s.modelName(),
+			Compared:    s.compared,
 			Committed:   !opts.DryRun,
+			Translation: s.historyTranslation(ctx, response),
+			// Scored before taking the usage, which includes a model score
+			Score: s.scoreMessage(ctx, commitMsg, processedDiff.Chunks),
+			Usage: s.usage.take(),
 		}
 		if err := s.historyMgr.Save(entry); err != nil {
 			// Log but don't fail the commit
-			s.uiManager.ShowError(fmt.Errorf("warning: failed to save to history: %w", err))
+			s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.warning.history"), err))
 		}
 	}
 
 	// Dry-run mode: output message without committing
 	if opts.DryRun {
-		if opts.OutputFile != "" {
-			return s.writeToFile(opts.OutputFile, commitMsg)
+		if err := s.reportDryRun(opts, commitMsg, diffStats); err != nil {
+			return err
 		}
-		// Message already displayed, just return success
-		s.uiManager.ShowSuccess("Dry-run complete - message generated but not committed")
+		s.accepted = true
 		return nil
 	}
 
+	// Keep the accepted message, which may be edited or an earlier attempt,
+	// in case the commit fails
+	s.saveRecovery(response)
+
+	// A rebase or cherry-pick in progress commits the message when continued
+	if s.messageFile != "" {
+		return s.writeOperationMessage(commitMsg)
+	}
+
 	// Execute git commit
-	spinner := s.uiManager.ShowSpinner("Committing changes...")
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.committing"))
 	spinner.Start()
 
-	err := s.gitClient.Commit(ctx, commitMsg)
+	err = s.gitClient.Commit(ctx, commitMsg)
 	spinner.Stop()
 
 	if err != nil {
+		// The message stays in the recovery file for --resume
 		return fmt.Errorf("failed to commit: %w", err)
 	}
 
-	s.uiManager.ShowSuccess("Successfully committed!")
+	s.clearRecovery()
+	s.accepted = true
+	s.uiManager.ShowSuccess(i18n.T("commit.success.committed"))
+	s.runPostCommitHooks(ctx, commitMsg)
+
+	// A split commit offers to push after its last part
+	if s.deferPush {
+		return nil
+	}
 
 	// Ask if user wants to push to remote
 	hasRemote, err := s.gitClient.HasRemote(ctx)
@@ -613,35 +1316,35 @@ func (s *CommitService) handleAccept(
 		return nil
 	}
 
-	confirmed, err := s.uiManager.PromptConfirm("Push to remote repository?")
+	confirmed, err := s.uiManager.PromptConfirm(i18n.T("push.confirm"))
 	if err != nil || !confirmed {
 		return nil
 	}
 
 	// First pull to sync with remote (if upstream exists)
-	pullSpinner := s.uiManager.ShowSpinner("Pulling from remote...")
+	pullSpinner := s.uiManager.ShowSpinner(i18n.T("push.spinner.pulling"))
 	pullSpinner.Start()
 
 	pullResult, err := s.gitClient.Pull(ctx)
 	pullSpinner.Stop()
 
 	if err != nil {
-		s.uiManager.ShowError(fmt.Errorf("failed to pull: %w", err))
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("push.error.pull"), err))
 		return nil
 	}
 
 	// If there were updates, inform user and ask to continue
 	if pullResult.Updated {
-		s.uiManager.ShowSuccess(fmt.Sprintf("Pulled %d file(s) from remote", pullResult.UpdatedFiles))
+		s.uiManager.ShowSuccess(i18n.T("push.success.pulled", pullResult.UpdatedFiles))
 
-		continueConfirmed, err := s.uiManager.PromptConfirm("Remote has updates. Continue with push?")
+		continueConfirmed, err := s.uiManager.PromptConfirm(i18n.T("push.confirm.continue"))
 		if err != nil || !continueConfirmed {
 			return nil
 		}
 	}
 
 	// Execute git push
-	pushSpinner := s.uiManager.ShowSpinner("Pushing to remote...")
+	pushSpinner := s.uiManager.ShowSpinner(i18n.T("push.spinner.pushing"))
 	pushSpinner.Start()
 
 	// Use PushWithUpstream if no upstream branch configured
@@ -653,12 +1356,12 @@ func (s *CommitService) handleAccept(
 
 	if err != nil {
 		pushSpinner.Stop()
-		s.uiManager.ShowError(fmt.Errorf("failed to push: %w", err))
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("push.error.push"), err))
 		return nil
 	}
 
 	pushSpinner.Stop()
-	s.uiManager.ShowSuccess("Pushed to remote!")
+	s.uiManager.ShowSuccess(i18n.T("push.success.pushed"))
 	return nil
 }
 
@@ -709,6 +1412,6 @@ func (s *CommitService) writeToFile(filePath, content string) error {
 		return fmt.Errorf("failed to write to file %s: %w", filePath, err)
 	}
 
-	s.uiManager.ShowSuccess(fmt.Sprintf("Message written to %s", filePath))
+	s.uiManager.ShowSuccess(i18n.T("commit.success.written", filePath))
 	return nil
 }
@@ -4,11 +4,13 @@ package app
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/gitsage/gitsage/internal/pkg/ai"
 	"github.com/gitsage/gitsage/internal/pkg/config"
@@ -21,6 +23,10 @@ import (
 // MockGitClient is a mock implementation of git.Client
 type MockGitClient struct {
 	mock.Mock
+	// GitDir is returned by GetGitDir; empty disables message recovery
+	GitDir string
+	// RepoRoot is returned by GetRepoRoot; empty means no repository files
+	RepoRoot string
 }
 
 func (m *MockGitClient) GetStagedDiff(ctx context.Context) ([]git.DiffChunk, error) {
@@ -54,6 +60,19 @@ func (m *MockGitClient) HasUnstagedChanges(ctx context.Context) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockGitClient) AddFiles(ctx context.Context, paths []string) error {
+	args := m.Called(ctx, paths)
+	return args.Error(0)
+}
+
+func (m *MockGitClient) GetUnstagedFiles(ctx context.Context) ([]git.UnstagedFile, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]git.UnstagedFile), args.Error(1)
+}
+
 func (m *MockGitClient) AddAll(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -87,6 +106,105 @@ func (m *MockGitClient) PushWithUpstream(ctx context.Context) error {
 	return args.Error(0)
 }
 
+func (m *MockGitClient) GetRecentCommits(ctx context.Context, n int) ([]string, error) {
+	args := m.Called(ctx, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGitClient) GetCommitMessages(ctx context.Context, base string) ([]string, error) {
+	args := m.Called(ctx, base)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGitClient) GetCommitTemplate(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockGitClient) GetGitDir(ctx context.Context) (string, error) {
+	return m.GitDir, nil
+}
+
+func (m *MockGitClient) GetRepoRoot(ctx context.Context) (string, error) {
+	return m.RepoRoot, nil
+}
+
+func (m *MockGitClient) ListTags(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGitClient) GetCommitsSince(ctx context.Context, ref string) ([]string, error) {
+	args := m.Called(ctx, ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGitClient) GetAuthoredCommits(ctx context.Context, since, author string) ([]git.AuthoredCommit, error) {
+	args := m.Called(ctx, since, author)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]git.AuthoredCommit), args.Error(1)
+}
+
+func (m *MockGitClient) GetCommitDiff(ctx context.Context, hash string) ([]git.DiffChunk, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]git.DiffChunk), args.Error(1)
+}
+
+func (m *MockGitClient) GetRemoteURL(ctx context.Context, name string) (string, error) {
+	args := m.Called(ctx, name)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockGitClient) GetUserEmail(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockGitClient) GetAuthorIdent(ctx context.Context) (*git.Ident, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*git.Ident), args.Error(1)
+}
+
+func (m *MockGitClient) CreateTag(ctx context.Context, name, message string, sign bool) error {
+	args := m.Called(ctx, name, message, sign)
+	return args.Error(0)
+}
+
+func (m *MockGitClient) WriteIndexTree(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockGitClient) StageFromTree(ctx context.Context, tree string, paths []string) error {
+	args := m.Called(ctx, tree, paths)
+	return args.Error(0)
+}
+
+func (m *MockGitClient) GetHeadCommit(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockGitClient) GetCurrentBranch(ctx context.Context) (string, error) {
 	args := m.Called(ctx)
 	return args.String(0), args.Error(1)
@@ -151,6 +269,19 @@ func (m *MockUIManager) EditMessage(message *ai.GenerateResponse) (*ai.GenerateR
 	return args.Get(0).(*ai.GenerateResponse), args.Error(1)
 }
 
+func (m *MockUIManager) SelectBullets(bullets []string) ([]bool, error) {
+	args := m.Called(bullets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+func (m *MockUIManager) EditSubject(subject string) (string, error) {
+	args := m.Called(subject)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockUIManager) ShowSpinner(text string) ui.Spinner {
 	args := m.Called(text)
 	return args.Get(0).(ui.Spinner)
@@ -174,6 +305,38 @@ func (m *MockUIManager) ShowSuccess(message string) {
 	m.Called(message)
 }
 
+func (m *MockUIManager) ShowInfo(message string) {
+	m.Called(message)
+}
+
+func (m *MockUIManager) ShowDiff(diff string) error {
+	args := m.Called(diff)
+	return args.Error(0)
+}
+
+func (m *MockUIManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	args := m.Called(previous, current)
+	return args.Error(0)
+}
+
+func (m *MockUIManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	args := m.Called(attempts)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUIManager) SelectCandidate(candidates []ui.Candidate) (int, error) {
+	args := m.Called(candidates)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUIManager) SelectFiles(files []ui.FileOption) ([]string, error) {
+	args := m.Called(files)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 // MockSpinner is a mock implementation of ui.Spinner
 type MockSpinner struct {
 	mock.Mock
@@ -407,6 +570,9 @@ func TestGenerateAndCommit_DryRun(t *testing.T) {
 	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
 	uiManager.On("ShowSuccess", mock.Anything).Return()
 	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("ShowInfo", mock.MatchedBy(func(s string) bool {
+		return strings.Contains(s, "(1, +10 -5)") && strings.Contains(s, "modified   test.go")
+	})).Return()
 
 	historyMgr.On("Save", mock.Anything).Return(nil)
 
@@ -418,6 +584,7 @@ func TestGenerateAndCommit_DryRun(t *testing.T) {
 	assert.NoError(t, err)
 	// Verify Commit was NOT called
 	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+	uiManager.AssertNumberOfCalls(t, "ShowInfo", 1)
 }
 
 func TestGenerateAndCommit_Cancel(t *testing.T) {
@@ -470,43 +637,33 @@ func TestGenerateAndCommit_Cancel(t *testing.T) {
 	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
 }
 
-func TestGenerateAndCommit_Edit(t *testing.T) {
+func TestGenerateAndCommit_InterruptedDuringPrompt(t *testing.T) {
 	gitClient := &MockGitClient{}
 	aiProvider := &MockAIProvider{}
 	diffProcessor := &MockDiffProcessor{}
 	uiManager := &MockUIManager{}
 	historyMgr := &MockHistoryManager{}
 	spinner := &MockSpinner{}
-	cfg := &config.Config{
-		History: config.HistoryConfig{Enabled: false},
-	}
+	cfg := &config.Config{History: config.HistoryConfig{Enabled: true}}
 
 	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
 
-	// Setup mock expectations
 	chunks := []git.DiffChunk{
 		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
 	}
 	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
-	processedDiff := &processor.ProcessedDiff{
-		Chunks:           chunks,
-		TotalSize:        100,
-		RequiresChunking: false,
-	}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
 	response := &ai.GenerateResponse{
 		Subject: "feat: add new feature",
 		RawText: "feat: add new feature",
 	}
-	editedResponse := &ai.GenerateResponse{
-		Subject: "fix: edited message",
-		RawText: "fix: edited message",
-	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
 	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
 	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
-	gitClient.On("Commit", mock.Anything, "fix: edited message").Return(nil)
-	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
 
 	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
 
@@ -514,76 +671,63 @@ func TestGenerateAndCommit_Edit(t *testing.T) {
 
 	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
 	uiManager.On("DisplayMessage", response).Return(nil)
-	uiManager.On("PromptAction").Return(ui.ActionEdit, nil)
-	uiManager.On("EditMessage", response).Return(editedResponse, nil)
-	uiManager.On("ShowSuccess", mock.Anything).Return()
-	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+	// Ctrl+C arrives while the prompt is open, then the user accepts
+	uiManager.On("PromptAction").Run(func(mock.Arguments) { cancel() }).Return(ui.ActionAccept, nil)
 
 	spinner.On("Start").Return()
 	spinner.On("Stop").Return()
 
-	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+	err := service.GenerateAndCommit(ctx, &CommitOptions{})
 
-	assert.NoError(t, err)
-	gitClient.AssertCalled(t, "Commit", mock.Anything, "fix: edited message")
+	assert.ErrorIs(t, err, context.Canceled)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+	historyMgr.AssertNotCalled(t, "Save", mock.Anything)
 }
 
-func TestGenerateAndCommit_Regenerate(t *testing.T) {
+func TestGenerateAndCommit_ViewDiff(t *testing.T) {
 	gitClient := &MockGitClient{}
 	aiProvider := &MockAIProvider{}
 	diffProcessor := &MockDiffProcessor{}
 	uiManager := &MockUIManager{}
 	historyMgr := &MockHistoryManager{}
 	spinner := &MockSpinner{}
-	cfg := &config.Config{
-		History: config.HistoryConfig{Enabled: false},
-	}
+	cfg := &config.Config{}
 
 	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
 
 	// Setup mock expectations
 	chunks := []git.DiffChunk{
-		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "diff --git a/test.go b/test.go\n+added"},
+		{FilePath: "go.sum", ChangeType: git.ChangeTypeModified, Content: "diff --git a/go.sum b/go.sum\n+hash\n", IsLockFile: true},
 	}
-	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	stats := &git.DiffStats{TotalFiles: 2, Chunks: chunks}
+	// Lock file is filtered for the AI but must still show up in the preview
 	processedDiff := &processor.ProcessedDiff{
-		Chunks:           chunks,
+		Chunks:           chunks[:1],
 		TotalSize:        100,
 		RequiresChunking: false,
 	}
-	response1 := &ai.GenerateResponse{
-		Subject: "feat: first attempt",
-		RawText: "feat: first attempt",
-	}
-	response2 := &ai.GenerateResponse{
-		Subject: "feat: second attempt",
-		RawText: "feat: second attempt",
+	response := &ai.GenerateResponse{
+		Subject: "feat: add new feature",
+		RawText: "feat: add new feature",
 	}
 
 	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
 	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
 	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
-	gitClient.On("Commit", mock.Anything, "feat: second attempt").Return(nil)
-	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
 
 	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
 
-	// First call returns response1, second call returns response2
-	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
-		return req.PreviousAttempt == ""
-	})).Return(response1, nil).Once()
-	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
-		return req.PreviousAttempt != ""
-	})).Return(response2, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
 
 	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
-	uiManager.On("DisplayMessage", response1).Return(nil).Once()
-	uiManager.On("DisplayMessage", response2).Return(nil).Once()
-	// First prompt returns regenerate, second returns accept
-	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil).Once()
-	uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
-	uiManager.On("ShowSuccess", mock.Anything).Return()
-	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionViewDiff, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil).Once()
+	uiManager.On("ShowDiff", "diff --git a/test.go b/test.go\n+added\ndiff --git a/go.sum b/go.sum\n+hash\n").Return(nil)
+	uiManager.On("ShowSuccess", "Commit cancelled").Return()
+	uiManager.On("ShowError", mock.Anything).Maybe()
 
 	spinner.On("Start").Return()
 	spinner.On("Stop").Return()
@@ -591,18 +735,22 @@ func TestGenerateAndCommit_Regenerate(t *testing.T) {
 	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
 
 	assert.NoError(t, err)
-	// Verify AI was called twice
-	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 2)
+	uiManager.AssertExpectations(t)
+	// Viewing the diff must not trigger a regeneration
+	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 1)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
 }
 
-func TestGenerateAndCommit_MaxRegenerationAttempts(t *testing.T) {
+func TestGenerateAndCommit_Edit(t *testing.T) {
 	gitClient := &MockGitClient{}
 	aiProvider := &MockAIProvider{}
 	diffProcessor := &MockDiffProcessor{}
 	uiManager := &MockUIManager{}
 	historyMgr := &MockHistoryManager{}
 	spinner := &MockSpinner{}
-	cfg := &config.Config{}
+	cfg := &config.Config{
+		History: config.HistoryConfig{Enabled: false},
+	}
 
 	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
 
@@ -617,22 +765,29 @@ func TestGenerateAndCommit_MaxRegenerationAttempts(t *testing.T) {
 		RequiresChunking: false,
 	}
 	response := &ai.GenerateResponse{
-		Subject: "feat: attempt",
-		RawText: "feat: attempt",
+		Subject: "feat: add new feature",
+		RawText: "feat: add new feature",
+	}
+	editedResponse := &ai.GenerateResponse{
+		Subject: "fix: edited message",
+		RawText: "fix: edited message",
 	}
 
 	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
 	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
 	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+	gitClient.On("Commit", mock.Anything, "fix: edited message").Return(nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
 
 	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
 
 	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
 
 	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
-	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
-	// Always return regenerate to hit the limit
-	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionEdit, nil)
+	uiManager.On("EditMessage", response).Return(editedResponse, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
 	uiManager.On("ShowError", mock.Anything).Return()
 
 	spinner.On("Start").Return()
@@ -640,89 +795,777 @@ func TestGenerateAndCommit_MaxRegenerationAttempts(t *testing.T) {
 
 	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "maximum regeneration attempts reached")
-	// Should be called MaxRegenerationAttempts times (initial + 4 regenerations, then error on 5th)
-	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", MaxRegenerationAttempts)
+	assert.NoError(t, err)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "fix: edited message")
 }
 
-func TestGenerateAndCommit_ChunkedDiff(t *testing.T) {
+// setupEditTest returns a service whose generated message is edited by the
+// user with the given action.
+func setupEditTest(t *testing.T, action ui.Action) (*CommitService, *MockGitClient, *MockUIManager, *ai.GenerateResponse) {
+	t.Helper()
+
 	gitClient := &MockGitClient{}
 	aiProvider := &MockAIProvider{}
 	diffProcessor := &MockDiffProcessor{}
 	uiManager := &MockUIManager{}
-	historyMgr := &MockHistoryManager{}
 	spinner := &MockSpinner{}
-	progressSpinner := &MockProgressSpinner{}
-	cfg := &config.Config{
-		History: config.HistoryConfig{Enabled: false},
-	}
-
-	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+	cfg := &config.Config{}
 
-	// Create large content to trigger two-phase processing (> 10KB total)
-	largeContent := strings.Repeat("x", 4*1024) // 4KB each
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
 
-	// Setup mock expectations with large diff (over 10KB threshold)
-	// Two-phase processing triggers when totalSize > 10*1024 AND fileCount > 1
 	chunks := []git.DiffChunk{
-		{FilePath: "file1.go", ChangeType: git.ChangeTypeModified, Content: largeContent},
-		{FilePath: "file2.go", ChangeType: git.ChangeTypeAdded, Content: largeContent},
-		{FilePath: "file3.go", ChangeType: git.ChangeTypeModified, Content: largeContent},
-	}
-	stats := &git.DiffStats{TotalFiles: 3, Chunks: chunks}
-	processedDiff := &processor.ProcessedDiff{
-		Chunks:           chunks,
-		TotalSize:        12 * 1024, // Over 10KB threshold
-		RequiresChunking: true,
-		Summary:          "Summary of changes",
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
 	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
+	response := &ai.GenerateResponse{Subject: "feat: add new feature", RawText: "feat: add new feature"}
 
 	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
 	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
 	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
-	gitClient.On("Commit", mock.Anything, mock.Anything).Return(nil)
-	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil).Maybe()
 
 	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
-
-	// Two-phase: 3 summarize calls (one per file group) + 1 final generate call = 4 calls
-	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{
-		Subject: "feat: chunk change",
-		RawText: "feat: chunk change",
-	}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
 
 	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
-	uiManager.On("ShowProgressSpinner", mock.Anything, mock.Anything).Return(progressSpinner)
-	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
-	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
-	uiManager.On("ShowSuccess", mock.Anything).Return()
-	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(action, nil)
 
 	spinner.On("Start").Return()
 	spinner.On("Stop").Return()
-	progressSpinner.On("Start").Return()
-	progressSpinner.On("Stop").Return()
-	progressSpinner.On("SetCurrent", mock.Anything).Return()
-	progressSpinner.On("SetCurrentFile", mock.Anything).Return()
+
+	return service, gitClient, uiManager, response
+}
+
+func TestGenerateAndCommit_EditEmptyCancels(t *testing.T) {
+	service, gitClient, uiManager, response := setupEditTest(t, ui.ActionEdit)
+
+	uiManager.On("EditMessage", response).Return(&ai.GenerateResponse{}, nil)
+	uiManager.On("ShowSuccess", "Commit cancelled due to empty commit message").Return()
 
 	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
 
 	assert.NoError(t, err)
-	// Two-phase: 3 file groups summarized + 1 final generation = 4 AI calls
-	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 4)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+	uiManager.AssertExpectations(t)
 }
 
-func TestGenerateAndCommit_NoChangesAfterFiltering(t *testing.T) {
-	gitClient := &MockGitClient{}
-	aiProvider := &MockAIProvider{}
-	diffProcessor := &MockDiffProcessor{}
-	uiManager := &MockUIManager{}
-	historyMgr := &MockHistoryManager{}
-	spinner := &MockSpinner{}
-	cfg := &config.Config{}
+func TestGenerateAndCommit_EditInvalidReEdit(t *testing.T) {
+	service, gitClient, uiManager, response := setupEditTest(t, ui.ActionEdit)
 
-	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+	invalid := &ai.GenerateResponse{Subject: "update stuff", RawText: "update stuff"}
+	fixed := &ai.GenerateResponse{Subject: "fix: update stuff", RawText: "fix: update stuff"}
+
+	uiManager.On("EditMessage", response).Return(invalid, nil).Once()
+	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("PromptConfirm", mock.Anything).Return(false, nil).Once()
+	// Re-editing starts from the invalid message
+	uiManager.On("EditMessage", invalid).Return(fixed, nil).Once()
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	gitClient.On("Commit", mock.Anything, "fix: update stuff").Return(nil)
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "fix: update stuff")
+	uiManager.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_EditInvalidCommitAnyway(t *testing.T) {
+	service, gitClient, uiManager, response := setupEditTest(t, ui.ActionEdit)
+
+	invalid := &ai.GenerateResponse{Subject: "update stuff", RawText: "update stuff"}
+
+	uiManager.On("EditMessage", response).Return(invalid, nil).Once()
+	uiManager.On("ShowError", mock.Anything).Return()
+	uiManager.On("PromptConfirm", mock.Anything).Return(true, nil).Once()
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	gitClient.On("Commit", mock.Anything, "update stuff").Return(nil)
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "update stuff")
+	uiManager.AssertNumberOfCalls(t, "EditMessage", 1)
+}
+
+func TestGenerateAndCommit_EditSubject(t *testing.T) {
+	service, gitClient, uiManager, _ := setupEditTest(t, ui.ActionEditSubject)
+
+	uiManager.On("EditSubject", "feat: add new feature").Return("feat: add login form ", nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	gitClient.On("Commit", mock.Anything, "feat: add login form").Return(nil)
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "feat: add login form")
+	uiManager.AssertNotCalled(t, "EditMessage", mock.Anything)
+}
+
+func TestGenerateAndCommit_EditSubjectEmptyCancels(t *testing.T) {
+	service, gitClient, uiManager, _ := setupEditTest(t, ui.ActionEditSubject)
+
+	uiManager.On("EditSubject", "feat: add new feature").Return("  ", nil)
+	uiManager.On("ShowSuccess", "Commit cancelled due to empty commit message").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+	uiManager.AssertExpectations(t)
+}
+
+func TestWithSubject(t *testing.T) {
+	structured := &ai.GenerateResponse{Subject: "feat: add login", Body: "Adds the form.", Footer: "Refs: #12"}
+	edited := withSubject(structured, "feat(auth): add login form")
+	assert.Equal(t, "feat(auth): add login form", edited.Subject)
+	assert.Equal(t, "Adds the form.", edited.Body)
+	assert.Equal(t, "Refs: #12", edited.Footer)
+	assert.Equal(t, "feat: add login", structured.Subject, "the original is not changed")
+
+	raw := &ai.GenerateResponse{RawText: "feat: add login\n\nAdds the form."}
+	assert.Equal(t, "feat: add login", messageSubject(raw))
+	assert.Equal(t, "feat(auth): add login form\n\nAdds the form.", withSubject(raw, "feat(auth): add login form").RawText)
+}
+
+func TestGenerateAndCommit_Regenerate(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		History: config.HistoryConfig{Enabled: false},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+
+	// Setup mock expectations
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{
+		Chunks:           chunks,
+		TotalSize:        100,
+		RequiresChunking: false,
+	}
+	response1 := &ai.GenerateResponse{
+		Subject: "feat: first attempt",
+		RawText: "feat: first attempt",
+	}
+	response2 := &ai.GenerateResponse{
+		Subject: "feat: second attempt",
+		RawText: "feat: second attempt",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+	gitClient.On("Commit", mock.Anything, "feat: second attempt").Return(nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	// First call returns response1, second call returns response2
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.PreviousAttempt == ""
+	})).Return(response1, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.PreviousAttempt != ""
+	})).Return(response2, nil).Once()
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response1).Return(nil).Once()
+	uiManager.On("DisplayComparison", response1, response2).Return(nil).Once()
+	// First prompt returns regenerate, second returns accept
+	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowError", mock.Anything).Return()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	// Verify AI was called twice
+	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 2)
+}
+
+func TestGenerateAndCommit_PickEarlierAttempt(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		History: config.HistoryConfig{Enabled: false},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+
+	// Setup mock expectations
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{
+		Chunks:           chunks,
+		TotalSize:        100,
+		RequiresChunking: false,
+	}
+	response1 := &ai.GenerateResponse{
+		Subject: "feat: first attempt",
+		RawText: "feat: first attempt",
+	}
+	response2 := &ai.GenerateResponse{
+		Subject: "feat: second attempt",
+		RawText: "feat: second attempt",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+	gitClient.On("Commit", mock.Anything, "feat: first attempt").Return(nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response1, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response2, nil).Once()
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response1).Return(nil).Twice()
+	uiManager.On("DisplayComparison", response1, response2).Return(nil).Once()
+	// Regenerate, go back to the first attempt, then accept it
+	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionPickAttempt, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+	uiManager.On("SelectAttempt", []*ai.GenerateResponse{response1, response2}).Return(0, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	uiManager.AssertExpectations(t)
+	gitClient.AssertCalled(t, "Commit", mock.Anything, "feat: first attempt")
+}
+
+func TestGenerateAndCommit_PickAttemptWithoutHistory(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+
+	// Setup mock expectations
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{
+		Chunks:           chunks,
+		TotalSize:        100,
+		RequiresChunking: false,
+	}
+	response := &ai.GenerateResponse{
+		Subject: "feat: add new feature",
+		RawText: "feat: add new feature",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionPickAttempt, nil).Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil).Once()
+	uiManager.On("ShowError", mock.MatchedBy(func(err error) bool {
+		return strings.Contains(err.Error(), "no earlier attempts")
+	})).Return().Once()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+	uiManager.On("ShowSuccess", "Commit cancelled").Return()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	uiManager.AssertExpectations(t)
+	uiManager.AssertNotCalled(t, "SelectAttempt", mock.Anything)
+}
+
+func TestGenerateAndCommit_MaxRegenerationAttempts(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+
+	// Setup mock expectations
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{
+		Chunks:           chunks,
+		TotalSize:        100,
+		RequiresChunking: false,
+	}
+	response := &ai.GenerateResponse{
+		Subject: "feat: attempt",
+		RawText: "feat: attempt",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+	uiManager.On("DisplayComparison", mock.Anything, mock.Anything).Return(nil)
+	// Always return regenerate to hit the limit
+	uiManager.On("PromptAction").Return(ui.ActionRegenerate, nil)
+	uiManager.On("ShowError", mock.Anything).Return()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "maximum regeneration attempts reached")
+	// Should be called MaxRegenerationAttempts times (initial + 4 regenerations, then error on 5th)
+	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", MaxRegenerationAttempts)
+}
+
+func TestGenerateAndCommit_ChunkedDiff(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	progressSpinner := &MockProgressSpinner{}
+	cfg := &config.Config{
+		History: config.HistoryConfig{Enabled: false},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
+
+	// Create large content to trigger two-phase processing (> 10KB total)
+	largeContent := strings.Repeat("x", 4*1024) // 4KB each
+
+	// Setup mock expectations with large diff (over 10KB threshold)
+	// Two-phase processing triggers when totalSize > 10*1024 AND fileCount > 1
+	chunks := []git.DiffChunk{
+		{FilePath: "file1.go", ChangeType: git.ChangeTypeModified, Content: largeContent},
+		{FilePath: "file2.go", ChangeType: git.ChangeTypeAdded, Content: largeContent},
+		{FilePath: "file3.go", ChangeType: git.ChangeTypeModified, Content: largeContent},
+	}
+	stats := &git.DiffStats{TotalFiles: 3, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{
+		Chunks:           chunks,
+		TotalSize:        12 * 1024, // Over 10KB threshold
+		RequiresChunking: true,
+		Summary:          "Summary of changes",
+	}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+	gitClient.On("Commit", mock.Anything, mock.Anything).Return(nil)
+	gitClient.On("HasRemote", mock.Anything).Return(false, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	// Two-phase: 3 summarize calls (one per file group) + 1 final generate call = 4 calls
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{
+		Subject: "feat: chunk change",
+		RawText: "feat: chunk change",
+	}, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowProgressSpinner", mock.Anything, mock.Anything).Return(progressSpinner)
+	uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowError", mock.Anything).Return()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+	progressSpinner.On("Start").Return()
+	progressSpinner.On("Stop").Return()
+	progressSpinner.On("SetCurrent", mock.Anything).Return()
+	progressSpinner.On("SetCurrentFile", mock.Anything).Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	// Two-phase: 3 file groups summarized + 1 final generation = 4 AI calls
+	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 4)
+}
+
+func TestGenerateAndCommit_Preset(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		Generation: config.GenerationConfig{Preset: "minimal"},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
+	response := &ai.GenerateResponse{Subject: "feat: add feature", RawText: "feat: add feature"}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Preset == ai.PresetMinimal
+	})).Return(response, nil)
+	aiProvider.On("Name").Return("test-provider").Maybe()
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowInfo", mock.Anything).Maybe()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_RecentCommits(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		Generation: config.GenerationConfig{RecentCommits: 3},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
+	response := &ai.GenerateResponse{Subject: "refactor(ui): extract view", RawText: "refactor(ui): extract view"}
+	recent := []string{"refactor(ui): split manager", "feat(ai): add presets"}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+	gitClient.On("GetRecentCommits", mock.Anything, 3).Return(recent, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return len(req.RecentCommits) == 2 && req.RecentCommits[0] == recent[0]
+	})).Return(response, nil)
+	aiProvider.On("Name").Return("test-provider").Maybe()
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowInfo", mock.Anything).Maybe()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	gitClient.AssertExpectations(t)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_Squash(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{
+		Generation: config.GenerationConfig{RecentCommits: 3},
+	}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+	chunks := []git.DiffChunk{
+		{FilePath: "auth.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
+	response := &ai.GenerateResponse{Subject: "feat(auth): add token refresh", RawText: "feat(auth): add token refresh"}
+	squashed := []string{"feat(auth): add token refresh", "fix(auth): typo in refresh"}
+
+	// The squashed commits replace the recent commits, which would be the same ones
+	gitClient.On("GetCommitMessages", mock.Anything, "main").Return(squashed, nil)
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return len(req.SquashedCommits) == 2 && len(req.RecentCommits) == 0
+	})).Return(response, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowInfo", mock.Anything).Maybe()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true, SquashBase: "main"})
+
+	assert.NoError(t, err)
+	gitClient.AssertExpectations(t)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_SquashNothing(t *testing.T) {
+	tests := []struct {
+		name       string
+		messages   []string
+		hasChanges bool
+		wantMsg    string
+	}{
+		{"no commits", []string{}, true, "no commits to squash since main"},
+		{"no changes", []string{"feat: add", "revert: add"}, false, "the commits since main change nothing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitClient := &MockGitClient{}
+			service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, nil)
+
+			gitClient.On("GetCommitMessages", mock.Anything, "main").Return(tt.messages, nil)
+			gitClient.On("HasStagedChanges", mock.Anything).Return(tt.hasChanges, nil)
+
+			err := service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true, SquashBase: "main"})
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestGetRecentCommits_ErrorIgnored(t *testing.T) {
+	gitClient := &MockGitClient{}
+	cfg := &config.Config{Generation: config.GenerationConfig{RecentCommits: 5}}
+	service := NewCommitService(gitClient, nil, nil, nil, nil, cfg)
+
+	gitClient.On("GetRecentCommits", mock.Anything, 5).Return(nil, errors.New("git log failed"))
+
+	assert.Nil(t, service.getRecentCommits(context.Background()))
+}
+
+func TestGenerateFromSummaries_Preset(t *testing.T) {
+	tests := []struct {
+		preset string
+		want   string
+	}{
+		{"minimal", "不要 Body"},
+		{"standard", "不超过 3 行"},
+		{"detailed", "每个模块一行"},
+		{"", "不超过 3 行"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			aiProvider := &MockAIProvider{}
+			cfg := &config.Config{Generation: config.GenerationConfig{Preset: tt.preset}}
+			service := NewCommitService(nil, aiProvider, nil, nil, nil, cfg)
+
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+				return strings.Contains(req.CustomPrompt, tt.want)
+			})).Return(&ai.GenerateResponse{Subject: "feat: x"}, nil)
+
+			_, err := service.generateFromSummaries(context.Background(), []string{"- a.go: change"}, &git.DiffStats{}, nil, "", "", "", ai.Intent{})
+
+			assert.NoError(t, err)
+			aiProvider.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGenerateFromSummaries_Context(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	service := NewCommitService(nil, aiProvider, nil, nil, nil, &config.Config{})
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "开发者说明的改动意图") &&
+			strings.Contains(req.CustomPrompt, "fixes the race in batch uploader")
+	})).Return(&ai.GenerateResponse{Subject: "fix: x"}, nil)
+
+	_, err := service.generateFromSummaries(context.Background(), []string{"- a.go: change"}, &git.DiffStats{}, nil, "", "", "fixes the race in batch uploader", ai.Intent{})
+
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_Context(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "uploader.go", ChangeType: git.ChangeTypeModified, Content: "+mu.Lock()"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 10}
+	response := &ai.GenerateResponse{Subject: "fix(upload): 修复批量上传竞态", RawText: "fix(upload): 修复批量上传竞态"}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Context == "fixes the race in batch uploader"
+	})).Return(response, nil)
+	aiProvider.On("Name").Return("test-provider").Maybe()
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	uiManager.On("ShowInfo", mock.Anything).Maybe()
+	uiManager.On("ShowError", mock.Anything).Maybe()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{DryRun: true, Context: "fixes the race in batch uploader"})
+
+	assert.NoError(t, err)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateCommitMessage_IntentRetry(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{{FilePath: "auth.go", Content: "diff"}}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}
+	intent := ai.Intent{Type: "fix", Scope: "auth"}
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.Intent == intent && req.PreviousAttempt == ""
+	})).Return(&ai.GenerateResponse{Subject: "feat(login): 支持记住密码", RawText: "feat(login): 支持记住密码"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.PreviousAttempt, `instead of "fix"`)
+	})).Return(&ai.GenerateResponse{Subject: "fix(auth): 修复记住密码", RawText: "fix(auth): 修复记住密码"}, nil).Once()
+	aiProvider.On("Name").Return("test-provider").Maybe()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	response, err := service.generateCommitMessage(context.Background(), processedDiff, &git.DiffStats{}, nil, "", "", "", "", intent, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fix(auth): 修复记住密码", response.Subject)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateCommitMessage_IntentRewrite(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{})
+
+	chunks := []git.DiffChunk{{FilePath: "auth.go", Content: "diff"}}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 4}
+
+	// The model keeps its own classification, so the subject is rewritten
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).
+		Return(&ai.GenerateResponse{Subject: "feat(login): 支持记住密码", Body: "- login: 新增选项", RawText: "feat(login): 支持记住密码\n\n- login: 新增选项"}, nil).Twice()
+	aiProvider.On("Name").Return("test-provider").Maybe()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	response, err := service.generateCommitMessage(context.Background(), processedDiff, &git.DiffStats{}, nil, "", "", "", "", ai.Intent{Type: "fix"}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fix(login): 支持记住密码", response.Subject)
+	assert.Equal(t, "fix(login): 支持记住密码\n\n- login: 新增选项", response.RawText)
+	assert.Equal(t, "- login: 新增选项", response.Body)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateAndCommit_NoChangesAfterFiltering(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	historyMgr := &MockHistoryManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, historyMgr, cfg)
 
 	// Setup mock expectations - all files are lock files
 	chunks := []git.DiffChunk{
@@ -804,3 +1647,47 @@ func TestFormatCommitMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAndWarn_BreakingChange(t *testing.T) {
+	uiManager := &MockUIManager{}
+	service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+	uiManager.On("ShowError", errors.New("message declares a breaking change: config.yaml is now gitsage.yaml")).Return().Once()
+
+	service.validateAndWarn(&ai.GenerateResponse{RawText: "feat!: rename config\n\nBREAKING CHANGE: config.yaml is now gitsage.yaml"})
+	uiManager.AssertExpectations(t)
+
+	// Messages without a breaking change show nothing
+	service.validateAndWarn(&ai.GenerateResponse{RawText: "feat: add export"})
+	uiManager.AssertNumberOfCalls(t, "ShowError", 1)
+}
+
+func TestGenerateAndCommit_NoTerminalWithoutYes(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+
+	chunks := []git.DiffChunk{{FilePath: "main.go", Additions: 1, Content: "+fmt.Println()"}}
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 1, Chunks: chunks}, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).
+		Return(&ai.GenerateResponse{Subject: "feat: print", RawText: "feat: print"}, nil)
+	aiProvider.On("Name").Return("test-provider").Maybe()
+
+	// Stdin is not a terminal, as with "git diff | gitsage commit", even
+	// when the tests run in one
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	require.NoError(t, err)
+	defer stdin.Close()
+	orig := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = orig }()
+
+	uiManager := ui.NewManager(ui.Options{})
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+
+	err = service.GenerateAndCommit(context.Background(), &CommitOptions{})
+	assert.ErrorIs(t, err, ui.ErrInputRequired)
+	gitClient.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+}
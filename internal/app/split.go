@@ -0,0 +1,238 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// splitGroup is a set of changed files committed together by commit --split.
+type splitGroup struct {
+	// dir is the matched package directory; empty for the cross-package group.
+	dir    string
+	scope  string
+	chunks []git.DiffChunk
+}
+
+// ValidateScopeRules checks that every rule has a path with valid glob
+// segments and a scope usable in a commit title.
+func ValidateScopeRules(rules []config.ScopeRule) error {
+	for _, rule := range rules {
+		dir := strings.Trim(rule.Path, "/")
+		if dir == "" {
+			return fmt.Errorf("scope rule for %q has no path", rule.Scope)
+		}
+		for _, segment := range strings.Split(dir, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid scope rule path %q: %w", rule.Path, err)
+			}
+		}
+		if err := (ai.Intent{Scope: rule.Scope}).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchScopeRule returns the package directory containing filePath and its
+// scope, using the first matching rule. ok is false if no rule matches.
+func matchScopeRule(rules []config.ScopeRule, filePath string) (dir, scope string, ok bool) {
+	segments := strings.Split(filePath, "/")
+	for _, rule := range rules {
+		pattern := strings.Split(strings.Trim(rule.Path, "/"), "/")
+		// The file must be inside the directory, not the directory itself
+		if len(segments) <= len(pattern) {
+			continue
+		}
+
+		matched := true
+		for i, segment := range pattern {
+			if ok, _ := path.Match(segment, segments[i]); !ok {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		dir = strings.Join(segments[:len(pattern)], "/")
+		scope = rule.Scope
+		if scope == "" {
+			scope = segments[len(pattern)-1]
+		}
+		return dir, scope, true
+	}
+	return "", "", false
+}
+
+// partitionByScope groups the changed files by package directory, in the
+// order the packages first appear. Files outside every package, and renames
+// across packages, form a final cross-package group.
+func partitionByScope(rules []config.ScopeRule, chunks []git.DiffChunk) []splitGroup {
+	var groups []splitGroup
+	var cross []git.DiffChunk
+	index := make(map[string]int)
+
+	for _, chunk := range chunks {
+		dir, scope, ok := matchScopeRule(rules, chunk.FilePath)
+		if ok && chunk.OldPath != "" && chunk.OldPath != chunk.FilePath {
+			oldDir, _, oldOK := matchScopeRule(rules, chunk.OldPath)
+			ok = oldOK && oldDir == dir
+		}
+		if !ok {
+			cross = append(cross, chunk)
+			continue
+		}
+
+		i, seen := index[dir]
+		if !seen {
+			i = len(groups)
+			index[dir] = i
+			groups = append(groups, splitGroup{dir: dir, scope: scope})
+		}
+		groups[i].chunks = append(groups[i].chunks, chunk)
+	}
+
+	if len(cross) > 0 {
+		groups = append(groups, splitGroup{chunks: cross})
+	}
+	return groups
+}
+
+// paths returns the paths to stage for the group, including rename sources.
+func (g splitGroup) paths() []string {
+	var paths []string
+	for _, chunk := range g.chunks {
+		paths = append(paths, chunk.FilePath)
+		if chunk.OldPath != "" && chunk.OldPath != chunk.FilePath {
+			paths = append(paths, chunk.OldPath)
+		}
+	}
+	return paths
+}
+
+// stats returns the diff statistics of the group.
+func (g splitGroup) stats() *git.DiffStats {
+	return newDiffStats(g.chunks)
+}
+
+// commitSplit makes one commit per package group, each with its own generated
+// message, and the cross-package changes last. Only the group being committed
+// is staged; whatever is not committed is staged again at the end.
+func (s *CommitService) commitSplit(
+	ctx context.Context,
+	opts *CommitOptions,
+	groups []splitGroup,
+	recentCommits []string,
+	commitTemplate string,
+) error {
+	processed, groups, err := s.processSplitGroups(ctx, groups)
+	if err != nil {
+		return err
+	}
+
+	// Dry runs only print the messages and leave the staging area alone
+	var tree string
+	if !opts.DryRun {
+		if tree, err = s.gitClient.WriteIndexTree(ctx); err != nil {
+			return fmt.Errorf("failed to save staged changes: %w", err)
+		}
+		defer func() {
+			if err := s.gitClient.StageFromTree(context.WithoutCancel(ctx), tree, nil); err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("split.error.restore"), err))
+			}
+		}()
+	}
+	defer func() { s.deferPush = false }()
+
+	for i, group := range groups {
+		label := group.dir
+		if label == "" {
+			label = i18n.T("split.cross_package")
+		}
+		s.uiManager.ShowInfo(i18n.T("split.group", i+1, len(groups), label, len(group.chunks)))
+
+		if !opts.DryRun {
+			// The last group is the rest of the staged changes
+			var paths []string
+			if i < len(groups)-1 {
+				paths = group.paths()
+			}
+			if err := s.gitClient.StageFromTree(ctx, tree, paths); err != nil {
+				return fmt.Errorf("failed to stage %s: %w", label, err)
+			}
+		}
+
+		groupOpts := *opts
+		if groupOpts.Intent.Scope == "" {
+			groupOpts.Intent.Scope = group.scope
+		}
+
+		s.accepted = false
+		s.deferPush = i < len(groups)-1
+		err := s.generateAndHandleLoop(ctx, &groupOpts, processed[i], group.stats(), recentCommits, commitTemplate, group.chunks, nil)
+		if err != nil {
+			return err
+		}
+		// Cancelling one commit stops the split
+		if !s.accepted {
+			return nil
+		}
+	}
+	return nil
+}
+
+// processSplitGroups processes the diff of each group. Groups left without
+// changes after filtering, such as a package whose only change is its lock
+// file, are folded into the cross-package group.
+func (s *CommitService) processSplitGroups(ctx context.Context, groups []splitGroup) ([]*processor.ProcessedDiff, []splitGroup, error) {
+	var kept []splitGroup
+	var processed []*processor.ProcessedDiff
+	var folded []git.DiffChunk
+
+	for _, group := range groups {
+		if group.dir == "" {
+			folded = append(folded, group.chunks...)
+			continue
+		}
+		diff, err := s.diffProcessor.Process(ctx, group.chunks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to process diff: %w", err)
+		}
+		if len(diff.Chunks) == 0 {
+			folded = append(folded, group.chunks...)
+			continue
+		}
+		kept = append(kept, group)
+		processed = append(processed, diff)
+	}
+
+	if len(folded) > 0 {
+		cross := splitGroup{chunks: folded}
+		diff, err := s.diffProcessor.Process(ctx, cross.chunks)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to process diff: %w", err)
+		}
+		if len(diff.Chunks) == 0 && len(kept) == 0 {
+			return nil, nil, fmt.Errorf("no changes to commit after filtering lock files")
+		}
+		if len(diff.Chunks) == 0 {
+			// Lock files only: commit them with the last package
+			last := len(kept) - 1
+			kept[last].chunks = append(kept[last].chunks, cross.chunks...)
+		} else {
+			kept = append(kept, cross)
+			processed = append(processed, diff)
+		}
+	}
+	return processed, kept, nil
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testScopeRules = []config.ScopeRule{
+	{Path: "services/billing", Scope: "pay"},
+	{Path: "packages/*"},
+}
+
+func TestValidateScopeRules(t *testing.T) {
+	assert.NoError(t, ValidateScopeRules(testScopeRules))
+	assert.Error(t, ValidateScopeRules([]config.ScopeRule{{Scope: "api"}}))
+	assert.Error(t, ValidateScopeRules([]config.ScopeRule{{Path: "packages/[a"}}))
+	assert.Error(t, ValidateScopeRules([]config.ScopeRule{{Path: "api", Scope: "a(b)"}}))
+}
+
+func TestMatchScopeRule(t *testing.T) {
+	tests := []struct {
+		path      string
+		wantDir   string
+		wantScope string
+		wantOK    bool
+	}{
+		{"services/billing/invoice.go", "services/billing", "pay", true},
+		{"packages/ui/src/button.tsx", "packages/ui", "ui", true},
+		{"packages/README.md", "", "", false},
+		{"go.mod", "", "", false},
+		{"services/auth/main.go", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			dir, scope, ok := matchScopeRule(testScopeRules, tt.path)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantDir, dir)
+			assert.Equal(t, tt.wantScope, scope)
+		})
+	}
+}
+
+func TestPartitionByScope(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "packages/ui/a.ts"},
+		{FilePath: "go.mod"},
+		{FilePath: "packages/api/b.go"},
+		{FilePath: "packages/ui/c.ts"},
+		{FilePath: "packages/api/moved.go", OldPath: "packages/ui/moved.go"},
+	}
+
+	groups := partitionByScope(testScopeRules, chunks)
+
+	require.Len(t, groups, 3)
+	assert.Equal(t, "packages/ui", groups[0].dir)
+	assert.Equal(t, []string{"packages/ui/a.ts", "packages/ui/c.ts"}, groups[0].paths())
+	assert.Equal(t, "api", groups[1].scope)
+	assert.Equal(t, "", groups[2].dir)
+	assert.Equal(t, []string{"go.mod", "packages/api/moved.go", "packages/ui/moved.go"}, groups[2].paths())
+}
+
+func TestGenerateAndCommit_Split(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "packages/api/b.go", ChangeType: git.ChangeTypeModified, Content: "api"},
+		{FilePath: "packages/ui/a.ts", ChangeType: git.ChangeTypeModified, Content: "ui"},
+		{FilePath: "go.mod", ChangeType: git.ChangeTypeModified, Content: "mod"},
+	}
+
+	setup := func(cancelSecond bool) (*CommitService, *MockGitClient, *MockAIProvider) {
+		gitClient := &MockGitClient{}
+		aiProvider := &MockAIProvider{}
+		diffProcessor := &MockDiffProcessor{}
+		uiManager := &MockUIManager{}
+		spinner := &MockSpinner{}
+		cfg := &config.Config{Generation: config.GenerationConfig{ScopeRules: testScopeRules}}
+		service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+
+		gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+		gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+		gitClient.On("GetDiffStats", mock.Anything).Return(&git.DiffStats{TotalFiles: 3, Chunks: chunks}, nil)
+		gitClient.On("WriteIndexTree", mock.Anything).Return("tree", nil)
+		gitClient.On("StageFromTree", mock.Anything, "tree", mock.Anything).Return(nil)
+		gitClient.On("Commit", mock.Anything, mock.Anything).Return(nil)
+		gitClient.On("HasRemote", mock.Anything).Return(false, nil)
+		for _, c := range [][]git.DiffChunk{chunks, chunks[:1], chunks[1:2], chunks[2:]} {
+			diffProcessor.On("Process", mock.Anything, c).Return(&processor.ProcessedDiff{Chunks: c, TotalSize: 3}, nil)
+		}
+
+		for _, scope := range []string{"api", "ui"} {
+			response := &ai.GenerateResponse{Subject: "feat(" + scope + "): change", RawText: "feat(" + scope + "): change"}
+			aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+				return req.Intent.Scope == scope
+			})).Return(response, nil)
+		}
+		cross := &ai.GenerateResponse{Subject: "chore: bump module", RawText: "chore: bump module"}
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return req.Intent.Scope == ""
+		})).Return(cross, nil)
+
+		uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+		uiManager.On("ShowInfo", mock.Anything).Return()
+		uiManager.On("DisplayMessage", mock.Anything).Return(nil)
+		uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+		if cancelSecond {
+			uiManager.On("PromptAction").Return(ui.ActionCancel, nil)
+		} else {
+			uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+		}
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		uiManager.On("ShowError", mock.Anything).Maybe()
+		spinner.On("Start").Return()
+		spinner.On("Stop").Return()
+
+		return service, gitClient, aiProvider
+	}
+
+	t.Run("one commit per package, cross-package last", func(t *testing.T) {
+		service, gitClient, _ := setup(false)
+
+		err := service.GenerateAndCommit(context.Background(), &CommitOptions{Split: true})
+
+		require.NoError(t, err)
+		gitClient.AssertNumberOfCalls(t, "Commit", 3)
+		gitClient.AssertCalled(t, "StageFromTree", mock.Anything, "tree", []string{"packages/api/b.go"})
+		gitClient.AssertCalled(t, "StageFromTree", mock.Anything, "tree", []string{"packages/ui/a.ts"})
+		// The cross-package commit takes the rest, then the index is restored
+		gitClient.AssertNumberOfCalls(t, "StageFromTree", 4)
+	})
+
+	t.Run("cancel stops and restages the rest", func(t *testing.T) {
+		service, gitClient, _ := setup(true)
+
+		err := service.GenerateAndCommit(context.Background(), &CommitOptions{Split: true})
+
+		require.NoError(t, err)
+		gitClient.AssertNumberOfCalls(t, "Commit", 1)
+		gitClient.AssertCalled(t, "StageFromTree", mock.Anything, "tree", []string(nil))
+	})
+}
@@ -0,0 +1,36 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"path/filepath"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/stack"
+)
+
+// StackFileName is the file in the git directory caching the detected stack,
+// so the manifests are only parsed again after they change.
+const StackFileName = "gitsage-stack.json"
+
+// getStack returns a one-line summary of the repository's languages and
+// frameworks when generation.detect_stack is set, or an empty string.
+func (s *CommitService) getStack(ctx context.Context) string {
+	if s.config == nil || !s.config.Generation.DetectStack {
+		return ""
+	}
+
+	root, err := s.gitClient.GetRepoRoot(ctx)
+	if err != nil || root == "" {
+		apperrors.Debug("Stack detection disabled: %v", err)
+		return ""
+	}
+	cachePath := ""
+	if gitDir, err := s.gitClient.GetGitDir(ctx); err == nil && gitDir != "" {
+		cachePath = filepath.Join(gitDir, StackFileName)
+	}
+
+	summary := stack.Load(root, cachePath).Summary()
+	apperrors.Debug("Detected stack: %s", summary)
+	return summary
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestGetStack(t *testing.T) {
+	root := t.TempDir()
+	gitDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\nrequire github.com/gin-gonic/gin v1.9.1\n"), 0644))
+
+	enabled := &config.Config{Generation: config.GenerationConfig{DetectStack: true}}
+	service := NewCommitService(&MockGitClient{RepoRoot: root, GitDir: gitDir}, nil, nil, nil, nil, enabled)
+
+	assert.Equal(t, "Languages: Go. Frameworks: Gin.", service.getStack(context.Background()))
+	assert.FileExists(t, filepath.Join(gitDir, StackFileName))
+
+	disabled := NewCommitService(&MockGitClient{RepoRoot: root, GitDir: gitDir}, nil, nil, nil, nil, &config.Config{})
+	assert.Empty(t, disabled.getStack(context.Background()))
+
+	noRepo := NewCommitService(&MockGitClient{}, nil, nil, nil, nil, enabled)
+	assert.Empty(t, noRepo.getStack(context.Background()))
+}
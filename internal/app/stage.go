@@ -0,0 +1,102 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// scratchSuffixes are file name endings of editor backups, merge leftovers and logs.
+var scratchSuffixes = []string{"~", ".swp", ".swo", ".bak", ".orig", ".rej", ".tmp", ".log"}
+
+// scratchNames are file names of OS metadata files.
+var scratchNames = map[string]bool{".DS_Store": true, "Thumbs.db": true}
+
+// scratchDirs are directory names that usually hold local scratch files.
+var scratchDirs = map[string]bool{"tmp": true, "temp": true, "scratch": true}
+
+// isScratchFile reports whether an untracked path looks like a backup or local
+// scratch file that was probably not meant to be committed.
+func isScratchFile(filePath string) bool {
+	trimmed := strings.TrimSuffix(filePath, "/")
+	dirs := strings.Split(trimmed, "/")
+	name := dirs[len(dirs)-1]
+
+	// Untracked directories are listed with a trailing slash
+	if strings.HasSuffix(filePath, "/") && scratchDirs[strings.ToLower(name)] {
+		return true
+	}
+	for _, dir := range dirs[:len(dirs)-1] {
+		if scratchDirs[strings.ToLower(dir)] {
+			return true
+		}
+	}
+
+	if scratchNames[name] {
+		return true
+	}
+	// Emacs auto-save files
+	if strings.HasPrefix(name, "#") && strings.HasSuffix(name, "#") && len(name) > 1 {
+		return true
+	}
+	for _, suffix := range scratchSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileOptions turns unstaged files into picker options. Changes to tracked
+// files and new files are preselected; likely scratch files are not.
+func fileOptions(files []git.UnstagedFile) []ui.FileOption {
+	options := make([]ui.FileOption, 0, len(files))
+	for _, file := range files {
+		option := ui.FileOption{Path: file.Path, Selected: true}
+		switch {
+		case file.Untracked && isScratchFile(file.Path):
+			option.Status = i18n.T("ui.files.status.scratch")
+			option.Selected = false
+		case file.Untracked:
+			option.Status = i18n.T("ui.files.status.untracked")
+		case file.Deleted:
+			option.Status = i18n.T("ui.files.status.deleted")
+		default:
+			option.Status = i18n.T("ui.files.status.modified")
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// stageSelectedFiles lets the user pick which unstaged files to stage and
+// stages them. It fails if nothing is selected.
+func (s *CommitService) stageSelectedFiles(ctx context.Context) error {
+	files, err := s.gitClient.GetUnstagedFiles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list unstaged files: %w", err)
+	}
+
+	selected, err := s.uiManager.SelectFiles(fileOptions(files))
+	if err != nil {
+		return fmt.Errorf("failed to prompt user: %w", err)
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no staged changes. Use 'git add' to stage changes before generating a commit message")
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.staging"))
+	spinner.Start()
+	if err := s.gitClient.AddFiles(ctx, selected); err != nil {
+		spinner.Stop()
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+	spinner.Stop()
+	s.uiManager.ShowSuccess(i18n.T("commit.success.staged", len(selected)))
+	return nil
+}
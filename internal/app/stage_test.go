@@ -0,0 +1,104 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestIsScratchFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"main.go", false},
+		{"docs/notes.md", false},
+		{"main.go~", true},
+		{".main.go.swp", true},
+		{"config.yaml.bak", true},
+		{"service.go.orig", true},
+		{"debug.log", true},
+		{"web/.DS_Store", true},
+		{"#draft.txt#", true},
+		{"tmp/", true},
+		{"scratch/try.go", true},
+		{"internal/tmp/out.json", true},
+		{"template.go", false},
+		{"logs.go", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, isScratchFile(tt.path), tt.path)
+	}
+}
+
+func TestFileOptions(t *testing.T) {
+	options := fileOptions([]git.UnstagedFile{
+		{Path: "main.go"},
+		{Path: "old.go", Deleted: true},
+		{Path: "new.go", Untracked: true},
+		{Path: "new.go~", Untracked: true},
+	})
+
+	assert.Equal(t, []string{"main.go", "old.go", "new.go"}, selectedOptionPaths(options))
+	assert.Equal(t, "looks like a scratch file", options[3].Status)
+}
+
+// selectedOptionPaths returns the paths of the preselected options.
+func selectedOptionPaths(options []ui.FileOption) []string {
+	var paths []string
+	for _, option := range options {
+		if option.Selected {
+			paths = append(paths, option.Path)
+		}
+	}
+	return paths
+}
+
+func TestStageSelectedFiles(t *testing.T) {
+	gitClient := &MockGitClient{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(gitClient, nil, nil, uiManager, nil, &config.Config{})
+
+	gitClient.On("GetUnstagedFiles", mock.Anything).Return([]git.UnstagedFile{
+		{Path: "main.go"},
+		{Path: "main.go.bak", Untracked: true},
+	}, nil)
+	uiManager.On("SelectFiles", mock.MatchedBy(func(files []ui.FileOption) bool {
+		return len(files) == 2 && files[0].Selected && !files[1].Selected
+	})).Return([]string{"main.go"}, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+	gitClient.On("AddFiles", mock.Anything, []string{"main.go"}).Return(nil)
+	uiManager.On("ShowSuccess", "Staged 1 file(s)").Return()
+
+	err := service.stageSelectedFiles(context.Background())
+
+	assert.NoError(t, err)
+	gitClient.AssertExpectations(t)
+	uiManager.AssertExpectations(t)
+}
+
+func TestStageSelectedFiles_NothingSelected(t *testing.T) {
+	gitClient := &MockGitClient{}
+	uiManager := &MockUIManager{}
+	service := NewCommitService(gitClient, nil, nil, uiManager, nil, &config.Config{})
+
+	gitClient.On("GetUnstagedFiles", mock.Anything).Return([]git.UnstagedFile{{Path: "main.go~", Untracked: true}}, nil)
+	uiManager.On("SelectFiles", mock.Anything).Return(nil, nil)
+
+	err := service.stageSelectedFiles(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no staged changes")
+	gitClient.AssertNotCalled(t, "AddFiles", mock.Anything, mock.Anything)
+}
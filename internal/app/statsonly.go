@@ -0,0 +1,22 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// withoutContent returns a copy of the diff whose chunks keep only their
+// paths, change types and line counts. In stats-only mode it is all that is
+// sent to the AI, including the group summaries of large diffs, so no diff
+// content leaves the machine.
+func withoutContent(diff *processor.ProcessedDiff) *processor.ProcessedDiff {
+	chunks := make([]git.DiffChunk, len(diff.Chunks))
+	for i, chunk := range diff.Chunks {
+		chunk.Content = ""
+		// Symlink targets are file content too
+		chunk.OldTarget, chunk.NewTarget = "", ""
+		chunks[i] = chunk
+	}
+	return &processor.ProcessedDiff{Chunks: chunks}
+}
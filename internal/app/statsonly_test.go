@@ -0,0 +1,78 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+func TestWithoutContent(t *testing.T) {
+	diff := &processor.ProcessedDiff{
+		Chunks: []git.DiffChunk{
+			{FilePath: "a.go", ChangeType: git.ChangeTypeModified, Additions: 3, Deletions: 1, Content: "+secret"},
+			{FilePath: "link", ChangeType: git.ChangeTypeSymlink, OldTarget: "old/target", NewTarget: "new/target"},
+		},
+		TotalSize:        7,
+		RequiresChunking: true,
+	}
+
+	stripped := withoutContent(diff)
+
+	assert.Equal(t, []git.DiffChunk{
+		{FilePath: "a.go", ChangeType: git.ChangeTypeModified, Additions: 3, Deletions: 1},
+		{FilePath: "link", ChangeType: git.ChangeTypeSymlink},
+	}, stripped.Chunks)
+	assert.False(t, stripped.RequiresChunking)
+	assert.Equal(t, "+secret", diff.Chunks[0].Content, "the original diff is kept for the commit")
+}
+
+func TestGenerateCommitMessage_StatsOnly(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{
+		Generation: config.GenerationConfig{StatsOnly: true},
+	})
+
+	processedDiff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "internal/auth/token.go", Additions: 12, Deletions: 4, Content: "+secret := \"hunter2\""},
+	}}
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return req.StatsOnly && len(req.DiffChunks) == 1 && req.DiffChunks[0].Content == "" && req.DiffChunks[0].Additions == 12
+	})).Return(&ai.GenerateResponse{Subject: "fix(auth): rotate tokens"}, nil).Once()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	response, err := service.generateCommitMessage(context.Background(), processedDiff, &git.DiffStats{TotalFiles: 1}, nil, "", "", "", "", ai.Intent{}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fix(auth): rotate tokens", response.Subject)
+	aiProvider.AssertExpectations(t)
+}
+
+func TestStatsOnly_SkipsExamplesAndCritic(t *testing.T) {
+	critic := &MockAIProvider{}
+	service := NewCommitService(nil, nil, nil, nil, nil, &config.Config{
+		Provider: config.ProviderConfig{Name: "ollama"},
+		Generation: config.GenerationConfig{
+			FewShot:   "always",
+			Examples:  []config.Example{{Diff: "+a", Message: "feat: a"}},
+			StatsOnly: true,
+		},
+	})
+	service.SetCritic(critic)
+
+	assert.Empty(t, service.getExamples(context.Background()))
+	assert.Nil(t, service.verifyMessage(context.Background(), &processor.ProcessedDiff{}, &ai.GenerateResponse{Subject: "feat: x"}))
+	critic.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,179 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// summaryCache holds the file group summaries of two-phase generation for
+// the session, by group key and by file key. The diff does not change
+// between regenerations, so only the final request is repeated. It is safe
+// for concurrent use.
+type summaryCache struct {
+	mu      sync.Mutex
+	entries map[string]string
+}
+
+// get returns the cached summary for key.
+func (c *summaryCache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	summary, ok := c.entries[key]
+	return summary, ok
+}
+
+// set caches the summary for key.
+func (c *summaryCache) set(key, summary string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]string)
+	}
+	c.entries[key] = summary
+}
+
+// fileKey returns a hash of the path and diff content of one file.
+func fileKey(chunk git.DiffChunk) string {
+	h := sha256.New()
+	h.Write([]byte(chunk.FilePath))
+	h.Write([]byte{0})
+	h.Write([]byte(chunk.Content))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// compositeKey returns a hash of the file keys of the chunks, in order.
+// Changing one file changes it, but not the keys of the other files.
+func compositeKey(chunks []git.DiffChunk) string {
+	h := sha256.New()
+	for _, chunk := range chunks {
+		h.Write([]byte(fileKey(chunk)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// groupKey returns the composite key of a file group.
+func groupKey(group fileGroup) string {
+	return compositeKey(group.chunks)
+}
+
+// summarizeFileGroupCached returns the group's summary from the session
+// cache, or summarizes it and caches the result. Each file's lines are also
+// cached by file key, so when an edit to one file regroups the others, only
+// the files not summarized before are sent. cached reports whether the AI
+// was not called. Failed summaries are not cached.
+func (s *CommitService) summarizeFileGroupCached(ctx context.Context, group fileGroup) (summary string, cached bool, err error) {
+	key := groupKey(group)
+	if summary, ok := s.summaries.get(key); ok {
+		return summary, true, nil
+	}
+
+	lines := make([]string, len(group.chunks))
+	var missing fileGroup
+	var missingIdx []int
+	for i, chunk := range group.chunks {
+		if line, ok := s.summaries.get(fileKey(chunk)); ok {
+			lines[i] = line
+			continue
+		}
+		missing.chunks = append(missing.chunks, chunk)
+		missing.files = append(missing.files, chunk.FilePath)
+		missingIdx = append(missingIdx, i)
+	}
+
+	if len(missing.chunks) > 0 {
+		summary, err = s.summarizeFileGroup(ctx, missing)
+		if err != nil {
+			return "", false, err
+		}
+		if split, ok := splitSummary(missing.chunks, summary); ok {
+			for j, line := range split {
+				s.summaries.set(fileKey(missing.chunks[j]), line)
+				lines[missingIdx[j]] = line
+			}
+		} else {
+			// Keep the new summary whole, after the lines reused in order
+			lines = append(lines, summary)
+		}
+	}
+
+	var parts []string
+	for _, line := range lines {
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+	summary = strings.Join(parts, "\n")
+	s.summaries.set(key, summary)
+	return summary, len(missing.chunks) == 0, nil
+}
+
+// splitSummary assigns the lines of a group summary to the files of the
+// group. A line belongs to the file it names first, by path or else by base
+// name, and a line naming none continues the previous one. ok is false when
+// a file gets no line or a line cannot be told apart.
+func splitSummary(chunks []git.DiffChunk, summary string) ([]string, bool) {
+	if len(chunks) == 1 {
+		return []string{summary}, true
+	}
+
+	parts := make([][]string, len(chunks))
+	current := -1
+	for _, line := range strings.Split(summary, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		index, ok := summaryFile(chunks, line)
+		if !ok {
+			return nil, false
+		}
+		if index >= 0 {
+			current = index
+		} else if current < 0 {
+			return nil, false
+		}
+		parts[current] = append(parts[current], line)
+	}
+
+	lines := make([]string, len(chunks))
+	for i, part := range parts {
+		if len(part) == 0 {
+			return nil, false
+		}
+		lines[i] = strings.Join(part, "\n")
+	}
+	return lines, true
+}
+
+// summaryFile returns the index of the file a summary line names first, or
+// -1 if it names none. ok is false when base names leave it ambiguous.
+func summaryFile(chunks []git.DiffChunk, line string) (index int, ok bool) {
+	for _, name := range []func(git.DiffChunk) string{
+		func(c git.DiffChunk) string { return c.FilePath },
+		func(c git.DiffChunk) string { return path.Base(c.FilePath) },
+	} {
+		index, pos, length, ambiguous := -1, len(line), 0, false
+		for i, chunk := range chunks {
+			n := name(chunk)
+			p := strings.Index(line, n)
+			switch {
+			case p < 0:
+			case p < pos, p == pos && len(n) > length:
+				index, pos, length, ambiguous = i, p, len(n), false
+			case p == pos && len(n) == length:
+				ambiguous = true
+			}
+		}
+		if index >= 0 {
+			return index, !ambiguous
+		}
+	}
+	return -1, true
+}
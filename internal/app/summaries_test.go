@@ -0,0 +1,249 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+func TestGroupKey(t *testing.T) {
+	a := fileGroup{chunks: []git.DiffChunk{{FilePath: "a.go", Content: "+x"}}}
+	renamed := fileGroup{chunks: []git.DiffChunk{{FilePath: "b.go", Content: "+x"}}}
+	edited := fileGroup{chunks: []git.DiffChunk{{FilePath: "a.go", Content: "+y"}}}
+
+	assert.Equal(t, groupKey(a), groupKey(fileGroup{chunks: []git.DiffChunk{{FilePath: "a.go", Content: "+x"}}}))
+	assert.NotEqual(t, groupKey(a), groupKey(renamed))
+	assert.NotEqual(t, groupKey(a), groupKey(edited))
+}
+
+func TestGenerateWithTwoPhase_ReusesSummaries(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	progress := &MockProgressSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{})
+
+	diff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "a.go", Content: strings.Repeat("a", 3*1024)},
+		{FilePath: "b.go", Content: strings.Repeat("b", 3*1024)},
+	}}
+
+	uiManager.On("ShowProgressSpinner", mock.Anything, 2).Return(progress)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	progress.On("Start").Return()
+	progress.On("Stop").Return()
+	progress.On("SetCurrent", mock.Anything).Return()
+	progress.On("SetCurrentFile", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	isSummary := func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "简要描述")
+	}
+	isFinal := func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "根据以下文件改动摘要")
+	}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return isSummary(req) && strings.Contains(req.CustomPrompt, "a.go")
+	})).Return(&ai.GenerateResponse{RawText: "- a.go: 改动 a"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return isSummary(req) && strings.Contains(req.CustomPrompt, "b.go")
+	})).Return(nil, errors.New("rate limited")).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return isSummary(req) && strings.Contains(req.CustomPrompt, "b.go")
+	})).Return(&ai.GenerateResponse{RawText: "- b.go: 改动 b"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(isFinal)).
+		Return(&ai.GenerateResponse{Subject: "feat: update a and b"}, nil).Times(3)
+
+	for i := 0; i < 3; i++ {
+		_, _, err := service.generateWithTwoPhase(context.Background(), diff, &git.DiffStats{TotalFiles: 2}, nil, "", "", "", ai.Intent{})
+		assert.NoError(t, err)
+	}
+
+	// a.go is summarized once; b.go again after its failed summary, then reused
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateWithTwoPhase_StopEarly(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	progress := &MockProgressSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{})
+
+	// Each file is its own group: one batch of two, then c.go
+	diff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "a.go", Content: strings.Repeat("a", MaxGroupSize)},
+		{FilePath: "b.go", Content: strings.Repeat("b", MaxGroupSize)},
+		{FilePath: "c.go", Content: strings.Repeat("c", MaxGroupSize)},
+	}}
+
+	uiManager.On("ShowProgressSpinner", mock.Anything, 3).Return(progress)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	progress.On("Start").Return()
+	progress.On("Stop").Return()
+	progress.On("SetCurrent", mock.Anything).Return()
+	progress.On("SetCurrentFile", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	var finalPrompt string
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "简要描述")
+	})).Run(func(args mock.Arguments) {
+		// The user presses "s" while the first batch is running
+		service.StopSummarizing()
+	}).Return(&ai.GenerateResponse{RawText: "- 文件改动"}, nil).Twice()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "根据以下文件改动摘要")
+	})).Run(func(args mock.Arguments) {
+		finalPrompt = args.Get(1).(*ai.GenerateRequest).CustomPrompt
+	}).Return(&ai.GenerateResponse{Subject: "feat: update files"}, nil).Once()
+
+	response, stopped, err := service.generateWithTwoPhase(context.Background(), diff, &git.DiffStats{TotalFiles: 3}, nil, "", "", "", ai.Intent{})
+	assert.NoError(t, err)
+	assert.True(t, stopped)
+	assert.Equal(t, "feat: update files", response.Subject)
+	assert.Contains(t, finalPrompt, "- 1 more files changed")
+	assert.NotContains(t, finalPrompt, "c.go")
+	aiProvider.AssertExpectations(t)
+}
+
+func TestGenerateCommitMessage_StoppedSummariesNotCached(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	progress := &MockProgressSpinner{}
+	service := NewCommitService(nil, aiProvider, nil, uiManager, nil, &config.Config{Cache: config.CacheConfig{
+		Enabled:    true,
+		MaxEntries: 10,
+		TTLMinutes: 60,
+		FilePath:   filepath.Join(t.TempDir(), "cache.json"),
+	}})
+
+	diff := &processor.ProcessedDiff{Chunks: []git.DiffChunk{
+		{FilePath: "a.go", Content: strings.Repeat("a", MaxGroupSize)},
+		{FilePath: "b.go", Content: strings.Repeat("b", MaxGroupSize)},
+		{FilePath: "c.go", Content: strings.Repeat("c", MaxGroupSize)},
+	}}
+
+	uiManager.On("ShowProgressSpinner", mock.Anything, 3).Return(progress)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	progress.On("Start").Return()
+	progress.On("Stop").Return()
+	progress.On("SetCurrent", mock.Anything).Return()
+	progress.On("SetCurrentFile", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	// The user presses "s" while the first batch is running
+	aiProvider.On("Name").Return("ollama").Maybe()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "简要描述")
+	})).Run(func(args mock.Arguments) {
+		service.StopSummarizing()
+	}).Return(&ai.GenerateResponse{RawText: "- 文件改动"}, nil).Twice()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "根据以下文件改动摘要")
+	})).Return(&ai.GenerateResponse{Subject: "feat: update files"}, nil).Once()
+
+	response, err := service.generateCommitMessage(context.Background(), diff, &git.DiffStats{TotalFiles: 3}, nil, "", "", "", "", ai.Intent{}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "feat: update files", response.Subject)
+
+	// The next run summarizes every file instead of reusing this message
+	assert.Equal(t, 0, service.cache.Size())
+	aiProvider.AssertExpectations(t)
+}
+
+func TestCompositeKey(t *testing.T) {
+	a := git.DiffChunk{FilePath: "a.go", Content: "+x"}
+	b := git.DiffChunk{FilePath: "b.go", Content: "+y"}
+
+	assert.Equal(t, compositeKey([]git.DiffChunk{a, b}), compositeKey([]git.DiffChunk{a, b}))
+	// Moving content between files is a different diff
+	assert.NotEqual(t, compositeKey([]git.DiffChunk{a, b}),
+		compositeKey([]git.DiffChunk{{FilePath: "a.go", Content: "+x+y"}, {FilePath: "b.go"}}))
+	assert.NotEqual(t, fileKey(a), fileKey(git.DiffChunk{FilePath: "a.go", Content: "+z"}))
+}
+
+func TestSplitSummary(t *testing.T) {
+	chunks := []git.DiffChunk{{FilePath: "internal/a.go"}, {FilePath: "pkg/a.go"}, {FilePath: "cmd/main.go"}}
+
+	tests := []struct {
+		name    string
+		summary string
+		want    []string
+		ok      bool
+	}{
+		{
+			name:    "one line per file",
+			summary: "- internal/a.go: 改动 a\n- pkg/a.go: 改动 b\n- main.go: 调用 a.go",
+			want:    []string{"- internal/a.go: 改动 a", "- pkg/a.go: 改动 b", "- main.go: 调用 a.go"},
+			ok:      true,
+		},
+		{
+			name:    "continuation lines",
+			summary: "- internal/a.go: 改动 a\n  细节\n\n- pkg/a.go: 改动 b\n- cmd/main.go: 入口",
+			want:    []string{"- internal/a.go: 改动 a\n  细节", "- pkg/a.go: 改动 b", "- cmd/main.go: 入口"},
+			ok:      true,
+		},
+		{name: "file without a line", summary: "- internal/a.go: 改动 a\n- pkg/a.go: 改动 b"},
+		{name: "ambiguous base name", summary: "- a.go: 改动\n- pkg/a.go: 改动 b\n- main.go: 入口"},
+		{name: "leading line names no file", summary: "总结:\n- internal/a.go: 改动 a\n- pkg/a.go: 改动 b\n- main.go: 入口"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := splitSummary(chunks, tt.summary)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSummarizeFileGroupCached_ReusesUnchangedFiles(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	service := NewCommitService(nil, aiProvider, nil, nil, nil, &config.Config{})
+
+	a := git.DiffChunk{FilePath: "a.go", Content: "+a"}
+	b := git.DiffChunk{FilePath: "b.go", Content: "+b"}
+	edited := git.DiffChunk{FilePath: "b.go", Content: "+b2"}
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "+a")
+	})).Return(&ai.GenerateResponse{RawText: "- a.go: 改动 a\n- b.go: 改动 b"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return !strings.Contains(req.CustomPrompt, "+a") && strings.Contains(req.CustomPrompt, "+b2")
+	})).Return(&ai.GenerateResponse{RawText: "- b.go: 新改动"}, nil).Once()
+
+	summary, cached, err := service.summarizeFileGroupCached(context.Background(), fileGroup{chunks: []git.DiffChunk{a, b}, files: []string{"a.go", "b.go"}})
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, "- a.go: 改动 a\n- b.go: 改动 b", summary)
+
+	// Editing b.go only sends b.go
+	summary, cached, err = service.summarizeFileGroupCached(context.Background(), fileGroup{chunks: []git.DiffChunk{a, edited}, files: []string{"a.go", "b.go"}})
+	assert.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, "- a.go: 改动 a\n- b.go: 新改动", summary)
+
+	// A regrouped file is reused without a request
+	summary, cached, err = service.summarizeFileGroupCached(context.Background(), fileGroup{chunks: []git.DiffChunk{a}, files: []string{"a.go"}})
+	assert.NoError(t, err)
+	assert.True(t, cached)
+	assert.Equal(t, "- a.go: 改动 a", summary)
+
+	aiProvider.AssertExpectations(t)
+}
@@ -0,0 +1,147 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// SummaryOptions contains options for the summary workflow.
+type SummaryOptions struct {
+	// Range is the revision range summarized, e.g. main..feature; empty
+	// summarizes the staged changes. The git client must diff the same range.
+	Range string
+	// OutputFile receives the summary instead of stdout.
+	OutputFile string
+}
+
+// Summarize writes a plain-language summary of the staged changes or a
+// revision range, for stand-up notes or review preparation. Nothing is
+// committed. Large diffs are summarized in two phases like commit messages.
+func (s *CommitService) Summarize(ctx context.Context, opts *SummaryOptions) error {
+	if opts == nil {
+		opts = &SummaryOptions{}
+	}
+
+	hasChanges, err := s.gitClient.HasStagedChanges(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check staged changes: %w", err)
+	}
+	if !hasChanges {
+		if opts.Range != "" {
+			return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("no changes in %s", opts.Range))
+		}
+		return apperrors.NewNoStagedChangesError()
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.retrieving"))
+	spinner.Start()
+	diffChunks, err := s.gitClient.GetStagedDiff(ctx)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	processedDiff, err := s.diffProcessor.Process(ctx, diffChunks)
+	if err != nil {
+		return fmt.Errorf("failed to process diff: %w", err)
+	}
+	if len(processedDiff.Chunks) == 0 {
+		return fmt.Errorf("no changes to summarize after filtering lock files")
+	}
+
+	confirmed, err := s.confirmPendingPayload(func() string { return s.describePayload(diffChunks, processedDiff) })
+	if err != nil {
+		return fmt.Errorf("failed to confirm payload: %w", err)
+	}
+	if !confirmed {
+		s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+		return nil
+	}
+
+	if err := s.checkProviderHealth(ctx); err != nil {
+		return fmt.Errorf("failed to generate summary: %w", err)
+	}
+	summary, err := s.generateSummary(ctx, processedDiff, opts.Range)
+	if err != nil {
+		return fmt.Errorf("failed to generate summary: %w", err)
+	}
+
+	// Like a dry run, nothing is written or shown after cancellation
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if opts.OutputFile != "" {
+		if err := writeFile(opts.OutputFile, []byte(summary+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write to file %s: %w", opts.OutputFile, err)
+		}
+		s.uiManager.ShowSuccess(i18n.T("summary.success.written", opts.OutputFile))
+		return nil
+	}
+	s.uiManager.ShowInfo(summary)
+	return nil
+}
+
+// generateSummary asks the AI to summarize the changes. Diffs too large for
+// one request are first summarized per file group, as in two-phase generation.
+func (s *CommitService) generateSummary(ctx context.Context, processedDiff *processor.ProcessedDiff, revisionRange string) (string, error) {
+	var details string
+	if s.useTwoPhase(processedDiff) {
+		summaries, _, err := s.summarizeChunks(ctx, processedDiff.Chunks)
+		if err != nil {
+			return "", err
+		}
+		details = "[[FILE SUMMARIES]]\n" + strings.Join(summaries, "\n") + "\n"
+	} else {
+		var diff strings.Builder
+		for _, chunk := range processedDiff.Chunks {
+			diff.WriteString(fmt.Sprintf("=== %s ===\n%s\n\n", chunk.FilePath, chunk.Content))
+		}
+		details = "[[DIFF]]\n" + diff.String()
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("summary.spinner.generating"))
+	spinner.Start()
+	defer spinner.Stop()
+
+	summary, err := s.summarize(ctx, buildSummaryPrompt(processedDiff.Chunks, details, revisionRange))
+	if err != nil {
+		return "", err
+	}
+	if summary == "" {
+		return "", fmt.Errorf("the AI returned an empty summary")
+	}
+	return summary, nil
+}
+
+// buildSummaryPrompt builds the prompt asking for a summary of the changes,
+// given the changed files and either their diff or their group summaries.
+func buildSummaryPrompt(chunks []git.DiffChunk, details, revisionRange string) string {
+	var files strings.Builder
+	for _, chunk := range chunks {
+		files.WriteString(fmt.Sprintf("- %s (%s, +%d -%d)\n", chunk.FilePath, chunk.ChangeType, chunk.Additions, chunk.Deletions))
+	}
+
+	changes := "the staged changes"
+	if revisionRange != "" {
+		changes = "the changes in " + revisionRange
+	}
+
+	return fmt.Sprintf(`You are summarizing %s for a developer's stand-up notes or review preparation. Do not write a commit message.
+
+[[CHANGED FILES]]
+%s
+%s
+[[INSTRUCTION]]
+Start with one or two sentences on the overall purpose of the changes.
+Then list the notable changes as "- " bullets grouped by area, describing behavior rather than individual lines.
+End with the points a reviewer should look at closely, such as risky logic, migrations or removed APIs, if there are any.
+Do not invent changes that are not shown. Output only the summary as plain text.`,
+		changes, files.String(), details)
+}
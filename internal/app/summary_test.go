@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+func newSummaryService(t *testing.T, chunks []git.DiffChunk) (*CommitService, *MockAIProvider, *MockUIManager) {
+	t.Helper()
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(len(chunks) > 0, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	return NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{}), aiProvider, uiManager
+}
+
+func TestSummarize(t *testing.T) {
+	chunks := []git.DiffChunk{{FilePath: "auth/token.go", ChangeType: git.ChangeTypeModified, Additions: 3, Content: "+refresh()"}}
+
+	t.Run("shows the summary", func(t *testing.T) {
+		service, aiProvider, uiManager := newSummaryService(t, chunks)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+			return strings.Contains(req.CustomPrompt, "summarizing the changes in main..HEAD") &&
+				strings.Contains(req.CustomPrompt, "- auth/token.go (modified, +3 -0)") &&
+				strings.Contains(req.CustomPrompt, "[[DIFF]]\n=== auth/token.go ===\n+refresh()")
+		})).Return(&ai.GenerateResponse{RawText: "Adds token refresh.\n\n- auth: refresh before expiry"}, nil).Once()
+		uiManager.On("ShowInfo", "Adds token refresh.\n\n- auth: refresh before expiry").Return().Once()
+
+		require.NoError(t, service.Summarize(context.Background(), &SummaryOptions{Range: "main..HEAD"}))
+		aiProvider.AssertExpectations(t)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("writes the summary to a file", func(t *testing.T) {
+		service, aiProvider, uiManager := newSummaryService(t, chunks)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{RawText: "Adds token refresh."}, nil)
+		uiManager.On("ShowSuccess", mock.Anything).Return()
+		path := filepath.Join(t.TempDir(), "summary.md")
+
+		require.NoError(t, service.Summarize(context.Background(), &SummaryOptions{OutputFile: path}))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "Adds token refresh.\n", string(data))
+		uiManager.AssertNotCalled(t, "ShowInfo", mock.Anything)
+	})
+
+	t.Run("first use declined after seeing the payload", func(t *testing.T) {
+		service, aiProvider, uiManager := newSummaryService(t, chunks)
+		service.SetConfirmPayload(true)
+		aiProvider.On("Name").Return("openai")
+		uiManager.On("ShowInfo", mock.MatchedBy(func(s string) bool {
+			return strings.Contains(s, "Sent to openai: 1 files") && strings.Contains(s, "auth/token.go")
+		})).Return().Once()
+		uiManager.On("PromptConfirm", "Send this to the AI provider?").Return(false, nil).Once()
+		uiManager.On("ShowSuccess", "Cancelled after reviewing what would be sent; nothing was sent to the AI provider").Return().Once()
+
+		require.NoError(t, service.Summarize(context.Background(), nil))
+		aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("empty reply", func(t *testing.T) {
+		service, aiProvider, _ := newSummaryService(t, chunks)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{}, nil)
+
+		assert.ErrorContains(t, service.Summarize(context.Background(), nil), "empty summary")
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		service, _, _ := newSummaryService(t, nil)
+
+		err := service.Summarize(context.Background(), &SummaryOptions{Range: "v1..v1"})
+		require.True(t, apperrors.IsAppError(err))
+		assert.Equal(t, apperrors.ErrInvalidArguments, apperrors.GetAppError(err).Code)
+		assert.ErrorContains(t, err, "no changes in v1..v1")
+	})
+}
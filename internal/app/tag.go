@@ -0,0 +1,242 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// MaxTagCommits is the maximum number of commit subjects sent to the AI for a tag message.
+const MaxTagCommits = 200
+
+// TagOptions contains options for the tag workflow.
+type TagOptions struct {
+	// Name is the tag to create, e.g. v1.4.0.
+	Name string
+	// Sign creates a GPG-signed tag instead of a plain annotated one.
+	Sign bool
+	// DryRun generates and shows the tag message without creating the tag.
+	DryRun bool
+}
+
+// GenerateAndTag summarizes the commits since the previous tag into an
+// annotated tag message and, once accepted, creates the tag on HEAD.
+func (s *CommitService) GenerateAndTag(ctx context.Context, opts *TagOptions) error {
+	tags, err := s.gitClient.ListTags(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	for _, tag := range tags {
+		if tag == opts.Name {
+			return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("tag %q already exists", opts.Name))
+		}
+	}
+
+	previousTag := ""
+	if len(tags) > 0 {
+		previousTag = tags[0]
+	}
+
+	commits, err := s.gitClient.GetCommitsSince(ctx, previousTag)
+	if err != nil {
+		return fmt.Errorf("failed to read commits: %w", err)
+	}
+	if len(commits) == 0 {
+		if previousTag == "" {
+			return apperrors.New(apperrors.ErrInvalidArguments, "no commits to tag")
+		}
+		return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("no commits since %s", previousTag))
+	}
+
+	confirmed, err := s.confirmPendingPayload(func() string {
+		return i18n.T("payload.commits", s.aiProvider.Name(), len(commits))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to confirm payload: %w", err)
+	}
+	if !confirmed {
+		s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+		return nil
+	}
+
+	var previousAttempt string
+	regenerationCount := 0
+
+	for {
+		if err := s.checkProviderHealth(ctx); err != nil {
+			return fmt.Errorf("failed to generate tag message: %w", err)
+		}
+		response, err := s.generateTagMessage(ctx, opts.Name, previousTag, commits, previousAttempt)
+		if err != nil {
+			return fmt.Errorf("failed to generate tag message: %w", err)
+		}
+
+		if err := s.uiManager.DisplayMessage(response); err != nil {
+			return fmt.Errorf("failed to display message: %w", err)
+		}
+
+		action, err := s.promptTagAction(commits)
+		if err != nil {
+			return fmt.Errorf("failed to get user action: %w", err)
+		}
+
+		switch action {
+		case ui.ActionAccept:
+			return s.createTag(ctx, opts, response)
+
+		case ui.ActionEdit:
+			edited, err := s.uiManager.EditMessage(response)
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.edit"), err))
+				continue
+			}
+			if strings.TrimSpace(s.formatCommitMessage(edited)) == "" {
+				// Like git, an empty message aborts the tag
+				s.uiManager.ShowSuccess(i18n.T("tag.success.empty_message"))
+				return nil
+			}
+			return s.createTag(ctx, opts, edited)
+
+		case ui.ActionEditSubject:
+			subject, err := s.uiManager.EditSubject(messageSubject(response))
+			if err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.edit"), err))
+				continue
+			}
+			if strings.TrimSpace(subject) == "" {
+				s.uiManager.ShowSuccess(i18n.T("tag.success.empty_message"))
+				return nil
+			}
+			return s.createTag(ctx, opts, withSubject(response, strings.TrimSpace(subject)))
+
+		case ui.ActionRegenerate:
+			regenerationCount++
+			if regenerationCount >= MaxRegenerationAttempts {
+				s.uiManager.ShowError(errors.New(i18n.T("commit.error.max_regenerations", MaxRegenerationAttempts)))
+				return fmt.Errorf("maximum regeneration attempts reached")
+			}
+			previousAttempt = s.formatCommitMessage(response)
+			continue
+
+		case ui.ActionCancel:
+			s.uiManager.ShowSuccess(i18n.T("tag.success.cancelled"))
+			return nil
+		}
+	}
+}
+
+// promptTagAction prompts until the user accepts, edits, regenerates or
+// cancels. Viewing the diff lists the commits being tagged.
+func (s *CommitService) promptTagAction(commits []string) (ui.Action, error) {
+	for {
+		action, err := s.uiManager.PromptAction()
+		if err != nil {
+			return action, err
+		}
+
+		switch action {
+		case ui.ActionViewDiff:
+			if err := s.uiManager.ShowDiff(strings.Join(commits, "\n")); err != nil {
+				s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.error.show_diff"), err))
+			}
+		case ui.ActionPickAttempt:
+			s.uiManager.ShowError(errors.New(i18n.T("tag.error.pick_attempt")))
+		case ui.ActionRefresh:
+			s.uiManager.ShowError(errors.New(i18n.T("tag.error.refresh")))
+		case ui.ActionTranslate:
+			s.uiManager.ShowError(errors.New(i18n.T("tag.error.translate")))
+		case ui.ActionPickBullets:
+			s.uiManager.ShowError(errors.New(i18n.T("tag.error.pick_bullets")))
+		default:
+			return action, nil
+		}
+	}
+}
+
+// generateTagMessage asks the AI to summarize the commits into a tag message.
+func (s *CommitService) generateTagMessage(
+	ctx context.Context,
+	name string,
+	previousTag string,
+	commits []string,
+	previousAttempt string,
+) (*ai.GenerateResponse, error) {
+	spinner := s.uiManager.ShowSpinner(i18n.T("tag.spinner.generating"))
+	spinner.Start()
+	defer spinner.Stop()
+
+	req := &ai.GenerateRequest{
+		CustomPrompt: buildTagPrompt(name, previousTag, commits, previousAttempt),
+		Redact:       s.pathRedactor(),
+	}
+	return s.aiProvider.GenerateCommitMessage(ctx, req)
+}
+
+// createTag creates the tag with the accepted message, unless in dry-run mode.
+func (s *CommitService) createTag(ctx context.Context, opts *TagOptions, response *ai.GenerateResponse) error {
+	// Nothing is created after cancellation, even if the user accepted in a
+	// prompt that was still open when Ctrl+C arrived
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	message := s.formatCommitMessage(response)
+
+	if opts.DryRun {
+		s.uiManager.ShowSuccess(i18n.T("tag.success.dry_run"))
+		return nil
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("tag.spinner.creating"))
+	spinner.Start()
+	err := s.gitClient.CreateTag(ctx, opts.Name, message, opts.Sign)
+	spinner.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	s.uiManager.ShowSuccess(i18n.T("tag.success.created", opts.Name))
+	return nil
+}
+
+// buildTagPrompt builds the prompt asking for a tag message that summarizes
+// the commits since the previous tag.
+func buildTagPrompt(name, previousTag string, commits []string, previousAttempt string) string {
+	var list strings.Builder
+	for i, subject := range commits {
+		if i == MaxTagCommits {
+			list.WriteString(fmt.Sprintf("- ... and %d more\n", len(commits)-MaxTagCommits))
+			break
+		}
+		list.WriteString("- " + subject + "\n")
+	}
+
+	since := "the start of the repository"
+	if previousTag != "" {
+		since = previousTag
+	}
+
+	var retry string
+	if previousAttempt != "" {
+		retry = fmt.Sprintf("\n[[PREVIOUS ATTEMPT]]\nThe developer rejected this message; write a different one.\n%s\n", previousAttempt)
+	}
+
+	return fmt.Sprintf(`You are writing the message of the annotated git tag %s.
+
+[[COMMITS SINCE %s]]
+%s%s
+[[INSTRUCTION]]
+Summarize these commits as release notes for %s.
+The first line is a short title naming the release, without a type prefix (e.g. "%s: faster sync and offline mode").
+After a blank line, group the notable changes under "Features", "Fixes" and "Other" as "- " bullet lists, leaving out empty groups.
+Merge related commits into one bullet, describe user-visible effects, and skip purely internal changes such as formatting or CI tweaks.
+Do not invent changes that are not in the list. Output only the tag message.`,
+		name, since, list.String(), retry, name, name)
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndTag(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(gitClient, aiProvider, nil, uiManager, nil, nil)
+
+	response := &ai.GenerateResponse{Subject: "v1.4.0: offline mode", Body: "Features\n- offline mode"}
+
+	gitClient.On("ListTags", mock.Anything).Return([]string{"v1.3.0", "v1.2.0"}, nil)
+	gitClient.On("GetCommitsSince", mock.Anything, "v1.3.0").Return([]string{"feat: offline mode"}, nil)
+	gitClient.On("CreateTag", mock.Anything, "v1.4.0", "v1.4.0: offline mode\n\nFeatures\n- offline mode", true).Return(nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "[[COMMITS SINCE v1.3.0]]\n- feat: offline mode\n")
+	})).Return(response, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndTag(context.Background(), &TagOptions{Name: "v1.4.0", Sign: true})
+
+	require.NoError(t, err)
+	gitClient.AssertExpectations(t)
+}
+
+func TestGenerateAndTag_PayloadDeclined(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	service := NewCommitService(gitClient, aiProvider, nil, uiManager, nil, nil)
+	service.SetConfirmPayload(true)
+
+	gitClient.On("ListTags", mock.Anything).Return([]string{"v1.3.0"}, nil)
+	gitClient.On("GetCommitsSince", mock.Anything, "v1.3.0").Return([]string{"feat: offline mode", "fix: sync"}, nil)
+	aiProvider.On("Name").Return("openai")
+	uiManager.On("ShowInfo", "Sent to openai: the messages of 2 commits").Return().Once()
+	uiManager.On("PromptConfirm", "Send this to the AI provider?").Return(false, nil).Once()
+	uiManager.On("ShowSuccess", "Cancelled after reviewing what would be sent; nothing was sent to the AI provider").Return().Once()
+
+	require.NoError(t, service.GenerateAndTag(context.Background(), &TagOptions{Name: "v1.4.0"}))
+	aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+	gitClient.AssertNotCalled(t, "CreateTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	uiManager.AssertExpectations(t)
+}
+
+func TestGenerateAndTag_DryRun(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	service := NewCommitService(gitClient, aiProvider, nil, uiManager, nil, nil)
+
+	response := &ai.GenerateResponse{Subject: "v0.1.0: first release"}
+
+	gitClient.On("ListTags", mock.Anything).Return(nil, nil)
+	gitClient.On("GetCommitsSince", mock.Anything, "").Return([]string{"feat: init"}, nil)
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("PromptAction").Return(ui.ActionAccept, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndTag(context.Background(), &TagOptions{Name: "v0.1.0", DryRun: true})
+
+	require.NoError(t, err)
+	gitClient.AssertNotCalled(t, "CreateTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateAndTag_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		commits []string
+		wantMsg string
+	}{
+		{"tag exists", []string{"v1.4.0"}, nil, `tag "v1.4.0" already exists`},
+		{"no new commits", []string{"v1.3.0"}, []string{}, "no commits since v1.3.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitClient := &MockGitClient{}
+			service := NewCommitService(gitClient, &MockAIProvider{}, nil, &MockUIManager{}, nil, nil)
+
+			gitClient.On("ListTags", mock.Anything).Return(tt.tags, nil)
+			gitClient.On("GetCommitsSince", mock.Anything, mock.Anything).Return(tt.commits, nil)
+
+			err := service.GenerateAndTag(context.Background(), &TagOptions{Name: "v1.4.0"})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+			assert.Equal(t, apperrors.ErrInvalidArguments, apperrors.GetAppError(err).Code)
+		})
+	}
+}
+
+func TestBuildTagPrompt(t *testing.T) {
+	commits := make([]string, MaxTagCommits+5)
+	for i := range commits {
+		commits[i] = "fix: change"
+	}
+
+	prompt := buildTagPrompt("v2.0.0", "", commits, "v2.0.0: old attempt")
+
+	assert.Contains(t, prompt, "[[COMMITS SINCE the start of the repository]]")
+	assert.Equal(t, MaxTagCommits, strings.Count(prompt, "- fix: change\n"))
+	assert.Contains(t, prompt, "- ... and 5 more")
+	assert.Contains(t, prompt, "[[PREVIOUS ATTEMPT]]")
+	assert.Contains(t, prompt, "v2.0.0: old attempt")
+}
@@ -0,0 +1,54 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// getCommitTemplate returns git's commit.template if generation should follow it.
+// The template only shapes the prompt, so failures are logged and ignored.
+func (s *CommitService) getCommitTemplate(ctx context.Context) string {
+	if s.config == nil || !s.config.Generation.CommitTemplate {
+		return ""
+	}
+
+	template, err := s.gitClient.GetCommitTemplate(ctx)
+	if err != nil {
+		apperrors.Debug("Failed to read commit template: %v", err)
+		return ""
+	}
+	return strings.TrimSpace(template)
+}
+
+// stripTemplateComments removes the "#" comment lines the AI keeps from a
+// commit template, as git does when committing from a template in the editor.
+// Messages are returned unchanged when no template is in use.
+func (s *CommitService) stripTemplateComments(response *ai.GenerateResponse, commitTemplate string) *ai.GenerateResponse {
+	if response == nil || commitTemplate == "" {
+		return response
+	}
+
+	var lines []string
+	stripped := false
+	for _, line := range strings.Split(s.formatCommitMessage(response), "\n") {
+		if strings.HasPrefix(line, "#") {
+			stripped = true
+			continue
+		}
+		// Collapse the blank lines left around removed comments
+		if strings.TrimSpace(line) == "" && len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if !stripped {
+		return response
+	}
+
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+	return ai.ParseCommitMessage(text).ToGenerateResponse(text)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCommitTemplate(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		service := NewCommitService(gitClient, nil, nil, nil, nil, &config.Config{})
+
+		assert.Empty(t, service.getCommitTemplate(context.Background()))
+		gitClient.AssertNotCalled(t, "GetCommitTemplate", mock.Anything)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		cfg := &config.Config{Generation: config.GenerationConfig{CommitTemplate: true}}
+		service := NewCommitService(gitClient, nil, nil, nil, nil, cfg)
+		gitClient.On("GetCommitTemplate", mock.Anything).Return("[JIRA-ID] Summary\n\n", nil)
+
+		assert.Equal(t, "[JIRA-ID] Summary", service.getCommitTemplate(context.Background()))
+	})
+
+	t.Run("error ignored", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		cfg := &config.Config{Generation: config.GenerationConfig{CommitTemplate: true}}
+		service := NewCommitService(gitClient, nil, nil, nil, nil, cfg)
+		gitClient.On("GetCommitTemplate", mock.Anything).Return("", errors.New("no such file"))
+
+		assert.Empty(t, service.getCommitTemplate(context.Background()))
+	})
+}
+
+func TestStripTemplateComments(t *testing.T) {
+	service := NewCommitService(nil, nil, nil, nil, nil, &config.Config{})
+	raw := "fix(auth): 修复登录超时\n\n# Why was this change made?\n会话过期后未刷新令牌\n\n# Refs:\nRefs: JIRA-42"
+	response := ai.ParseCommitMessage(raw).ToGenerateResponse(raw)
+
+	t.Run("no template", func(t *testing.T) {
+		assert.Same(t, response, service.stripTemplateComments(response, ""))
+	})
+
+	t.Run("template", func(t *testing.T) {
+		got := service.stripTemplateComments(response, "# Why was this change made?")
+
+		assert.Equal(t, "fix(auth): 修复登录超时", got.Subject)
+		assert.Equal(t, "fix(auth): 修复登录超时\n\n会话过期后未刷新令牌\n\nRefs: JIRA-42", service.formatCommitMessage(got))
+	})
+
+	t.Run("no comments", func(t *testing.T) {
+		plain := &ai.GenerateResponse{Subject: "fix: x", Body: "body"}
+		assert.Same(t, plain, service.stripTemplateComments(plain, "# hint"))
+	})
+}
@@ -0,0 +1,93 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+)
+
+// translationLanguage returns the configured generation.translate_to, or an
+// empty string if translation is disabled.
+func (s *CommitService) translationLanguage() string {
+	if s.config == nil {
+		return ""
+	}
+	return strings.TrimSpace(s.config.Generation.TranslateTo)
+}
+
+// translateMessage translates the description and body of the message into
+// language. The type, scope, breaking-change marker and footers are kept as
+// they are, so the translation is still a valid conventional commit.
+func (s *CommitService) translateMessage(ctx context.Context, response *ai.GenerateResponse, language string) (*ai.GenerateResponse, error) {
+	original := message.NewCommitMessage(s.formatCommitMessage(response))
+	if original.Subject == "" {
+		return nil, errors.New("the message has no description to translate")
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.translating", language))
+	spinner.Start()
+	reply, err := s.summarize(ctx, buildTranslatePrompt(original, language))
+	spinner.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	subject, body, _ := strings.Cut(reply, "\n")
+	translated := *original
+	translated.Subject = strings.TrimSpace(subject)
+	if original.Body != "" {
+		translated.Body = strings.TrimSpace(body)
+	}
+	if translated.Subject == "" {
+		return nil, errors.New("the AI returned an empty translation")
+	}
+
+	return &ai.GenerateResponse{
+		Subject: translated.FormatSubject(),
+		Body:    translated.Body,
+		Footer:  translated.Footer,
+		RawText: translated.Format(),
+	}, nil
+}
+
+// historyTranslation returns the translation kept in history for the accepted
+// message, or an empty string if history.keep_translation is off. A failed
+// translation is reported without failing the commit.
+func (s *CommitService) historyTranslation(ctx context.Context, response *ai.GenerateResponse) string {
+	language := s.translationLanguage()
+	if s.config == nil || !s.config.History.KeepTranslation || language == "" {
+		return ""
+	}
+
+	translated, err := s.translateMessage(ctx, response, language)
+	if err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("commit.warning.translation"), err))
+		return ""
+	}
+	return s.formatCommitMessage(translated)
+}
+
+// buildTranslatePrompt builds the prompt asking for a translation of the
+// description and body of the message.
+func buildTranslatePrompt(msg *message.CommitMessage, language string) string {
+	text := msg.Subject
+	if msg.Body != "" {
+		text += "\n\n" + msg.Body
+	}
+
+	return fmt.Sprintf(`Translate this commit message description and body into %s.
+
+[[MESSAGE]]
+%s
+
+[[INSTRUCTION]]
+Keep the first line as the translated description, in the imperative mood and without a type prefix.
+Keep the line structure, "- " bullets, code identifiers, file paths and issue references unchanged.
+Output only the translation.`, language, text)
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func newTranslateService(cfg *config.Config) (*CommitService, *MockAIProvider, *MockUIManager) {
+	aiProvider := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	return NewCommitService(&MockGitClient{}, aiProvider, nil, uiManager, nil, cfg), aiProvider, uiManager
+}
+
+func TestTranslateMessage_KeepsStructure(t *testing.T) {
+	service, aiProvider, _ := newTranslateService(&config.Config{})
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "into English") &&
+			strings.Contains(req.CustomPrompt, "添加登录\n\n- auth: 支持令牌刷新") &&
+			!strings.Contains(req.CustomPrompt, "Refs")
+	})).Return(&ai.GenerateResponse{RawText: "add login\n\n- auth: support token refresh"}, nil)
+
+	translated, err := service.translateMessage(context.Background(), &ai.GenerateResponse{
+		Subject: "feat(auth)!: 添加登录",
+		Body:    "- auth: 支持令牌刷新",
+		Footer:  "Refs: #12",
+	}, "English")
+
+	require.NoError(t, err)
+	assert.Equal(t, "feat(auth)!: add login", translated.Subject)
+	assert.Equal(t, "- auth: support token refresh", translated.Body)
+	assert.Equal(t, "Refs: #12", translated.Footer)
+	assert.Equal(t, "feat(auth)!: add login\n\n- auth: support token refresh\n\nRefs: #12", translated.RawText)
+}
+
+func TestPromptAction_Translate(t *testing.T) {
+	original := &ai.GenerateResponse{Subject: "fix: 修复空指针"}
+
+	t.Run("replaces the message with its translation", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Generation.TranslateTo = "English"
+		service, aiProvider, uiManager := newTranslateService(cfg)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{RawText: "fix nil pointer"}, nil)
+		uiManager.On("PromptAction").Return(ui.ActionTranslate, nil).Once()
+		uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+		uiManager.On("DisplayComparison", original, mock.Anything).Return(nil).Once()
+
+		action, response, err := service.promptAction(context.Background(), "", []*ai.GenerateResponse{original}, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, ui.ActionAccept, action)
+		assert.Equal(t, "fix: fix nil pointer", response.Subject)
+		uiManager.AssertExpectations(t)
+	})
+
+	t.Run("no language configured", func(t *testing.T) {
+		service, _, uiManager := newTranslateService(&config.Config{})
+		uiManager.On("PromptAction").Return(ui.ActionTranslate, nil).Once()
+		uiManager.On("PromptAction").Return(ui.ActionAccept, nil).Once()
+		uiManager.On("ShowError", mock.MatchedBy(func(err error) bool {
+			return strings.Contains(err.Error(), "generation.translate_to")
+		})).Return().Once()
+
+		_, response, err := service.promptAction(context.Background(), "", []*ai.GenerateResponse{original}, nil)
+
+		require.NoError(t, err)
+		assert.Same(t, original, response)
+		uiManager.AssertNotCalled(t, "ShowSpinner", mock.Anything)
+		uiManager.AssertNumberOfCalls(t, "ShowError", 1)
+	})
+}
+
+func TestHistoryTranslation(t *testing.T) {
+	response := &ai.GenerateResponse{Subject: "docs: 更新说明"}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Generation.TranslateTo = "English"
+		service, _, _ := newTranslateService(cfg)
+
+		assert.Empty(t, service.historyTranslation(context.Background(), response))
+	})
+
+	t.Run("keeps the translation", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Generation.TranslateTo = "English"
+		cfg.History.KeepTranslation = true
+		service, aiProvider, _ := newTranslateService(cfg)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{RawText: "update readme"}, nil)
+
+		assert.Equal(t, "docs: update readme", service.historyTranslation(context.Background(), response))
+	})
+
+	t.Run("failed translation is reported", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Generation.TranslateTo = "English"
+		cfg.History.KeepTranslation = true
+		service, aiProvider, uiManager := newTranslateService(cfg)
+		aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("timeout"))
+		uiManager.On("ShowError", mock.Anything).Return().Once()
+
+		assert.Empty(t, service.historyTranslation(context.Background(), response))
+		uiManager.AssertExpectations(t)
+	})
+}
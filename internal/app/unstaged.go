@@ -0,0 +1,59 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// MaxUnstagedContextFiles caps the unstaged files listed in the prompt.
+const MaxUnstagedContextFiles = 20
+
+// getUnstagedContext returns a compact summary of the unstaged and untracked
+// files, one "status: path" line each, when generation.include_unstaged_context
+// is set. Files that are part of the commit are left out, so a partly staged
+// file is described by its staged diff only. The summary is context for the
+// AI and never changes what is committed.
+func (s *CommitService) getUnstagedContext(ctx context.Context, committed []git.DiffChunk) []string {
+	if s.config == nil || !s.config.Generation.IncludeUnstagedContext {
+		return nil
+	}
+
+	files, err := s.gitClient.GetUnstagedFiles(ctx)
+	if err != nil {
+		apperrors.Debug("Failed to list unstaged files: %v", err)
+		return nil
+	}
+
+	inCommit := make(map[string]bool, len(committed))
+	for _, chunk := range committed {
+		inCommit[chunk.FilePath] = true
+	}
+
+	var lines []string
+	skipped := 0
+	for _, file := range files {
+		if inCommit[file.Path] {
+			continue
+		}
+		if len(lines) == MaxUnstagedContextFiles {
+			skipped++
+			continue
+		}
+		status := "modified"
+		switch {
+		case file.Untracked:
+			status = "untracked"
+		case file.Deleted:
+			status = "deleted"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", status, file.Path))
+	}
+	if skipped > 0 {
+		lines = append(lines, fmt.Sprintf("... and %d more", skipped))
+	}
+	return lines
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestGetUnstagedContext(t *testing.T) {
+	enabled := &config.Config{Generation: config.GenerationConfig{IncludeUnstagedContext: true}}
+	committed := []git.DiffChunk{{FilePath: "auth.go"}}
+
+	t.Run("disabled", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		service := NewCommitService(gitClient, nil, nil, nil, nil, &config.Config{})
+
+		assert.Nil(t, service.getUnstagedContext(context.Background(), committed))
+		gitClient.AssertNotCalled(t, "GetUnstagedFiles", mock.Anything)
+	})
+
+	t.Run("lists files outside the commit", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		gitClient.On("GetUnstagedFiles", mock.Anything).Return([]git.UnstagedFile{
+			{Path: "auth.go"},
+			{Path: "auth_test.go"},
+			{Path: "old.go", Deleted: true},
+			{Path: "notes.md", Untracked: true},
+		}, nil)
+		service := NewCommitService(gitClient, nil, nil, nil, nil, enabled)
+
+		assert.Equal(t, []string{"modified: auth_test.go", "deleted: old.go", "untracked: notes.md"},
+			service.getUnstagedContext(context.Background(), committed))
+	})
+
+	t.Run("caps the list", func(t *testing.T) {
+		var files []git.UnstagedFile
+		for i := 0; i < MaxUnstagedContextFiles+3; i++ {
+			files = append(files, git.UnstagedFile{Path: fmt.Sprintf("file%d.go", i)})
+		}
+		gitClient := &MockGitClient{}
+		gitClient.On("GetUnstagedFiles", mock.Anything).Return(files, nil)
+		service := NewCommitService(gitClient, nil, nil, nil, nil, enabled)
+
+		lines := service.getUnstagedContext(context.Background(), committed)
+		assert.Len(t, lines, MaxUnstagedContextFiles+1)
+		assert.Equal(t, "... and 3 more", lines[MaxUnstagedContextFiles])
+	})
+
+	t.Run("git failure is ignored", func(t *testing.T) {
+		gitClient := &MockGitClient{}
+		gitClient.On("GetUnstagedFiles", mock.Anything).Return(nil, errors.New("git failed"))
+		service := NewCommitService(gitClient, nil, nil, nil, nil, enabled)
+
+		assert.Nil(t, service.getUnstagedContext(context.Background(), committed))
+	})
+}
@@ -0,0 +1,47 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"sync"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/history"
+)
+
+// usageRecorder totals the token usage of the AI requests made for a
+// message. Requests run concurrently while summarizing file groups.
+type usageRecorder struct {
+	mu    sync.Mutex
+	usage history.Usage
+}
+
+// record adds the usage reported with the response. Responses without
+// usage, such as those of providers that report none, are ignored.
+func (r *usageRecorder) record(response *ai.GenerateResponse) {
+	if response == nil || response.Usage == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.Requests++
+	if response.Usage.CachedTokens > 0 {
+		r.usage.CacheHits++
+	}
+	r.usage.PromptTokens += response.Usage.PromptTokens
+	r.usage.CachedTokens += response.Usage.CachedTokens
+	r.usage.CompletionTokens += response.Usage.CompletionTokens
+}
+
+// take returns the usage recorded since the last call and starts over, or
+// nil if no usage was recorded.
+func (r *usageRecorder) take() *history.Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.usage.Requests == 0 {
+		return nil
+	}
+	usage := r.usage
+	r.usage = history.Usage{}
+	return &usage
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/history"
+)
+
+func TestUsageRecorder(t *testing.T) {
+	var recorder usageRecorder
+	assert.Nil(t, recorder.take())
+
+	recorder.record(nil)
+	recorder.record(&ai.GenerateResponse{Subject: "feat: no usage reported"})
+	assert.Nil(t, recorder.take())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(cached int) {
+			defer wg.Done()
+			recorder.record(&ai.GenerateResponse{Usage: &ai.Usage{PromptTokens: 1000, CachedTokens: cached, CompletionTokens: 50}})
+		}(i * 256)
+	}
+	wg.Wait()
+
+	assert.Equal(t, &history.Usage{
+		Requests:         4,
+		CacheHits:        3,
+		PromptTokens:     4000,
+		CachedTokens:     1536,
+		CompletionTokens: 200,
+	}, recorder.take())
+	assert.Nil(t, recorder.take(), "take should start over")
+}
+
+func TestSummarize_RecordsUsage(t *testing.T) {
+	aiProvider := &MockAIProvider{}
+	service := NewCommitService(&MockGitClient{}, aiProvider, nil, &MockUIManager{}, nil, &config.Config{})
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{
+		RawText: "summary",
+		Usage:   &ai.Usage{PromptTokens: 1200, CachedTokens: 1024, CompletionTokens: 20},
+	}, nil)
+
+	_, err := service.summarize(context.Background(), "prompt")
+	require.NoError(t, err)
+
+	usage := service.usage.take()
+	require.NotNil(t, usage)
+	assert.Equal(t, 1, usage.CacheHits)
+	assert.Equal(t, 1024, usage.CachedTokens)
+}
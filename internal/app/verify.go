@@ -0,0 +1,121 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// MaxCritiqueDiffSize is the maximum size (in bytes) of diff content sent to the critic.
+const MaxCritiqueDiffSize = 8 * 1024
+
+// critiqueOK is the reply the critic gives when the message matches the diff.
+const critiqueOK = "OK"
+
+// SetCritic enables the verification pass: after each generation the critic
+// checks the message against the diff and flags claims the diff does not support.
+func (s *CommitService) SetCritic(critic ai.Provider) {
+	s.critic = critic
+}
+
+// verifyMessage asks the critic to check the message against the diff.
+// Verification is advisory, so failures are logged and yield no issues. It is
+// skipped in stats-only mode, which never sends the diff.
+func (s *CommitService) verifyMessage(
+	ctx context.Context,
+	processedDiff *processor.ProcessedDiff,
+	response *ai.GenerateResponse,
+) []string {
+	if s.critic == nil || response == nil || s.statsOnly {
+		return nil
+	}
+
+	spinner := s.uiManager.ShowSpinner(i18n.T("commit.spinner.verifying"))
+	spinner.Start()
+	defer spinner.Stop()
+
+	req := &ai.GenerateRequest{
+		CustomPrompt: buildCritiquePrompt(processedDiff, s.formatCommitMessage(response)),
+		Redact:       s.pathRedactor(),
+	}
+
+	critique, err := s.critic.GenerateCommitMessage(ctx, req)
+	if err != nil {
+		apperrors.Debug("Failed to verify commit message: %v", err)
+		return nil
+	}
+	s.usage.record(critique)
+
+	text := strings.TrimSpace(critique.RawText)
+	if text == "" {
+		text = critique.Subject
+	}
+	return parseCritique(text)
+}
+
+// showCritique shows each issue found by the critic as a warning.
+func (s *CommitService) showCritique(issues []string) {
+	for _, issue := range issues {
+		s.uiManager.ShowError(errors.New(i18n.T("commit.warning.verify", issue)))
+	}
+}
+
+// buildCritiquePrompt builds the prompt asking the critic to fact-check a message.
+func buildCritiquePrompt(processedDiff *processor.ProcessedDiff, commitMessage string) string {
+	var files, diff strings.Builder
+	for _, chunk := range processedDiff.Chunks {
+		files.WriteString(fmt.Sprintf("- %s (%s, +%d -%d)\n", chunk.FilePath, chunk.ChangeType, chunk.Additions, chunk.Deletions))
+
+		if diff.Len() >= MaxCritiqueDiffSize {
+			continue
+		}
+		content := chunk.Content
+		if remaining := MaxCritiqueDiffSize - diff.Len(); len(content) > remaining {
+			content = content[:remaining] + "\n... [truncated]"
+		}
+		diff.WriteString(fmt.Sprintf("=== %s ===\n%s\n\n", chunk.FilePath, content))
+	}
+
+	return fmt.Sprintf(`You are reviewing a commit message for factual accuracy. Do not rewrite it.
+
+[[COMMIT MESSAGE]]
+%s
+
+[[CHANGED FILES]]
+%s
+[[DIFF]]
+%s
+[[INSTRUCTION]]
+Check every claim in the commit message against the changed files and the diff.
+Flag modules, files, features or changes that the message mentions but the diff does not contain,
+and claims that contradict the diff (e.g. "add" for a deletion).
+If the message is accurate, reply with exactly: %s
+Otherwise reply with one problem per line, each starting with "- ". Output nothing else.`,
+		commitMessage, files.String(), diff.String(), critiqueOK)
+}
+
+// parseCritique extracts the issues from the critic's reply.
+// An empty reply or a reply starting with OK means no issues were found.
+func parseCritique(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(strings.ToUpper(text), critiqueOK) {
+		return nil
+	}
+
+	var issues []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*•"))
+		if line != "" {
+			issues = append(issues, line)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,132 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+func TestParseCritique(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"ok", "OK", nil},
+		{"ok lowercase with trailing text", "ok, the message is accurate", nil},
+		{"single issue", "- mentions README changes that are not in the diff", []string{"mentions README changes that are not in the diff"}},
+		{
+			"multiple issues with blank lines",
+			"- claims a new API endpoint\n\n* says tests were added\n",
+			[]string{"claims a new API endpoint", "says tests were added"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseCritique(tt.text))
+		})
+	}
+}
+
+func TestBuildCritiquePrompt(t *testing.T) {
+	processedDiff := &processor.ProcessedDiff{
+		Chunks: []git.DiffChunk{
+			{FilePath: "a.go", ChangeType: git.ChangeTypeModified, Content: strings.Repeat("a", MaxCritiqueDiffSize+100), Additions: 3},
+			{FilePath: "b.go", ChangeType: git.ChangeTypeAdded, Content: "only listed", Additions: 1},
+		},
+	}
+
+	prompt := buildCritiquePrompt(processedDiff, "feat(core): add thing")
+
+	assert.Contains(t, prompt, "feat(core): add thing")
+	assert.Contains(t, prompt, "- a.go (modified, +3 -0)")
+	assert.Contains(t, prompt, "- b.go (added, +1 -0)")
+	assert.Contains(t, prompt, "[truncated]")
+	// The diff budget is spent on a.go, so b.go content is left out
+	assert.NotContains(t, prompt, "only listed")
+}
+
+func TestGenerateAndCommit_Verify(t *testing.T) {
+	gitClient := &MockGitClient{}
+	aiProvider := &MockAIProvider{}
+	critic := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	cfg := &config.Config{}
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, cfg)
+	service.SetCritic(critic)
+
+	chunks := []git.DiffChunk{
+		{FilePath: "test.go", ChangeType: git.ChangeTypeModified, Content: "test content"},
+	}
+	stats := &git.DiffStats{TotalFiles: 1, Chunks: chunks}
+	processedDiff := &processor.ProcessedDiff{Chunks: chunks, TotalSize: 100}
+	response := &ai.GenerateResponse{Subject: "feat: add docs and tests", RawText: "feat: add docs and tests"}
+
+	gitClient.On("HasStagedChanges", mock.Anything).Return(true, nil)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	gitClient.On("GetDiffStats", mock.Anything).Return(stats, nil)
+
+	diffProcessor.On("Process", mock.Anything, chunks).Return(processedDiff, nil)
+
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(response, nil)
+	critic.On("GenerateCommitMessage", mock.Anything, mock.MatchedBy(func(req *ai.GenerateRequest) bool {
+		return strings.Contains(req.CustomPrompt, "feat: add docs and tests")
+	})).Return(&ai.GenerateResponse{RawText: "- mentions docs that are not in the diff"}, nil)
+
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("DisplayMessage", response).Return(nil)
+	uiManager.On("ShowError", mock.MatchedBy(func(err error) bool {
+		return err.Error() == "verification: mentions docs that are not in the diff"
+	})).Return().Once()
+	uiManager.On("PromptAction").Return(ui.ActionCancel, nil)
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	err := service.GenerateAndCommit(context.Background(), &CommitOptions{})
+
+	assert.NoError(t, err)
+	critic.AssertExpectations(t)
+	uiManager.AssertExpectations(t)
+}
+
+func TestVerifyMessage_CriticError(t *testing.T) {
+	critic := &MockAIProvider{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+
+	service := NewCommitService(nil, nil, nil, uiManager, nil, &config.Config{})
+	service.SetCritic(critic)
+
+	critic.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited"))
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	issues := service.verifyMessage(context.Background(), &processor.ProcessedDiff{}, &ai.GenerateResponse{Subject: "feat: x"})
+
+	assert.Nil(t, issues)
+}
+
+func TestVerifyMessage_NoCritic(t *testing.T) {
+	service := NewCommitService(nil, nil, nil, nil, nil, &config.Config{})
+
+	assert.Nil(t, service.verifyMessage(context.Background(), &processor.ProcessedDiff{}, &ai.GenerateResponse{}))
+}
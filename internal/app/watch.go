@@ -0,0 +1,194 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// DraftFileName is the file in the git directory holding the message "gitsage
+// watch" keeps up to date with the staged changes. Like RecoveryFileName it
+// lives in .git and is never committed.
+const DraftFileName = "GITSAGE_DRAFT"
+
+// DefaultWatchDebounce is how long the index must stay unchanged before the
+// draft is updated, so that a series of "git add" runs makes one request.
+const DefaultWatchDebounce = 2 * time.Second
+
+// WatchOptions contains options for the watch workflow.
+type WatchOptions struct {
+	// Debounce is how long the index must stay unchanged before the draft is
+	// updated; zero or less uses DefaultWatchDebounce.
+	Debounce time.Duration
+}
+
+// watchDraft is the state of the draft between updates.
+type watchDraft struct {
+	path string
+	// chunks are the staged changes the draft was last written for.
+	chunks []git.DiffChunk
+	// current is set once the draft reflects chunks.
+	current bool
+}
+
+// Watch keeps a draft message for the staged changes in DraftFileName until
+// ctx is cancelled. The draft is written with the same request as
+// GenerateAndCommit, so committing the same changes afterwards is answered
+// from the response cache, and files whose diff did not change between
+// updates keep their summaries. Failed updates are reported and retried on
+// the next change of the index.
+func (s *CommitService) Watch(ctx context.Context, opts *WatchOptions) error {
+	if opts == nil {
+		opts = &WatchOptions{}
+	}
+	debounce := opts.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	gitDir, err := s.gitClient.GetGitDir(ctx)
+	if err != nil || gitDir == "" {
+		return fmt.Errorf("failed to find the git directory: %w", err)
+	}
+
+	// Git replaces the index by renaming index.lock over it, so the directory
+	// is watched rather than the file
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to watch the index")
+	}
+	defer watcher.Close()
+	if err := watcher.Add(gitDir); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to watch "+gitDir)
+	}
+
+	draft := &watchDraft{path: filepath.Join(gitDir, DraftFileName)}
+	s.uiManager.ShowInfo(i18n.T("watch.info.watching", draft.path))
+	if err := s.updateDraft(ctx, draft); err != nil {
+		return stopWatching(err)
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Base(event.Name) == "index" {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("watch.error.watch"), err))
+		case <-timer.C:
+			if err := s.updateDraft(ctx, draft); err != nil {
+				return stopWatching(err)
+			}
+		}
+	}
+}
+
+// updateDraft writes the draft for the staged changes if they changed since
+// the last update, and removes it when nothing is staged. Failures are
+// reported and retried on the next update; the returned error stops
+// watching: errPayloadDeclined if the user declined sending the changes, or
+// the error asking them.
+func (s *CommitService) updateDraft(ctx context.Context, draft *watchDraft) error {
+	chunks, err := s.gitClient.GetStagedDiff(ctx)
+	if err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("watch.error.update"), err))
+		return nil
+	}
+	if draft.current && sameChanges(chunks, draft.chunks) {
+		return nil
+	}
+	draft.chunks, draft.current = chunks, false
+
+	processedDiff, err := s.diffProcessor.Process(ctx, chunks)
+	if err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("watch.error.update"), err))
+		return nil
+	}
+	if len(processedDiff.Chunks) == 0 {
+		removeDraft(draft)
+		return nil
+	}
+
+	// A huge diff is not sent off without the confirmation commit asks for,
+	// and the draft of earlier changes would be misleading
+	if s.overBudget(s.estimatePlan(processedDiff)) {
+		removeDraft(draft)
+		s.uiManager.ShowInfo(i18n.T("watch.info.over_budget"))
+		return nil
+	}
+
+	confirmed, err := s.confirmPendingPayload(func() string { return s.describePayload(chunks, processedDiff) })
+	if err != nil {
+		return fmt.Errorf("failed to confirm payload: %w", err)
+	}
+	if !confirmed {
+		removeDraft(draft)
+		s.uiManager.ShowSuccess(i18n.T("commit.success.payload_declined"))
+		return errPayloadDeclined
+	}
+
+	// The same context as GenerateAndCommit, which makes the cache key match
+	recentCommits := s.getRecentCommits(ctx)
+	commitTemplate := s.getCommitTemplate(ctx)
+	s.examples = s.getExamples(ctx)
+	s.unstaged = s.getUnstagedContext(ctx, chunks)
+	s.stack = s.getStack(ctx)
+
+	response, err := s.generateCommitMessage(ctx, processedDiff, newDiffStats(chunks), recentCommits, commitTemplate, "", "", "", ai.Intent{}, false)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("watch.error.update"), err))
+		}
+		return nil
+	}
+	if err := writeFile(draft.path, []byte(s.formatCommitMessage(response)+"\n"), 0600); err != nil {
+		s.uiManager.ShowError(fmt.Errorf("%s: %w", i18n.T("watch.error.update"), err))
+		return nil
+	}
+	draft.current = true
+	s.uiManager.ShowSuccess(i18n.T("watch.success.updated", len(chunks), response.Subject))
+	return nil
+}
+
+// errPayloadDeclined is returned by updateDraft when the user declined
+// sending the staged changes.
+var errPayloadDeclined = errors.New("payload declined")
+
+// stopWatching returns the error of Watch for an error stopping it, which is
+// none when the user declined sending the changes.
+func stopWatching(err error) error {
+	if errors.Is(err, errPayloadDeclined) {
+		return nil
+	}
+	return err
+}
+
+// removeDraft removes the draft when there are no changes it can describe.
+func removeDraft(draft *watchDraft) {
+	if err := os.Remove(draft.path); err != nil && !os.IsNotExist(err) {
+		apperrors.Debug("Failed to remove %s: %v", DraftFileName, err)
+	}
+	draft.current = true
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// readDraft returns the contents of the draft, or "" if there is none.
+func readDraft(path string) string {
+	data, _ := os.ReadFile(path)
+	return string(data)
+}
+
+func TestWatch(t *testing.T) {
+	gitClient := &MockGitClient{GitDir: t.TempDir()}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	spinner := &MockSpinner{}
+	path := filepath.Join(gitClient.GitDir, DraftFileName)
+
+	first := []git.DiffChunk{{FilePath: "auth.go", ChangeType: git.ChangeTypeModified, Content: "+a"}}
+	second := append([]git.DiffChunk{{FilePath: "auth_test.go", ChangeType: git.ChangeTypeModified, Content: "+b"}}, first...)
+	gitClient.On("GetStagedDiff", mock.Anything).Return(first, nil).Once()
+	gitClient.On("GetStagedDiff", mock.Anything).Return(second, nil).Once()
+	gitClient.On("GetStagedDiff", mock.Anything).Return([]git.DiffChunk{}, nil)
+	for _, chunks := range [][]git.DiffChunk{first, second, {}} {
+		diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	}
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "fix(auth): refresh tokens"}, nil).Once()
+	aiProvider.On("GenerateCommitMessage", mock.Anything, mock.Anything).Return(&ai.GenerateResponse{Subject: "test(auth): cover refresh"}, nil).Once()
+	aiProvider.On("Name").Return("mock").Maybe()
+	uiManager.On("ShowSpinner", mock.Anything).Return(spinner)
+	uiManager.On("ShowInfo", mock.Anything).Return()
+	uiManager.On("ShowSuccess", mock.Anything).Return()
+	spinner.On("Start").Return()
+	spinner.On("Stop").Return()
+
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil, &config.Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- service.Watch(ctx, &WatchOptions{Debounce: 20 * time.Millisecond}) }()
+
+	// The draft is written right away, and again when the index changes
+	require.Eventually(t, func() bool { return readDraft(path) == "fix(auth): refresh tokens\n" }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(gitClient.GitDir, "index"), []byte("1"), 0644))
+	require.Eventually(t, func() bool { return readDraft(path) == "test(auth): cover refresh\n" }, 5*time.Second, 10*time.Millisecond)
+
+	// Committing empties the staging area, which removes the draft
+	require.NoError(t, os.WriteFile(filepath.Join(gitClient.GitDir, "index"), []byte("2"), 0644))
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	aiProvider.AssertNumberOfCalls(t, "GenerateCommitMessage", 2)
+}
+
+func TestUpdateDraft_Unchanged(t *testing.T) {
+	gitClient := &MockGitClient{GitDir: t.TempDir()}
+	chunks := []git.DiffChunk{{FilePath: "auth.go", Content: "+a"}}
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	service := NewCommitService(gitClient, &MockAIProvider{}, &MockDiffProcessor{}, &MockUIManager{}, nil, &config.Config{})
+
+	// Changes the draft was written for are not sent again
+	draft := &watchDraft{path: filepath.Join(gitClient.GitDir, DraftFileName), chunks: chunks, current: true}
+	service.updateDraft(context.Background(), draft)
+	assert.NoFileExists(t, draft.path)
+}
+
+func TestUpdateDraft_OverBudget(t *testing.T) {
+	gitClient := &MockGitClient{GitDir: t.TempDir()}
+	aiProvider := &MockAIProvider{}
+	diffProcessor := &MockDiffProcessor{}
+	uiManager := &MockUIManager{}
+	chunks := []git.DiffChunk{{FilePath: "auth.go", Content: string(make([]byte, 1000))}}
+	gitClient.On("GetStagedDiff", mock.Anything).Return(chunks, nil)
+	diffProcessor.On("Process", mock.Anything, chunks).Return(&processor.ProcessedDiff{Chunks: chunks}, nil)
+	aiProvider.On("Name").Return("mock").Maybe()
+	uiManager.On("ShowInfo", mock.Anything).Return().Once()
+	service := NewCommitService(gitClient, aiProvider, diffProcessor, uiManager, nil,
+		&config.Config{Budget: config.BudgetConfig{MaxTokens: 10}})
+
+	// Nothing is sent without the confirmation commit would ask for, and the
+	// draft of the earlier changes is removed
+	draft := &watchDraft{path: filepath.Join(gitClient.GitDir, DraftFileName)}
+	require.NoError(t, os.WriteFile(draft.path, []byte("fix: earlier\n"), 0600))
+	service.updateDraft(context.Background(), draft)
+	assert.NoFileExists(t, draft.path)
+	aiProvider.AssertNotCalled(t, "GenerateCommitMessage", mock.Anything, mock.Anything)
+	uiManager.AssertExpectations(t)
+}
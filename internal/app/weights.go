@@ -0,0 +1,24 @@
+// Package app contains the application layer with business orchestration logic.
+package app
+
+import (
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// PathWeights converts the git.path_weights rules for the diff processor.
+func PathWeights(rules []config.PathWeight) []processor.PathWeight {
+	weights := make([]processor.PathWeight, 0, len(rules))
+	for _, rule := range rules {
+		weights = append(weights, processor.PathWeight(rule))
+	}
+	return weights
+}
+
+// pathWeights returns the configured path weights.
+func (s *CommitService) pathWeights() []processor.PathWeight {
+	if s.config == nil {
+		return nil
+	}
+	return PathWeights(s.config.Git.PathWeights)
+}
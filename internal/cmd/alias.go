@@ -0,0 +1,111 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/spf13/cobra"
+)
+
+// DefaultAliasName is the git alias installed by "gitsage alias install".
+const DefaultAliasName = "sage"
+
+// aliasCommand is what the git alias runs: gitsage with the alias's arguments.
+const aliasCommand = "!gitsage"
+
+// AliasFlags holds the flags for the alias install command.
+type AliasFlags struct {
+	Name   string
+	Global bool
+	Force  bool
+}
+
+// NewAliasCmd creates the alias command and its subcommands.
+func NewAliasCmd() *cobra.Command {
+	aliasCmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage the git alias for GitSage",
+		Long: `Manage a git alias that runs GitSage as a git subcommand, so that
+"git sage" works like "gitsage".
+
+Examples:
+  gitsage alias install           # Add "git sage" to this repository
+  gitsage alias install --global  # Add "git sage" for all repositories
+  gitsage alias install --name ai # Add "git ai" instead`,
+	}
+
+	aliasCmd.AddCommand(newAliasInstallCmd())
+
+	return aliasCmd
+}
+
+// newAliasInstallCmd creates the 'alias install' subcommand.
+func newAliasInstallCmd() *cobra.Command {
+	flags := &AliasFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Add a git alias that runs GitSage",
+		Long: `Set alias.<name> in git config to run GitSage, so that "git sage" runs
+the commit flow with the repository's defaults and passes its arguments on
+(e.g. "git sage --dry-run").
+
+The alias is added to the repository's config, or with --global to your
+user config. An existing alias with another command is only replaced with --force.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAliasInstall(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Name, "name", DefaultAliasName, "Name of the git alias")
+	cmd.Flags().BoolVar(&flags.Global, "global", false, "Add the alias to your user git config instead of the repository's")
+	cmd.Flags().BoolVar(&flags.Force, "force", false, "Replace an existing alias with the same name")
+
+	return cmd
+}
+
+// runAliasInstall executes the alias install command logic.
+func runAliasInstall(cmd *cobra.Command, flags *AliasFlags) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	name := strings.TrimSpace(flags.Name)
+	if name == "" || strings.ContainsAny(name, " \t.") {
+		return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("invalid alias name %q", flags.Name))
+	}
+	key := "alias." + name
+
+	gitClient := git.NewClient()
+	existing, err := gitClient.GetConfig(ctx, key, flags.Global)
+	if err != nil {
+		return fmt.Errorf("failed to read git config: %w", err)
+	}
+
+	quiet, _ := scriptFlags(cmd)
+	switch {
+	case existing == aliasCommand:
+		if !quiet {
+			fmt.Printf("git %s already runs gitsage.\n", name)
+		}
+		return nil
+	case existing != "" && !flags.Force:
+		return apperrors.New(apperrors.ErrInvalidArguments,
+			fmt.Sprintf("%s is already set to %q; pass --force to replace it", key, existing))
+	}
+
+	if err := gitClient.SetConfig(ctx, key, aliasCommand, flags.Global); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+
+	if !quiet {
+		fmt.Printf("Installed git alias: run \"git %s\" to generate and commit.\n", name)
+		fmt.Println("Set defaults for this repository with git config, e.g. \"git config gitsage.all true\".")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// setupAliasRepo creates a git repository as the working directory, with
+// the user's git config left out.
+func setupAliasRepo(t *testing.T) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_GLOBAL", "")
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	gitConfig(t, "init", "-q")
+	return dir
+}
+
+// gitConfig runs git in the working directory and returns its trimmed output.
+func gitConfig(t *testing.T, args ...string) string {
+	t.Helper()
+	output, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestApplyRepoDefaults(t *testing.T) {
+	setupAliasRepo(t)
+	gitConfig(t, "config", "gitsage.all", "yes")
+	gitConfig(t, "config", "gitsage.type", "fix")
+	gitConfig(t, "config", "gitsage.dry-run", "true")
+	gitConfig(t, "config", "gitsage.unknown", "1")
+
+	root := NewRootCmd("test", "none", "unknown")
+	root.SetContext(context.Background())
+	if err := root.ParseFlags([]string{"--type", "feat"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyRepoDefaults(root); err != nil {
+		t.Fatalf("applyRepoDefaults() error = %v", err)
+	}
+
+	if all, _ := root.Flags().GetBool("all"); !all {
+		t.Error("--all should come from gitsage.all")
+	}
+	if dryRun, _ := root.Flags().GetBool("dry-run"); !dryRun {
+		t.Error("--dry-run should come from gitsage.dry-run")
+	}
+	if typ, _ := root.Flags().GetString("type"); typ != "feat" {
+		t.Errorf("--type = %q; the command line should win over gitsage.type", typ)
+	}
+}
+
+func TestApplyRepoDefaults_InvalidValue(t *testing.T) {
+	setupAliasRepo(t)
+	gitConfig(t, "config", "gitsage.split", "maybe")
+
+	root := NewRootCmd("test", "none", "unknown")
+	root.SetContext(context.Background())
+
+	err := applyRepoDefaults(root)
+	if !apperrors.IsAppError(err) || apperrors.GetAppError(err).Code != apperrors.ErrInvalidConfig {
+		t.Fatalf("applyRepoDefaults() error = %v, want an invalid config error", err)
+	}
+	if !strings.Contains(err.Error(), "gitsage.split") {
+		t.Errorf("error %q should name the git config key", err)
+	}
+}
+
+func TestAliasInstall(t *testing.T) {
+	setupAliasRepo(t)
+
+	run := func(args ...string) error {
+		root := NewRootCmd("test", "none", "unknown")
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+		root.SetArgs(append([]string{"alias", "install", "--skip-path-check", "--quiet"}, args...))
+		return root.Execute()
+	}
+
+	if err := run(); err != nil {
+		t.Fatalf("alias install error = %v", err)
+	}
+	if got := gitConfig(t, "config", "--get", "alias.sage"); got != aliasCommand {
+		t.Errorf("alias.sage = %q, want %q", got, aliasCommand)
+	}
+	if err := run(); err != nil {
+		t.Errorf("installing again should succeed, got %v", err)
+	}
+
+	gitConfig(t, "config", "alias.ai", "!other-tool")
+	if err := run("--name", "ai"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("replacing another alias without --force: error = %v", err)
+	}
+	if err := run("--name", "ai", "--force"); err != nil {
+		t.Fatalf("alias install --force error = %v", err)
+	}
+	if got := gitConfig(t, "config", "--get", "alias.ai"); got != aliasCommand {
+		t.Errorf("alias.ai = %q, want %q", got, aliasCommand)
+	}
+
+	if err := run("--name", "my.alias"); err == nil {
+		t.Error("alias names with a dot should be rejected")
+	}
+}
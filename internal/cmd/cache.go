@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/cache"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// NewCacheCmd creates the cache command and its subcommands.
+func NewCacheCmd() *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect the response cache",
+		Long: `Inspect the cache of generated messages kept in cache.file_path, to see
+why a message was (or was not) reused.
+
+Examples:
+  gitsage cache stats        # Show entries, hit ratio, size and age
+  gitsage cache inspect 1    # Show the most recently used entry
+  gitsage cache inspect 3f2a # Show the entry whose key starts with 3f2a`,
+	}
+
+	cacheCmd.AddCommand(newCacheStatsCmd())
+	cacheCmd.AddCommand(newCacheInspectCmd())
+
+	return cacheCmd
+}
+
+// newCacheStatsCmd creates the 'cache stats' subcommand.
+func newCacheStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show the entries, hit ratio, size and age of the response cache",
+		Long: `Show the number of cached messages, how many lookups found one, the
+size of the cached messages and the oldest and newest entry, followed by the
+entries, most recently used first. Use the number or key of an entry with
+"gitsage cache inspect".`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			responses, err := loadResponseCache(cmd)
+			if err != nil || responses == nil {
+				return err
+			}
+
+			stats := responses.Stats()
+			fmt.Printf("Entries: %d (%s)\n", stats.Entries, formatBytes(stats.Bytes))
+			fmt.Printf("Lookups: %d (%d hits, %.0f%% hit ratio)\n",
+				stats.Hits+stats.Misses, stats.Hits, stats.HitRatio()*100)
+			if stats.Entries == 0 {
+				return nil
+			}
+			fmt.Printf("Oldest:  %s\n", stats.Oldest.Format(time.RFC3339))
+			fmt.Printf("Newest:  %s\n", stats.Newest.Format(time.RFC3339))
+
+			fmt.Println()
+			for i, entry := range responses.Entries() {
+				fmt.Printf("[%d] %s  %s  %s  %d hits  %s\n", i+1, shortKey(entry.Key),
+					entry.CreatedAt.Format(time.RFC3339), formatBytes(entry.Size), entry.Hits, cachedSubject(entry))
+			}
+			return nil
+		},
+	}
+}
+
+// newCacheInspectCmd creates the 'cache inspect' subcommand.
+func newCacheInspectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "inspect <key|index>",
+		Short: "Show a response cache entry",
+		Long: `Show a cached message with its key, age, expiry and hits. The entry is
+given by its number in "gitsage cache stats" (1 is the most recently used)
+or by its key or a unique prefix of it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			responses, err := loadResponseCache(cmd)
+			if err != nil || responses == nil {
+				return err
+			}
+
+			entry, err := findCacheEntry(responses.Entries(), args[0])
+			if err != nil {
+				return err
+			}
+
+			value, err := json.MarshalIndent(entry.Value, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to format cache entry: %w", err)
+			}
+			fmt.Printf("Key:     %s\n", entry.Key)
+			fmt.Printf("Created: %s\n", entry.CreatedAt.Format(time.RFC3339))
+			fmt.Printf("Expires: %s\n", entry.ExpiresAt.Format(time.RFC3339))
+			fmt.Printf("Size:    %s\n", formatBytes(entry.Size))
+			fmt.Printf("Hits:    %d\n", entry.Hits)
+			fmt.Println()
+			fmt.Println(string(value))
+			return nil
+		},
+	}
+}
+
+// loadResponseCache loads the response cache from cache.file_path. It
+// returns nil after telling the user when the cache is not kept in a file.
+func loadResponseCache(cmd *cobra.Command) (*cache.LRUCache, error) {
+	mgr, err := newConfigManager(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config manager: %w", err)
+	}
+
+	cfg, err := mgr.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cfg.Cache.Enabled {
+		fmt.Println("The response cache is disabled. Enable it with: gitsage config set cache.enabled true")
+		return nil, nil
+	}
+	if cfg.Cache.FilePath == "" {
+		fmt.Println("The response cache is kept in memory only. Set cache.file_path to keep it between runs.")
+		return nil, nil
+	}
+
+	responses := cache.NewLRUCache(cfg.Cache.MaxEntries, time.Duration(cfg.Cache.TTLMinutes)*time.Minute)
+	if err := responses.Load(cfg.Cache.FilePath); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// findCacheEntry returns the entry with the given 1-based index, full key or
+// unique key prefix.
+func findCacheEntry(entries []cache.EntryInfo, ref string) (cache.EntryInfo, error) {
+	if index, err := strconv.Atoi(ref); err == nil && index >= 1 && index <= len(entries) {
+		return entries[index-1], nil
+	}
+
+	var matches []cache.EntryInfo
+	for _, entry := range entries {
+		if entry.Key == ref {
+			return entry, nil
+		}
+		if strings.HasPrefix(entry.Key, ref) {
+			matches = append(matches, entry)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return cache.EntryInfo{}, apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("no cache entry %q", ref))
+	case 1:
+		return matches[0], nil
+	default:
+		return cache.EntryInfo{}, apperrors.New(apperrors.ErrInvalidArguments,
+			fmt.Sprintf("cache key prefix %q matches %d entries", ref, len(matches)))
+	}
+}
+
+// cachedSubject returns the subject of a cached message, if it has one.
+func cachedSubject(entry cache.EntryInfo) string {
+	raw, ok := entry.Value.(json.RawMessage)
+	if !ok {
+		return ""
+	}
+	var response ai.GenerateResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return ""
+	}
+	return response.Subject
+}
+
+// shortKey returns the first characters of a cache key, enough to inspect it.
+func shortKey(key string) string {
+	if len(key) > 12 {
+		return key[:12]
+	}
+	return key
+}
+
+// formatBytes formats a size in bytes for display.
+func formatBytes(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KB", float64(size)/1024)
+}
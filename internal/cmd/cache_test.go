@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/cache"
+)
+
+func TestFindCacheEntry(t *testing.T) {
+	entries := []cache.EntryInfo{{Key: "3f2a01"}, {Key: "3f2b02"}, {Key: "9c0003"}}
+
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"1", "3f2a01", false},
+		{"3", "9c0003", false},
+		{"3f2b", "3f2b02", false},
+		{"9c0003", "9c0003", false},
+		{"3f2", "", true},
+		{"ffff", "", true},
+		{"4", "", true},
+	}
+
+	for _, tt := range tests {
+		entry, err := findCacheEntry(entries, tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("findCacheEntry(%q) should fail", tt.ref)
+			}
+			continue
+		}
+		if err != nil || entry.Key != tt.want {
+			t.Errorf("findCacheEntry(%q) = %q, %v; want %q", tt.ref, entry.Key, err, tt.want)
+		}
+	}
+}
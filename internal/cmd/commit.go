@@ -15,6 +15,7 @@ import (
 	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
 	"github.com/gitsage/gitsage/internal/pkg/git"
 	"github.com/gitsage/gitsage/internal/pkg/history"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 	"github.com/gitsage/gitsage/internal/pkg/processor"
 	"github.com/gitsage/gitsage/internal/pkg/security"
 	"github.com/gitsage/gitsage/internal/pkg/ui"
@@ -23,10 +24,24 @@ import (
 
 // CommitFlags holds the flags for the commit command.
 type CommitFlags struct {
-	DryRun     bool
-	Yes        bool
-	OutputFile string
-	NoCache    bool
+	DryRun       bool
+	Yes          bool
+	OutputFile   string
+	NoCache      bool
+	ExplainPlan  bool
+	All          bool
+	PathspecFile string
+	Type         string
+	Scope        string
+	Context      string
+	Issue        string
+	OutputFormat string
+	Resume       bool
+	Base         string
+	Split        bool
+	Compare      string
+	StatsOnly    bool
+	ShowPayload  bool
 }
 
 // NewCommitCmd creates the commit command.
@@ -47,92 +62,153 @@ Examples:
   gitsage commit              # Interactive commit
   gitsage commit --yes        # Auto-accept generated message
   gitsage commit --dry-run    # Generate without committing
-  gitsage commit -o msg.txt   # Save message to file`,
+  gitsage commit -o msg.txt   # Save message to file
+  gitsage commit -a           # Include modified tracked files without staging
+  gitsage commit --explain-plan  # Show how the diff would be sent to the AI
+  gitsage commit --type fix --scope auth  # Require the given type and scope
+  gitsage commit -m "fixes the race in batch uploader"  # Explain why the change was made
+  gitsage commit --output-format json  # Print message, files and stats as JSON (implies --dry-run)
+  gitsage commit --resume        # Continue with the message left by a failed or interrupted run
+  gitsage commit --split         # One commit per package in generation.scope_rules
+  gitsage commit --compare providers=openai,ollama  # Pick among the messages of several providers`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCommit(cmd, flags)
 		},
 	}
 
-	// Add commit-specific flags
+	addCommitFlags(cmd, flags)
+
+	return cmd
+}
+
+// addCommitFlags adds the flags of the commit command, which running gitsage
+// without a subcommand accepts as well.
+func addCommitFlags(cmd *cobra.Command, flags *CommitFlags) {
 	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Generate message without committing")
 	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip interactive confirmation and commit immediately")
 	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write generated message to file (implies --dry-run)")
 	cmd.Flags().BoolVar(&flags.NoCache, "no-cache", false, "Bypass response cache")
-
-	return cmd
+	cmd.Flags().BoolVar(&flags.ExplainPlan, "explain-plan", false, "Show how the diff would be grouped and sent to the AI, then exit")
+	cmd.Flags().BoolVarP(&flags.All, "all", "a", false, "Include modified and deleted tracked files without staging them (git commit -a)")
+	cmd.Flags().StringVar(&flags.PathspecFile, "pathspec-from-file", "", "Commit only the paths listed in this file, one per line")
+	cmd.Flags().StringVar(&flags.Type, "type", "", "Require this Conventional Commits type in the generated message (e.g. fix)")
+	cmd.Flags().StringVar(&flags.Scope, "scope", "", "Require this scope in the generated message (e.g. auth)")
+	cmd.Flags().StringVarP(&flags.Context, "context", "m", "", "Explain why the change was made; passed to the AI as authoritative intent")
+	cmd.Flags().StringVar(&flags.Issue, "issue", "", "Reference this issue in the footer, in the linking syntax of the origin's hosting platform (e.g. 12)")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output-format", app.OutputFormatText, "Dry-run output format: text, json or github (json and github imply --dry-run)")
+	cmd.Flags().BoolVar(&flags.Resume, "resume", false, "Continue with the message saved in .git/"+app.RecoveryFileName+" by an earlier run")
+	cmd.Flags().BoolVar(&flags.Split, "split", false, "Make one commit per package matched by generation.scope_rules, cross-package changes last")
+	cmd.Flags().StringVar(&flags.Compare, "compare", "", "Generate with several providers in parallel and pick a message (providers=openai,ollama:llama3)")
+	cmd.Flags().BoolVar(&flags.StatsOnly, "stats-only", false, "Send only file paths, change types and +/- counts to the AI, never the diff content")
+	cmd.Flags().BoolVar(&flags.ShowPayload, "show-payload", false, "Show the files, sizes and redactions of what is sent to the AI and ask before sending it")
 }
 
 // runCommit executes the commit command logic.
 func runCommit(cmd *cobra.Command, flags *CommitFlags) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	// The command context is cancelled on SIGINT/SIGTERM; Ctrl+C pressed while
+	// a spinner owns the raw-mode terminal cancels it through the UI
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
 	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
 
-	// Get global flags
+	cfg, err := loadCommandConfig(cmd, flags.Yes)
+	if err != nil {
+		return err
+	}
 	verbose, _ := cmd.Flags().GetBool("verbose")
-	configPath, _ := cmd.Flags().GetString("config")
-	providerOverride, _ := cmd.Flags().GetString("provider")
-	modelOverride, _ := cmd.Flags().GetString("model")
 
-	// Enable verbose logging if flag is set
-	apperrors.SetVerbose(verbose)
+	if _, err := ai.ParsePreset(cfg.Generation.Preset); err != nil {
+		apperrors.Error("Invalid generation preset: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.preset")
+	}
 
-	// Load configuration with custom path if specified
-	// The --config flag allows using a different config file for this execution
-	cfgMgr, err := config.NewManager(configPath)
-	if err != nil {
-		apperrors.Error("Failed to create config manager: %v", err)
-		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "failed to create config manager")
+	if _, err := ai.ParseFewShotMode(cfg.Generation.FewShot); err != nil {
+		apperrors.Error("Invalid few-shot mode: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.few_shot")
 	}
 
-	// Log custom config path if specified
-	if configPath != "" {
-		apperrors.Debug("Using custom config path: %s", configPath)
+	if _, err := app.ParseDuplicateCheck(cfg.Generation.DuplicateCheck); err != nil {
+		apperrors.Error("Invalid duplicate check: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.duplicate_check")
 	}
 
-	// Check if config exists
-	if !cfgMgr.ConfigExists() {
-		// Launch interactive setup if config doesn't exist
-		if err := ui.RunInteractiveSetup(cfgMgr); err != nil {
-			return fmt.Errorf("setup failed: %w", err)
-		}
+	if _, err := app.ParseScoreMode(cfg.Generation.Score); err != nil {
+		apperrors.Error("Invalid score mode: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.score")
 	}
 
-	// Apply command-line flag overrides BEFORE loading config
-	// This ensures flags take highest priority (flags > env > file > defaults)
-	// These overrides are temporary and don't persist to the config file
-	if providerOverride != "" {
-		cfgMgr.SetOverride("provider.name", providerOverride)
-		apperrors.Debug("Provider overridden via flag: %s", providerOverride)
+	if _, err := app.ParseOwnershipMode(cfg.Generation.Ownership); err != nil {
+		apperrors.Error("Invalid ownership mode: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.ownership")
 	}
-	if modelOverride != "" {
-		cfgMgr.SetOverride("provider.model", modelOverride)
-		apperrors.Debug("Model overridden via flag: %s", modelOverride)
+
+	if _, err := app.ParseGroupFailure(cfg.Generation.GroupFailure); err != nil {
+		apperrors.Error("Invalid group failure policy: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.group_failure")
 	}
 
-	cfg, err := cfgMgr.Load()
-	if err != nil {
-		apperrors.Error("Failed to load config: %v", err)
-		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "failed to load config")
+	if err := app.ValidateRisk(cfg.Risk); err != nil {
+		apperrors.Error("Invalid risk settings: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid risk")
 	}
 
-	// If output file is specified, enable dry-run mode
-	if flags.OutputFile != "" {
-		flags.DryRun = true
+	if err := app.ValidateIssues(cfg.Issues); err != nil {
+		apperrors.Error("Invalid issue settings: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid issues")
 	}
 
-	// Validate API key format before making requests (fail fast)
-	if err := security.ValidateAPIKeyFormat(cfg.Provider.Name, cfg.Provider.APIKey); err != nil {
-		apperrors.Error("API key validation failed: %v", err)
-		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid API key")
+	if _, err := processor.ParseFormattingMode(cfg.Git.FormattingOnly); err != nil {
+		apperrors.Error("Invalid formatting mode: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid git.formatting_only")
 	}
 
-	// Check and show first-use security warning for external providers
-	if cfg.Provider.Name != "ollama" && !cfg.Security.WarningAcknowledged {
-		if err := showSecurityWarning(cfgMgr, flags.Yes); err != nil {
-			return err
+	if err := processor.ValidatePathWeights(app.PathWeights(cfg.Git.PathWeights)); err != nil {
+		apperrors.Error("Invalid path weights: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid git.path_weights")
+	}
+
+	if err := app.ValidateScopeRules(cfg.Generation.ScopeRules); err != nil {
+		apperrors.Error("Invalid scope rules: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid generation.scope_rules")
+	}
+
+	if flags.Split {
+		switch {
+		case len(cfg.Generation.ScopeRules) == 0:
+			return apperrors.New(apperrors.ErrInvalidArguments, "--split needs generation.scope_rules to be configured")
+		case flags.All || flags.PathspecFile != "" || flags.Resume:
+			return apperrors.New(apperrors.ErrInvalidArguments, "--split cannot be combined with --all, --pathspec-from-file or --resume")
 		}
 	}
 
+	compared, err := parseCompareProviders(flags.Compare, cfg.Provider)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.ErrInvalidArguments, "invalid --compare")
+	}
+	if err := checkLocalOnly(ctx, cfg.Privacy, compared...); err != nil {
+		return err
+	}
+
+	intent := ai.Intent{Type: flags.Type, Scope: strings.TrimSpace(flags.Scope)}
+	if err := intent.Validate(); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrInvalidArguments, "invalid --type or --scope")
+	}
+
+	switch flags.OutputFormat {
+	case "", app.OutputFormatText:
+	case app.OutputFormatJSON, app.OutputFormatGitHub:
+		// The reports describe a commit that was not made
+		flags.DryRun = true
+	default:
+		return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("invalid --output-format %q (valid: text, json, github)", flags.OutputFormat))
+	}
+
+	// If output file is specified, enable dry-run mode
+	if flags.OutputFile != "" {
+		flags.DryRun = true
+	}
+
 	// Verbose logging
 	if verbose {
 		apperrors.Info("Using provider: %s", cfg.Provider.Name)
@@ -147,6 +223,11 @@ func runCommit(cmd *cobra.Command, flags *CommitFlags) error {
 
 	// Create dependencies
 	gitClient := git.NewClient()
+	gitClient.SetMaxDiffMemory(cfg.Git.MaxDiffMemory)
+	gitClient.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+	if err := gitClient.SetCommitScope(git.CommitScope{All: flags.All, PathspecFile: flags.PathspecFile, Base: flags.Base}); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrInvalidArguments, "invalid commit scope")
+	}
 
 	aiProvider, err := ai.NewProvider(&cfg.Provider)
 	if err != nil {
@@ -155,13 +236,27 @@ func runCommit(cmd *cobra.Command, flags *CommitFlags) error {
 	}
 	apperrors.Debug("AI provider created: %s", aiProvider.Name())
 
-	diffProcessor := processor.NewProcessorWithConfig(processor.ProcessorConfig{
-		DiffSizeThreshold: cfg.Git.DiffSizeThreshold,
-	})
+	diffProcessor := newDiffProcessor(cfg)
 
-	// Create UI manager - always use DefaultManager for consistent UI experience
-	// The --yes flag controls auto-accept behavior, not the UI style
-	uiMgr := ui.NewDefaultManager(cfg.UI.ColorEnabled, cfg.UI.Editor, flags.Yes)
+	// Create UI manager - interactive on a terminal (or line prompts in accessible mode),
+	// NonInteractiveManager when output is redirected. The --yes flag controls
+	// auto-accept behavior, not the UI style
+	keys, err := ui.NewKeyMap(cfg.UI.KeyBindings)
+	if err != nil {
+		apperrors.Error("Invalid key bindings: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid ui.keybindings")
+	}
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Editor:       messageEditor(ctx, gitClient, cfg),
+		AutoAccept:   flags.Yes || app.IsReportFormat(flags.OutputFormat),
+		KeyMap:       keys,
+		VimMode:      cfg.UI.VimMode,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
 
 	// Create history manager
 	var historyMgr history.Manager
@@ -179,27 +274,275 @@ func runCommit(cmd *cobra.Command, flags *CommitFlags) error {
 		cfg,
 	)
 
+	// Optional critic pass, using a separate (typically cheaper) model if configured
+	if cfg.Generation.Verify {
+		criticCfg := cfg.Provider
+		if cfg.Generation.VerifyModel != "" {
+			criticCfg.Model = cfg.Generation.VerifyModel
+		}
+		critic, err := ai.NewProvider(&criticCfg)
+		if err != nil {
+			apperrors.Error("Failed to create critic provider: %v", err)
+			return apperrors.NewAIProviderError(cfg.Provider.Name, err)
+		}
+		service.SetCritic(critic)
+	}
+
+	// The first message comes from all compared providers; the configured
+	// provider is used as is, the others with their default endpoint
+	if len(compared) > 0 {
+		providers := make([]app.CompareProvider, 0, len(compared))
+		for _, providerCfg := range compared {
+			provider := aiProvider
+			if providerCfg.Name != cfg.Provider.Name || providerCfg.Model != cfg.Provider.Model {
+				if provider, err = ai.NewProvider(&providerCfg); err != nil {
+					apperrors.Error("Failed to create AI provider: %v", err)
+					return apperrors.NewAIProviderError(providerCfg.Name, err)
+				}
+			}
+			providers = append(providers, app.CompareProvider{Provider: provider, Model: providerCfg.Model})
+		}
+		service.SetCompare(providers)
+	}
+
+	// Pressing "s" during file group summaries generates from those done so far
+	ui.SetStopHandler(service.StopSummarizing)
+	defer ui.SetStopHandler(nil)
+
 	// Execute the commit workflow
 	opts := &app.CommitOptions{
-		DryRun:      flags.DryRun,
-		OutputFile:  flags.OutputFile,
-		SkipConfirm: flags.Yes,
-		NoCache:     flags.NoCache,
+		DryRun:       flags.DryRun,
+		OutputFile:   flags.OutputFile,
+		SkipConfirm:  flags.Yes,
+		NoCache:      flags.NoCache,
+		ExplainPlan:  flags.ExplainPlan,
+		Intent:       intent,
+		Context:      strings.TrimSpace(flags.Context),
+		Issue:        flags.Issue,
+		OutputFormat: flags.OutputFormat,
+		Resume:       flags.Resume,
+		SquashBase:   flags.Base,
+		Split:        flags.Split,
+		StatsOnly:    flags.StatsOnly,
+		ShowPayload:  flags.ShowPayload || firstUsePayload(cfg, flags.Yes),
 	}
 
 	return service.GenerateAndCommit(ctx, opts)
 }
 
+// messageEditor returns the external editor for messages the way git picks
+// one: ui.editor, else $GIT_EDITOR, else core.editor. An empty result leaves
+// the UI to fall back to $VISUAL and $EDITOR.
+func messageEditor(ctx context.Context, gitClient *git.DefaultClient, cfg *config.Config) string {
+	if strings.TrimSpace(cfg.UI.Editor) != "" {
+		return cfg.UI.Editor
+	}
+	if editor := os.Getenv("GIT_EDITOR"); strings.TrimSpace(editor) != "" {
+		return editor
+	}
+	editor, err := gitClient.GetConfig(ctx, "core.editor", false)
+	if err != nil {
+		apperrors.Debug("Failed to read core.editor: %v", err)
+	}
+	return editor
+}
+
+// newDiffProcessor creates the diff processor configured by the git section.
+func newDiffProcessor(cfg *config.Config) processor.DiffProcessor {
+	return processor.NewProcessorWithConfig(processor.ProcessorConfig{
+		DiffSizeThreshold:  cfg.Git.DiffSizeThreshold,
+		SummarizeLockFiles: cfg.Git.SummarizeLockFiles,
+		GeneratedPatterns:  cfg.Git.GeneratedPatterns,
+		FormattingMode:     cfg.Git.FormattingOnly,
+		Minify: processor.MinifyConfig{
+			Enabled:             cfg.Git.Minify.Enabled,
+			ContextLines:        cfg.Git.Minify.ContextLines,
+			DropWhitespaceHunks: cfg.Git.Minify.DropWhitespaceHunks,
+			VendorPatterns:      cfg.Git.Minify.VendorPatterns,
+		},
+		PathWeights: app.PathWeights(cfg.Git.PathWeights),
+	})
+}
+
+// parseCompareProviders parses the --compare value "providers=name[:model],...".
+// A provider named like the configured one uses its settings, with the model
+// replaced if one is given. The others share the API key and use their default
+// endpoint and model. An empty value compares nothing.
+func parseCompareProviders(value string, base config.ProviderConfig) ([]config.ProviderConfig, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	list, ok := strings.CutPrefix(value, "providers=")
+	if !ok {
+		return nil, fmt.Errorf("expected providers=name,name, got %q", value)
+	}
+
+	var providers []config.ProviderConfig
+	seen := make(map[string]bool)
+	for _, spec := range strings.Split(list, ",") {
+		name, model, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		name, model = strings.TrimSpace(name), strings.TrimSpace(model)
+		if name == "" {
+			return nil, fmt.Errorf("empty provider name in %q", list)
+		}
+		key := name + ":" + model
+		if seen[key] {
+			return nil, fmt.Errorf("provider %s is listed twice", strings.TrimSuffix(key, ":"))
+		}
+		seen[key] = true
+
+		providerCfg := base
+		if name != base.Name {
+			providerCfg = config.ProviderConfig{
+				Name:         name,
+				APIKey:       base.APIKey,
+				APIKeyCmd:    base.APIKeyCmd,
+				APIKeyFile:   base.APIKeyFile,
+				Organization: base.Organization,
+				Project:      base.Project,
+				User:         base.User,
+				ExtraHeaders: base.ExtraHeaders,
+				Temperature:  base.Temperature,
+				MaxTokens:    base.MaxTokens,
+				Ollama:       base.Ollama,
+			}
+		}
+		if model != "" {
+			providerCfg.Model = model
+		}
+		providers = append(providers, providerCfg)
+	}
+
+	if len(providers) < 2 {
+		return nil, fmt.Errorf("at least two providers are needed to compare, got %q", list)
+	}
+	return providers, nil
+}
+
+// loadCommandConfig loads the configuration for a command that calls the AI
+// provider: it runs the setup wizard if needed, applies the --provider and
+// --model overrides, and checks the API key and first-use security warning.
+func loadCommandConfig(cmd *cobra.Command, yes bool) (*config.Config, error) {
+	// Get global flags
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	configPath, _ := cmd.Flags().GetString("config")
+	providerOverride, _ := cmd.Flags().GetString("provider")
+	modelOverride, _ := cmd.Flags().GetString("model")
+
+	// Enable verbose logging if flag is set
+	apperrors.SetVerbose(verbose)
+
+	// Load configuration with custom path if specified
+	// The --config flag allows using a different config file for this execution
+	cfgMgr, err := newConfigManager(cmd)
+	if err != nil {
+		apperrors.Error("Failed to create config manager: %v", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrInvalidConfig, "failed to create config manager")
+	}
+
+	// Log custom config path if specified
+	if configPath != "" {
+		apperrors.Debug("Using custom config path: %s", configPath)
+	}
+	if profile := cfgMgr.Profile(); profile != "" {
+		apperrors.Debug("Using config profile: %s", profile)
+	}
+
+	_, noInput := scriptFlags(cmd)
+
+	// Check if config exists
+	if !cfgMgr.ConfigExists() {
+		if noInput {
+			return nil, apperrors.New(apperrors.ErrInvalidConfig, "configuration not found; run 'gitsage config init' first")
+		}
+		// Launch interactive setup if config doesn't exist
+		if err := ui.RunInteractiveSetup(cfgMgr); err != nil {
+			return nil, fmt.Errorf("setup failed: %w", err)
+		}
+	}
+
+	// Apply command-line flag overrides BEFORE loading config
+	// This ensures flags take highest priority (flags > env > file > defaults)
+	// These overrides are temporary and don't persist to the config file
+	if providerOverride != "" {
+		cfgMgr.SetOverride("provider.name", providerOverride)
+		apperrors.Debug("Provider overridden via flag: %s", providerOverride)
+	}
+	if modelOverride != "" {
+		cfgMgr.SetOverride("provider.model", modelOverride)
+		apperrors.Debug("Model overridden via flag: %s", modelOverride)
+	}
+
+	cfg, err := cfgMgr.Load()
+	if err != nil {
+		apperrors.Error("Failed to load config: %v", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrInvalidConfig, "failed to load config")
+	}
+
+	if err := i18n.SetLanguage(cfg.UI.Language); err != nil {
+		apperrors.Error("Invalid UI language: %v", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid ui.language")
+	}
+
+	// Refuse remote providers before anything is sent when the user or the
+	// repository requires local ones
+	if err := applyRepoPrivacy(cmd.Context(), cfg); err != nil {
+		return nil, err
+	}
+	if err := checkLocalOnly(cmd.Context(), cfg.Privacy, cfg.Provider); err != nil {
+		return nil, err
+	}
+
+	// Validate API key format before making requests (fail fast); keys from
+	// api_key_cmd or api_key_file are read when the provider is created
+	if cfg.Provider.HasAPIKeySource() {
+		apperrors.Debug("API key is read from provider.api_key_cmd or provider.api_key_file")
+	} else if err := security.ValidateAPIKeyFormat(cfg.Provider.Name, cfg.Provider.APIKey); err != nil {
+		apperrors.Error("API key validation failed: %v", err)
+		return nil, apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid API key")
+	}
+
+	// Check and show first-use security warning for external providers
+	if needsSecurityWarning(cfg) {
+		if noInput && !yes {
+			return nil, apperrors.New(apperrors.ErrInvalidArguments, "the first-use security warning must be acknowledged; rerun with --yes or without --no-input")
+		}
+		if err := showSecurityWarning(cfgMgr, yes); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// needsSecurityWarning reports whether the first-use security warning for
+// external providers has not been acknowledged yet. The configuration keeps
+// this value when the warning is acknowledged after it was loaded.
+func needsSecurityWarning(cfg *config.Config) bool {
+	return cfg.Provider.Name != "ollama" && cfg.Provider.Name != "mock" && !cfg.Security.WarningAcknowledged
+}
+
+// firstUsePayload reports whether a command shows what it is about to send
+// and asks before its first request. That is the case on the first use of an
+// external provider, right after the security warning was acknowledged, so
+// nothing leaves the machine before the user has seen what it is. --yes
+// skips it like the other confirmations.
+func firstUsePayload(cfg *config.Config, yes bool) bool {
+	return needsSecurityWarning(cfg) && !yes
+}
+
 // showSecurityWarning displays the first-use security warning and prompts for acknowledgment.
 func showSecurityWarning(cfgMgr *config.ViperManager, autoAccept bool) error {
 	fmt.Print(security.FirstUseWarning)
 
 	if autoAccept {
 		// In non-interactive mode, auto-acknowledge
-		fmt.Println("Auto-acknowledging security warning (--yes flag)")
+		fmt.Println(i18n.T("security.auto_acknowledge"))
 	} else {
 		// Prompt for acknowledgment
-		fmt.Print("Do you understand and wish to continue? [y/N]: ")
+		fmt.Print(i18n.T("security.prompt"))
 		reader := bufio.NewReader(os.Stdin)
 		response, err := reader.ReadString('\n')
 		if err != nil {
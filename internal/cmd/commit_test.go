@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestParseCompareProviders(t *testing.T) {
+	base := config.ProviderConfig{
+		Name:        "openai",
+		APIKey:      "sk-test",
+		Model:       "gpt-4o-mini",
+		Endpoint:    "https://proxy.example.com/v1",
+		Temperature: 0.5,
+	}
+
+	t.Run("empty compares nothing", func(t *testing.T) {
+		providers, err := parseCompareProviders("", base)
+		if err != nil || providers != nil {
+			t.Errorf("parseCompareProviders(\"\") = %v, %v; want nil, nil", providers, err)
+		}
+	})
+
+	t.Run("configured and other providers", func(t *testing.T) {
+		providers, err := parseCompareProviders("providers=openai, ollama:llama3", base)
+		if err != nil {
+			t.Fatalf("parseCompareProviders() error = %v", err)
+		}
+		if len(providers) != 2 {
+			t.Fatalf("got %d providers, want 2", len(providers))
+		}
+		if !reflect.DeepEqual(providers[0], base) {
+			t.Errorf("configured provider = %+v, want %+v", providers[0], base)
+		}
+		want := config.ProviderConfig{Name: "ollama", APIKey: "sk-test", Model: "llama3", Temperature: 0.5}
+		if !reflect.DeepEqual(providers[1], want) {
+			t.Errorf("other provider = %+v, want %+v", providers[1], want)
+		}
+	})
+
+	t.Run("same provider with another model", func(t *testing.T) {
+		providers, err := parseCompareProviders("providers=openai,openai:gpt-4o", base)
+		if err != nil {
+			t.Fatalf("parseCompareProviders() error = %v", err)
+		}
+		if providers[1].Model != "gpt-4o" || providers[1].Endpoint != base.Endpoint {
+			t.Errorf("second provider = %+v, want the configured one with model gpt-4o", providers[1])
+		}
+	})
+
+	errorTests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{"missing key", "openai,ollama", "expected providers="},
+		{"one provider", "providers=openai", "at least two providers"},
+		{"empty name", "providers=openai,,ollama", "empty provider name"},
+		{"listed twice", "providers=ollama,ollama", "provider ollama is listed twice"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseCompareProviders(tt.value, base)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseCompareProviders(%q) error = %v, want %q", tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 
 	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +29,8 @@ Configuration is stored in ~/.gitsage/config.yaml by default.`,
 	configCmd.AddCommand(newConfigSetCmd())
 	configCmd.AddCommand(newConfigListCmd())
 	configCmd.AddCommand(newConfigEditCmd())
+	configCmd.AddCommand(newConfigTUICmd())
+	configCmd.AddCommand(newConfigExplainCmd())
 
 	return configCmd
 }
@@ -40,8 +45,7 @@ func newConfigInitCmd() *cobra.Command {
 The configuration file will be created with permissions 0600 (user read/write only)
 for security, as it may contain API keys.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			configPath, _ := cmd.Flags().GetString("config")
-			mgr, err := config.NewManager(configPath)
+			mgr, err := newConfigManager(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to create config manager: %w", err)
 			}
@@ -50,8 +54,10 @@ for security, as it may contain API keys.`,
 				return err
 			}
 
-			fmt.Printf("Configuration file created at %s\n", mgr.GetConfigPath())
-			fmt.Println("Edit this file to set your API key and customize settings.")
+			if quiet, _ := scriptFlags(cmd); !quiet {
+				fmt.Printf("Configuration file created at %s\n", mgr.GetConfigPath())
+				fmt.Println("Edit this file to set your API key and customize settings.")
+			}
 			return nil
 		},
 	}
@@ -70,14 +76,21 @@ Examples:
   gitsage config set provider.name openai
   gitsage config set provider.api_key sk-xxx
   gitsage config set provider.model gpt-4o-mini
-  gitsage config set git.diff_size_threshold 20480`,
-		Args: cobra.ExactArgs(2),
+  gitsage config set git.diff_size_threshold 20480
+
+Keys and values are checked before the file is written; lists such as
+generation.scope_rules are edited in the config file.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigSet,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := args[0]
 			value := args[1]
 
-			configPath, _ := cmd.Flags().GetString("config")
-			mgr, err := config.NewManager(configPath)
+			if err := config.ValidateSetting(key, value); err != nil {
+				return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid setting")
+			}
+
+			mgr, err := newConfigManager(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to create config manager: %w", err)
 			}
@@ -97,7 +110,9 @@ Examples:
 				displayValue = config.MaskAPIKey(value)
 			}
 
-			fmt.Printf("Set %s = %s\n", key, displayValue)
+			if quiet, _ := scriptFlags(cmd); !quiet {
+				fmt.Printf("Set %s = %s\n", key, displayValue)
+			}
 			return nil
 		},
 	}
@@ -112,8 +127,7 @@ func newConfigListCmd() *cobra.Command {
 
 API keys are masked for security, showing only the last 4 characters.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			configPath, _ := cmd.Flags().GetString("config")
-			mgr, err := config.NewManager(configPath)
+			mgr, err := newConfigManager(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to create config manager: %w", err)
 			}
@@ -132,8 +146,7 @@ func newConfigEditCmd() *cobra.Command {
 		Short: "Edit configuration file",
 		Long:  `Open the configuration file in your default editor.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			configPath, _ := cmd.Flags().GetString("config")
-			mgr, err := config.NewManager(configPath)
+			mgr, err := newConfigManager(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to create config manager: %w", err)
 			}
@@ -141,6 +154,9 @@ func newConfigEditCmd() *cobra.Command {
 			if !mgr.ConfigExists() {
 				return fmt.Errorf("config file not found at %s. Run 'gitsage config init' first", mgr.GetConfigPath())
 			}
+			if _, noInput := scriptFlags(cmd); noInput {
+				return fmt.Errorf("cannot open an editor with --no-input")
+			}
 
 			path := mgr.GetConfigPath()
 			editor := os.Getenv("EDITOR")
@@ -155,7 +171,9 @@ func newConfigEditCmd() *cobra.Command {
 				}
 			}
 
-			fmt.Printf("Opening config file %s with %s...\n", path, editor)
+			if quiet, _ := scriptFlags(cmd); !quiet {
+				fmt.Printf("Opening config file %s with %s...\n", path, editor)
+			}
 
 			c := exec.Command(editor, path)
 			c.Stdin = os.Stdin
@@ -166,6 +184,187 @@ func newConfigEditCmd() *cobra.Command {
 	}
 }
 
+// newConfigTUICmd creates the 'config tui' subcommand.
+func newConfigTUICmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Edit configuration in a full-screen editor",
+		Long: `Open a full-screen editor listing every key that 'config set' accepts,
+grouped by section, with its current value and description.
+
+Enter toggles a boolean, picks one of the accepted values of keys such as
+provider.name, or edits the value of the others. Values are checked as they
+are typed, and each change is written to the config file once confirmed.
+API keys are masked. Lists such as generation.scope_rules are edited in the
+config file with 'gitsage config edit'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mgr, err := newConfigManager(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to create config manager: %w", err)
+			}
+
+			if !mgr.ConfigExists() {
+				return fmt.Errorf("config file not found at %s. Run 'gitsage config init' first", mgr.GetConfigPath())
+			}
+			if _, noInput := scriptFlags(cmd); noInput || !ui.IsInteractive() {
+				return fmt.Errorf("the config editor needs a terminal; use 'gitsage config set' instead")
+			}
+
+			return ui.RunConfigEditor(mgr)
+		},
+	}
+}
+
+// newConfigManager creates the config manager for the --config file, with
+// the profile selected by --profile, if given, or GITSAGE_PROFILE.
+func newConfigManager(cmd *cobra.Command) (*config.ViperManager, error) {
+	configPath, _ := cmd.Flags().GetString("config")
+	mgr, err := config.NewManager(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
+		mgr.SetProfile(profile)
+	}
+	return mgr, nil
+}
+
+// completeConfigKeys completes the first argument with the keys that can be
+// set, each with its description.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var keys []string
+	for _, info := range config.Keys() {
+		if strings.HasPrefix(info.Key, toComplete) {
+			keys = append(keys, info.Key+"\t"+info.Description)
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeConfigSet completes the key of 'config set', then its value when
+// the key takes one of a fixed set or a boolean.
+func completeConfigSet(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 1 {
+		return completeConfigKeys(cmd, args, toComplete)
+	}
+	info, ok := config.LookupKey(args[0])
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	switch {
+	case len(info.Values) > 0:
+		return info.Values, cobra.ShellCompDirectiveNoFileComp
+	case info.Type == config.TypeBool:
+		return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+	case strings.HasSuffix(info.Key, "_file") || strings.HasSuffix(info.Key, "file_path"):
+		return nil, cobra.ShellCompDirectiveDefault
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// overrideFlags are the flags that override a configuration key for one run.
+var overrideFlags = map[string]string{
+	"provider.name":  "provider",
+	"provider.model": "model",
+}
+
+// newConfigExplainCmd creates the 'config explain' subcommand.
+func newConfigExplainCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain <key>",
+		Short: "Show the effective value of a key and where it comes from",
+		Long: `Show the value a configuration key has for a run, and its value in each
+source: flags (--provider and --model), environment variables, the config
+file and the defaults. The first source that sets the key wins.
+
+Examples:
+  gitsage config explain provider.model
+  gitsage config explain provider.model --model gpt-4o
+  gitsage --config ./team.yaml config explain generation.preset`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			mgr, err := newConfigManager(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to create config manager: %w", err)
+			}
+
+			// Apply the same overrides as a run with these flags
+			for key, name := range overrideFlags {
+				if value, _ := cmd.Flags().GetString(name); value != "" {
+					mgr.SetOverride(key, value)
+				}
+			}
+
+			explanation, err := mgr.Explain(args[0])
+			if err != nil {
+				return err
+			}
+			printExplanation(os.Stdout, explanation)
+			return nil
+		},
+	}
+}
+
+// printExplanation prints the effective value of a key and its value in
+// each source, marking the source that wins. The flag row is only shown for
+// keys that have a flag.
+func printExplanation(w io.Writer, explanation *config.Explanation) {
+	display := func(value interface{}) string {
+		text := fmt.Sprintf("%v", value)
+		if strings.Contains(explanation.Key, "api_key") && text != "" {
+			text = config.MaskAPIKey(text)
+		}
+		return text
+	}
+	origin := func(layer config.Layer) string {
+		switch layer.Source {
+		case config.SourceFlag:
+			return "--" + overrideFlags[explanation.Key]
+		case config.SourceEnv:
+			return "environment variable " + layer.Origin
+		case config.SourceProfile:
+			return "profile section " + layer.Origin
+		case config.SourceFile:
+			return "config file " + layer.Origin
+		default:
+			return "the default"
+		}
+	}
+
+	effective := explanation.Effective()
+	fmt.Fprintf(w, "%s = %s\n", explanation.Key, display(effective.Value))
+	fmt.Fprintf(w, "  from %s\n\n", origin(effective))
+
+	fmt.Fprintln(w, "Sources, highest priority first:")
+	winner := true
+	for _, layer := range explanation.Layers {
+		if layer.Source == config.SourceFlag && overrideFlags[explanation.Key] == "" {
+			continue
+		}
+		marker, value := " ", "(not set)"
+		if layer.Set {
+			value = display(layer.Value)
+			if winner {
+				marker, winner = "*", false
+			}
+		}
+		name := layer.Origin
+		if layer.Source == config.SourceFlag {
+			name = "--" + overrideFlags[explanation.Key]
+		}
+		if name != "" {
+			value += "  (" + name + ")"
+		}
+		fmt.Fprintf(w, "%s %-8s %s\n", marker, layer.Source, value)
+	}
+}
+
 // printSettings recursively prints configuration settings with proper formatting.
 func printSettings(prefix string, settings map[string]interface{}) {
 	for key, value := range settings {
@@ -201,7 +400,9 @@ func printSettingsIndented(indent, prefix string, settings map[string]interface{
 		default:
 			// Mask API keys
 			displayValue := fmt.Sprintf("%v", value)
-			if strings.Contains(strings.ToLower(key), "api_key") && displayValue != "" {
+			// Header values are usually gateway tokens
+			secret := strings.Contains(strings.ToLower(key), "api_key") || strings.HasPrefix(fullKey, "provider.extra_headers.")
+			if secret && displayValue != "" {
 				displayValue = config.MaskAPIKey(displayValue)
 			}
 			fmt.Printf("%s%s: %s\n", indent, key, displayValue)
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/spf13/cobra"
+)
+
+func TestPrintExplanation(t *testing.T) {
+	var out bytes.Buffer
+	printExplanation(&out, &config.Explanation{
+		Key: "provider.model",
+		Layers: []config.Layer{
+			{Source: config.SourceFlag},
+			{Source: config.SourceEnv, Origin: "GITSAGE_PROVIDER_MODEL", Set: true, Value: "gpt-4o"},
+			{Source: config.SourceFile, Origin: "/home/me/.gitsage/config.yaml", Set: true, Value: "deepseek-chat"},
+			{Source: config.SourceDefault, Set: true, Value: "gpt-4o-mini"},
+		},
+	})
+
+	want := `provider.model = gpt-4o
+  from environment variable GITSAGE_PROVIDER_MODEL
+
+Sources, highest priority first:
+  flag     (not set)  (--model)
+* env      gpt-4o  (GITSAGE_PROVIDER_MODEL)
+  file     deepseek-chat  (/home/me/.gitsage/config.yaml)
+  default  gpt-4o-mini
+`
+	if out.String() != want {
+		t.Errorf("printExplanation() =\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestPrintExplanation_MasksAPIKey(t *testing.T) {
+	var out bytes.Buffer
+	printExplanation(&out, &config.Explanation{
+		Key: "provider.api_key",
+		Layers: []config.Layer{
+			{Source: config.SourceEnv, Origin: "GITSAGE_PROVIDER_API_KEY", Set: true, Value: "sk-secret1234"},
+			{Source: config.SourceDefault, Set: true, Value: ""},
+		},
+	})
+
+	if strings.Contains(out.String(), "sk-secret") || !strings.Contains(out.String(), "1234") {
+		t.Errorf("API key should be masked:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "flag") {
+		t.Errorf("keys without a flag should not show the flag row:\n%s", out.String())
+	}
+}
+
+func TestConfigKeyValues_MatchParsers(t *testing.T) {
+	parsers := map[string]func(string) error{
+		"generation.preset":          func(v string) error { _, err := ai.ParsePreset(v); return err },
+		"generation.few_shot":        func(v string) error { _, err := ai.ParseFewShotMode(v); return err },
+		"generation.duplicate_check": func(v string) error { _, err := app.ParseDuplicateCheck(v); return err },
+		"generation.score":           func(v string) error { _, err := app.ParseScoreMode(v); return err },
+		"generation.group_failure":   func(v string) error { _, err := app.ParseGroupFailure(v); return err },
+		"generation.ownership":       func(v string) error { _, err := app.ParseOwnershipMode(v); return err },
+		"git.formatting_only":        func(v string) error { _, err := processor.ParseFormattingMode(v); return err },
+		"issues.platform":            func(v string) error { return app.ValidateIssues(config.IssuesConfig{Platform: v}) },
+		"ui.language":                func(v string) error { _, err := i18n.Resolve(v); return err },
+		"provider.name": func(v string) error {
+			_, err := ai.NewProvider(&config.ProviderConfig{Name: v, APIKey: "sk-test-key-that-is-long-enough-for-validation"})
+			return err
+		},
+	}
+
+	for _, info := range config.Keys() {
+		if len(info.Values) == 0 {
+			continue
+		}
+		parse, ok := parsers[info.Key]
+		if !ok {
+			t.Errorf("%s takes fixed values but has no parser to check them against", info.Key)
+			continue
+		}
+		for _, value := range info.Values {
+			if err := parse(value); err != nil {
+				t.Errorf("%s value %q is rejected at load: %v", info.Key, value, err)
+			}
+		}
+	}
+}
+
+func TestCompleteConfigSet(t *testing.T) {
+	keys, _ := completeConfigSet(nil, nil, "generation.pre")
+	if len(keys) != 1 || !strings.HasPrefix(keys[0], "generation.preset\t") {
+		t.Errorf("expected generation.preset with its description, got %v", keys)
+	}
+
+	values, directive := completeConfigSet(nil, []string{"generation.preset"}, "")
+	if strings.Join(values, ",") != "minimal,standard,detailed" || directive != cobra.ShellCompDirectiveNoFileComp {
+		t.Errorf("unexpected preset values %v (directive %d)", values, directive)
+	}
+	if values, _ := completeConfigSet(nil, []string{"ui.vim_mode"}, ""); strings.Join(values, ",") != "true,false" {
+		t.Errorf("expected booleans, got %v", values)
+	}
+	if _, directive := completeConfigSet(nil, []string{"provider.api_key_file"}, ""); directive != cobra.ShellCompDirectiveDefault {
+		t.Errorf("expected file completion for a file key, got directive %d", directive)
+	}
+	if values, _ := completeConfigSet(nil, []string{"provider.name", "openai"}, ""); len(values) != 0 {
+		t.Errorf("expected nothing after the value, got %v", values)
+	}
+}
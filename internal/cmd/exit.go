@@ -0,0 +1,42 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"errors"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Exit codes of the gitsage binary, one per error class of the errors package.
+const (
+	ExitOK            = 0
+	ExitUserError     = 1
+	ExitSystemError   = 2
+	ExitExternalError = 3
+	ExitInterrupted   = 130
+)
+
+// exitCodesHelp documents the exit codes in the root command's help.
+const exitCodesHelp = `Exit codes:
+  0    Success
+  1    User error: no staged changes, invalid configuration, arguments, API key
+       or commit message (gitsage validate)
+  2    System error: a git command failed or timed out, or a file system
+       operation failed
+  3    External error: AI provider failure, network error, rate limit, timeout
+       or authentication failure
+  130  Interrupted by Ctrl+C or SIGTERM; nothing was committed`
+
+// ExitCode returns the process exit code for an error returned by a command.
+// Cancellation exits like a shell-interrupted process; errors without an
+// error class are treated as user errors.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+	return apperrors.GetExitCode(err)
+}
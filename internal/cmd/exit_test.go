@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain error", errors.New("no changes found"), ExitUserError},
+		{"user error", apperrors.New(apperrors.ErrInvalidArguments, "bad flag"), ExitUserError},
+		{"wrapped system error", fmt.Errorf("failed to commit: %w", apperrors.NewGitError(errors.New("exit status 128"), "fatal")), ExitSystemError},
+		{"git timeout", fmt.Errorf("failed to get staged diff: %w", apperrors.NewGitTimeoutError("git diff --cached", 60*time.Second)), ExitSystemError},
+		{"wrapped provider error", fmt.Errorf("failed to generate commit message: %w", apperrors.NewAIProviderError("openai", errors.New("boom"))), ExitExternalError},
+		{"interrupted", fmt.Errorf("failed to generate commit message: %w", apperrors.NewAIProviderError("openai", context.Canceled)), ExitInterrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootHelpDocumentsExitCodes(t *testing.T) {
+	root := NewRootCmd("test", "none", "unknown")
+	if !strings.Contains(root.Long, "Exit codes:") {
+		t.Errorf("root help does not document exit codes:\n%s", root.Long)
+	}
+}
@@ -2,6 +2,7 @@
 package cmd
 
 import (
+	"github.com/gitsage/gitsage/internal/app"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +35,14 @@ Examples:
 	// Add generate-specific flags (subset of commit flags)
 	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip interactive confirmation")
 	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write generated message to file")
+	cmd.Flags().BoolVarP(&flags.All, "all", "a", false, "Include modified and deleted tracked files without staging them")
+	cmd.Flags().StringVar(&flags.PathspecFile, "pathspec-from-file", "", "Describe only the paths listed in this file, one per line")
+	cmd.Flags().StringVar(&flags.Type, "type", "", "Require this Conventional Commits type in the generated message (e.g. fix)")
+	cmd.Flags().StringVar(&flags.Scope, "scope", "", "Require this scope in the generated message (e.g. auth)")
+	cmd.Flags().StringVarP(&flags.Context, "context", "m", "", "Explain why the change was made; passed to the AI as authoritative intent")
+	cmd.Flags().StringVar(&flags.Issue, "issue", "", "Reference this issue in the footer, in the linking syntax of the origin's hosting platform (e.g. 12)")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output-format", app.OutputFormatText, "Output format: text, json or github")
+	cmd.Flags().BoolVar(&flags.ShowPayload, "show-payload", false, "Show the files, sizes and redactions of what is sent to the AI and ask before sending it")
 
 	return cmd
 }
@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/gitsage/gitsage/internal/pkg/config"
 	"github.com/gitsage/gitsage/internal/pkg/history"
 	"github.com/spf13/cobra"
 )
@@ -27,6 +27,7 @@ By default, displays the most recent 20 entries. Use --limit to change the numbe
 Examples:
   gitsage history           # Show last 20 entries
   gitsage history --limit 5 # Show last 5 entries
+  gitsage history stats     # Show token usage, prompt cache hits and provider comparisons
   gitsage history clear     # Clear all history`,
 		RunE: runHistoryList,
 	}
@@ -35,6 +36,7 @@ Examples:
 	historyCmd.Flags().IntP("limit", "l", DefaultHistoryLimit, "Number of entries to display")
 
 	// Add subcommands
+	historyCmd.AddCommand(newHistoryStatsCmd())
 	historyCmd.AddCommand(newHistoryClearCmd())
 
 	return historyCmd
@@ -45,8 +47,7 @@ func runHistoryList(cmd *cobra.Command, args []string) error {
 	limit, _ := cmd.Flags().GetInt("limit")
 
 	// Load configuration to get history file path
-	configPath, _ := cmd.Flags().GetString("config")
-	mgr, err := config.NewManager(configPath)
+	mgr, err := newConfigManager(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to create config manager: %w", err)
 	}
@@ -111,6 +112,23 @@ func printHistoryEntry(entry *history.Entry, index int) {
 		fmt.Println()
 	}
 
+	// Print the providers the message was chosen from
+	if len(entry.Compared) > 0 {
+		fmt.Printf("    Compared: %s\n", strings.Join(entry.Compared, ", "))
+	}
+
+	// Print token usage if the provider reported it
+	if entry.Usage != nil {
+		fmt.Printf("    Tokens: %d prompt (%d cached), %d output\n",
+			entry.Usage.PromptTokens, entry.Usage.CachedTokens, entry.Usage.CompletionTokens)
+	}
+
+	// Print the quality score of the message
+	if entry.Score != nil {
+		fmt.Printf("    Score: %d (specificity %d, type %d, length %d; %s)\n",
+			entry.Score.Total, entry.Score.Specificity, entry.Score.Type, entry.Score.Length, entry.Score.Method)
+	}
+
 	// Print message (indent each line)
 	fmt.Println("    Message:")
 	messageLines := strings.Split(entry.Message, "\n")
@@ -118,6 +136,14 @@ func printHistoryEntry(entry *history.Entry, index int) {
 		fmt.Printf("      %s\n", line)
 	}
 
+	// Print translation if one was kept
+	if entry.Translation != "" {
+		fmt.Println("    Translation:")
+		for _, line := range strings.Split(entry.Translation, "\n") {
+			fmt.Printf("      %s\n", line)
+		}
+	}
+
 	// Print diff summary if available
 	if entry.DiffSummary != "" {
 		fmt.Println("    Diff Summary:")
@@ -132,6 +158,167 @@ func printHistoryEntry(entry *history.Entry, index int) {
 	fmt.Println()
 }
 
+// newHistoryStatsCmd creates the 'history stats' subcommand.
+func newHistoryStatsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show token usage, provider comparisons and message quality",
+		Long: `Total the token usage recorded with the history entries, including how
+many requests read part of their prompt from the provider's prompt cache.
+
+Usage is recorded for providers that report it, such as OpenAI and DeepSeek.
+For messages generated with commit --compare, it also shows how often each
+provider's message was chosen, to help pick the default provider.
+
+Messages graded with generation.score are averaged per provider and model,
+with the trend of the most recent ones against the earlier ones.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Load configuration to get history file path
+			mgr, err := newConfigManager(cmd)
+			if err != nil {
+				return fmt.Errorf("failed to create config manager: %w", err)
+			}
+
+			cfg, err := mgr.Load()
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			historyMgr := history.NewFileManager(cfg.History.FilePath, cfg.History.MaxEntries)
+			entries, err := historyMgr.List(0)
+			if err != nil {
+				return fmt.Errorf("failed to load history: %w", err)
+			}
+
+			var total history.Usage
+			for _, entry := range entries {
+				if entry.Usage != nil {
+					total.Add(*entry.Usage)
+				}
+			}
+			if total.Requests == 0 {
+				fmt.Println("No token usage recorded.")
+			} else {
+				fmt.Printf("Requests:      %d (%d prompt cache hits, %s)\n",
+					total.Requests, total.CacheHits, percent(total.CacheHits, total.Requests))
+				fmt.Printf("Prompt tokens: %d (%d cached, %s)\n",
+					total.PromptTokens, total.CachedTokens, percent(total.CachedTokens, total.PromptTokens))
+				fmt.Printf("Output tokens: %d\n", total.CompletionTokens)
+			}
+
+			printComparisonStats(entries)
+			printQualityStats(entries)
+			return nil
+		},
+	}
+}
+
+// printComparisonStats shows how often each provider's message was chosen
+// in the comparisons recorded in history, most wins first.
+func printComparisonStats(entries []*history.Entry) {
+	compared := make(map[string]int)
+	wins := make(map[string]int)
+	for _, entry := range entries {
+		if len(entry.Compared) == 0 {
+			continue
+		}
+		for _, name := range entry.Compared {
+			compared[name]++
+		}
+		wins[entry.Provider]++
+	}
+	if len(compared) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(compared))
+	for name := range compared {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if wins[names[i]] != wins[names[j]] {
+			return wins[names[i]] > wins[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Println()
+	fmt.Println("Provider comparisons:")
+	for _, name := range names {
+		fmt.Printf("  %-12s chosen %d of %d (%s)\n", name, wins[name], compared[name], percent(wins[name], compared[name]))
+	}
+}
+
+// QualityTrendWindow is the number of most recent scored messages of a
+// provider and model compared with the earlier ones in history stats.
+const QualityTrendWindow = 10
+
+// printQualityStats shows the average quality score of the messages of each
+// provider and model, best first, and how the most recent ones compare.
+func printQualityStats(entries []*history.Entry) {
+	scores := make(map[string][]*history.Score)
+	for _, entry := range entries {
+		if entry.Score == nil {
+			continue
+		}
+		name := entry.Provider
+		if entry.Model != "" {
+			name += "/" + entry.Model
+		}
+		scores[name] = append(scores[name], entry.Score)
+	}
+	if len(scores) == 0 {
+		return
+	}
+
+	average := func(scores []*history.Score, field func(*history.Score) int) int {
+		sum := 0
+		for _, score := range scores {
+			sum += field(score)
+		}
+		return sum / len(scores)
+	}
+	total := func(score *history.Score) int { return score.Total }
+
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if a, b := average(scores[names[i]], total), average(scores[names[j]], total); a != b {
+			return a > b
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Println()
+	fmt.Println("Message quality (total: specificity / type / length):")
+	for _, name := range names {
+		list := scores[name]
+		fmt.Printf("  %-24s %3d: %d / %d / %d over %d messages", name, average(list, total),
+			average(list, func(s *history.Score) int { return s.Specificity }),
+			average(list, func(s *history.Score) int { return s.Type }),
+			average(list, func(s *history.Score) int { return s.Length }),
+			len(list))
+		// Entries are oldest first
+		if len(list) > QualityTrendWindow {
+			recent := average(list[len(list)-QualityTrendWindow:], total)
+			earlier := average(list[:len(list)-QualityTrendWindow], total)
+			fmt.Printf(", last %d: %d (%+d)", QualityTrendWindow, recent, recent-earlier)
+		}
+		fmt.Println()
+	}
+}
+
+// percent formats part as a percentage of whole.
+func percent(part, whole int) string {
+	if whole == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%d%%", part*100/whole)
+}
+
 // newHistoryClearCmd creates the 'history clear' subcommand.
 func newHistoryClearCmd() *cobra.Command {
 	return &cobra.Command{
@@ -142,8 +329,7 @@ func newHistoryClearCmd() *cobra.Command {
 This action cannot be undone.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Load configuration to get history file path
-			configPath, _ := cmd.Flags().GetString("config")
-			mgr, err := config.NewManager(configPath)
+			mgr, err := newConfigManager(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to create config manager: %w", err)
 			}
@@ -161,7 +347,9 @@ This action cannot be undone.`,
 				return fmt.Errorf("failed to clear history: %w", err)
 			}
 
-			fmt.Println("History cleared successfully.")
+			if quiet, _ := scriptFlags(cmd); !quiet {
+				fmt.Println("History cleared successfully.")
+			}
 			return nil
 		},
 	}
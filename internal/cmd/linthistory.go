@@ -0,0 +1,311 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// LintHistoryFlags holds the flags for the lint-history command.
+type LintHistoryFlags struct {
+	Range        string
+	Strict       bool
+	Suggest      bool
+	OutputFile   string
+	OutputFormat string
+}
+
+// historyLintReport is the JSON output of the lint-history command.
+type historyLintReport struct {
+	Range    string             `json:"range"`
+	Checked  int                `json:"checked"`
+	Invalid  int                `json:"invalid"`
+	Warnings int                `json:"warnings"`
+	Commits  []commitLintResult `json:"commits"`
+}
+
+// commitLintResult is the validation report of one commit.
+type commitLintResult struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	*validationReport
+	// Suggestion is a valid message generated in place of an invalid one,
+	// and SuggestionError why none could be
+	Suggestion      string `json:"suggestion,omitempty"`
+	SuggestionError string `json:"suggestion_error,omitempty"`
+}
+
+// NewLintHistoryCmd creates the lint-history command.
+func NewLintHistoryCmd() *cobra.Command {
+	flags := &LintHistoryFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "lint-history --range <range>",
+		Short: "Check the commit messages of a range of existing commits",
+		Long: `Validate the messages of existing commits against the rules of
+gitsage validate, and print a report of the commits that break them.
+Merge commits are left out, and revert, fixup! and squash! messages are
+always valid.
+
+The range is any revision range git log accepts. The command exits with 1
+if any message is invalid, so a branch can be checked before opening a
+pull request, or in CI.
+
+With --suggest, the AI writes a valid message for each invalid commit from
+its diff and current message. Nothing is rewritten: the messages are output
+as a shell script that adds an "amend!" commit for each, which
+"git rebase -i --autosquash" then uses to reword the commits. The script is
+written to stdout, or to --output, and the report to stderr.
+
+With --output-format github, the problems and suggestions are written as
+GitHub Actions annotations, which show up on the run of a pull request check.
+
+Examples:
+  gitsage lint-history --range origin/main..HEAD
+  gitsage lint-history --range v1.2.0..v1.3.0 --strict
+  gitsage lint-history --range origin/main..HEAD --output-format json
+  gitsage lint-history --range origin/main..HEAD --output-format github
+  gitsage lint-history --range origin/main..HEAD --suggest -o reword.sh`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLintHistory(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Range, "range", "", "Revision range of the commits to check (e.g. origin/main..HEAD)")
+	cmd.Flags().BoolVar(&flags.Strict, "strict", false, "Treat warnings as errors")
+	cmd.Flags().BoolVar(&flags.Suggest, "suggest", false, "Generate a valid message for each invalid commit, output as a reword script")
+	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write the reword script of --suggest to file")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output-format", app.OutputFormatText, "Output format: text, json or github")
+	_ = cmd.MarkFlagRequired("range")
+
+	return cmd
+}
+
+// runLintHistory executes the lint-history command logic.
+func runLintHistory(cmd *cobra.Command, flags *LintHistoryFlags) error {
+	if err := checkReportFormat(flags.OutputFormat); err != nil {
+		return err
+	}
+
+	if flags.OutputFile != "" && !flags.Suggest {
+		return apperrors.New(apperrors.ErrInvalidArguments, "--output requires --suggest")
+	}
+
+	// Like validate, no configuration is needed without --suggest, so it can
+	// run in CI as is
+	commits, err := git.NewClient().GetCommitsInRange(cmd.Context(), strings.TrimSpace(flags.Range))
+	if err != nil {
+		return err
+	}
+
+	report := lintCommits(commits, flags.Strict)
+	report.Range = flags.Range
+
+	reportOut := cmd.OutOrStdout()
+	if flags.Suggest {
+		// stdout is kept for the script
+		reportOut = cmd.ErrOrStderr()
+	}
+	if flags.Suggest && report.Invalid > 0 {
+		plan, err := suggestRewords(cmd, report, commits)
+		if err != nil {
+			return err
+		}
+		if err := writeRewordPlan(cmd, flags, plan); err != nil {
+			return err
+		}
+	}
+
+	if flags.OutputFormat == app.OutputFormatJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(reportOut, string(out))
+	} else if flags.OutputFormat == app.OutputFormatGitHub {
+		printLintAnnotations(reportOut, report)
+	} else {
+		printLintReport(reportOut, report)
+	}
+
+	if report.Invalid > 0 {
+		return apperrors.New(apperrors.ErrInvalidMessage, fmt.Sprintf("%d of %d commit messages do not follow Conventional Commits", report.Invalid, report.Checked))
+	}
+	return nil
+}
+
+// lintCommits validates the message of each commit. With strict set,
+// warnings make a message invalid.
+func lintCommits(commits []git.RangeCommit, strict bool) *historyLintReport {
+	report := &historyLintReport{Commits: []commitLintResult{}}
+	for _, commit := range commits {
+		result := validateMessage(commit.Message, strict)
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		report.Commits = append(report.Commits, commitLintResult{
+			Hash:             commit.Hash,
+			Subject:          subject,
+			validationReport: result,
+		})
+
+		report.Checked++
+		if !result.Valid {
+			report.Invalid++
+		}
+		if len(result.Warnings) > 0 {
+			report.Warnings++
+		}
+	}
+	return report
+}
+
+// printLintReport writes the commits with errors or warnings, followed by a
+// summary line.
+func printLintReport(w io.Writer, report *historyLintReport) {
+	for _, commit := range report.Commits {
+		if len(commit.Errors) == 0 && len(commit.Warnings) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n", shortHash(commit.Hash), commit.Subject)
+		for _, issue := range commit.Errors {
+			fmt.Fprintf(w, "  error: %s: %s\n", issue.Field, issue.Message)
+		}
+		for _, warning := range commit.Warnings {
+			fmt.Fprintf(w, "  warning: %s\n", warning)
+		}
+		if commit.Suggestion != "" {
+			lines := strings.Split(commit.Suggestion, "\n")
+			fmt.Fprintf(w, "  suggestion: %s\n", lines[0])
+			for _, line := range lines[1:] {
+				if line != "" {
+					line = "    " + line
+				}
+				fmt.Fprintln(w, line)
+			}
+		}
+		if commit.SuggestionError != "" {
+			fmt.Fprintf(w, "  no suggestion: %s\n", commit.SuggestionError)
+		}
+	}
+
+	fmt.Fprintf(w, "%d commits checked: %d invalid, %d with warnings\n", report.Checked, report.Invalid, report.Warnings)
+}
+
+// printLintAnnotations writes the errors and warnings of each commit, and
+// its suggested message, as GitHub Actions annotations, followed by a notice
+// with the totals.
+func printLintAnnotations(w io.Writer, report *historyLintReport) {
+	for _, commit := range report.Commits {
+		title := fmt.Sprintf("Commit %s: %s", shortHash(commit.Hash), commit.Subject)
+		for _, annotation := range commit.annotations(title, "") {
+			fmt.Fprintln(w, annotation)
+		}
+		if commit.Suggestion != "" {
+			fmt.Fprintln(w, app.Annotation{
+				Level:   app.AnnotationNotice,
+				Title:   "Suggested message for " + shortHash(commit.Hash),
+				Message: commit.Suggestion,
+			})
+		}
+	}
+
+	fmt.Fprintln(w, app.Annotation{
+		Level:   app.AnnotationNotice,
+		Title:   "Commit messages",
+		Message: fmt.Sprintf("%d commits checked: %d invalid, %d with warnings", report.Checked, report.Invalid, report.Warnings),
+	})
+}
+
+// suggestRewords asks the AI for a valid message for each invalid commit of
+// the report, adds them to the report and returns the reword script.
+func suggestRewords(cmd *cobra.Command, report *historyLintReport, commits []git.RangeCommit) (string, error) {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
+
+	cfg, err := loadCommandConfig(cmd, false)
+	if err != nil {
+		return "", err
+	}
+
+	gitClient := git.NewClient()
+	gitClient.SetMaxDiffMemory(cfg.Git.MaxDiffMemory)
+	gitClient.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+
+	aiProvider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		apperrors.Error("Failed to create AI provider: %v", err)
+		return "", apperrors.NewAIProviderError(cfg.Provider.Name, err)
+	}
+
+	// Progress goes to stderr, which also takes the report
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	var invalid []git.RangeCommit
+	results := make(map[string]*commitLintResult)
+	for i, commit := range commits {
+		if !report.Commits[i].Valid {
+			invalid = append(invalid, commit)
+			results[commit.Hash] = &report.Commits[i]
+		}
+	}
+
+	service := app.NewCommitService(gitClient, aiProvider, newDiffProcessor(cfg), uiMgr, nil, cfg)
+	service.SetConfirmPayload(firstUsePayload(cfg, false))
+	suggestions, err := service.SuggestRewords(ctx, invalid)
+	if err != nil {
+		return "", err
+	}
+
+	for _, suggestion := range suggestions {
+		result := results[suggestion.Hash]
+		result.Suggestion = suggestion.Message
+		if suggestion.Err != nil {
+			result.SuggestionError = suggestion.Err.Error()
+		}
+	}
+	return app.RewordPlan(suggestions), nil
+}
+
+// writeRewordPlan writes the reword script to --output, made executable, or
+// to stdout.
+func writeRewordPlan(cmd *cobra.Command, flags *LintHistoryFlags, plan string) error {
+	if plan == "" {
+		return nil
+	}
+	if flags.OutputFile == "" {
+		fmt.Fprint(cmd.OutOrStdout(), plan)
+		return nil
+	}
+	if err := os.WriteFile(flags.OutputFile, []byte(plan), 0755); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, fmt.Sprintf("failed to write to file %s", flags.OutputFile))
+	}
+	return nil
+}
+
+// shortHash abbreviates a commit hash like git's default --abbrev.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestLintCommits(t *testing.T) {
+	commits := []git.RangeCommit{
+		{Hash: "1111111aaaa", Message: "feat(api): add pagination\n\nAdds page and per_page."},
+		{Hash: "2222222bbbb", Message: "add pagination"},
+		{Hash: "3333333cccc", Message: "fixup! feat(api): add pagination"},
+		{Hash: "4444444dddd", Message: "feat: " + strings.Repeat("a", 120)},
+	}
+
+	t.Run("default", func(t *testing.T) {
+		report := lintCommits(commits, false)
+		if report.Checked != 4 || report.Invalid != 1 || report.Warnings != 1 {
+			t.Fatalf("checked/invalid/warnings = %d/%d/%d, want 4/1/1", report.Checked, report.Invalid, report.Warnings)
+		}
+		if report.Commits[0].Subject != "feat(api): add pagination" {
+			t.Errorf("subject = %q", report.Commits[0].Subject)
+		}
+		if !report.Commits[2].Exempt {
+			t.Error("expected the fixup! commit to be exempt")
+		}
+
+		var out bytes.Buffer
+		printLintReport(&out, report)
+		want := "2222222 add pagination\n  error: type: missing commit type"
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("report = %q, want it to contain %q", out.String(), want)
+		}
+		if strings.Contains(out.String(), "1111111") {
+			t.Errorf("report lists a valid commit: %q", out.String())
+		}
+		if !strings.HasSuffix(out.String(), "4 commits checked: 1 invalid, 1 with warnings\n") {
+			t.Errorf("unexpected summary in %q", out.String())
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		if report := lintCommits(commits, true); report.Invalid != 2 {
+			t.Errorf("invalid = %d, want 2", report.Invalid)
+		}
+	})
+}
+
+func TestPrintLintReport_Suggestions(t *testing.T) {
+	report := lintCommits([]git.RangeCommit{{Hash: "2222222bbbb", Message: "wip"}, {Hash: "3333333cccc", Message: "init"}}, false)
+	report.Commits[0].Suggestion = "feat(api): paginate users\n\n- api/users.go: add page parameter"
+	report.Commits[1].SuggestionError = "failed to get diff"
+
+	var out bytes.Buffer
+	printLintReport(&out, report)
+
+	want := "2222222 wip\n  error: type: missing commit type\n  suggestion: feat(api): paginate users\n\n    - api/users.go: add page parameter\n" +
+		"3333333 init\n  error: type: missing commit type\n  no suggestion: failed to get diff\n"
+	if !strings.HasPrefix(out.String(), want) {
+		t.Errorf("report = %q, want it to start with %q", out.String(), want)
+	}
+}
+
+func TestPrintLintAnnotations(t *testing.T) {
+	report := lintCommits([]git.RangeCommit{{Hash: "1111111aaaa", Message: "fix: typo"}, {Hash: "2222222bbbb", Message: "wip"}}, false)
+	report.Commits[1].Suggestion = "feat(api): paginate users\n\n- api/users.go: add page parameter"
+
+	var out bytes.Buffer
+	printLintAnnotations(&out, report)
+
+	want := "::error title=Commit 2222222%3A wip::type: missing commit type\n" +
+		"::notice title=Suggested message for 2222222::feat(api): paginate users%0A%0A- api/users.go: add page parameter\n" +
+		"::notice title=Commit messages::2 commits checked: 1 invalid, 0 with warnings\n"
+	if out.String() != want {
+		t.Errorf("annotations = %q, want %q", out.String(), want)
+	}
+}
+
+func TestLintHistoryCmd_InvalidFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"missing range", nil, "range"},
+		{"output without suggest", []string{"--range", "main..HEAD", "-o", "reword.sh"}, "--output requires --suggest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRootCmd("test", "none", "unknown")
+			root.SilenceErrors = true
+			root.SilenceUsage = true
+			root.SetOut(&bytes.Buffer{})
+			root.SetErr(&bytes.Buffer{})
+			root.SetArgs(append([]string{"lint-history", "--skip-path-check"}, tt.args...))
+
+			if err := root.Execute(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
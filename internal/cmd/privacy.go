@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/security"
+)
+
+// applyRepoPrivacy overrides the privacy settings with the repository's
+// config.RepoPrivacyFile. A file that cannot be read is an error rather than
+// ignored, since it may hold a restriction.
+func applyRepoPrivacy(ctx context.Context, cfg *config.Config) error {
+	root, err := git.NewClient().GetRepoRoot(ctx)
+	if err != nil || root == "" {
+		// Outside a repository there is no file to apply
+		apperrors.Debug("No repository privacy settings: %v", err)
+		return nil
+	}
+
+	path := filepath.Join(root, config.RepoPrivacyFile)
+	if err := config.ApplyPrivacyFile(path, &cfg.Privacy); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid "+config.RepoPrivacyFile)
+	}
+	return nil
+}
+
+// checkLocalOnly refuses the providers whose endpoint is not on this machine
+// or a private network when privacy.local_only is set. The mock provider
+// makes no requests and is always allowed.
+func checkLocalOnly(ctx context.Context, privacy config.PrivacyConfig, providers ...config.ProviderConfig) error {
+	if !privacy.LocalOnly {
+		return nil
+	}
+
+	for _, provider := range providers {
+		if provider.Name == ai.ProviderNameMock {
+			continue
+		}
+
+		var err error
+		if endpoint := ai.Endpoint(&provider); endpoint == "" {
+			err = fmt.Errorf("provider %s has no endpoint to check", provider.Name)
+		} else {
+			err = security.CheckLocalEndpoint(ctx, endpoint)
+		}
+		if err != nil {
+			return apperrors.Wrap(err, apperrors.ErrInvalidConfig, fmt.Sprintf("privacy.local_only refuses provider %s", provider.Name)).
+				WithSuggestion("Use a local provider such as ollama, or set provider.endpoint to a local or private-network server")
+		}
+	}
+	return nil
+}
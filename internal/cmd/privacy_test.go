@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+func TestCheckLocalOnly(t *testing.T) {
+	localOnly := config.PrivacyConfig{LocalOnly: true}
+
+	tests := []struct {
+		name     string
+		privacy  config.PrivacyConfig
+		provider config.ProviderConfig
+		wantErr  bool
+	}{
+		{"off allows remote providers", config.PrivacyConfig{}, config.ProviderConfig{Name: "openai"}, false},
+		{"ollama default endpoint", localOnly, config.ProviderConfig{Name: "ollama"}, false},
+		{"mock makes no requests", localOnly, config.ProviderConfig{Name: "mock"}, false},
+		{"openai-compatible server on the network", localOnly, config.ProviderConfig{Name: "openai", Endpoint: "http://10.0.0.2:8000/v1"}, false},
+		{"openai default endpoint", localOnly, config.ProviderConfig{Name: "openai"}, true},
+		{"remote ollama", localOnly, config.ProviderConfig{Name: "ollama", Endpoint: "http://203.0.113.9:11434"}, true},
+		{"registered provider without endpoint", localOnly, config.ProviderConfig{Name: "custom"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLocalOnly(context.Background(), tt.privacy, tt.provider)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkLocalOnly() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && apperrors.GetExitCode(err) != 1 {
+				t.Errorf("exit code = %d, want 1 (configuration error)", apperrors.GetExitCode(err))
+			}
+		})
+	}
+}
+
+func TestCheckLocalOnly_ComparedProviders(t *testing.T) {
+	providers := []config.ProviderConfig{{Name: "ollama"}, {Name: "deepseek"}}
+
+	if err := checkLocalOnly(context.Background(), config.PrivacyConfig{LocalOnly: true}, providers...); err == nil {
+		t.Error("checkLocalOnly() should refuse a remote provider among the compared ones")
+	}
+}
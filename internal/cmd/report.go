@@ -0,0 +1,117 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// ReportFlags holds the flags for the report command.
+type ReportFlags struct {
+	Since      string
+	Author     string
+	OutputFile string
+}
+
+// NewReportCmd creates the report command.
+func NewReportCmd() *cobra.Command {
+	flags := &ReportFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Write a work report from your recent commits",
+		Long: `Gather your commits on all branches since a date, group them by project
+and scope, and write a Markdown work report from them for stand-ups or
+timesheets.
+
+Commits are gathered from the current repository, or from every
+repository listed in report.repos when it is configured.
+
+Examples:
+  gitsage report                                # Your commits since yesterday
+  gitsage report --since "1 week ago"           # Weekly report
+  gitsage report --since 2025-06-01 -o june.md  # Save the report to a file
+  gitsage report --author alice@example.com     # Another author's commits`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReport(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Since, "since", app.DefaultReportSince, `Start of the period, in any form git log --since accepts (e.g. "1 week ago")`)
+	cmd.Flags().StringVar(&flags.Author, "author", app.ReportAuthorMe, `Author to report on, matched like git log --author; "me" uses user.email`)
+	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write the report to file")
+
+	return cmd
+}
+
+// runReport executes the report command logic.
+func runReport(cmd *cobra.Command, flags *ReportFlags) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
+
+	cfg, err := loadCommandConfig(cmd, false)
+	if err != nil {
+		return err
+	}
+
+	newGitClient := func(workDir string) *git.DefaultClient {
+		client := git.NewClientWithWorkDir(workDir)
+		client.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+		return client
+	}
+
+	// Configured repositories are named after their root directories
+	var repos []app.ReportRepo
+	for _, path := range cfg.Report.Repos {
+		repos = append(repos, app.ReportRepo{Git: newGitClient(expandHome(path))})
+	}
+
+	aiProvider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		apperrors.Error("Failed to create AI provider: %v", err)
+		return apperrors.NewAIProviderError(cfg.Provider.Name, err)
+	}
+
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	service := app.NewCommitService(newGitClient(""), aiProvider, nil, uiMgr, nil, cfg)
+	service.SetConfirmPayload(firstUsePayload(cfg, false))
+
+	return service.GenerateReport(ctx, &app.ReportOptions{
+		Since:      strings.TrimSpace(flags.Since),
+		Author:     strings.TrimSpace(flags.Author),
+		Repos:      repos,
+		OutputFile: flags.OutputFile,
+	})
+}
+
+// expandHome replaces a leading "~" in path with the user's home directory.
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
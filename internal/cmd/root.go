@@ -3,10 +3,17 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 	"github.com/gitsage/gitsage/internal/pkg/pathcheck"
 	"github.com/gitsage/gitsage/internal/pkg/ui"
 	"github.com/spf13/cobra"
@@ -16,6 +23,7 @@ import (
 func NewRootCmd(version, commitHash, date string) *cobra.Command {
 	// Create commit command first so we can reference it
 	commitCmd := NewCommitCmd()
+	flags := &CommitFlags{}
 
 	rootCmd := &cobra.Command{
 		Use:   "gitsage",
@@ -25,28 +33,22 @@ semantic Git commit messages based on staged changes.
 
 It analyzes your git diff output, sends it to configurable AI providers
 (OpenAI, DeepSeek, Ollama), and presents you with an interactive interface
-to review, edit, and confirm commit messages before execution.`,
+to review, edit, and confirm commit messages before execution.
+
+` + exitCodesHelp,
 		Version: version,
 		// PersistentPreRunE runs before any command (including subcommands)
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := setupLogging(cmd); err != nil {
+				return err
+			}
 			return runPathCheckIfNeeded(cmd)
 		},
-		// Default action is to run the commit command
+		// Default action is to run the commit command with the repository's defaults
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Get commit-specific flags from root command
-			dryRun, _ := cmd.Flags().GetBool("dry-run")
-			yes, _ := cmd.Flags().GetBool("yes")
-			output, _ := cmd.Flags().GetString("output")
-			noCache, _ := cmd.Flags().GetBool("no-cache")
-
-			// Create flags struct for commit command
-			flags := &CommitFlags{
-				DryRun:     dryRun,
-				Yes:        yes,
-				OutputFile: output,
-				NoCache:    noCache,
+			if err := applyRepoDefaults(cmd); err != nil {
+				return err
 			}
-
 			return runCommit(cmd, flags)
 		},
 	}
@@ -58,32 +60,118 @@ Built:  ` + date + "\n")
 
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
+	rootCmd.PersistentFlags().String("log-file", "", "Append debug logs, including every git command run, to this file")
 	rootCmd.PersistentFlags().String("config", "", "Config file path (default: ~/.gitsage/config.yaml)")
-	rootCmd.PersistentFlags().String("provider", "", "AI provider to use (openai, deepseek, ollama)")
+	rootCmd.PersistentFlags().String("provider", "", "AI provider to use (openai, deepseek, ollama, mock)")
 	rootCmd.PersistentFlags().String("model", "", "AI model to use")
+	rootCmd.PersistentFlags().String("profile", "", "Config profile to layer over the config file (default: $GITSAGE_PROFILE)")
 	rootCmd.PersistentFlags().Bool("skip-path-check", false, "Skip PATH detection check")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress spinners and success messages; only errors and requested output are printed")
+	rootCmd.PersistentFlags().Bool("no-input", false, "Fail instead of prompting (combine with --yes to accept the generated message)")
 
 	// Add commit-specific flags to root command for default action
-	rootCmd.Flags().Bool("dry-run", false, "Generate message without committing")
-	rootCmd.Flags().BoolP("yes", "y", false, "Skip interactive confirmation and commit immediately")
-	rootCmd.Flags().StringP("output", "o", "", "Write generated message to file (implies --dry-run)")
-	rootCmd.Flags().Bool("no-cache", false, "Bypass response cache")
+	addCommitFlags(rootCmd, flags)
 
 	// Add subcommands
 	rootCmd.AddCommand(commitCmd)
 	rootCmd.AddCommand(NewGenerateCmd())
+	rootCmd.AddCommand(NewSquashCmd())
+	rootCmd.AddCommand(NewTagCmd())
+	rootCmd.AddCommand(NewSummaryCmd())
+	rootCmd.AddCommand(NewWatchCmd())
+	rootCmd.AddCommand(NewReportCmd())
+	rootCmd.AddCommand(NewValidateCmd())
+	rootCmd.AddCommand(NewLintHistoryCmd())
 	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewHistoryCmd())
+	rootCmd.AddCommand(NewCacheCmd())
+	rootCmd.AddCommand(NewAliasCmd())
 
 	return rootCmd
 }
 
+// setupLogging applies --verbose and opens the --log-file, which receives
+// debug messages such as the git commands run even without --verbose.
+func setupLogging(cmd *cobra.Command) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	apperrors.SetVerbose(verbose)
+
+	path, _ := cmd.Flags().GetString("log-file")
+	if path == "" {
+		return nil
+	}
+	// The file stays open until the process exits
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to open log file")
+	}
+	apperrors.SetLogFile(file)
+	apperrors.Debug("Running %s", cmd.CommandPath())
+	return nil
+}
+
+// applyRepoDefaults sets the flags not given on the command line from the
+// gitsage section of git config, such as "git config gitsage.all true", so
+// each repository can choose what running gitsage (or git sage) alone does.
+func applyRepoDefaults(cmd *cobra.Command) error {
+	vars, err := git.NewClient().GetConfigSection(cmd.Context(), "gitsage")
+	if err != nil {
+		// Without git there is nothing to commit; the commit flow reports it
+		apperrors.Debug("Failed to read gitsage defaults from git config: %v", err)
+		return nil
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || name == "help" || name == "version" {
+			apperrors.Warn("Ignoring git config gitsage.%s: not a gitsage flag", name)
+			continue
+		}
+		if flag.Changed {
+			continue
+		}
+		value := vars[name]
+		// Git also spells booleans yes/on and no/off
+		if flag.Value.Type() == "bool" {
+			switch strings.ToLower(value) {
+			case "yes", "on":
+				value = "true"
+			case "no", "off", "":
+				value = "false"
+			}
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return apperrors.Wrap(err, apperrors.ErrInvalidConfig, fmt.Sprintf("invalid git config gitsage.%s", name))
+		}
+	}
+	return nil
+}
+
+// scriptFlags returns the global --quiet and --no-input flags.
+func scriptFlags(cmd *cobra.Command) (quiet, noInput bool) {
+	quiet, _ = cmd.Flags().GetBool("quiet")
+	noInput, _ = cmd.Flags().GetBool("no-input")
+	return quiet, noInput
+}
+
+// newUIManager creates the UI manager for a command, honoring --quiet and --no-input.
+func newUIManager(cmd *cobra.Command, opts ui.Options) ui.Manager {
+	opts.Quiet, opts.NoInput = scriptFlags(cmd)
+	return ui.NewManager(opts)
+}
+
 // runPathCheckIfNeeded performs PATH detection if needed.
 // It skips the check for config and help commands, or if --skip-path-check flag is set.
 func runPathCheckIfNeeded(cmd *cobra.Command) error {
-	// Skip for config, help, and version commands
+	// Skip for config, help, and version commands, and for validate, which runs as a git hook
 	cmdName := cmd.Name()
-	if cmdName == "config" || cmdName == "help" || cmdName == "version" {
+	if cmdName == "config" || cmdName == "help" || cmdName == "version" || cmdName == "validate" {
 		return nil
 	}
 
@@ -99,9 +187,14 @@ func runPathCheckIfNeeded(cmd *cobra.Command) error {
 		return nil
 	}
 
+	// Scripts must not be prompted; the check runs on the next interactive use
+	quiet, noInput := scriptFlags(cmd)
+	if quiet || noInput {
+		return nil
+	}
+
 	// Load config to check if PATH check was already done
-	configPath, _ := cmd.Flags().GetString("config")
-	cfgManager, err := config.NewManager(configPath)
+	cfgManager, err := newConfigManager(cmd)
 	if err != nil {
 		// If we can't load config, skip PATH check but don't fail
 		return nil
@@ -113,17 +206,21 @@ func runPathCheckIfNeeded(cmd *cobra.Command) error {
 		return nil
 	}
 
+	// An invalid language is reported by the command itself; fall back to the default here
+	_ = i18n.SetLanguage(cfg.UI.Language)
+
 	// Skip if PATH check was already done
 	if cfg.Security.PathCheckDone {
 		return nil
 	}
 
 	// Perform PATH check
-	return performPathCheck(cfgManager)
+	return performPathCheck(cfgManager, cfg.UI.Accessible)
 }
 
 // performPathCheck performs the actual PATH detection and prompts user if needed.
-func performPathCheck(cfgManager *config.ViperManager) error {
+// In accessible mode the prompt is a plain line prompt instead of a Bubble Tea widget.
+func performPathCheck(cfgManager *config.ViperManager, accessible bool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -147,8 +244,17 @@ func performPathCheck(cfgManager *config.ViperManager) error {
 		return nil
 	}
 
+	// Only prompt on a real terminal; a non-interactive manager would auto-confirm
+	// and modify shell profiles without the user's consent
+	if !ui.IsInteractive() {
+		return nil
+	}
+
 	// Create UI manager for user interaction
-	uiManager := ui.NewDefaultManager(true, "", false)
+	var uiManager ui.Manager = ui.NewDefaultManager(ui.ColorEnabled(true), "", false)
+	if accessible || ui.LegacyConsole() {
+		uiManager = ui.NewAccessibleManager("", false)
+	}
 
 	// Get executable directory for display
 	execDir, err := checker.GetExecutableDir()
@@ -158,11 +264,11 @@ func performPathCheck(cfgManager *config.ViperManager) error {
 
 	// Prompt user
 	fmt.Println()
-	fmt.Printf("GitSage 检测到可执行文件目录 (%s) 不在系统 PATH 中。\n", execDir)
-	fmt.Println("添加到 PATH 后，您可以在任何目录直接运行 'gitsage' 命令。")
+	fmt.Println(i18n.T("pathcheck.not_in_path", execDir))
+	fmt.Println(i18n.T("pathcheck.benefit"))
 	fmt.Println()
 
-	confirmed, err := uiManager.PromptConfirm("是否自动添加到 PATH?")
+	confirmed, err := uiManager.PromptConfirm(i18n.T("pathcheck.confirm"))
 	if err != nil {
 		// If prompt fails, mark as done and continue
 		_ = cfgManager.Set("security.path_check_done", "true")
@@ -174,7 +280,7 @@ func performPathCheck(cfgManager *config.ViperManager) error {
 		result, err := checker.AddToPath(ctx)
 		if err != nil || !result.Success {
 			// Show error and manual instructions
-			uiManager.ShowError(fmt.Errorf("自动添加失败"))
+			uiManager.ShowError(errors.New(i18n.T("pathcheck.failed")))
 
 			// Get shell type for instructions (Unix only)
 			shellType := pathcheck.ShellUnknown
@@ -188,11 +294,11 @@ func performPathCheck(cfgManager *config.ViperManager) error {
 			// Show success message
 			uiManager.ShowSuccess(result.Message)
 			if result.NeedsReload {
-				fmt.Println("请重启终端或执行 source 命令使更改生效。")
+				fmt.Println(i18n.T("pathcheck.reload"))
 			}
 		}
 	} else {
-		fmt.Println("已跳过 PATH 配置。您可以稍后手动添加。")
+		fmt.Println(i18n.T("pathcheck.skipped"))
 	}
 
 	// Mark PATH check as done regardless of outcome
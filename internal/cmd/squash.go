@@ -0,0 +1,50 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/spf13/cobra"
+)
+
+// NewSquashCmd creates the squash command, which writes one message for the
+// commits a squash merge is about to combine.
+func NewSquashCmd() *cobra.Command {
+	flags := &CommitFlags{
+		DryRun: true, // The squash commit is made by git merge --squash
+	}
+
+	cmd := &cobra.Command{
+		Use:   "squash --base <branch>",
+		Short: "Generate one message for the commits about to be squashed",
+		Long: `Generate a single Conventional Commits message for the commits on the
+current branch since it diverged from the base branch, from their combined
+messages and diff. Nothing is committed.
+
+The message is displayed to stdout by default, or can be written to a file
+using the --output flag for a squash merge:
+
+  git checkout main
+  git merge --squash feature
+  git commit -F squash-msg.txt
+
+Examples:
+  gitsage squash --base main                    # Generate and display message
+  gitsage squash --base main -o squash-msg.txt  # Save message to file
+  gitsage squash --base origin/main --yes       # Skip interactive prompt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommit(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Base, "base", "", "Branch the commits are squashed onto (e.g. main)")
+	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip interactive confirmation")
+	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write generated message to file")
+	cmd.Flags().BoolVar(&flags.NoCache, "no-cache", false, "Bypass response cache")
+	cmd.Flags().StringVar(&flags.Type, "type", "", "Require this Conventional Commits type in the generated message (e.g. feat)")
+	cmd.Flags().StringVar(&flags.Scope, "scope", "", "Require this scope in the generated message (e.g. auth)")
+	cmd.Flags().StringVarP(&flags.Context, "context", "m", "", "Explain why the change was made; passed to the AI as authoritative intent")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output-format", app.OutputFormatText, "Output format: text, json or github")
+	_ = cmd.MarkFlagRequired("base")
+
+	return cmd
+}
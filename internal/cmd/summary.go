@@ -0,0 +1,98 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// SummaryFlags holds the flags for the summary command.
+type SummaryFlags struct {
+	Staged     bool
+	Range      string
+	OutputFile string
+}
+
+// NewSummaryCmd creates the summary command.
+func NewSummaryCmd() *cobra.Command {
+	flags := &SummaryFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "summary [--staged | --range A..B]",
+		Short: "Summarize changes with AI without committing",
+		Long: `Write a plain-language summary of the staged changes, or of the changes
+in a revision range, for stand-up notes or review preparation. Nothing is
+committed.
+
+The diff goes through the same processing as for commit messages, and
+large diffs are summarized file group by file group first.
+
+Examples:
+  gitsage summary                     # Summarize the staged changes
+  gitsage summary --range main..HEAD  # Summarize the branch for review
+  gitsage summary --range v1.3.0..    # Summarize everything since a tag
+  gitsage summary -o standup.md       # Save the summary to a file`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSummary(cmd, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.Staged, "staged", true, "Summarize the staged changes")
+	cmd.Flags().StringVar(&flags.Range, "range", "", "Summarize the changes in a revision range instead (e.g. main..HEAD)")
+	cmd.Flags().StringVarP(&flags.OutputFile, "output", "o", "", "Write the summary to file")
+	cmd.MarkFlagsMutuallyExclusive("staged", "range")
+
+	return cmd
+}
+
+// runSummary executes the summary command logic.
+func runSummary(cmd *cobra.Command, flags *SummaryFlags) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
+
+	cfg, err := loadCommandConfig(cmd, false)
+	if err != nil {
+		return err
+	}
+
+	gitClient := git.NewClient()
+	gitClient.SetMaxDiffMemory(cfg.Git.MaxDiffMemory)
+	gitClient.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+	if err := gitClient.SetCommitScope(git.CommitScope{Range: flags.Range}); err != nil {
+		return apperrors.Wrap(err, apperrors.ErrInvalidArguments, "invalid --range")
+	}
+
+	aiProvider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		apperrors.Error("Failed to create AI provider: %v", err)
+		return apperrors.NewAIProviderError(cfg.Provider.Name, err)
+	}
+
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	service := app.NewCommitService(gitClient, aiProvider, newDiffProcessor(cfg), uiMgr, nil, cfg)
+	service.SetConfirmPayload(firstUsePayload(cfg, false))
+	ui.SetStopHandler(service.StopSummarizing)
+	defer ui.SetStopHandler(nil)
+
+	return service.Summarize(ctx, &app.SummaryOptions{
+		Range:      flags.Range,
+		OutputFile: flags.OutputFile,
+	})
+}
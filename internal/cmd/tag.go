@@ -0,0 +1,100 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// TagFlags holds the flags for the tag command.
+type TagFlags struct {
+	Sign   bool
+	DryRun bool
+	Yes    bool
+}
+
+// NewTagCmd creates the tag command.
+func NewTagCmd() *cobra.Command {
+	flags := &TagFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "tag <name>",
+		Short: "Create an annotated tag with an AI-generated message",
+		Long: `Summarize the commits since the previous tag into release notes and
+create an annotated tag on HEAD with them as its message.
+
+The previous tag is the most recent one reachable from HEAD; without
+one, the whole history is summarized.
+
+Examples:
+  gitsage tag v1.4.0            # Review the message, then create the tag
+  gitsage tag v1.4.0 --sign     # Create a GPG-signed tag (git tag -s)
+  gitsage tag v1.4.0 --dry-run  # Generate the message without tagging
+  gitsage tag v1.4.0 --yes      # Tag with the generated message`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTag(cmd, args[0], flags)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&flags.Sign, "sign", "s", false, "Create a GPG-signed tag")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Generate message without creating the tag")
+	cmd.Flags().BoolVarP(&flags.Yes, "yes", "y", false, "Skip interactive confirmation and tag immediately")
+
+	return cmd
+}
+
+// runTag executes the tag command logic.
+func runTag(cmd *cobra.Command, name string, flags *TagFlags) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
+
+	cfg, err := loadCommandConfig(cmd, flags.Yes)
+	if err != nil {
+		return err
+	}
+
+	gitClient := git.NewClient()
+	gitClient.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+
+	aiProvider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		apperrors.Error("Failed to create AI provider: %v", err)
+		return apperrors.NewAIProviderError(cfg.Provider.Name, err)
+	}
+
+	keys, err := ui.NewKeyMap(cfg.UI.KeyBindings)
+	if err != nil {
+		apperrors.Error("Invalid key bindings: %v", err)
+		return apperrors.Wrap(err, apperrors.ErrInvalidConfig, "invalid ui.keybindings")
+	}
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Editor:       messageEditor(ctx, gitClient, cfg),
+		AutoAccept:   flags.Yes,
+		KeyMap:       keys,
+		VimMode:      cfg.UI.VimMode,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	service := app.NewCommitService(gitClient, aiProvider, nil, uiMgr, nil, cfg)
+	service.SetConfirmPayload(firstUsePayload(cfg, flags.Yes))
+
+	return service.GenerateAndTag(ctx, &app.TagOptions{
+		Name:   name,
+		Sign:   flags.Sign,
+		DryRun: flags.DryRun,
+	})
+}
@@ -0,0 +1,180 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gitsage/gitsage/internal/app"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/message"
+	"github.com/spf13/cobra"
+)
+
+// ValidateFlags holds the flags for the validate command.
+type ValidateFlags struct {
+	File         string
+	Strict       bool
+	OutputFormat string
+}
+
+// validationReport is the JSON output of the validate command.
+type validationReport struct {
+	Valid    bool              `json:"valid"`
+	Exempt   bool              `json:"exempt,omitempty"`
+	Errors   []validationIssue `json:"errors"`
+	Warnings []string          `json:"warnings"`
+}
+
+// validationIssue is a validation error of one field of the message.
+type validationIssue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewValidateCmd creates the validate command.
+func NewValidateCmd() *cobra.Command {
+	flags := &ValidateFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate a commit message without calling the AI",
+		Long: `Validate a commit message against the Conventional Commits rules
+GitSage applies to generated messages: a known type, a subject, and a
+subject line length limit (a warning unless --strict is set).
+
+The message is read from --file, or from stdin. Like git, comment lines
+starting with "#" and the diff below the --verbose scissors line are
+ignored. Merge, revert, fixup! and squash! messages are always valid.
+
+The command exits with 1 if the message is invalid, so it can serve as a
+commit-msg hook:
+
+  #!/bin/sh
+  exec gitsage validate --file "$1"
+
+With --output-format github, errors and warnings are written as GitHub
+Actions annotations, which show up on the run of a pull request check.
+
+Examples:
+  gitsage validate --file .git/COMMIT_EDITMSG
+  echo "feat(api): add pagination" | gitsage validate
+  gitsage validate --file msg.txt --output-format json
+  gitsage validate --file msg.txt --output-format github`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.File, "file", "f", "", "Read the message from this file instead of stdin")
+	cmd.Flags().BoolVar(&flags.Strict, "strict", false, "Treat warnings as errors")
+	cmd.Flags().StringVar(&flags.OutputFormat, "output-format", app.OutputFormatText, "Output format: text, json or github")
+
+	return cmd
+}
+
+// runValidate executes the validate command logic.
+func runValidate(cmd *cobra.Command, flags *ValidateFlags) error {
+	if err := checkReportFormat(flags.OutputFormat); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	if flags.File != "" {
+		data, err = os.ReadFile(flags.File)
+	} else {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	}
+	if err != nil {
+		return apperrors.Wrap(err, apperrors.ErrFileSystemError, "failed to read commit message")
+	}
+
+	report := validateMessage(string(data), flags.Strict)
+
+	if flags.OutputFormat == app.OutputFormatJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(out))
+	} else if flags.OutputFormat == app.OutputFormatGitHub {
+		// Annotations point at the message file when there is one
+		for _, annotation := range report.annotations("Commit message", flags.File) {
+			fmt.Fprintln(cmd.OutOrStdout(), annotation)
+		}
+	} else {
+		for _, issue := range report.Errors {
+			fmt.Fprintf(cmd.ErrOrStderr(), "error: %s: %s\n", issue.Field, issue.Message)
+		}
+		for _, warning := range report.Warnings {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+		}
+	}
+
+	if !report.Valid {
+		return apperrors.New(apperrors.ErrInvalidMessage, "commit message does not follow Conventional Commits")
+	}
+	return nil
+}
+
+// checkReportFormat returns an error unless format is an output format of
+// the validation commands.
+func checkReportFormat(format string) error {
+	switch format {
+	case app.OutputFormatText, app.OutputFormatJSON, app.OutputFormatGitHub:
+		return nil
+	}
+	return apperrors.New(apperrors.ErrInvalidArguments, fmt.Sprintf("invalid --output-format %q (valid: text, json, github)", format))
+}
+
+// annotations returns the errors and warnings as GitHub Actions annotations
+// with the given title, on the first line of file if it is set.
+func (r *validationReport) annotations(title, file string) []string {
+	line := 0
+	if file != "" {
+		line = 1
+	}
+
+	var annotations []string
+	for _, issue := range r.Errors {
+		annotations = append(annotations, app.Annotation{
+			Level: app.AnnotationError, Title: title, File: file, Line: line,
+			Message: issue.Field + ": " + issue.Message,
+		}.String())
+	}
+	// Warnings are errors in strict mode
+	level := app.AnnotationWarning
+	if !r.Valid && len(r.Errors) == 0 {
+		level = app.AnnotationError
+	}
+	for _, warning := range r.Warnings {
+		annotations = append(annotations, app.Annotation{
+			Level: level, Title: title, File: file, Line: line, Message: warning,
+		}.String())
+	}
+	return annotations
+}
+
+// validateMessage validates the message as git would commit it. With strict
+// set, warnings make the message invalid.
+func validateMessage(text string, strict bool) *validationReport {
+	report := &validationReport{Valid: true, Errors: []validationIssue{}, Warnings: []string{}}
+
+	text = message.CleanupMessage(text)
+	if message.IsExempt(text) {
+		report.Exempt = true
+		return report
+	}
+
+	result := message.NewCommitMessage(text).ValidateWithWarnings()
+	for _, e := range result.Errors {
+		report.Errors = append(report.Errors, validationIssue{Field: e.Field, Message: e.Message})
+	}
+	report.Warnings = append(report.Warnings, result.Warnings...)
+	report.Valid = result.IsValid && !(strict && len(result.Warnings) > 0)
+	return report
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runValidateCmd runs "gitsage validate" with the given stdin and arguments.
+func runValidateCmd(t *testing.T, stdin string, args ...string) (stdout, stderr string, exitCode int) {
+	t.Helper()
+	root := NewRootCmd("test", "none", "unknown")
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+
+	var out, errOut bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetErr(&errOut)
+	root.SetArgs(append([]string{"validate"}, args...))
+
+	err := root.Execute()
+	return out.String(), errOut.String(), ExitCode(err)
+}
+
+func TestValidateCmd(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		args       []string
+		wantCode   int
+		wantStderr string
+	}{
+		{"valid", "feat(api): add pagination\n", nil, ExitOK, ""},
+		{"git template comments", "fix: typo\n\n# Please enter the commit message for your changes.\n", nil, ExitOK, ""},
+		{"merge commit", "Merge branch 'feature'\n", nil, ExitOK, ""},
+		{"missing type", "add pagination\n", nil, ExitUserError, "error: type: missing commit type"},
+		{"unknown type", "feature: add pagination\n", nil, ExitUserError, "error: type:"},
+		{"long subject", "feat: " + strings.Repeat("a", 120), nil, ExitOK, "warning: subject line exceeds"},
+		{"long subject strict", "feat: " + strings.Repeat("a", 120), []string{"--strict"}, ExitUserError, "warning: subject line exceeds"},
+		{"invalid format", "feat: a\n", []string{"--output-format", "xml"}, ExitUserError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, stderr, code := runValidateCmd(t, tt.message, tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+			}
+			if tt.wantStderr != "" && !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestValidateCmd_FileJSON(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(file, []byte("add pagination\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, code := runValidateCmd(t, "", "--file", file, "--output-format", "json")
+	if code != ExitUserError {
+		t.Errorf("exit code = %d, want %d", code, ExitUserError)
+	}
+
+	var report validationReport
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	if report.Valid || len(report.Errors) != 1 || report.Errors[0].Field != "type" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
+
+func TestValidateCmd_GitHub(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(file, []byte("add pagination\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, code := runValidateCmd(t, "", "--file", file, "--output-format", "github")
+	if code != ExitUserError {
+		t.Errorf("exit code = %d, want %d", code, ExitUserError)
+	}
+	want := "::error file=" + file + ",line=1,title=Commit message::type: missing commit type\n"
+	if stdout != want {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+
+	// Warnings are errors in strict mode
+	stdout, _, _ = runValidateCmd(t, "feat: "+strings.Repeat("a", 120), "--strict", "--output-format", "github")
+	if !strings.HasPrefix(stdout, "::error title=Commit message::subject line exceeds") {
+		t.Errorf("stdout = %q, want a subject length error", stdout)
+	}
+}
@@ -0,0 +1,86 @@
+// Package cmd contains the CLI command definitions for GitSage.
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+// WatchFlags holds the flags for the watch command.
+type WatchFlags struct {
+	Debounce time.Duration
+}
+
+// NewWatchCmd creates the watch command.
+func NewWatchCmd() *cobra.Command {
+	flags := &WatchFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Keep a draft commit message for the staged changes",
+		Long: `Watch the index and keep a draft message for the staged changes in
+.git/` + app.DraftFileName + `, so the message is ready by the time you commit.
+
+The draft is updated once the index has not changed for the debounce
+interval. Files whose diff did not change keep their summaries, and the
+draft is generated as 'gitsage commit' would, so committing the same
+changes is answered from the response cache. Changes above the budget are
+not sent. The draft is removed when nothing is staged.
+
+Examples:
+  gitsage watch                  # Run in a second terminal while you stage
+  gitsage watch --debounce 5s    # Wait longer for staging to settle
+  git commit -F .git/` + app.DraftFileName + `   # Commit the draft as is`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(cmd, flags)
+		},
+	}
+
+	cmd.Flags().DurationVar(&flags.Debounce, "debounce", app.DefaultWatchDebounce, "How long the index must stay unchanged before the draft is updated")
+
+	return cmd
+}
+
+// runWatch executes the watch command logic.
+func runWatch(cmd *cobra.Command, flags *WatchFlags) error {
+	// Watching runs until Ctrl+C, so there is no overall timeout
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	ui.SetInterruptHandler(cancel)
+	defer ui.SetInterruptHandler(nil)
+
+	cfg, err := loadCommandConfig(cmd, false)
+	if err != nil {
+		return err
+	}
+
+	gitClient := git.NewClient()
+	gitClient.SetMaxDiffMemory(cfg.Git.MaxDiffMemory)
+	gitClient.SetCommandTimeout(time.Duration(cfg.Git.CommandTimeout) * time.Second)
+
+	aiProvider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		apperrors.Error("Failed to create AI provider: %v", err)
+		return apperrors.NewAIProviderError(cfg.Provider.Name, err)
+	}
+
+	uiMgr := newUIManager(cmd, ui.Options{
+		ColorEnabled: cfg.UI.ColorEnabled,
+		Accessible:   cfg.UI.Accessible,
+	})
+	if closer, ok := uiMgr.(interface{ Close() }); ok {
+		defer closer.Close()
+	}
+
+	service := app.NewCommitService(gitClient, aiProvider, newDiffProcessor(cfg), uiMgr, nil, cfg)
+	service.SetConfirmPayload(firstUsePayload(cfg, false))
+	return service.Watch(ctx, &app.WatchOptions{Debounce: flags.Debounce})
+}
@@ -0,0 +1,110 @@
+// Package aitest provides a provider that replays exchanges recorded with
+// GITSAGE_RECORD, for deterministic tests of code calling a provider without
+// network access.
+package aitest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// ReplayProvider serves the responses of a recorded fixture. Each exchange
+// is served once, to the request it was recorded for, so that concurrent
+// requests get the right responses whatever their order.
+type ReplayProvider struct {
+	exchanges []ai.Exchange
+
+	mu     sync.Mutex
+	served []bool
+}
+
+// NewReplayProvider returns a provider replaying the fixture at path.
+func NewReplayProvider(path string) (*ReplayProvider, error) {
+	exchanges, err := ai.ReadExchanges(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(exchanges) == 0 {
+		return nil, fmt.Errorf("fixture %s has no exchanges", path)
+	}
+	return &ReplayProvider{exchanges: exchanges, served: make([]bool, len(exchanges))}, nil
+}
+
+// Replay returns a provider replaying the fixture at path and, when the
+// test ends, fails it if an exchange was not served.
+func Replay(t testing.TB, path string) *ReplayProvider {
+	t.Helper()
+	provider, err := NewReplayProvider(path)
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+	t.Cleanup(func() {
+		if remaining := provider.Remaining(); remaining > 0 {
+			t.Errorf("%d recorded exchanges of %s were not replayed", remaining, path)
+		}
+	})
+	return provider
+}
+
+// GenerateCommitMessage returns the response, or error, recorded for the
+// request.
+func (p *ReplayProvider) GenerateCommitMessage(ctx context.Context, req *ai.GenerateRequest) (*ai.GenerateResponse, error) {
+	key := ai.RequestKey(req)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, exchange := range p.exchanges {
+		if p.served[i] || exchange.Key != key {
+			continue
+		}
+		p.served[i] = true
+		if exchange.Error != "" {
+			return nil, errors.New(exchange.Error)
+		}
+		// Callers may modify the response
+		resp := *exchange.Response
+		return &resp, nil
+	}
+
+	files := make([]string, len(req.DiffChunks))
+	for i, chunk := range req.DiffChunks {
+		files[i] = chunk.FilePath
+	}
+	return nil, fmt.Errorf("no recorded response for request %.12s (files: %s); record the fixture again with %s",
+		key, strings.Join(files, ", "), ai.RecordEnvVar)
+}
+
+// Name returns the name of the recorded provider.
+func (p *ReplayProvider) Name() string {
+	return p.exchanges[0].Provider
+}
+
+// ValidateConfig accepts any configuration, since no request is sent.
+func (p *ReplayProvider) ValidateConfig(config ai.ProviderConfig) error {
+	return nil
+}
+
+// Capabilities returns the capabilities of the recorded provider, so that
+// requests are built as they were when recording.
+func (p *ReplayProvider) Capabilities() ai.Capabilities {
+	return p.exchanges[0].Capabilities
+}
+
+// Remaining returns the number of recorded exchanges not served yet.
+func (p *ReplayProvider) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	remaining := 0
+	for _, served := range p.served {
+		if !served {
+			remaining++
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,80 @@
+package aitest
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// scriptedProvider replies with the subject set for each file.
+type scriptedProvider struct {
+	subjects map[string]string
+}
+
+func (p *scriptedProvider) GenerateCommitMessage(ctx context.Context, req *ai.GenerateRequest) (*ai.GenerateResponse, error) {
+	return &ai.GenerateResponse{Subject: p.subjects[req.DiffChunks[0].FilePath]}, nil
+}
+
+func (p *scriptedProvider) Name() string { return "scripted" }
+
+func (p *scriptedProvider) ValidateConfig(config ai.ProviderConfig) error { return nil }
+
+func (p *scriptedProvider) Capabilities() ai.Capabilities {
+	return ai.Capabilities{MaxContextTokens: 4096}
+}
+
+func request(file string) *ai.GenerateRequest {
+	return &ai.GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: file, Content: "+" + file}}}
+}
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.jsonl")
+	recorder := ai.NewRecorder(&scriptedProvider{subjects: map[string]string{
+		"a.go": "feat: add a",
+		"b.go": "fix: repair b",
+	}}, path)
+	for _, file := range []string{"a.go", "b.go"} {
+		if _, err := recorder.GenerateCommitMessage(context.Background(), request(file)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provider := Replay(t, path)
+	if provider.Name() != "scripted" || provider.Capabilities().MaxContextTokens != 4096 {
+		t.Errorf("expected the recorded provider's name and capabilities, got %s %+v", provider.Name(), provider.Capabilities())
+	}
+
+	// Responses are matched to requests, not served in order
+	resp, err := provider.GenerateCommitMessage(context.Background(), request("b.go"))
+	if err != nil || resp.Subject != "fix: repair b" {
+		t.Errorf("GenerateCommitMessage(b.go) = %+v, %v", resp, err)
+	}
+	if provider.Remaining() != 1 {
+		t.Errorf("Remaining() = %d, want 1", provider.Remaining())
+	}
+
+	_, err = provider.GenerateCommitMessage(context.Background(), request("c.go"))
+	if err == nil || !strings.Contains(err.Error(), "c.go") {
+		t.Errorf("expected an error naming the unrecorded request, got %v", err)
+	}
+
+	resp, err = provider.GenerateCommitMessage(context.Background(), request("a.go"))
+	if err != nil || resp.Subject != "feat: add a" {
+		t.Errorf("GenerateCommitMessage(a.go) = %+v, %v", resp, err)
+	}
+
+	// Each exchange is served once
+	if _, err := provider.GenerateCommitMessage(context.Background(), request("a.go")); err == nil {
+		t.Error("expected an error replaying an exchange twice")
+	}
+}
+
+func TestNewReplayProvider_Invalid(t *testing.T) {
+	if _, err := NewReplayProvider(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected an error for a missing fixture")
+	}
+}
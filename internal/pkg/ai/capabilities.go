@@ -0,0 +1,148 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import "github.com/gitsage/gitsage/internal/pkg/git"
+
+// DefaultDiffBudget is the diff size (in bytes) sent in a single request when
+// the model's context window is unknown.
+const DefaultDiffBudget = 10 * 1024
+
+// BytesPerToken approximates how many bytes of diff text make up one token.
+const BytesPerToken = 4
+
+// Capabilities describes the limits and features of a provider's model.
+type Capabilities struct {
+	// MaxContextTokens is the model's context window; zero if unknown.
+	MaxContextTokens int
+	// Streaming reports whether replies can be streamed as they are generated.
+	Streaming bool
+	// JSONMode reports whether the model can be constrained to reply in JSON.
+	JSONMode bool
+	// InputCostPer1K and OutputCostPer1K are the list prices in USD per
+	// 1,000 tokens; zero for local or unknown models.
+	InputCostPer1K  float64
+	OutputCostPer1K float64
+}
+
+// CapabilityReporter is implemented by providers that know the capabilities
+// of their configured model.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// CapabilitiesOf returns the capabilities reported by the provider, or the
+// zero Capabilities (all unknown) if it does not report any.
+func CapabilitiesOf(provider Provider) Capabilities {
+	if reporter, ok := provider.(CapabilityReporter); ok {
+		return reporter.Capabilities()
+	}
+	return Capabilities{}
+}
+
+// contextFill is the share of the context window a request may fill; the
+// rest absorbs the error of estimating tokens from the text size.
+const contextFill = 0.8
+
+// FitsContext reports whether the request, with a reply of up to replyTokens,
+// fits in the model's context window, so that it can be sent with the full
+// diff in one request. When the window is unknown, the diff must be within
+// DefaultDiffBudget.
+func (c Capabilities) FitsContext(req *GenerateRequest, replyTokens int) bool {
+	if c.MaxContextTokens <= 0 {
+		return diffSize(req.DiffChunks) <= DefaultDiffBudget
+	}
+	return EstimateRequestTokens(req)+replyTokens <= int(float64(c.MaxContextTokens)*contextFill)
+}
+
+// EstimateRequestTokens returns the approximate number of prompt tokens of a
+// request: its diff and everything sent along, including the default
+// instructions.
+func EstimateRequestTokens(req *GenerateRequest) int {
+	size := len(DefaultSystemPrompt) + len(DefaultUserPromptTemplate) + diffSize(req.DiffChunks) +
+		len(req.CustomPrompt) + len(req.PreviousAttempt) + len(req.CommitTemplate) + len(req.Context) + len(req.Stack) + len(req.AreaScope)
+	for _, chunk := range req.DiffChunks {
+		size += len(chunk.FilePath)
+	}
+	for _, lines := range [][]string{req.RecentCommits, req.SquashedCommits, req.SensitiveFiles, req.UnstagedFiles, req.OwnerAreas} {
+		for _, line := range lines {
+			size += len(line)
+		}
+	}
+	for _, example := range req.Examples {
+		size += example.size()
+	}
+	return EstimateTokens(size)
+}
+
+// diffSize returns the total size of the chunks' content.
+func diffSize(chunks []git.DiffChunk) int {
+	size := 0
+	for _, chunk := range chunks {
+		size += len(chunk.Content)
+	}
+	return size
+}
+
+// HasPricing reports whether the cost of a request can be estimated.
+func (c Capabilities) HasPricing() bool {
+	return c.InputCostPer1K > 0 || c.OutputCostPer1K > 0
+}
+
+// EstimateCost returns the approximate cost in USD of sending inputTokens
+// and receiving outputTokens.
+func (c Capabilities) EstimateCost(inputTokens, outputTokens int) float64 {
+	return float64(inputTokens)/1000*c.InputCostPer1K + float64(outputTokens)/1000*c.OutputCostPer1K
+}
+
+// EstimateTokens returns the approximate number of tokens in size bytes of text.
+func EstimateTokens(size int) int {
+	return (size + BytesPerToken - 1) / BytesPerToken
+}
+
+// requiresChunking reports whether a request is too large for the model to
+// take in one prompt with a reply of up to replyTokens, so that the prompt
+// lists the files and asks for a focus on the main changes.
+func requiresChunking(req *GenerateRequest, capabilities Capabilities, replyTokens int) bool {
+	return !capabilities.FitsContext(req, replyTokens)
+}
+
+// openAIModels are the capabilities of the OpenAI models GitSage documents,
+// at list prices.
+var openAIModels = map[string]Capabilities{
+	"gpt-4o":        {MaxContextTokens: 128000, Streaming: true, JSONMode: true, InputCostPer1K: 0.0025, OutputCostPer1K: 0.01},
+	"gpt-4o-mini":   {MaxContextTokens: 128000, Streaming: true, JSONMode: true, InputCostPer1K: 0.00015, OutputCostPer1K: 0.0006},
+	"gpt-4-turbo":   {MaxContextTokens: 128000, Streaming: true, JSONMode: true, InputCostPer1K: 0.01, OutputCostPer1K: 0.03},
+	"gpt-3.5-turbo": {MaxContextTokens: 16385, Streaming: true, JSONMode: true, InputCostPer1K: 0.0005, OutputCostPer1K: 0.0015},
+}
+
+// deepSeekModels are the capabilities of the DeepSeek models, at list prices.
+var deepSeekModels = map[string]Capabilities{
+	"deepseek-chat":     {MaxContextTokens: 64000, Streaming: true, JSONMode: true, InputCostPer1K: 0.00027, OutputCostPer1K: 0.0011},
+	"deepseek-reasoner": {MaxContextTokens: 64000, Streaming: true, InputCostPer1K: 0.00055, OutputCostPer1K: 0.00219},
+}
+
+// Capabilities returns the capabilities of the configured model. Models not
+// in the table, such as those of OpenAI-compatible endpoints, have an
+// unknown context window and price.
+func (p *OpenAIProvider) Capabilities() Capabilities {
+	if capabilities, ok := openAIModels[p.config.Model]; ok {
+		return capabilities
+	}
+	return Capabilities{Streaming: true}
+}
+
+// Capabilities returns the capabilities of the configured model.
+func (p *DeepSeekProvider) Capabilities() Capabilities {
+	if capabilities, ok := deepSeekModels[p.config.Model]; ok {
+		return capabilities
+	}
+	return Capabilities{Streaming: true}
+}
+
+// Capabilities returns the capabilities of a local Ollama model: replies can
+// be streamed or constrained to JSON, and requests are free. The context
+// window is provider.ollama.num_ctx; without it, it depends on how the model
+// is run and is left unknown.
+func (p *OllamaProvider) Capabilities() Capabilities {
+	return Capabilities{MaxContextTokens: p.config.Ollama.NumCtx, Streaming: true, JSONMode: true}
+}
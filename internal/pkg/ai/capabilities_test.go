@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestCapabilities_FitsContext(t *testing.T) {
+	req := &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "main.go", Content: strings.Repeat("x", 40*1024)}}}
+
+	if (Capabilities{}).FitsContext(req, DefaultMaxTokens) {
+		t.Error("unknown context: a 40KB diff should exceed DefaultDiffBudget")
+	}
+	if !(Capabilities{MaxContextTokens: 128000}).FitsContext(req, DefaultMaxTokens) {
+		t.Error("a 40KB diff should fit a 128K context")
+	}
+	if (Capabilities{MaxContextTokens: 8192}).FitsContext(req, DefaultMaxTokens) {
+		t.Error("a 40KB diff should not fit an 8K context")
+	}
+
+	// What is sent along with the diff counts too
+	withExamples := *req
+	withExamples.Examples = []Example{{Diff: strings.Repeat("y", 400*1024)}}
+	if (Capabilities{MaxContextTokens: 128000}).FitsContext(&withExamples, DefaultMaxTokens) {
+		t.Error("a 40KB diff with 400KB of examples should not fit a 128K context")
+	}
+	// And so does the reply
+	if (Capabilities{MaxContextTokens: 128000}).FitsContext(req, 100000) {
+		t.Error("a 40KB diff should not fit next to a 100K-token reply")
+	}
+}
+
+func TestEstimateRequestTokens(t *testing.T) {
+	empty := EstimateRequestTokens(&GenerateRequest{})
+	if empty <= 0 {
+		t.Fatalf("EstimateRequestTokens() = %d, want the instructions counted", empty)
+	}
+	req := &GenerateRequest{
+		DiffChunks:    []git.DiffChunk{{Content: strings.Repeat("x", 4000)}},
+		RecentCommits: []string{strings.Repeat("c", 400)},
+	}
+	if got := EstimateRequestTokens(req); got != empty+1100 {
+		t.Errorf("EstimateRequestTokens() = %d, want %d", got, empty+1100)
+	}
+}
+
+func TestCapabilities_EstimateCost(t *testing.T) {
+	c := Capabilities{InputCostPer1K: 0.002, OutputCostPer1K: 0.01}
+	if !c.HasPricing() {
+		t.Error("expected pricing")
+	}
+	if got := c.EstimateCost(2000, 500); got < 0.0089 || got > 0.0091 {
+		t.Errorf("EstimateCost() = %f, want 0.009", got)
+	}
+	if (Capabilities{}).HasPricing() {
+		t.Error("expected no pricing for unknown models")
+	}
+	if got := EstimateTokens(10); got != 3 {
+		t.Errorf("EstimateTokens(10) = %d, want 3", got)
+	}
+}
+
+func TestCapabilitiesOf(t *testing.T) {
+	key := "sk-test-key-that-is-long-enough-for-validation"
+
+	openai, _ := NewOpenAIProvider(ProviderConfig{APIKey: key})
+	if c := CapabilitiesOf(openai); c.MaxContextTokens != 128000 || !c.HasPricing() || !c.JSONMode {
+		t.Errorf("unexpected default OpenAI model capabilities: %+v", c)
+	}
+	custom, _ := NewOpenAIProvider(ProviderConfig{APIKey: key, Model: "llama-3-70b"})
+	if c := CapabilitiesOf(custom); c.MaxContextTokens != 0 || c.HasPricing() {
+		t.Errorf("expected unknown limits for an unlisted model, got %+v", c)
+	}
+
+	deepseek, _ := NewDeepSeekProvider(ProviderConfig{APIKey: key})
+	if c := CapabilitiesOf(deepseek); c.MaxContextTokens != 64000 {
+		t.Errorf("unexpected DeepSeek capabilities: %+v", c)
+	}
+
+	ollama, _ := NewOllamaProvider(ProviderConfig{})
+	if c := CapabilitiesOf(ollama); c.HasPricing() || c.MaxContextTokens != 0 {
+		t.Errorf("unexpected Ollama capabilities: %+v", c)
+	}
+
+	if c := CapabilitiesOf(nil); c != (Capabilities{}) {
+		t.Errorf("expected zero capabilities without a provider, got %+v", c)
+	}
+}
+
+func TestRequiresChunking(t *testing.T) {
+	req := &GenerateRequest{DiffChunks: []git.DiffChunk{{Content: strings.Repeat("x", 20*1024)}}}
+
+	if !requiresChunking(req, Capabilities{}, DefaultMaxTokens) {
+		t.Error("expected a 20KB diff to exceed the default budget")
+	}
+	if requiresChunking(req, Capabilities{MaxContextTokens: 128000}, DefaultMaxTokens) {
+		t.Error("expected a 20KB diff to fit a 128K context")
+	}
+}
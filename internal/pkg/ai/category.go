@@ -0,0 +1,65 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"path"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// CategoryType returns the commit type implied by the kind of files changed
+// when they are all of one kind: "docs", "test", "ci" or "build". It returns
+// "" for changes to source files, whose type depends on what the change does.
+func CategoryType(chunks []git.DiffChunk) string {
+	if len(chunks) == 0 {
+		return ""
+	}
+	all := func(match func(string) bool) bool {
+		for _, chunk := range chunks {
+			if !match(chunk.FilePath) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case all(isDocFile):
+		return "docs"
+	case all(isTestFile):
+		return "test"
+	case all(isCIFile):
+		return "ci"
+	case all(isBuildFile):
+		return "build"
+	}
+	return ""
+}
+
+func isDocFile(file string) bool {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".md", ".rst", ".txt", ".adoc":
+		return true
+	}
+	return strings.HasPrefix(file, "docs/")
+}
+
+func isTestFile(file string) bool {
+	base := path.Base(file)
+	return strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.HasPrefix(file, "test/") || strings.HasPrefix(file, "tests/") || strings.Contains(file, "/testdata/")
+}
+
+func isCIFile(file string) bool {
+	return strings.HasPrefix(file, ".github/") || strings.HasPrefix(file, ".circleci/") ||
+		file == ".gitlab-ci.yml" || file == "Jenkinsfile" || file == ".travis.yml"
+}
+
+func isBuildFile(file string) bool {
+	switch path.Base(file) {
+	case "Makefile", "Dockerfile", "go.mod", "go.sum", "package.json", "Cargo.toml", "pom.xml", "build.gradle", ".goreleaser.yml":
+		return true
+	}
+	return false
+}
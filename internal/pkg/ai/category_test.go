@@ -0,0 +1,31 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestCategoryType(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{[]string{"README.md", "docs/setup.html"}, "docs"},
+		{[]string{"auth_test.go", "auth/testdata/token.json"}, "test"},
+		{[]string{".github/workflows/ci.yml"}, "ci"},
+		{[]string{"go.mod", "go.sum"}, "build"},
+		{[]string{"auth.go", "README.md"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		var chunks []git.DiffChunk
+		for _, file := range tt.files {
+			chunks = append(chunks, git.DiffChunk{FilePath: file})
+		}
+		if got := CategoryType(chunks); got != tt.want {
+			t.Errorf("CategoryType(%v) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
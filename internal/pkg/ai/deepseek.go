@@ -53,11 +53,7 @@ func NewDeepSeekProvider(config ProviderConfig) (*DeepSeekProvider, error) {
 	clientConfig.BaseURL = config.Endpoint
 
 	// Create HTTP client with timeout and connection pooling
-	transport := &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
-	}
+	transport := newTransport(config.ExtraHeaders)
 	clientConfig.HTTPClient = &http.Client{
 		Timeout:   DefaultTimeout,
 		Transport: transport,
@@ -107,41 +103,31 @@ func (p *DeepSeekProvider) GenerateCommitMessage(ctx context.Context, req *Gener
 		return nil, errors.New("no diff chunks provided")
 	}
 
-	// Determine if chunking is required based on total diff size
-	totalSize := 0
-	for _, chunk := range req.DiffChunks {
-		totalSize += len(chunk.Content)
-	}
-	requiresChunking := totalSize > 10*1024 // 10KB threshold
-
-	// Build prompt data
-	promptData := BuildPromptData(req, requiresChunking)
-
-	// Render user prompt
-	userPrompt, err := p.promptTemplate.RenderUserPrompt(promptData)
+	// Build the prompts; chunking is required when the diff exceeds the model's budget
+	systemPrompt, userPrompt, err := p.promptTemplate.BuildPrompts(req, requiresChunking(req, p.Capabilities(), p.config.MaxTokens))
 	if err != nil {
 		return nil, fmt.Errorf("failed to render prompt: %w", err)
 	}
 
 	// Create chat completion request
 	chatReq := openai.ChatCompletionRequest{
-		Model: p.config.Model,
+		Model: req.modelOr(p.config.Model),
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: p.promptTemplate.GetSystemPrompt(),
+				Content: systemPrompt,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
 				Content: userPrompt,
 			},
 		},
-		Temperature: p.config.Temperature,
+		Temperature: req.temperatureOr(p.config.Temperature),
 		MaxTokens:   p.config.MaxTokens,
 	}
 
 	// Log API request in verbose mode
-	apperrors.LogAPIRequest("deepseek", p.config.Endpoint, p.config.Model, len(userPrompt))
+	apperrors.LogAPIRequest("deepseek", p.config.Endpoint, req.modelOr(p.config.Model), len(userPrompt))
 	startTime := time.Now()
 
 	// Call DeepSeek API with retry logic
@@ -196,7 +182,9 @@ func (p *DeepSeekProvider) GenerateCommitMessage(ctx context.Context, req *Gener
 	// Parse the response into structured format
 	parsed := ParseCommitMessage(rawText)
 
-	return parsed.ToGenerateResponse(rawText), nil
+	response := parsed.ToGenerateResponse(rawText)
+	response.Usage = chatUsage(resp.Usage)
+	return response, nil
 }
 
 // isDeepSeekRetryableError checks if an error is retryable for DeepSeek.
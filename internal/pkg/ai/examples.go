@@ -0,0 +1,76 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Example is a few-shot pair of a diff snippet and the ideal commit message for it.
+type Example struct {
+	Diff    string
+	Message string
+}
+
+// size returns the number of prompt bytes the example takes.
+func (e Example) size() int {
+	return len(e.Diff) + len(e.Message)
+}
+
+// FewShotMode controls when few-shot examples are added to the prompt.
+type FewShotMode string
+
+const (
+	// FewShotAuto adds examples for local models, which follow a format far
+	// more reliably when shown one.
+	FewShotAuto FewShotMode = "auto"
+	// FewShotAlways adds examples for every provider.
+	FewShotAlways FewShotMode = "always"
+	// FewShotNever never adds examples.
+	FewShotNever FewShotMode = "never"
+)
+
+// ParseFewShotMode parses a few-shot mode. An empty name yields FewShotAuto.
+func ParseFewShotMode(name string) (FewShotMode, error) {
+	switch m := FewShotMode(strings.ToLower(strings.TrimSpace(name))); m {
+	case "":
+		return FewShotAuto, nil
+	case FewShotAuto, FewShotAlways, FewShotNever:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown few-shot mode %q (valid: auto, always, never)", name)
+	}
+}
+
+// Enabled reports whether examples are added to prompts for the named provider.
+func (m FewShotMode) Enabled(provider string) bool {
+	switch m {
+	case FewShotAlways:
+		return true
+	case FewShotNever:
+		return false
+	default:
+		return provider == ProviderNameOllama
+	}
+}
+
+// CapExamples returns the examples that fit in maxBytes, in order. Examples
+// that would exceed the budget are skipped so a later, smaller one can still
+// fit; incomplete examples are dropped. A non-positive maxBytes means no cap.
+func CapExamples(examples []Example, maxBytes int) []Example {
+	var kept []Example
+	used := 0
+	for _, example := range examples {
+		example.Diff = strings.TrimSpace(example.Diff)
+		example.Message = strings.TrimSpace(example.Message)
+		if example.Diff == "" || example.Message == "" {
+			continue
+		}
+		if maxBytes > 0 && used+example.size() > maxBytes {
+			continue
+		}
+		kept = append(kept, example)
+		used += example.size()
+	}
+	return kept
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParseFewShotMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    FewShotMode
+		wantErr bool
+	}{
+		{"", FewShotAuto, false},
+		{"auto", FewShotAuto, false},
+		{"Always", FewShotAlways, false},
+		{" never ", FewShotNever, false},
+		{"sometimes", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFewShotMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFewShotMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFewShotMode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFewShotModeEnabled(t *testing.T) {
+	if !FewShotAuto.Enabled(ProviderNameOllama) || FewShotAuto.Enabled(ProviderNameOpenAI) {
+		t.Error("auto should enable examples for ollama only")
+	}
+	if !FewShotAlways.Enabled(ProviderNameDeepSeek) {
+		t.Error("always should enable examples for every provider")
+	}
+	if FewShotNever.Enabled(ProviderNameOllama) {
+		t.Error("never should disable examples")
+	}
+}
+
+func TestCapExamples(t *testing.T) {
+	examples := []Example{
+		{Diff: "+a", Message: "feat: a"},                      // 9 bytes
+		{Diff: strings.Repeat("+", 50), Message: "feat: big"}, // too large
+		{Diff: " ", Message: "chore: no diff"},                // incomplete
+		{Diff: "\n+b\n", Message: "fix: b\n"},                 // 8 bytes once trimmed
+		{Diff: "+c", Message: "feat: c"},                      // over budget
+	}
+
+	got := CapExamples(examples, 20)
+	if len(got) != 2 || got[0].Message != "feat: a" || got[1].Diff != "+b" || got[1].Message != "fix: b" {
+		t.Errorf("CapExamples() = %+v", got)
+	}
+
+	if got := CapExamples(examples, 0); len(got) != 4 {
+		t.Errorf("CapExamples() without cap kept %d examples, want 4", len(got))
+	}
+}
+
+func TestRenderPromptExamples(t *testing.T) {
+	pt := NewPromptTemplate()
+	data := &PromptData{
+		DiffStats: &git.DiffStats{},
+		Examples:  []Example{{Diff: "+func Retry() error", Message: "feat(client): add retry support"}},
+	}
+
+	prompt, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	for _, want := range []string{"[[EXAMPLES]]", "+func Retry() error", "feat(client): add retry support"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt does not contain %q:\n%s", want, prompt)
+		}
+	}
+
+	// The examples stay the same between commits and come first, so the
+	// prompt prefix can be cached by the provider
+	data.PreviousAttempt = "fix: retry"
+	data.Context = "flaky network"
+	prompt, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	examples := strings.Index(prompt, "[[EXAMPLES]]")
+	for _, later := range []string{"fix: retry", "[[DEVELOPER CONTEXT]]", "[[CODE CHANGES / DIFF]]"} {
+		if i := strings.Index(prompt, later); i < examples {
+			t.Errorf("%q comes before the examples:\n%s", later, prompt)
+		}
+	}
+
+	data.Examples = nil
+	prompt, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "[[EXAMPLES]]") {
+		t.Errorf("prompt has an examples section without examples:\n%s", prompt)
+	}
+}
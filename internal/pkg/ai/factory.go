@@ -3,8 +3,12 @@ package ai
 
 import (
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/gitsage/gitsage/internal/pkg/config"
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/sashabaranov/go-openai"
 )
 
 // ProviderName constants for supported providers.
@@ -12,24 +16,119 @@ const (
 	ProviderNameOpenAI   = "openai"
 	ProviderNameDeepSeek = "deepseek"
 	ProviderNameOllama   = "ollama"
+	ProviderNameMock     = "mock"
 )
 
+// Factory creates a provider from its configuration.
+type Factory func(config ProviderConfig) (Provider, error)
+
+// registry holds the providers added with RegisterProvider.
+var registry = struct {
+	sync.RWMutex
+	factories map[string]Factory
+}{factories: make(map[string]Factory)}
+
+// RegisterProvider makes a provider available under name, so that it can be
+// selected with provider.name. Built-in providers cannot be replaced, and a
+// name can only be registered once.
+func RegisterProvider(name string, factory Factory) error {
+	if name == "" || factory == nil {
+		return fmt.Errorf("provider name and factory are required")
+	}
+	switch name {
+	case ProviderNameOpenAI, ProviderNameDeepSeek, ProviderNameOllama, ProviderNameMock:
+		return fmt.Errorf("provider %s is built in", name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		return fmt.Errorf("provider %s is already registered", name)
+	}
+	registry.factories[name] = factory
+	return nil
+}
+
+// PromptTemplateSetter is implemented by providers whose prompts can be
+// replaced with NewProviderWithCustomPrompt.
+type PromptTemplateSetter interface {
+	SetPromptTemplate(pt *PromptTemplate)
+}
+
 // NewProvider creates a new AI provider based on the configuration.
 func NewProvider(cfg *config.ProviderConfig) (Provider, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("provider configuration is required")
 	}
 
+	// The key is resolved only now, so that api_key_cmd runs only for
+	// commands that call the provider
+	apiKey, err := cfg.ResolveAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Header values may reference the environment, so that gateway tokens
+	// need not be stored in the file
+	var headers map[string]string
+	if len(cfg.ExtraHeaders) > 0 {
+		headers = make(map[string]string, len(cfg.ExtraHeaders))
+		for name, value := range cfg.ExtraHeaders {
+			headers[name] = os.ExpandEnv(value)
+		}
+	}
+
 	// Convert config.ProviderConfig to ai.ProviderConfig
 	aiConfig := ProviderConfig{
-		APIKey:      cfg.APIKey,
-		Model:       cfg.Model,
-		Endpoint:    cfg.Endpoint,
-		Temperature: cfg.Temperature,
-		MaxTokens:   cfg.MaxTokens,
+		APIKey:       apiKey,
+		Model:        cfg.Model,
+		Endpoint:     cfg.Endpoint,
+		Temperature:  cfg.Temperature,
+		MaxTokens:    cfg.MaxTokens,
+		Organization: cfg.Organization,
+		Project:      cfg.Project,
+		User:         cfg.User,
+		ExtraHeaders: headers,
+		Ollama: OllamaSettings{
+			NumCtx:        cfg.Ollama.NumCtx,
+			TopP:          cfg.Ollama.TopP,
+			RepeatPenalty: cfg.Ollama.RepeatPenalty,
+		},
 	}
 
+	provider, err := newProvider(cfg.Name, aiConfig)
+	if err != nil {
+		return nil, err
+	}
+	if path := os.Getenv(RecordEnvVar); path != "" {
+		apperrors.Debug("Recording provider exchanges to %s", path)
+		return NewRecorder(provider, path), nil
+	}
+	return provider, nil
+}
+
+// Endpoint returns the endpoint the provider sends its requests to: the
+// configured one, or the default of a built-in provider. It is empty for the
+// mock provider, which makes no requests, and for registered providers
+// without a configured endpoint.
+func Endpoint(cfg *config.ProviderConfig) string {
+	if cfg.Endpoint != "" {
+		return cfg.Endpoint
+	}
 	switch cfg.Name {
+	case ProviderNameOpenAI, "":
+		return openai.DefaultConfig("").BaseURL
+	case ProviderNameDeepSeek:
+		return DefaultDeepSeekEndpoint
+	case ProviderNameOllama:
+		return DefaultOllamaEndpoint
+	}
+	return ""
+}
+
+// newProvider creates the provider registered under name.
+func newProvider(name string, aiConfig ProviderConfig) (Provider, error) {
+	switch name {
 	case ProviderNameOpenAI, "":
 		// Default to OpenAI if no provider specified
 		return NewOpenAIProvider(aiConfig)
@@ -41,8 +140,17 @@ func NewProvider(cfg *config.ProviderConfig) (Provider, error) {
 	case ProviderNameOllama:
 		return NewOllamaProvider(aiConfig)
 
+	case ProviderNameMock:
+		return NewMockProvider(aiConfig)
+
 	default:
-		return nil, fmt.Errorf("unknown provider: %s", cfg.Name)
+		registry.RLock()
+		factory, ok := registry.factories[name]
+		registry.RUnlock()
+		if ok {
+			return factory(aiConfig)
+		}
+		return nil, fmt.Errorf("unknown provider: %s", name)
 	}
 }
 
@@ -56,12 +164,7 @@ func NewProviderWithCustomPrompt(cfg *config.ProviderConfig, systemPrompt, userP
 	// Set custom prompt template if provider supports it
 	pt := NewPromptTemplateWithCustom(systemPrompt, userPrompt)
 
-	switch p := provider.(type) {
-	case *OpenAIProvider:
-		p.SetPromptTemplate(pt)
-	case *DeepSeekProvider:
-		p.SetPromptTemplate(pt)
-	case *OllamaProvider:
+	if p, ok := provider.(PromptTemplateSetter); ok {
 		p.SetPromptTemplate(pt)
 	}
 
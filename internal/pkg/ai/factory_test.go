@@ -1,6 +1,9 @@
 package ai
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gitsage/gitsage/internal/pkg/config"
@@ -140,3 +143,74 @@ func TestNewProviderWithCustomPrompt(t *testing.T) {
 		t.Errorf("UserPrompt = %q, want %q", openaiProvider.promptTemplate.UserPrompt, customUser)
 	}
 }
+
+// stubProvider is a minimal Provider for registry tests.
+type stubProvider struct {
+	config ProviderConfig
+}
+
+func (p *stubProvider) GenerateCommitMessage(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	return &GenerateResponse{Subject: "chore: stub"}, nil
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) ValidateConfig(config ProviderConfig) error { return nil }
+
+func TestRegisterProvider(t *testing.T) {
+	err := RegisterProvider("stub-registry", func(cfg ProviderConfig) (Provider, error) {
+		return &stubProvider{config: cfg}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterProvider() error = %v", err)
+	}
+
+	provider, err := NewProvider(&config.ProviderConfig{Name: "stub-registry", Model: "m1", MaxTokens: 42})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	stub, ok := provider.(*stubProvider)
+	if !ok {
+		t.Fatalf("expected the registered provider, got %T", provider)
+	}
+	if stub.config.Model != "m1" || stub.config.MaxTokens != 42 {
+		t.Errorf("factory got config %+v", stub.config)
+	}
+
+	// A name is registered once
+	if err := RegisterProvider("stub-registry", func(ProviderConfig) (Provider, error) { return nil, nil }); err == nil {
+		t.Error("expected an error registering the same name twice")
+	}
+}
+
+func TestRegisterProvider_Invalid(t *testing.T) {
+	factory := func(ProviderConfig) (Provider, error) { return &stubProvider{}, nil }
+
+	for _, name := range []string{ProviderNameOpenAI, ProviderNameDeepSeek, ProviderNameOllama, ""} {
+		if err := RegisterProvider(name, factory); err == nil {
+			t.Errorf("RegisterProvider(%q) should fail", name)
+		}
+	}
+	if err := RegisterProvider("stub-nil", nil); err == nil {
+		t.Error("RegisterProvider() should fail without a factory")
+	}
+}
+
+func TestNewProvider_APIKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "openai.key")
+	if err := os.WriteFile(keyFile, []byte("sk-test-key-that-is-long-enough-for-validation\n"), 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	provider, err := NewProvider(&config.ProviderConfig{Name: "openai", APIKeyFile: keyFile})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if provider.Name() != "openai" {
+		t.Errorf("Name() = %q, want %q", provider.Name(), "openai")
+	}
+
+	if _, err := NewProvider(&config.ProviderConfig{Name: "openai", APIKeyFile: keyFile + ".missing"}); err == nil {
+		t.Error("NewProvider() with a missing key file should fail")
+	}
+}
@@ -0,0 +1,146 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/sashabaranov/go-openai"
+)
+
+const (
+	// HealthCheckTimeout bounds a provider health check, so an unreachable
+	// provider is reported right away instead of after the retry cycle.
+	HealthCheckTimeout = 3 * time.Second
+
+	// OllamaTagsPath is the API path listing the locally available models.
+	OllamaTagsPath = "/api/tags"
+)
+
+// HealthChecker is implemented by providers that can cheaply check they are
+// reachable before generating.
+type HealthChecker interface {
+	// HealthCheck returns an error only when generation is certain to fail,
+	// e.g. the server is not running or the API key is rejected. Slow or
+	// inconclusive checks return nil and leave the decision to generation.
+	HealthCheck(ctx context.Context) error
+}
+
+// HealthCheck checks that Ollama is running and the configured model is pulled.
+func (p *OllamaProvider) HealthCheck(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.Endpoint+OllamaTagsPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		if isInconclusiveHealthError(err) {
+			apperrors.Debug("Ollama health check inconclusive: %v", err)
+			return nil
+		}
+		return wrapOllamaAPIError(err)
+	}
+	defer httpResp.Body.Close()
+
+	// Proxies in front of Ollama may not expose the tags route
+	if httpResp.StatusCode != http.StatusOK {
+		apperrors.Debug("Ollama health check inconclusive: %s returned status %d", OllamaTagsPath, httpResp.StatusCode)
+		return nil
+	}
+
+	var tags struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+	}
+	if err := json.NewDecoder(httpResp.Body).Decode(&tags); err != nil {
+		apperrors.Debug("Ollama health check: unexpected /api/tags response: %v", err)
+		return nil
+	}
+
+	for _, model := range tags.Models {
+		if ollamaModelMatches(model.Name, p.config.Model) {
+			return nil
+		}
+	}
+	return wrapOllamaAPIError(&OllamaAPIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("model %q not found", p.config.Model),
+	})
+}
+
+// ollamaModelMatches reports whether an installed model satisfies the
+// configured one; a name without a tag means the "latest" tag.
+func ollamaModelMatches(installed, configured string) bool {
+	if !strings.Contains(configured, ":") {
+		configured += ":latest"
+	}
+	if !strings.Contains(installed, ":") {
+		installed += ":latest"
+	}
+	return installed == configured
+}
+
+// HealthCheck checks that the OpenAI API is reachable and accepts the API key.
+func (p *OpenAIProvider) HealthCheck(ctx context.Context) error {
+	return checkModelsEndpoint(ctx, p.client, wrapAPIError)
+}
+
+// HealthCheck checks that the DeepSeek API is reachable and accepts the API key.
+func (p *DeepSeekProvider) HealthCheck(ctx context.Context) error {
+	return checkModelsEndpoint(ctx, p.client, wrapDeepSeekAPIError)
+}
+
+// checkModelsEndpoint lists the models of an OpenAI-compatible API. Endpoints
+// without a models route are not treated as unhealthy.
+func checkModelsEndpoint(ctx context.Context, client *openai.Client, wrap func(error) error) error {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	_, err := client.ListModels(ctx)
+	if err == nil {
+		return nil
+	}
+
+	// Only a rejected API key is certain to fail generation too; restricted
+	// keys may not list models, and some compatible APIs lack the route
+	if status := httpStatusCode(err); status != 0 && status != http.StatusUnauthorized {
+		apperrors.Debug("Provider health check inconclusive: %v", err)
+		return nil
+	}
+	if isInconclusiveHealthError(err) {
+		apperrors.Debug("Provider health check inconclusive: %v", err)
+		return nil
+	}
+	return wrap(err)
+}
+
+// httpStatusCode returns the HTTP status of an OpenAI client error, or 0 if
+// the request never got a response.
+func httpStatusCode(err error) int {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode
+	}
+	return 0
+}
+
+// isInconclusiveHealthError reports whether a failed check says nothing
+// definite about the provider: it was slow or the run was cancelled.
+func isInconclusiveHealthError(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled)
+}
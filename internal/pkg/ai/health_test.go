@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+func TestOllamaProvider_HealthCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"model pulled", "codellama", http.StatusOK, `{"models":[{"name":"codellama:latest"}]}`, ""},
+		{"tagged model pulled", "qwen2.5-coder:7b", http.StatusOK, `{"models":[{"name":"llama3:latest"},{"name":"qwen2.5-coder:7b"}]}`, ""},
+		{"model missing", "codellama", http.StatusOK, `{"models":[{"name":"llama3:latest"}]}`, "model not found"},
+		{"tags route missing", "codellama", http.StatusNotFound, "not found", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != OllamaTagsPath {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider, err := NewOllamaProvider(ProviderConfig{Endpoint: server.URL, Model: tt.model})
+			if err != nil {
+				t.Fatalf("NewOllamaProvider() error = %v", err)
+			}
+
+			err = provider.HealthCheck(context.Background())
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("HealthCheck() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("HealthCheck() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOllamaProvider_HealthCheck_NotRunning(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL
+	server.Close()
+
+	provider, err := NewOllamaProvider(ProviderConfig{Endpoint: endpoint})
+	if err != nil {
+		t.Fatalf("NewOllamaProvider() error = %v", err)
+	}
+
+	err = provider.HealthCheck(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot connect to Ollama") {
+		t.Errorf("HealthCheck() error = %v, want cannot connect to Ollama", err)
+	}
+}
+
+func TestOpenAIProvider_HealthCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		wantCode apperrors.ErrorCode
+	}{
+		{"reachable", http.StatusOK, 0},
+		{"key rejected", http.StatusUnauthorized, apperrors.ErrAuthenticationFailed},
+		{"models route missing", http.StatusNotFound, 0},
+		{"restricted key", http.StatusForbidden, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					w.Write([]byte(`{"object":"list","data":[]}`))
+					return
+				}
+				w.Write([]byte(`{"error":{"message":"nope","type":"invalid_request_error"}}`))
+			}))
+			defer server.Close()
+
+			provider, err := NewOpenAIProvider(ProviderConfig{APIKey: "sk-test-key-1234567890", Endpoint: server.URL})
+			if err != nil {
+				t.Fatalf("NewOpenAIProvider() error = %v", err)
+			}
+
+			err = provider.HealthCheck(context.Background())
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("HealthCheck() error = %v", err)
+				}
+				return
+			}
+			if appErr := apperrors.GetAppError(err); appErr == nil || appErr.Code != tt.wantCode {
+				t.Errorf("HealthCheck() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestProvidersImplementHealthChecker(t *testing.T) {
+	var _ HealthChecker = (*OpenAIProvider)(nil)
+	var _ HealthChecker = (*DeepSeekProvider)(nil)
+	var _ HealthChecker = (*OllamaProvider)(nil)
+}
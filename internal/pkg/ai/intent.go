@@ -0,0 +1,88 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Intent is a commit type and scope chosen by the user. When set, generated
+// messages must use them instead of the model's own classification.
+type Intent struct {
+	Type  string
+	Scope string
+}
+
+// IsZero reports whether no type or scope was requested.
+func (i Intent) IsZero() bool {
+	return i.Type == "" && i.Scope == ""
+}
+
+// Validate checks that the type is a Conventional Commits type and that the
+// scope can be written inside a subject's parentheses.
+func (i Intent) Validate() error {
+	if i.Type != "" && !slices.Contains(ValidCommitTypes, i.Type) {
+		return fmt.Errorf("invalid commit type %q (valid: %s)", i.Type, strings.Join(ValidCommitTypes, ", "))
+	}
+	if strings.ContainsAny(i.Scope, "()\n") {
+		return fmt.Errorf("invalid commit scope %q: must not contain parentheses or newlines", i.Scope)
+	}
+	return nil
+}
+
+// Instruction returns the prompt line requiring the type and scope.
+func (i Intent) Instruction() string {
+	switch {
+	case i.Type != "" && i.Scope != "":
+		return fmt.Sprintf(`The title MUST use type %q and scope %q, i.e. start with "%s(%s): ".`, i.Type, i.Scope, i.Type, i.Scope)
+	case i.Type != "":
+		return fmt.Sprintf(`The title MUST use type %q, i.e. start with "%s: " or "%s(<scope>): ".`, i.Type, i.Type, i.Type)
+	default:
+		return fmt.Sprintf(`The title MUST use scope %q, i.e. start with "<type>(%s): ".`, i.Scope, i.Scope)
+	}
+}
+
+// Check returns an error describing how the subject deviates from the
+// requested type and scope, or nil if it conforms.
+func (i Intent) Check(subject string) error {
+	parsed := ParseCommitMessage(subject)
+	if i.Type != "" && parsed.Type != i.Type {
+		return fmt.Errorf("subject uses type %q instead of %q", parsed.Type, i.Type)
+	}
+	if i.Scope != "" && parsed.Scope != i.Scope {
+		return fmt.Errorf("subject uses scope %q instead of %q", parsed.Scope, i.Scope)
+	}
+	return nil
+}
+
+// Apply returns a copy of the response whose subject uses the requested type
+// and scope, keeping the model's description. A subject without a type is
+// left unchanged when no type was requested.
+func (i Intent) Apply(resp *GenerateResponse) *GenerateResponse {
+	if resp == nil || i.IsZero() {
+		return resp
+	}
+
+	parsed := ParseCommitMessage(resp.Subject)
+	if i.Type != "" {
+		parsed.Type = i.Type
+	}
+	if i.Scope != "" {
+		parsed.Scope = i.Scope
+	}
+	if parsed.Type == "" {
+		return resp
+	}
+
+	result := *resp
+	result.Subject = parsed.FormatSubject()
+	if resp.RawText != "" {
+		_, rest, found := strings.Cut(strings.TrimSpace(resp.RawText), "\n")
+		result.RawText = result.Subject
+		if found {
+			result.RawText += "\n" + rest
+		}
+	}
+	return &result
+}
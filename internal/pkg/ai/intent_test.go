@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestIntentValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		intent  Intent
+		wantErr bool
+	}{
+		{"empty", Intent{}, false},
+		{"type and scope", Intent{Type: "fix", Scope: "auth"}, false},
+		{"scope only", Intent{Scope: "auth"}, false},
+		{"unknown type", Intent{Type: "bugfix"}, true},
+		{"scope with parentheses", Intent{Scope: "a(b)"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.intent.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIntentCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		intent  Intent
+		subject string
+		wantErr bool
+	}{
+		{"zero intent", Intent{}, "feat: anything", false},
+		{"matching", Intent{Type: "fix", Scope: "auth"}, "fix(auth): repair login", false},
+		{"type only matches any scope", Intent{Type: "fix"}, "fix(ui): repair button", false},
+		{"wrong type", Intent{Type: "fix"}, "feat: add login", true},
+		{"missing scope", Intent{Type: "fix", Scope: "auth"}, "fix: repair login", true},
+		{"wrong scope", Intent{Scope: "auth"}, "fix(ui): repair button", true},
+		{"no type", Intent{Type: "fix"}, "repair login", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.intent.Check(tt.subject)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check(%q) error = %v, wantErr %v", tt.subject, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIntentApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		intent  Intent
+		subject string
+		want    string
+	}{
+		{"replace type and scope", Intent{Type: "fix", Scope: "auth"}, "feat(login): remember me", "fix(auth): remember me"},
+		{"keep model scope", Intent{Type: "fix"}, "feat(login): remember me", "fix(login): remember me"},
+		{"add type", Intent{Type: "fix"}, "remember me", "fix: remember me"},
+		{"scope without type leaves untyped subject", Intent{Scope: "auth"}, "remember me", "remember me"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &GenerateResponse{Subject: tt.subject, Body: "- body", RawText: tt.subject + "\n\n- body"}
+			got := tt.intent.Apply(resp)
+			if got.Subject != tt.want {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.want)
+			}
+			if !strings.HasPrefix(got.RawText, tt.want+"\n") || got.Body != "- body" {
+				t.Errorf("RawText = %q, Body = %q", got.RawText, got.Body)
+			}
+			if resp.Subject != tt.subject {
+				t.Errorf("Apply modified the original response")
+			}
+		})
+	}
+}
+
+func TestRenderPromptIntent(t *testing.T) {
+	pt := NewPromptTemplate()
+	data := &PromptData{
+		DiffStats: &git.DiffStats{},
+		Intent:    Intent{Type: "fix", Scope: "auth"},
+	}
+
+	prompt, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(prompt, `start with "fix(auth): "`) {
+		t.Errorf("prompt does not require the intent:\n%s", prompt)
+	}
+
+	data.Intent = Intent{}
+	prompt, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "MUST use") {
+		t.Errorf("prompt requires an intent that was not given:\n%s", prompt)
+	}
+}
@@ -0,0 +1,225 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// MaxMockBodyFiles is the number of files the mock provider lists in a body.
+const MaxMockBodyFiles = 10
+
+// MockProvider writes plausible commit messages from the changed files
+// alone, without network access, for demos, CI and air-gapped machines.
+// Its replies depend only on the request.
+type MockProvider struct {
+	config ProviderConfig
+}
+
+// NewMockProvider creates a mock provider. It needs no API key or endpoint.
+func NewMockProvider(config ProviderConfig) (*MockProvider, error) {
+	return &MockProvider{config: config}, nil
+}
+
+// Name returns the provider name.
+func (p *MockProvider) Name() string {
+	return ProviderNameMock
+}
+
+// ValidateConfig accepts any configuration.
+func (p *MockProvider) ValidateConfig(config ProviderConfig) error {
+	return nil
+}
+
+// Capabilities returns those of a free model with an unknown context
+// window, so that large diffs take the same path as with Ollama.
+func (p *MockProvider) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+// GenerateCommitMessage writes a message for the request's files. Requests
+// made only of a prompt, such as file summaries, get a reply naming the
+// files the prompt lists.
+func (p *MockProvider) GenerateCommitMessage(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	chunks := req.DiffChunks
+	if len(chunks) == 0 && req.DiffStats != nil {
+		chunks = req.DiffStats.Chunks
+	}
+	if len(chunks) == 0 {
+		if req.CustomPrompt == "" {
+			return nil, errors.New("no diff chunks provided")
+		}
+		return &GenerateResponse{RawText: mockPromptReply(req.CustomPrompt)}, nil
+	}
+
+	commitType := req.Intent.Type
+	if commitType == "" {
+		commitType = mockCommitType(chunks)
+	}
+	scope := req.Intent.Scope
+	if scope == "" {
+		scope = mockScope(chunks)
+	}
+
+	header := commitType
+	if scope != "" {
+		header += "(" + scope + ")"
+	}
+	subject := fmt.Sprintf("%s: %s %s", header, mockVerb(commitType, 0), mockObject(chunks))
+	// Regenerating asks for a different message
+	if req.PreviousAttempt != "" && strings.Contains(req.PreviousAttempt, subject) {
+		subject = fmt.Sprintf("%s: %s %s", header, mockVerb(commitType, 1), mockObject(chunks))
+	}
+
+	var body strings.Builder
+	for i, chunk := range chunks {
+		if i == MaxMockBodyFiles {
+			body.WriteString(fmt.Sprintf("- ... and %d more files\n", len(chunks)-MaxMockBodyFiles))
+			break
+		}
+		body.WriteString(fmt.Sprintf("- %s: %s (+%d -%d)\n", chunk.FilePath, mockChangeWord(chunk.ChangeType), chunk.Additions, chunk.Deletions))
+	}
+
+	resp := &GenerateResponse{Subject: subject, Body: strings.TrimSpace(body.String())}
+	resp.RawText = resp.Subject + "\n\n" + resp.Body
+	return resp, nil
+}
+
+// mockCommitType guesses the commit type from the kind of files changed and
+// how they changed.
+func mockCommitType(chunks []git.DiffChunk) string {
+	if commitType := CategoryType(chunks); commitType != "" {
+		return commitType
+	}
+
+	added, deleted, lines := 0, 0, 0
+	for _, chunk := range chunks {
+		switch chunk.ChangeType {
+		case git.ChangeTypeAdded:
+			added++
+		case git.ChangeTypeDeleted:
+			deleted++
+		}
+		lines += chunk.Additions + chunk.Deletions
+	}
+	switch {
+	case added > 0:
+		return "feat"
+	case deleted == len(chunks):
+		return "chore"
+	case lines <= 10:
+		return "fix"
+	default:
+		return "refactor"
+	}
+}
+
+// mockScope returns the name of the deepest directory holding every changed
+// file, or "" if they share none.
+func mockScope(chunks []git.DiffChunk) string {
+	common := path.Dir(chunks[0].FilePath)
+	for _, chunk := range chunks[1:] {
+		for dir := path.Dir(chunk.FilePath); common != "." && dir != common && !strings.HasPrefix(dir, common+"/"); {
+			common = path.Dir(common)
+		}
+	}
+	if common == "." || common == "/" {
+		return ""
+	}
+	return path.Base(common)
+}
+
+// mockVerbs are the subject verbs of each commit type; the second one is
+// used when regenerating.
+var mockVerbs = map[string][2]string{
+	"feat":     {"add", "introduce"},
+	"fix":      {"correct", "repair"},
+	"refactor": {"restructure", "clean up"},
+	"docs":     {"update", "revise"},
+	"test":     {"extend", "cover"},
+	"ci":       {"update", "adjust"},
+	"build":    {"update", "adjust"},
+	"chore":    {"remove", "drop"},
+}
+
+// mockVerb returns the subject verb of the commit type.
+func mockVerb(commitType string, variant int) string {
+	if verbs, ok := mockVerbs[commitType]; ok {
+		return verbs[variant]
+	}
+	return [2]string{"update", "revise"}[variant]
+}
+
+// mockObject names what the subject is about: the file, or the number of files.
+func mockObject(chunks []git.DiffChunk) string {
+	if len(chunks) == 1 {
+		return path.Base(chunks[0].FilePath)
+	}
+	return fmt.Sprintf("%d files", len(chunks))
+}
+
+// mockChangeWord describes how a file changed.
+func mockChangeWord(changeType git.ChangeType) string {
+	switch changeType {
+	case git.ChangeTypeAdded:
+		return "added"
+	case git.ChangeTypeDeleted:
+		return "removed"
+	case git.ChangeTypeRenamed:
+		return "renamed"
+	default:
+		return "updated"
+	}
+}
+
+var (
+	// mockFileHeader matches the "=== path ===" and "=== path (...) ===" file
+	// headers of prompts.
+	mockFileHeader = regexp.MustCompile(`(?m)^=== (\S+)`)
+	// mockExactReply matches a prompt's instruction to reply with a fixed word.
+	mockExactReply = regexp.MustCompile(`reply with exactly: (\S+)`)
+	// mockBullet matches the "- " list items of prompts.
+	mockBullet = regexp.MustCompile(`(?m)^- (.+)$`)
+)
+
+// mockPromptReply answers a request made only of a prompt: a fixed reply it
+// asks for, a line per file it lists, or its list items as notes.
+func mockPromptReply(prompt string) string {
+	if match := mockExactReply.FindStringSubmatch(prompt); match != nil {
+		return strings.TrimRight(match[1], ".")
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, match := range mockFileHeader.FindAllStringSubmatch(prompt, -1) {
+		if file := match[1]; !seen[file] {
+			seen[file] = true
+			lines = append(lines, fmt.Sprintf("- %s: updated", file))
+		}
+	}
+	if len(lines) > 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	var notes []string
+	for _, match := range mockBullet.FindAllStringSubmatch(prompt, -1) {
+		notes = append(notes, "- "+match[1])
+	}
+	if len(notes) == 0 {
+		return "Update"
+	}
+	return "Changes\n\n" + strings.Join(notes, "\n")
+}
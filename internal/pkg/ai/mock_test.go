@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestNewProvider_Mock(t *testing.T) {
+	provider, err := NewProvider(&config.ProviderConfig{Name: "mock"})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if provider.Name() != ProviderNameMock {
+		t.Errorf("Name() = %q, want %q", provider.Name(), ProviderNameMock)
+	}
+}
+
+func TestMockProvider_GenerateCommitMessage(t *testing.T) {
+	p, _ := NewMockProvider(ProviderConfig{})
+
+	tests := []struct {
+		name   string
+		req    *GenerateRequest
+		header string
+	}{
+		{
+			name:   "docs",
+			req:    &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "README.md", ChangeType: git.ChangeTypeModified, Additions: 20}}},
+			header: "docs: update README.md",
+		},
+		{
+			name: "new files",
+			req: &GenerateRequest{DiffChunks: []git.DiffChunk{
+				{FilePath: "internal/auth/token.go", ChangeType: git.ChangeTypeAdded, Additions: 40},
+				{FilePath: "internal/auth/session.go", ChangeType: git.ChangeTypeModified, Additions: 3},
+			}},
+			header: "feat(auth): add 2 files",
+		},
+		{
+			name:   "small change",
+			req:    &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "cmd/main.go", ChangeType: git.ChangeTypeModified, Additions: 1, Deletions: 1}}},
+			header: "fix(cmd): correct main.go",
+		},
+		{
+			name: "tests across directories",
+			req: &GenerateRequest{DiffChunks: []git.DiffChunk{
+				{FilePath: "app/a_test.go", ChangeType: git.ChangeTypeModified, Additions: 30},
+				{FilePath: "git/b_test.go", ChangeType: git.ChangeTypeModified, Additions: 30},
+			}},
+			header: "test: extend 2 files",
+		},
+		{
+			name: "intent",
+			req: &GenerateRequest{
+				DiffChunks: []git.DiffChunk{{FilePath: "cmd/main.go", ChangeType: git.ChangeTypeModified, Additions: 50, Deletions: 50}},
+				Intent:     Intent{Type: "perf", Scope: "cli"},
+			},
+			header: "perf(cli): update main.go",
+		},
+		{
+			name:   "files from the stats",
+			req:    &GenerateRequest{CustomPrompt: "summaries", DiffStats: &git.DiffStats{Chunks: []git.DiffChunk{{FilePath: "go.mod", ChangeType: git.ChangeTypeModified, Additions: 1}}}},
+			header: "build: update go.mod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := p.GenerateCommitMessage(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("GenerateCommitMessage() error = %v", err)
+			}
+			if resp.Subject != tt.header {
+				t.Errorf("Subject = %q, want %q", resp.Subject, tt.header)
+			}
+			if !ParseCommitMessage(resp.RawText).IsValid || !strings.HasPrefix(resp.RawText, resp.Subject+"\n\n"+resp.Body) {
+				t.Errorf("RawText %q is not the Conventional Commits message", resp.RawText)
+			}
+		})
+	}
+}
+
+func TestMockProvider_Regenerate(t *testing.T) {
+	p, _ := NewMockProvider(ProviderConfig{})
+	req := &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "cmd/main.go", ChangeType: git.ChangeTypeModified, Additions: 1}}}
+
+	first, _ := p.GenerateCommitMessage(context.Background(), req)
+	again, _ := p.GenerateCommitMessage(context.Background(), req)
+	if first.RawText != again.RawText {
+		t.Error("expected the same reply to the same request")
+	}
+
+	req.PreviousAttempt = first.RawText
+	second, _ := p.GenerateCommitMessage(context.Background(), req)
+	if second.Subject == first.Subject {
+		t.Errorf("expected a different subject when regenerating, got %q twice", first.Subject)
+	}
+}
+
+func TestMockProvider_PromptOnly(t *testing.T) {
+	p, _ := NewMockProvider(ProviderConfig{})
+	reply := func(prompt string) string {
+		t.Helper()
+		resp, err := p.GenerateCommitMessage(context.Background(), &GenerateRequest{CustomPrompt: prompt})
+		if err != nil {
+			t.Fatalf("GenerateCommitMessage() error = %v", err)
+		}
+		return resp.RawText
+	}
+
+	summary := reply("Describe each file:\n\n=== auth/token.go (modified, +1 -0) ===\n+x\n\n=== auth/session.go ===\n+y\n")
+	if summary != "- auth/token.go: updated\n- auth/session.go: updated" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	if got := reply("Check the message.\nIf the message is accurate, reply with exactly: OK\n"); got != "OK" {
+		t.Errorf("expected the requested reply, got %q", got)
+	}
+	if got := reply("Commits:\n- feat: add login\n- fix: typo\n"); !strings.Contains(got, "- feat: add login\n- fix: typo") {
+		t.Errorf("expected the listed items, got %q", got)
+	}
+
+	if _, err := p.GenerateCommitMessage(context.Background(), &GenerateRequest{}); err == nil {
+		t.Error("expected an error for an empty request")
+	}
+}
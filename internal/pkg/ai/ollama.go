@@ -48,8 +48,11 @@ type OllamaMessage struct {
 
 // OllamaOptions represents optional parameters for Ollama requests.
 type OllamaOptions struct {
-	Temperature float32 `json:"temperature,omitempty"`
-	NumPredict  int     `json:"num_predict,omitempty"`
+	Temperature   float32 `json:"temperature,omitempty"`
+	NumPredict    int     `json:"num_predict,omitempty"`
+	NumCtx        int     `json:"num_ctx,omitempty"`
+	TopP          float32 `json:"top_p,omitempty"`
+	RepeatPenalty float32 `json:"repeat_penalty,omitempty"`
 }
 
 // OllamaChatResponse represents a response from the Ollama chat API.
@@ -59,6 +62,8 @@ type OllamaChatResponse struct {
 	Message   OllamaMessage `json:"message"`
 	Done      bool          `json:"done"`
 	Error     string        `json:"error,omitempty"`
+	// PromptEvalCount is the number of prompt tokens the model evaluated.
+	PromptEvalCount int `json:"prompt_eval_count,omitempty"`
 }
 
 // NewOllamaProvider creates a new Ollama provider.
@@ -82,11 +87,7 @@ func NewOllamaProvider(config ProviderConfig) (*OllamaProvider, error) {
 	}
 
 	// Create HTTP client with timeout and connection pooling
-	transport := &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
-	}
+	transport := newTransport(config.ExtraHeaders)
 	httpClient := &http.Client{
 		Timeout:   DefaultTimeout,
 		Transport: transport,
@@ -138,29 +139,19 @@ func (p *OllamaProvider) GenerateCommitMessage(ctx context.Context, req *Generat
 		return nil, errors.New("no diff chunks provided")
 	}
 
-	// Determine if chunking is required based on total diff size
-	totalSize := 0
-	for _, chunk := range req.DiffChunks {
-		totalSize += len(chunk.Content)
-	}
-	requiresChunking := totalSize > 10*1024 // 10KB threshold
-
-	// Build prompt data
-	promptData := BuildPromptData(req, requiresChunking)
-
-	// Render user prompt
-	userPrompt, err := p.promptTemplate.RenderUserPrompt(promptData)
+	// Build the prompts; chunking is required when the diff exceeds the model's budget
+	systemPrompt, userPrompt, err := p.promptTemplate.BuildPrompts(req, requiresChunking(req, p.Capabilities(), p.config.MaxTokens))
 	if err != nil {
 		return nil, fmt.Errorf("failed to render prompt: %w", err)
 	}
 
 	// Create Ollama chat request
 	chatReq := OllamaChatRequest{
-		Model: p.config.Model,
+		Model: req.modelOr(p.config.Model),
 		Messages: []OllamaMessage{
 			{
 				Role:    "system",
-				Content: p.promptTemplate.GetSystemPrompt(),
+				Content: systemPrompt,
 			},
 			{
 				Role:    "user",
@@ -169,13 +160,16 @@ func (p *OllamaProvider) GenerateCommitMessage(ctx context.Context, req *Generat
 		},
 		Stream: false, // We don't need streaming for commit messages
 		Options: &OllamaOptions{
-			Temperature: p.config.Temperature,
-			NumPredict:  p.config.MaxTokens,
+			Temperature:   req.temperatureOr(p.config.Temperature),
+			NumPredict:    p.config.MaxTokens,
+			NumCtx:        p.config.Ollama.NumCtx,
+			TopP:          p.config.Ollama.TopP,
+			RepeatPenalty: p.config.Ollama.RepeatPenalty,
 		},
 	}
 
 	// Log API request in verbose mode
-	apperrors.LogAPIRequest("ollama", p.config.Endpoint, p.config.Model, len(userPrompt))
+	apperrors.LogAPIRequest("ollama", p.config.Endpoint, req.modelOr(p.config.Model), len(userPrompt))
 	startTime := time.Now()
 
 	// Call Ollama API with retry logic
@@ -221,6 +215,12 @@ func (p *OllamaProvider) GenerateCommitMessage(ctx context.Context, req *Generat
 		return nil, fmt.Errorf("ollama error: %s", resp.Error)
 	}
 
+	// Ollama drops the start of a prompt beyond the context window without
+	// an error; the message would describe only part of the diff
+	if err := p.checkTruncation(EstimateTokens(len(systemPrompt)+len(userPrompt)), resp.PromptEvalCount); err != nil {
+		return nil, err
+	}
+
 	rawText := resp.Message.Content
 
 	// Log raw AI response in verbose mode
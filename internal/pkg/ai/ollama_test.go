@@ -316,6 +316,39 @@ func TestOllamaProvider_GenerateCommitMessage_Success(t *testing.T) {
 	}
 }
 
+func TestOllamaProvider_GenerateCommitMessage_Overrides(t *testing.T) {
+	var got OllamaChatRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(OllamaChatResponse{
+			Message: OllamaMessage{Role: "assistant", Content: "feat: add feature"},
+			Done:    true,
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(ProviderConfig{Endpoint: server.URL, Model: "codellama", Temperature: 0.2})
+	if err != nil {
+		t.Fatalf("NewOllamaProvider() error = %v", err)
+	}
+
+	req := &GenerateRequest{
+		DiffChunks:  []git.DiffChunk{{FilePath: "test.go", Content: "+// new comment"}},
+		DiffStats:   &git.DiffStats{},
+		Temperature: 0.6,
+		Model:       "qwen2.5-coder:14b",
+	}
+	if _, err := provider.GenerateCommitMessage(context.Background(), req); err != nil {
+		t.Fatalf("GenerateCommitMessage() error = %v", err)
+	}
+
+	if got.Model != "qwen2.5-coder:14b" || got.Options == nil || got.Options.Temperature != 0.6 {
+		t.Errorf("request did not use the overrides: model %q, options %+v", got.Model, got.Options)
+	}
+}
+
 func TestOllamaProvider_GenerateCommitMessage_ServerError(t *testing.T) {
 	// Create a mock server that returns an error
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
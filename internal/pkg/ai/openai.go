@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"time"
 
@@ -67,15 +68,20 @@ func NewOpenAIProvider(config ProviderConfig) (*OpenAIProvider, error) {
 		clientConfig.BaseURL = config.Endpoint
 	}
 
-	// Create HTTP client with timeout and connection pooling
-	transport := &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
+	clientConfig.OrgID = config.Organization
+
+	// go-openai has no setting for the project, so it is sent like the extra
+	// headers, which take precedence
+	headers := make(map[string]string, len(config.ExtraHeaders)+1)
+	if config.Project != "" {
+		headers["OpenAI-Project"] = config.Project
 	}
+	maps.Copy(headers, config.ExtraHeaders)
+
+	// Create HTTP client with timeout and connection pooling
 	clientConfig.HTTPClient = &http.Client{
 		Timeout:   DefaultTimeout,
-		Transport: transport,
+		Transport: newTransport(headers),
 	}
 
 	client := openai.NewClientWithConfig(clientConfig)
@@ -123,41 +129,32 @@ func (p *OpenAIProvider) GenerateCommitMessage(ctx context.Context, req *Generat
 		return nil, errors.New("no diff chunks provided")
 	}
 
-	// Determine if chunking is required based on total diff size
-	totalSize := 0
-	for _, chunk := range req.DiffChunks {
-		totalSize += len(chunk.Content)
-	}
-	requiresChunking := totalSize > 10*1024 // 10KB threshold
-
-	// Build prompt data
-	promptData := BuildPromptData(req, requiresChunking)
-
-	// Render user prompt
-	userPrompt, err := p.promptTemplate.RenderUserPrompt(promptData)
+	// Build the prompts; chunking is required when the diff exceeds the model's budget
+	systemPrompt, userPrompt, err := p.promptTemplate.BuildPrompts(req, requiresChunking(req, p.Capabilities(), p.config.MaxTokens))
 	if err != nil {
 		return nil, fmt.Errorf("failed to render prompt: %w", err)
 	}
 
 	// Create chat completion request
 	chatReq := openai.ChatCompletionRequest{
-		Model: p.config.Model,
+		Model: req.modelOr(p.config.Model),
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: p.promptTemplate.GetSystemPrompt(),
+				Content: systemPrompt,
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
 				Content: userPrompt,
 			},
 		},
-		Temperature: p.config.Temperature,
+		Temperature: req.temperatureOr(p.config.Temperature),
 		MaxTokens:   p.config.MaxTokens,
+		User:        p.config.User,
 	}
 
 	// Log API request in verbose mode
-	apperrors.LogAPIRequest("openai", p.config.Endpoint, p.config.Model, len(userPrompt))
+	apperrors.LogAPIRequest("openai", p.config.Endpoint, req.modelOr(p.config.Model), len(userPrompt))
 	startTime := time.Now()
 
 	// Call OpenAI API with retry logic
@@ -212,7 +209,27 @@ func (p *OpenAIProvider) GenerateCommitMessage(ctx context.Context, req *Generat
 	// Parse the response into structured format
 	parsed := ParseCommitMessage(rawText)
 
-	return parsed.ToGenerateResponse(rawText), nil
+	response := parsed.ToGenerateResponse(rawText)
+	response.Usage = chatUsage(resp.Usage)
+	return response, nil
+}
+
+// chatUsage converts the usage of a chat completion. OpenAI and DeepSeek
+// cache prompt prefixes automatically and report the cached part in
+// prompt_tokens_details.
+func chatUsage(usage openai.Usage) *Usage {
+	if usage.PromptTokens == 0 && usage.CompletionTokens == 0 {
+		return nil
+	}
+	result := &Usage{
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+	}
+	if usage.PromptTokensDetails != nil {
+		result.CachedTokens = usage.PromptTokensDetails.CachedTokens
+	}
+	apperrors.Debug("Prompt cache: %d of %d prompt tokens cached", result.CachedTokens, result.PromptTokens)
+	return result
 }
 
 // isRetryableError checks if an error is retryable.
@@ -1,6 +1,10 @@
 package ai
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -184,3 +188,81 @@ func TestCalculateBackoff(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenAIProvider_GenerateCommitMessage_Usage(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage string
+		want  *Usage
+	}{
+		{"cache hit", `{"prompt_tokens": 2048, "completion_tokens": 40, "prompt_tokens_details": {"cached_tokens": 1536}}`, &Usage{PromptTokens: 2048, CompletionTokens: 40, CachedTokens: 1536}},
+		{"cache miss", `{"prompt_tokens": 900, "completion_tokens": 30}`, &Usage{PromptTokens: 900, CompletionTokens: 30}},
+		{"not reported", `{}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "feat: add login"}}], "usage": ` + tt.usage + `}`))
+			}))
+			defer server.Close()
+
+			provider, err := NewOpenAIProvider(ProviderConfig{
+				APIKey:   "sk-test-key-that-is-long-enough-for-validation",
+				Endpoint: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("NewOpenAIProvider() error = %v", err)
+			}
+
+			resp, err := provider.GenerateCommitMessage(context.Background(), &GenerateRequest{CustomPrompt: "summarize"})
+			if err != nil {
+				t.Fatalf("GenerateCommitMessage() error = %v", err)
+			}
+			if (resp.Usage == nil) != (tt.want == nil) || (tt.want != nil && *resp.Usage != *tt.want) {
+				t.Errorf("Usage = %+v, want %+v", resp.Usage, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenAIProvider_GenerateCommitMessage_Scoping(t *testing.T) {
+	var org, project, user string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org = r.Header.Get("OpenAI-Organization")
+		project = r.Header.Get("OpenAI-Project")
+		var body struct {
+			User string `json:"user"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		user = body.User
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "feat: add login"}}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		APIKey:       "sk-test-key-that-is-long-enough-for-validation",
+		Endpoint:     server.URL,
+		Organization: "org-acme",
+		Project:      "proj_tools",
+		User:         "jdoe",
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+
+	if _, err := provider.GenerateCommitMessage(context.Background(), &GenerateRequest{CustomPrompt: "summarize"}); err != nil {
+		t.Fatalf("GenerateCommitMessage() error = %v", err)
+	}
+	if org != "org-acme" {
+		t.Errorf("OpenAI-Organization = %q, want %q", org, "org-acme")
+	}
+	if project != "proj_tools" {
+		t.Errorf("OpenAI-Project = %q, want %q", project, "proj_tools")
+	}
+	if user != "jdoe" {
+		t.Errorf("user = %q, want %q", user, "jdoe")
+	}
+}
@@ -4,6 +4,7 @@ package ai
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 // ValidCommitTypes contains all valid Conventional Commits types.
@@ -223,8 +224,7 @@ func ValidateCommitMessage(rawText string) []string {
 	}
 
 	// Check subject length (warning threshold is 100 chars for Chinese support)
-	subjectLine := parsed.FormatSubject()
-	if len(subjectLine) > 100 {
+	if utf8.RuneCountInString(parsed.FormatSubject()) > 100 {
 		issues = append(issues, "subject line exceeds 100 characters")
 	}
 
@@ -0,0 +1,71 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Preset controls how much detail is requested for the commit message body.
+type Preset string
+
+const (
+	// PresetMinimal requests a subject line only.
+	PresetMinimal Preset = "minimal"
+	// PresetStandard requests a subject line and a short body.
+	PresetStandard Preset = "standard"
+	// PresetDetailed requests a subject line and a full body with one bullet per module.
+	PresetDetailed Preset = "detailed"
+)
+
+// ParsePreset parses a preset name. An empty name yields PresetStandard.
+func ParsePreset(name string) (Preset, error) {
+	switch p := Preset(strings.ToLower(strings.TrimSpace(name))); p {
+	case "":
+		return PresetStandard, nil
+	case PresetMinimal, PresetStandard, PresetDetailed:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown preset %q (valid: minimal, standard, detailed)", name)
+	}
+}
+
+// Instruction returns the final output instruction for the user prompt template.
+func (p Preset) Instruction() string {
+	switch p {
+	case PresetMinimal:
+		return `1. Title: Summarize the main intent in one line (Chinese).
+2. Body: None. Output the title line only.
+3. Output raw text only.`
+	case PresetDetailed:
+		return `1. Title: Summarize the main intent in one line (Chinese).
+2. Body: List details by module (scope), one bullet per module. Cover every module that changed. **Do not use file paths in the body.**
+3. Output raw text only.`
+	default:
+		return `1. Title: Summarize the main intent in one line (Chinese).
+2. Body: At most 3 short bullets covering the most important changes. **Do not use file paths in the body.**
+3. Output raw text only.`
+	}
+}
+
+// SummaryRequirements returns the output requirements for the final phase of
+// two-phase generation, where the message is written from per-file summaries.
+func (p Preset) SummaryRequirements() string {
+	switch p {
+	case PresetMinimal:
+		return `1. Subject 格式: <type>(<scope>): <简短描述>（不超过50字）
+2. 只输出 Subject 一行，不要 Body
+3. 只输出 commit message，不要解释`
+	case PresetDetailed:
+		return `1. Subject 格式: <type>(<scope>): <简短描述>（不超过50字）
+2. Body 必须包含：按模块/目录分组列出主要改动，每个模块一行，格式如：
+   - 模块名: 具体功能描述
+3. 如果有多个模块，都要列出
+4. 只输出 commit message，不要解释`
+	default:
+		return `1. Subject 格式: <type>(<scope>): <简短描述>（不超过50字）
+2. Body 简要概括最重要的改动，不超过 3 行，格式如：
+   - 模块名: 具体功能描述
+3. 只输出 commit message，不要解释`
+	}
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParsePreset(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Preset
+		wantErr bool
+	}{
+		{"", PresetStandard, false},
+		{"minimal", PresetMinimal, false},
+		{"standard", PresetStandard, false},
+		{"Detailed", PresetDetailed, false},
+		{" minimal ", PresetMinimal, false},
+		{"verbose", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePreset(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePreset(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePreset(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderUserPrompt_Preset(t *testing.T) {
+	tests := []struct {
+		preset Preset
+		want   string
+	}{
+		{PresetMinimal, "Output the title line only"},
+		{PresetStandard, "At most 3 short bullets"},
+		{PresetDetailed, "one bullet per module"},
+		{"", "At most 3 short bullets"}, // Zero value behaves like standard
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.preset), func(t *testing.T) {
+			pt := NewPromptTemplate()
+			prompt, err := pt.RenderUserPrompt(&PromptData{
+				DiffStats: &git.DiffStats{TotalFiles: 1},
+				Chunks:    []git.DiffChunk{{FilePath: "main.go", Content: "+x"}},
+				Preset:    tt.preset,
+			})
+			if err != nil {
+				t.Fatalf("RenderUserPrompt() error = %v", err)
+			}
+			if !strings.Contains(prompt, tt.want) {
+				t.Errorf("RenderUserPrompt() missing %q:\n%s", tt.want, prompt)
+			}
+		})
+	}
+}
+
+func TestRenderUserPrompt_RecentCommits(t *testing.T) {
+	pt := NewPromptTemplate()
+	data := &PromptData{
+		DiffStats: &git.DiffStats{TotalFiles: 1},
+		Chunks:    []git.DiffChunk{{FilePath: "main.go", Content: "+x"}},
+	}
+
+	prompt, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(prompt, "[[RECENT COMMITS]]") {
+		t.Error("RenderUserPrompt() should omit the section without recent commits")
+	}
+
+	data.RecentCommits = []string{"refactor(ui): split manager", "feat(ai): add presets"}
+	prompt, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	for _, want := range []string{"[[RECENT COMMITS]]", "- refactor(ui): split manager", "- feat(ai): add presets"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("RenderUserPrompt() missing %q:\n%s", want, prompt)
+		}
+	}
+}
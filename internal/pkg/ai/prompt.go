@@ -3,6 +3,7 @@ package ai
 
 import (
 	"bytes"
+	"strings"
 	"text/template"
 
 	"github.com/gitsage/gitsage/internal/pkg/git"
@@ -32,6 +33,8 @@ const DefaultSystemPrompt = `你是一位拥有上帝视角的 **首席软件架
 - <scope>: <详细描述>
 - chore: <依赖更新> (仅在必要时列出)
 
+正文的详略程度以用户消息中的 [[FINAL INSTRUCTION]] 为准。
+
 【质量标准】
 1.  **工程化术语**：描述中使用标准的软件工程术语（如“解耦”、“重构”、“接口定义”、“依赖注入”、“线程安全”等），避免口语化。
 2.  **路径清洗**：正文中**严禁**出现 'src/...', 'internal/...' 或文件扩展名（.js, .go, .java），必须使用抽象的模块名。
@@ -50,24 +53,107 @@ feat(user, ui): 扩展用户信息模型并同步前端展示
 请分析下方的 Diff，输出最终的 Commit Message。`
 
 // DefaultUserPromptTemplate uses a "Content-First" strategy to help local models focus on logic.
+// The repository context that stays the same between commits (examples and the
+// commit template) comes first, so providers that cache prompt prefixes can
+// reuse it across requests.
 const DefaultUserPromptTemplate = `Analyze the code changes below and write the commit message.
 
+{{if .Examples}}
+[[EXAMPLES]]
+> Examples of changes and the commit messages this project expects. Follow their style and level of detail, not their content:
+{{range .Examples}}
+--- Example diff ---
+{{.Diff}}
+--- Example message ---
+{{.Message}}
+
+{{end}}
+{{end}}
+
+{{if .CommitTemplate}}
+[[COMMIT TEMPLATE]]
+> This repository requires commit messages to follow the template below. Fill in its structure instead of the default format, replacing placeholders with content. Keep every line starting with "#" exactly as written:
+{{.CommitTemplate}}
+{{end}}
+
 {{if .PreviousAttempt}}
 > Note: The user rejected the previous attempt. Please improve upon this:
 {{.PreviousAttempt}}
 {{end}}
 
+{{if .Context}}
+[[DEVELOPER CONTEXT]]
+> The developer explains why this change was made. Treat it as authoritative for the intent and reflect it in the message:
+{{.Context}}
+{{end}}
+
 [[CODE CHANGES / DIFF]]
-{{if .RequiresChunking}}
+{{if .StatsOnly}}
+> Note: The diff content is private and not included. Only the changed files are listed, with their change type and added/deleted lines. Infer the change from the paths and counts, and do not invent details of the code:
+{{range .Chunks}}
+- {{.FilePath}}{{if .OldPath}} (from {{.OldPath}}){{end}} ({{.ChangeType}}{{if .IsBinary}}, binary{{else}}, +{{.Additions}} -{{.Deletions}}{{end}}{{if not .ModeChange.IsZero}}, {{.ModeChange.Describe}}{{end}})
+{{end}}
+{{else if .RequiresChunking}}
 > Note: Diff is too large. Summary of changes:
 {{range .Chunks}}
-- {{.FilePath}} ({{.ChangeType}})
+- {{.FilePath}} ({{.ChangeType}}{{if not .ModeChange.IsZero}}, {{.ModeChange.Describe}}{{end}})
 {{end}}
 {{else}}
-{{range .Chunks}}
+{{range .Chunks}}{{if not .IsGenerated}}
 --- File: {{.FilePath}} ---
-{{.Content}}
+{{if not .ModeChange.IsZero}}> Mode change: {{.FilePath}} {{.ModeChange.Describe}}
+{{end}}{{.Content}}
+
+{{end}}{{end}}
+{{end}}
+
+{{if .GeneratedFiles}}
+[[GENERATED FILES]]
+> These files are generated and their content is omitted. Do not describe their code; note them in a single body line instead (e.g. "regenerated protobuf stubs"):
+{{range .GeneratedFiles}}
+- {{.}}
+{{end}}
+{{end}}
+
+{{if .SensitiveFiles}}
+[[SECURITY-SENSITIVE FILES]]
+> These changed files are security-sensitive. Add a body line stating the security impact of their changes (e.g. "- security: tokens are now validated before use"), even if the change looks minor:
+{{range .SensitiveFiles}}
+- {{.}}
+{{end}}
+{{end}}
 
+{{if .OwnerAreas}}
+[[CODE OWNERSHIP]]
+{{if .AreaScope}}> The changed files belong to this team area, from CODEOWNERS and the directory layout. Use "{{.AreaScope}}" as the scope unless another scope is required:
+{{else}}> The changed files belong to these team areas, from CODEOWNERS and the directory layout. Add a body line naming the affected areas (e.g. "- affects payments team area"):
+{{end}}{{range .OwnerAreas}}
+- {{.}}
+{{end}}
+{{end}}
+
+{{if .UnstagedFiles}}
+[[UNSTAGED CHANGES - CONTEXT ONLY]]
+> These files have changes that are NOT part of this commit. Use them only to understand the work in progress. Do not describe them in the message:
+{{range .UnstagedFiles}}
+- {{.}}
+{{end}}
+{{end}}
+
+{{if .SquashedCommits}}
+[[SQUASHED COMMITS]]
+> These commits are squashed into one. Write a single message for their combined change, using the messages for intent and the diff for what actually changed. Leave out steps that later commits undid or fixed:
+{{range .SquashedCommits}}
+---
+{{.}}
+{{end}}
+{{end}}
+
+{{if .RecentCommits}}
+[[RECENT COMMITS]]
+> Recent commits on this branch. Keep the message consistent with ongoing work, but do not repeat these subjects:
+{{range .RecentCommits}}
+- {{.}}
 {{end}}
 {{end}}
 
@@ -75,9 +161,8 @@ const DefaultUserPromptTemplate = `Analyze the code changes below and write the
 Files: {{.DiffStats.TotalFiles}} | +{{.DiffStats.TotalAdditions}} | -{{.DiffStats.TotalDeletions}}
 
 [[FINAL INSTRUCTION]]
-1. Title: Summarize the main intent in one line (Chinese).
-2. Body: List details by module (scope). **Do not use file paths in the body.**
-3. Output raw text only.`
+{{.Preset.Instruction}}{{if not .Intent.IsZero}}
+4. {{.Intent.Instruction}}{{end}}`
 
 // PromptTemplate handles prompt generation for AI providers.
 type PromptTemplate struct {
@@ -93,6 +178,21 @@ type PromptData struct {
 	RequiresChunking bool
 	PreviousAttempt  string
 	CustomPrompt     string
+	Preset           Preset
+	RecentCommits    []string
+	// GeneratedFiles are the one-line summaries of generated files.
+	GeneratedFiles  []string
+	Context         string
+	Intent          Intent
+	CommitTemplate  string
+	Examples        []Example
+	SquashedCommits []string
+	UnstagedFiles   []string
+	SensitiveFiles  []string
+	OwnerAreas      []string
+	AreaScope       string
+	// StatsOnly lists the files with their line counts instead of the diff.
+	StatsOnly bool
 }
 
 // NewPromptTemplate creates a new PromptTemplate with default prompts.
@@ -150,6 +250,29 @@ func (pt *PromptTemplate) GetSystemPrompt() string {
 	return pt.SystemPrompt
 }
 
+// SystemPromptFor returns the system prompt with the repository's stack
+// summary appended, so the message uses the project's terminology.
+func (pt *PromptTemplate) SystemPromptFor(stack string) string {
+	if stack == "" {
+		return pt.SystemPrompt
+	}
+	return pt.SystemPrompt + "\n\n【项目技术栈】\n" + stack + "\n描述改动时使用该技术栈的惯用术语（例如 “gin handler”、“React 组件”）。"
+}
+
+// BuildPrompts renders the system and user prompts for a request and applies
+// its Redact function to both.
+func (pt *PromptTemplate) BuildPrompts(req *GenerateRequest, requiresChunking bool) (systemPrompt, userPrompt string, err error) {
+	userPrompt, err = pt.RenderUserPrompt(BuildPromptData(req, requiresChunking))
+	if err != nil {
+		return "", "", err
+	}
+	systemPrompt = pt.SystemPromptFor(req.Stack)
+	if req.Redact != nil {
+		systemPrompt, userPrompt = req.Redact(systemPrompt), req.Redact(userPrompt)
+	}
+	return systemPrompt, userPrompt, nil
+}
+
 // BuildPromptData creates PromptData from a GenerateRequest.
 func BuildPromptData(req *GenerateRequest, requiresChunking bool) *PromptData {
 	return &PromptData{
@@ -158,5 +281,30 @@ func BuildPromptData(req *GenerateRequest, requiresChunking bool) *PromptData {
 		RequiresChunking: requiresChunking,
 		PreviousAttempt:  req.PreviousAttempt,
 		CustomPrompt:     req.CustomPrompt,
+		Preset:           req.Preset,
+		RecentCommits:    req.RecentCommits,
+		GeneratedFiles:   generatedFileSummaries(req.DiffChunks),
+		Context:          req.Context,
+		Intent:           req.Intent,
+		CommitTemplate:   req.CommitTemplate,
+		Examples:         req.Examples,
+		SquashedCommits:  req.SquashedCommits,
+		UnstagedFiles:    req.UnstagedFiles,
+		SensitiveFiles:   req.SensitiveFiles,
+		OwnerAreas:       req.OwnerAreas,
+		AreaScope:        req.AreaScope,
+		StatsOnly:        req.StatsOnly,
+	}
+}
+
+// generatedFileSummaries returns the summaries of the generated files among chunks.
+func generatedFileSummaries(chunks []git.DiffChunk) []string {
+	var summaries []string
+	for _, chunk := range chunks {
+		// Stats-only chunks have no summary to list
+		if summary := strings.TrimSpace(chunk.Content); chunk.IsGenerated && summary != "" {
+			summaries = append(summaries, summary)
+		}
 	}
+	return summaries
 }
@@ -43,6 +43,34 @@ func TestNewPromptTemplateWithCustom_EmptyFallsBackToDefault(t *testing.T) {
 	}
 }
 
+func TestPromptTemplate_SystemPromptFor(t *testing.T) {
+	pt := NewPromptTemplateWithCustom("custom system", "")
+
+	if got := pt.SystemPromptFor(""); got != "custom system" {
+		t.Errorf("SystemPromptFor(\"\") = %q, want the system prompt unchanged", got)
+	}
+	got := pt.SystemPromptFor("Languages: Go. Frameworks: Gin.")
+	if !strings.HasPrefix(got, "custom system") || !strings.Contains(got, "Languages: Go. Frameworks: Gin.") {
+		t.Errorf("SystemPromptFor() = %q, want the stack appended to the system prompt", got)
+	}
+}
+
+func TestPromptTemplate_BuildPrompts_Redact(t *testing.T) {
+	pt := NewPromptTemplateWithCustom("system for /home/me/repo", "")
+	req := &GenerateRequest{
+		CustomPrompt: "diff of /home/me/repo/main.go",
+		Redact:       func(s string) string { return strings.ReplaceAll(s, "/home/me/repo", ".") },
+	}
+
+	system, user, err := pt.BuildPrompts(req, false)
+	if err != nil {
+		t.Fatalf("BuildPrompts() error = %v", err)
+	}
+	if system != "system for ." || user != "diff of ./main.go" {
+		t.Errorf("BuildPrompts() = %q, %q, want both prompts redacted", system, user)
+	}
+}
+
 func TestPromptTemplate_RenderUserPrompt(t *testing.T) {
 	pt := NewPromptTemplate()
 
@@ -175,6 +203,7 @@ func TestBuildPromptData(t *testing.T) {
 		},
 		CustomPrompt:    "custom",
 		PreviousAttempt: "previous",
+		Context:         "fixes the race in batch uploader",
 	}
 
 	data := BuildPromptData(req, true)
@@ -194,6 +223,239 @@ func TestBuildPromptData(t *testing.T) {
 	if data.PreviousAttempt != "previous" {
 		t.Error("PreviousAttempt should match")
 	}
+	if data.Context != req.Context {
+		t.Error("Context should match")
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_GeneratedFiles(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	req := &GenerateRequest{
+		DiffStats: &git.DiffStats{TotalFiles: 2},
+		DiffChunks: []git.DiffChunk{
+			{FilePath: "api/user.go", Content: "+func GetUser() {}"},
+			{FilePath: "api/user.pb.go", Content: "api/user.pb.go: regenerated protobuf stubs (+120/-80 lines, content omitted)\n", IsGenerated: true},
+		},
+	}
+
+	result, err := pt.RenderUserPrompt(BuildPromptData(req, false))
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+
+	if !strings.Contains(result, "--- File: api/user.go ---") {
+		t.Error("Result should contain the regular file")
+	}
+	if strings.Contains(result, "--- File: api/user.pb.go ---") {
+		t.Error("Generated files should not be listed with the diff")
+	}
+	if !strings.Contains(result, "[[GENERATED FILES]]") || !strings.Contains(result, "- api/user.pb.go: regenerated protobuf stubs") {
+		t.Errorf("Result should list the generated file summary:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_ModeChange(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	chunk := git.DiffChunk{
+		FilePath:   "scripts/build.sh",
+		Content:    "diff --git a/scripts/build.sh b/scripts/build.sh\nold mode 100644\nnew mode 100755\n",
+		ModeChange: git.ModeChange{OldMode: "100644", NewMode: "100755"},
+	}
+
+	for _, requiresChunking := range []bool{false, true} {
+		data := &PromptData{
+			DiffStats:        &git.DiffStats{TotalFiles: 1},
+			Chunks:           []git.DiffChunk{chunk},
+			RequiresChunking: requiresChunking,
+		}
+
+		result, err := pt.RenderUserPrompt(data)
+		if err != nil {
+			t.Fatalf("RenderUserPrompt() error = %v", err)
+		}
+		if !strings.Contains(result, "made executable") {
+			t.Errorf("Result should describe the mode change (chunking: %v):\n%s", requiresChunking, result)
+		}
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_Context(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats: &git.DiffStats{TotalFiles: 1},
+		Chunks:    []git.DiffChunk{{FilePath: "uploader.go", Content: "+mu.Lock()"}},
+		Context:   "fixes the race in batch uploader",
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[DEVELOPER CONTEXT]]") || !strings.Contains(result, "fixes the race in batch uploader") {
+		t.Errorf("Result should include the developer context:\n%s", result)
+	}
+
+	data.Context = ""
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[DEVELOPER CONTEXT]]") {
+		t.Errorf("Result should omit the context section when empty:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_SquashedCommits(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats:       &git.DiffStats{TotalFiles: 1},
+		Chunks:          []git.DiffChunk{{FilePath: "auth.go", Content: "+refresh()"}},
+		SquashedCommits: []string{"feat(auth): add token refresh\n\nRefresh before expiry.", "fix(auth): typo"},
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[SQUASHED COMMITS]]") || !strings.Contains(result, "Refresh before expiry.") || !strings.Contains(result, "fix(auth): typo") {
+		t.Errorf("Result should include the squashed commit messages:\n%s", result)
+	}
+
+	data.SquashedCommits = nil
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[SQUASHED COMMITS]]") {
+		t.Errorf("Result should omit the squashed commits section when empty:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_UnstagedFiles(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats:     &git.DiffStats{TotalFiles: 1},
+		Chunks:        []git.DiffChunk{{FilePath: "auth.go", Content: "+refresh()"}},
+		UnstagedFiles: []string{"modified: auth_test.go", "untracked: notes.md"},
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[UNSTAGED CHANGES - CONTEXT ONLY]]") || !strings.Contains(result, "- untracked: notes.md") {
+		t.Errorf("Result should include the unstaged files:\n%s", result)
+	}
+	if !strings.Contains(result, "NOT part of this commit") {
+		t.Errorf("Result should mark the unstaged files as context only:\n%s", result)
+	}
+
+	data.UnstagedFiles = nil
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[UNSTAGED CHANGES") {
+		t.Errorf("Result should omit the unstaged section when empty:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_SensitiveFiles(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats:      &git.DiffStats{TotalFiles: 1},
+		Chunks:         []git.DiffChunk{{FilePath: "auth/token.go", Content: "+check()"}},
+		SensitiveFiles: []string{"auth/token.go"},
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[SECURITY-SENSITIVE FILES]]") || !strings.Contains(result, "security impact") {
+		t.Errorf("Result should ask for the security impact of sensitive files:\n%s", result)
+	}
+
+	data.SensitiveFiles = nil
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[SECURITY-SENSITIVE FILES]]") {
+		t.Errorf("Result should omit the sensitive files section when empty:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_OwnerAreas(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats:  &git.DiffStats{TotalFiles: 1},
+		Chunks:     []git.DiffChunk{{FilePath: "services/payments/charge.go", Content: "+retry()"}},
+		OwnerAreas: []string{"payments (@acme/payments-team): 1 files"},
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[CODE OWNERSHIP]]") || !strings.Contains(result, "- payments (@acme/payments-team): 1 files") {
+		t.Errorf("Result should list the owner areas:\n%s", result)
+	}
+	if !strings.Contains(result, "body line naming the affected areas") {
+		t.Errorf("Result should ask for a body line without a scope:\n%s", result)
+	}
+
+	data.AreaScope = "payments"
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, `Use "payments" as the scope`) {
+		t.Errorf("Result should suggest the area as the scope:\n%s", result)
+	}
+
+	data.OwnerAreas, data.AreaScope = nil, ""
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[CODE OWNERSHIP]]") {
+		t.Errorf("Result should omit the ownership section when empty:\n%s", result)
+	}
+}
+
+func TestPromptTemplate_RenderUserPrompt_CommitTemplate(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	data := &PromptData{
+		DiffStats:      &git.DiffStats{TotalFiles: 1},
+		Chunks:         []git.DiffChunk{{FilePath: "auth.go", Content: "+refresh()"}},
+		CommitTemplate: "[JIRA-ID] Summary\n\n# Why was this change made?",
+	}
+
+	result, err := pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if !strings.Contains(result, "[[COMMIT TEMPLATE]]") || !strings.Contains(result, "# Why was this change made?") {
+		t.Errorf("Result should include the commit template:\n%s", result)
+	}
+
+	data.CommitTemplate = ""
+	result, err = pt.RenderUserPrompt(data)
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+	if strings.Contains(result, "[[COMMIT TEMPLATE]]") {
+		t.Errorf("Result should omit the template section when empty:\n%s", result)
+	}
 }
 
 func TestDefaultSystemPrompt_ContainsConventionalCommitsInstructions(t *testing.T) {
@@ -210,3 +472,35 @@ func TestDefaultSystemPrompt_ContainsConventionalCommitsInstructions(t *testing.
 		t.Error("System prompt should mention chore")
 	}
 }
+
+func TestPromptTemplate_RenderUserPrompt_StatsOnly(t *testing.T) {
+	pt := NewPromptTemplate()
+
+	req := &GenerateRequest{
+		DiffStats: &git.DiffStats{TotalFiles: 3, TotalAdditions: 12, TotalDeletions: 4},
+		DiffChunks: []git.DiffChunk{
+			{FilePath: "internal/auth/token.go", ChangeType: git.ChangeTypeModified, Additions: 12, Deletions: 4, Content: "+secret := \"hunter2\""},
+			{FilePath: "assets/logo.png", ChangeType: git.ChangeTypeAdded, IsBinary: true},
+			{FilePath: "pkg/new.go", OldPath: "pkg/old.go", ChangeType: git.ChangeTypeRenamed},
+		},
+		StatsOnly: true,
+	}
+
+	result, err := pt.RenderUserPrompt(BuildPromptData(req, false))
+	if err != nil {
+		t.Fatalf("RenderUserPrompt() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"- internal/auth/token.go (modified, +12 -4)",
+		"- assets/logo.png (added, binary)",
+		"- pkg/new.go (from pkg/old.go) (renamed, +0 -0)",
+	} {
+		if !strings.Contains(result, want) {
+			t.Errorf("Result should contain %q:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "hunter2") || strings.Contains(result, "--- File:") {
+		t.Errorf("Result should not contain diff content:\n%s", result)
+	}
+}
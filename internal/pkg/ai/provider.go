@@ -13,6 +13,64 @@ type GenerateRequest struct {
 	DiffStats       *git.DiffStats
 	CustomPrompt    string
 	PreviousAttempt string
+	// Preset controls the requested body detail; the zero value means PresetStandard.
+	Preset Preset
+	// RecentCommits are subjects of the latest commits on the branch, most recent first.
+	RecentCommits []string
+	// CommitTemplate is the repository's commit.template the message must follow.
+	CommitTemplate string
+	// Context is the developer's explanation of why the change was made.
+	Context string
+	// Intent is the commit type and scope the message must use, if any.
+	Intent Intent
+	// Examples are few-shot diff/message pairs showing the expected style.
+	Examples []Example
+	// SquashedCommits are the messages of the commits the message replaces
+	// in a squash merge, oldest first.
+	SquashedCommits []string
+	// Stack is a one-line summary of the repository's languages and
+	// frameworks, added to the system prompt.
+	Stack string
+	// SensitiveFiles are the changed files matching security-sensitive
+	// patterns, whose security impact the message must state.
+	SensitiveFiles []string
+	// OwnerAreas are the team areas owning the changed files, one
+	// "area (owners): N files" line each, from CODEOWNERS and the directory
+	// layout.
+	OwnerAreas []string
+	// AreaScope is the scope suggested by the single area owning every
+	// changed file; empty asks for a body line naming the areas instead.
+	AreaScope string
+	// UnstagedFiles summarize the unstaged and untracked files, one
+	// "status: path" line each. They are context only and not committed.
+	UnstagedFiles []string
+	// StatsOnly marks a request whose chunks carry no content, only their
+	// paths, change types and line counts, so that the prompt describes the
+	// change from those.
+	StatsOnly bool
+	// Temperature overrides the provider's configured temperature when non-zero.
+	Temperature float32
+	// Model overrides the provider's configured model when set.
+	Model string
+	// Redact, if set, rewrites the rendered prompts before they are sent,
+	// e.g. to hide local paths.
+	Redact func(string) string
+}
+
+// modelOr returns the request's model, or the configured one if not overridden.
+func (r *GenerateRequest) modelOr(configured string) string {
+	if r.Model != "" {
+		return r.Model
+	}
+	return configured
+}
+
+// temperatureOr returns the request's temperature, or the configured one if not overridden.
+func (r *GenerateRequest) temperatureOr(configured float32) float32 {
+	if r.Temperature != 0 {
+		return r.Temperature
+	}
+	return configured
 }
 
 // GenerateResponse contains the generated commit message.
@@ -21,6 +79,17 @@ type GenerateResponse struct {
 	Body    string
 	Footer  string
 	RawText string
+	// Usage is the token usage the provider reported; nil if it reports none.
+	Usage *Usage
+}
+
+// Usage is the token usage of a single request.
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	// CachedTokens are the prompt tokens the provider read from its prompt
+	// cache, which are billed at a discount.
+	CachedTokens int
 }
 
 // ProviderConfig contains configuration for an AI provider.
@@ -30,6 +99,26 @@ type ProviderConfig struct {
 	Endpoint    string
 	Temperature float32
 	MaxTokens   int
+	// Organization and Project are sent as the OpenAI-Organization and
+	// OpenAI-Project headers; User as the user of each request. Only the
+	// OpenAI provider uses them.
+	Organization string
+	Project      string
+	User         string
+	// ExtraHeaders are set on every HTTP request of the provider, e.g. the
+	// token of an API gateway.
+	ExtraHeaders map[string]string
+	// Ollama holds the options of Ollama models.
+	Ollama OllamaSettings
+}
+
+// OllamaSettings are options passed to Ollama models; zero values keep the
+// model's defaults.
+type OllamaSettings struct {
+	// NumCtx is the context window in tokens the model is run with.
+	NumCtx        int
+	TopP          float32
+	RepeatPenalty float32
 }
 
 // Provider defines the interface for AI providers.
@@ -0,0 +1,104 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// OllamaPullPath is the API path downloading a model.
+const OllamaPullPath = "/api/pull"
+
+// PullProgress is a status update while a model is downloaded, e.g.
+// "pulling manifest" or a layer being downloaded. Total and Completed are the
+// bytes of the current layer, zero when the status has none.
+type PullProgress struct {
+	Status    string `json:"status"`
+	Total     int64  `json:"total,omitempty"`
+	Completed int64  `json:"completed,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ModelPuller is implemented by providers that can download a model that is
+// not available yet.
+type ModelPuller interface {
+	// PullModelName returns the model PullModel downloads.
+	PullModelName() string
+	// PullModel downloads the configured model, calling progress with each
+	// status update. It blocks until the model is ready or ctx is cancelled.
+	PullModel(ctx context.Context, progress func(PullProgress)) error
+}
+
+// IsModelNotFound reports whether the error means the model has not been
+// pulled, so that pulling it would let generation succeed.
+func IsModelNotFound(err error) bool {
+	var apiErr *OllamaAPIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// PullModelName returns the configured model.
+func (p *OllamaProvider) PullModelName() string {
+	return p.config.Model
+}
+
+// PullModel pulls the configured model with Ollama, streaming the progress.
+func (p *OllamaProvider) PullModel(ctx context.Context, progress func(PullProgress)) error {
+	body, err := json.Marshal(map[string]any{"model": p.config.Model, "stream": true})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.Endpoint+OllamaPullPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// A model takes minutes to download, far beyond the request timeout;
+	// ctx still cancels it
+	client := &http.Client{Transport: p.httpClient.Transport}
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		return wrapOllamaAPIError(fmt.Errorf("failed to execute request: %w", err))
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return wrapOllamaAPIError(&OllamaAPIError{StatusCode: httpResp.StatusCode, Message: string(respBody)})
+	}
+
+	// The response is a stream of JSON status lines ending with "success"
+	scanner := bufio.NewScanner(httpResp.Body)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var update PullProgress
+		if err := json.Unmarshal(scanner.Bytes(), &update); err != nil {
+			return fmt.Errorf("failed to parse pull progress: %w", err)
+		}
+		if update.Error != "" {
+			return fmt.Errorf("failed to pull %s: %s", p.config.Model, update.Error)
+		}
+		if progress != nil {
+			progress(update)
+		}
+		if update.Status == "success" {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("failed to read pull progress: %w", err)
+	}
+	return fmt.Errorf("pull of %s ended before it completed", p.config.Model)
+}
@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOllamaProvider_PullModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != OllamaPullPath {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var req struct {
+			Model  string `json:"model"`
+			Stream bool   `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "qwen2.5-coder:7b" || !req.Stream {
+			t.Errorf("unexpected request %+v (%v)", req, err)
+		}
+		w.Write([]byte(`{"status":"pulling manifest"}` + "\n" +
+			`{"status":"pulling 6a0746a1ec1a","digest":"sha256:6a07","total":4000,"completed":1000}` + "\n" +
+			`{"status":"pulling 6a0746a1ec1a","digest":"sha256:6a07","total":4000,"completed":4000}` + "\n" +
+			`{"status":"success"}` + "\n"))
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(ProviderConfig{Endpoint: server.URL, Model: "qwen2.5-coder:7b"})
+	if err != nil {
+		t.Fatalf("NewOllamaProvider() error = %v", err)
+	}
+
+	var updates []PullProgress
+	if err := provider.PullModel(context.Background(), func(p PullProgress) { updates = append(updates, p) }); err != nil {
+		t.Fatalf("PullModel() error = %v", err)
+	}
+	if len(updates) != 4 || updates[1].Completed != 1000 || updates[1].Total != 4000 || updates[3].Status != "success" {
+		t.Errorf("PullModel() progress = %+v", updates)
+	}
+}
+
+func TestOllamaProvider_PullModel_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"unknown model", http.StatusOK, `{"status":"pulling manifest"}` + "\n" + `{"error":"pull model manifest: file does not exist"}`, "file does not exist"},
+		{"stream cut short", http.StatusOK, `{"status":"pulling manifest"}`, "ended before it completed"},
+		{"server error", http.StatusInternalServerError, "boom", "status 500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			provider, err := NewOllamaProvider(ProviderConfig{Endpoint: server.URL, Model: "nope"})
+			if err != nil {
+				t.Fatalf("NewOllamaProvider() error = %v", err)
+			}
+
+			err = provider.PullModel(context.Background(), nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PullModel() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsModelNotFound(t *testing.T) {
+	if !IsModelNotFound(wrapOllamaAPIError(&OllamaAPIError{StatusCode: http.StatusNotFound, Message: "model not found"})) {
+		t.Error("IsModelNotFound() = false for a 404")
+	}
+	if IsModelNotFound(wrapOllamaAPIError(&OllamaAPIError{StatusCode: http.StatusBadRequest})) {
+		t.Error("IsModelNotFound() = true for a 400")
+	}
+}
@@ -0,0 +1,174 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// RecordEnvVar is the environment variable that, set to a file path, records
+// every provider request and response to it as fixtures for replay tests.
+const RecordEnvVar = "GITSAGE_RECORD"
+
+// Exchange is a recorded provider call: the request, identified by its key,
+// and the response or error the provider returned.
+type Exchange struct {
+	Key string `json:"key"`
+	// Files are the paths in the request's diff, to tell exchanges apart.
+	Files []string `json:"files,omitempty"`
+	// Provider and Capabilities are those of the recorded provider, so that
+	// a replay makes the same decisions, e.g. on two-phase generation.
+	Provider     string            `json:"provider"`
+	Capabilities Capabilities      `json:"capabilities"`
+	Response     *GenerateResponse `json:"response,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// RequestKey identifies a request by everything that shapes its prompt, so
+// that a replay serves the response recorded for the same request.
+func RequestKey(req *GenerateRequest) string {
+	// Redact is a function and cannot be encoded; it does not change what
+	// the request asks for
+	data, err := json.Marshal(struct {
+		DiffChunks      []git.DiffChunk
+		CustomPrompt    string
+		PreviousAttempt string
+		Preset          Preset
+		RecentCommits   []string
+		CommitTemplate  string
+		Context         string
+		Intent          Intent
+		Examples        []Example
+		SquashedCommits []string
+		Stack           string
+		SensitiveFiles  []string
+		UnstagedFiles   []string
+		Temperature     float32
+		Model           string
+	}{
+		req.DiffChunks, req.CustomPrompt, req.PreviousAttempt, req.Preset, req.RecentCommits,
+		req.CommitTemplate, req.Context, req.Intent, req.Examples, req.SquashedCommits,
+		req.Stack, req.SensitiveFiles, req.UnstagedFiles, req.Temperature, req.Model,
+	})
+	if err != nil {
+		// Only plain data is encoded
+		panic(fmt.Sprintf("failed to encode request: %v", err))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Recorder passes requests to a provider and appends each exchange to a
+// fixture file, one JSON object per line.
+type Recorder struct {
+	Provider
+	path string
+	mu   sync.Mutex
+}
+
+// NewRecorder returns a Recorder appending the exchanges of provider to the
+// file at path.
+func NewRecorder(provider Provider, path string) *Recorder {
+	return &Recorder{Provider: provider, path: path}
+}
+
+// GenerateCommitMessage calls the provider and records the exchange. A
+// failure to record is only logged.
+func (r *Recorder) GenerateCommitMessage(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	resp, err := r.Provider.GenerateCommitMessage(ctx, req)
+
+	exchange := Exchange{
+		Key:          RequestKey(req),
+		Provider:     r.Provider.Name(),
+		Capabilities: CapabilitiesOf(r.Provider),
+		Response:     resp,
+	}
+	for _, chunk := range req.DiffChunks {
+		exchange.Files = append(exchange.Files, chunk.FilePath)
+	}
+	if err != nil {
+		exchange.Error = err.Error()
+	}
+	if recordErr := r.record(exchange); recordErr != nil {
+		apperrors.Warn("Failed to record the provider exchange to %s: %v", r.path, recordErr)
+	}
+	return resp, err
+}
+
+// record appends the exchange to the fixture file.
+func (r *Recorder) record(exchange Exchange) error {
+	data, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Capabilities returns the capabilities of the recorded provider.
+func (r *Recorder) Capabilities() Capabilities {
+	return CapabilitiesOf(r.Provider)
+}
+
+// HealthCheck checks the recorded provider, if it supports health checks.
+func (r *Recorder) HealthCheck(ctx context.Context) error {
+	if checker, ok := r.Provider.(HealthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// SetPromptTemplate sets the prompts of the recorded provider, if they can
+// be replaced.
+func (r *Recorder) SetPromptTemplate(pt *PromptTemplate) {
+	if setter, ok := r.Provider.(PromptTemplateSetter); ok {
+		setter.SetPromptTemplate(pt)
+	}
+}
+
+// ReadExchanges reads the exchanges recorded in the fixture file at path.
+func ReadExchanges(path string) ([]Exchange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open fixture: %w", err)
+	}
+	defer f.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(f)
+	// Responses and file lists can exceed the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &exchange); err != nil {
+			return nil, fmt.Errorf("invalid exchange on line %d of %s: %w", line, path, err)
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	return exchanges, nil
+}
@@ -0,0 +1,88 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// failingProvider is a stubProvider whose requests fail.
+type failingProvider struct {
+	stubProvider
+}
+
+func (p *failingProvider) GenerateCommitMessage(ctx context.Context, req *GenerateRequest) (*GenerateResponse, error) {
+	return nil, errors.New("rate limited")
+}
+
+func TestRequestKey(t *testing.T) {
+	req := &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "main.go", Content: "+x"}}, Context: "why"}
+	same := *req
+	same.Redact = func(s string) string { return s }
+	if RequestKey(req) != RequestKey(&same) {
+		t.Error("expected Redact not to change the key")
+	}
+
+	other := *req
+	other.PreviousAttempt = "feat: add x"
+	if RequestKey(req) == RequestKey(&other) {
+		t.Error("expected the previous attempt to change the key")
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.jsonl")
+	req := &GenerateRequest{DiffChunks: []git.DiffChunk{{FilePath: "main.go", Content: "+x"}}}
+
+	resp, err := NewRecorder(&stubProvider{}, path).GenerateCommitMessage(context.Background(), req)
+	if err != nil || resp.Subject != "chore: stub" {
+		t.Fatalf("GenerateCommitMessage() = %+v, %v", resp, err)
+	}
+	if _, err := NewRecorder(&failingProvider{}, path).GenerateCommitMessage(context.Background(), req); err == nil {
+		t.Fatal("expected the provider error")
+	}
+
+	exchanges, err := ReadExchanges(path)
+	if err != nil {
+		t.Fatalf("ReadExchanges() error = %v", err)
+	}
+	if len(exchanges) != 2 {
+		t.Fatalf("recorded %d exchanges, want 2", len(exchanges))
+	}
+	first := exchanges[0]
+	if first.Key != RequestKey(req) || first.Provider != "stub" || first.Response.Subject != "chore: stub" {
+		t.Errorf("unexpected exchange %+v", first)
+	}
+	if len(first.Files) != 1 || first.Files[0] != "main.go" {
+		t.Errorf("Files = %v, want [main.go]", first.Files)
+	}
+	if exchanges[1].Error != "rate limited" || exchanges[1].Response != nil {
+		t.Errorf("expected the error recorded, got %+v", exchanges[1])
+	}
+}
+
+func TestNewProvider_Record(t *testing.T) {
+	t.Setenv(RecordEnvVar, filepath.Join(t.TempDir(), "fixture.jsonl"))
+
+	provider, err := NewProvider(&config.ProviderConfig{
+		Name:   "openai",
+		Model:  "gpt-4o-mini",
+		APIKey: "sk-test-key-that-is-long-enough-for-validation",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if _, ok := provider.(*Recorder); !ok {
+		t.Fatalf("expected a Recorder with %s set, got %T", RecordEnvVar, provider)
+	}
+	if provider.Name() != "openai" {
+		t.Errorf("Name() = %q, want openai", provider.Name())
+	}
+	if CapabilitiesOf(provider).MaxContextTokens != 128000 {
+		t.Error("expected the capabilities of the recorded provider")
+	}
+}
@@ -0,0 +1,42 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"net/http"
+	"time"
+)
+
+// newTransport returns the pooled transport of the providers' HTTP clients,
+// adding the headers to every request when there are any.
+func newTransport(headers map[string]string) http.RoundTripper {
+	transport := &http.Transport{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		IdleConnTimeout:     90 * time.Second,
+	}
+	if len(headers) == 0 {
+		return transport
+	}
+
+	header := make(http.Header, len(headers))
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	return &headerTransport{base: transport, header: header}
+}
+
+// headerTransport sets extra headers, such as the token of an API gateway, on
+// every request. They replace headers of the same name the client sets.
+type headerTransport struct {
+	base   http.RoundTripper
+	header http.Header
+}
+
+// RoundTrip sends the request with the extra headers.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.header {
+		req.Header[name] = values
+	}
+	return t.base.RoundTrip(req)
+}
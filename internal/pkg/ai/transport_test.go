@@ -0,0 +1,77 @@
+package ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+func TestNewProvider_ExtraHeaders(t *testing.T) {
+	t.Setenv("GITSAGE_TEST_GATEWAY_TOKEN", "gw-secret")
+
+	for _, name := range []string{ProviderNameOpenAI, ProviderNameDeepSeek, ProviderNameOllama} {
+		t.Run(name, func(t *testing.T) {
+			var got http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Clone()
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"object":"list","data":[],"models":[{"name":"llama3:latest"}]}`))
+			}))
+			defer server.Close()
+
+			provider, err := NewProvider(&config.ProviderConfig{
+				Name:     name,
+				APIKey:   "sk-test-key-that-is-long-enough-for-validation",
+				Model:    "llama3",
+				Endpoint: server.URL,
+				ExtraHeaders: map[string]string{
+					"helicone-auth": "Bearer $GITSAGE_TEST_GATEWAY_TOKEN",
+					"X-Trace-Id":    "abc123",
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+
+			if err := provider.(HealthChecker).HealthCheck(context.Background()); err != nil {
+				t.Fatalf("HealthCheck() error = %v", err)
+			}
+			if v := got.Get("Helicone-Auth"); v != "Bearer gw-secret" {
+				t.Errorf("Helicone-Auth = %q, want %q", v, "Bearer gw-secret")
+			}
+			if v := got.Get("X-Trace-Id"); v != "abc123" {
+				t.Errorf("X-Trace-Id = %q, want %q", v, "abc123")
+			}
+		})
+	}
+}
+
+func TestNewOpenAIProvider_ExtraHeadersOverrideProject(t *testing.T) {
+	var project string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		project = r.Header.Get("OpenAI-Project")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"object":"list","data":[]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider(ProviderConfig{
+		APIKey:       "sk-test-key-that-is-long-enough-for-validation",
+		Endpoint:     server.URL,
+		Project:      "proj_config",
+		ExtraHeaders: map[string]string{"OpenAI-Project": "proj_header"},
+	})
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+
+	if err := provider.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("HealthCheck() error = %v", err)
+	}
+	if project != "proj_header" {
+		t.Errorf("OpenAI-Project = %q, want %q", project, "proj_header")
+	}
+}
@@ -0,0 +1,55 @@
+// Package ai provides AI provider interfaces and implementations for GitSage.
+package ai
+
+import (
+	"errors"
+	"fmt"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// OllamaMinContext is the smallest context window Ollama runs models with
+// by default. A prompt that fits in it is never truncated.
+const OllamaMinContext = 2048
+
+// ContextTruncatedError reports that the model evaluated only part of the
+// prompt because it did not fit the context window.
+type ContextTruncatedError struct {
+	// PromptTokens is the estimated size of the prompt.
+	PromptTokens int
+	// EvaluatedTokens is the number of prompt tokens the model saw.
+	EvaluatedTokens int
+}
+
+func (e *ContextTruncatedError) Error() string {
+	return fmt.Sprintf("prompt of about %d tokens was truncated to %d tokens by the model's context window",
+		e.PromptTokens, e.EvaluatedTokens)
+}
+
+// IsContextTruncated reports whether the error means the prompt did not fit
+// the model's context window, so that a smaller request could succeed.
+func IsContextTruncated(err error) bool {
+	var truncated *ContextTruncatedError
+	return errors.As(err, &truncated)
+}
+
+// checkTruncation compares the estimated size of a prompt with the number of
+// prompt tokens Ollama evaluated. Token estimates err on the low side for
+// code, so far fewer evaluated tokens than estimated means the prompt was
+// cut. Prompts that fit the context window with the reply are not checked,
+// since Ollama may skip evaluating a prefix it has cached.
+func (p *OllamaProvider) checkTruncation(promptTokens, evaluatedTokens int) error {
+	window := p.config.Ollama.NumCtx
+	if window <= 0 {
+		window = OllamaMinContext
+	}
+	if evaluatedTokens <= 0 || promptTokens+p.config.MaxTokens <= window || evaluatedTokens >= promptTokens*3/4 {
+		return nil
+	}
+
+	apperrors.Debug("Ollama evaluated %d of about %d prompt tokens", evaluatedTokens, promptTokens)
+	appErr := apperrors.Wrap(&ContextTruncatedError{PromptTokens: promptTokens, EvaluatedTokens: evaluatedTokens},
+		apperrors.ErrAIProviderFailed, "Ollama truncated the prompt")
+	appErr.WithSuggestion("Set provider.ollama.num_ctx to a larger context window")
+	return appErr
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestOllamaProvider_CheckTruncation(t *testing.T) {
+	tests := []struct {
+		name          string
+		numCtx        int
+		prompt        int
+		evaluated     int
+		wantTruncated bool
+	}{
+		{"fits the default window", 0, 1000, 200, false},
+		{"cut to the default window", 0, 6000, 2048, true},
+		{"all evaluated", 0, 6000, 7000, false},
+		{"no count reported", 0, 6000, 0, false},
+		{"fits the configured window", 32768, 6000, 1000, false},
+		{"cut to the configured window", 8192, 12000, 8192, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewOllamaProvider(ProviderConfig{Ollama: OllamaSettings{NumCtx: tt.numCtx}})
+			if err != nil {
+				t.Fatalf("NewOllamaProvider() error = %v", err)
+			}
+			err = provider.checkTruncation(tt.prompt, tt.evaluated)
+			if got := IsContextTruncated(err); got != tt.wantTruncated {
+				t.Errorf("checkTruncation(%d, %d) = %v, want truncated %v", tt.prompt, tt.evaluated, err, tt.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestOllamaProvider_GenerateCommitMessage_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OllamaChatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if req.Options == nil || req.Options.NumCtx != 4096 || req.Options.TopP != 0.9 || req.Options.RepeatPenalty != 1.1 {
+			t.Errorf("Options = %+v", req.Options)
+		}
+		json.NewEncoder(w).Encode(OllamaChatResponse{Message: OllamaMessage{Role: "assistant", Content: "feat: add handler"}, Done: true})
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(ProviderConfig{
+		Endpoint: server.URL,
+		Ollama:   OllamaSettings{NumCtx: 4096, TopP: 0.9, RepeatPenalty: 1.1},
+	})
+	if err != nil {
+		t.Fatalf("NewOllamaProvider() error = %v", err)
+	}
+	if got := provider.Capabilities().MaxContextTokens; got != 4096 {
+		t.Errorf("Capabilities().MaxContextTokens = %d, want num_ctx", got)
+	}
+
+	req := &GenerateRequest{
+		DiffChunks: []git.DiffChunk{{FilePath: "main.go", Content: "+func handler() {}"}},
+		DiffStats:  &git.DiffStats{TotalFiles: 1},
+	}
+	if _, err := provider.GenerateCommitMessage(context.Background(), req); err != nil {
+		t.Errorf("GenerateCommitMessage() error = %v", err)
+	}
+}
+
+func TestOllamaProvider_GenerateCommitMessage_Truncated(t *testing.T) {
+	var evaluated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OllamaChatResponse{
+			Message:         OllamaMessage{Role: "assistant", Content: "feat: add handler"},
+			Done:            true,
+			PromptEvalCount: evaluated,
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewOllamaProvider(ProviderConfig{Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllamaProvider() error = %v", err)
+	}
+
+	// A diff within the default budget, but beyond Ollama's default window
+	req := &GenerateRequest{
+		DiffChunks: []git.DiffChunk{{FilePath: "main.go", Content: strings.Repeat("x", 9*1024)}},
+		DiffStats:  &git.DiffStats{TotalFiles: 1},
+	}
+	evaluated = OllamaMinContext
+	if _, err := provider.GenerateCommitMessage(context.Background(), req); !IsContextTruncated(err) {
+		t.Errorf("GenerateCommitMessage() error = %v, want truncation", err)
+	}
+
+	evaluated = 4000
+	if _, err := provider.GenerateCommitMessage(context.Background(), req); err != nil {
+		t.Errorf("GenerateCommitMessage() error = %v", err)
+	}
+}
@@ -4,6 +4,7 @@ package cache
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"sync"
 	"time"
 )
@@ -19,6 +20,11 @@ const (
 type Entry struct {
 	Value     interface{}
 	ExpiresAt time.Time
+	CreatedAt time.Time
+	// Size is the size of the value encoded as JSON, in bytes.
+	Size int
+	// Hits is the number of times the entry was read.
+	Hits int
 }
 
 // IsExpired checks if the cache entry has expired.
@@ -33,6 +39,33 @@ type Manager interface {
 	Delete(key string)
 	Clear()
 	Size() int
+	Stats() Stats
+	Entries() []EntryInfo
+}
+
+// Stats summarizes the contents and use of a cache.
+type Stats struct {
+	Entries int
+	Hits    int
+	Misses  int
+	// Bytes is the total size of the cached values.
+	Bytes  int
+	Oldest time.Time
+	Newest time.Time
+}
+
+// HitRatio returns the share of lookups that found an entry, from 0 to 1.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// EntryInfo describes a cache entry.
+type EntryInfo struct {
+	Key string
+	Entry
 }
 
 // LRUCache implements an in-memory LRU cache with TTL support.
@@ -42,6 +75,8 @@ type LRUCache struct {
 	order      []string // Tracks access order for LRU eviction
 	maxEntries int
 	defaultTTL time.Duration
+	hits       int
+	misses     int
 }
 
 // NewLRUCache creates a new LRU cache with the specified configuration.
@@ -68,17 +103,21 @@ func (c *LRUCache) Get(key string) (interface{}, bool) {
 
 	entry, exists := c.entries[key]
 	if !exists {
+		c.misses++
 		return nil, false
 	}
 
 	// Check if expired
 	if entry.IsExpired() {
 		c.deleteUnlocked(key)
+		c.misses++
 		return nil, false
 	}
 
 	// Move to end of order (most recently used)
 	c.moveToEnd(key)
+	c.hits++
+	entry.Hits++
 
 	return entry.Value, true
 }
@@ -92,14 +131,18 @@ func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
 	if ttl <= 0 {
 		ttl = c.defaultTTL
 	}
+	now := time.Now()
+	entry := &Entry{
+		Value:     value,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+		Size:      valueSize(value),
+	}
 
 	// Check if key already exists
 	if _, exists := c.entries[key]; exists {
 		// Update existing entry
-		c.entries[key] = &Entry{
-			Value:     value,
-			ExpiresAt: time.Now().Add(ttl),
-		}
+		c.entries[key] = entry
 		c.moveToEnd(key)
 		return
 	}
@@ -110,10 +153,7 @@ func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
 	}
 
 	// Add new entry
-	c.entries[key] = &Entry{
-		Value:     value,
-		ExpiresAt: time.Now().Add(ttl),
-	}
+	c.entries[key] = entry
 	c.order = append(c.order, key)
 }
 
@@ -130,6 +170,7 @@ func (c *LRUCache) Clear() {
 	defer c.mu.Unlock()
 	c.entries = make(map[string]*Entry)
 	c.order = make([]string, 0, c.maxEntries)
+	c.hits, c.misses = 0, 0
 }
 
 // Size returns the number of entries in the cache.
@@ -139,6 +180,51 @@ func (c *LRUCache) Size() int {
 	return len(c.entries)
 }
 
+// Stats returns the number of entries, their size and age, and the hits and
+// misses of Get. Expired entries that were not removed yet are counted.
+func (c *LRUCache) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := Stats{Entries: len(c.entries), Hits: c.hits, Misses: c.misses}
+	for _, entry := range c.entries {
+		stats.Bytes += entry.Size
+		if stats.Oldest.IsZero() || entry.CreatedAt.Before(stats.Oldest) {
+			stats.Oldest = entry.CreatedAt
+		}
+		if entry.CreatedAt.After(stats.Newest) {
+			stats.Newest = entry.CreatedAt
+		}
+	}
+	return stats
+}
+
+// Entries returns the entries that have not expired, most recently used
+// first. Reading them does not count as a hit.
+func (c *LRUCache) Entries() []EntryInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entries := make([]EntryInfo, 0, len(c.order))
+	for i := len(c.order) - 1; i >= 0; i-- {
+		key := c.order[i]
+		if entry := c.entries[key]; !entry.IsExpired() {
+			entries = append(entries, EntryInfo{Key: key, Entry: *entry})
+		}
+	}
+	return entries
+}
+
+// valueSize returns the size of a value encoded as JSON, or 0 if it cannot
+// be encoded.
+func valueSize(value interface{}) int {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
 // deleteUnlocked removes an entry without acquiring the lock.
 // Caller must hold the lock.
 func (c *LRUCache) deleteUnlocked(key string) {
@@ -0,0 +1,117 @@
+// Package cache provides response caching for GitSage.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheFile is the on-disk form of an LRUCache.
+type cacheFile struct {
+	Hits    int         `json:"hits"`
+	Misses  int         `json:"misses"`
+	Entries []fileEntry `json:"entries"`
+}
+
+// fileEntry is a cache entry in the cache file.
+type fileEntry struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	CreatedAt time.Time       `json:"created_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Size      int             `json:"size"`
+	Hits      int             `json:"hits"`
+}
+
+// Load replaces the cache contents with the entries saved in the file at
+// path, leaving out expired ones. A missing file leaves the cache empty.
+// Loaded values are json.RawMessage; decode them into the type that was set.
+func (c *LRUCache) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache file: %w", err)
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse cache file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*Entry)
+	c.order = make([]string, 0, c.maxEntries)
+	c.hits, c.misses = file.Hits, file.Misses
+
+	// Entries are saved least recently used first; keep the most recent ones
+	now := time.Now()
+	for _, e := range file.Entries {
+		if now.After(e.ExpiresAt) {
+			continue
+		}
+		if _, exists := c.entries[e.Key]; exists {
+			c.removeFromOrder(e.Key)
+		} else if len(c.entries) >= c.maxEntries {
+			c.evictOldest()
+		}
+		c.entries[e.Key] = &Entry{
+			Value:     e.Value,
+			ExpiresAt: e.ExpiresAt,
+			CreatedAt: e.CreatedAt,
+			Size:      e.Size,
+			Hits:      e.Hits,
+		}
+		c.order = append(c.order, e.Key)
+	}
+	return nil
+}
+
+// Save writes the entries that have not expired and the hit counts to the
+// file at path, creating its directory if needed.
+func (c *LRUCache) Save(path string) error {
+	c.mu.RLock()
+	file := cacheFile{Hits: c.hits, Misses: c.misses, Entries: make([]fileEntry, 0, len(c.order))}
+	for _, key := range c.order {
+		entry := c.entries[key]
+		if entry.IsExpired() {
+			continue
+		}
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			c.mu.RUnlock()
+			return fmt.Errorf("failed to encode cache entry %s: %w", key, err)
+		}
+		file.Entries = append(file.Entries, fileEntry{
+			Key:       key,
+			Value:     value,
+			CreatedAt: entry.CreatedAt,
+			ExpiresAt: entry.ExpiresAt,
+			Size:      entry.Size,
+			Hits:      entry.Hits,
+		})
+	}
+	c.mu.RUnlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	// Cached messages describe the user's code: user read/write only
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_Stats(t *testing.T) {
+	cache := NewLRUCache(10, time.Hour)
+	cache.Set("key1", "value1", 0)
+	cache.Set("key2", "value22", 0)
+
+	cache.Get("key1")
+	cache.Get("key1")
+	cache.Get("missing")
+
+	stats := cache.Stats()
+	if stats.Entries != 2 || stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 2 entries, 2 hits and 1 miss", stats)
+	}
+	// Values are sized as JSON: "value1" and "value22"
+	if stats.Bytes != 17 {
+		t.Errorf("Stats().Bytes = %d, want 17", stats.Bytes)
+	}
+	if stats.Oldest.IsZero() || stats.Newest.Before(stats.Oldest) {
+		t.Errorf("Stats() oldest %v, newest %v", stats.Oldest, stats.Newest)
+	}
+	if ratio := stats.HitRatio(); ratio < 0.66 || ratio > 0.67 {
+		t.Errorf("HitRatio() = %v, want 2/3", ratio)
+	}
+	if (Stats{}).HitRatio() != 0 {
+		t.Error("HitRatio() without lookups should be 0")
+	}
+}
+
+func TestLRUCache_Entries(t *testing.T) {
+	cache := NewLRUCache(10, time.Hour)
+	cache.Set("key1", "value1", 0)
+	cache.Set("key2", "value2", 0)
+	cache.Set("expired", "value3", time.Nanosecond)
+	cache.Get("key1")
+	time.Sleep(time.Millisecond)
+
+	entries := cache.Entries()
+	if len(entries) != 2 || entries[0].Key != "key1" || entries[1].Key != "key2" {
+		t.Fatalf("Entries() = %+v, want key1 then key2", entries)
+	}
+	if entries[0].Hits != 1 {
+		t.Errorf("key1 hits = %d, want 1", entries[0].Hits)
+	}
+	if cache.Stats().Hits != 1 {
+		t.Error("Entries() should not count as hits")
+	}
+}
+
+func TestLRUCache_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "cache.json")
+
+	cache := NewLRUCache(10, time.Hour)
+	cache.Set("old", map[string]string{"Subject": "fix: old"}, 0)
+	cache.Set("new", map[string]string{"Subject": "feat: new"}, 0)
+	cache.Get("old")
+	cache.Get("missing")
+	if err := cache.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// A smaller cache keeps the most recently used entries
+	loaded := NewLRUCache(1, time.Hour)
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if stats := loaded.Stats(); stats.Entries != 1 || stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("loaded Stats() = %+v, want 1 entry, 1 hit and 1 miss", stats)
+	}
+	value, ok := loaded.Get("old")
+	if !ok {
+		t.Fatal("expected the most recently used entry to be loaded")
+	}
+	var response struct{ Subject string }
+	if err := json.Unmarshal(value.(json.RawMessage), &response); err != nil || response.Subject != "fix: old" {
+		t.Errorf("loaded value = %s, %v; want subject fix: old", value, err)
+	}
+
+	if err := NewLRUCache(10, time.Hour).Load(filepath.Join(t.TempDir(), "none.json")); err != nil {
+		t.Errorf("Load() of a missing file error = %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyCmdTimeout is how long api_key_cmd may run, e.g. while a password
+// manager asks to be unlocked.
+const APIKeyCmdTimeout = 60 * time.Second
+
+// resolvedKeys caches the output of api_key_cmd by command, so that it runs
+// once per process even when several providers are created.
+var resolvedKeys sync.Map
+
+// HasAPIKeySource reports whether the API key is read from api_key_cmd or
+// api_key_file instead of being set directly.
+func (p *ProviderConfig) HasAPIKeySource() bool {
+	return p.APIKey == "" && (p.APIKeyCmd != "" || p.APIKeyFile != "")
+}
+
+// ResolveAPIKey returns the API key: api_key if set, otherwise the output of
+// api_key_cmd, otherwise the contents of api_key_file, with surrounding
+// whitespace trimmed. It returns an empty key if none of them is set.
+func (p *ProviderConfig) ResolveAPIKey() (string, error) {
+	switch {
+	case p.APIKey != "":
+		return p.APIKey, nil
+	case p.APIKeyCmd != "":
+		if key, ok := resolvedKeys.Load(p.APIKeyCmd); ok {
+			return key.(string), nil
+		}
+		key, err := runAPIKeyCmd(p.APIKeyCmd)
+		if err != nil {
+			return "", err
+		}
+		resolvedKeys.Store(p.APIKeyCmd, key)
+		return key, nil
+	case p.APIKeyFile != "":
+		return readAPIKeyFile(p.APIKeyFile)
+	default:
+		return "", nil
+	}
+}
+
+// runAPIKeyCmd runs command with the shell and returns its trimmed output.
+// The password manager may prompt on the terminal, so stdin and stderr are
+// passed through.
+func runAPIKeyCmd(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), APIKeyCmdTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	// The command itself is left out of errors: it may contain a secret
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("provider.api_key_cmd timed out after %v", APIKeyCmdTimeout)
+		}
+		return "", fmt.Errorf("provider.api_key_cmd failed: %w", err)
+	}
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		return "", fmt.Errorf("provider.api_key_cmd printed no API key")
+	}
+	return key, nil
+}
+
+// readAPIKeyFile reads the API key from path; a leading ~ is the home directory.
+func readAPIKeyFile(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(homeDir, rest)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read provider.api_key_file: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("provider.api_key_file %s is empty", path)
+	}
+	return key, nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestResolveAPIKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "openai.key")
+	if err := os.WriteFile(keyFile, []byte("sk-from-file\n"), 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cfg  ProviderConfig
+		want string
+		sh   bool
+	}{
+		{"api_key wins", ProviderConfig{APIKey: "sk-direct", APIKeyCmd: "echo sk-cmd", APIKeyFile: keyFile}, "sk-direct", false},
+		{"cmd wins over file", ProviderConfig{APIKeyCmd: "echo '  sk-from-cmd  '", APIKeyFile: keyFile}, "sk-from-cmd", true},
+		{"file", ProviderConfig{APIKeyFile: keyFile}, "sk-from-file", false},
+		{"none", ProviderConfig{}, "", false},
+	}
+
+	for _, tt := range tests {
+		if tt.sh && runtime.GOOS == "windows" {
+			continue
+		}
+		got, err := tt.cfg.ResolveAPIKey()
+		if err != nil {
+			t.Errorf("%s: ResolveAPIKey() error = %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: ResolveAPIKey() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAPIKey_Errors(t *testing.T) {
+	emptyFile := filepath.Join(t.TempDir(), "empty.key")
+	if err := os.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+
+	cfgs := []ProviderConfig{
+		{APIKeyFile: emptyFile},
+		{APIKeyFile: filepath.Join(t.TempDir(), "missing.key")},
+	}
+	if runtime.GOOS != "windows" {
+		cfgs = append(cfgs, ProviderConfig{APIKeyCmd: "exit 3"}, ProviderConfig{APIKeyCmd: "true"})
+	}
+
+	for _, cfg := range cfgs {
+		if _, err := cfg.ResolveAPIKey(); err == nil {
+			t.Errorf("ResolveAPIKey() with %+v should fail", cfg)
+		}
+	}
+}
+
+func TestResolveAPIKey_CmdErrorHidesCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	cfg := ProviderConfig{APIKeyCmd: "false --token=secret-value"}
+	_, err := cfg.ResolveAPIKey()
+	if err == nil || strings.Contains(err.Error(), "secret-value") {
+		t.Errorf("ResolveAPIKey() error = %v, want an error without the command", err)
+	}
+}
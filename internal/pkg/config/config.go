@@ -3,12 +3,105 @@ package config
 
 // Config represents the complete GitSage configuration.
 type Config struct {
-	Provider ProviderConfig `mapstructure:"provider"`
-	Git      GitConfig      `mapstructure:"git"`
-	UI       UIConfig       `mapstructure:"ui"`
-	History  HistoryConfig  `mapstructure:"history"`
-	Security SecurityConfig `mapstructure:"security"`
-	Cache    CacheConfig    `mapstructure:"cache"`
+	Provider   ProviderConfig   `mapstructure:"provider"`
+	Generation GenerationConfig `mapstructure:"generation"`
+	Git        GitConfig        `mapstructure:"git"`
+	UI         UIConfig         `mapstructure:"ui"`
+	History    HistoryConfig    `mapstructure:"history"`
+	Security   SecurityConfig   `mapstructure:"security"`
+	Privacy    PrivacyConfig    `mapstructure:"privacy"`
+	Cache      CacheConfig      `mapstructure:"cache"`
+	Report     ReportConfig     `mapstructure:"report"`
+	Issues     IssuesConfig     `mapstructure:"issues"`
+	Budget     BudgetConfig     `mapstructure:"budget"`
+	Risk       RiskConfig       `mapstructure:"risk"`
+	Hooks      HooksConfig      `mapstructure:"hooks"`
+}
+
+// GenerationConfig contains commit message generation settings.
+type GenerationConfig struct {
+	// Preset controls the message detail: "minimal" (subject only), "standard"
+	// (subject and a short body) or "detailed" (full body with one bullet per module).
+	Preset string `mapstructure:"preset"`
+	// RecentCommits is the number of recent commit subjects on the current branch
+	// included in the prompt for consistency. Zero disables it.
+	RecentCommits int `mapstructure:"recent_commits"`
+	// Verify enables a critic pass that checks the message against the diff.
+	Verify bool `mapstructure:"verify"`
+	// VerifyModel is the model used by the critic; empty uses provider.model.
+	VerifyModel string `mapstructure:"verify_model"`
+	// CommitTemplate makes generated messages follow git's commit.template
+	// when one is configured.
+	CommitTemplate bool `mapstructure:"commit_template"`
+	// FewShot controls when Examples are added to the prompt: "auto" (local
+	// models only), "always" or "never".
+	FewShot string `mapstructure:"few_shot"`
+	// FewShotMaxBytes caps the size of the examples added to the prompt.
+	FewShotMaxBytes int `mapstructure:"few_shot_max_bytes"`
+	// Examples are few-shot pairs of a diff snippet and its ideal commit message.
+	// Examples in the repository's RepoExamplesFile are added after these.
+	Examples []Example `mapstructure:"examples"`
+	// DetectStack adds the repository's languages and frameworks, detected
+	// from its manifests, to the system prompt.
+	DetectStack bool `mapstructure:"detect_stack"`
+	// IncludeUnstagedContext lists the unstaged and untracked files in the
+	// prompt as context only, so the AI understands work in progress without
+	// describing it. It never changes what is committed.
+	IncludeUnstagedContext bool `mapstructure:"include_unstaged_context"`
+	// CheckAccuracy cross-checks the modules named by the body bullets against
+	// the changed paths and shows an accuracy score before the commit.
+	CheckAccuracy bool `mapstructure:"check_accuracy"`
+	// TranslateTo is the language the Translate action translates messages
+	// into, e.g. "English"; empty disables translation.
+	TranslateTo string `mapstructure:"translate_to"`
+	// FooterTemplate is added to the footer of every message, with {branch},
+	// {date} and {version-from-file:<path>} resolved when committing.
+	FooterTemplate string `mapstructure:"footer_template"`
+	// Regenerate controls how regenerated messages are made to differ.
+	Regenerate RegenerateConfig `mapstructure:"regenerate"`
+	// DuplicateCheck handles subjects repeating one of the recent commits:
+	// "warn", "regenerate" (retry once with feedback, then warn) or "off".
+	DuplicateCheck string `mapstructure:"duplicate_check"`
+	// Score grades accepted messages for the history: "heuristic" (local
+	// checks), "model" (asks the provider, falling back to the checks) or "off".
+	Score string `mapstructure:"score"`
+	// GroupFailure handles file groups that fail to summarize in two-phase
+	// generation: "list" (list the files), "split" (retry in smaller groups,
+	// then list), "skip" (count the files in a note) or "abort".
+	GroupFailure string `mapstructure:"group_failure"`
+	// StatsOnly sends only the changed paths, change types and line counts
+	// to the AI, never the diff content.
+	StatsOnly bool `mapstructure:"stats_only"`
+	// Ownership tells the AI which team areas own the changed files, from
+	// CODEOWNERS and the directory layout: "context" (a body line naming
+	// the areas), "scope" (the area as the scope when it owns every file,
+	// else context) or "off".
+	Ownership string `mapstructure:"ownership"`
+	// ScopeRules map monorepo directories to commit scopes; "commit --split"
+	// makes one commit per matched directory.
+	ScopeRules []ScopeRule `mapstructure:"scope_rules"`
+}
+
+// ScopeRule maps the files under a directory to a commit scope.
+type ScopeRule struct {
+	// Path is the directory relative to the repository root. Each segment may
+	// be a glob, e.g. "packages/*" matches every directory under packages.
+	Path string `mapstructure:"path"`
+	// Scope is the commit scope for the directory; empty uses its name.
+	Scope string `mapstructure:"scope"`
+}
+
+// RegenerateConfig is the escalation schedule applied when the user regenerates
+// a message, so repeated attempts do not come back nearly identical.
+type RegenerateConfig struct {
+	// TemperatureStep is added to provider.temperature on each regeneration.
+	TemperatureStep float32 `mapstructure:"temperature_step"`
+	// MaxTemperature caps the escalated temperature.
+	MaxTemperature float32 `mapstructure:"max_temperature"`
+	// Model is used from regeneration ModelAfter on; empty keeps provider.model.
+	Model string `mapstructure:"model"`
+	// ModelAfter is the number of regenerations after which Model is used.
+	ModelAfter int `mapstructure:"model_after"`
 }
 
 // CacheConfig contains cache-related settings.
@@ -16,6 +109,9 @@ type CacheConfig struct {
 	Enabled    bool `mapstructure:"enabled"`
 	MaxEntries int  `mapstructure:"max_entries"`
 	TTLMinutes int  `mapstructure:"ttl_minutes"`
+	// FilePath is where responses are kept between runs; empty keeps them
+	// in memory for the run only.
+	FilePath string `mapstructure:"file_path"`
 }
 
 // SecurityConfig contains security-related settings.
@@ -24,6 +120,15 @@ type SecurityConfig struct {
 	WarningAcknowledged bool `mapstructure:"warning_acknowledged"`
 	// PathCheckDone indicates if the PATH check has been performed.
 	PathCheckDone bool `mapstructure:"path_check_done"`
+	// SensitiveCheck flags changes to security-sensitive files: the message
+	// must state their security impact and committing needs confirmation.
+	SensitiveCheck bool `mapstructure:"sensitive_check"`
+	// SensitivePatterns are extra path globs of security-sensitive files, in
+	// addition to auth and crypto code, Dockerfiles, CI workflows and IAM policies.
+	SensitivePatterns []string `mapstructure:"sensitive_patterns"`
+	// RedactPaths replaces the repository root with "." and home
+	// directories with "~" in everything sent to the AI provider.
+	RedactPaths bool `mapstructure:"redact_paths"`
 }
 
 // ProviderConfig contains AI provider settings.
@@ -34,12 +139,98 @@ type ProviderConfig struct {
 	Endpoint    string  `mapstructure:"endpoint"`
 	Temperature float32 `mapstructure:"temperature"`
 	MaxTokens   int     `mapstructure:"max_tokens"`
+	// HealthCheck pings the provider before the first generation so an
+	// unreachable provider fails fast instead of after the retry cycle.
+	HealthCheck bool `mapstructure:"health_check"`
+	// APIKeyCmd is a command whose output is the API key, e.g.
+	// "op read op://dev/openai/key", used when APIKey is empty.
+	APIKeyCmd string `mapstructure:"api_key_cmd"`
+	// APIKeyFile is a file holding the API key, used when APIKey and
+	// APIKeyCmd are empty.
+	APIKeyFile string `mapstructure:"api_key_file"`
+	// Organization and Project scope OpenAI requests for keys that belong to
+	// several organizations or projects.
+	Organization string `mapstructure:"organization"`
+	Project      string `mapstructure:"project"`
+	// User identifies the end user in OpenAI requests, for audit logs.
+	User string `mapstructure:"user"`
+	// ExtraHeaders are set on every HTTP request to the provider, e.g. the
+	// token of an API gateway. Values may reference environment variables
+	// as $VAR or ${VAR}.
+	ExtraHeaders map[string]string `mapstructure:"extra_headers"`
+	// Ollama holds options passed to Ollama models.
+	Ollama OllamaConfig `mapstructure:"ollama"`
+}
+
+// OllamaConfig contains Ollama model options; zero values keep the model's
+// defaults.
+type OllamaConfig struct {
+	// NumCtx is the context window in tokens the model is run with. Ollama
+	// silently cuts prompts longer than it, so diffs that do not fit are
+	// summarized per file group first.
+	NumCtx int `mapstructure:"num_ctx"`
+	// TopP limits sampling to the most likely tokens (nucleus sampling).
+	TopP float32 `mapstructure:"top_p"`
+	// RepeatPenalty penalizes repeated tokens, e.g. 1.1.
+	RepeatPenalty float32 `mapstructure:"repeat_penalty"`
+}
+
+// PrivacyConfig contains settings limiting where diffs are sent.
+type PrivacyConfig struct {
+	// LocalOnly refuses providers whose endpoint is not on this machine or a
+	// private network. The repository's RepoPrivacyFile overrides it.
+	LocalOnly bool `mapstructure:"local_only"`
 }
 
 // GitConfig contains Git-related settings.
 type GitConfig struct {
 	DiffSizeThreshold int      `mapstructure:"diff_size_threshold"`
 	ExcludePatterns   []string `mapstructure:"exclude_patterns"`
+	// MaxDiffMemory caps the staged diff content (in bytes) kept in memory.
+	MaxDiffMemory int `mapstructure:"max_diff_memory"`
+	// CommandTimeout is the timeout (in seconds) for quick git commands; diff,
+	// status, add and commit get several times as long.
+	CommandTimeout int `mapstructure:"command_timeout"`
+	// SummarizeLockFiles sends a one-line package summary of lock file changes
+	// to the AI instead of leaving lock files out.
+	SummarizeLockFiles bool `mapstructure:"summarize_lock_files"`
+	// GeneratedPatterns are extra path globs of generated files, which are sent
+	// to the AI as a one-line summary. Common generated files (protobuf stubs,
+	// mocks, swagger docs) and files with a "Code generated by" header are
+	// detected without configuration.
+	GeneratedPatterns []string `mapstructure:"generated_patterns"`
+	// FormattingOnly handles files whose changes are whitespace only:
+	// "summarize" (send a one-line note), "exclude" or "keep".
+	FormattingOnly string `mapstructure:"formatting_only"`
+	// Minify strips diff noise before the diff is sent to the AI.
+	Minify MinifyConfig `mapstructure:"minify"`
+	// PathWeights order the files sent to the AI, so that the important ones
+	// come first and survive when the diff is trimmed.
+	PathWeights []PathWeight `mapstructure:"path_weights"`
+}
+
+// PathWeight gives the files matching a path glob a weight.
+type PathWeight struct {
+	// Path is a glob such as "internal/**" or "*.md"; the first matching
+	// rule applies.
+	Path string `mapstructure:"path"`
+	// Weight is "high", "normal" or "low".
+	Weight string `mapstructure:"weight"`
+}
+
+// MinifyConfig controls the minification of diffs sent to the AI, which
+// reduces the tokens used per request.
+type MinifyConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// ContextLines is the number of unchanged lines kept around each change;
+	// -1 keeps all the context git produced.
+	ContextLines int `mapstructure:"context_lines"`
+	// DropWhitespaceHunks leaves out hunks that only change whitespace.
+	DropWhitespaceHunks bool `mapstructure:"drop_whitespace_hunks"`
+	// VendorPatterns are extra path globs of vendored files, which are sent as
+	// a one-line summary. vendor/, node_modules/ and third_party/ are vendored
+	// without configuration.
+	VendorPatterns []string `mapstructure:"vendor_patterns"`
 }
 
 // UIConfig contains UI-related settings.
@@ -47,6 +238,88 @@ type UIConfig struct {
 	Editor       string `mapstructure:"editor"`
 	ColorEnabled bool   `mapstructure:"color_enabled"`
 	SpinnerStyle string `mapstructure:"spinner_style"`
+	// KeyBindings overrides prompt key bindings by name (e.g. accept: ["a"]).
+	KeyBindings map[string][]string `mapstructure:"keybindings"`
+	// VimMode enables vim-style modal editing in the inline editor.
+	VimMode bool `mapstructure:"vim_mode"`
+	// Accessible replaces animated widgets with numbered line prompts for screen readers.
+	Accessible bool `mapstructure:"accessible"`
+	// Language selects the UI language: "auto" (from the locale), "en" or "zh".
+	Language string `mapstructure:"language"`
+	// NotifyAfterSeconds sends a desktop notification when generating a
+	// message took at least this long, e.g. with a slow local model. Zero
+	// disables it.
+	NotifyAfterSeconds int `mapstructure:"notify_after_seconds"`
+	// CommitPreview shows the commit as "git log -1 --stat" will show it
+	// and asks for confirmation before committing.
+	CommitPreview bool `mapstructure:"commit_preview"`
+}
+
+// ReportConfig contains work report settings.
+type ReportConfig struct {
+	// Repos are the paths of the repositories "gitsage report" gathers
+	// commits from; empty uses the current repository.
+	Repos []string `mapstructure:"repos"`
+}
+
+// IssuesConfig contains the settings of the footer referencing the issue a
+// commit is for, given with --issue or found in the branch name.
+type IssuesConfig struct {
+	// BranchPattern is a regular expression finding the issue in the branch
+	// name, in its first capture group if it has one, e.g. `^\w+/(\d+)-`;
+	// empty leaves the issue to --issue.
+	BranchPattern string `mapstructure:"branch_pattern"`
+	// Platform is the hosting platform whose linking syntax the footer uses:
+	// "auto" (detected from the origin remote), "github", "gitlab", "gitea"
+	// or "bitbucket".
+	Platform string `mapstructure:"platform"`
+	// Templates override the footer of a platform, or of an unrecognized
+	// one with the "default" key; {issue} is replaced with the issue.
+	Templates map[string]string `mapstructure:"templates"`
+}
+
+// BudgetConfig contains the pre-flight guard against sending a huge diff to
+// the provider. Above any of the limits the estimate of the requests is
+// shown and the user confirms before the first one is sent; zero disables a limit.
+type BudgetConfig struct {
+	// MaxRequests is the number of API requests, counting the two-phase summaries.
+	MaxRequests int `mapstructure:"max_requests"`
+	// MaxTokens is the estimated number of input tokens.
+	MaxTokens int `mapstructure:"max_tokens"`
+	// MaxCost is the estimated cost in USD, for providers with known prices.
+	MaxCost float64 `mapstructure:"max_cost"`
+	// ShowEstimate shows the estimate before every generation, even within budget.
+	ShowEstimate bool `mapstructure:"show_estimate"`
+}
+
+// RiskConfig contains the estimate of how risky a commit is to review, shown
+// with the generated message so reviewers can triage. The size of the change,
+// critical files and missing tests raise the level; zero disables a threshold.
+type RiskConfig struct {
+	// Enabled shows the risk level and what raised it with the message.
+	Enabled bool `mapstructure:"enabled"`
+	// Footer adds a "Risk: <level>" footer to accepted messages.
+	Footer bool `mapstructure:"footer"`
+	// MediumLines and HighLines are the added and deleted lines from which
+	// the size of the change raises the risk by one or two levels.
+	MediumLines int `mapstructure:"medium_lines"`
+	HighLines   int `mapstructure:"high_lines"`
+	// MediumFiles and HighFiles are the changed files from which the
+	// number of files raises the risk by one or two levels.
+	MediumFiles int `mapstructure:"medium_files"`
+	HighFiles   int `mapstructure:"high_files"`
+	// CriticalPaths are path globs of critical files, in addition to the
+	// security-sensitive ones; changing any raises the risk.
+	CriticalPaths []string `mapstructure:"critical_paths"`
+}
+
+// HooksConfig contains the commands gitsage runs around its own workflow,
+// e.g. to send notifications without wrapping the CLI.
+type HooksConfig struct {
+	// PostCommit are shell commands run from the repository root after each
+	// successful commit. The placeholders {sha}, {short_sha}, {subject},
+	// {message} and {branch} are replaced with shell-quoted values.
+	PostCommit []string `mapstructure:"post_commit"`
 }
 
 // HistoryConfig contains history-related settings.
@@ -54,6 +327,9 @@ type HistoryConfig struct {
 	Enabled    bool   `mapstructure:"enabled"`
 	MaxEntries int    `mapstructure:"max_entries"`
 	FilePath   string `mapstructure:"file_path"`
+	// KeepTranslation saves a translation of each accepted message into
+	// generation.translate_to along with it.
+	KeepTranslation bool `mapstructure:"keep_translation"`
 }
 
 // Manager defines the interface for configuration management.
@@ -0,0 +1,47 @@
+// Package config provides configuration management for GitSage.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// RepoExamplesFile is the repository-level file with few-shot examples, at
+// the root of the work tree. It uses the same format as generation.examples:
+//
+//	examples:
+//	  - diff: |
+//	      +func (c *Client) Retry() error {
+//	    message: "feat(client): add retry support"
+const RepoExamplesFile = ".gitsage-examples.yaml"
+
+// Example is a few-shot pair of a diff snippet and its ideal commit message.
+type Example struct {
+	Diff    string `mapstructure:"diff"`
+	Message string `mapstructure:"message"`
+}
+
+// LoadExamplesFile reads the examples from a file in the RepoExamplesFile
+// format. A missing file yields no examples.
+func LoadExamplesFile(path string) ([]Example, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read examples file: %w", err)
+	}
+
+	var file struct {
+		Examples []Example `mapstructure:"examples"`
+	}
+	if err := v.Unmarshal(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse examples file: %w", err)
+	}
+	return file.Examples, nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadExamplesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, RepoExamplesFile)
+
+	examples, err := LoadExamplesFile(path)
+	if err != nil || examples != nil {
+		t.Fatalf("missing file: got %v, %v", examples, err)
+	}
+
+	content := `examples:
+  - diff: |
+      +func (c *Client) Retry() error {
+    message: "feat(client): add retry support"
+  - diff: "-legacy()"
+    message: "refactor: drop legacy path"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	examples, err = LoadExamplesFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(examples) != 2 {
+		t.Fatalf("got %d examples, want 2", len(examples))
+	}
+	if examples[0].Diff != "+func (c *Client) Retry() error {\n" || examples[0].Message != "feat(client): add retry support" {
+		t.Errorf("unexpected first example %+v", examples[0])
+	}
+
+	if err := os.WriteFile(path, []byte("examples: [unclosed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadExamplesFile(path); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Configuration sources, from the highest priority to the lowest.
+const (
+	SourceFlag    = "flag"
+	SourceEnv     = "env"
+	SourceProfile = "profile"
+	SourceFile    = "file"
+	SourceDefault = "default"
+)
+
+// Layer is what one configuration source gives a key.
+type Layer struct {
+	Source string
+	// Origin names the source: the environment variable, the profile section
+	// or the config file path. It is empty for flags and defaults.
+	Origin string
+	// Set reports whether the source sets the key.
+	Set   bool
+	Value interface{}
+}
+
+// Explanation is the value of a configuration key in each source, highest
+// priority first.
+type Explanation struct {
+	Key    string
+	Layers []Layer
+}
+
+// Effective returns the layer whose value is used: the first one that sets
+// the key.
+func (e *Explanation) Effective() Layer {
+	for _, layer := range e.Layers {
+		if layer.Set {
+			return layer
+		}
+	}
+	return Layer{}
+}
+
+// EnvVarName returns the environment variable that sets a configuration key,
+// e.g. GITSAGE_PROVIDER_MODEL for provider.model.
+func EnvVarName(key string) string {
+	return "GITSAGE_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// Explain reports the value of a configuration key in each source: flag
+// overrides set with SetOverride, the environment, the selected profile, the
+// config file and the defaults. Sections and unknown keys are rejected.
+func (m *ViperManager) Explain(key string) (*Explanation, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+
+	file := viper.New()
+	file.SetConfigType(DefaultConfigFileExt)
+	file.SetConfigFile(m.configPath)
+	if err := file.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	defaults := viper.New()
+	setDefaults(defaults)
+
+	if !defaults.IsSet(key) && !file.IsSet(key) {
+		return nil, fmt.Errorf("unknown config key %q", key)
+	}
+	if _, ok := defaults.Get(key).(map[string]interface{}); ok {
+		return nil, fmt.Errorf("%q is a section; explain one of its keys, e.g. %s.%s", key, key, firstKey(defaults.GetStringMap(key)))
+	}
+
+	override, overridden := m.overrides[key]
+	envName := EnvVarName(key)
+	// Like Viper, an empty variable does not count as set
+	envValue := os.Getenv(envName)
+
+	layers := []Layer{
+		{Source: SourceFlag, Set: overridden, Value: override},
+		{Source: SourceEnv, Origin: envName, Set: envValue != "", Value: envValue},
+	}
+	if m.profile != "" {
+		section := "profiles." + strings.ToLower(m.profile)
+		if !file.IsSet(section) {
+			return nil, fmt.Errorf("profile %q not found in %s", m.profile, m.configPath)
+		}
+		profileKey := section + "." + key
+		layers = append(layers, Layer{Source: SourceProfile, Origin: section, Set: file.IsSet(profileKey), Value: file.Get(profileKey)})
+	}
+	layers = append(layers,
+		Layer{Source: SourceFile, Origin: m.configPath, Set: file.IsSet(key), Value: file.Get(key)},
+		Layer{Source: SourceDefault, Set: defaults.IsSet(key), Value: defaults.Get(key)},
+	)
+
+	return &Explanation{Key: key, Layers: layers}, nil
+}
+
+// firstKey returns the alphabetically first key of a section.
+func firstKey(section map[string]interface{}) string {
+	first := ""
+	for key := range section {
+		if first == "" || key < first {
+			first = key
+		}
+	}
+	return first
+}
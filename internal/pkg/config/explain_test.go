@@ -0,0 +1,70 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("provider:\n  model: file-model\n  name: deepseek\n"), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	t.Setenv("GITSAGE_PROVIDER_MODEL", "env-model")
+
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	explanation, err := mgr.Explain("provider.model")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	want := []Layer{
+		{Source: SourceFlag},
+		{Source: SourceEnv, Origin: "GITSAGE_PROVIDER_MODEL", Set: true, Value: "env-model"},
+		{Source: SourceFile, Origin: configPath, Set: true, Value: "file-model"},
+		{Source: SourceDefault, Set: true, Value: "gpt-4o-mini"},
+	}
+	if len(explanation.Layers) != len(want) {
+		t.Fatalf("Explain() layers = %+v, want %+v", explanation.Layers, want)
+	}
+	for i, layer := range explanation.Layers {
+		if layer != want[i] {
+			t.Errorf("layer %d = %+v, want %+v", i, layer, want[i])
+		}
+	}
+	if got := explanation.Effective(); got.Source != SourceEnv {
+		t.Errorf("Effective() = %+v, want the environment", got)
+	}
+
+	// A flag override wins over everything
+	mgr.SetOverride("provider.model", "flag-model")
+	explanation, _ = mgr.Explain("Provider.Model")
+	if got := explanation.Effective(); got.Source != SourceFlag || got.Value != "flag-model" {
+		t.Errorf("Effective() with override = %+v, want the flag", got)
+	}
+
+	// A key set in the file only shadows the default
+	explanation, _ = mgr.Explain("provider.name")
+	if got := explanation.Effective(); got.Source != SourceFile || got.Value != "deepseek" {
+		t.Errorf("Effective() of provider.name = %+v, want the file", got)
+	}
+
+	if _, err := mgr.Explain("provider.unknown"); err == nil || !strings.Contains(err.Error(), "unknown config key") {
+		t.Errorf("Explain() of an unknown key error = %v", err)
+	}
+	if _, err := mgr.Explain("provider"); err == nil || !strings.Contains(err.Error(), "is a section") {
+		t.Errorf("Explain() of a section error = %v", err)
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	if got := EnvVarName("git.minify.context_lines"); got != "GITSAGE_GIT_MINIFY_CONTEXT_LINES" {
+		t.Errorf("EnvVarName() = %q", got)
+	}
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Value types of configuration keys.
+const (
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeInt    = "int"
+	TypeFloat  = "float"
+	// TypeList is a comma-separated list of strings.
+	TypeList = "list"
+)
+
+// KeyInfo describes a configuration key that can be set with "config set".
+type KeyInfo struct {
+	Key  string
+	Type string
+	// Values are the accepted values of a key taking one of a fixed set;
+	// empty if any value of its type is accepted.
+	Values      []string
+	Description string
+}
+
+// keyRegistry describes every key of the config file holding a single value.
+var keyRegistry = []KeyInfo{
+	{Key: "provider.name", Type: TypeString, Values: []string{"openai", "deepseek", "ollama", "mock"}, Description: "AI provider"},
+	{Key: "provider.api_key", Type: TypeString, Description: "API key of the provider"},
+	{Key: "provider.api_key_cmd", Type: TypeString, Description: "Command printing the API key, used when api_key is empty"},
+	{Key: "provider.api_key_file", Type: TypeString, Description: "File holding the API key, used when api_key and api_key_cmd are empty"},
+	{Key: "provider.model", Type: TypeString, Description: "Model name"},
+	{Key: "provider.endpoint", Type: TypeString, Description: "Custom API endpoint"},
+	{Key: "provider.temperature", Type: TypeFloat, Description: "Sampling temperature"},
+	{Key: "provider.max_tokens", Type: TypeInt, Description: "Maximum tokens of a reply"},
+	{Key: "provider.health_check", Type: TypeBool, Description: "Ping the provider before the first generation"},
+	{Key: "provider.organization", Type: TypeString, Description: "OpenAI organization the requests are billed to"},
+	{Key: "provider.project", Type: TypeString, Description: "OpenAI project the requests are scoped to"},
+	{Key: "provider.user", Type: TypeString, Description: "End-user identifier sent with OpenAI requests for audit"},
+	{Key: "provider.ollama.num_ctx", Type: TypeInt, Description: "Context window of Ollama models in tokens, 0 keeps the model's default"},
+	{Key: "provider.ollama.top_p", Type: TypeFloat, Description: "Nucleus sampling of Ollama models, 0 keeps the model's default"},
+	{Key: "provider.ollama.repeat_penalty", Type: TypeFloat, Description: "Repetition penalty of Ollama models, 0 keeps the model's default"},
+
+	{Key: "git.diff_size_threshold", Type: TypeInt, Description: "Diff size in bytes above which the diff is chunked"},
+	{Key: "git.max_diff_memory", Type: TypeInt, Description: "Staged diff content in bytes kept in memory"},
+	{Key: "git.command_timeout", Type: TypeInt, Description: "Timeout in seconds of quick git commands"},
+	{Key: "git.summarize_lock_files", Type: TypeBool, Description: "Send a package summary of lock file changes"},
+	{Key: "git.generated_patterns", Type: TypeList, Description: "Extra globs of generated files, sent as a one-line summary"},
+	{Key: "git.formatting_only", Type: TypeString, Values: []string{"summarize", "exclude", "keep"}, Description: "Handling of whitespace-only changes"},
+	{Key: "git.exclude_patterns", Type: TypeList, Description: "Globs of files left out of the diff"},
+	{Key: "git.minify.enabled", Type: TypeBool, Description: "Minify diffs before sending them"},
+	{Key: "git.minify.context_lines", Type: TypeInt, Description: "Unchanged lines kept around each change, -1 for all"},
+	{Key: "git.minify.drop_whitespace_hunks", Type: TypeBool, Description: "Leave out whitespace-only hunks"},
+	{Key: "git.minify.vendor_patterns", Type: TypeList, Description: "Extra globs of vendored files, sent as a one-line summary"},
+
+	{Key: "generation.preset", Type: TypeString, Values: []string{"minimal", "standard", "detailed"}, Description: "Message detail"},
+	{Key: "generation.recent_commits", Type: TypeInt, Description: "Recent commit subjects added to the prompt"},
+	{Key: "generation.verify", Type: TypeBool, Description: "Check the message against the diff with a critic"},
+	{Key: "generation.verify_model", Type: TypeString, Description: "Model of the critic, empty for provider.model"},
+	{Key: "generation.commit_template", Type: TypeBool, Description: "Follow git's commit.template"},
+	{Key: "generation.few_shot", Type: TypeString, Values: []string{"auto", "always", "never"}, Description: "When examples are added to the prompt"},
+	{Key: "generation.few_shot_max_bytes", Type: TypeInt, Description: "Size cap of the examples in the prompt"},
+	{Key: "generation.detect_stack", Type: TypeBool, Description: "Add the detected languages and frameworks to the prompt"},
+	{Key: "generation.include_unstaged_context", Type: TypeBool, Description: "List unstaged files in the prompt as context"},
+	{Key: "generation.check_accuracy", Type: TypeBool, Description: "Show how well the body matches the changed paths"},
+	{Key: "generation.translate_to", Type: TypeString, Description: "Language of the Translate action, empty to disable"},
+	{Key: "generation.footer_template", Type: TypeString, Description: "Footer added to every message"},
+	{Key: "generation.regenerate.temperature_step", Type: TypeFloat, Description: "Temperature added on each regeneration"},
+	{Key: "generation.regenerate.max_temperature", Type: TypeFloat, Description: "Cap of the escalated temperature"},
+	{Key: "generation.regenerate.model", Type: TypeString, Description: "Model used after model_after regenerations"},
+	{Key: "generation.regenerate.model_after", Type: TypeInt, Description: "Regenerations after which regenerate.model is used"},
+	{Key: "generation.duplicate_check", Type: TypeString, Values: []string{"warn", "regenerate", "off"}, Description: "Handling of subjects repeating a recent commit"},
+	{Key: "generation.score", Type: TypeString, Values: []string{"heuristic", "model", "off"}, Description: "How accepted messages are graded for history stats"},
+	{Key: "generation.group_failure", Type: TypeString, Values: []string{"list", "split", "skip", "abort"}, Description: "Handling of file groups that fail to summarize"},
+	{Key: "generation.stats_only", Type: TypeBool, Description: "Send only paths, change types and line counts, never the diff content"},
+	{Key: "generation.ownership", Type: TypeString, Values: []string{"off", "context", "scope"}, Description: "Use the CODEOWNERS area of the changed files as body context or as the scope"},
+
+	{Key: "ui.editor", Type: TypeString, Description: "Editor for messages, empty for $GIT_EDITOR, core.editor, $VISUAL or $EDITOR"},
+	{Key: "ui.color_enabled", Type: TypeBool, Description: "Colored output"},
+	{Key: "ui.spinner_style", Type: TypeString, Description: "Loading spinner style"},
+	{Key: "ui.vim_mode", Type: TypeBool, Description: "Vim-style editing in the inline editor"},
+	{Key: "ui.accessible", Type: TypeBool, Description: "Screen-reader friendly line prompts"},
+	{Key: "ui.language", Type: TypeString, Values: []string{"auto", "en", "zh"}, Description: "UI language"},
+	{Key: "ui.notify_after_seconds", Type: TypeInt, Description: "Seconds of generation after which a desktop notification is sent, 0 disables"},
+	{Key: "ui.commit_preview", Type: TypeBool, Description: "Show the commit as git log --stat will and confirm before committing"},
+
+	{Key: "history.enabled", Type: TypeBool, Description: "Record accepted messages"},
+	{Key: "history.max_entries", Type: TypeInt, Description: "Entries kept in the history"},
+	{Key: "history.file_path", Type: TypeString, Description: "History file"},
+	{Key: "history.keep_translation", Type: TypeBool, Description: "Save a translation with each message"},
+
+	{Key: "security.warning_acknowledged", Type: TypeBool, Description: "First-use security warning acknowledged"},
+	{Key: "security.path_check_done", Type: TypeBool, Description: "PATH check performed"},
+	{Key: "security.sensitive_check", Type: TypeBool, Description: "Flag changes to security-sensitive files"},
+	{Key: "security.sensitive_patterns", Type: TypeList, Description: "Extra globs of security-sensitive files"},
+	{Key: "security.redact_paths", Type: TypeBool, Description: "Hide local paths in prompts"},
+
+	{Key: "privacy.local_only", Type: TypeBool, Description: "Refuse providers outside this machine and private networks"},
+
+	{Key: "cache.enabled", Type: TypeBool, Description: "Cache responses"},
+	{Key: "cache.max_entries", Type: TypeInt, Description: "Responses kept in the cache"},
+	{Key: "cache.ttl_minutes", Type: TypeInt, Description: "Minutes a cached response is used"},
+	{Key: "cache.file_path", Type: TypeString, Description: "Cache file, empty to keep responses in memory"},
+
+	{Key: "report.repos", Type: TypeList, Description: "Repositories the report gathers commits from"},
+
+	{Key: "issues.branch_pattern", Type: TypeString, Description: "Regular expression finding the issue in the branch name, empty to use only --issue"},
+	{Key: "issues.platform", Type: TypeString, Values: []string{"auto", "github", "gitlab", "gitea", "bitbucket"}, Description: "Hosting platform whose syntax the issue footer uses"},
+
+	{Key: "budget.max_requests", Type: TypeInt, Description: "Requests above which generating asks for confirmation, 0 disables"},
+	{Key: "budget.max_tokens", Type: TypeInt, Description: "Estimated input tokens above which generating asks for confirmation, 0 disables"},
+	{Key: "budget.max_cost", Type: TypeFloat, Description: "Estimated cost in USD above which generating asks for confirmation, 0 disables"},
+	{Key: "budget.show_estimate", Type: TypeBool, Description: "Show the estimate before every generation"},
+
+	{Key: "risk.enabled", Type: TypeBool, Description: "Show the risk level of the commit with the message"},
+	{Key: "risk.footer", Type: TypeBool, Description: "Add a Risk footer to accepted messages"},
+	{Key: "risk.medium_lines", Type: TypeInt, Description: "Changed lines from which the size is a medium risk, 0 disables"},
+	{Key: "risk.high_lines", Type: TypeInt, Description: "Changed lines from which the size is a high risk, 0 disables"},
+	{Key: "risk.medium_files", Type: TypeInt, Description: "Changed files from which the file count is a medium risk, 0 disables"},
+	{Key: "risk.high_files", Type: TypeInt, Description: "Changed files from which the file count is a high risk, 0 disables"},
+	{Key: "risk.critical_paths", Type: TypeList, Description: "Extra globs of critical files whose changes raise the risk"},
+
+	{Key: "hooks.post_commit", Type: TypeList, Description: "Commands run after a commit, with {sha}, {short_sha}, {subject}, {message} and {branch} passed as GITSAGE_* environment variables"},
+}
+
+// fileOnlyKeys hold lists of rules or maps, which are edited in the config file.
+var fileOnlyKeys = map[string]bool{
+	"generation.examples":    true,
+	"generation.scope_rules": true,
+	"git.path_weights":       true,
+	"ui.keybindings":         true,
+	"profiles":               true,
+	"provider.extra_headers": true,
+	"issues.templates":       true,
+}
+
+// isFileOnly reports whether the key is one of fileOnlyKeys or inside one,
+// e.g. a single header of provider.extra_headers.
+func isFileOnly(key string) bool {
+	for fileOnly := range fileOnlyKeys {
+		if key == fileOnly || strings.HasPrefix(key, fileOnly+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys that can be set with "config set", sorted.
+func Keys() []KeyInfo {
+	keys := make([]KeyInfo, len(keyRegistry))
+	copy(keys, keyRegistry)
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Key < keys[j].Key })
+	return keys
+}
+
+// LookupKey returns the description of a key.
+func LookupKey(key string) (KeyInfo, bool) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, info := range keyRegistry {
+		if info.Key == key {
+			return info, true
+		}
+	}
+	return KeyInfo{}, false
+}
+
+// ValidateSetting checks that value is valid for key before it is written,
+// suggesting the closest key or value when it is not.
+func ValidateSetting(key, value string) error {
+	info, ok := LookupKey(key)
+	if !ok {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if isFileOnly(normalized) {
+			return fmt.Errorf("%s cannot be set from the command line; edit the config file with 'gitsage config edit'", key)
+		}
+		names := make([]string, len(keyRegistry))
+		for i, info := range keyRegistry {
+			names[i] = info.Key
+		}
+		if suggestion := closest(normalized, names); suggestion != "" {
+			return fmt.Errorf("unknown config key %q; did you mean %s?", key, suggestion)
+		}
+		return fmt.Errorf("unknown config key %q", key)
+	}
+
+	switch info.Type {
+	case TypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be true or false, got %q", info.Key, value)
+		}
+	case TypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%s must be a whole number, got %q", info.Key, value)
+		}
+	case TypeFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number, got %q", info.Key, value)
+		}
+	}
+
+	if len(info.Values) > 0 {
+		for _, valid := range info.Values {
+			if strings.EqualFold(strings.TrimSpace(value), valid) {
+				return nil
+			}
+		}
+		message := fmt.Sprintf("invalid value %q for %s (valid: %s)", value, info.Key, strings.Join(info.Values, ", "))
+		if suggestion := closest(strings.ToLower(value), info.Values); suggestion != "" {
+			message += fmt.Sprintf("; did you mean %s?", suggestion)
+		}
+		return fmt.Errorf("%s", message)
+	}
+	return nil
+}
+
+// closest returns the candidate nearest to s in edit distance, or "" if
+// none is close enough to be a likely typo.
+func closest(s string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := editDistance(s, candidate)
+		// A key given without its section, e.g. "model" for provider.model
+		if strings.HasSuffix(candidate, "."+s) {
+			distance = 1
+		}
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if bestDistance < 0 || bestDistance > max(2, len(s)/3) {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
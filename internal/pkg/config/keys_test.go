@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestKeys_CoverDefaults(t *testing.T) {
+	defaults := viper.New()
+	setDefaults(defaults)
+
+	registered := make(map[string]bool)
+	for _, info := range Keys() {
+		registered[info.Key] = true
+		if !defaults.IsSet(info.Key) {
+			t.Errorf("%s is registered but has no default", info.Key)
+		}
+		if info.Description == "" {
+			t.Errorf("%s has no description", info.Key)
+		}
+	}
+	for _, key := range defaults.AllKeys() {
+		if !registered[key] {
+			t.Errorf("%s has a default but is not registered", key)
+		}
+	}
+}
+
+func TestKeys_TypesMatchDefaults(t *testing.T) {
+	defaults := viper.New()
+	setDefaults(defaults)
+
+	for _, info := range Keys() {
+		value := defaults.Get(info.Key)
+		var ok bool
+		switch info.Type {
+		case TypeString:
+			_, ok = value.(string)
+		case TypeBool:
+			_, ok = value.(bool)
+		case TypeInt:
+			_, ok = value.(int)
+		case TypeFloat:
+			_, ok = value.(float64)
+		case TypeList:
+			_, ok = value.([]string)
+		}
+		if !ok {
+			t.Errorf("%s is registered as %s, but its default is %T", info.Key, info.Type, value)
+		}
+		if len(info.Values) > 0 {
+			if err := ValidateSetting(info.Key, defaults.GetString(info.Key)); err != nil {
+				t.Errorf("default of %s is not one of its values: %v", info.Key, err)
+			}
+		}
+	}
+}
+
+func TestValidateSetting(t *testing.T) {
+	tests := []struct {
+		key, value string
+		// wantErr is a substring of the error, empty if the setting is valid
+		wantErr string
+	}{
+		{"provider.name", "deepseek", ""},
+		{"generation.preset", "Detailed", ""},
+		{"provider.max_tokens", "800", ""},
+		{"provider.temperature", "0.7", ""},
+		{"ui.vim_mode", "true", ""},
+		{"git.exclude_patterns", "*.min.js,dist/**", ""},
+		{"provider.api_key", "anything goes", ""},
+		{"provider.modle", "gpt-4o", "did you mean provider.model?"},
+		{"model", "gpt-4o", "did you mean provider.model?"},
+		{"no.such.key", "x", `unknown config key "no.such.key"`},
+		{"provider.name", "opanai", "did you mean openai?"},
+		{"generation.preset", "verbose", "valid: minimal, standard, detailed"},
+		{"provider.max_tokens", "lots", "must be a whole number"},
+		{"provider.temperature", "warm", "must be a number"},
+		{"ui.vim_mode", "sometimes", "must be true or false"},
+		{"generation.scope_rules", "x", "edit the config file"},
+		{"profiles.work.provider.name", "x", "edit the config file"},
+		{"provider.extra_headers.helicone-auth", "x", "edit the config file"},
+	}
+	for _, tt := range tests {
+		err := ValidateSetting(tt.key, tt.value)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("ValidateSetting(%q, %q) error = %v", tt.key, tt.value, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("ValidateSetting(%q, %q) error = %v, want %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
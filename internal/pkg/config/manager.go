@@ -28,6 +28,10 @@ const (
 type ViperManager struct {
 	v          *viper.Viper
 	configPath string
+	// overrides are the values set with SetOverride, by key
+	overrides map[string]interface{}
+	// profile is the section under "profiles" layered over the config file
+	profile string
 }
 
 // NewManager creates a new configuration manager.
@@ -64,6 +68,7 @@ func NewManager(configPath string) (*ViperManager, error) {
 	return &ViperManager{
 		v:          v,
 		configPath: configPath,
+		profile:    strings.TrimSpace(os.Getenv(ProfileEnvVar)),
 	}, nil
 }
 
@@ -73,32 +78,95 @@ func bindEnvVars(v *viper.Viper) {
 	// Provider settings
 	_ = v.BindEnv("provider.name", "GITSAGE_PROVIDER_NAME")
 	_ = v.BindEnv("provider.api_key", "GITSAGE_PROVIDER_API_KEY")
+	_ = v.BindEnv("provider.api_key_cmd", "GITSAGE_PROVIDER_API_KEY_CMD")
+	_ = v.BindEnv("provider.api_key_file", "GITSAGE_PROVIDER_API_KEY_FILE")
 	_ = v.BindEnv("provider.model", "GITSAGE_PROVIDER_MODEL")
 	_ = v.BindEnv("provider.endpoint", "GITSAGE_PROVIDER_ENDPOINT")
 	_ = v.BindEnv("provider.temperature", "GITSAGE_PROVIDER_TEMPERATURE")
 	_ = v.BindEnv("provider.max_tokens", "GITSAGE_PROVIDER_MAX_TOKENS")
+	_ = v.BindEnv("provider.health_check", "GITSAGE_PROVIDER_HEALTH_CHECK")
+	_ = v.BindEnv("provider.organization", "GITSAGE_PROVIDER_ORGANIZATION")
+	_ = v.BindEnv("provider.project", "GITSAGE_PROVIDER_PROJECT")
+	_ = v.BindEnv("provider.user", "GITSAGE_PROVIDER_USER")
+	_ = v.BindEnv("provider.ollama.num_ctx", "GITSAGE_PROVIDER_OLLAMA_NUM_CTX")
+	_ = v.BindEnv("provider.ollama.top_p", "GITSAGE_PROVIDER_OLLAMA_TOP_P")
+	_ = v.BindEnv("provider.ollama.repeat_penalty", "GITSAGE_PROVIDER_OLLAMA_REPEAT_PENALTY")
 
 	// Git settings
 	_ = v.BindEnv("git.diff_size_threshold", "GITSAGE_GIT_DIFF_SIZE_THRESHOLD")
+	_ = v.BindEnv("git.max_diff_memory", "GITSAGE_GIT_MAX_DIFF_MEMORY")
+	_ = v.BindEnv("git.command_timeout", "GITSAGE_GIT_COMMAND_TIMEOUT")
+	_ = v.BindEnv("git.summarize_lock_files", "GITSAGE_GIT_SUMMARIZE_LOCK_FILES")
+	_ = v.BindEnv("git.formatting_only", "GITSAGE_GIT_FORMATTING_ONLY")
+	_ = v.BindEnv("git.minify.enabled", "GITSAGE_GIT_MINIFY_ENABLED")
+	_ = v.BindEnv("git.minify.context_lines", "GITSAGE_GIT_MINIFY_CONTEXT_LINES")
+	_ = v.BindEnv("git.minify.drop_whitespace_hunks", "GITSAGE_GIT_MINIFY_DROP_WHITESPACE_HUNKS")
+
+	// Generation settings
+	_ = v.BindEnv("generation.preset", "GITSAGE_GENERATION_PRESET")
+	_ = v.BindEnv("generation.recent_commits", "GITSAGE_GENERATION_RECENT_COMMITS")
+	_ = v.BindEnv("generation.verify", "GITSAGE_GENERATION_VERIFY")
+	_ = v.BindEnv("generation.verify_model", "GITSAGE_GENERATION_VERIFY_MODEL")
+	_ = v.BindEnv("generation.commit_template", "GITSAGE_GENERATION_COMMIT_TEMPLATE")
+	_ = v.BindEnv("generation.few_shot", "GITSAGE_GENERATION_FEW_SHOT")
+	_ = v.BindEnv("generation.few_shot_max_bytes", "GITSAGE_GENERATION_FEW_SHOT_MAX_BYTES")
+	_ = v.BindEnv("generation.detect_stack", "GITSAGE_GENERATION_DETECT_STACK")
+	_ = v.BindEnv("generation.include_unstaged_context", "GITSAGE_GENERATION_INCLUDE_UNSTAGED_CONTEXT")
+	_ = v.BindEnv("generation.check_accuracy", "GITSAGE_GENERATION_CHECK_ACCURACY")
+	_ = v.BindEnv("generation.translate_to", "GITSAGE_GENERATION_TRANSLATE_TO")
+	_ = v.BindEnv("generation.footer_template", "GITSAGE_GENERATION_FOOTER_TEMPLATE")
+	_ = v.BindEnv("generation.regenerate.temperature_step", "GITSAGE_GENERATION_REGENERATE_TEMPERATURE_STEP")
+	_ = v.BindEnv("generation.regenerate.max_temperature", "GITSAGE_GENERATION_REGENERATE_MAX_TEMPERATURE")
+	_ = v.BindEnv("generation.regenerate.model", "GITSAGE_GENERATION_REGENERATE_MODEL")
+	_ = v.BindEnv("generation.regenerate.model_after", "GITSAGE_GENERATION_REGENERATE_MODEL_AFTER")
+	_ = v.BindEnv("generation.duplicate_check", "GITSAGE_GENERATION_DUPLICATE_CHECK")
+	_ = v.BindEnv("generation.score", "GITSAGE_GENERATION_SCORE")
+	_ = v.BindEnv("generation.group_failure", "GITSAGE_GENERATION_GROUP_FAILURE")
+	_ = v.BindEnv("generation.stats_only", "GITSAGE_GENERATION_STATS_ONLY")
+	_ = v.BindEnv("generation.ownership", "GITSAGE_GENERATION_OWNERSHIP")
 
 	// UI settings
 	_ = v.BindEnv("ui.editor", "GITSAGE_UI_EDITOR")
 	_ = v.BindEnv("ui.color_enabled", "GITSAGE_UI_COLOR_ENABLED")
 	_ = v.BindEnv("ui.spinner_style", "GITSAGE_UI_SPINNER_STYLE")
+	_ = v.BindEnv("ui.vim_mode", "GITSAGE_UI_VIM_MODE")
+	_ = v.BindEnv("ui.accessible", "GITSAGE_UI_ACCESSIBLE")
+	_ = v.BindEnv("ui.language", "GITSAGE_UI_LANGUAGE")
+	_ = v.BindEnv("ui.notify_after_seconds", "GITSAGE_UI_NOTIFY_AFTER_SECONDS")
+	_ = v.BindEnv("ui.commit_preview", "GITSAGE_UI_COMMIT_PREVIEW")
 
 	// History settings
 	_ = v.BindEnv("history.enabled", "GITSAGE_HISTORY_ENABLED")
 	_ = v.BindEnv("history.max_entries", "GITSAGE_HISTORY_MAX_ENTRIES")
 	_ = v.BindEnv("history.file_path", "GITSAGE_HISTORY_FILE_PATH")
+	_ = v.BindEnv("history.keep_translation", "GITSAGE_HISTORY_KEEP_TRANSLATION")
 
 	// Security settings
 	_ = v.BindEnv("security.warning_acknowledged", "GITSAGE_SECURITY_WARNING_ACKNOWLEDGED")
 	_ = v.BindEnv("security.path_check_done", "GITSAGE_SECURITY_PATH_CHECK_DONE")
+	_ = v.BindEnv("security.sensitive_check", "GITSAGE_SECURITY_SENSITIVE_CHECK")
+	_ = v.BindEnv("security.redact_paths", "GITSAGE_SECURITY_REDACT_PATHS")
+	_ = v.BindEnv("privacy.local_only", "GITSAGE_PRIVACY_LOCAL_ONLY")
 
 	// Cache settings
 	_ = v.BindEnv("cache.enabled", "GITSAGE_CACHE_ENABLED")
 	_ = v.BindEnv("cache.max_entries", "GITSAGE_CACHE_MAX_ENTRIES")
 	_ = v.BindEnv("cache.ttl_minutes", "GITSAGE_CACHE_TTL_MINUTES")
+	_ = v.BindEnv("cache.file_path", "GITSAGE_CACHE_FILE_PATH")
+
+	// Issue settings
+	_ = v.BindEnv("issues.branch_pattern", "GITSAGE_ISSUES_BRANCH_PATTERN")
+	_ = v.BindEnv("issues.platform", "GITSAGE_ISSUES_PLATFORM")
+
+	// Budget settings
+	_ = v.BindEnv("budget.max_requests", "GITSAGE_BUDGET_MAX_REQUESTS")
+	_ = v.BindEnv("budget.max_tokens", "GITSAGE_BUDGET_MAX_TOKENS")
+	_ = v.BindEnv("budget.max_cost", "GITSAGE_BUDGET_MAX_COST")
+	_ = v.BindEnv("budget.show_estimate", "GITSAGE_BUDGET_SHOW_ESTIMATE")
+
+	// Risk settings
+	_ = v.BindEnv("risk.enabled", "GITSAGE_RISK_ENABLED")
+	_ = v.BindEnv("risk.footer", "GITSAGE_RISK_FOOTER")
 }
 
 // setDefaults sets the default configuration values.
@@ -106,13 +174,31 @@ func setDefaults(v *viper.Viper) {
 	// Provider defaults
 	v.SetDefault("provider.name", "openai")
 	v.SetDefault("provider.api_key", "")
+	v.SetDefault("provider.api_key_cmd", "")
+	v.SetDefault("provider.api_key_file", "")
 	v.SetDefault("provider.model", "gpt-4o-mini")
 	v.SetDefault("provider.endpoint", "")
 	v.SetDefault("provider.temperature", 0.2)
 	v.SetDefault("provider.max_tokens", 500)
+	v.SetDefault("provider.health_check", false)
+	v.SetDefault("provider.organization", "")
+	v.SetDefault("provider.project", "")
+	v.SetDefault("provider.user", "")
+	v.SetDefault("provider.ollama.num_ctx", 0)
+	v.SetDefault("provider.ollama.top_p", 0.0)
+	v.SetDefault("provider.ollama.repeat_penalty", 0.0)
 
 	// Git defaults
 	v.SetDefault("git.diff_size_threshold", 10240) // 10KB
+	v.SetDefault("git.max_diff_memory", 67108864)  // 64MB
+	v.SetDefault("git.command_timeout", 10)        // seconds
+	v.SetDefault("git.summarize_lock_files", false)
+	v.SetDefault("git.generated_patterns", []string{})
+	v.SetDefault("git.formatting_only", "summarize")
+	v.SetDefault("git.minify.enabled", false)
+	v.SetDefault("git.minify.context_lines", 1)
+	v.SetDefault("git.minify.drop_whitespace_hunks", true)
+	v.SetDefault("git.minify.vendor_patterns", []string{})
 	v.SetDefault("git.exclude_patterns", []string{
 		"*.lock",
 		"go.sum",
@@ -122,25 +208,86 @@ func setDefaults(v *viper.Viper) {
 		"Cargo.lock",
 	})
 
+	// Generation defaults
+	v.SetDefault("generation.preset", "standard")
+	v.SetDefault("generation.recent_commits", 5)
+	v.SetDefault("generation.verify", false)
+	v.SetDefault("generation.verify_model", "")
+	v.SetDefault("generation.commit_template", true)
+	v.SetDefault("generation.few_shot", "auto")
+	v.SetDefault("generation.few_shot_max_bytes", 2048)
+	v.SetDefault("generation.detect_stack", true)
+	v.SetDefault("generation.include_unstaged_context", false)
+	v.SetDefault("generation.check_accuracy", true)
+	v.SetDefault("generation.translate_to", "")
+	v.SetDefault("generation.footer_template", "")
+	v.SetDefault("generation.regenerate.temperature_step", 0.2)
+	v.SetDefault("generation.regenerate.max_temperature", 1.0)
+	v.SetDefault("generation.regenerate.model", "")
+	v.SetDefault("generation.regenerate.model_after", 2)
+	v.SetDefault("generation.duplicate_check", "warn")
+	v.SetDefault("generation.score", "heuristic")
+	v.SetDefault("generation.group_failure", "list")
+	v.SetDefault("generation.stats_only", false)
+	v.SetDefault("generation.ownership", "off")
+
 	// UI defaults
 	v.SetDefault("ui.editor", "")
 	v.SetDefault("ui.color_enabled", true)
 	v.SetDefault("ui.spinner_style", "dots")
+	v.SetDefault("ui.vim_mode", false)
+	v.SetDefault("ui.accessible", false)
+	v.SetDefault("ui.language", "auto")
+	v.SetDefault("ui.notify_after_seconds", 30)
+	v.SetDefault("ui.commit_preview", false)
 
 	// History defaults
 	v.SetDefault("history.enabled", true)
 	v.SetDefault("history.max_entries", 1000)
 	homeDir, _ := os.UserHomeDir()
 	v.SetDefault("history.file_path", filepath.Join(homeDir, ".gitsage", "history.json"))
+	v.SetDefault("history.keep_translation", false)
 
 	// Security defaults
 	v.SetDefault("security.warning_acknowledged", false)
 	v.SetDefault("security.path_check_done", false)
+	v.SetDefault("security.sensitive_check", true)
+	v.SetDefault("security.sensitive_patterns", []string{})
+	v.SetDefault("security.redact_paths", false)
+
+	// Privacy defaults
+	v.SetDefault("privacy.local_only", false)
 
 	// Cache defaults
 	v.SetDefault("cache.enabled", true)
 	v.SetDefault("cache.max_entries", 100)
 	v.SetDefault("cache.ttl_minutes", 60) // 1 hour
+	v.SetDefault("cache.file_path", filepath.Join(homeDir, ".gitsage", "cache.json"))
+
+	// Report defaults
+	v.SetDefault("report.repos", []string{})
+
+	// Issue defaults
+	v.SetDefault("issues.branch_pattern", "")
+	v.SetDefault("issues.platform", "auto")
+
+	// Budget defaults
+	v.SetDefault("budget.max_requests", 10)
+	v.SetDefault("budget.max_tokens", 0)
+	v.SetDefault("budget.max_cost", 0.0)
+	v.SetDefault("budget.show_estimate", false)
+
+	// Risk defaults
+	v.SetDefault("risk.enabled", true)
+	v.SetDefault("risk.footer", false)
+	v.SetDefault("risk.medium_lines", 200)
+	v.SetDefault("risk.high_lines", 800)
+	v.SetDefault("risk.medium_files", 10)
+	v.SetDefault("risk.high_files", 30)
+	v.SetDefault("risk.critical_paths", []string{})
+
+	// Hooks defaults
+	v.SetDefault("hooks.post_commit", []string{})
 }
 
 // GetConfigPath returns the path to the configuration file.
@@ -149,7 +296,7 @@ func (m *ViperManager) GetConfigPath() string {
 }
 
 // Load loads the configuration from file, environment, and defaults.
-// Priority: flags > env > file > defaults
+// Priority: flags > env > profile > file > defaults
 func (m *ViperManager) Load() (*Config, error) {
 	// Try to read config file (ignore error if file doesn't exist)
 	if err := m.v.ReadInConfig(); err != nil {
@@ -161,6 +308,11 @@ func (m *ViperManager) Load() (*Config, error) {
 		}
 	}
 
+	if err := m.applyProfile(); err != nil {
+		return nil, err
+	}
+	defer m.restoreFile()
+
 	var cfg Config
 	if err := m.v.Unmarshal(&cfg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
@@ -226,11 +378,14 @@ func (m *ViperManager) Init() error {
 func (m *ViperManager) Save(config *Config) error {
 	// Update viper with config values
 	m.v.Set("provider", config.Provider)
+	m.v.Set("generation", config.Generation)
 	m.v.Set("git", config.Git)
 	m.v.Set("ui", config.UI)
 	m.v.Set("history", config.History)
 	m.v.Set("security", config.Security)
 	m.v.Set("cache", config.Cache)
+	m.v.Set("budget", config.Budget)
+	m.v.Set("hooks", config.Hooks)
 
 	// Write to file
 	if err := m.v.WriteConfig(); err != nil {
@@ -250,8 +405,13 @@ func (m *ViperManager) Set(key string, value string) error {
 		}
 	}
 
-	// Convert value to appropriate type based on existing value type
+	// Convert value to appropriate type based on existing value type. A
+	// float such as 0.0 reads back from the file as an integer, so
+	// registered float keys keep their type
 	existingValue := m.v.Get(key)
+	if info, ok := LookupKey(key); ok && info.Type == TypeFloat {
+		existingValue = 0.0
+	}
 	convertedValue, err := convertValue(value, existingValue)
 	if err != nil {
 		return fmt.Errorf("failed to convert value for key %s: %w", key, err)
@@ -305,10 +465,14 @@ func (m *ViperManager) Get(key string) (string, error) {
 	return fmt.Sprintf("%v", value), nil
 }
 
-// List returns all configuration values as a map.
+// List returns all configuration values as a map, with the active profile
+// applied.
 func (m *ViperManager) List() map[string]interface{} {
 	// Load config first (ignore errors, use defaults)
 	_ = m.v.ReadInConfig()
+	if m.applyProfile() == nil {
+		defer m.restoreFile()
+	}
 
 	return m.v.AllSettings()
 }
@@ -317,6 +481,10 @@ func (m *ViperManager) List() map[string]interface{} {
 // This is used for command-line flag overrides that shouldn't persist.
 func (m *ViperManager) SetOverride(key string, value interface{}) {
 	m.v.Set(key, value)
+	if m.overrides == nil {
+		m.overrides = make(map[string]interface{})
+	}
+	m.overrides[strings.ToLower(key)] = value
 }
 
 // MaskAPIKey masks an API key, showing only the last 4 characters.
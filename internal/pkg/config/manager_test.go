@@ -164,7 +164,8 @@ func TestConfigPrecedence_Property(t *testing.T) {
 			return cfg.Provider.Name == "openai" &&
 				cfg.Provider.Model == "gpt-4o-mini" &&
 				cfg.Provider.Temperature == 0.2 &&
-				cfg.Provider.MaxTokens == 500
+				cfg.Provider.MaxTokens == 500 &&
+				!cfg.Provider.HealthCheck
 		},
 		gen.Int(), // Dummy generator to run the test multiple times
 	))
@@ -543,3 +544,94 @@ func TestPathCheckDonePersistence_Property(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+// TestLoadKeyBindings verifies that ui.keybindings and ui.vim_mode are read from the config file.
+func TestLoadKeyBindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".gitsage.yaml")
+
+	content := `ui:
+  vim_mode: true
+  keybindings:
+    accept: ["a", "enter"]
+    view_diff: v
+`
+	if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+
+	cfg, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	if !cfg.UI.VimMode {
+		t.Error("Expected vim_mode to be enabled")
+	}
+	if got := cfg.UI.KeyBindings["accept"]; len(got) != 2 || got[0] != "a" || got[1] != "enter" {
+		t.Errorf("Expected accept bindings [a enter], got %v", got)
+	}
+	// A single key is accepted in place of a list
+	if got := cfg.UI.KeyBindings["view_diff"]; len(got) != 1 || got[0] != "v" {
+		t.Errorf("Expected view_diff bindings [v], got %v", got)
+	}
+}
+
+// TestLoadLanguage verifies that ui.language defaults to auto and can be overridden by env var.
+func TestLoadLanguage(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, ".gitsage.yaml")
+
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+
+	cfg, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.UI.Language != "auto" {
+		t.Errorf("Expected default language auto, got %q", cfg.UI.Language)
+	}
+
+	t.Setenv("GITSAGE_UI_LANGUAGE", "zh")
+	cfg, err = mgr.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.UI.Language != "zh" {
+		t.Errorf("Expected language zh from env, got %q", cfg.UI.Language)
+	}
+}
+
+// TestSetFloatKeyWrittenAsInteger verifies that a float key whose value reads
+// back from the file as an integer (0.0 is written as 0) still takes decimals.
+func TestSetFloatKeyWrittenAsInteger(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".gitsage.yaml")
+
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	if err := mgr.Init(); err != nil {
+		t.Fatalf("Failed to init config: %v", err)
+	}
+
+	if err := mgr.Set("provider.ollama.top_p", "0.9"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	cfg, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if cfg.Provider.Ollama.TopP != 0.9 {
+		t.Errorf("Provider.Ollama.TopP = %v, want 0.9", cfg.Provider.Ollama.TopP)
+	}
+}
@@ -0,0 +1,37 @@
+// Package config provides configuration management for GitSage.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+)
+
+// RepoPrivacyFile is the repository-level file with privacy settings, at the
+// root of the work tree. Its keys are those of the privacy section and take
+// precedence over it, so a restricted repository can require local providers
+// for everyone who clones it:
+//
+//	local_only: true
+const RepoPrivacyFile = ".gitsage-privacy.yaml"
+
+// ApplyPrivacyFile overrides the privacy settings with those set in a file
+// in the RepoPrivacyFile format. A missing file changes nothing.
+func ApplyPrivacyFile(path string, privacy *PrivacyConfig) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read privacy file: %w", err)
+	}
+
+	if v.IsSet("local_only") {
+		privacy.LocalOnly = v.GetBool("local_only")
+	}
+	return nil
+}
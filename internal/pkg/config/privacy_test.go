@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyPrivacyFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string // empty for no file
+		global  bool
+		want    bool
+	}{
+		{"no file keeps the setting", "", true, true},
+		{"file turns it on", "local_only: true\n", false, true},
+		{"file turns it off", "local_only: false\n", true, false},
+		{"file without the key keeps the setting", "# nothing yet\n", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), RepoPrivacyFile)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			privacy := PrivacyConfig{LocalOnly: tt.global}
+			if err := ApplyPrivacyFile(path, &privacy); err != nil {
+				t.Fatalf("ApplyPrivacyFile() error = %v", err)
+			}
+			if privacy.LocalOnly != tt.want {
+				t.Errorf("LocalOnly = %v, want %v", privacy.LocalOnly, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyPrivacyFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RepoPrivacyFile)
+	if err := os.WriteFile(path, []byte("local_only: [true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	privacy := PrivacyConfig{}
+	if err := ApplyPrivacyFile(path, &privacy); err == nil {
+		t.Error("ApplyPrivacyFile() should fail for invalid YAML")
+	}
+}
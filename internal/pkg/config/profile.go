@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ProfileEnvVar is the environment variable that selects a profile.
+const ProfileEnvVar = "GITSAGE_PROFILE"
+
+// SetProfile selects the profile layered over the config file, replacing
+// the one from GITSAGE_PROFILE; an empty name selects none.
+func (m *ViperManager) SetProfile(name string) {
+	m.profile = strings.TrimSpace(name)
+}
+
+// Profile returns the name of the selected profile, or an empty string.
+func (m *ViperManager) Profile() string {
+	return m.profile
+}
+
+// Profiles returns the names of the profiles in the config file, sorted.
+func (m *ViperManager) Profiles() []string {
+	var names []string
+	for name := range m.v.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile merges the selected profile's section, e.g. profiles.work,
+// over the values read from the config file. Environment variables and
+// overrides still take priority. Call restoreFile when done, so that the
+// profile is never written back into the base config.
+func (m *ViperManager) applyProfile() error {
+	if m.profile == "" {
+		return nil
+	}
+	section, ok := m.v.Get("profiles." + m.profile).(map[string]interface{})
+	if !ok {
+		available := "none defined"
+		if names := m.Profiles(); len(names) > 0 {
+			available = "available: " + strings.Join(names, ", ")
+		}
+		return fmt.Errorf("profile %q not found in %s (%s)", m.profile, m.configPath, available)
+	}
+	if err := m.v.MergeConfigMap(section); err != nil {
+		return fmt.Errorf("failed to apply profile %q: %w", m.profile, err)
+	}
+	return nil
+}
+
+// restoreFile rereads the config file, dropping a merged profile.
+func (m *ViperManager) restoreFile() {
+	if m.profile != "" {
+		_ = m.v.ReadInConfig()
+	}
+}
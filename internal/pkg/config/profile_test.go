@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profileConfig = `provider:
+  name: openai
+  model: base-model
+  endpoint: https://api.openai.com/v1
+profiles:
+  work:
+    provider:
+      model: work-model
+      endpoint: https://llm.corp.example/v1
+  home:
+    provider:
+      name: ollama
+`
+
+func writeProfileConfig(t *testing.T) string {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(profileConfig), 0600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return configPath
+}
+
+func TestLoad_Profile(t *testing.T) {
+	configPath := writeProfileConfig(t)
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	mgr.SetProfile("work")
+	cfg, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Provider.Model != "work-model" || cfg.Provider.Endpoint != "https://llm.corp.example/v1" {
+		t.Errorf("profile values not applied: %+v", cfg.Provider)
+	}
+	if cfg.Provider.Name != "openai" {
+		t.Errorf("Provider.Name = %q, want the base value openai", cfg.Provider.Name)
+	}
+
+	// Writing a key must not copy the profile into the base config
+	if err := mgr.Set("ui.language", "zh"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	mgr.SetProfile("")
+	cfg, err = mgr.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Provider.Model != "base-model" {
+		t.Errorf("base Provider.Model = %q, want base-model", cfg.Provider.Model)
+	}
+}
+
+func TestLoad_ProfileFromEnv(t *testing.T) {
+	configPath := writeProfileConfig(t)
+	t.Setenv(ProfileEnvVar, "home")
+	t.Setenv("GITSAGE_PROVIDER_NAME", "deepseek")
+
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if mgr.Profile() != "home" {
+		t.Errorf("Profile() = %q, want home", mgr.Profile())
+	}
+
+	cfg, err := mgr.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// Environment variables win over the profile
+	if cfg.Provider.Name != "deepseek" {
+		t.Errorf("Provider.Name = %q, want deepseek from the environment", cfg.Provider.Name)
+	}
+}
+
+func TestLoad_UnknownProfile(t *testing.T) {
+	mgr, err := NewManager(writeProfileConfig(t))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+
+	mgr.SetProfile("travel")
+	_, err = mgr.Load()
+	if err == nil || !strings.Contains(err.Error(), "available: home, work") {
+		t.Errorf("Load() error = %v, want the available profiles", err)
+	}
+}
+
+func TestExplain_Profile(t *testing.T) {
+	configPath := writeProfileConfig(t)
+	mgr, err := NewManager(configPath)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	mgr.SetProfile("work")
+
+	explanation, err := mgr.Explain("provider.model")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	want := Layer{Source: SourceProfile, Origin: "profiles.work", Set: true, Value: "work-model"}
+	if got := explanation.Effective(); got != want {
+		t.Errorf("Effective() = %+v, want %+v", got, want)
+	}
+
+	// Keys the profile leaves alone come from the file
+	explanation, _ = mgr.Explain("provider.name")
+	if got := explanation.Effective(); got.Source != SourceFile {
+		t.Errorf("Effective() of provider.name = %+v, want the file", got)
+	}
+}
@@ -20,11 +20,13 @@ const (
 	ErrInvalidConfig
 	ErrMissingAPIKey
 	ErrInvalidArguments
+	ErrInvalidMessage
 
 	// System errors (Exit Code 2)
 	ErrGitCommandFailed ErrorCode = iota + 200
 	ErrFileSystemError
 	ErrConfigCorruption
+	ErrGitTimeout
 
 	// External errors (Exit Code 3)
 	ErrAIProviderFailed ErrorCode = iota + 300
@@ -59,12 +61,16 @@ func (c ErrorCode) String() string {
 		return "MissingAPIKey"
 	case ErrInvalidArguments:
 		return "InvalidArguments"
+	case ErrInvalidMessage:
+		return "InvalidMessage"
 	case ErrGitCommandFailed:
 		return "GitCommandFailed"
 	case ErrFileSystemError:
 		return "FileSystemError"
 	case ErrConfigCorruption:
 		return "ConfigCorruption"
+	case ErrGitTimeout:
+		return "GitTimeout"
 	case ErrAIProviderFailed:
 		return "AIProviderFailed"
 	case ErrNetworkError:
@@ -253,9 +259,10 @@ func NewInvalidConfigError(message string) *AppError {
 // NewGitError creates an error for git command failures.
 func NewGitError(err error, output string) *AppError {
 	appErr := &AppError{
-		Code:    ErrGitCommandFailed,
-		Message: "git command failed",
-		Cause:   err,
+		Code:       ErrGitCommandFailed,
+		Message:    "git command failed",
+		Cause:      err,
+		Suggestion: "Run with --verbose or --log-file to see the git commands that were run",
 	}
 	if output != "" {
 		appErr.Context = map[string]interface{}{
@@ -265,6 +272,25 @@ func NewGitError(err error, output string) *AppError {
 	return appErr
 }
 
+// NewBareRepositoryError creates an error for running in a bare repository.
+func NewBareRepositoryError() *AppError {
+	return &AppError{
+		Code:       ErrGitCommandFailed,
+		Message:    "cannot commit in bare repository",
+		Suggestion: "Run gitsage inside a working tree, or set GIT_WORK_TREE to point at one",
+	}
+}
+
+// NewNotInWorkTreeError creates an error for running outside a work tree,
+// e.g. inside the .git directory.
+func NewNotInWorkTreeError() *AppError {
+	return &AppError{
+		Code:       ErrGitCommandFailed,
+		Message:    "not inside a git work tree",
+		Suggestion: "Run gitsage from the repository's working directory",
+	}
+}
+
 // NewNetworkError creates an error for network failures.
 func NewNetworkError(err error) *AppError {
 	return &AppError{
@@ -299,6 +325,19 @@ func NewTimeoutError(err error) *AppError {
 	}
 }
 
+// NewGitTimeoutError creates an error for a git command that exceeded its timeout.
+// It is a system error: the command ran locally, not against an external service.
+func NewGitTimeoutError(command string, timeout time.Duration) *AppError {
+	return &AppError{
+		Code:    ErrGitTimeout,
+		Message: fmt.Sprintf("git command timed out after %v: %s", timeout, command),
+		Context: map[string]interface{}{
+			"command": command,
+		},
+		Suggestion: "Increase the timeout for large repositories or slow filesystems, e.g. 'gitsage config set git.command_timeout 30'",
+	}
+}
+
 // NewAuthenticationError creates an error for authentication failures.
 func NewAuthenticationError(provider string) *AppError {
 	return &AppError{
@@ -17,6 +17,7 @@ func TestErrorCode_ExitCode(t *testing.T) {
 		{"MissingAPIKey", ErrMissingAPIKey, 1},
 		{"GitCommandFailed", ErrGitCommandFailed, 2},
 		{"FileSystemError", ErrFileSystemError, 2},
+		{"GitTimeout", ErrGitTimeout, 2},
 		{"AIProviderFailed", ErrAIProviderFailed, 3},
 		{"NetworkError", ErrNetworkError, 3},
 		{"RateLimited", ErrRateLimited, 3},
@@ -261,6 +262,26 @@ func TestNewRateLimitError(t *testing.T) {
 	}
 }
 
+func TestNewGitTimeoutError(t *testing.T) {
+	err := NewGitTimeoutError("git diff --cached", 60*time.Second)
+
+	if err.Code != ErrGitTimeout {
+		t.Errorf("Code = %v, want %v", err.Code, ErrGitTimeout)
+	}
+	if err.Code.ExitCode() != 2 {
+		t.Errorf("ExitCode() = %d, want 2", err.Code.ExitCode())
+	}
+	if err.IsRetryable() {
+		t.Error("git timeout error should not be retryable")
+	}
+	if want := "git command timed out after 1m0s: git diff --cached"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if err.Context["command"] != "git diff --cached" {
+		t.Errorf("Context[command] = %v, want git diff --cached", err.Context["command"])
+	}
+}
+
 func TestFormatError(t *testing.T) {
 	tests := []struct {
 		name     string
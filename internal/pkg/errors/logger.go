@@ -46,6 +46,9 @@ type Logger struct {
 	output  io.Writer
 	level   LogLevel
 	verbose bool
+	// file, if set, receives every message, debug ones included, whatever
+	// the level of the output.
+	file io.Writer
 }
 
 // Global logger instance
@@ -81,6 +84,14 @@ func SetOutput(w io.Writer) {
 	defaultLogger.output = w
 }
 
+// SetLogFile sets a writer that receives every log message, including debug
+// messages when verbose logging is off. A nil writer disables it.
+func SetLogFile(w io.Writer) {
+	defaultLogger.mu.Lock()
+	defer defaultLogger.mu.Unlock()
+	defaultLogger.file = w
+}
+
 // NewLogger creates a new logger with the given configuration.
 func NewLogger(output io.Writer, verbose bool) *Logger {
 	level := LogLevelError
@@ -99,13 +110,18 @@ func (l *Logger) log(level LogLevel, format string, args ...interface{}) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	if level > l.level {
+	if level > l.level && l.file == nil {
 		return
 	}
 
-	timestamp := time.Now().Format("15:04:05")
+	now := time.Now()
 	message := fmt.Sprintf(format, args...)
-	fmt.Fprintf(l.output, "[%s] %s: %s\n", timestamp, level.String(), message)
+	if level <= l.level {
+		fmt.Fprintf(l.output, "[%s] %s: %s\n", now.Format("15:04:05"), level.String(), message)
+	}
+	if l.file != nil {
+		fmt.Fprintf(l.file, "%s %s: %s\n", now.Format(time.RFC3339), level.String(), message)
+	}
 }
 
 // Error logs an error message.
@@ -175,6 +175,34 @@ func TestSetVerbose(t *testing.T) {
 	}
 }
 
+func TestSetLogFile(t *testing.T) {
+	originalVerbose := IsVerbose()
+	defer SetVerbose(originalVerbose)
+	defer SetOutput(defaultLogger.output)
+	defer SetLogFile(nil)
+
+	var output, file bytes.Buffer
+	SetOutput(&output)
+	SetLogFile(&file)
+	SetVerbose(false)
+
+	Debug("git status --porcelain")
+	Error("git command failed")
+
+	if strings.Contains(output.String(), "DEBUG") {
+		t.Error("Output should not contain DEBUG in non-verbose mode")
+	}
+	if !strings.Contains(output.String(), "git command failed") {
+		t.Error("Output should contain the error")
+	}
+	if !strings.Contains(file.String(), "DEBUG: git status --porcelain") {
+		t.Errorf("Log file should contain debug messages, got %q", file.String())
+	}
+	if !strings.Contains(file.String(), "ERROR: git command failed") {
+		t.Errorf("Log file should contain the error, got %q", file.String())
+	}
+}
+
 func TestLogLevel_String(t *testing.T) {
 	tests := []struct {
 		level    LogLevel
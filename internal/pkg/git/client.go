@@ -2,13 +2,13 @@
 package git
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +19,11 @@ import (
 const (
 	// GitCommandTimeout is the default timeout for git commands.
 	GitCommandTimeout = 10 * time.Second
+	// LongCommandTimeoutFactor scales the command timeout for commands whose
+	// run time grows with the staging area (diff, status, add, commit).
+	LongCommandTimeoutFactor = 6
+	// GitNetworkTimeout is the timeout for commands that talk to a remote.
+	GitNetworkTimeout = 60 * time.Second
 )
 
 // ChangeType represents the type of change in a diff.
@@ -29,6 +34,16 @@ const (
 	ChangeTypeModified
 	ChangeTypeDeleted
 	ChangeTypeRenamed
+	// ChangeTypeSymlink is a symbolic link whose target was added, changed or removed.
+	ChangeTypeSymlink
+	// ChangeTypeSubmodule is a submodule (gitlink) whose commit was added, updated or removed.
+	ChangeTypeSubmodule
+)
+
+// Git object modes of entries that are not regular files.
+const (
+	symlinkMode   = "120000"
+	submoduleMode = "160000"
 )
 
 // String returns the string representation of ChangeType.
@@ -42,6 +57,10 @@ func (c ChangeType) String() string {
 		return "deleted"
 	case ChangeTypeRenamed:
 		return "renamed"
+	case ChangeTypeSymlink:
+		return "symlink"
+	case ChangeTypeSubmodule:
+		return "submodule"
 	default:
 		return "unknown"
 	}
@@ -57,6 +76,42 @@ type DiffChunk struct {
 	IsLockFile bool
 	IsBinary   bool
 	OldPath    string // For renames, the original file path
+	// IsGenerated is set by the diff processor for generated files whose
+	// content was replaced with a summary.
+	IsGenerated bool
+	// ModeChange holds the file mode change, if any (e.g. chmod +x).
+	ModeChange ModeChange
+	// OldTarget and NewTarget hold the symlink target or submodule commit
+	// before and after the change for ChangeTypeSymlink and ChangeTypeSubmodule.
+	// OldTarget is empty for added entries, NewTarget for removed ones.
+	OldTarget string
+	NewTarget string
+}
+
+// ModeChange is a change of a file's mode, parsed from the "old mode" and
+// "new mode" lines of a diff. Both modes are empty if the mode is unchanged.
+type ModeChange struct {
+	OldMode string
+	NewMode string
+}
+
+// IsZero reports whether there is no mode change.
+func (m ModeChange) IsZero() bool {
+	return m.OldMode == "" && m.NewMode == ""
+}
+
+// Describe describes the mode change, e.g. "made executable".
+func (m ModeChange) Describe() string {
+	switch {
+	case m.IsZero():
+		return ""
+	case m.OldMode == "100644" && m.NewMode == "100755":
+		return "made executable"
+	case m.OldMode == "100755" && m.NewMode == "100644":
+		return "made non-executable"
+	default:
+		return fmt.Sprintf("mode changed from %s to %s", m.OldMode, m.NewMode)
+	}
 }
 
 // DiffStats contains statistics about the diff.
@@ -75,12 +130,30 @@ type Client interface {
 	HasStagedChanges(ctx context.Context) (bool, error)
 	HasUnstagedChanges(ctx context.Context) (bool, error)
 	AddAll(ctx context.Context) error
+	AddFiles(ctx context.Context, paths []string) error
+	GetUnstagedFiles(ctx context.Context) ([]UnstagedFile, error)
 	Pull(ctx context.Context) (*PullResult, error)
 	Push(ctx context.Context) error
 	PushWithUpstream(ctx context.Context) error
 	HasRemote(ctx context.Context) (bool, error)
 	HasUpstream(ctx context.Context) (bool, error)
 	GetCurrentBranch(ctx context.Context) (string, error)
+	GetHeadCommit(ctx context.Context) (string, error)
+	GetRecentCommits(ctx context.Context, n int) ([]string, error)
+	GetCommitMessages(ctx context.Context, base string) ([]string, error)
+	GetCommitTemplate(ctx context.Context) (string, error)
+	GetGitDir(ctx context.Context) (string, error)
+	GetRepoRoot(ctx context.Context) (string, error)
+	ListTags(ctx context.Context) ([]string, error)
+	GetCommitsSince(ctx context.Context, ref string) ([]string, error)
+	GetAuthoredCommits(ctx context.Context, since, author string) ([]AuthoredCommit, error)
+	GetCommitDiff(ctx context.Context, hash string) ([]DiffChunk, error)
+	GetRemoteURL(ctx context.Context, name string) (string, error)
+	GetUserEmail(ctx context.Context) (string, error)
+	GetAuthorIdent(ctx context.Context) (*Ident, error)
+	CreateTag(ctx context.Context, name, message string, sign bool) error
+	WriteIndexTree(ctx context.Context) (string, error)
+	StageFromTree(ctx context.Context, tree string, paths []string) error
 }
 
 // DefaultClient implements the Client interface using exec.CommandContext.
@@ -88,16 +161,133 @@ type DefaultClient struct {
 	// workDir is the working directory for git commands.
 	// If empty, uses the current directory.
 	workDir string
+	// maxDiffMemory caps the diff content (in bytes) kept by GetStagedDiff.
+	maxDiffMemory int
+	// commandTimeout is the timeout for quick git commands.
+	commandTimeout time.Duration
+	// scope selects which changes are diffed and committed.
+	scope CommitScope
+	// pathspecs are the paths read from scope.PathspecFile.
+	pathspecs []string
+	// runner runs the git commands.
+	runner Runner
 }
 
 // NewClient creates a new DefaultClient.
 func NewClient() *DefaultClient {
-	return &DefaultClient{}
+	return NewClientWithWorkDir("")
 }
 
 // NewClientWithWorkDir creates a new DefaultClient with a specific working directory.
+// Its commands are logged at debug level.
 func NewClientWithWorkDir(workDir string) *DefaultClient {
-	return &DefaultClient{workDir: workDir}
+	return &DefaultClient{
+		workDir:        workDir,
+		maxDiffMemory:  DefaultMaxDiffMemory,
+		commandTimeout: GitCommandTimeout,
+		runner:         &loggingRunner{runner: ExecRunner{}},
+	}
+}
+
+// SetRunner replaces the runner of the git commands. They are still logged
+// at debug level.
+func (c *DefaultClient) SetRunner(runner Runner) {
+	c.runner = &loggingRunner{runner: runner}
+}
+
+// SetMaxDiffMemory sets the cap (in bytes) on diff content kept in memory.
+// Content beyond the cap is omitted; zero or less uses DefaultMaxDiffMemory.
+func (c *DefaultClient) SetMaxDiffMemory(maxBytes int) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxDiffMemory
+	}
+	c.maxDiffMemory = maxBytes
+}
+
+// SetCommandTimeout sets the timeout for quick git commands. Commands that scale
+// with the staging area get LongCommandTimeoutFactor times as long.
+// Zero or less uses GitCommandTimeout.
+func (c *DefaultClient) SetCommandTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = GitCommandTimeout
+	}
+	c.commandTimeout = timeout
+}
+
+// longCommandTimeout returns the timeout for commands that scale with the staging area.
+func (c *DefaultClient) longCommandTimeout() time.Duration {
+	return c.commandTimeout * LongCommandTimeoutFactor
+}
+
+// newTimeoutError creates a timeout error naming the git command that timed out.
+func newTimeoutError(timeout time.Duration, args []string) error {
+	// Shortened by characters, since a commit message or path may hold multi-byte ones
+	command := []rune(strings.Join(args, " "))
+	if len(command) > 80 {
+		command = append(command[:77], []rune("...")...)
+	}
+	return apperrors.NewGitTimeoutError(string(command), timeout)
+}
+
+// gitPathEnvVars are environment variables holding repository paths that git
+// resolves against its working directory.
+var gitPathEnvVars = []string{"GIT_DIR", "GIT_WORK_TREE"}
+
+// command creates a git command that runs in the client's working directory,
+// like "git -C <dir>". The environment is inherited, so GIT_DIR, GIT_WORK_TREE
+// and repository settings such as core.hooksPath apply as they do for git.
+// Relative GIT_DIR and GIT_WORK_TREE values are made absolute first, since they
+// refer to the current directory rather than the working directory.
+func (c *DefaultClient) command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if c.workDir == "" {
+		return cmd
+	}
+
+	cmd.Dir = c.workDir
+	for _, name := range gitPathEnvVars {
+		value := os.Getenv(name)
+		if value == "" || filepath.IsAbs(value) {
+			continue
+		}
+		if abs, err := filepath.Abs(value); err == nil {
+			if cmd.Env == nil {
+				cmd.Env = os.Environ()
+			}
+			cmd.Env = append(cmd.Env, name+"="+abs)
+		}
+	}
+	return cmd
+}
+
+// checkWorkTree verifies that git commands run inside a work tree, turning the
+// cryptic failures of a bare repository into a clear error.
+func (c *DefaultClient) checkWorkTree(ctx context.Context) error {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "rev-parse", "--is-bare-repository", "--is-inside-work-tree")
+
+	output, err := c.combinedOutput(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return newTimeoutError(timeout, cmd.Args)
+		}
+		return apperrors.NewGitError(err, string(output))
+	}
+
+	fields := strings.Fields(string(output))
+	if len(fields) < 2 {
+		return nil
+	}
+	if fields[0] == "true" {
+		return apperrors.NewBareRepositoryError()
+	}
+	if fields[1] != "true" {
+		return apperrors.NewNotInWorkTreeError()
+	}
+	return nil
 }
 
 // lockFilePatterns contains patterns for lock files that should be excluded.
@@ -130,20 +320,22 @@ func isLockFile(filePath string) bool {
 
 // HasStagedChanges checks if there are any staged changes in the repository.
 func (c *DefaultClient) HasStagedChanges(ctx context.Context) (bool, error) {
+	if err := c.checkWorkTree(ctx); err != nil {
+		return false, err
+	}
+
 	// Apply timeout to context
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "diff", "--cached", "--quiet")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, c.diffArgs(ctx, "--quiet")...)
 
-	err := cmd.Run()
+	err := c.run(cmd)
 	if err != nil {
 		// Check for context timeout
 		if ctx.Err() == context.DeadlineExceeded {
-			return false, apperrors.NewTimeoutError(ctx.Err())
+			return false, newTimeoutError(timeout, cmd.Args)
 		}
 		// Exit code 1 means there are differences (staged changes exist)
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -157,7 +349,8 @@ func (c *DefaultClient) HasStagedChanges(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
-// GetStagedDiff retrieves all staged changes as DiffChunks.
+// GetStagedDiff retrieves all staged changes as DiffChunks. When a commit scope
+// is set, it retrieves the changes in scope instead.
 func (c *DefaultClient) GetStagedDiff(ctx context.Context) ([]DiffChunk, error) {
 	// First check if there are staged changes
 	hasChanges, err := c.HasStagedChanges(ctx)
@@ -169,19 +362,17 @@ func (c *DefaultClient) GetStagedDiff(ctx context.Context) ([]DiffChunk, error)
 	}
 
 	// Apply timeout to context for remaining operations
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Get the full diff content
-	diffCmd := exec.CommandContext(ctx, "git", "diff", "--cached")
-	if c.workDir != "" {
-		diffCmd.Dir = c.workDir
-	}
+	// Get numstat first so the diff can be parsed while it streams in
+	numstatCmd := c.command(ctx, c.diffArgs(ctx, "--numstat", "-z")...)
 
-	diffOutput, err := diffCmd.Output()
+	numstatOutput, err := c.output(numstatCmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, apperrors.NewTimeoutError(ctx.Err())
+			return nil, newTimeoutError(timeout, numstatCmd.Args)
 		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return nil, apperrors.NewGitError(err, string(exitErr.Stderr))
@@ -189,28 +380,41 @@ func (c *DefaultClient) GetStagedDiff(ctx context.Context) ([]DiffChunk, error)
 		return nil, apperrors.NewGitError(err, "")
 	}
 
-	// Get numstat for additions/deletions count
-	numstatCmd := exec.CommandContext(ctx, "git", "diff", "--cached", "--numstat")
-	if c.workDir != "" {
-		numstatCmd.Dir = c.workDir
-	}
+	// Parse numstat to get file statistics
+	fileStats := parseNumstat(numstatOutput)
 
-	numstatOutput, err := numstatCmd.Output()
-	if err != nil {
+	// Stream the full diff content, parsing one file section at a time
+	diffCmd := c.command(ctx, c.diffArgs(ctx)...)
+
+	var stderr bytes.Buffer
+	diffCmd.Stderr = &stderr
+
+	stdout, writer := io.Pipe()
+	diffCmd.Stdout = writer
+	done := make(chan error, 1)
+	go func() {
+		err := c.run(diffCmd)
+		writer.CloseWithError(err)
+		done <- err
+	}()
+
+	chunks, omitted, parseErr := parseDiffStream(stdout, fileStats, c.maxDiffMemory)
+	// Unblock git if parsing stopped early
+	stdout.Close()
+
+	if err := <-done; err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, apperrors.NewTimeoutError(ctx.Err())
-		}
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, apperrors.NewGitError(err, string(exitErr.Stderr))
+			return nil, newTimeoutError(timeout, diffCmd.Args)
 		}
-		return nil, apperrors.NewGitError(err, "")
+		return nil, apperrors.NewGitError(err, stderr.String())
+	}
+	if parseErr != nil {
+		return nil, apperrors.NewGitError(parseErr, "")
 	}
 
-	// Parse numstat to get file statistics
-	fileStats := parseNumstat(numstatOutput)
-
-	// Parse the diff output into chunks
-	chunks := parseDiff(diffOutput, fileStats)
+	if omitted {
+		apperrors.Warn("Staged diff exceeds %d bytes; content of the remaining files was omitted", c.maxDiffMemory)
+	}
 
 	return chunks, nil
 }
@@ -238,18 +442,16 @@ func (c *DefaultClient) GetDiffStats(ctx context.Context) (*DiffStats, error) {
 // Commit executes a git commit with the given message.
 func (c *DefaultClient) Commit(ctx context.Context, message string) error {
 	// Apply timeout to context
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "commit", "-m", message)
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, c.commitArgs(message)...)
 
-	output, err := cmd.CombinedOutput()
+	output, err := c.combinedOutput(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return apperrors.NewTimeoutError(ctx.Err())
+			return newTimeoutError(timeout, cmd.Args)
 		}
 		return apperrors.NewGitError(err, string(output))
 	}
@@ -258,19 +460,17 @@ func (c *DefaultClient) Commit(ctx context.Context, message string) error {
 
 // HasUnstagedChanges checks if there are any unstaged changes (modified/untracked files).
 func (c *DefaultClient) HasUnstagedChanges(ctx context.Context) (bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Check for modified files (not staged)
-	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, "status", "--porcelain")
 
-	output, err := cmd.Output()
+	output, err := c.output(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return false, apperrors.NewTimeoutError(ctx.Err())
+			return false, newTimeoutError(timeout, cmd.Args)
 		}
 		return false, apperrors.NewGitError(err, "")
 	}
@@ -281,24 +481,106 @@ func (c *DefaultClient) HasUnstagedChanges(ctx context.Context) (bool, error) {
 
 // AddAll stages all changes (git add .).
 func (c *DefaultClient) AddAll(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "add", ".")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
+	cmd := c.command(ctx, "add", ".")
+
+	output, err := c.combinedOutput(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return newTimeoutError(timeout, cmd.Args)
+		}
+		return apperrors.NewGitError(err, string(output))
+	}
+	return nil
+}
+
+// AddFiles stages the given paths (git add -- <paths>), including deletions.
+// Paths are relative to the repository root, as reported by GetUnstagedFiles.
+func (c *DefaultClient) AddFiles(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return nil
 	}
 
-	output, err := cmd.CombinedOutput()
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Resolve against the root rather than the working directory, and do not
+	// expand glob characters in file names
+	args := []string{"add", "--"}
+	for _, path := range paths {
+		args = append(args, ":(top,literal)"+path)
+	}
+	cmd := c.command(ctx, args...)
+
+	output, err := c.combinedOutput(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return apperrors.NewTimeoutError(ctx.Err())
+			return newTimeoutError(timeout, cmd.Args)
 		}
 		return apperrors.NewGitError(err, string(output))
 	}
 	return nil
 }
 
+// UnstagedFile is a file with changes that are not staged.
+type UnstagedFile struct {
+	Path      string
+	Untracked bool
+	Deleted   bool
+}
+
+// GetUnstagedFiles lists files with unstaged changes, including untracked files.
+// Untracked directories are listed as a whole, as git status shows them.
+func (c *DefaultClient) GetUnstagedFiles(ctx context.Context) ([]UnstagedFile, error) {
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "status", "--porcelain", "-z")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	return parseStatus(output), nil
+}
+
+// parseStatus parses "git status --porcelain -z" output into unstaged files.
+// Entries are "XY path", where Y is the work tree status; renames and copies
+// are followed by an extra entry holding the original path.
+func parseStatus(output []byte) []UnstagedFile {
+	var files []UnstagedFile
+	entries := strings.Split(string(output), "\x00")
+
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		x, y, path := entry[0], entry[1], entry[3:]
+		if x == 'R' || x == 'C' {
+			i++ // Skip the original path
+		}
+
+		switch {
+		case x == '?' && y == '?':
+			files = append(files, UnstagedFile{Path: path, Untracked: true})
+		case y != ' ':
+			files = append(files, UnstagedFile{Path: path, Deleted: y == 'D'})
+		}
+	}
+	return files
+}
+
 // Push pushes commits to the remote repository.
 // If setUpstream is true and there's no upstream, it will set the upstream to origin/<branch>.
 func (c *DefaultClient) Push(ctx context.Context) error {
@@ -313,7 +595,8 @@ func (c *DefaultClient) PushWithUpstream(ctx context.Context) error {
 // pushInternal handles the actual push logic.
 func (c *DefaultClient) pushInternal(ctx context.Context, setUpstream bool) error {
 	// Use longer timeout for push (network operation)
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	timeout := GitNetworkTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	args := []string{"push"}
@@ -325,15 +608,12 @@ func (c *DefaultClient) pushInternal(ctx context.Context, setUpstream bool) erro
 		args = append(args, "-u", "origin", branch)
 	}
 
-	cmd := exec.CommandContext(ctx, "git", args...)
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, args...)
 
-	output, err := cmd.CombinedOutput()
+	output, err := c.combinedOutput(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return apperrors.NewTimeoutError(ctx.Err())
+			return newTimeoutError(timeout, cmd.Args)
 		}
 		return apperrors.NewGitError(err, string(output))
 	}
@@ -342,18 +622,180 @@ func (c *DefaultClient) pushInternal(ctx context.Context, setUpstream bool) erro
 
 // GetCurrentBranch returns the name of the current branch.
 func (c *DefaultClient) GetCurrentBranch(ctx context.Context) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "HEAD")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
+	cmd := c.command(ctx, "rev-parse", "--abbrev-ref", "HEAD")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		return "", apperrors.NewGitError(err, "")
 	}
 
-	output, err := cmd.Output()
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetHeadCommit returns the full hash of the commit HEAD points to.
+func (c *DefaultClient) GetHeadCommit(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "rev-parse", "--verify", "HEAD")
+
+	output, err := c.output(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return "", apperrors.NewTimeoutError(ctx.Err())
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetRecentCommits returns the subjects of the last n commits on the current branch,
+// most recent first. A repository without commits yields an empty list.
+func (c *DefaultClient) GetRecentCommits(ctx context.Context, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// An unborn branch has no HEAD; git log would fail on it
+	headCmd := c.command(ctx, "rev-parse", "--verify", "--quiet", "HEAD")
+	if err := c.run(headCmd); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, headCmd.Args)
+		}
+		return nil, nil
+	}
+
+	cmd := c.command(ctx, "log", fmt.Sprintf("--max-count=%d", n), "--format=%s")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	var subjects []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects, nil
+}
+
+// GetCommitMessages returns the full messages of the commits on the current
+// branch that are not on base, oldest first. Merge commits are left out.
+func (c *DefaultClient) GetCommitMessages(ctx context.Context, base string) ([]string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Messages are NUL-terminated since they span several lines
+	cmd := c.command(ctx, "log", "--no-merges", "--reverse", "--format=%B%x00", base+"..HEAD", "--")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, apperrors.NewGitError(err, string(exitErr.Stderr))
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	var messages []string
+	for _, message := range strings.Split(string(output), "\x00") {
+		if message = strings.TrimSpace(normalizeLineEndings(message)); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// GetCommitTemplate returns the contents of the file configured as git's
+// commit.template, or an empty string if none is configured.
+func (c *DefaultClient) GetCommitTemplate(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// --path expands "~/" the way git commit does
+	cmd := c.command(ctx, "config", "--path", "--get", "commit.template")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		// Exit code 1 means the key is not set
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+
+	path := strings.TrimSpace(string(output))
+	if path == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(path) && c.workDir != "" {
+		path = filepath.Join(c.workDir, path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit template: %w", err)
+	}
+	return normalizeLineEndings(string(content)), nil
+}
+
+// GetGitDir returns the absolute path of the repository's .git directory.
+// In a linked worktree this is the worktree's own git directory.
+func (c *DefaultClient) GetGitDir(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "rev-parse", "--absolute-git-dir")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetRepoRoot returns the absolute path of the top-level directory of the work tree.
+func (c *DefaultClient) GetRepoRoot(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "rev-parse", "--show-toplevel")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
 		}
 		return "", apperrors.NewGitError(err, "")
 	}
@@ -363,18 +805,16 @@ func (c *DefaultClient) GetCurrentBranch(ctx context.Context) (string, error) {
 
 // HasRemote checks if the repository has a remote configured.
 func (c *DefaultClient) HasRemote(ctx context.Context) (bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "remote")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, "remote")
 
-	output, err := cmd.Output()
+	output, err := c.output(cmd)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return false, apperrors.NewTimeoutError(ctx.Err())
+			return false, newTimeoutError(timeout, cmd.Args)
 		}
 		return false, apperrors.NewGitError(err, "")
 	}
@@ -392,15 +832,12 @@ type PullResult struct {
 
 // HasUpstream checks if the current branch has an upstream tracking branch.
 func (c *DefaultClient) HasUpstream(ctx context.Context) (bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, GitCommandTimeout)
+	ctx, cancel := context.WithTimeout(ctx, c.commandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
 
-	err := cmd.Run()
+	err := c.run(cmd)
 	if err != nil {
 		// Exit code 128 means no upstream configured
 		return false, nil
@@ -420,20 +857,18 @@ func (c *DefaultClient) Pull(ctx context.Context) (*PullResult, error) {
 	}
 
 	// Use longer timeout for pull (network operation)
-	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	timeout := GitNetworkTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "git", "pull", "--rebase")
-	if c.workDir != "" {
-		cmd.Dir = c.workDir
-	}
+	cmd := c.command(ctx, "pull", "--rebase")
 
-	output, err := cmd.CombinedOutput()
+	output, err := c.combinedOutput(cmd)
 	outputStr := string(output)
 
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, apperrors.NewTimeoutError(ctx.Err())
+			return nil, newTimeoutError(timeout, cmd.Args)
 		}
 		return nil, apperrors.NewGitError(err, outputStr)
 	}
@@ -480,26 +915,29 @@ type fileStat struct {
 	isBinary  bool
 }
 
-// parseNumstat parses the output of git diff --numstat.
-// Format: additions<TAB>deletions<TAB>filepath
-// Binary files show as: -<TAB>-<TAB>filepath
+// parseNumstat parses the output of git diff --numstat -z.
+// Format: additions<TAB>deletions<TAB>path<NUL>; renames and copies leave the
+// path empty and follow with old<NUL>new<NUL>. Paths are never quoted with -z.
+// Binary files show as: -<TAB>-<TAB>path
 func parseNumstat(output []byte) map[string]fileStat {
 	stats := make(map[string]fileStat)
-	scanner := bufio.NewScanner(bytes.NewReader(output))
+	fields := strings.Split(string(output), "\x00")
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		parts := strings.Split(line, "\t")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
 		if len(parts) < 3 {
 			continue
 		}
 
 		addStr, delStr, filePath := parts[0], parts[1], parts[2]
 
-		// Handle renamed files: old path => new path
-		if strings.Contains(filePath, " => ") {
-			// Extract the new path from rename notation
-			filePath = extractNewPath(filePath)
+		// Handle renamed files: the old and new path follow
+		if filePath == "" {
+			if i+2 >= len(fields) {
+				break
+			}
+			filePath = fields[i+2]
+			i += 2
 		}
 
 		stat := fileStat{}
@@ -517,66 +955,6 @@ func parseNumstat(output []byte) map[string]fileStat {
 	return stats
 }
 
-// extractNewPath extracts the new file path from git rename notation.
-// Examples:
-//   - "old.txt => new.txt" -> "new.txt"
-//   - "{old => new}/file.txt" -> "new/file.txt"
-//   - "dir/{old.txt => new.txt}" -> "dir/new.txt"
-func extractNewPath(renamePath string) string {
-	// Handle simple rename: "old.txt => new.txt"
-	if strings.Contains(renamePath, " => ") && !strings.Contains(renamePath, "{") {
-		parts := strings.Split(renamePath, " => ")
-		if len(parts) == 2 {
-			return strings.TrimSpace(parts[1])
-		}
-	}
-
-	// Handle brace notation: "{old => new}/file.txt" or "dir/{old.txt => new.txt}"
-	re := regexp.MustCompile(`\{([^}]*) => ([^}]*)\}`)
-	result := re.ReplaceAllString(renamePath, "$2")
-	return result
-}
-
-// parseDiff parses the full diff output into DiffChunks.
-func parseDiff(diffOutput []byte, fileStats map[string]fileStat) []DiffChunk {
-	var chunks []DiffChunk
-
-	// Split diff by file headers
-	// Each file diff starts with "diff --git a/... b/..."
-	diffStr := string(diffOutput)
-	fileDiffs := splitByFileDiff(diffStr)
-
-	for _, fileDiff := range fileDiffs {
-		if fileDiff == "" {
-			continue
-		}
-
-		chunk := parseFileDiff(fileDiff, fileStats)
-		if chunk != nil {
-			chunks = append(chunks, *chunk)
-		}
-	}
-
-	return chunks
-}
-
-// splitByFileDiff splits the diff output by file boundaries.
-func splitByFileDiff(diffStr string) []string {
-	// Split on "diff --git" but keep the delimiter
-	parts := strings.Split(diffStr, "diff --git ")
-	var result []string
-	for i, part := range parts {
-		if part == "" {
-			continue
-		}
-		if i > 0 {
-			part = "diff --git " + part
-		}
-		result = append(result, part)
-	}
-	return result
-}
-
 // parseFileDiff parses a single file's diff into a DiffChunk.
 func parseFileDiff(fileDiff string, fileStats map[string]fileStat) *DiffChunk {
 	lines := strings.Split(fileDiff, "\n")
@@ -588,14 +966,40 @@ func parseFileDiff(fileDiff string, fileStats map[string]fileStat) *DiffChunk {
 		Content: fileDiff,
 	}
 
-	// Parse the diff header to extract file path and change type
+	// Parse the diff header to extract file path and change type. Paths may be
+	// C-style quoted; the header ends at the first hunk
+	entryMode := ""
 	for _, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			break
+		}
+
+		// The object mode tells symlinks and submodules from regular files:
+		// "index abc..def 120000", "new file mode 160000", ...
+		if fields := strings.Fields(line); len(fields) == 3 && fields[0] == "index" {
+			entryMode = fields[2]
+		}
+		for _, prefix := range []string{"new file mode ", "deleted file mode ", "new mode "} {
+			if strings.HasPrefix(line, prefix) {
+				entryMode = strings.TrimPrefix(line, prefix)
+			}
+		}
+
 		// Parse "diff --git a/path b/path"
 		if strings.HasPrefix(line, "diff --git ") {
 			chunk.FilePath = extractFilePath(line)
 			chunk.ChangeType = ChangeTypeModified // Default
 		}
 
+		// "+++ b/path" names the new file unambiguously, unlike the
+		// "diff --git" line for paths with spaces. Git appends a tab to
+		// paths containing spaces
+		if strings.HasPrefix(line, "+++ ") {
+			if path := strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t"); path != "/dev/null" {
+				chunk.FilePath = strings.TrimPrefix(unquotePath(path), "b/")
+			}
+		}
+
 		// Detect new file
 		if strings.HasPrefix(line, "new file mode") {
 			chunk.ChangeType = ChangeTypeAdded
@@ -608,11 +1012,19 @@ func parseFileDiff(fileDiff string, fileStats map[string]fileStat) *DiffChunk {
 
 		// Detect renamed file
 		if strings.HasPrefix(line, "rename from ") {
-			chunk.OldPath = strings.TrimPrefix(line, "rename from ")
+			chunk.OldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
 			chunk.ChangeType = ChangeTypeRenamed
 		}
 		if strings.HasPrefix(line, "rename to ") {
-			chunk.FilePath = strings.TrimPrefix(line, "rename to ")
+			chunk.FilePath = unquotePath(strings.TrimPrefix(line, "rename to "))
+		}
+
+		// Detect mode change
+		if strings.HasPrefix(line, "old mode ") {
+			chunk.ModeChange.OldMode = strings.TrimPrefix(line, "old mode ")
+		}
+		if strings.HasPrefix(line, "new mode ") {
+			chunk.ModeChange.NewMode = strings.TrimPrefix(line, "new mode ")
 		}
 
 		// Detect binary file
@@ -621,6 +1033,18 @@ func parseFileDiff(fileDiff string, fileStats map[string]fileStat) *DiffChunk {
 		}
 	}
 
+	// Symlink and submodule hunks hold a target path or commit, not content
+	switch entryMode {
+	case symlinkMode:
+		chunk.ChangeType = ChangeTypeSymlink
+		chunk.OldTarget, chunk.NewTarget = parseEntryTargets(lines, "")
+	case submoduleMode:
+		chunk.ChangeType = ChangeTypeSubmodule
+		chunk.OldTarget, chunk.NewTarget = parseEntryTargets(lines, "Subproject commit ")
+		chunk.OldTarget = strings.TrimSuffix(chunk.OldTarget, "-dirty")
+		chunk.NewTarget = strings.TrimSuffix(chunk.NewTarget, "-dirty")
+	}
+
 	// Get statistics from numstat
 	if stat, ok := fileStats[chunk.FilePath]; ok {
 		chunk.Additions = stat.additions
@@ -634,21 +1058,39 @@ func parseFileDiff(fileDiff string, fileStats map[string]fileStat) *DiffChunk {
 	return chunk
 }
 
+// parseEntryTargets returns the removed and added values of a symlink or
+// submodule hunk, whose lines are "-<value>" and "+<value>" after the prefix.
+func parseEntryTargets(lines []string, prefix string) (oldTarget, newTarget string) {
+	inHunk := false
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+			continue
+		case strings.HasPrefix(line, "-") && oldTarget == "":
+			oldTarget = strings.TrimPrefix(line[1:], prefix)
+		case strings.HasPrefix(line, "+") && newTarget == "":
+			newTarget = strings.TrimPrefix(line[1:], prefix)
+		}
+	}
+	return oldTarget, newTarget
+}
+
 // extractFilePath extracts the file path from a diff header line.
-// Format: "diff --git a/path/to/file b/path/to/file"
+// Format: "diff --git a/path/to/file b/path/to/file", where either path may
+// be quoted.
 func extractFilePath(line string) string {
 	// Remove "diff --git " prefix
 	line = strings.TrimPrefix(line, "diff --git ")
 
-	// Split by " b/"
-	parts := strings.Split(line, " b/")
-	if len(parts) >= 2 {
-		return parts[1]
+	if _, newPath, ok := splitDiffGitPaths(line); ok {
+		return strings.TrimPrefix(newPath, "b/")
 	}
 
 	// Fallback: try to extract from "a/path"
 	if strings.HasPrefix(line, "a/") {
-		parts = strings.SplitN(line, " ", 2)
+		parts := strings.SplitN(line, " ", 2)
 		if len(parts) > 0 {
 			return strings.TrimPrefix(parts[0], "a/")
 		}
@@ -3,10 +3,17 @@ package git
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
 )
 
 // setupTestRepo creates a temporary git repository for testing.
@@ -316,6 +323,365 @@ func TestCommit(t *testing.T) {
 	}
 }
 
+func TestGetHeadCommit(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	if _, err := client.GetHeadCommit(context.Background()); err == nil {
+		t.Error("expected an error in a repository without commits")
+	}
+
+	writeFile(t, tmpDir, "README.md", "# Test")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "initial commit")
+
+	sha, err := client.GetHeadCommit(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := strings.TrimSpace(runGit(t, tmpDir, "rev-parse", "HEAD")); sha != want {
+		t.Errorf("GetHeadCommit() = %q, want %q", sha, want)
+	}
+}
+
+func TestGetRecentCommits(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+
+	// A repository without commits has no history
+	commits, err := client.GetRecentCommits(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("unexpected error on empty repo: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("expected no commits, got %v", commits)
+	}
+
+	for i, subject := range []string{"feat: first", "fix: second", "refactor: third"} {
+		writeFile(t, tmpDir, "file.txt", subject)
+		runGit(t, tmpDir, "add", ".")
+		runGit(t, tmpDir, "commit", "-m", subject+"\n\nbody line "+string(rune('a'+i)))
+	}
+
+	commits, err = client.GetRecentCommits(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commits) != 2 || commits[0] != "refactor: third" || commits[1] != "fix: second" {
+		t.Errorf("expected [refactor: third, fix: second], got %v", commits)
+	}
+
+	commits, err = client.GetRecentCommits(context.Background(), 0)
+	if err != nil || commits != nil {
+		t.Errorf("expected nil for n=0, got %v, %v", commits, err)
+	}
+}
+
+func TestGetCommitTemplate(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+
+	template, err := client.GetCommitTemplate(context.Background())
+	if err != nil || template != "" {
+		t.Fatalf("expected no template, got %q, %v", template, err)
+	}
+
+	// Relative paths are resolved against the repository, with CRLF normalized
+	writeFile(t, tmpDir, ".gitmessage", "[JIRA-ID] Summary\r\n\r\n# Why was this change made?\r\n")
+	runGit(t, tmpDir, "config", "commit.template", ".gitmessage")
+
+	template, err = client.GetCommitTemplate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template != "[JIRA-ID] Summary\n\n# Why was this change made?\n" {
+		t.Errorf("unexpected template %q", template)
+	}
+
+	runGit(t, tmpDir, "config", "commit.template", "missing.txt")
+	if _, err := client.GetCommitTemplate(context.Background()); err == nil {
+		t.Error("expected an error for a missing template file")
+	}
+}
+
+func TestGetGitDir(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+
+	gitDir, err := client.GetGitDir(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(filepath.Join(tmpDir, ".git"))
+	if got, _ := filepath.EvalSymlinks(gitDir); got != want {
+		t.Errorf("GetGitDir() = %q, want %q", gitDir, want)
+	}
+}
+
+func TestGetRepoRoot(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	subDir := filepath.Join(tmpDir, "pkg")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatalf("failed to create subdirectory: %v", err)
+	}
+	client := NewClientWithWorkDir(subDir)
+
+	root, err := client.GetRepoRoot(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(tmpDir)
+	if got, _ := filepath.EvalSymlinks(root); got != want {
+		t.Errorf("GetRepoRoot() = %q, want %q", root, want)
+	}
+}
+
+func TestHasStagedChanges_BareRepository(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "gitsage-bare-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	runGit(t, tmpDir, "init", "--bare")
+
+	client := NewClientWithWorkDir(tmpDir)
+	_, err = client.HasStagedChanges(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "cannot commit in bare repository") {
+		t.Errorf("expected bare repository error, got %v", err)
+	}
+}
+
+func TestHasStagedChanges_InsideGitDir(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(filepath.Join(tmpDir, ".git"))
+	_, err := client.HasStagedChanges(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "not inside a git work tree") {
+		t.Errorf("expected work tree error, got %v", err)
+	}
+}
+
+func TestGetStagedDiff_Worktree(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "main.go", "package main\n")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "initial commit")
+
+	worktree := filepath.Join(tmpDir, "wt")
+	runGit(t, tmpDir, "worktree", "add", "-b", "feature", worktree)
+	writeFile(t, worktree, "feature.go", "package main\n")
+	runGit(t, worktree, "add", "feature.go")
+
+	client := NewClientWithWorkDir(worktree)
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "feature.go" {
+		t.Errorf("expected feature.go staged in the worktree, got %+v", chunks)
+	}
+}
+
+func TestCommand_RelativeGitDir(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	rel, err := filepath.Rel(cwd, filepath.Join(tmpDir, ".git"))
+	if err != nil {
+		t.Skipf("no relative path to temp dir: %v", err)
+	}
+	t.Setenv("GIT_DIR", rel)
+	t.Setenv("GIT_WORK_TREE", "")
+
+	// The relative GIT_DIR refers to the current directory, not the work dir
+	client := NewClientWithWorkDir(os.TempDir())
+	cmd := client.command(context.Background(), "status")
+
+	want := "GIT_DIR=" + filepath.Join(tmpDir, ".git")
+	found := false
+	for _, env := range cmd.Env {
+		if env == want {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected %s in the command environment", want)
+	}
+}
+
+func TestCommit_HooksPath(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	// A commit-msg hook in core.hooksPath that rejects every commit
+	writeFile(t, tmpDir, "hooks/commit-msg", "#!/bin/sh\necho rejected by hook >&2\nexit 1\n")
+	if err := os.Chmod(filepath.Join(tmpDir, "hooks", "commit-msg"), 0755); err != nil {
+		t.Fatalf("failed to make hook executable: %v", err)
+	}
+	runGit(t, tmpDir, "config", "core.hooksPath", "hooks")
+
+	writeFile(t, tmpDir, "main.go", "package main\n")
+	runGit(t, tmpDir, "add", "main.go")
+
+	client := NewClientWithWorkDir(tmpDir)
+	err := client.Commit(context.Background(), "feat: add main")
+
+	if err == nil {
+		t.Fatal("expected the hook in core.hooksPath to reject the commit")
+	}
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || !strings.Contains(fmt.Sprint(appErr.Context["output"]), "rejected by hook") {
+		t.Errorf("expected hook output in the error, got %v", err)
+	}
+}
+
+func TestSetCommandTimeout(t *testing.T) {
+	client := NewClient()
+	if client.longCommandTimeout() != GitCommandTimeout*LongCommandTimeoutFactor {
+		t.Errorf("expected default long timeout %v, got %v", GitCommandTimeout*LongCommandTimeoutFactor, client.longCommandTimeout())
+	}
+
+	client.SetCommandTimeout(30 * time.Second)
+	if client.commandTimeout != 30*time.Second || client.longCommandTimeout() != 180*time.Second {
+		t.Errorf("unexpected timeouts: %v, %v", client.commandTimeout, client.longCommandTimeout())
+	}
+
+	client.SetCommandTimeout(0)
+	if client.commandTimeout != GitCommandTimeout {
+		t.Errorf("expected zero to reset to the default, got %v", client.commandTimeout)
+	}
+}
+
+func TestCommandTimeout_NamesCommand(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	client.SetCommandTimeout(time.Nanosecond)
+
+	_, err := client.HasRemote(context.Background())
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.ErrGitTimeout {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "git remote") {
+		t.Errorf("expected the error to name the command, got %q", err.Error())
+	}
+}
+
+func TestNewTimeoutError_ShortensByCharacter(t *testing.T) {
+	err := newTimeoutError(time.Second, []string{"git", "commit", "-m", strings.Repeat("修复", 50)})
+
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected an *AppError, got %v", err)
+	}
+	command, _ := appErr.Context["command"].(string)
+	if !utf8.ValidString(command) {
+		t.Errorf("command %q is not valid UTF-8", command)
+	}
+	if utf8.RuneCountInString(command) != 80 || !strings.HasSuffix(command, "...") {
+		t.Errorf("command = %q, want 80 characters ending in ...", command)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	output := " M a.go\x00?? notes.txt~\x00D  gone.go\x00 D removed.go\x00R  new.go\x00old.go\x00RM moved.go\x00orig.go\x00?? tmp/\x00"
+
+	files := parseStatus([]byte(output))
+	expected := []UnstagedFile{
+		{Path: "a.go"},
+		{Path: "notes.txt~", Untracked: true},
+		{Path: "removed.go", Deleted: true},
+		{Path: "moved.go"},
+		{Path: "tmp/", Untracked: true},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %+v", len(expected), files)
+	}
+	for i := range expected {
+		if files[i] != expected[i] {
+			t.Errorf("file %d = %+v, want %+v", i, files[i], expected[i])
+		}
+	}
+}
+
+func TestAddFiles(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "main.go", "package main\n")
+	writeFile(t, tmpDir, "main.go.bak", "package main\n")
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	files, err := client.GetUnstagedFiles(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 || !files[0].Untracked || !files[1].Untracked {
+		t.Fatalf("expected two untracked files, got %+v", files)
+	}
+
+	if err := client.AddFiles(ctx, []string{"main.go"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := runGit(t, tmpDir, "status", "--porcelain")
+	if !strings.Contains(status, "A  main.go") || !strings.Contains(status, "?? main.go.bak") {
+		t.Errorf("expected only main.go to be staged, got %q", status)
+	}
+}
+
+func TestAddFiles_FromSubdirectory(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "sub/a.txt", "a\n")
+	writeFile(t, tmpDir, "b[1].txt", "b\n")
+
+	client := NewClientWithWorkDir(filepath.Join(tmpDir, "sub"))
+	ctx := context.Background()
+
+	files, err := client.GetUnstagedFiles(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+
+	if err := client.AddFiles(ctx, paths); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status := runGit(t, tmpDir, "status", "--porcelain")
+	if !strings.Contains(status, "A  sub/a.txt") || !strings.Contains(status, "A  b[1].txt") {
+		t.Errorf("expected both files to be staged, got %q", status)
+	}
+}
+
 func TestGetDiffStats(t *testing.T) {
 	tmpDir := setupTestRepo(t)
 	defer os.RemoveAll(tmpDir)
@@ -347,24 +713,125 @@ func TestGetDiffStats(t *testing.T) {
 	}
 }
 
-func TestExtractNewPath(t *testing.T) {
+func TestGetStagedDiff_ModeChange(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "build.sh", "#!/bin/sh\necho build\n")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "initial commit")
+
+	if err := os.Chmod(filepath.Join(tmpDir, "build.sh"), 0o755); err != nil {
+		t.Fatalf("failed to chmod: %v", err)
+	}
+	runGit(t, tmpDir, "add", "build.sh")
+
+	client := NewClientWithWorkDir(tmpDir)
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+
+	change := chunks[0].ModeChange
+	if change.OldMode != "100644" || change.NewMode != "100755" {
+		t.Errorf("expected mode change 100644 -> 100755, got %+v", change)
+	}
+	if change.Describe() != "made executable" {
+		t.Errorf("Describe() = %q, want %q", change.Describe(), "made executable")
+	}
+}
+
+func TestGetStagedDiff_SymlinkAndSubmodule(t *testing.T) {
+	subDir := setupTestRepo(t)
+	defer os.RemoveAll(subDir)
+	writeFile(t, subDir, "lib.go", "package lib\n")
+	runGit(t, subDir, "add", ".")
+	runGit(t, subDir, "commit", "-m", "initial commit")
+	firstCommit := strings.TrimSpace(runGit(t, subDir, "rev-parse", "HEAD"))
+
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+	if err := os.Symlink("config.dev.yaml", filepath.Join(tmpDir, "config.yaml")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	runGit(t, tmpDir, "-c", "protocol.file.allow=always", "submodule", "add", "-q", subDir, "lib")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "initial commit")
+
+	// Retarget the symlink and move the submodule forward
+	if err := os.Remove(filepath.Join(tmpDir, "config.yaml")); err != nil {
+		t.Fatalf("failed to remove symlink: %v", err)
+	}
+	if err := os.Symlink("config.prod.yaml", filepath.Join(tmpDir, "config.yaml")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+	libDir := filepath.Join(tmpDir, "lib")
+	runGit(t, libDir, "config", "user.email", "test@example.com")
+	runGit(t, libDir, "config", "user.name", "Test User")
+	writeFile(t, libDir, "lib.go", "package lib\n\nfunc Lib() {}\n")
+	runGit(t, libDir, "commit", "-qam", "add Lib")
+	secondCommit := strings.TrimSpace(runGit(t, libDir, "rev-parse", "HEAD"))
+	runGit(t, tmpDir, "add", "config.yaml", "lib")
+
+	client := NewClientWithWorkDir(tmpDir)
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %+v", chunks)
+	}
+
+	link, sub := chunks[0], chunks[1]
+	if link.ChangeType != ChangeTypeSymlink || link.OldTarget != "config.dev.yaml" || link.NewTarget != "config.prod.yaml" {
+		t.Errorf("unexpected symlink chunk: %+v", link)
+	}
+	if sub.ChangeType != ChangeTypeSubmodule || sub.OldTarget != firstCommit || sub.NewTarget != secondCommit {
+		t.Errorf("unexpected submodule chunk: %+v", sub)
+	}
+}
+
+func TestModeChange_Describe(t *testing.T) {
 	tests := []struct {
-		input    string
+		change   ModeChange
 		expected string
 	}{
-		{"old.txt => new.txt", "new.txt"},
-		{"{old => new}/file.txt", "new/file.txt"},
-		{"dir/{old.txt => new.txt}", "dir/new.txt"},
-		{"src/{old => new}/main.go", "src/new/main.go"},
+		{ModeChange{}, ""},
+		{ModeChange{OldMode: "100644", NewMode: "100755"}, "made executable"},
+		{ModeChange{OldMode: "100755", NewMode: "100644"}, "made non-executable"},
+		{ModeChange{OldMode: "100644", NewMode: "120000"}, "mode changed from 100644 to 120000"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := extractNewPath(tt.input)
-			if result != tt.expected {
-				t.Errorf("extractNewPath(%q) = %q, want %q", tt.input, result, tt.expected)
-			}
-		})
+		if got := tt.change.Describe(); got != tt.expected {
+			t.Errorf("Describe(%+v) = %q, want %q", tt.change, got, tt.expected)
+		}
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	output := "1\t0\ta.txt\x00-\t-\tlogo.png\x00" +
+		"2\t1\t\x00old name.txt\x00\xe6\x96\x87 \xe4\xbb\xb6.txt\x00" +
+		"0\t0\t\x00src/old/main.go\x00src/new/main.go\x00"
+
+	stats := parseNumstat([]byte(output))
+
+	expected := map[string]fileStat{
+		"a.txt":           {additions: 1},
+		"logo.png":        {isBinary: true},
+		"文 件.txt":         {additions: 2, deletions: 1},
+		"src/new/main.go": {},
+	}
+	if len(stats) != len(expected) {
+		t.Fatalf("expected %d entries, got %+v", len(expected), stats)
+	}
+	for path, want := range expected {
+		if got, ok := stats[path]; !ok || got != want {
+			t.Errorf("stats[%q] = %+v, want %+v", path, got, want)
+		}
 	}
 }
 
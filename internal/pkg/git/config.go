@@ -0,0 +1,96 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// GetConfig returns the value of a git config variable, or an empty string
+// if it is not set. With global, only the user's config is read.
+func (c *DefaultClient) GetConfig(ctx context.Context, key string, global bool) (string, error) {
+	output, err := c.readConfig(ctx, configArgs(global, "--get", key))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// GetConfigSection returns the variables of a git config section, such as
+// "gitsage", from all config files with the usual precedence. Keys are the
+// lowercase variable names without the section; a variable set more than
+// once keeps its last value, and one without a value is "true".
+func (c *DefaultClient) GetConfigSection(ctx context.Context, section string) (map[string]string, error) {
+	output, err := c.readConfig(ctx, []string{"config", "--null", "--get-regexp", "^" + regexp.QuoteMeta(section) + `\.`})
+	if err != nil {
+		return nil, err
+	}
+
+	vars := make(map[string]string)
+	// Each entry is the key, a newline and the value, terminated by NUL
+	for _, entry := range strings.Split(string(output), "\x00") {
+		if entry == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(entry, "\n")
+		if !ok {
+			value = "true"
+		}
+		vars[strings.ToLower(strings.TrimPrefix(key, section+"."))] = value
+	}
+	return vars, nil
+}
+
+// SetConfig sets a git config variable in the repository's config or, with
+// global, in the user's.
+func (c *DefaultClient) SetConfig(ctx context.Context, key, value string, global bool) error {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, configArgs(global, key, value)...)
+
+	output, err := c.combinedOutput(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return newTimeoutError(timeout, cmd.Args)
+		}
+		return apperrors.NewGitError(err, string(output))
+	}
+	return nil
+}
+
+// readConfig runs a git config query; no matching variable yields no output.
+func (c *DefaultClient) readConfig(ctx context.Context, args []string) ([]byte, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, args...)
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		// Exit code 1 means the key is not set
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+	return output, nil
+}
+
+// configArgs returns the arguments of a git config command, limited to the
+// user's config with global.
+func configArgs(global bool, args ...string) []string {
+	if global {
+		return append([]string{"config", "--global"}, args...)
+	}
+	return append([]string{"config"}, args...)
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfig(t *testing.T) {
+	// Keep the user's global config out of the test
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_GLOBAL", "")
+
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	if value, err := client.GetConfig(ctx, "alias.sage", false); err != nil || value != "" {
+		t.Fatalf("GetConfig() of unset key = %q, %v; want empty", value, err)
+	}
+	if vars, err := client.GetConfigSection(ctx, "gitsage"); err != nil || len(vars) != 0 {
+		t.Fatalf("GetConfigSection() of empty section = %v, %v; want none", vars, err)
+	}
+
+	if err := client.SetConfig(ctx, "alias.sage", "!gitsage", false); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	if value, err := client.GetConfig(ctx, "alias.sage", false); err != nil || value != "!gitsage" {
+		t.Errorf("GetConfig() = %q, %v; want %q", value, err, "!gitsage")
+	}
+	if value, err := client.GetConfig(ctx, "alias.sage", true); err != nil || value != "" {
+		t.Errorf("GetConfig() of global config = %q, %v; want empty", value, err)
+	}
+
+	runGit(t, tmpDir, "config", "gitsage.dryRun", "true")
+	runGit(t, tmpDir, "config", "gitsage.context", "first\nsecond")
+	runGit(t, tmpDir, "config", "--add", "gitsage.type", "feat")
+	runGit(t, tmpDir, "config", "--add", "gitsage.type", "fix")
+	runGit(t, tmpDir, "config", "gitsagex.other", "ignored")
+	// A variable without a value is a true boolean
+	f, err := os.OpenFile(filepath.Join(tmpDir, ".git", "config"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString("[gitsage]\n\tsplit\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	vars, err := client.GetConfigSection(ctx, "gitsage")
+	if err != nil {
+		t.Fatalf("GetConfigSection() error = %v", err)
+	}
+	want := map[string]string{"dryrun": "true", "context": "first\nsecond", "type": "fix", "split": "true"}
+	if len(vars) != len(want) {
+		t.Errorf("GetConfigSection() = %q, want %q", vars, want)
+	}
+	for key, value := range want {
+		if vars[key] != value {
+			t.Errorf("GetConfigSection()[%q] = %q, want %q", key, vars[key], value)
+		}
+	}
+}
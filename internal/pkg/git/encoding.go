@@ -0,0 +1,63 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// fallbackEncodings are tried in order for file content that is not valid
+// UTF-8. GB18030 covers GBK and GB2312 files; Windows-1252 decodes any byte
+// sequence, so it always succeeds.
+var fallbackEncodings = []encoding.Encoding{
+	simplifiedchinese.GB18030,
+	charmap.Windows1252,
+}
+
+// normalizeDiffText transcodes a file section of a diff to UTF-8 and
+// normalizes CRLF line endings to LF, so prompts and length checks always
+// see clean UTF-8 text.
+func normalizeDiffText(s string) string {
+	return normalizeLineEndings(toUTF8(s))
+}
+
+// toUTF8 returns s unchanged if it is valid UTF-8. Otherwise each invalid
+// line is transcoded on its own, since the old and new side of a diff may use
+// different encodings (e.g. a file converted from GBK to UTF-8).
+func toUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	for i, line := range lines {
+		if !utf8.ValidString(line) {
+			lines[i] = decodeLine(line)
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// decodeLine decodes a line with the first fallback encoding that yields no
+// replacement characters.
+func decodeLine(line string) string {
+	for _, enc := range fallbackEncodings {
+		decoded, err := enc.NewDecoder().String(line)
+		if err == nil && !strings.ContainsRune(decoded, utf8.RuneError) {
+			return decoded
+		}
+	}
+	return strings.ToValidUTF8(line, string(utf8.RuneError))
+}
+
+// normalizeLineEndings replaces CRLF line endings with LF.
+func normalizeLineEndings(s string) string {
+	if !strings.Contains(s, "\r\n") {
+		return s
+	}
+	return strings.ReplaceAll(s, "\r\n", "\n")
+}
@@ -0,0 +1,48 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNormalizeDiffText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"utf-8 is unchanged", "+新增函数\n", "+新增函数\n"},
+		{"crlf is normalized", "@@ -1 +1 @@\r\n-old\r\n+new\r\n", "@@ -1 +1 @@\n-old\n+new\n"},
+		{"gbk is transcoded", "+\xd6\xd0\xce\xc4\n", "+中文\n"},
+		{"latin-1 is transcoded", "+caf\xe9\n", "+café\n"},
+		{"lines are transcoded separately", "-\xd6\xd0\xce\xc4\n+中文\n", "-中文\n+中文\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeDiffText(tt.input); got != tt.expected {
+				t.Errorf("normalizeDiffText(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDiffStream_NonUTF8(t *testing.T) {
+	diff := "diff --git a/legacy.c b/legacy.c\r\n@@ -1 +1 @@\r\n-// \xb4\xed\xce\xf3\r\n+// caf\xe9\r\n"
+
+	chunks, _, err := parseDiffStream(strings.NewReader(diff), nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	if !utf8.ValidString(chunks[0].Content) || strings.Contains(chunks[0].Content, "\r") {
+		t.Errorf("expected UTF-8 content with LF line endings, got %q", chunks[0].Content)
+	}
+	if !strings.Contains(chunks[0].Content, "-// 错误\n") {
+		t.Errorf("expected the GBK line to be transcoded, got %q", chunks[0].Content)
+	}
+}
@@ -0,0 +1,70 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// RangeCommit is a commit in a revision range.
+type RangeCommit struct {
+	Hash    string
+	Message string
+}
+
+// GetCommitsInRange returns the commits of a revision range such as
+// "origin/main..HEAD", oldest first. Merge commits are left out. A
+// repository without commits yields an empty list.
+func (c *DefaultClient) GetCommitsInRange(ctx context.Context, revRange string) ([]RangeCommit, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if !c.hasHead(ctx) {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, []string{"git", "rev-parse", "HEAD"})
+		}
+		return nil, nil
+	}
+
+	// Fields are separated by US and commits terminated by NUL since
+	// messages span several lines
+	cmd := c.command(ctx, "log", "--no-merges", "--reverse", "--format=%H%x1f%B%x00", revRange, "--")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, apperrors.NewGitError(err, string(exitErr.Stderr))
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	var commits []RangeCommit
+	for _, record := range strings.Split(string(output), "\x00") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		commits = append(commits, RangeCommit{
+			Hash:    fields[0],
+			Message: strings.TrimSpace(normalizeLineEndings(fields[1])),
+		})
+	}
+	return commits, nil
+}
+
+// GetCommitDiff returns the changes a commit made to its parent, like
+// "git show", regardless of the commit scope. A root commit has no parent to
+// diff against and yields an error.
+func (c *DefaultClient) GetCommitDiff(ctx context.Context, hash string) ([]DiffChunk, error) {
+	scoped := *c
+	scoped.scope = CommitScope{Range: hash + "^.." + hash}
+	scoped.pathspecs = nil
+	return scoped.GetStagedDiff(ctx)
+}
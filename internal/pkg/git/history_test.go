@@ -0,0 +1,77 @@
+package git
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestGetCommitsInRange(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	commits, err := client.GetCommitsInRange(ctx, "main..HEAD")
+	if err != nil || len(commits) != 0 {
+		t.Fatalf("GetCommitsInRange() on empty repo = %v, %v; want no commits", commits, err)
+	}
+
+	writeFile(t, tmpDir, "a.txt", "a")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "chore: base")
+	runGit(t, tmpDir, "branch", "base")
+
+	writeFile(t, tmpDir, "b.txt", "b")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "feat(api): add b\n\nAdds the b endpoint.")
+	writeFile(t, tmpDir, "c.txt", "c")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "add c")
+
+	commits, err = client.GetCommitsInRange(ctx, "base..HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitsInRange() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0].Message != "feat(api): add b\n\nAdds the b endpoint." || commits[1].Message != "add c" {
+		t.Fatalf("GetCommitsInRange() = %+v; want both commits after base, oldest first", commits)
+	}
+	if len(commits[0].Hash) != 40 {
+		t.Errorf("expected a full hash, got %q", commits[0].Hash)
+	}
+
+	if _, err := client.GetCommitsInRange(ctx, "nonexistent..HEAD"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
+
+func TestGetCommitDiff(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a.txt", "a")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "chore: a")
+	writeFile(t, tmpDir, "b.txt", "b\nb\n")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "add b")
+	// Staged changes are not part of the commit
+	writeFile(t, tmpDir, "c.txt", "c")
+	runGit(t, tmpDir, "add", ".")
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	chunks, err := client.GetCommitDiff(ctx, "HEAD")
+	if err != nil {
+		t.Fatalf("GetCommitDiff() error = %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "b.txt" || chunks[0].Additions != 2 {
+		t.Errorf("GetCommitDiff() = %+v; want the addition of b.txt", chunks)
+	}
+
+	if _, err := client.GetCommitDiff(ctx, "HEAD~1"); err == nil {
+		t.Error("expected an error for a root commit")
+	}
+}
@@ -0,0 +1,67 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Ident is the author or committer of a commit.
+type Ident struct {
+	Name  string
+	Email string
+	When  time.Time
+}
+
+// GetAuthorIdent returns the author the next commit is made with, honoring
+// GIT_AUTHOR_NAME, GIT_AUTHOR_EMAIL and GIT_AUTHOR_DATE like git commit.
+func (c *DefaultClient) GetAuthorIdent(ctx context.Context) (*Ident, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "var", "GIT_AUTHOR_IDENT")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+	return ParseIdent(strings.TrimSpace(string(output)))
+}
+
+// ParseIdent parses an ident in git's raw form, e.g.
+// "Jane Doe <jane@example.com> 1700000000 +0100".
+func ParseIdent(raw string) (*Ident, error) {
+	open, closing := strings.LastIndex(raw, "<"), strings.LastIndex(raw, ">")
+	if open < 0 || closing < open {
+		return nil, fmt.Errorf("invalid ident %q", raw)
+	}
+	ident := &Ident{
+		Name:  strings.TrimSpace(raw[:open]),
+		Email: raw[open+1 : closing],
+	}
+
+	fields := strings.Fields(raw[closing+1:])
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid ident date %q", raw)
+	}
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ident date %q", raw)
+	}
+	zone, err := time.Parse("-0700", fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ident zone %q", raw)
+	}
+	_, offset := zone.Zone()
+	ident.When = time.Unix(seconds, 0).In(time.FixedZone(fields[1], offset))
+	return ident, nil
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestParseIdent(t *testing.T) {
+	ident, err := ParseIdent("Jane Doe <jane@example.com> 1700000000 +0130")
+	if err != nil {
+		t.Fatalf("ParseIdent() error = %v", err)
+	}
+	if ident.Name != "Jane Doe" || ident.Email != "jane@example.com" {
+		t.Errorf("ParseIdent() = %+v", ident)
+	}
+	if got := ident.When.Format("Mon Jan 2 15:04:05 2006 -0700"); got != "Tue Nov 14 23:43:20 2023 +0130" {
+		t.Errorf("When = %s", got)
+	}
+
+	for _, raw := range []string{"Jane Doe", "Jane <jane@example.com>", "Jane <jane@example.com> soon +0000"} {
+		if _, err := ParseIdent(raw); err == nil {
+			t.Errorf("ParseIdent(%q) should fail", raw)
+		}
+	}
+}
+
+func TestGetAuthorIdent(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+	t.Setenv("GIT_AUTHOR_DATE", "1700000000 +0000")
+
+	ident, err := NewClientWithWorkDir(tmpDir).GetAuthorIdent(context.Background())
+	if err != nil {
+		t.Fatalf("GetAuthorIdent() error = %v", err)
+	}
+	if ident.Email != "test@example.com" || ident.When.Unix() != 1700000000 {
+		t.Errorf("GetAuthorIdent() = %+v", ident)
+	}
+}
@@ -0,0 +1,84 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"net/url"
+	"os/exec"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Hosting platforms of a remote, which differ in how commits reference issues.
+const (
+	PlatformGitHub    = "github"
+	PlatformGitLab    = "gitlab"
+	PlatformGitea     = "gitea"
+	PlatformBitbucket = "bitbucket"
+)
+
+// GetRemoteURL returns the URL of the named remote, with url.<base>.insteadOf
+// rewrites applied, or an empty string if there is no such remote.
+func (c *DefaultClient) GetRemoteURL(ctx context.Context, name string) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "remote", "get-url", name)
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		// git exits with 2 for a missing remote
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 2 {
+			return "", nil
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// DetectPlatform returns the hosting platform of a remote URL from its host
+// name, or an empty string for a host it does not recognize, such as a
+// self-hosted server on a custom domain. HTTPS, SSH and scp-like
+// ("git@host:path") URLs are understood.
+func DetectPlatform(remoteURL string) string {
+	host := strings.ToLower(remoteHost(remoteURL))
+	switch {
+	case host == "":
+		return ""
+	case host == "github.com" || strings.HasSuffix(host, ".ghe.com") || strings.HasPrefix(host, "github."):
+		return PlatformGitHub
+	case strings.Contains(host, "gitlab"):
+		return PlatformGitLab
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return PlatformGitea
+	case strings.Contains(host, "bitbucket"):
+		return PlatformBitbucket
+	}
+	return ""
+}
+
+// remoteHost returns the host name of a remote URL.
+func remoteHost(remoteURL string) string {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+
+	// scp-like syntax: [user@]host:path
+	host, _, found := strings.Cut(remoteURL, ":")
+	if !found || strings.Contains(host, "/") {
+		return ""
+	}
+	if _, after, ok := strings.Cut(host, "@"); ok {
+		host = after
+	}
+	return host
+}
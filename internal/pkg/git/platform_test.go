@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	tests := []struct {
+		remoteURL string
+		want      string
+	}{
+		{"https://github.com/gitsage/gitsage.git", PlatformGitHub},
+		{"git@github.com:gitsage/gitsage.git", PlatformGitHub},
+		{"ssh://git@GitHub.com/gitsage/gitsage.git", PlatformGitHub},
+		{"https://acme.ghe.com/team/repo.git", PlatformGitHub},
+		{"https://gitlab.com/group/sub/repo.git", PlatformGitLab},
+		{"git@gitlab.example.com:group/repo.git", PlatformGitLab},
+		{"https://codeberg.org/user/repo.git", PlatformGitea},
+		{"https://gitea.example.com/user/repo", PlatformGitea},
+		{"ssh://git@forgejo.example.com:2222/user/repo.git", PlatformGitea},
+		{"git@bitbucket.org:team/repo.git", PlatformBitbucket},
+		{"https://git.example.com/team/repo.git", ""},
+		{"/srv/git/repo.git", ""},
+		{"../repo", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := DetectPlatform(tt.remoteURL); got != tt.want {
+			t.Errorf("DetectPlatform(%q) = %q, want %q", tt.remoteURL, got, tt.want)
+		}
+	}
+}
+
+func TestGetRemoteURL(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	got, err := client.GetRemoteURL(ctx, "origin")
+	if err != nil || got != "" {
+		t.Fatalf("GetRemoteURL() without a remote = %q, %v; want empty", got, err)
+	}
+
+	runGit(t, tmpDir, "remote", "add", "origin", "git@gitlab.com:group/repo.git")
+	got, err = client.GetRemoteURL(ctx, "origin")
+	if err != nil || got != "git@gitlab.com:group/repo.git" {
+		t.Fatalf("GetRemoteURL() = %q, %v; want the origin URL", got, err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"strings"
+)
+
+// cEscapes maps the escape letters git uses in quoted paths to their bytes.
+var cEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 't': '\t', 'n': '\n', 'v': '\v', 'f': '\f', 'r': '\r',
+	'"': '"', '\\': '\\',
+}
+
+// unquotePath undoes git's C-style path quoting. Git quotes paths containing
+// special characters, and non-ASCII bytes unless core.quotepath is false, e.g.
+// "\346\226\207.txt" for 文.txt. Paths that are not quoted are returned as is.
+func unquotePath(path string) string {
+	unquoted, rest, ok := readQuoted(path)
+	if !ok || rest != "" {
+		return path
+	}
+	return unquoted
+}
+
+// readQuoted reads a C-style quoted string at the start of s and returns its
+// value and the text after the closing quote.
+func readQuoted(s string) (value, rest string, ok bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return "", s, false
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			return sb.String(), s[i+1:], true
+		case c != '\\':
+			sb.WriteByte(c)
+		case i+1 >= len(s):
+			return "", s, false
+		case isOctal(s[i+1]):
+			// Octal escapes are exactly three digits and encode one byte
+			if i+3 >= len(s) || !isOctal(s[i+2]) || !isOctal(s[i+3]) {
+				return "", s, false
+			}
+			sb.WriteByte((s[i+1]-'0')<<6 | (s[i+2]-'0')<<3 | (s[i+3] - '0'))
+			i += 3
+		default:
+			escaped, known := cEscapes[s[i+1]]
+			if !known {
+				return "", s, false
+			}
+			sb.WriteByte(escaped)
+			i++
+		}
+	}
+	return "", s, false
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+// splitDiffGitPaths splits the paths of a "diff --git a/<old> b/<new>" header
+// (without the "diff --git " prefix), either of which may be quoted. Unquoted
+// paths may contain spaces, so the header is split where both halves name
+// the same file when possible.
+func splitDiffGitPaths(header string) (oldPath, newPath string, ok bool) {
+	// Quoted old path: "a/..." b/... or "a/..." "b/..."
+	if value, rest, quoted := readQuoted(header); quoted {
+		return value, unquotePath(strings.TrimPrefix(rest, " ")), true
+	}
+
+	// Quoted new path only: a/... "b/..."
+	if strings.HasSuffix(header, `"`) {
+		if i := strings.LastIndex(header, ` "`); i >= 0 {
+			if value, rest, quoted := readQuoted(header[i+1:]); quoted && rest == "" {
+				return header[:i], value, true
+			}
+		}
+	}
+
+	// Unquoted paths of the same length, as in every header but renames
+	if len(header)%2 == 1 {
+		mid := len(header) / 2
+		oldPath, newPath = header[:mid], header[mid+1:]
+		if header[mid] == ' ' && len(oldPath) > 2 && oldPath[2:] == newPath[2:] {
+			return oldPath, newPath, true
+		}
+	}
+
+	if i := strings.Index(header, " b/"); i >= 0 {
+		return header[:i], header[i+1:], true
+	}
+	return "", "", false
+}
@@ -0,0 +1,90 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestUnquotePath(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plain.txt", "plain.txt"},
+		{"with space.txt", "with space.txt"},
+		{`"\346\226\207.txt"`, "文.txt"},
+		{`"a/tab\there.txt"`, "a/tab\there.txt"},
+		{`"quote\"and\\backslash"`, `quote"and\backslash`},
+		{`"文 件.txt"`, "文 件.txt"},
+		{`"unterminated`, `"unterminated`},
+		{`"bad\qescape"`, `"bad\qescape"`},
+	}
+
+	for _, tt := range tests {
+		if got := unquotePath(tt.input); got != tt.expected {
+			t.Errorf("unquotePath(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExtractFilePath(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"diff --git a/main.go b/main.go", "main.go"},
+		{"diff --git a/my file.txt b/my file.txt", "my file.txt"},
+		{"diff --git a/a b/c.txt b/a b/c.txt", "a b/c.txt"},
+		{`diff --git "a/\346\226\207.txt" "b/\346\226\207.txt"`, "文.txt"},
+		{`diff --git a/old name.txt "b/\346\226\207 \344\273\266.txt"`, "文 件.txt"},
+		{`diff --git "a/\346\226\207.txt" b/new.txt`, "new.txt"},
+	}
+
+	for _, tt := range tests {
+		if got := extractFilePath(tt.line); got != tt.expected {
+			t.Errorf("extractFilePath(%q) = %q, want %q", tt.line, got, tt.expected)
+		}
+	}
+}
+
+func TestGetStagedDiff_QuotedPaths(t *testing.T) {
+	for _, quotePath := range []string{"true", "false"} {
+		t.Run("core.quotepath="+quotePath, func(t *testing.T) {
+			tmpDir := setupTestRepo(t)
+			defer os.RemoveAll(tmpDir)
+			runGit(t, tmpDir, "config", "core.quotepath", quotePath)
+
+			writeFile(t, tmpDir, "old name.txt", "hello\n")
+			runGit(t, tmpDir, "add", ".")
+			runGit(t, tmpDir, "commit", "-m", "initial commit")
+
+			runGit(t, tmpDir, "mv", "old name.txt", "文 件.txt")
+			writeFile(t, tmpDir, "说明.md", "one\ntwo\n")
+			runGit(t, tmpDir, "add", ".")
+
+			client := NewClientWithWorkDir(tmpDir)
+			chunks, err := client.GetStagedDiff(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(chunks) != 2 {
+				t.Fatalf("expected 2 chunks, got %+v", chunks)
+			}
+
+			byPath := make(map[string]DiffChunk)
+			for _, chunk := range chunks {
+				byPath[chunk.FilePath] = chunk
+			}
+			renamed, ok := byPath["文 件.txt"]
+			if !ok || renamed.ChangeType != ChangeTypeRenamed || renamed.OldPath != "old name.txt" {
+				t.Errorf("expected the rename to be parsed, got %+v", chunks)
+			}
+			added, ok := byPath["说明.md"]
+			if !ok || added.ChangeType != ChangeTypeAdded || added.Additions != 2 {
+				t.Errorf("expected the new file with numstat counts, got %+v", chunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// AuthoredCommit is a commit listed in a work report.
+type AuthoredCommit struct {
+	Hash    string
+	Date    time.Time
+	Message string
+}
+
+// GetAuthoredCommits returns the commits on any branch by author since the
+// given date, most recent first. Merge commits are left out. since takes any
+// date "git log --since" understands, e.g. "yesterday" or "1 week ago", and
+// author is matched like "git log --author". A repository without commits
+// yields an empty list.
+func (c *DefaultClient) GetAuthoredCommits(ctx context.Context, since, author string) ([]AuthoredCommit, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if !c.hasHead(ctx) {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, []string{"git", "rev-parse", "HEAD"})
+		}
+		return nil, nil
+	}
+
+	// Fields are separated by US and commits terminated by NUL since
+	// messages span several lines
+	args := []string{"log", "--all", "--no-merges", "--format=%H%x1f%aI%x1f%B%x00"}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+	cmd := c.command(ctx, append(args, "--")...)
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, apperrors.NewGitError(err, string(exitErr.Stderr))
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	var commits []AuthoredCommit
+	for _, record := range strings.Split(string(output), "\x00") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[1])
+		commits = append(commits, AuthoredCommit{
+			Hash:    fields[0],
+			Date:    date,
+			Message: strings.TrimSpace(normalizeLineEndings(fields[2])),
+		})
+	}
+	return commits, nil
+}
+
+// GetUserEmail returns the configured user.email, or an empty string if none
+// is set.
+func (c *DefaultClient) GetUserEmail(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "config", "--get", "user.email")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		// Exit code 1 means the key is not set
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+func TestGetAuthoredCommits(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	commits, err := client.GetAuthoredCommits(ctx, "yesterday", "test@example.com")
+	if err != nil || len(commits) != 0 {
+		t.Fatalf("GetAuthoredCommits() on empty repo = %v, %v; want no commits", commits, err)
+	}
+
+	writeFile(t, tmpDir, "a.txt", "a")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "feat(api): add a\n\nAdds the a endpoint.")
+
+	// Commits on other branches and by other authors
+	runGit(t, tmpDir, "checkout", "-b", "feature")
+	writeFile(t, tmpDir, "b.txt", "b")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "fix: b")
+	writeFile(t, tmpDir, "c.txt", "c")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "-c", "user.email=other@example.com", "commit", "-m", "chore: c")
+	runGit(t, tmpDir, "checkout", "-")
+
+	commits, err = client.GetAuthoredCommits(ctx, "1 hour ago", "test@example.com")
+	if err != nil {
+		t.Fatalf("GetAuthoredCommits() error = %v", err)
+	}
+	messages := make(map[string]bool)
+	for _, commit := range commits {
+		messages[commit.Message] = true
+	}
+	if len(commits) != 2 || !messages["fix: b"] || !messages["feat(api): add a\n\nAdds the a endpoint."] {
+		t.Fatalf("GetAuthoredCommits() = %+v; want both commits by the author", commits)
+	}
+	if len(commits[0].Hash) != 40 || commits[0].Date.IsZero() {
+		t.Errorf("expected hash and date, got %+v", commits[0])
+	}
+
+	commits, err = client.GetAuthoredCommits(ctx, "2000-01-01", "nobody@example.com")
+	if err != nil || len(commits) != 0 {
+		t.Errorf("GetAuthoredCommits() for another author = %v, %v; want no commits", commits, err)
+	}
+}
+
+func TestGetUserEmail(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	email, err := NewClientWithWorkDir(tmpDir).GetUserEmail(context.Background())
+	if err != nil || email != "test@example.com" {
+		t.Errorf("GetUserEmail() = %q, %v; want test@example.com", email, err)
+	}
+}
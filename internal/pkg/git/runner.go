@@ -0,0 +1,142 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Runner runs the git commands of a client. Every command goes through it,
+// so each can be logged and tests can stand in for git.
+type Runner interface {
+	// Run starts cmd and waits for it to finish, like cmd.Run.
+	Run(cmd *exec.Cmd) error
+}
+
+// ExecRunner runs commands with os/exec.
+type ExecRunner struct{}
+
+// Run runs cmd.
+func (ExecRunner) Run(cmd *exec.Cmd) error {
+	return cmd.Run()
+}
+
+// CommandRecord is a git command run by GitSage, as it is logged.
+type CommandRecord struct {
+	// Args are the arguments after "git".
+	Args []string
+	// Dir is the working directory, empty for the current one.
+	Dir      string
+	Duration time.Duration
+	// ExitCode is the exit status of git, or -1 if it could not be started
+	// or was killed, e.g. on timeout.
+	ExitCode int
+	Err      error
+}
+
+// String formats the record as a line of the log, e.g.
+// "git diff --cached --quiet (exit 1, 4ms)".
+func (r CommandRecord) String() string {
+	var sb strings.Builder
+	sb.WriteString("git")
+	for _, arg := range r.Args {
+		sb.WriteString(" ")
+		sb.WriteString(quoteArg(arg))
+	}
+	if r.Dir != "" {
+		fmt.Fprintf(&sb, " in %s", r.Dir)
+	}
+	fmt.Fprintf(&sb, " (exit %d, %s)", r.ExitCode, r.Duration.Round(time.Millisecond))
+	if r.Err != nil && r.ExitCode < 0 {
+		fmt.Fprintf(&sb, ": %v", r.Err)
+	}
+	return sb.String()
+}
+
+// maxArgLength is the length at which arguments, such as a commit message
+// passed with -m, are shortened in the log.
+const maxArgLength = 60
+
+// quoteArg quotes an argument of the log if it holds spaces or line
+// breaks, shortening long ones.
+func quoteArg(arg string) string {
+	if len(arg) > maxArgLength {
+		arg = arg[:maxArgLength-3] + "..."
+	}
+	if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+		return fmt.Sprintf("%q", arg)
+	}
+	return arg
+}
+
+// loggingRunner runs commands with another runner and logs each as a debug
+// message, so that --verbose and the log file show what git was asked to do.
+type loggingRunner struct {
+	runner Runner
+}
+
+// Run runs cmd and logs it.
+func (r *loggingRunner) Run(cmd *exec.Cmd) error {
+	start := time.Now()
+	err := r.runner.Run(cmd)
+
+	record := CommandRecord{
+		Dir:      cmd.Dir,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if len(cmd.Args) > 0 {
+		record.Args = cmd.Args[1:]
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		record.ExitCode = 0
+	case errors.As(err, &exitErr):
+		record.ExitCode = exitErr.ExitCode()
+	default:
+		record.ExitCode = -1
+	}
+
+	apperrors.Debug("%s", record)
+	return err
+}
+
+// run runs cmd with the client's runner.
+func (c *DefaultClient) run(cmd *exec.Cmd) error {
+	return c.runner.Run(cmd)
+}
+
+// output runs cmd and returns its standard output, like cmd.Output: on
+// failure, the standard error is kept in the returned *exec.ExitError.
+func (c *DefaultClient) output(cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	captureStderr := cmd.Stderr == nil
+	if captureStderr {
+		cmd.Stderr = &stderr
+	}
+
+	err := c.run(cmd)
+	var exitErr *exec.ExitError
+	if captureStderr && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+// combinedOutput runs cmd and returns its standard output and standard
+// error interleaved, like cmd.CombinedOutput.
+func (c *DefaultClient) combinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := c.run(cmd)
+	return output.Bytes(), err
+}
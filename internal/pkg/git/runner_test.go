@@ -0,0 +1,161 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// fakeRunner answers every command with a fixed output instead of running git.
+type fakeRunner struct {
+	stdout string
+	err    error
+	args   [][]string
+}
+
+func (r *fakeRunner) Run(cmd *exec.Cmd) error {
+	r.args = append(r.args, cmd.Args[1:])
+	if cmd.Stdout != nil {
+		fmt.Fprint(cmd.Stdout, r.stdout)
+	}
+	return r.err
+}
+
+// captureLog returns a buffer receiving the log messages until the test ends.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	apperrors.SetLogFile(&buf)
+	t.Cleanup(func() { apperrors.SetLogFile(nil) })
+	return &buf
+}
+
+func TestCommandRecord_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		record CommandRecord
+		want   string
+	}{
+		{
+			name:   "success",
+			record: CommandRecord{Args: []string{"diff", "--cached", "--quiet"}, Duration: 4 * time.Millisecond, ExitCode: 1},
+			want:   "git diff --cached --quiet (exit 1, 4ms)",
+		},
+		{
+			name:   "quoted and shortened arguments",
+			record: CommandRecord{Args: []string{"commit", "-m", "feat: add a trace of the git commands run by gitsage for debugging"}, Dir: "/repo"},
+			want:   `git commit -m "feat: add a trace of the git commands run by gitsage for ..." in /repo (exit 0, 0s)`,
+		},
+		{
+			name:   "not started",
+			record: CommandRecord{Args: []string{"status"}, ExitCode: -1, Err: errors.New("executable file not found")},
+			want:   "git status (exit -1, 0s): executable file not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRunner(t *testing.T) {
+	runner := &fakeRunner{stdout: "Jane Doe\n"}
+	client := NewClient()
+	client.SetRunner(runner)
+
+	log := captureLog(t)
+	name, err := client.GetConfig(context.Background(), "user.name", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "Jane Doe" {
+		t.Errorf("GetConfig() = %q, want the output of the runner", name)
+	}
+	if len(runner.args) != 1 || strings.Join(runner.args[0], " ") != "config --get user.name" {
+		t.Errorf("runner got %v, want git config --get user.name", runner.args)
+	}
+
+	if !strings.Contains(log.String(), "DEBUG: git config --get user.name (exit 0, ") {
+		t.Errorf("log = %q, want the command with exit 0", log)
+	}
+}
+
+func TestSetRunner_StartFailure(t *testing.T) {
+	client := NewClient()
+	client.SetRunner(&fakeRunner{err: exec.ErrNotFound})
+
+	log := captureLog(t)
+	if _, err := client.GetConfig(context.Background(), "user.name", false); err == nil {
+		t.Fatal("expected an error when git cannot be started")
+	}
+
+	if !strings.Contains(log.String(), "(exit -1, 0s): "+exec.ErrNotFound.Error()) {
+		t.Errorf("log = %q, want the command with exit -1 and its error", log)
+	}
+}
+
+func TestLoggingRunner_RealGit(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "README.md", "# Test")
+	runGit(t, tmpDir, "add", ".")
+
+	log := captureLog(t)
+	client := NewClientWithWorkDir(tmpDir)
+	hasChanges, err := client.HasStagedChanges(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasChanges {
+		t.Fatal("expected staged changes")
+	}
+
+	// git diff --quiet exits 1 when there are differences
+	if !strings.Contains(log.String(), "--quiet in "+tmpDir+" (exit 1, ") {
+		t.Errorf("log = %q, want the git diff command with exit 1", log)
+	}
+}
+
+func TestOutput_KeepsStderr(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	_, err := client.output(client.command(context.Background(), "rev-parse", "--verify", "no-such-branch"))
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("output() error = %v, want an *exec.ExitError", err)
+	}
+	if len(exitErr.Stderr) == 0 {
+		t.Error("output() should keep the standard error in the *exec.ExitError")
+	}
+}
+
+func TestCombinedOutput(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	output, err := client.combinedOutput(client.command(context.Background(), "rev-parse", "--verify", "no-such-branch"))
+	if err == nil {
+		t.Fatal("expected an error for an unknown revision")
+	}
+	if !strings.Contains(string(output), "fatal") {
+		t.Errorf("combinedOutput() = %q, want the standard error", output)
+	}
+}
@@ -0,0 +1,127 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CommitScope selects which changes are diffed and committed. The zero value
+// uses the staging area, like a plain "git commit".
+type CommitScope struct {
+	// All also commits modified and deleted tracked files that are not staged,
+	// like "git commit -a".
+	All bool
+	// PathspecFile names a file listing the paths to commit, one per line, like
+	// "git commit --pathspec-from-file". Only those paths are committed, with
+	// their working tree content.
+	PathspecFile string
+	// Base diffs the commits on the current branch since it diverged from Base,
+	// like "git diff Base...HEAD", to describe them before a squash merge.
+	// Nothing can be committed in this scope.
+	Base string
+	// Range diffs a revision range such as "main..feature", like
+	// "git diff A..B", to describe changes that are already committed.
+	// Nothing can be committed in this scope.
+	Range string
+}
+
+// SetCommitScope sets which changes are diffed and committed. With a scope
+// other than the zero value, diffs are taken against HEAD instead of the index.
+func (c *DefaultClient) SetCommitScope(scope CommitScope) error {
+	if scope.All && scope.PathspecFile != "" {
+		return errors.New("--all cannot be combined with --pathspec-from-file")
+	}
+	if scope.Base != "" && (scope.All || scope.PathspecFile != "") {
+		return errors.New("--base cannot be combined with --all or --pathspec-from-file")
+	}
+	if scope.Range != "" {
+		if scope.All || scope.PathspecFile != "" || scope.Base != "" {
+			return errors.New("--range cannot be combined with --all, --pathspec-from-file or --base")
+		}
+		if !strings.Contains(scope.Range, "..") || strings.Trim(scope.Range, ".") == "" {
+			return fmt.Errorf("invalid range %q (expected A..B)", scope.Range)
+		}
+	}
+
+	var pathspecs []string
+	if scope.PathspecFile != "" {
+		abs, err := filepath.Abs(scope.PathspecFile)
+		if err != nil {
+			return fmt.Errorf("failed to resolve pathspec file: %w", err)
+		}
+		scope.PathspecFile = abs
+
+		pathspecs, err = readPathspecFile(abs)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.scope = scope
+	c.pathspecs = pathspecs
+	return nil
+}
+
+// readPathspecFile reads a pathspec file with one pathspec per line.
+func readPathspecFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pathspec file: %w", err)
+	}
+
+	var pathspecs []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+			pathspecs = append(pathspecs, line)
+		}
+	}
+	if len(pathspecs) == 0 {
+		return nil, fmt.Errorf("pathspec file %s is empty", path)
+	}
+	return pathspecs, nil
+}
+
+// diffArgs returns the git diff arguments for the changes in scope.
+// The staging area is compared with HEAD by default; with a commit scope the
+// working tree is, unless the branch has no commits yet. With a base, HEAD is
+// compared with its merge base, and with a range its two ends are compared.
+func (c *DefaultClient) diffArgs(ctx context.Context, extra ...string) []string {
+	args := []string{"diff", "--cached"}
+	switch {
+	case c.scope.Base != "":
+		args = []string{"diff", c.scope.Base + "...HEAD"}
+	case c.scope.Range != "":
+		args = []string{"diff", c.scope.Range}
+	case (c.scope.All || len(c.pathspecs) > 0) && c.hasHead(ctx):
+		args = []string{"diff", "HEAD"}
+	}
+	args = append(args, extra...)
+
+	if len(c.pathspecs) > 0 {
+		args = append(args, "--")
+		args = append(args, c.pathspecs...)
+	}
+	return args
+}
+
+// commitArgs returns the git commit arguments for the changes in scope.
+func (c *DefaultClient) commitArgs(message string) []string {
+	args := []string{"commit"}
+	if c.scope.All {
+		args = append(args, "-a")
+	}
+	if c.scope.PathspecFile != "" {
+		args = append(args, "--pathspec-from-file="+c.scope.PathspecFile)
+	}
+	return append(args, "-m", message)
+}
+
+// hasHead reports whether the current branch has any commits.
+func (c *DefaultClient) hasHead(ctx context.Context) bool {
+	return c.run(c.command(ctx, "rev-parse", "--verify", "--quiet", "HEAD")) == nil
+}
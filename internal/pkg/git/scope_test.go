@@ -0,0 +1,208 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupCommittedRepo creates a repository with a.go and b.go committed.
+func setupCommittedRepo(t *testing.T) string {
+	t.Helper()
+	tmpDir := setupTestRepo(t)
+
+	writeFile(t, tmpDir, "a.go", "package a\n")
+	writeFile(t, tmpDir, "b.go", "package b\n")
+	runGit(t, tmpDir, "add", ".")
+	runGit(t, tmpDir, "commit", "-m", "initial commit")
+	return tmpDir
+}
+
+func TestSetCommitScope_Invalid(t *testing.T) {
+	client := NewClient()
+
+	if err := client.SetCommitScope(CommitScope{All: true, PathspecFile: "paths.txt"}); err == nil {
+		t.Error("expected an error when combining All with a pathspec file")
+	}
+	if err := client.SetCommitScope(CommitScope{All: true, Base: "main"}); err == nil {
+		t.Error("expected an error when combining All with a base")
+	}
+	if err := client.SetCommitScope(CommitScope{Range: "main..HEAD", Base: "main"}); err == nil {
+		t.Error("expected an error when combining a range with a base")
+	}
+	for _, r := range []string{"main", "..", "..."} {
+		if err := client.SetCommitScope(CommitScope{Range: r}); err == nil {
+			t.Errorf("expected an error for range %q", r)
+		}
+	}
+	if err := client.SetCommitScope(CommitScope{PathspecFile: filepath.Join(t.TempDir(), "missing.txt")}); err == nil {
+		t.Error("expected an error for a missing pathspec file")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	writeFile(t, filepath.Dir(empty), "empty.txt", "\n\n")
+	if err := client.SetCommitScope(CommitScope{PathspecFile: empty}); err == nil {
+		t.Error("expected an error for an empty pathspec file")
+	}
+}
+
+func TestCommitScope_All(t *testing.T) {
+	tmpDir := setupCommittedRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	// Modify a tracked file without staging it
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n")
+
+	client := NewClientWithWorkDir(tmpDir)
+	if err := client.SetCommitScope(CommitScope{All: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "a.go" || chunks[0].Additions != 2 {
+		t.Fatalf("expected the unstaged change to a.go, got %+v", chunks)
+	}
+
+	if err := client.Commit(context.Background(), "feat: add A"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := runGit(t, tmpDir, "status", "--porcelain"); strings.TrimSpace(status) != "" {
+		t.Errorf("expected a clean tree after commit -a, got %q", status)
+	}
+}
+
+func TestCommitScope_PathspecFile(t *testing.T) {
+	tmpDir := setupCommittedRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n")
+	writeFile(t, tmpDir, "b.go", "package b\n\nfunc B() {}\n")
+	writeFile(t, tmpDir, "paths.txt", "a.go\n")
+
+	client := NewClientWithWorkDir(tmpDir)
+	if err := client.SetCommitScope(CommitScope{PathspecFile: filepath.Join(tmpDir, "paths.txt")}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "a.go" {
+		t.Fatalf("expected only a.go in the diff, got %+v", chunks)
+	}
+
+	if err := client.Commit(context.Background(), "feat: add A"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := runGit(t, tmpDir, "status", "--porcelain")
+	if strings.Contains(status, "a.go") || !strings.Contains(status, "b.go") {
+		t.Errorf("expected only a.go to be committed, got status %q", status)
+	}
+}
+
+func TestCommitScope_AllWithoutCommits(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "main.go", "package main\n")
+	runGit(t, tmpDir, "add", "main.go")
+
+	client := NewClientWithWorkDir(tmpDir)
+	if err := client.SetCommitScope(CommitScope{All: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without HEAD the staging area is diffed instead
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "main.go" {
+		t.Errorf("expected main.go, got %+v", chunks)
+	}
+}
+
+func TestCommitScope_Base(t *testing.T) {
+	tmpDir := setupCommittedRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	runGit(t, tmpDir, "branch", "-M", "main")
+	runGit(t, tmpDir, "checkout", "-b", "feature")
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n")
+	runGit(t, tmpDir, "commit", "-am", "feat: add A\n\nAdds the A function.")
+	writeFile(t, tmpDir, "b.go", "package b\n\nfunc B() {}\n")
+	runGit(t, tmpDir, "commit", "-am", "feat: add B")
+
+	// Staged changes are not part of the squashed commits
+	writeFile(t, tmpDir, "c.go", "package c\n")
+	runGit(t, tmpDir, "add", "c.go")
+
+	client := NewClientWithWorkDir(tmpDir)
+	if err := client.SetCommitScope(CommitScope{Base: "main"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var paths []string
+	for _, chunk := range chunks {
+		paths = append(paths, chunk.FilePath)
+	}
+	if strings.Join(paths, ",") != "a.go,b.go" {
+		t.Errorf("expected the changes to a.go and b.go, got %v", paths)
+	}
+
+	messages, err := client.GetCommitMessages(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 2 || messages[0] != "feat: add A\n\nAdds the A function." || messages[1] != "feat: add B" {
+		t.Errorf("expected both messages oldest first, got %q", messages)
+	}
+
+	if _, err := client.GetCommitMessages(context.Background(), "missing"); err == nil {
+		t.Error("expected an error for an unknown base")
+	}
+}
+
+func TestCommitScope_Range(t *testing.T) {
+	tmpDir := setupCommittedRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	runGit(t, tmpDir, "tag", "v1")
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n")
+	runGit(t, tmpDir, "commit", "-am", "feat: add A")
+
+	// Staged changes are not part of the range
+	writeFile(t, tmpDir, "c.go", "package c\n")
+	runGit(t, tmpDir, "add", "c.go")
+
+	client := NewClientWithWorkDir(tmpDir)
+	if err := client.SetCommitScope(CommitScope{Range: "v1..HEAD"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 || chunks[0].FilePath != "a.go" {
+		t.Errorf("expected the change to a.go, got %+v", chunks)
+	}
+
+	if err := client.SetCommitScope(CommitScope{Range: "HEAD..HEAD"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if has, err := client.HasStagedChanges(context.Background()); err != nil || has {
+		t.Errorf("expected no changes in an empty range, got %v, %v", has, err)
+	}
+}
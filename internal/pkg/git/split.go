@@ -0,0 +1,81 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// WriteIndexTree writes the staging area as a tree object and returns its
+// hash, so the staged content can be restored with StageFromTree.
+func (c *DefaultClient) WriteIndexTree(ctx context.Context) (string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := c.command(ctx, "write-tree")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", newTimeoutError(timeout, cmd.Args)
+		}
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", apperrors.NewGitError(err, string(exitErr.Stderr))
+		}
+		return "", apperrors.NewGitError(err, "")
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// StageFromTree resets the staging area to HEAD and stages the given paths,
+// relative to the repository root, with their content in tree. Without paths
+// the whole tree is staged. The working tree is left untouched.
+func (c *DefaultClient) StageFromTree(ctx context.Context, tree string, paths []string) error {
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if len(paths) == 0 {
+		return c.runIndexCommand(ctx, timeout, "", "read-tree", tree)
+	}
+
+	base := "HEAD"
+	if !c.hasHead(ctx) {
+		base = "--empty"
+	}
+	if err := c.runIndexCommand(ctx, timeout, "", "read-tree", base); err != nil {
+		return err
+	}
+
+	// Paths from diffs are relative to the root, not the working directory,
+	// and may contain glob characters
+	var pathspecs strings.Builder
+	for _, path := range paths {
+		pathspecs.WriteString(":(top,literal)" + path + "\x00")
+	}
+	return c.runIndexCommand(ctx, timeout, pathspecs.String(),
+		"reset", "--quiet", tree, "--pathspec-from-file=-", "--pathspec-file-nul")
+}
+
+// runIndexCommand runs a git command that updates the staging area.
+func (c *DefaultClient) runIndexCommand(ctx context.Context, timeout time.Duration, stdin string, args ...string) error {
+	cmd := c.command(ctx, args...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	output, err := c.combinedOutput(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return newTimeoutError(timeout, cmd.Args)
+		}
+		return apperrors.NewGitError(err, string(output))
+	}
+	return nil
+}
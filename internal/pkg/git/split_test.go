@@ -0,0 +1,54 @@
+package git
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestStageFromTree(t *testing.T) {
+	tmpDir := setupCommittedRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	// a.go is partially staged, b.go deleted and c/d.go added
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n")
+	runGit(t, tmpDir, "add", "a.go")
+	writeFile(t, tmpDir, "a.go", "package a\n\nfunc A() {}\n\nfunc Unstaged() {}\n")
+	runGit(t, tmpDir, "rm", "-q", "b.go")
+	if err := os.MkdirAll(tmpDir+"/c", 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, tmpDir, "c/d.go", "package c\n")
+	runGit(t, tmpDir, "add", "c/d.go")
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	tree, err := client.WriteIndexTree(ctx)
+	if err != nil || tree == "" {
+		t.Fatalf("WriteIndexTree() = %q, %v", tree, err)
+	}
+
+	if err := client.StageFromTree(ctx, tree, []string{"a.go", "b.go"}); err != nil {
+		t.Fatalf("StageFromTree() error = %v", err)
+	}
+	if staged := runGit(t, tmpDir, "diff", "--cached", "--name-status"); staged != "M\ta.go\nD\tb.go\n" {
+		t.Errorf("expected only a.go and b.go staged, got %q", staged)
+	}
+	// The staged version of a.go is kept, not the working tree one
+	if content := runGit(t, tmpDir, "show", ":a.go"); strings.Contains(content, "Unstaged") {
+		t.Errorf("expected the staged content of a.go, got %q", content)
+	}
+	runGit(t, tmpDir, "commit", "-q", "-m", "feat: a")
+
+	if err := client.StageFromTree(ctx, tree, nil); err != nil {
+		t.Fatalf("StageFromTree() error = %v", err)
+	}
+	if staged := runGit(t, tmpDir, "diff", "--cached", "--name-status"); staged != "A\tc/d.go\n" {
+		t.Errorf("expected the rest staged, got %q", staged)
+	}
+	if unstaged := runGit(t, tmpDir, "diff", "--name-only"); unstaged != "a.go\n" {
+		t.Errorf("expected the working tree change to a.go kept, got %q", unstaged)
+	}
+}
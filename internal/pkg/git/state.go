@@ -0,0 +1,81 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// RepoState is an operation git has stopped in the middle of, waiting for
+// the user to resolve conflicts or make changes.
+type RepoState string
+
+// Operations detected from the files git keeps in its directory.
+const (
+	StateNone       RepoState = ""
+	StateRebase     RepoState = "rebase"
+	StateApply      RepoState = "am"
+	StateCherryPick RepoState = "cherry-pick"
+	StateRevert     RepoState = "revert"
+	StateMerge      RepoState = "merge"
+	StateBisect     RepoState = "bisect"
+)
+
+// DetectState returns the operation in progress in the repository whose git
+// directory is gitDir. A rebase is reported before the cherry-pick it may be
+// stopped at, since "git rebase --continue" concludes both.
+func DetectState(gitDir string) RepoState {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+
+	switch {
+	case exists("rebase-merge"):
+		return StateRebase
+	case exists("rebase-apply"):
+		// "git rebase --apply" and "git am" share the directory
+		if exists(filepath.Join("rebase-apply", "applying")) {
+			return StateApply
+		}
+		return StateRebase
+	case exists("CHERRY_PICK_HEAD"):
+		return StateCherryPick
+	case exists("REVERT_HEAD"):
+		return StateRevert
+	case exists("MERGE_HEAD"):
+		return StateMerge
+	case exists("BISECT_LOG"):
+		return StateBisect
+	}
+	return StateNone
+}
+
+// MessageFile returns the file in gitDir holding the message git uses when
+// the operation is continued, or "" if a message cannot be given to it:
+// "git rebase --apply", "git am" and "git bisect" take no message.
+func MessageFile(gitDir string, state RepoState) string {
+	switch state {
+	case StateRebase:
+		// The apply backend has no message file for staged changes
+		if _, err := os.Stat(filepath.Join(gitDir, "rebase-merge")); err != nil {
+			return ""
+		}
+		return filepath.Join(gitDir, "rebase-merge", "message")
+	case StateCherryPick, StateRevert, StateMerge:
+		return filepath.Join(gitDir, "MERGE_MSG")
+	}
+	return ""
+}
+
+// ContinueCommand returns the command concluding the operation, e.g.
+// "git rebase --continue", or ending it for a bisect.
+func ContinueCommand(state RepoState) string {
+	switch state {
+	case StateNone:
+		return ""
+	case StateBisect:
+		return "git bisect reset"
+	}
+	return "git " + string(state) + " --continue"
+}
@@ -0,0 +1,59 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectState(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []string
+		state       RepoState
+		messageFile string
+		continueCmd string
+	}{
+		{name: "clean", state: StateNone},
+		{name: "interactive rebase", files: []string{"rebase-merge/", "CHERRY_PICK_HEAD"}, state: StateRebase, messageFile: "rebase-merge/message", continueCmd: "git rebase --continue"},
+		{name: "apply rebase", files: []string{"rebase-apply/"}, state: StateRebase, continueCmd: "git rebase --continue"},
+		{name: "am", files: []string{"rebase-apply/", "rebase-apply/applying"}, state: StateApply, continueCmd: "git am --continue"},
+		{name: "cherry-pick", files: []string{"CHERRY_PICK_HEAD"}, state: StateCherryPick, messageFile: "MERGE_MSG", continueCmd: "git cherry-pick --continue"},
+		{name: "revert", files: []string{"REVERT_HEAD"}, state: StateRevert, messageFile: "MERGE_MSG", continueCmd: "git revert --continue"},
+		{name: "merge", files: []string{"MERGE_HEAD"}, state: StateMerge, messageFile: "MERGE_MSG", continueCmd: "git merge --continue"},
+		{name: "bisect", files: []string{"BISECT_LOG"}, state: StateBisect, continueCmd: "git bisect reset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gitDir := t.TempDir()
+			for _, file := range tt.files {
+				path := filepath.Join(gitDir, file)
+				if file[len(file)-1] == '/' {
+					if err := os.MkdirAll(path, 0755); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+				if err := os.WriteFile(path, nil, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			state := DetectState(gitDir)
+			if state != tt.state {
+				t.Fatalf("DetectState() = %q, want %q", state, tt.state)
+			}
+			want := ""
+			if tt.messageFile != "" {
+				want = filepath.Join(gitDir, tt.messageFile)
+			}
+			if got := MessageFile(gitDir, state); got != want {
+				t.Errorf("MessageFile() = %q, want %q", got, want)
+			}
+			if got := ContinueCommand(state); got != tt.continueCmd {
+				t.Errorf("ContinueCommand() = %q, want %q", got, tt.continueCmd)
+			}
+		})
+	}
+}
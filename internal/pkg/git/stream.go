@@ -0,0 +1,133 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// DefaultMaxDiffMemory is the default cap (in bytes) on diff content held in memory.
+const DefaultMaxDiffMemory = 64 * 1024 * 1024 // 64MB
+
+// diffReadBufferSize is the read buffer size for streamed diff parsing.
+// Lines longer than the buffer are read in fragments.
+const diffReadBufferSize = 64 * 1024
+
+// diffOmittedNote ends a file's content when it was cut at the memory cap.
+const diffOmittedNote = "... [content omitted: diff memory limit reached]\n"
+
+// parseDiffStream parses git diff output one file section at a time, so the
+// full output is never held in memory as a single string.
+//
+// At most maxBytes of hunk content is kept across all files; once the cap is
+// reached, later hunk lines are read and discarded while file headers are
+// still kept, so every file is reported with its path and change type.
+// A maxBytes of zero or less disables the cap. The returned flag reports
+// whether any content was omitted. Each file section is transcoded to UTF-8
+// with LF line endings (see normalizeDiffText).
+func parseDiffStream(r io.Reader, fileStats map[string]fileStat, maxBytes int) ([]DiffChunk, bool, error) {
+	reader := bufio.NewReaderSize(r, diffReadBufferSize)
+
+	var chunks []DiffChunk
+	var section strings.Builder
+	used := 0
+	omitted := false
+
+	// State of the current file section
+	inHunks := false
+	sectionOmitted := false
+	lineStart := true
+
+	flush := func() {
+		if section.Len() == 0 {
+			return
+		}
+		if sectionOmitted {
+			if !strings.HasSuffix(section.String(), "\n") {
+				section.WriteString("\n")
+			}
+			section.WriteString(diffOmittedNote)
+		}
+		if chunk := parseFileDiff(normalizeDiffText(section.String()), fileStats); chunk != nil {
+			chunks = append(chunks, *chunk)
+		}
+		section.Reset()
+	}
+
+	for {
+		fragment, err := reader.ReadSlice('\n')
+		if len(fragment) > 0 {
+			if lineStart {
+				if hasPrefix(fragment, "diff --git ") {
+					flush()
+					inHunks = false
+					sectionOmitted = false
+				} else if hasPrefix(fragment, "@@") {
+					inHunks = true
+				}
+			}
+
+			switch {
+			case !inHunks || maxBytes <= 0:
+				section.Write(fragment)
+			case used+len(fragment) <= maxBytes:
+				section.Write(fragment)
+				used += len(fragment)
+			default:
+				// Stop keeping hunk content; the rest of the stream is drained
+				used = maxBytes
+				sectionOmitted = true
+				omitted = true
+			}
+
+			lineStart = fragment[len(fragment)-1] == '\n'
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != bufio.ErrBufferFull {
+			return nil, omitted, err
+		}
+	}
+	flush()
+
+	return chunks, omitted, nil
+}
+
+// ParseDiff parses the output of git diff, as produced without --numstat or
+// colors, into DiffChunks. Line counts are taken from the hunks, since no
+// numstat output is available.
+func ParseDiff(r io.Reader) ([]DiffChunk, error) {
+	chunks, _, err := parseDiffStream(r, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range chunks {
+		chunks[i].Additions, chunks[i].Deletions = countHunkLines(chunks[i].Content)
+	}
+	return chunks, nil
+}
+
+// countHunkLines counts the added and removed lines in a file's hunks.
+func countHunkLines(content string) (additions, deletions int) {
+	inHunks := false
+	for _, line := range strings.Split(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunks = true
+		case !inHunks:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// hasPrefix reports whether the byte slice starts with prefix.
+func hasPrefix(b []byte, prefix string) bool {
+	return len(b) >= len(prefix) && string(b[:len(prefix)]) == prefix
+}
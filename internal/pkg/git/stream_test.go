@@ -0,0 +1,165 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/a.go b/a.go
+index 1111111..2222222 100644
+--- a/a.go
++++ b/a.go
+@@ -1,1 +1,2 @@
+ package a
++// added
+diff --git a/b.go b/b.go
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/b.go
+@@ -0,0 +1,1 @@
++package b
+diff --git a/old.go b/new.go
+similarity index 100%
+rename from old.go
+rename to new.go
+`
+
+func TestParseDiffStream(t *testing.T) {
+	stats := map[string]fileStat{"a.go": {additions: 1}, "b.go": {additions: 1}}
+
+	chunks, omitted, err := parseDiffStream(strings.NewReader(sampleDiff), stats, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if omitted {
+		t.Error("expected no content to be omitted without a cap")
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks, got %d", len(chunks))
+	}
+
+	if chunks[0].FilePath != "a.go" || chunks[0].ChangeType != ChangeTypeModified || chunks[0].Additions != 1 {
+		t.Errorf("unexpected first chunk: %+v", chunks[0])
+	}
+	if chunks[1].FilePath != "b.go" || chunks[1].ChangeType != ChangeTypeAdded {
+		t.Errorf("unexpected second chunk: %+v", chunks[1])
+	}
+	if chunks[2].FilePath != "new.go" || chunks[2].OldPath != "old.go" || chunks[2].ChangeType != ChangeTypeRenamed {
+		t.Errorf("unexpected third chunk: %+v", chunks[2])
+	}
+
+	// Sections are kept byte for byte
+	var joined strings.Builder
+	for _, chunk := range chunks {
+		joined.WriteString(chunk.Content)
+	}
+	if joined.String() != sampleDiff {
+		t.Errorf("chunk contents do not add up to the input:\n%s", joined.String())
+	}
+}
+
+func TestParseDiffStream_MemoryCap(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("diff --git a/big.go b/big.go\n--- a/big.go\n+++ b/big.go\n@@ -0,0 +1,1000 @@\n")
+	for i := 0; i < 1000; i++ {
+		sb.WriteString("+generated line\n")
+	}
+	sb.WriteString("diff --git a/small.go b/small.go\nnew file mode 100644\n@@ -0,0 +1,1 @@\n+package small\n")
+
+	chunks, omitted, err := parseDiffStream(strings.NewReader(sb.String()), nil, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !omitted {
+		t.Error("expected content to be omitted")
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+
+	big := chunks[0]
+	if len(big.Content) > 2*1024 {
+		t.Errorf("expected capped content, got %d bytes", len(big.Content))
+	}
+	if !strings.HasSuffix(big.Content, diffOmittedNote) {
+		t.Errorf("expected omitted note, got %q", big.Content[len(big.Content)-60:])
+	}
+
+	// Later files keep their headers so they are still reported
+	small := chunks[1]
+	if small.FilePath != "small.go" || small.ChangeType != ChangeTypeAdded {
+		t.Errorf("unexpected second chunk: %+v", small)
+	}
+	if strings.Contains(small.Content, "+package small") {
+		t.Error("expected hunk content past the cap to be omitted")
+	}
+}
+
+func TestParseDiffStream_LongLine(t *testing.T) {
+	long := strings.Repeat("x", 3*diffReadBufferSize)
+	diff := "diff --git a/min.js b/min.js\n@@ -1 +1 @@\n+" + long + "\n+diff --git not a header\n"
+
+	chunks, _, err := parseDiffStream(strings.NewReader(diff), nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("expected 1 chunk, got %d", len(chunks))
+	}
+	if chunks[0].Content != diff {
+		t.Error("expected a line longer than the read buffer to be kept whole")
+	}
+}
+
+func TestParseDiff(t *testing.T) {
+	diff := sampleDiff + "diff --git a/c.go b/c.go\nindex 4444444..5555555 100644\n--- a/c.go\n+++ b/c.go\n@@ -1,3 +1,2 @@\n package c\n--- removed\n-var x = 1\n+var x = 2\n"
+
+	chunks, err := ParseDiff(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 4 {
+		t.Fatalf("expected 4 chunks, got %d", len(chunks))
+	}
+
+	// Counts come from the hunks; header lines are not changes
+	counts := [][2]int{{1, 0}, {1, 0}, {0, 0}, {1, 2}}
+	for i, want := range counts {
+		if chunks[i].Additions != want[0] || chunks[i].Deletions != want[1] {
+			t.Errorf("%s: got +%d -%d, want +%d -%d", chunks[i].FilePath, chunks[i].Additions, chunks[i].Deletions, want[0], want[1])
+		}
+	}
+	if chunks[3].FilePath != "c.go" {
+		t.Errorf("unexpected fourth chunk: %+v", chunks[3])
+	}
+}
+
+func TestGetStagedDiff_MaxDiffMemory(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a.txt", strings.Repeat("line\n", 1000))
+	writeFile(t, tmpDir, "b.txt", "small\n")
+	runGit(t, tmpDir, "add", ".")
+
+	client := NewClientWithWorkDir(tmpDir)
+	client.SetMaxDiffMemory(512)
+
+	chunks, err := client.GetStagedDiff(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if !strings.HasSuffix(chunks[0].Content, diffOmittedNote) {
+		t.Error("expected the first file to be cut at the memory cap")
+	}
+	if chunks[0].Additions != 1000 || chunks[1].FilePath != "b.txt" {
+		t.Errorf("expected stats and paths to survive the cap: %+v", chunks[1])
+	}
+}
@@ -0,0 +1,103 @@
+// Package git provides Git operations for GitSage.
+package git
+
+import (
+	"context"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// ListTags returns the tags reachable from HEAD, most recently created first.
+// A repository without commits yields an empty list.
+func (c *DefaultClient) ListTags(ctx context.Context) ([]string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if !c.hasHead(ctx) {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, []string{"git", "rev-parse", "HEAD"})
+		}
+		return nil, nil
+	}
+
+	cmd := c.command(ctx, "tag", "--list", "--merged", "HEAD", "--sort=-creatordate")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	return splitLines(output), nil
+}
+
+// GetCommitsSince returns the subjects of the commits on the current branch
+// after ref, most recent first. An empty ref yields the whole history.
+func (c *DefaultClient) GetCommitsSince(ctx context.Context, ref string) ([]string, error) {
+	timeout := c.commandTimeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if !c.hasHead(ctx) {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, []string{"git", "rev-parse", "HEAD"})
+		}
+		return nil, nil
+	}
+
+	revision := "HEAD"
+	if ref != "" {
+		revision = ref + "..HEAD"
+	}
+	cmd := c.command(ctx, "log", "--no-merges", "--format=%s", revision, "--")
+
+	output, err := c.output(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, newTimeoutError(timeout, cmd.Args)
+		}
+		return nil, apperrors.NewGitError(err, "")
+	}
+
+	return splitLines(output), nil
+}
+
+// CreateTag creates an annotated tag on HEAD with the given message, signed
+// with the user's GPG key if sign is set.
+func (c *DefaultClient) CreateTag(ctx context.Context, name, message string, sign bool) error {
+	// Signing may wait for a passphrase prompt
+	timeout := c.longCommandTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	mode := "--annotate"
+	if sign {
+		mode = "--sign"
+	}
+	// Markdown-style "#" headings in the message are kept
+	cmd := c.command(ctx, "tag", mode, "--cleanup=whitespace", "-m", message, name)
+
+	output, err := c.combinedOutput(cmd)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return newTimeoutError(timeout, cmd.Args)
+		}
+		return apperrors.NewGitError(err, string(output))
+	}
+	return nil
+}
+
+// splitLines returns the non-empty trimmed lines of git output.
+func splitLines(output []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
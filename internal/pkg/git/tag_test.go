@@ -0,0 +1,67 @@
+package git
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	tmpDir := setupTestRepo(t)
+	defer os.RemoveAll(tmpDir)
+
+	client := NewClientWithWorkDir(tmpDir)
+	ctx := context.Background()
+
+	// A repository without commits has neither tags nor history
+	tags, err := client.ListTags(ctx)
+	if err != nil || len(tags) != 0 {
+		t.Fatalf("ListTags() on empty repo = %v, %v; want no tags", tags, err)
+	}
+	commits, err := client.GetCommitsSince(ctx, "")
+	if err != nil || len(commits) != 0 {
+		t.Fatalf("GetCommitsSince() on empty repo = %v, %v; want no commits", commits, err)
+	}
+
+	commit := func(subject string) {
+		writeFile(t, tmpDir, "file.txt", subject)
+		runGit(t, tmpDir, "add", ".")
+		runGit(t, tmpDir, "commit", "-m", subject)
+	}
+
+	commit("feat: first")
+	if err := client.CreateTag(ctx, "v1.0.0", "v1.0.0: first release\n\n# Features\n- first", false); err != nil {
+		t.Fatalf("CreateTag() error = %v", err)
+	}
+	commit("fix: second")
+	commit("feat: third")
+
+	tags, err = client.ListTags(ctx)
+	if err != nil || len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Fatalf("ListTags() = %v, %v; want [v1.0.0]", tags, err)
+	}
+
+	// The tag is annotated and keeps "#" lines
+	message := runGit(t, tmpDir, "tag", "-l", "--format=%(objecttype)\n%(contents)", "v1.0.0")
+	if !strings.HasPrefix(message, "tag\n") || !strings.Contains(message, "# Features") {
+		t.Errorf("expected annotated tag keeping the heading, got %q", message)
+	}
+
+	commits, err = client.GetCommitsSince(ctx, "v1.0.0")
+	if err != nil {
+		t.Fatalf("GetCommitsSince() error = %v", err)
+	}
+	if len(commits) != 2 || commits[0] != "feat: third" || commits[1] != "fix: second" {
+		t.Errorf("GetCommitsSince(v1.0.0) = %v, want [feat: third, fix: second]", commits)
+	}
+
+	commits, err = client.GetCommitsSince(ctx, "")
+	if err != nil || len(commits) != 3 {
+		t.Errorf("GetCommitsSince(\"\") = %v, %v; want 3 commits", commits, err)
+	}
+
+	if err := client.CreateTag(ctx, "v1.0.0", "again", false); err == nil {
+		t.Error("expected error when the tag already exists")
+	}
+}
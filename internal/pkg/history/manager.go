@@ -26,6 +26,46 @@ type Entry struct {
 	Provider    string    `json:"provider"`
 	Model       string    `json:"model"`
 	Committed   bool      `json:"committed"`
+	// Compared lists the providers compared with --compare; Provider is the
+	// one whose message was chosen.
+	Compared []string `json:"compared,omitempty"`
+	// Translation is the message translated into generation.translate_to,
+	// saved with history.keep_translation.
+	Translation string `json:"translation,omitempty"`
+	// Usage is the token usage of the AI requests made for the message.
+	Usage *Usage `json:"usage,omitempty"`
+	// Score grades the final message, unless generation.score is off.
+	Score *Score `json:"score,omitempty"`
+}
+
+// Score grades a commit message from 0 to 100 on each criterion.
+type Score struct {
+	// Method is "heuristic" or "model", the way the message was graded.
+	Method      string `json:"method"`
+	Specificity int    `json:"specificity"`
+	Type        int    `json:"type"`
+	Length      int    `json:"length"`
+	Total       int    `json:"total"`
+}
+
+// Usage is the token usage of the AI requests made for a message.
+type Usage struct {
+	Requests int `json:"requests"`
+	// CacheHits are the requests that read part of their prompt from the
+	// provider's prompt cache.
+	CacheHits        int `json:"cache_hits"`
+	PromptTokens     int `json:"prompt_tokens"`
+	CachedTokens     int `json:"cached_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// Add adds the usage of other to u.
+func (u *Usage) Add(other Usage) {
+	u.Requests += other.Requests
+	u.CacheHits += other.CacheHits
+	u.PromptTokens += other.PromptTokens
+	u.CachedTokens += other.CachedTokens
+	u.CompletionTokens += other.CompletionTokens
 }
 
 // Manager defines the interface for history management.
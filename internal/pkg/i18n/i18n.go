@@ -0,0 +1,115 @@
+// Package i18n provides localized user-facing strings for GitSage.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Supported languages.
+const (
+	English = "en"
+	Chinese = "zh"
+	// Auto detects the language from the LC_ALL, LC_MESSAGES and LANG environment variables.
+	Auto = "auto"
+)
+
+var catalogs = map[string]map[string]string{
+	English: messagesEN,
+	Chinese: messagesZH,
+}
+
+var (
+	mu      sync.RWMutex
+	current = English
+)
+
+// SetLanguage selects the language used by T.
+// An empty value or "auto" detects the language from the environment.
+func SetLanguage(lang string) error {
+	resolved, err := Resolve(lang)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	current = resolved
+	mu.Unlock()
+	return nil
+}
+
+// Language returns the currently selected language.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Resolve normalizes a language setting to a supported language code.
+// Region suffixes are ignored, so "zh-CN" and "zh_TW" both resolve to "zh".
+func Resolve(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" || lang == Auto {
+		return detect(), nil
+	}
+
+	base := baseLanguage(lang)
+	if _, ok := catalogs[base]; !ok {
+		return "", fmt.Errorf("unsupported language %q (valid: %s, %s)", lang, strings.Join(Languages(), ", "), Auto)
+	}
+	return base, nil
+}
+
+// Languages returns the sorted codes of all supported languages.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// T returns the localized string for key, formatted with args if any are given.
+// Missing translations fall back to English, and unknown keys to the key itself.
+func T(key string, args ...any) string {
+	format, ok := catalogs[Language()][key]
+	if !ok {
+		format, ok = messagesEN[key]
+		if !ok {
+			format = key
+		}
+	}
+
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// detect returns the language of the user's locale, defaulting to English.
+func detect() string {
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		if base := baseLanguage(strings.ToLower(value)); catalogs[base] != nil {
+			return base
+		}
+		// The first non-empty variable takes precedence, as in POSIX locale lookup
+		return English
+	}
+	return English
+}
+
+// baseLanguage strips region, encoding and modifier suffixes, e.g. "zh_CN.UTF-8" -> "zh".
+func baseLanguage(lang string) string {
+	if i := strings.IndexAny(lang, "-_.@"); i >= 0 {
+		return lang[:i]
+	}
+	return lang
+}
@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useLanguage sets the language for the duration of a test.
+func useLanguage(t *testing.T, lang string) {
+	t.Helper()
+	prev := Language()
+	require.NoError(t, SetLanguage(lang))
+	t.Cleanup(func() { _ = SetLanguage(prev) })
+}
+
+func TestT(t *testing.T) {
+	useLanguage(t, English)
+	assert.Equal(t, "Commit cancelled", T("commit.success.cancelled"))
+	assert.Equal(t, "Message written to msg.txt", T("commit.success.written", "msg.txt"))
+
+	useLanguage(t, Chinese)
+	assert.Equal(t, "已取消提交", T("commit.success.cancelled"))
+	assert.Equal(t, "已从远程拉取 3 个文件", T("push.success.pulled", 3))
+}
+
+func TestT_Fallback(t *testing.T) {
+	useLanguage(t, Chinese)
+
+	messagesEN["test.only_english"] = "English only"
+	t.Cleanup(func() { delete(messagesEN, "test.only_english") })
+
+	assert.Equal(t, "English only", T("test.only_english"))
+	assert.Equal(t, "unknown.key", T("unknown.key"))
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"en", English},
+		{"EN", English},
+		{"zh", Chinese},
+		{"zh-CN", Chinese},
+		{"zh_TW", Chinese},
+		{" zh ", Chinese},
+	}
+
+	for _, tt := range tests {
+		got, err := Resolve(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	_, err := Resolve("fr")
+	assert.Error(t, err)
+}
+
+func TestResolve_Auto(t *testing.T) {
+	tests := []struct {
+		name   string
+		lcAll  string
+		lcMsgs string
+		lang   string
+		want   string
+	}{
+		{"no locale", "", "", "", English},
+		{"LANG chinese", "", "", "zh_CN.UTF-8", Chinese},
+		{"LANG english", "", "", "en_US.UTF-8", English},
+		{"LC_ALL overrides LANG", "en_US.UTF-8", "", "zh_CN.UTF-8", English},
+		{"LC_MESSAGES overrides LANG", "", "zh_CN.UTF-8", "en_US.UTF-8", Chinese},
+		{"unsupported locale", "", "", "fr_FR.UTF-8", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LC_ALL", tt.lcAll)
+			t.Setenv("LC_MESSAGES", tt.lcMsgs)
+			t.Setenv("LANG", tt.lang)
+
+			for _, input := range []string{"", Auto} {
+				got, err := Resolve(input)
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSetLanguage_Invalid(t *testing.T) {
+	useLanguage(t, Chinese)
+
+	assert.Error(t, SetLanguage("klingon"))
+	assert.Equal(t, Chinese, Language(), "invalid language should keep the current one")
+}
+
+// TestCatalogsComplete verifies every translation has an English fallback
+// and uses the same format verbs, so Sprintf arguments line up.
+func TestCatalogsComplete(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, value := range catalog {
+			en, ok := messagesEN[key]
+			if !assert.True(t, ok, "%s key %q missing from English catalog", lang, key) {
+				continue
+			}
+			assert.Equal(t, verbs(en), verbs(value), "%s key %q format verbs differ", lang, key)
+		}
+	}
+	for key := range messagesEN {
+		_, ok := messagesZH[key]
+		assert.True(t, ok, "key %q missing from Chinese catalog", key)
+	}
+}
+
+// verbs returns the format verbs in s in order, e.g. "%s %d" -> ["%s", "%d"].
+func verbs(s string) []string {
+	var out []string
+	for i := 0; i < len(s)-1; i++ {
+		if s[i] == '%' {
+			out = append(out, s[i:i+2])
+			i++
+		}
+	}
+	return out
+}
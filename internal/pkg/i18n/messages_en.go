@@ -0,0 +1,268 @@
+package i18n
+
+// messagesEN is the English catalog. It is the fallback for every other language,
+// so it must contain every key.
+var messagesEN = map[string]string{
+	// Message display
+	"ui.message.title":            "Generated Commit Message",
+	"ui.compare.title":            "Regenerated Commit Message",
+	"ui.compare.previous":         "Previous",
+	"ui.compare.new":              "New",
+	"ui.compare.previous_message": "Previous commit message:",
+	"ui.compare.new_message":      "New commit message:",
+	"ui.error":                    "Error: %s",
+
+	// Action selector
+	"ui.action.title":             "What would you like to do?",
+	"ui.action.accept":            "Accept",
+	"ui.action.accept.desc":       "Commit with this message",
+	"ui.action.edit":              "Edit",
+	"ui.action.edit.desc":         "Modify the message",
+	"ui.action.edit_subject":      "Edit subject",
+	"ui.action.edit_subject.desc": "Change the subject line and commit",
+	"ui.action.regenerate":        "Regenerate",
+	"ui.action.regenerate.desc":   "Generate a new message",
+	"ui.action.view_diff":         "View diff",
+	"ui.action.view_diff.desc":    "Review the staged changes",
+	"ui.action.pick_attempt":      "Earlier attempts",
+	"ui.action.pick_attempt.desc": "Go back to a previous message",
+	"ui.action.refresh":           "Refresh",
+	"ui.action.refresh.desc":      "Re-read the staged changes and regenerate",
+	"ui.action.translate":         "Translate",
+	"ui.action.translate.desc":    "Translate the message into generation.translate_to",
+	"ui.action.pick_bullets":      "Pick bullets",
+	"ui.action.pick_bullets.desc": "Drop bullets from the body",
+	"ui.action.cancel":            "Cancel",
+	"ui.action.cancel.desc":       "Abort without committing",
+	"ui.action.help":              "%s %s to move • %s to select • %s quick select • %s subject • %s diff • %s earlier attempts • %s refresh • %s translate • %s bullets • %s to cancel",
+
+	// Attempt picker
+	"ui.attempt.title":   "Which attempt would you like to use?",
+	"ui.attempt.current": "(current)",
+	"ui.attempt.help":    "%s %s to move • %s to select • 1-9 quick select • Esc to go back",
+
+	// Provider comparison
+	"ui.candidate.heading": "Commit Messages by Provider",
+	"ui.candidate.title":   "Which provider's message would you like to use?",
+	"ui.candidate.message": "Commit message from %s:",
+
+	// Progress
+	"ui.progress.stop_hint": "(s: stop early)",
+	"ui.progress.stopping":  "(stopping after this batch...)",
+
+	// File picker
+	"ui.files.title":            "No staged changes found. Select the files to stage:",
+	"ui.files.help":             "%s %s to move • %s to toggle • %s to toggle all • Enter to stage • Esc to cancel",
+	"ui.files.status.untracked": "untracked",
+	"ui.files.status.modified":  "modified",
+	"ui.files.status.deleted":   "deleted",
+	"ui.files.status.scratch":   "looks like a scratch file",
+
+	// Bullet picker
+	"ui.bullets.title": "Uncheck the bullets to drop from the body:",
+	"ui.bullets.help":  "%s %s to move • %s to toggle • %s to toggle all • Enter to keep the checked bullets • Esc to go back",
+
+	// Confirm prompt
+	"ui.confirm.yes": "[%s] Yes",
+	"ui.confirm.no":  "[%s] No",
+
+	// Editor
+	"ui.edit.title":           "Edit Commit Message",
+	"ui.edit.description":     "Edit below. Press Ctrl+D or Tab then Enter to save. Ctrl+C or Esc to cancel.",
+	"ui.edit.help":            "Ctrl+D to save • Esc to cancel",
+	"ui.edit.help_vim":        "i to insert • Esc for normal mode • ZZ or Ctrl+D to save • ZQ or Ctrl+C to cancel",
+	"ui.edit.mode_normal":     "-- NORMAL --",
+	"ui.edit.mode_insert":     "-- INSERT --",
+	"ui.edit.external_failed": "External editor not available, using inline editor...",
+	"ui.edit.guide":           "Edit the message. Lines starting with '%s' are ignored,\nand an empty message cancels.",
+
+	// Subject input
+	"ui.subject.title": "Edit Subject",
+	"ui.subject.help":  "Enter to commit • Esc to go back • %d characters",
+
+	// Diff pager
+	"ui.diff.title": "Staged Changes",
+	"ui.diff.help":  "↑/↓ or j/k to scroll • PgUp/PgDn to page • g/G top/bottom • q to go back",
+
+	// Config editor
+	"ui.config.title":      "GitSage Configuration",
+	"ui.config.help":       "↑/↓ or j/k to move • Enter to edit or toggle • g/G top/bottom • q to quit",
+	"ui.config.help_input": "Enter to save • Esc to go back",
+	"ui.config.help_pick":  "←/→ to choose • Enter to save • Esc to go back",
+	"ui.config.unset":      "(not set)",
+	"ui.config.saved":      "Set %s = %s",
+
+	// Accessible prompts
+	"ui.accessible.yes_no":            "(y/N)",
+	"ui.accessible.answer_yes_no":     "Please answer y or n.",
+	"ui.accessible.enter_number":      "Enter a number (1-%d): ",
+	"ui.accessible.enter_number_back": "Enter a number (1-%d), or leave empty to go back: ",
+	"ui.accessible.invalid_choice":    "Invalid choice %q.",
+	"ui.accessible.toggle_files":      "Enter numbers (1-%d) to toggle, or leave empty to stage the selected files: ",
+	"ui.accessible.toggle_bullets":    "Enter numbers (1-%d) to toggle, or leave empty to keep the checked bullets: ",
+	"ui.accessible.external_failed":   "External editor not available.",
+	"ui.accessible.current_message":   "Current commit message:",
+	"ui.accessible.edit_instructions": "Type the new commit message. End with a line containing only a period.",
+	"ui.accessible.edit_keep":         "Enter the period right away to keep the current message.",
+	"ui.accessible.edit_cancel":       "Enter an empty line, then the period, to cancel the commit.",
+	"ui.accessible.current_subject":   "Current subject:",
+	"ui.accessible.new_subject":       "New subject, or leave empty to keep it: ",
+
+	// Commit workflow
+	"commit.spinner.staging":            "Staging selected files...",
+	"commit.success.staged":             "Staged %d file(s)",
+	"commit.spinner.retrieving":         "Retrieving staged changes...",
+	"commit.spinner.processing":         "Processing diff...",
+	"commit.spinner.generating":         "Generating commit message...",
+	"commit.spinner.analyzing":          "Analyzing files",
+	"commit.spinner.committing":         "Committing changes...",
+	"commit.spinner.verifying":          "Verifying commit message...",
+	"commit.spinner.translating":        "Translating commit message into %s...",
+	"commit.warning.verify":             "verification: %s",
+	"commit.error.edit":                 "failed to edit message",
+	"commit.error.max_regenerations":    "maximum regeneration attempts (%d) reached",
+	"commit.error.show_diff":            "failed to show diff",
+	"commit.error.no_attempts":          "no earlier attempts yet, regenerate to create one",
+	"commit.error.refresh":              "failed to refresh staged changes",
+	"commit.error.refresh_unavailable":  "staged changes cannot be refreshed while splitting a commit",
+	"commit.error.translate":            "failed to translate the message",
+	"commit.error.translate_unset":      "set generation.translate_to to the language to translate into",
+	"commit.error.pick_bullets":         "failed to pick bullets",
+	"commit.info.refresh_unchanged":     "Staged changes are unchanged",
+	"commit.info.refreshed":             "Staged changes updated (%d files), regenerating",
+	"commit.warning":                    "warning: %s",
+	"commit.warning.hook":               "warning: post-commit hook failed",
+	"commit.warning.history":            "warning: failed to save to history",
+	"commit.warning.compare":            "warning: %s failed to generate a message: %v",
+	"commit.warning.translation":        "warning: failed to translate the message, no translation is kept in history",
+	"commit.warning.duplicate":          "subject repeats the recent commit %q",
+	"commit.info.risk":                  "Risk: %s",
+	"commit.info.risk.reasons":          "Risk: %s (%s)",
+	"commit.info.accuracy":              "Body accuracy: %d%% (%d/%d modules match the changes, %d/%d major directories described)",
+	"commit.warning.accuracy_unknown":   "body mentions module %q, which has no changes",
+	"commit.warning.accuracy_omitted":   "body does not describe the changes in %s",
+	"commit.warning.breaking":           "message declares a breaking change: %s",
+	"commit.success.cancelled":          "Commit cancelled",
+	"commit.success.empty_message":      "Commit cancelled due to empty commit message",
+	"commit.warning.invalid_edit":       "edited message is not a valid conventional commit: %v",
+	"commit.confirm.sensitive":          "Security-sensitive files changed: %s. Commit anyway?",
+	"commit.success.sensitive_declined": "Commit cancelled; run with --resume to commit this message later",
+	"commit.success.preview_declined":   "Commit cancelled after the preview; run with --resume to commit this message later",
+	"commit.confirm.preview":            "Make this commit?",
+	"commit.confirm.budget":             "This is above the budget set in the budget section of the config. Send it to the AI provider?",
+	"commit.success.budget_declined":    "Commit cancelled; nothing was sent to the AI provider",
+	"commit.confirm.payload":            "Send this to the AI provider?",
+	"commit.success.payload_declined":   "Cancelled after reviewing what would be sent; nothing was sent to the AI provider",
+	"commit.confirm.invalid_edit":       "Commit it anyway? Choose No to edit again",
+	"commit.dry_run.files":              "Files to be committed (%d, +%d -%d):",
+	"commit.success.dry_run":            "Dry-run complete - message generated but not committed",
+	"commit.info.in_progress":           "git %s is in progress: the message will be written for '%s' instead of being committed",
+	"commit.success.operation_message":  "Message saved; run '%s' to commit it",
+	"commit.notify.ready":               "Commit message ready for review",
+	"commit.success.committed":          "Successfully committed!",
+	"commit.success.written":            "Message written to %s",
+
+	// Tag workflow
+	"tag.spinner.generating":    "Generating tag message...",
+	"tag.spinner.creating":      "Creating tag...",
+	"tag.error.pick_attempt":    "earlier attempts cannot be picked for a tag message",
+	"tag.error.refresh":         "a tag message has no staged changes to refresh",
+	"tag.error.translate":       "tag messages cannot be translated",
+	"tag.error.pick_bullets":    "bullets of tag messages cannot be picked, edit the message instead",
+	"tag.success.cancelled":     "Tag cancelled",
+	"tag.success.empty_message": "Tag cancelled due to empty tag message",
+	"tag.success.dry_run":       "Dry-run complete - message generated but no tag created",
+	"tag.success.created":       "Created tag %s",
+
+	// Summary workflow
+	"summary.spinner.generating": "Summarizing changes...",
+	"summary.success.written":    "Summary written to %s",
+
+	// Watch workflow
+	"watch.info.watching":    "Watching staged changes, draft in %s (Ctrl+C to stop)",
+	"watch.info.over_budget": "Staged changes are above the budget; draft not updated, run gitsage commit to confirm",
+	"watch.success.updated":  "Draft updated (%d files): %s",
+	"watch.error.watch":      "failed to watch the index",
+	"watch.error.update":     "failed to update the draft",
+
+	// Report workflow
+	"report.spinner.collecting": "Collecting commits...",
+	"report.spinner.generating": "Writing work report...",
+	"report.success.written":    "Report written to %s",
+
+	// Split commits
+	"split.group":         "Commit %d of %d: %s (%d files)",
+	"split.cross_package": "cross-package changes",
+	"split.error.restore": "failed to restage the changes that were not committed",
+
+	// Generation plan
+	"plan.title":         "Generation plan: %d files, %s",
+	"plan.direct":        "Single request: the whole diff is sent in one prompt",
+	"plan.two_phase":     "Two-phase: %d groups are summarized, then merged into one message",
+	"plan.group":         "Group %d: %d files, %s",
+	"plan.group.pieces":  "Group %d: 1 file, %s, summarized in %d pieces",
+	"plan.file":          "  - %s (%s)",
+	"plan.usage":         "Estimated usage: %d requests, ~%d input tokens, up to %d output tokens",
+	"plan.cost":          "Estimated cost: up to $%.4f",
+	"plan.estimate":      "Estimate: %d files, %s, %d requests, ~%d input tokens",
+	"plan.estimate.cost": ", up to $%.4f",
+
+	// Risk factors
+	"risk.lines":    "%d changed lines",
+	"risk.files":    "%d files",
+	"risk.critical": "critical files: %s",
+	"risk.untested": "code changed without tests",
+
+	// Payload view
+	"payload.title":         "Sent to %s: %d files, %s",
+	"payload.file":          "  - %s (%s, %s)",
+	"payload.file.replaced": "  - %s (%s, %s, summarized or redacted)",
+	"payload.omitted":       "Not sent: %s",
+	"payload.commits":       "Sent to %s: the messages of %d commits",
+	"payload.commit_diffs":  "Sent to %s: the diffs and messages of %d commits",
+	"payload.stats_only":    "Only paths, change types and line counts are sent, no diff content (stats-only)",
+	"payload.redact_paths":  "The repository root and home directory are replaced with . and ~ (security.redact_paths)",
+
+	// Push
+	"push.confirm":          "Push to remote repository?",
+	"push.spinner.pulling":  "Pulling from remote...",
+	"push.error.pull":       "failed to pull",
+	"push.success.pulled":   "Pulled %d file(s) from remote",
+	"push.confirm.continue": "Remote has updates. Continue with push?",
+	"push.spinner.pushing":  "Pushing to remote...",
+	"push.error.push":       "failed to push",
+	"push.success.pushed":   "Pushed to remote!",
+
+	// Model pull
+	"model.confirm.pull":   "Model %s is not pulled in Ollama. Pull it now?",
+	"model.spinner.pull":   "Pulling %s...",
+	"model.error.pull":     "failed to pull %s",
+	"model.success.pulled": "Pulled %s",
+
+	// Security warning
+	"security.auto_acknowledge": "Auto-acknowledging security warning (--yes flag)",
+	"security.prompt":           "Do you understand and wish to continue? [y/N]: ",
+
+	// PATH check
+	"pathcheck.not_in_path": "GitSage detected that its executable directory (%s) is not in your PATH.",
+	"pathcheck.benefit":     "Once it is on your PATH, you can run 'gitsage' from any directory.",
+	"pathcheck.confirm":     "Add it to PATH automatically?",
+	"pathcheck.failed":      "failed to add to PATH automatically",
+	"pathcheck.reload":      "Restart your terminal or source your shell profile for the change to take effect.",
+	"pathcheck.skipped":     "Skipped PATH setup. You can add it manually later.",
+
+	// Setup wizard
+	"setup.welcome":           "No configuration found. Let's set up GitSage!",
+	"setup.provider.title":    "Select AI Provider",
+	"setup.provider.ollama":   "Ollama (Local)",
+	"setup.api_key.title":     "API Key",
+	"setup.api_key.desc":      "Enter your API key",
+	"setup.api_key.too_short": "api key too short",
+	"setup.model.title":       "Model Name",
+	"setup.model.desc":        "Model to use",
+	"setup.model.empty":       "model name cannot be empty",
+	"setup.endpoint.title":    "API Endpoint",
+	"setup.endpoint.desc":     "Optional custom endpoint",
+	"setup.saved":             "Configuration saved to %s",
+	"setup.complete":          "Setup complete! You can now use GitSage.",
+}
@@ -0,0 +1,267 @@
+package i18n
+
+// messagesZH is the Simplified Chinese catalog.
+var messagesZH = map[string]string{
+	// Message display
+	"ui.message.title":            "生成的提交信息",
+	"ui.compare.title":            "重新生成的提交信息",
+	"ui.compare.previous":         "之前",
+	"ui.compare.new":              "新的",
+	"ui.compare.previous_message": "之前的提交信息：",
+	"ui.compare.new_message":      "新的提交信息：",
+	"ui.error":                    "错误：%s",
+
+	// Action selector
+	"ui.action.title":             "您想要做什么？",
+	"ui.action.accept":            "接受",
+	"ui.action.accept.desc":       "使用此信息提交",
+	"ui.action.edit":              "编辑",
+	"ui.action.edit.desc":         "修改提交信息",
+	"ui.action.edit_subject":      "编辑标题",
+	"ui.action.edit_subject.desc": "只修改标题行并提交",
+	"ui.action.regenerate":        "重新生成",
+	"ui.action.regenerate.desc":   "生成新的提交信息",
+	"ui.action.view_diff":         "查看差异",
+	"ui.action.view_diff.desc":    "查看暂存的更改",
+	"ui.action.pick_attempt":      "历史结果",
+	"ui.action.pick_attempt.desc": "返回之前生成的信息",
+	"ui.action.refresh":           "刷新",
+	"ui.action.refresh.desc":      "重新读取暂存的更改并重新生成",
+	"ui.action.translate":         "翻译",
+	"ui.action.translate.desc":    "将提交信息翻译为 generation.translate_to 指定的语言",
+	"ui.action.pick_bullets":      "挑选要点",
+	"ui.action.pick_bullets.desc": "从正文中去掉部分要点",
+	"ui.action.cancel":            "取消",
+	"ui.action.cancel.desc":       "放弃提交",
+	"ui.action.help":              "%s %s 移动 • %s 选择 • %s 快速选择 • %s 标题 • %s 差异 • %s 历史结果 • %s 刷新 • %s 翻译 • %s 要点 • %s 取消",
+
+	// Attempt picker
+	"ui.attempt.title":   "您想使用哪一次的结果？",
+	"ui.attempt.current": "（当前）",
+	"ui.attempt.help":    "%s %s 移动 • %s 选择 • 1-9 快速选择 • Esc 返回",
+
+	// Provider comparison
+	"ui.candidate.heading": "各提供商生成的提交信息",
+	"ui.candidate.title":   "您想使用哪个提供商的结果？",
+	"ui.candidate.message": "%s 生成的提交信息：",
+
+	// Progress
+	"ui.progress.stop_hint": "（按 s 提前结束）",
+	"ui.progress.stopping":  "（本批完成后结束...）",
+
+	// File picker
+	"ui.files.title":            "没有暂存的更改。请选择要暂存的文件：",
+	"ui.files.help":             "%s %s 移动 • %s 切换 • %s 全部切换 • Enter 暂存 • Esc 取消",
+	"ui.files.status.untracked": "未跟踪",
+	"ui.files.status.modified":  "已修改",
+	"ui.files.status.deleted":   "已删除",
+	"ui.files.status.scratch":   "疑似临时文件",
+
+	// Bullet picker
+	"ui.bullets.title": "取消勾选要从正文中去掉的要点：",
+	"ui.bullets.help":  "%s %s 移动 • %s 切换 • %s 全选/全不选 • 回车保留勾选的要点 • Esc 返回",
+
+	// Confirm prompt
+	"ui.confirm.yes": "[%s] 是",
+	"ui.confirm.no":  "[%s] 否",
+
+	// Editor
+	"ui.edit.title":           "编辑提交信息",
+	"ui.edit.description":     "在下方编辑。按 Ctrl+D 或 Tab 后回车保存，按 Ctrl+C 或 Esc 取消。",
+	"ui.edit.help":            "Ctrl+D 保存 • Esc 取消",
+	"ui.edit.help_vim":        "i 插入 • Esc 普通模式 • ZZ 或 Ctrl+D 保存 • ZQ 或 Ctrl+C 取消",
+	"ui.edit.mode_normal":     "-- 普通 --",
+	"ui.edit.mode_insert":     "-- 插入 --",
+	"ui.edit.external_failed": "外部编辑器不可用，改用内置编辑器...",
+	"ui.edit.guide":           "编辑提交信息。以 '%s' 开头的行会被忽略，\n信息为空则取消。",
+
+	// Subject input
+	"ui.subject.title": "编辑标题",
+	"ui.subject.help":  "回车提交 • Esc 返回 • %d 个字符",
+
+	// Diff pager
+	"ui.diff.title": "暂存的更改",
+	"ui.diff.help":  "↑/↓ 或 j/k 滚动 • PgUp/PgDn 翻页 • g/G 顶部/底部 • q 返回",
+
+	// Config editor
+	"ui.config.title":      "GitSage 配置",
+	"ui.config.help":       "↑/↓ 或 j/k 移动 • 回车编辑或切换 • g/G 顶部/底部 • q 退出",
+	"ui.config.help_input": "回车保存 • Esc 返回",
+	"ui.config.help_pick":  "←/→ 选择 • 回车保存 • Esc 返回",
+	"ui.config.unset":      "（未设置）",
+	"ui.config.saved":      "已设置 %s = %s",
+
+	// Accessible prompts
+	"ui.accessible.yes_no":            "(y/N)",
+	"ui.accessible.answer_yes_no":     "请输入 y 或 n。",
+	"ui.accessible.enter_number":      "请输入编号 (1-%d)：",
+	"ui.accessible.enter_number_back": "请输入编号 (1-%d)，留空返回：",
+	"ui.accessible.invalid_choice":    "无效的选择 %q。",
+	"ui.accessible.toggle_files":      "输入编号 (1-%d) 切换选择，留空暂存所选文件：",
+	"ui.accessible.toggle_bullets":    "输入编号 (1-%d) 切换选择，留空保留勾选的要点：",
+	"ui.accessible.external_failed":   "外部编辑器不可用。",
+	"ui.accessible.current_message":   "当前提交信息：",
+	"ui.accessible.edit_instructions": "请输入新的提交信息，以只包含一个句点的行结束。",
+	"ui.accessible.edit_keep":         "直接输入句点可保留当前信息。",
+	"ui.accessible.edit_cancel":       "先输入一个空行再输入句点，可取消提交。",
+	"ui.accessible.current_subject":   "当前标题：",
+	"ui.accessible.new_subject":       "输入新标题，留空则保留当前标题：",
+
+	// Commit workflow
+	"commit.spinner.staging":            "正在暂存所选文件...",
+	"commit.success.staged":             "已暂存 %d 个文件",
+	"commit.spinner.retrieving":         "正在获取暂存的更改...",
+	"commit.spinner.processing":         "正在处理差异...",
+	"commit.spinner.generating":         "正在生成提交信息...",
+	"commit.spinner.analyzing":          "正在分析文件",
+	"commit.spinner.committing":         "正在提交更改...",
+	"commit.spinner.verifying":          "正在校验提交信息...",
+	"commit.spinner.translating":        "正在将提交信息翻译为 %s...",
+	"commit.warning.verify":             "校验：%s",
+	"commit.error.edit":                 "编辑提交信息失败",
+	"commit.error.max_regenerations":    "已达到最大重新生成次数 (%d)",
+	"commit.error.show_diff":            "显示差异失败",
+	"commit.error.no_attempts":          "还没有之前的结果，请先重新生成",
+	"commit.error.refresh":              "刷新暂存的更改失败",
+	"commit.error.refresh_unavailable":  "拆分提交时无法刷新暂存的更改",
+	"commit.error.translate":            "翻译提交信息失败",
+	"commit.error.translate_unset":      "请将 generation.translate_to 设置为要翻译成的语言",
+	"commit.error.pick_bullets":         "挑选要点失败",
+	"commit.info.refresh_unchanged":     "暂存的更改没有变化",
+	"commit.info.refreshed":             "暂存的更改已更新（%d 个文件），正在重新生成",
+	"commit.warning":                    "警告：%s",
+	"commit.warning.hook":               "警告：提交后钩子执行失败",
+	"commit.warning.history":            "警告：保存历史记录失败",
+	"commit.warning.compare":            "警告：%s 生成提交信息失败：%v",
+	"commit.warning.translation":        "警告：翻译提交信息失败，历史记录中不保存译文",
+	"commit.warning.duplicate":          "标题与最近的提交 %q 重复",
+	"commit.info.risk":                  "风险：%s",
+	"commit.info.risk.reasons":          "风险：%s（%s）",
+	"commit.info.accuracy":              "正文准确度：%d%%（%d/%d 个模块与变更对应，%d/%d 个主要目录已描述）",
+	"commit.warning.accuracy_unknown":   "正文提到的模块 %q 没有变更",
+	"commit.warning.accuracy_omitted":   "正文没有描述 %s 中的变更",
+	"commit.warning.breaking":           "提交信息声明了破坏性变更：%s",
+	"commit.success.cancelled":          "已取消提交",
+	"commit.success.empty_message":      "提交信息为空，已取消提交",
+	"commit.warning.invalid_edit":       "编辑后的信息不符合 Conventional Commits 规范：%v",
+	"commit.confirm.sensitive":          "修改了安全敏感文件：%s。仍然提交吗？",
+	"commit.success.sensitive_declined": "已取消提交；之后可使用 --resume 提交此信息",
+	"commit.success.preview_declined":   "已在预览后取消提交；之后可使用 --resume 提交此信息",
+	"commit.confirm.preview":            "确认进行此提交吗？",
+	"commit.confirm.budget":             "超出了配置中 budget 部分设置的预算。仍然发送给 AI 供应商吗？",
+	"commit.success.budget_declined":    "已取消提交；未向 AI 供应商发送任何内容",
+	"commit.confirm.payload":            "将以上内容发送给 AI 供应商吗？",
+	"commit.success.payload_declined":   "已在查看待发送内容后取消；未向 AI 供应商发送任何内容",
+	"commit.confirm.invalid_edit":       "仍然提交吗？选择否可重新编辑",
+	"commit.dry_run.files":              "将要提交的文件（%d 个，+%d -%d）:",
+	"commit.success.dry_run":            "试运行完成 - 已生成提交信息但未提交",
+	"commit.info.in_progress":           "git %s 正在进行中：提交信息将写入供 '%s' 使用，而不会直接提交",
+	"commit.success.operation_message":  "提交信息已保存；运行 '%s' 完成提交",
+	"commit.notify.ready":               "提交信息已生成，等待确认",
+	"commit.success.committed":          "提交成功！",
+	"commit.success.written":            "提交信息已写入 %s",
+
+	// Tag workflow
+	"tag.spinner.generating":    "正在生成标签信息...",
+	"tag.spinner.creating":      "正在创建标签...",
+	"tag.error.pick_attempt":    "标签信息不支持选择之前的生成结果",
+	"tag.error.refresh":         "标签信息没有可刷新的暂存更改",
+	"tag.error.translate":       "标签信息不支持翻译",
+	"tag.error.pick_bullets":    "标签信息不支持挑选要点，请改为编辑信息",
+	"tag.success.cancelled":     "已取消创建标签",
+	"tag.success.empty_message": "标签信息为空，已取消创建标签",
+	"tag.success.dry_run":       "试运行完成 - 已生成信息但未创建标签",
+	"tag.success.created":       "已创建标签 %s",
+
+	// Summary workflow
+	"summary.spinner.generating": "正在总结变更...",
+	"summary.success.written":    "变更总结已写入 %s",
+
+	// Watch workflow
+	"watch.info.watching":    "正在监视暂存的改动，草稿位于 %s（按 Ctrl+C 停止）",
+	"watch.info.over_budget": "暂存的改动超出预算，草稿未更新；请运行 gitsage commit 确认",
+	"watch.success.updated":  "草稿已更新（%d 个文件）：%s",
+	"watch.error.watch":      "监视索引失败",
+	"watch.error.update":     "更新草稿失败",
+
+	// Report workflow
+	"report.spinner.collecting": "正在收集提交...",
+	"report.spinner.generating": "正在撰写工作报告...",
+	"report.success.written":    "工作报告已写入 %s",
+
+	// Split commits
+	"split.group":         "第 %d/%d 个提交：%s（%d 个文件）",
+	"split.cross_package": "跨包改动",
+	"split.error.restore": "重新暂存未提交的改动失败",
+
+	// Generation plan
+	"plan.title":         "生成计划：%d 个文件，%s",
+	"plan.direct":        "单次请求：整个 diff 在一个提示中发送",
+	"plan.two_phase":     "两阶段：先分别摘要 %d 个分组，再合并生成提交信息",
+	"plan.group":         "分组 %d：%d 个文件，%s",
+	"plan.group.pieces":  "分组 %d：1 个文件，%s，分 %d 段摘要",
+	"plan.file":          "  - %s（%s）",
+	"plan.usage":         "预计用量：%d 次请求，约 %d 个输入 token，最多 %d 个输出 token",
+	"plan.cost":          "预计费用：最多 $%.4f",
+	"plan.estimate":      "预估：%d 个文件，%s，%d 次请求，约 %d 个输入 token",
+	"plan.estimate.cost": "，最多 $%.4f",
+
+	// Risk factors
+	"risk.lines":    "改动 %d 行",
+	"risk.files":    "%d 个文件",
+	"risk.critical": "关键文件：%s",
+	"risk.untested": "代码改动没有测试",
+
+	// Payload view
+	"payload.title":         "发送给 %s：%d 个文件，%s",
+	"payload.file":          "  - %s（%s，%s）",
+	"payload.file.replaced": "  - %s（%s，%s，已摘要或脱敏）",
+	"payload.omitted":       "不发送：%s",
+	"payload.commits":       "发送给 %s：%d 个提交的提交信息",
+	"payload.commit_diffs":  "发送给 %s：%d 个提交的 diff 和提交信息",
+	"payload.stats_only":    "只发送路径、变更类型和行数，不发送 diff 内容（stats-only）",
+	"payload.redact_paths":  "仓库根目录和主目录被替换为 . 和 ~（security.redact_paths）",
+
+	// Push
+	"push.confirm":          "是否推送到远程仓库？",
+	"push.spinner.pulling":  "正在从远程拉取...",
+	"push.error.pull":       "拉取失败",
+	"push.success.pulled":   "已从远程拉取 %d 个文件",
+	"push.confirm.continue": "远程有更新。是否继续推送？",
+	"push.spinner.pushing":  "正在推送到远程...",
+	"push.error.push":       "推送失败",
+	"push.success.pushed":   "已推送到远程！",
+
+	// Model pull
+	"model.confirm.pull":   "Ollama 中还没有模型 %s。现在拉取吗？",
+	"model.spinner.pull":   "正在拉取 %s...",
+	"model.error.pull":     "拉取 %s 失败",
+	"model.success.pulled": "已拉取 %s",
+
+	// Security warning
+	"security.auto_acknowledge": "已自动确认安全警告 (--yes 参数)",
+	"security.prompt":           "您是否了解并希望继续？[y/N]：",
+
+	// PATH check
+	"pathcheck.not_in_path": "GitSage 检测到可执行文件目录 (%s) 不在系统 PATH 中。",
+	"pathcheck.benefit":     "添加到 PATH 后，您可以在任何目录直接运行 'gitsage' 命令。",
+	"pathcheck.confirm":     "是否自动添加到 PATH?",
+	"pathcheck.failed":      "自动添加失败",
+	"pathcheck.reload":      "请重启终端或执行 source 命令使更改生效。",
+	"pathcheck.skipped":     "已跳过 PATH 配置。您可以稍后手动添加。",
+
+	// Setup wizard
+	"setup.welcome":           "未找到配置文件。让我们开始设置 GitSage！",
+	"setup.provider.title":    "选择 AI 服务商",
+	"setup.provider.ollama":   "Ollama（本地）",
+	"setup.api_key.title":     "API 密钥",
+	"setup.api_key.desc":      "请输入您的 API 密钥",
+	"setup.api_key.too_short": "API 密钥太短",
+	"setup.model.title":       "模型名称",
+	"setup.model.desc":        "要使用的模型",
+	"setup.model.empty":       "模型名称不能为空",
+	"setup.endpoint.title":    "API 地址",
+	"setup.endpoint.desc":     "可选的自定义地址",
+	"setup.saved":             "配置已保存到 %s",
+	"setup.complete":          "设置完成！现在可以使用 GitSage 了。",
+}
@@ -0,0 +1,115 @@
+// Package message provides commit message validation and formatting for GitSage.
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// footerVariableRegex matches a footer template variable, e.g. "{branch}" or
+// "{version-from-file:VERSION}".
+var footerVariableRegex = regexp.MustCompile(`\{([a-z][a-z-]*)(?::([^{}]*))?\}`)
+
+// FooterVars supplies the values of footer template variables.
+type FooterVars struct {
+	Branch string
+	Date   time.Time
+	// ReadFile reads the file named by {version-from-file:<path>}.
+	ReadFile func(path string) ([]byte, error)
+}
+
+// ExpandFooterTemplate replaces the variables in a footer template:
+//
+//	{branch}                    the current branch
+//	{date}                      the commit date, e.g. 2025-06-01
+//	{version-from-file:<path>}  the "version" field of a JSON file such as
+//	                            package.json, or the first line of any other file
+//
+// An unknown variable or a file without a version is an error.
+func ExpandFooterTemplate(template string, vars FooterVars) (string, error) {
+	var expandErr error
+	expanded := footerVariableRegex.ReplaceAllStringFunc(template, func(variable string) string {
+		if expandErr != nil {
+			return variable
+		}
+		matches := footerVariableRegex.FindStringSubmatch(variable)
+		name, arg := matches[1], strings.TrimSpace(matches[2])
+
+		var value string
+		switch {
+		case name == "branch" && arg == "":
+			value = vars.Branch
+		case name == "date" && arg == "":
+			value = vars.Date.Format("2006-01-02")
+		case name == "version-from-file" && arg != "":
+			value, expandErr = versionFromFile(arg, vars.ReadFile)
+		default:
+			expandErr = fmt.Errorf("unknown footer variable %s", variable)
+		}
+		return value
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return strings.TrimSpace(expanded), nil
+}
+
+// versionFromFile returns the version recorded in the file at path.
+func versionFromFile(path string, readFile func(string) ([]byte, error)) (string, error) {
+	if readFile == nil {
+		return "", fmt.Errorf("cannot read %s", path)
+	}
+	data, err := readFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read version from %s: %w", path, err)
+	}
+
+	var version string
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var manifest struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return "", fmt.Errorf("failed to read version from %s: %w", path, err)
+		}
+		version = strings.TrimSpace(manifest.Version)
+	} else {
+		for _, line := range strings.Split(string(data), "\n") {
+			if version = strings.TrimSpace(line); version != "" {
+				break
+			}
+		}
+	}
+
+	if version == "" {
+		return "", fmt.Errorf("no version found in %s", path)
+	}
+	return version, nil
+}
+
+// AppendFooter adds the lines of footer to the message footer, leaving out
+// lines the footer already has so that appending twice changes nothing.
+func (cm *CommitMessage) AppendFooter(footer string) {
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(cm.Footer, "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+
+	lines := []string{}
+	if cm.Footer != "" {
+		lines = append(lines, cm.Footer)
+	}
+	for _, line := range strings.Split(footer, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || existing[line] {
+			continue
+		}
+		existing[line] = true
+		lines = append(lines, line)
+	}
+	cm.Footer = strings.Join(lines, "\n")
+}
@@ -0,0 +1,86 @@
+package message
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandFooterTemplate(t *testing.T) {
+	files := map[string]string{
+		"VERSION":      "\n1.4.2\n",
+		"package.json": `{"name": "web", "version": "2.0.0-rc.1"}`,
+		"empty.json":   `{"name": "web"}`,
+	}
+	vars := FooterVars{
+		Branch: "release/1.4",
+		Date:   time.Date(2025, 6, 1, 15, 4, 5, 0, time.UTC),
+		ReadFile: func(path string) ([]byte, error) {
+			content, ok := files[path]
+			if !ok {
+				return nil, errors.New("no such file")
+			}
+			return []byte(content), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  string
+	}{
+		{"branch and date", "Build: {branch}@{date}", "Build: release/1.4@2025-06-01", ""},
+		{"version file", "Version: {version-from-file:VERSION}", "Version: 1.4.2", ""},
+		{"json version", "Version: {version-from-file: package.json }", "Version: 2.0.0-rc.1", ""},
+		{"several lines", "Branch: {branch}\nDate: {date}\n", "Branch: release/1.4\nDate: 2025-06-01", ""},
+		{"no variables", "Env: production", "Env: production", ""},
+		{"unknown variable", "Env: {env}", "", "unknown footer variable {env}"},
+		{"missing file", "Version: {version-from-file:VERSION.txt}", "", "failed to read version from VERSION.txt"},
+		{"no version", "Version: {version-from-file:empty.json}", "", "no version found in empty.json"},
+		{"missing path", "Version: {version-from-file}", "", "unknown footer variable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandFooterTemplate(tt.template, vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ExpandFooterTemplate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ExpandFooterTemplate() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendFooter(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		footer  string
+		want    string
+	}{
+		{"no footer", "feat: add login", "Build: main", "feat: add login\n\nBuild: main"},
+		{"after body", "feat: add login\n\n- auth: add form", "Build: main", "feat: add login\n\n- auth: add form\n\nBuild: main"},
+		{"existing footer", "fix: typo\n\nRefs: #12", "Build: main", "fix: typo\n\nRefs: #12\nBuild: main"},
+		{"already present", "fix: typo\n\nBuild: main", "Build: main", "fix: typo\n\nBuild: main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewCommitMessage(tt.message)
+			cm.AppendFooter(tt.footer)
+			if got := cm.Format(); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+			if got := NewCommitMessage(cm.Format()).Footer; got != cm.Footer {
+				t.Errorf("footer after parsing = %q, want %q", got, cm.Footer)
+			}
+		})
+	}
+}
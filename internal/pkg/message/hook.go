@@ -0,0 +1,44 @@
+// Package message provides commit message validation and formatting for GitSage.
+package message
+
+import (
+	"strings"
+)
+
+// scissorsLine is the line "git commit --verbose" puts above the diff;
+// everything from it on is removed from the message.
+const scissorsLine = "# ------------------------ >8 ------------------------"
+
+// exemptPrefixes start the messages git and its tools write themselves,
+// which are not expected to follow Conventional Commits.
+var exemptPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+
+// CleanupMessage removes what git strips from a message file before
+// committing: the diff below the scissors line, "#" comment lines and
+// surrounding blank lines.
+func CleanupMessage(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line == scissorsLine {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// IsExempt reports whether the message was written by git or an autosquash
+// workflow, such as a merge, revert or fixup commit, and is not validated.
+func IsExempt(text string) bool {
+	for _, prefix := range exemptPrefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
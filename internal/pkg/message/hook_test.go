@@ -0,0 +1,45 @@
+package message
+
+import "testing"
+
+func TestCleanupMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "feat: add login\n", "feat: add login"},
+		{"comments", "\nfeat: add login\n\n# Please enter the commit message\n# On branch main\n", "feat: add login"},
+		{"scissors", "fix: typo\n\nbody\n# ------------------------ >8 ------------------------\ndiff --git a/x b/x\n", "fix: typo\n\nbody"},
+		{"crlf", "fix: typo  \r\n\r\nbody\r\n", "fix: typo\n\nbody"},
+		{"only comments", "# Please enter the commit message\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanupMessage(tt.text); got != tt.want {
+				t.Errorf("CleanupMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsExempt(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Merge branch 'feature' into main", true},
+		{"Revert \"feat: add login\"", true},
+		{"fixup! feat: add login", true},
+		{"squash! feat: add login", true},
+		{"feat: merge user records", false},
+		{"Reverted the login change", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsExempt(tt.text); got != tt.want {
+			t.Errorf("IsExempt(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 // ValidCommitTypes contains all valid Conventional Commits types.
@@ -15,13 +16,35 @@ var ValidCommitTypes = []string{
 	"test", "chore", "perf", "ci", "build", "revert",
 }
 
-// MaxSubjectLength is the recommended maximum length for commit subject lines.
-// Relaxed to 100 for better Chinese language support.
+// MaxSubjectLength is the recommended maximum length (in characters, not
+// bytes) for commit subject lines. Relaxed to 100 for better Chinese language support.
 const MaxSubjectLength = 100
 
-// conventionalCommitRegex matches the Conventional Commits format.
-// Format: <type>(<scope>): <subject> or <type>: <subject>
-var conventionalCommitRegex = regexp.MustCompile(`^(feat|fix|docs|style|refactor|test|chore|perf|ci|build|revert)(\([^)]+\))?:\s*(.+)$`)
+// headerRegex matches a Conventional Commits header:
+// <type>[(<scope>)][!]: <description>. Any word is accepted as the type so
+// that an unknown type is reported as invalid rather than missing.
+var headerRegex = regexp.MustCompile(`^([A-Za-z][\w-]*)(?:\(([^()\r\n]*)\))?(!)?:([ \t]*)(.*)$`)
+
+// footerTokenRegex matches the first line of a footer: a git trailer token
+// followed by ": " or " #", e.g. "Refs: #123" or "Closes #123". The token
+// uses "-" in place of spaces, except for "BREAKING CHANGE".
+var footerTokenRegex = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z][\w-]*)(?:: |:$| #)(.*)$`)
+
+// knownFooterTokens start a footer even inside a paragraph or when not every
+// line of the paragraph is a footer.
+var knownFooterTokens = []string{
+	"BREAKING CHANGE",
+	"BREAKING-CHANGE",
+	"Refs",
+	"Closes",
+	"Fixes",
+	"Resolves",
+	"See",
+	"Co-authored-by",
+	"Signed-off-by",
+	"Reviewed-by",
+	"Acked-by",
+}
 
 // ValidationError represents a commit message validation error.
 type ValidationError struct {
@@ -42,11 +65,18 @@ type ValidationResult struct {
 
 // CommitMessage represents a structured commit message following Conventional Commits format.
 type CommitMessage struct {
-	Type    string // feat, fix, docs, etc.
-	Scope   string // Optional scope
-	Subject string // Short description (max 72 chars recommended)
-	Body    string // Optional detailed description
-	Footer  string // Optional footer (breaking changes, refs)
+	Type     string // feat, fix, docs, etc.
+	Scope    string // Optional scope
+	Breaking bool   // "!" before the colon marks a breaking change
+	Subject  string // Short description (max 72 chars recommended)
+	Body     string // Optional detailed description
+	Footer   string // Optional footer (breaking changes, refs)
+}
+
+// Footer is a single footer of a commit message, e.g. "Refs: #123".
+type Footer struct {
+	Token string // e.g. "Refs" or "BREAKING CHANGE"; empty for a bare "#123" line
+	Value string // May span several lines and paragraphs
 }
 
 // NewCommitMessage creates a new CommitMessage from raw text.
@@ -56,113 +86,163 @@ func NewCommitMessage(rawText string) *CommitMessage {
 	return cm
 }
 
-// Parse parses raw text into the CommitMessage structure.
+// Parse parses raw text following the Conventional Commits 1.0.0
+// specification into the CommitMessage structure. A header that is not in
+// the <type>[(<scope>)][!]: <description> form is kept as the subject. The
+// footer starts at the first line with a known footer token or the first
+// paragraph made up of footer lines only, and runs to the end of the
+// message, so footer values may span several paragraphs.
+//
+// Parsing the output of Format yields the same CommitMessage, and Format
+// returns a message already in the canonical form unchanged.
 func (cm *CommitMessage) Parse(rawText string) {
-	rawText = strings.TrimSpace(rawText)
+	*cm = CommitMessage{}
+	rawText = strings.TrimSpace(strings.ReplaceAll(rawText, "\r\n", "\n"))
 	if rawText == "" {
 		return
 	}
 
 	lines := strings.Split(rawText, "\n")
-	if len(lines) == 0 {
-		return
-	}
-
-	// Parse subject line
-	subject := strings.TrimSpace(lines[0])
-	cm.parseSubject(subject)
+	cm.parseSubject(strings.TrimSpace(lines[0]))
 
-	// Parse body and footer
-	if len(lines) > 1 {
-		cm.parseBodyAndFooter(lines[1:])
-	}
+	rest := lines[1:]
+	start := footerStart(rest)
+	cm.Body = strings.TrimSpace(strings.Join(rest[:start], "\n"))
+	cm.Footer = strings.TrimSpace(strings.Join(rest[start:], "\n"))
 }
 
-// parseSubject parses the subject line for Conventional Commits format.
+// parseSubject parses the header line for Conventional Commits format.
 func (cm *CommitMessage) parseSubject(subject string) {
-	matches := conventionalCommitRegex.FindStringSubmatch(subject)
-	if matches != nil {
-		cm.Type = matches[1]
-		if matches[2] != "" {
-			cm.Scope = strings.Trim(matches[2], "()")
-		}
-		cm.Subject = strings.TrimSpace(matches[3])
-	} else {
-		// Try to extract type if it looks like "type: subject"
-		if idx := strings.Index(subject, ":"); idx > 0 {
-			potentialType := strings.TrimSpace(subject[:idx])
-			if IsValidCommitType(potentialType) {
-				cm.Type = potentialType
-				cm.Subject = strings.TrimSpace(subject[idx+1:])
-				return
-			}
-		}
+	matches := headerRegex.FindStringSubmatch(subject)
+	// Without a space after the colon only a known type makes a header, so
+	// "http://example.com" stays a subject
+	if matches != nil && matches[4] == "" && matches[5] != "" && !IsValidCommitType(matches[1]) {
+		matches = nil
+	}
+	if matches == nil {
 		// Not a valid format, store as subject only
 		cm.Subject = subject
+		return
 	}
+	cm.Type = matches[1]
+	cm.Scope = strings.TrimSpace(matches[2])
+	cm.Breaking = matches[3] != ""
+	cm.Subject = strings.TrimSpace(matches[5])
 }
 
-// parseBodyAndFooter parses the body and footer sections.
-func (cm *CommitMessage) parseBodyAndFooter(lines []string) {
-	bodyLines := []string{}
-	footerLines := []string{}
-	inFooter := false
-	foundBlankAfterSubject := false
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		// Skip the first blank line after subject
-		if !foundBlankAfterSubject && trimmedLine == "" {
-			foundBlankAfterSubject = true
+// footerStart returns the index of the line the footer starts at, or
+// len(lines) if there is no footer.
+func footerStart(lines []string) int {
+	paragraphStart := true
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			paragraphStart = true
 			continue
 		}
-
-		// Check if this line starts a footer section
-		if isFooterLine(trimmedLine) {
-			inFooter = true
+		if isFooterLine(trimmed) {
+			return i
 		}
-
-		if inFooter {
-			footerLines = append(footerLines, line)
-		} else if foundBlankAfterSubject {
-			bodyLines = append(bodyLines, line)
+		if paragraphStart && isFooterParagraph(lines[i:]) {
+			return i
 		}
+		paragraphStart = false
 	}
+	return len(lines)
+}
 
-	cm.Body = strings.TrimSpace(strings.Join(bodyLines, "\n"))
-	cm.Footer = strings.TrimSpace(strings.Join(footerLines, "\n"))
+// isFooterParagraph reports whether every line of the paragraph at the start
+// of lines is a footer line with a token.
+func isFooterParagraph(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			break
+		}
+		if !footerTokenRegex.MatchString(trimmed) {
+			return false
+		}
+	}
+	return true
 }
 
-// isFooterLine checks if a line is a footer line.
+// isFooterLine checks if a line starts with a known footer token or is an
+// issue reference like "#123".
 func isFooterLine(line string) bool {
-	footerPrefixes := []string{
-		"BREAKING CHANGE:",
-		"BREAKING-CHANGE:",
-		"Refs:",
-		"Closes:",
-		"Fixes:",
-		"Resolves:",
-		"See:",
-		"Co-authored-by:",
-		"Signed-off-by:",
-		"Reviewed-by:",
-		"Acked-by:",
+	if strings.HasPrefix(line, "#") {
+		return true
 	}
-
-	upperLine := strings.ToUpper(line)
-	for _, prefix := range footerPrefixes {
-		if strings.HasPrefix(upperLine, strings.ToUpper(prefix)) {
+	matches := footerTokenRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return false
+	}
+	for _, token := range knownFooterTokens {
+		if strings.EqualFold(matches[1], token) {
 			return true
 		}
 	}
+	return false
+}
 
-	// Also check for issue references like "#123" at the start
-	if strings.HasPrefix(line, "#") {
-		return true
+// Footers splits the footer into its token-value pairs. A line that does not
+// start with a token continues the value of the footer before it, so values
+// may span several lines and paragraphs.
+func (cm *CommitMessage) Footers() []Footer {
+	if cm.Footer == "" {
+		return nil
 	}
 
-	return false
+	var footers []Footer
+	for _, line := range strings.Split(cm.Footer, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if matches := footerTokenRegex.FindStringSubmatch(trimmed); matches != nil {
+			value := matches[2]
+			if strings.HasPrefix(trimmed[len(matches[1]):], " #") {
+				value = "#" + value
+			}
+			footers = append(footers, Footer{Token: matches[1], Value: value})
+			continue
+		}
+		if len(footers) == 0 {
+			footers = append(footers, Footer{Value: line})
+			continue
+		}
+		last := &footers[len(footers)-1]
+		last.Value += "\n" + line
+	}
+	for i := range footers {
+		footers[i].Value = strings.TrimSpace(footers[i].Value)
+	}
+	return footers
+}
+
+// IsBreaking reports whether the message declares a breaking change, with
+// "!" in the header or a BREAKING CHANGE footer.
+func (cm *CommitMessage) IsBreaking() bool {
+	return cm.Breaking || cm.breakingFooter() != nil
+}
+
+// BreakingChange returns the description of the breaking change: the value
+// of the BREAKING CHANGE footer, or the subject if the change is only marked
+// with "!". It is empty if the message declares no breaking change.
+func (cm *CommitMessage) BreakingChange() string {
+	if footer := cm.breakingFooter(); footer != nil {
+		return footer.Value
+	}
+	if cm.Breaking {
+		return cm.Subject
+	}
+	return ""
+}
+
+// breakingFooter returns the first BREAKING CHANGE footer, if any.
+func (cm *CommitMessage) breakingFooter() *Footer {
+	for _, footer := range cm.Footers() {
+		if footer.Token == "BREAKING CHANGE" || footer.Token == "BREAKING-CHANGE" {
+			return &footer
+		}
+	}
+	return nil
 }
 
 // Format returns the full formatted commit message following Conventional Commits.
@@ -193,10 +273,14 @@ func (cm *CommitMessage) FormatSubject() string {
 		return cm.Subject
 	}
 
+	header := cm.Type
 	if cm.Scope != "" {
-		return cm.Type + "(" + cm.Scope + "): " + cm.Subject
+		header += "(" + cm.Scope + ")"
 	}
-	return cm.Type + ": " + cm.Subject
+	if cm.Breaking {
+		header += "!"
+	}
+	return header + ": " + cm.Subject
 }
 
 // Validate validates the commit message against Conventional Commits format.
@@ -247,11 +331,10 @@ func (cm *CommitMessage) ValidateWithWarnings() *ValidationResult {
 	}
 
 	// Check subject length (warning, not error)
-	subjectLine := cm.FormatSubject()
-	if len(subjectLine) > MaxSubjectLength {
+	if length := cm.SubjectLength(); length > MaxSubjectLength {
 		result.Warnings = append(result.Warnings, fmt.Sprintf(
 			"subject line exceeds %d characters (%d chars)",
-			MaxSubjectLength, len(subjectLine),
+			MaxSubjectLength, length,
 		))
 	}
 
@@ -263,9 +346,15 @@ func IsValidCommitType(commitType string) bool {
 	return slices.Contains(ValidCommitTypes, commitType)
 }
 
+// SubjectLength returns the length of the formatted subject line in characters,
+// so a Chinese subject is not counted at three bytes per character.
+func (cm *CommitMessage) SubjectLength() int {
+	return utf8.RuneCountInString(cm.FormatSubject())
+}
+
 // SubjectExceedsLength checks if the formatted subject line exceeds the max length.
 func (cm *CommitMessage) SubjectExceedsLength() bool {
-	return len(cm.FormatSubject()) > MaxSubjectLength
+	return cm.SubjectLength() > MaxSubjectLength
 }
 
 // HasBody returns true if the commit message has a body section.
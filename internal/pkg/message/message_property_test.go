@@ -0,0 +1,110 @@
+package message
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// Feature: gitsage, Property 15: Conventional Commits round trip
+// Validates: Requirements 4.1
+
+// genWords generates a line of one to four lowercase words.
+func genWords() gopter.Gen {
+	return gen.SliceOfN(4, gen.AlphaString().SuchThat(func(s string) bool {
+		return len(s) > 0
+	})).Map(func(words []string) string {
+		return strings.ToLower(strings.Join(words, " "))
+	}).SuchThat(func(s string) bool {
+		return s != ""
+	})
+}
+
+// genBody generates an optional body of up to three paragraphs.
+func genBody() gopter.Gen {
+	return gen.SliceOf(genWords()).Map(func(lines []string) string {
+		if len(lines) > 3 {
+			lines = lines[:3]
+		}
+		return strings.Join(lines, "\n\n")
+	})
+}
+
+// genFooter generates an optional footer of trailers, some of them with
+// values spanning several lines.
+func genFooter() gopter.Gen {
+	trailer := gopter.CombineGens(
+		gen.OneConstOf("Refs: ", "Closes #", "Reviewed-by: ", "BREAKING CHANGE: ", "BREAKING-CHANGE: "),
+		genWords(),
+		gen.OneConstOf("", "\ncontinued here", "\n\nin a second paragraph"),
+	).Map(func(values []any) string {
+		return values[0].(string) + values[1].(string) + values[2].(string)
+	})
+	return gen.SliceOf(trailer).Map(func(trailers []string) string {
+		if len(trailers) > 3 {
+			trailers = trailers[:3]
+		}
+		return strings.Join(trailers, "\n")
+	})
+}
+
+// genCommitMessage generates a commit message in canonical form.
+func genCommitMessage() gopter.Gen {
+	return gopter.CombineGens(
+		gen.OneConstOf("feat", "fix", "docs", "refactor", "chore"),
+		gen.OneConstOf("", "api", "auth", "ui"),
+		gen.Bool(),
+		genWords(),
+		genBody(),
+		genFooter(),
+	).Map(func(values []any) *CommitMessage {
+		return &CommitMessage{
+			Type:     values[0].(string),
+			Scope:    values[1].(string),
+			Breaking: values[2].(bool),
+			Subject:  values[3].(string),
+			Body:     values[4].(string),
+			Footer:   values[5].(string),
+		}
+	})
+}
+
+// TestProperty_ConventionalCommitsRoundTrip verifies that formatting a commit
+// message and parsing it again yields the same message, and that Format is
+// stable on anything Parse accepts.
+//
+// Feature: gitsage, Property 15: Conventional Commits round trip
+// Validates: Requirements 4.1
+func TestProperty_ConventionalCommitsRoundTrip(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 200
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("parse of format returns the message", prop.ForAll(
+		func(cm *CommitMessage) bool {
+			return *NewCommitMessage(cm.Format()) == *cm
+		},
+		genCommitMessage(),
+	))
+
+	properties.Property("format of parse is stable", prop.ForAll(
+		func(rawText string) bool {
+			formatted := NewCommitMessage(rawText).Format()
+			return NewCommitMessage(formatted).Format() == formatted
+		},
+		gen.AnyString(),
+	))
+
+	properties.Property("breaking marker and footer are detected", prop.ForAll(
+		func(cm *CommitMessage) bool {
+			footer := strings.Contains(cm.Footer, "BREAKING CHANGE: ") || strings.Contains(cm.Footer, "BREAKING-CHANGE: ")
+			return NewCommitMessage(cm.Format()).IsBreaking() == (cm.Breaking || footer)
+		},
+		genCommitMessage(),
+	))
+
+	properties.TestingRun(t)
+}
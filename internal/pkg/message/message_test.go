@@ -1,6 +1,7 @@
 package message
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -328,6 +329,15 @@ func TestCommitMessage_SubjectExceedsLength(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "chinese subject counted in characters",
+			cm: &CommitMessage{
+				Type:    "feat",
+				Scope:   "user",
+				Subject: strings.Repeat("新增用户信息字段", 10),
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -340,6 +350,19 @@ func TestCommitMessage_SubjectExceedsLength(t *testing.T) {
 	}
 }
 
+func TestCommitMessage_SubjectLength(t *testing.T) {
+	cm := &CommitMessage{Type: "fix", Subject: "修复空指针"}
+	if got := cm.SubjectLength(); got != 10 {
+		t.Errorf("SubjectLength() = %d, want 10", got)
+	}
+
+	long := &CommitMessage{Type: "feat", Subject: strings.Repeat("长", 100)}
+	result := long.ValidateWithWarnings()
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "(106 chars)") {
+		t.Errorf("ValidateWithWarnings() warnings = %v, want a length warning counting characters", result.Warnings)
+	}
+}
+
 func TestCommitMessage_IsMultiLine(t *testing.T) {
 	tests := []struct {
 		name string
@@ -392,3 +415,86 @@ func TestCommitMessage_IsMultiLine(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitMessage_ParseHeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		rawText      string
+		wantType     string
+		wantScope    string
+		wantBreaking bool
+		wantSubject  string
+	}{
+		{"breaking marker", "feat!: drop Go 1.20 support", "feat", "", true, "drop Go 1.20 support"},
+		{"breaking marker with scope", "refactor(api)!: rename endpoints", "refactor", "api", true, "rename endpoints"},
+		{"unknown type is kept", "feature: add login", "feature", "", false, "add login"},
+		{"known type without space", "fix:handle nil", "fix", "", false, "handle nil"},
+		{"url is not a header", "http://example.com", "", "", false, "http://example.com"},
+		{"plain subject", "add pagination", "", "", false, "add pagination"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewCommitMessage(tt.rawText)
+			if cm.Type != tt.wantType || cm.Scope != tt.wantScope || cm.Breaking != tt.wantBreaking || cm.Subject != tt.wantSubject {
+				t.Errorf("Parse(%q) = %+v", tt.rawText, cm)
+			}
+		})
+	}
+}
+
+func TestCommitMessage_Footers(t *testing.T) {
+	rawText := "feat: add export\n\nExports reports as CSV.\n\nNote: body, not a footer\nbecause this line is prose.\n\n" +
+		"Reviewed-by: Z\nRefs #133\nBREAKING CHANGE: reports are written\nto a new directory.\n\nMove existing reports by hand."
+	cm := NewCommitMessage(rawText)
+
+	wantBody := "Exports reports as CSV.\n\nNote: body, not a footer\nbecause this line is prose."
+	if cm.Body != wantBody {
+		t.Errorf("Body = %q, want %q", cm.Body, wantBody)
+	}
+
+	want := []Footer{
+		{Token: "Reviewed-by", Value: "Z"},
+		{Token: "Refs", Value: "#133"},
+		{Token: "BREAKING CHANGE", Value: "reports are written\nto a new directory.\n\nMove existing reports by hand."},
+	}
+	got := cm.Footers()
+	if len(got) != len(want) {
+		t.Fatalf("Footers() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Footers()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if cm.Format() != rawText {
+		t.Errorf("Format() = %q, want %q", cm.Format(), rawText)
+	}
+}
+
+func TestCommitMessage_BreakingChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		rawText      string
+		wantBreaking bool
+		wantChange   string
+	}{
+		{"none", "feat: add export\n\nRefs: #1", false, ""},
+		{"marker only", "feat!: drop v1 endpoints", true, "drop v1 endpoints"},
+		{"footer", "feat: add export\n\nBREAKING CHANGE: config moved", true, "config moved"},
+		{"hyphenated footer", "fix: x\n\nBREAKING-CHANGE: flag removed", true, "flag removed"},
+		{"marker and footer", "feat!: v2\n\nBREAKING CHANGE: new schema", true, "new schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := NewCommitMessage(tt.rawText)
+			if cm.IsBreaking() != tt.wantBreaking {
+				t.Errorf("IsBreaking() = %v, want %v", cm.IsBreaking(), tt.wantBreaking)
+			}
+			if cm.BreakingChange() != tt.wantChange {
+				t.Errorf("BreakingChange() = %q, want %q", cm.BreakingChange(), tt.wantChange)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package owners reads a repository's CODEOWNERS file to tell which team
+// owns the changed files, so messages can name the area a change affects.
+package owners
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Locations are the paths, relative to the repository root, where GitHub,
+// GitLab and Gitea look for a CODEOWNERS file, in the order they are tried.
+var Locations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	".gitea/CODEOWNERS",
+}
+
+// rule is a CODEOWNERS line: a path pattern and the owners of the matching
+// files. A rule without owners makes the files unowned.
+type rule struct {
+	pattern string
+	owners  []string
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	rules []rule
+}
+
+// Load returns the CODEOWNERS file of the repository at root from the first
+// of Locations that exists, or nil if there is none.
+func Load(root string) (*File, error) {
+	for _, location := range Locations {
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(location)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return Parse(string(data)), nil
+	}
+	return nil, nil
+}
+
+// Parse parses the content of a CODEOWNERS file. Comments, blank lines and
+// GitLab section headers ("[Section]") are skipped, as are the escapes of
+// patterns with spaces, which are rare enough not to be worth supporting.
+func Parse(content string) *File {
+	f := &File{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = strings.TrimSpace(line[:comment])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		f.rules = append(f.rules, rule{pattern: fields[0], owners: fields[1:]})
+	}
+	return f
+}
+
+// Owners returns the owners of the file at filePath, relative to the
+// repository root. As on GitHub, the last matching rule wins; an empty
+// result means the file is unowned.
+func (f *File) Owners(filePath string) []string {
+	if f == nil {
+		return nil
+	}
+	for i := len(f.rules) - 1; i >= 0; i-- {
+		if match(f.rules[i].pattern, filePath) {
+			return f.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// match reports whether filePath matches a CODEOWNERS pattern, which follows
+// the gitignore rules: a pattern with a leading or inner slash is anchored at
+// the root, others match at any depth; a pattern matching a directory
+// matches every file below it; "*" stays within a path segment and "**"
+// spans any number of them.
+func match(pattern, filePath string) bool {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" || pattern == "**" {
+		return true
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	if !anchored {
+		patternSegments = append([]string{"**"}, patternSegments...)
+	}
+	pathSegments := strings.Split(filePath, "/")
+	// A matched directory owns everything below it
+	for end := len(pathSegments); end > 0; end-- {
+		if matchSegments(patternSegments, pathSegments[:end]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, where a
+// "**" segment matches zero or more path segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+// Area returns the name of the area an owner stands for: the team name of a
+// team ("@acme/payments-team" is "payments"), the user name of a user
+// ("@alice" is "alice") or the local part of an email address.
+func Area(owner string) string {
+	name := strings.TrimPrefix(owner, "@")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	} else if local, _, ok := strings.Cut(name, "@"); ok {
+		name = local
+	}
+	name = strings.ToLower(name)
+	for _, suffix := range []string{"-team", "_team", "-squad", "_squad"} {
+		if trimmed, ok := strings.CutSuffix(name, suffix); ok && trimmed != "" {
+			return trimmed
+		}
+	}
+	return name
+}
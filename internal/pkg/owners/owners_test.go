@@ -0,0 +1,73 @@
+package owners
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codeowners = `# Default owners
+*                      @acme/core
+
+[Payments]
+/services/payments/    @acme/payments-team @alice
+*.sql                  @acme/dba # reviewed by the DBAs
+docs/                  docs@acme.com
+/services/payments/vendor/
+apps/**/i18n/*.json    @acme/l10n
+`
+
+func TestOwners(t *testing.T) {
+	f := Parse(codeowners)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@acme/core"}},
+		{"services/payments/charge.go", []string{"@acme/payments-team", "@alice"}},
+		{"services/payments/db/schema.sql", []string{"@acme/dba"}},
+		{"migrations/001.sql", []string{"@acme/dba"}},
+		{"docs/setup.md", []string{"docs@acme.com"}},
+		{"services/payments/docs/api.md", []string{"docs@acme.com"}},
+		{"services/payments/vendor/lib.go", []string{}},
+		{"apps/web/i18n/en.json", []string{"@acme/l10n"}},
+		{"apps/web/src/i18n/en.json", []string{"@acme/l10n"}},
+		{"apps/web/i18n.json", []string{"@acme/core"}},
+		{"other/services/payments/charge.go", []string{"@acme/core"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, f.Owners(tt.path), tt.path)
+	}
+
+	var missing *File
+	assert.Nil(t, missing.Owners("main.go"))
+	assert.Nil(t, Parse("/api/ @acme/api\n").Owners("web/app.ts"))
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+
+	f, err := Load(root)
+	require.NoError(t, err)
+	assert.Nil(t, f)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @root\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".github"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @github\n"), 0644))
+
+	f, err = Load(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@github"}, f.Owners("main.go"))
+}
+
+func TestArea(t *testing.T) {
+	assert.Equal(t, "payments", Area("@acme/payments-team"))
+	assert.Equal(t, "platform", Area("@acme/Platform_Squad"))
+	assert.Equal(t, "alice", Area("@alice"))
+	assert.Equal(t, "docs", Area("docs@acme.com"))
+	assert.Equal(t, "team", Area("@acme/team"))
+}
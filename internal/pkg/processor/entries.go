@@ -0,0 +1,62 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// shortCommitLength is the length of abbreviated submodule commits.
+const shortCommitLength = 7
+
+// describeEntries replaces the content of symlink and submodule chunks with a
+// one-line description, since their hunks hold a link target or commit rather
+// than file content.
+func (p *DefaultProcessor) describeEntries(chunks []git.DiffChunk) []git.DiffChunk {
+	result := make([]git.DiffChunk, len(chunks))
+	for i, chunk := range chunks {
+		result[i] = chunk
+		if description := describeEntry(&chunk); description != "" {
+			result[i].Content = description + "\n"
+		}
+	}
+	return result
+}
+
+// describeEntry describes a symlink or submodule change, e.g.
+// "Submodule vendor/lib updated from 1a2b3c4 to 5d6e7f8". Returns an empty
+// string for other chunks.
+func describeEntry(chunk *git.DiffChunk) string {
+	switch chunk.ChangeType {
+	case git.ChangeTypeSymlink:
+		switch {
+		case chunk.OldTarget == "":
+			return fmt.Sprintf("Symlink %s added, pointing to %s", chunk.FilePath, chunk.NewTarget)
+		case chunk.NewTarget == "":
+			return fmt.Sprintf("Symlink %s removed (pointed to %s)", chunk.FilePath, chunk.OldTarget)
+		default:
+			return fmt.Sprintf("Symlink %s now points to %s (was %s)", chunk.FilePath, chunk.NewTarget, chunk.OldTarget)
+		}
+	case git.ChangeTypeSubmodule:
+		oldCommit, newCommit := shortCommit(chunk.OldTarget), shortCommit(chunk.NewTarget)
+		switch {
+		case oldCommit == "":
+			return fmt.Sprintf("Submodule %s added at commit %s", chunk.FilePath, newCommit)
+		case newCommit == "":
+			return fmt.Sprintf("Submodule %s removed (was at commit %s)", chunk.FilePath, oldCommit)
+		default:
+			return fmt.Sprintf("Submodule %s updated from commit %s to %s", chunk.FilePath, oldCommit, newCommit)
+		}
+	default:
+		return ""
+	}
+}
+
+// shortCommit abbreviates a commit hash.
+func shortCommit(commit string) string {
+	if len(commit) > shortCommitLength {
+		return commit[:shortCommitLength]
+	}
+	return commit
+}
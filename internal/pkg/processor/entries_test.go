@@ -0,0 +1,80 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestDescribeEntry(t *testing.T) {
+	const (
+		oldCommit = "b3528d67b18f0598c9e8e8dbc08de857a3b3b725"
+		newCommit = "926f4f3c40a387fd65fd2a75ace4f02da661e6d4"
+	)
+
+	tests := []struct {
+		chunk    git.DiffChunk
+		expected string
+	}{
+		{
+			chunk:    git.DiffChunk{FilePath: "config.yaml", ChangeType: git.ChangeTypeSymlink, OldTarget: "config.dev.yaml", NewTarget: "config.prod.yaml"},
+			expected: "Symlink config.yaml now points to config.prod.yaml (was config.dev.yaml)",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "latest", ChangeType: git.ChangeTypeSymlink, NewTarget: "v2"},
+			expected: "Symlink latest added, pointing to v2",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "latest", ChangeType: git.ChangeTypeSymlink, OldTarget: "v1"},
+			expected: "Symlink latest removed (pointed to v1)",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "vendor/lib", ChangeType: git.ChangeTypeSubmodule, OldTarget: oldCommit, NewTarget: newCommit},
+			expected: "Submodule vendor/lib updated from commit b3528d6 to 926f4f3",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "vendor/lib", ChangeType: git.ChangeTypeSubmodule, NewTarget: newCommit},
+			expected: "Submodule vendor/lib added at commit 926f4f3",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "main.go", ChangeType: git.ChangeTypeModified},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := describeEntry(&tt.chunk); got != tt.expected {
+			t.Errorf("describeEntry(%s) = %q, want %q", tt.chunk.FilePath, got, tt.expected)
+		}
+	}
+}
+
+func TestProcess_DescribesEntries(t *testing.T) {
+	p := NewProcessor()
+
+	chunks := []git.DiffChunk{
+		{
+			FilePath:   "config.yaml",
+			ChangeType: git.ChangeTypeSymlink,
+			Content:    "diff --git a/config.yaml b/config.yaml\n@@ -1 +1 @@\n-config.dev.yaml\n+config.prod.yaml\n",
+			OldTarget:  "config.dev.yaml",
+			NewTarget:  "config.prod.yaml",
+		},
+	}
+
+	result, err := p.Process(context.Background(), chunks)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if content := result.Chunks[0].Content; !strings.HasPrefix(content, "Symlink config.yaml now points to") {
+		t.Errorf("Expected the symlink change to be described, got %q", content)
+	}
+
+	summary := p.generateSummary(result.Chunks)
+	if !strings.Contains(summary, "[L] config.yaml") {
+		t.Errorf("Summary should mark symlinks with [L], got:\n%s", summary)
+	}
+}
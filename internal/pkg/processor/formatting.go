@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// Modes for files whose changes are whitespace or formatting only.
+const (
+	FormattingSummarize = "summarize"
+	FormattingExclude   = "exclude"
+	FormattingKeep      = "keep"
+)
+
+// ParseFormattingMode parses a formatting-only mode. An empty name yields FormattingSummarize.
+func ParseFormattingMode(name string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(name)); mode {
+	case "":
+		return FormattingSummarize, nil
+	case FormattingSummarize, FormattingExclude, FormattingKeep:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown formatting mode %q (valid: summarize, exclude, keep)", name)
+	}
+}
+
+// isFormattingOnly reports whether the diff only changes whitespace, i.e.
+// "git diff -w" would show nothing for it.
+func isFormattingOnly(content string) bool {
+	_, hunks := parseHunks(content)
+	if len(hunks) == 0 {
+		return false
+	}
+	for _, h := range hunks {
+		if !isWhitespaceOnly(h) {
+			return false
+		}
+	}
+	return true
+}
+
+// summarizeFormattingFile returns a one-line summary of a formatting-only
+// file, e.g. "main.go: formatting only (+12/-12 lines, content omitted)".
+func summarizeFormattingFile(chunk *git.DiffChunk) string {
+	return fmt.Sprintf("%s: formatting only (+%d/-%d lines, content omitted)\n",
+		chunk.FilePath, chunk.Additions, chunk.Deletions)
+}
+
+// handleFormattingFiles summarizes or leaves out files whose changes are
+// whitespace only, so a formatter run across the repository does not
+// dominate the prompt. If every file would be left out, they are summarized
+// instead so that there is still something to describe.
+func (p *DefaultProcessor) handleFormattingFiles(chunks []git.DiffChunk) []git.DiffChunk {
+	mode, err := ParseFormattingMode(p.config.FormattingMode)
+	if err != nil || mode == FormattingKeep {
+		return chunks
+	}
+
+	result := make([]git.DiffChunk, 0, len(chunks))
+	var formatting []git.DiffChunk
+	for _, chunk := range chunks {
+		if chunk.IsLockFile || chunk.IsBinary || chunk.IsGenerated || describeEntry(&chunk) != "" || !isFormattingOnly(chunk.Content) {
+			result = append(result, chunk)
+			continue
+		}
+		chunk.Content = summarizeFormattingFile(&chunk)
+		if mode == FormattingSummarize {
+			result = append(result, chunk)
+		}
+		formatting = append(formatting, chunk)
+	}
+
+	if mode == FormattingExclude && len(result) == 0 {
+		return formatting
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParseFormattingMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{"", FormattingSummarize, false},
+		{"summarize", FormattingSummarize, false},
+		{" Exclude ", FormattingExclude, false},
+		{"keep", FormattingKeep, false},
+		{"drop", "", true},
+	}
+
+	for _, tt := range tests {
+		mode, err := ParseFormattingMode(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormattingMode(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if mode != tt.expected {
+			t.Errorf("ParseFormattingMode(%q) = %q, want %q", tt.name, mode, tt.expected)
+		}
+	}
+}
+
+func TestIsFormattingOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"reindented", "@@ -1,2 +1,2 @@\n-\tif x {\n-\t}\n+    if x {\n+    }\n", true},
+		{"trailing whitespace", "@@ -1 +1 @@\n-a := 1 \n+a := 1\n@@ -9 +9 @@\n-b\t\n+b\n", true},
+		{"one real change", "@@ -1 +1 @@\n-a := 1 \n+a := 1\n@@ -9 +9 @@\n-b\n+c\n", false},
+		{"added line", "@@ -0,0 +1 @@\n+a\n", false},
+		{"no hunks", "diff --git a/x b/x\nold mode 100644\nnew mode 100755\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := isFormattingOnly(tt.content); got != tt.expected {
+			t.Errorf("%s: isFormattingOnly() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestProcess_FormattingOnly(t *testing.T) {
+	formatted := git.DiffChunk{FilePath: "fmt.go", Additions: 1, Deletions: 1, Content: "@@ -1 +1 @@\n-a  :=  1\n+a := 1\n"}
+	changed := git.DiffChunk{FilePath: "main.go", Additions: 1, Deletions: 1, Content: "@@ -1 +1 @@\n-a\n+b\n"}
+	summary := "fmt.go: formatting only (+1/-1 lines, content omitted)\n"
+
+	tests := []struct {
+		name     string
+		mode     string
+		chunks   []git.DiffChunk
+		expected map[string]string
+	}{
+		{"summarize by default", "", []git.DiffChunk{formatted, changed},
+			map[string]string{"fmt.go": summary, "main.go": changed.Content}},
+		{"exclude", FormattingExclude, []git.DiffChunk{formatted, changed},
+			map[string]string{"main.go": changed.Content}},
+		{"exclude keeps summaries when nothing else is left", FormattingExclude, []git.DiffChunk{formatted},
+			map[string]string{"fmt.go": summary}},
+		{"keep", FormattingKeep, []git.DiffChunk{formatted, changed},
+			map[string]string{"fmt.go": formatted.Content, "main.go": changed.Content}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProcessorWithConfig(ProcessorConfig{FormattingMode: tt.mode})
+			result, err := p.Process(context.Background(), tt.chunks)
+			if err != nil {
+				t.Fatalf("Process failed: %v", err)
+			}
+			if len(result.Chunks) != len(tt.expected) {
+				t.Fatalf("got %d chunks, want %d", len(result.Chunks), len(tt.expected))
+			}
+			for _, chunk := range result.Chunks {
+				if chunk.Content != tt.expected[chunk.FilePath] {
+					t.Errorf("%s: content = %q, want %q", chunk.FilePath, chunk.Content, tt.expected[chunk.FilePath])
+				}
+			}
+		})
+	}
+}
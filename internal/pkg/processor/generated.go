@@ -0,0 +1,148 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// DefaultGeneratedPatterns are path globs of commonly generated files.
+// Patterns without a slash match the file name; "dir/**" matches any file
+// below a directory of that name.
+var DefaultGeneratedPatterns = []string{
+	"*.pb.go",
+	"*.pb.gw.go",
+	"*_pb2.py",
+	"*_pb2_grpc.py",
+	"*_pb.js",
+	"*_pb.d.ts",
+	"*.pb.ts",
+	"mock_*.go",
+	"*_mock.go",
+	"mocks/**",
+	"swagger.json",
+	"swagger.yaml",
+	"*.swagger.json",
+	"zz_generated*.go",
+}
+
+// generatedHeaderLines is the number of lines at the top of a file searched
+// for a generated-code marker.
+const generatedHeaderLines = 10
+
+// generatedMarkerPattern matches common generated-code header comments, such
+// as Go's "Code generated by ... DO NOT EDIT."
+var generatedMarkerPattern = regexp.MustCompile(`(?i)code generated by|@generated|<auto-generated`)
+
+// hunkHeaderPattern captures the old and new start lines of a hunk header.
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// MatchPattern reports whether filePath matches a path glob. Patterns
+// without a slash match the file name; "dir/**" matches any file below a
+// directory of that name.
+func MatchPattern(pattern, filePath string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		return strings.HasPrefix(filePath, dir+"/") || strings.Contains(filePath, "/"+dir+"/")
+	}
+	if !strings.Contains(pattern, "/") {
+		filePath = path.Base(filePath)
+	}
+	matched, err := path.Match(pattern, filePath)
+	return err == nil && matched
+}
+
+// hasGeneratedHeader reports whether the diff shows a generated-code marker
+// within the first lines of the old or new file.
+func hasGeneratedHeader(content string) bool {
+	oldLine, newLine := 0, 0
+	for _, line := range strings.Split(content, "\n") {
+		if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			continue
+		}
+		if oldLine == 0 && newLine == 0 || line == "" {
+			continue
+		}
+
+		inHeader := false
+		switch line[0] {
+		case '+':
+			inHeader = newLine <= generatedHeaderLines
+			newLine++
+		case '-':
+			inHeader = oldLine <= generatedHeaderLines
+			oldLine++
+		case ' ':
+			inHeader = newLine <= generatedHeaderLines || oldLine <= generatedHeaderLines
+			oldLine++
+			newLine++
+		default:
+			continue
+		}
+		if inHeader && generatedMarkerPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedKind describes what a generated file contains, based on its path.
+func generatedKind(filePath string) string {
+	base := strings.ToLower(path.Base(filePath))
+	lower := strings.ToLower(filePath)
+	switch {
+	case strings.Contains(base, ".pb.") || strings.Contains(base, "_pb2") || strings.Contains(base, "_pb."):
+		return "protobuf stubs"
+	case strings.Contains(lower, "mock"):
+		return "mocks"
+	case strings.Contains(base, "swagger") || strings.Contains(base, "openapi"):
+		return "API docs"
+	default:
+		return "generated code"
+	}
+}
+
+// summarizeGeneratedFile returns a one-line summary of a generated file,
+// e.g. "api/user.pb.go: regenerated protobuf stubs (+120/-80 lines)".
+func summarizeGeneratedFile(chunk *git.DiffChunk) string {
+	verb := "regenerated"
+	switch chunk.ChangeType {
+	case git.ChangeTypeAdded:
+		verb = "added generated"
+	case git.ChangeTypeDeleted:
+		verb = "removed generated"
+	}
+	return fmt.Sprintf("%s: %s %s (+%d/-%d lines, content omitted)\n",
+		chunk.FilePath, verb, generatedKind(chunk.FilePath), chunk.Additions, chunk.Deletions)
+}
+
+// summarizeGeneratedFiles marks generated files and replaces their content
+// with a one-line summary so they do not flood the prompt.
+func (p *DefaultProcessor) summarizeGeneratedFiles(chunks []git.DiffChunk) []git.DiffChunk {
+	result := make([]git.DiffChunk, len(chunks))
+	for i, chunk := range chunks {
+		result[i] = chunk
+		if chunk.IsLockFile || chunk.IsBinary || describeEntry(&chunk) != "" || !p.isGenerated(&chunk) {
+			continue
+		}
+		result[i].IsGenerated = true
+		result[i].Content = summarizeGeneratedFile(&chunk)
+	}
+	return result
+}
+
+// isGenerated reports whether a chunk is a generated file, by path glob or header.
+func (p *DefaultProcessor) isGenerated(chunk *git.DiffChunk) bool {
+	for _, pattern := range p.config.GeneratedPatterns {
+		if MatchPattern(pattern, chunk.FilePath) {
+			return true
+		}
+	}
+	return hasGeneratedHeader(chunk.Content)
+}
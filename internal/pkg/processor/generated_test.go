@@ -0,0 +1,120 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"*.pb.go", "api/v1/user.pb.go", true},
+		{"*.pb.go", "api/v1/user.go", false},
+		{"mocks/**", "mocks/store.go", true},
+		{"mocks/**", "internal/mocks/store.go", true},
+		{"mocks/**", "internal/mockserver/store.go", false},
+		{"gen/*.ts", "gen/client.ts", true},
+		{"gen/*.ts", "web/gen/client.ts", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchPattern(tt.pattern, tt.path); got != tt.expected {
+			t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestHasGeneratedHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{
+			name:     "new file with Go header",
+			content:  "@@ -0,0 +1,3 @@\n+// Code generated by mockery v2.20.0. DO NOT EDIT.\n+\n+package mocks\n",
+			expected: true,
+		},
+		{
+			name:     "header in context lines",
+			content:  "@@ -1,4 +1,4 @@\n // @generated by protoc-gen-ts\n import x\n-old\n+new\n",
+			expected: true,
+		},
+		{
+			name:     "marker far from the top",
+			content:  "@@ -200,2 +200,2 @@\n-// Code generated by hand, just kidding\n+// fixed\n",
+			expected: false,
+		},
+		{
+			name:     "regular file",
+			content:  "@@ -1,2 +1,2 @@\n package main\n-func a() {}\n+func b() {}\n",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasGeneratedHeader(tt.content); got != tt.expected {
+				t.Errorf("hasGeneratedHeader() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSummarizeGeneratedFile(t *testing.T) {
+	tests := []struct {
+		chunk    git.DiffChunk
+		expected string
+	}{
+		{
+			chunk:    git.DiffChunk{FilePath: "api/user.pb.go", ChangeType: git.ChangeTypeModified, Additions: 120, Deletions: 80},
+			expected: "api/user.pb.go: regenerated protobuf stubs (+120/-80 lines, content omitted)\n",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "internal/mocks/store.go", ChangeType: git.ChangeTypeAdded, Additions: 40},
+			expected: "internal/mocks/store.go: added generated mocks (+40/-0 lines, content omitted)\n",
+		},
+		{
+			chunk:    git.DiffChunk{FilePath: "docs/swagger.json", ChangeType: git.ChangeTypeDeleted, Deletions: 300},
+			expected: "docs/swagger.json: removed generated API docs (+0/-300 lines, content omitted)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := summarizeGeneratedFile(&tt.chunk); got != tt.expected {
+			t.Errorf("summarizeGeneratedFile() = %q, want %q", got, tt.expected)
+		}
+	}
+}
+
+func TestProcess_SummarizesGeneratedFiles(t *testing.T) {
+	p := NewProcessorWithConfig(ProcessorConfig{GeneratedPatterns: []string{"gen/**"}})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "api/user.pb.go", Content: "@@ -1 +1 @@\n-a\n+b\n"},
+		{FilePath: "gen/client.ts", Content: "@@ -1 +1 @@\n-a\n+b\n"},
+		{FilePath: "main.go", Content: "@@ -0,0 +1 @@\n+// Code generated by stringer; DO NOT EDIT.\n"},
+		{FilePath: "service.go", Content: "@@ -1 +1 @@\n-a\n+b\n"},
+	}
+
+	result, err := p.Process(context.Background(), chunks)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	for _, chunk := range result.Chunks {
+		expected := chunk.FilePath != "service.go"
+		if chunk.IsGenerated != expected {
+			t.Errorf("%s: IsGenerated = %v, want %v", chunk.FilePath, chunk.IsGenerated, expected)
+		}
+		if chunk.IsGenerated && chunk.Content == chunks[0].Content {
+			t.Errorf("%s: content should be replaced with a summary", chunk.FilePath)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// maxLockSummaryExamples is the number of changed packages named in a lock file summary.
+const maxLockSummaryExamples = 3
+
+// lockLineParser extracts a package name and/or version from a lock file line
+// (without the diff prefix). Either result may be empty.
+type lockLineParser func(line string) (name, version string)
+
+var (
+	jsonKeyPattern       = regexp.MustCompile(`^\s*"([^"]*)": \{$`)
+	jsonNamePattern      = regexp.MustCompile(`^\s*"name": "([^"]+)",?$`)
+	jsonVersionPattern   = regexp.MustCompile(`^\s*"version": "([^"]+)",?$`)
+	tomlNamePattern      = regexp.MustCompile(`^name = "([^"]+)"$`)
+	tomlVersionPattern   = regexp.MustCompile(`^version = "([^"]+)"$`)
+	yarnVersionPattern   = regexp.MustCompile(`^\s+version:? "?([^"\s]+)"?$`)
+	gemfileSpecPattern   = regexp.MustCompile(`^    (\S+) \(([^)]+)\)$`)
+	pnpmPackagePattern   = regexp.MustCompile(`^  '?/?(@?[^@\s']+)@([^(:'\s]+)`)
+	jsonStructuralFields = map[string]bool{
+		"": true, "packages": true, "dependencies": true, "devDependencies": true,
+		"optionalDependencies": true, "peerDependencies": true, "requires": true,
+		"default": true, "develop": true, "_meta": true, "hash": true, "engines": true,
+		"bin": true, "funding": true,
+	}
+)
+
+// lockParserFor returns the line parser for a lock file.
+func lockParserFor(filePath string) lockLineParser {
+	switch filepath.Base(filePath) {
+	case "go.sum":
+		return parseGoSumLine
+	case "yarn.lock":
+		return parseYarnLine
+	case "pnpm-lock.yaml":
+		return parsePnpmLine
+	case "Gemfile.lock":
+		return parseGemfileLine
+	case "Cargo.lock", "poetry.lock":
+		return parseTomlLine
+	default:
+		// package-lock.json, composer.lock, Pipfile.lock and other JSON lock files
+		return parseJSONLine
+	}
+}
+
+func parseJSONLine(line string) (string, string) {
+	if m := jsonKeyPattern.FindStringSubmatch(line); m != nil && !jsonStructuralFields[m[1]] {
+		// package-lock.json v2 keys are install paths
+		name := m[1]
+		if i := strings.LastIndex(name, "node_modules/"); i >= 0 {
+			name = name[i+len("node_modules/"):]
+		}
+		return name, ""
+	}
+	if m := jsonNamePattern.FindStringSubmatch(line); m != nil {
+		return m[1], ""
+	}
+	if m := jsonVersionPattern.FindStringSubmatch(line); m != nil {
+		return "", strings.TrimPrefix(m[1], "==")
+	}
+	return "", ""
+}
+
+func parseTomlLine(line string) (string, string) {
+	if m := tomlNamePattern.FindStringSubmatch(line); m != nil {
+		return m[1], ""
+	}
+	if m := tomlVersionPattern.FindStringSubmatch(line); m != nil {
+		return "", m[1]
+	}
+	return "", ""
+}
+
+func parseGoSumLine(line string) (string, string) {
+	fields := strings.Fields(line)
+	if len(fields) != 3 {
+		return "", ""
+	}
+	return fields[0], strings.TrimSuffix(fields[1], "/go.mod")
+}
+
+func parseYarnLine(line string) (string, string) {
+	// Entry headers start at column 0: "@babel/core@^7.0.0", "@babel/core@^7.1.0":
+	if line != "" && line[0] != ' ' && line[0] != '#' && strings.HasSuffix(line, ":") {
+		spec := strings.Trim(strings.SplitN(line, ",", 2)[0], `":`)
+		if i := strings.LastIndex(spec, "@"); i > 0 {
+			return spec[:i], ""
+		}
+		return "", ""
+	}
+	if m := yarnVersionPattern.FindStringSubmatch(line); m != nil {
+		return "", m[1]
+	}
+	return "", ""
+}
+
+func parsePnpmLine(line string) (string, string) {
+	if m := pnpmPackagePattern.FindStringSubmatch(line); m != nil && strings.HasSuffix(line, ":") {
+		return m[1], m[2]
+	}
+	return "", ""
+}
+
+func parseGemfileLine(line string) (string, string) {
+	if m := gemfileSpecPattern.FindStringSubmatch(line); m != nil {
+		return m[1], m[2]
+	}
+	return "", ""
+}
+
+// lockChange is a package whose locked version changed.
+type lockChange struct {
+	name       string
+	oldVersion string
+	newVersion string
+}
+
+// parseLockDiff compares the package versions on removed and added lines of a
+// lock file diff. Each side tracks the package it is in from context lines,
+// so a version bump under an unchanged package header is attributed to it.
+func parseLockDiff(content string, parse lockLineParser) (updated, added, removed []lockChange) {
+	oldVersions := make(map[string]string)
+	newVersions := make(map[string]string)
+	var oldName, newName string
+	inHunk := false
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			inHunk = true
+			oldName, newName = "", ""
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "diff --git ") {
+			inHunk = false
+			continue
+		}
+
+		prefix, text := line[0], line[1:]
+		name, version := parse(text)
+		if name != "" {
+			if prefix != '+' {
+				oldName = name
+			}
+			if prefix != '-' {
+				newName = name
+			}
+		}
+		if version == "" {
+			continue
+		}
+		switch {
+		case prefix == '-' && oldName != "":
+			oldVersions[oldName] = version
+		case prefix == '+' && newName != "":
+			newVersions[newName] = version
+		}
+	}
+
+	for name, newVersion := range newVersions {
+		oldVersion, ok := oldVersions[name]
+		switch {
+		case !ok:
+			added = append(added, lockChange{name: name, newVersion: newVersion})
+		case oldVersion != newVersion:
+			updated = append(updated, lockChange{name: name, oldVersion: oldVersion, newVersion: newVersion})
+		}
+	}
+	for name, oldVersion := range oldVersions {
+		if _, ok := newVersions[name]; !ok {
+			removed = append(removed, lockChange{name: name, oldVersion: oldVersion})
+		}
+	}
+
+	for _, changes := range [][]lockChange{updated, added, removed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].name < changes[j].name })
+	}
+	return updated, added, removed
+}
+
+// summarizeLockFile returns a one-line summary of the package changes in a
+// lock file diff, e.g. "package-lock.json: 12 packages updated, 3 added".
+// Falls back to line counts when no packages can be recognized.
+func summarizeLockFile(chunk *git.DiffChunk) string {
+	updated, added, removed := parseLockDiff(chunk.Content, lockParserFor(chunk.FilePath))
+	if len(updated)+len(added)+len(removed) == 0 {
+		return fmt.Sprintf("%s: lock file changed (+%d/-%d lines)\n", chunk.FilePath, chunk.Additions, chunk.Deletions)
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		count int
+		verb  string
+	}{{len(updated), "updated"}, {len(added), "added"}, {len(removed), "removed"}} {
+		if part.count == 0 {
+			continue
+		}
+		if len(parts) == 0 {
+			noun := "packages"
+			if part.count == 1 {
+				noun = "package"
+			}
+			parts = append(parts, fmt.Sprintf("%d %s %s", part.count, noun, part.verb))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %s", part.count, part.verb))
+		}
+	}
+
+	changes := make([]lockChange, 0, len(updated)+len(added)+len(removed))
+	changes = append(append(append(changes, updated...), added...), removed...)
+
+	var examples []string
+	for _, change := range changes {
+		if len(examples) == maxLockSummaryExamples {
+			examples = append(examples, "...")
+			break
+		}
+		switch {
+		case change.oldVersion == "":
+			examples = append(examples, fmt.Sprintf("+%s %s", change.name, change.newVersion))
+		case change.newVersion == "":
+			examples = append(examples, fmt.Sprintf("-%s %s", change.name, change.oldVersion))
+		default:
+			examples = append(examples, fmt.Sprintf("%s %s -> %s", change.name, change.oldVersion, change.newVersion))
+		}
+	}
+
+	return fmt.Sprintf("%s: %s (%s)\n", chunk.FilePath, strings.Join(parts, ", "), strings.Join(examples, ", "))
+}
@@ -0,0 +1,115 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+const packageLockDiff = `diff --git a/package-lock.json b/package-lock.json
+--- a/package-lock.json
++++ b/package-lock.json
+@@ -10,12 +10,17 @@
+     "node_modules/react": {
+-      "version": "18.2.0",
++      "version": "18.3.1",
+       "license": "MIT"
+     },
++    "node_modules/scheduler": {
++      "version": "0.23.2",
++      "license": "MIT"
++    },
+-    "node_modules/left-pad": {
+-      "version": "1.3.0",
+-      "license": "WTFPL"
+-    },
+     "node_modules/react-dom": {
+-      "version": "18.2.0",
++      "version": "18.3.1",
+       "dependencies": {
+`
+
+func TestSummarizeLockFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		chunk    git.DiffChunk
+		expected string
+	}{
+		{
+			name:     "package-lock.json",
+			chunk:    git.DiffChunk{FilePath: "package-lock.json", Content: packageLockDiff},
+			expected: "package-lock.json: 2 packages updated, 1 added, 1 removed (react 18.2.0 -> 18.3.1, react-dom 18.2.0 -> 18.3.1, +scheduler 0.23.2, ...)\n",
+		},
+		{
+			name: "go.sum",
+			chunk: git.DiffChunk{FilePath: "go.sum", Content: "@@ -1,4 +1,4 @@\n" +
+				"-golang.org/x/net v0.1.0 h1:aaa=\n-golang.org/x/net v0.1.0/go.mod h1:bbb=\n" +
+				"+golang.org/x/net v0.2.0 h1:ccc=\n+golang.org/x/net v0.2.0/go.mod h1:ddd=\n"},
+			expected: "go.sum: 1 package updated (golang.org/x/net v0.1.0 -> v0.2.0)\n",
+		},
+		{
+			name: "yarn.lock",
+			chunk: git.DiffChunk{FilePath: "yarn.lock", Content: "@@ -1,4 +1,4 @@\n" +
+				` "@babel/core@^7.0.0", "@babel/core@^7.1.0":` + "\n" +
+				`-  version "7.0.0"` + "\n" + `+  version "7.1.2"` + "\n"},
+			expected: "yarn.lock: 1 package updated (@babel/core 7.0.0 -> 7.1.2)\n",
+		},
+		{
+			name: "Cargo.lock",
+			chunk: git.DiffChunk{FilePath: "Cargo.lock", Content: "@@ -1,4 +1,8 @@\n" +
+				" [[package]]\n name = \"serde\"\n-version = \"1.0.1\"\n+version = \"1.0.2\"\n" +
+				"+\n+[[package]]\n+name = \"toml\"\n+version = \"0.8.0\"\n"},
+			expected: "Cargo.lock: 1 package updated, 1 added (serde 1.0.1 -> 1.0.2, +toml 0.8.0)\n",
+		},
+		{
+			name: "Gemfile.lock",
+			chunk: git.DiffChunk{FilePath: "Gemfile.lock", Content: "@@ -1,3 +1,3 @@\n" +
+				"-    rack (2.2.3)\n+    rack (2.2.4)\n       rack-test (>= 1.0)\n"},
+			expected: "Gemfile.lock: 1 package updated (rack 2.2.3 -> 2.2.4)\n",
+		},
+		{
+			name: "pnpm-lock.yaml",
+			chunk: git.DiffChunk{FilePath: "pnpm-lock.yaml", Content: "@@ -1,2 +1,2 @@\n" +
+				"-  /@types/node@20.1.0:\n+  /@types/node@20.2.0:\n"},
+			expected: "pnpm-lock.yaml: 1 package updated (@types/node 20.1.0 -> 20.2.0)\n",
+		},
+		{
+			name:     "unrecognized changes fall back to line counts",
+			chunk:    git.DiffChunk{FilePath: "custom.lock", Content: "@@ -1 +1 @@\n-abc\n+def\n", Additions: 1, Deletions: 1},
+			expected: "custom.lock: lock file changed (+1/-1 lines)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := summarizeLockFile(&tt.chunk); got != tt.expected {
+				t.Errorf("summarizeLockFile() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProcess_SummarizeLockFiles(t *testing.T) {
+	p := NewProcessorWithConfig(ProcessorConfig{SummarizeLockFiles: true})
+
+	chunks := []git.DiffChunk{
+		{FilePath: "package-lock.json", Content: packageLockDiff, IsLockFile: true},
+	}
+
+	result, err := p.Process(context.Background(), chunks)
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if len(result.Chunks) != 1 {
+		t.Fatalf("Expected the lock file to be kept, got %d chunks", len(result.Chunks))
+	}
+	if !strings.HasPrefix(result.Chunks[0].Content, "package-lock.json: 2 packages updated") {
+		t.Errorf("Expected a package summary, got %q", result.Chunks[0].Content)
+	}
+	if chunks[0].Content != packageLockDiff {
+		t.Error("Process should not modify the input chunks")
+	}
+}
@@ -0,0 +1,241 @@
+package processor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// DefaultVendorPatterns are path globs of commonly vendored dependencies.
+var DefaultVendorPatterns = []string{
+	"vendor/**",
+	"node_modules/**",
+	"third_party/**",
+}
+
+// MinifyConfig configures the minification pass, which strips diff noise the
+// AI does not need so that fewer tokens are sent per request.
+type MinifyConfig struct {
+	Enabled bool
+	// ContextLines is the number of unchanged lines kept around each change;
+	// a negative value keeps all context.
+	ContextLines int
+	// DropWhitespaceHunks leaves out hunks that only change whitespace.
+	DropWhitespaceHunks bool
+	// VendorPatterns are path globs of vendored files, in addition to
+	// DefaultVendorPatterns. Their content is replaced with a one-line summary.
+	VendorPatterns []string
+}
+
+// hunkCountsPattern captures the start lines and the trailing section heading
+// of a hunk header.
+var hunkCountsPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@(.*)$`)
+
+// minify strips diff noise from the chunks and logs the size saved.
+func (p *DefaultProcessor) minify(chunks []git.DiffChunk) []git.DiffChunk {
+	before := p.calculateTotalSize(chunks)
+	result := make([]git.DiffChunk, len(chunks))
+	for i, chunk := range chunks {
+		result[i] = chunk
+		if chunk.IsLockFile || chunk.IsBinary || chunk.IsGenerated || describeEntry(&chunk) != "" {
+			continue
+		}
+		if p.isVendored(&chunk) {
+			result[i].Content = summarizeVendoredFile(&chunk)
+			continue
+		}
+		result[i].Content = minifyDiff(chunk.Content, p.config.Minify.ContextLines, p.config.Minify.DropWhitespaceHunks)
+	}
+
+	after := p.calculateTotalSize(result)
+	if before > 0 {
+		apperrors.Debug("Minified diff: %d -> %d bytes (%d%% smaller)", before, after, (before-after)*100/before)
+	}
+	return result
+}
+
+// isVendored reports whether a chunk is a vendored file, by path glob.
+func (p *DefaultProcessor) isVendored(chunk *git.DiffChunk) bool {
+	for _, pattern := range p.config.Minify.VendorPatterns {
+		if MatchPattern(pattern, chunk.FilePath) {
+			return true
+		}
+	}
+	return false
+}
+
+// summarizeVendoredFile returns a one-line summary of a vendored file, e.g.
+// "vendor/github.com/x/y/y.go: vendored dependency (+10/-2 lines, content omitted)".
+func summarizeVendoredFile(chunk *git.DiffChunk) string {
+	return fmt.Sprintf("%s: vendored dependency (+%d/-%d lines, content omitted)\n",
+		chunk.FilePath, chunk.Additions, chunk.Deletions)
+}
+
+// diffLine is a line of a hunk with its position in the old and new file.
+type diffLine struct {
+	text    string
+	oldLine int
+	newLine int
+}
+
+// isChange reports whether the line is an addition or deletion.
+func (l diffLine) isChange() bool {
+	return l.text != "" && (l.text[0] == '+' || l.text[0] == '-')
+}
+
+// isContext reports whether the line is an unchanged line.
+func (l diffLine) isContext() bool {
+	return l.text == "" || l.text[0] == ' '
+}
+
+// hunk is a parsed hunk of a file diff.
+type hunk struct {
+	section string // Text after the closing "@@", e.g. a function signature
+	lines   []diffLine
+}
+
+// minifyDiff drops "index" lines from the file header, leaves out
+// whitespace-only hunks if dropWhitespace is set, and keeps at most
+// contextLines unchanged lines around each change.
+func minifyDiff(content string, contextLines int, dropWhitespace bool) string {
+	header, hunks := parseHunks(content)
+
+	var sb strings.Builder
+	for _, line := range header {
+		if strings.HasPrefix(line, "index ") {
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	dropped := 0
+	for _, h := range hunks {
+		if dropWhitespace && isWhitespaceOnly(h) {
+			dropped++
+			continue
+		}
+		writeHunk(&sb, h, contextLines)
+	}
+	if len(hunks) > 0 && dropped == len(hunks) {
+		sb.WriteString("(whitespace-only changes)\n")
+	}
+	return sb.String()
+}
+
+// parseHunks splits a file diff into its header lines and hunks.
+func parseHunks(content string) ([]string, []hunk) {
+	var header []string
+	var hunks []hunk
+	oldLine, newLine := 0, 0
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if m := hunkCountsPattern.FindStringSubmatch(line); m != nil {
+			oldLine, _ = strconv.Atoi(m[1])
+			newLine, _ = strconv.Atoi(m[2])
+			hunks = append(hunks, hunk{section: m[3]})
+			continue
+		}
+		if len(hunks) == 0 {
+			header = append(header, line)
+			continue
+		}
+
+		h := &hunks[len(hunks)-1]
+		h.lines = append(h.lines, diffLine{text: line, oldLine: oldLine, newLine: newLine})
+		switch {
+		case strings.HasPrefix(line, "+"):
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			oldLine++
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file" belongs to the previous line
+		default:
+			oldLine++
+			newLine++
+		}
+	}
+	return header, hunks
+}
+
+// isWhitespaceOnly reports whether the hunk's removed and added lines differ
+// only in whitespace.
+func isWhitespaceOnly(h hunk) bool {
+	var removed, added strings.Builder
+	changed := false
+	for _, line := range h.lines {
+		if !line.isChange() {
+			continue
+		}
+		changed = true
+		target := &added
+		if line.text[0] == '-' {
+			target = &removed
+		}
+		for _, field := range strings.Fields(line.text[1:]) {
+			target.WriteString(field)
+		}
+	}
+	return changed && removed.String() == added.String()
+}
+
+// writeHunk writes the hunk, keeping at most contextLines unchanged lines
+// around each change. Runs of context that are cut split the hunk, and each
+// part gets a header with its own line numbers.
+func writeHunk(sb *strings.Builder, h hunk, contextLines int) {
+	keep := make([]bool, len(h.lines))
+	for i, line := range h.lines {
+		if strings.HasPrefix(line.text, "\\") {
+			// A "\ No newline" marker goes with the line it follows
+			keep[i] = i > 0 && keep[i-1]
+			continue
+		}
+		if contextLines < 0 || !line.isContext() {
+			keep[i] = true
+			continue
+		}
+		for j := max(0, i-contextLines); j <= min(len(h.lines)-1, i+contextLines); j++ {
+			if h.lines[j].isChange() {
+				keep[i] = true
+				break
+			}
+		}
+	}
+
+	section := h.section
+	for start := 0; start < len(h.lines); {
+		if !keep[start] {
+			start++
+			continue
+		}
+		end := start
+		for end < len(h.lines) && keep[end] {
+			end++
+		}
+
+		part := h.lines[start:end]
+		oldCount, newCount := 0, 0
+		for _, line := range part {
+			switch {
+			case strings.HasPrefix(line.text, "+"):
+				newCount++
+			case strings.HasPrefix(line.text, "-"):
+				oldCount++
+			case strings.HasPrefix(line.text, "\\"):
+			default:
+				oldCount++
+				newCount++
+			}
+		}
+		fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@%s\n", part[0].oldLine, oldCount, part[0].newLine, newCount, section)
+		section = ""
+		for _, line := range part {
+			sb.WriteString(line.text)
+			sb.WriteString("\n")
+		}
+		start = end
+	}
+}
@@ -0,0 +1,135 @@
+package processor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestMinifyDiff(t *testing.T) {
+	header := "diff --git a/main.go b/main.go\nindex 1234567..89abcde 100644\n--- a/main.go\n+++ b/main.go\n"
+
+	tests := []struct {
+		name           string
+		content        string
+		contextLines   int
+		dropWhitespace bool
+		expected       string
+	}{
+		{
+			name:         "drops index line",
+			content:      header + "@@ -1,2 +1,2 @@ func main() {\n a\n-b\n+c\n",
+			contextLines: 3,
+			expected:     "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@ func main() {\n a\n-b\n+c\n",
+		},
+		{
+			name:         "trims context",
+			content:      "@@ -10,7 +10,7 @@\n a\n b\n c\n-d\n+D\n e\n f\n g\n",
+			contextLines: 1,
+			expected:     "@@ -12,3 +12,3 @@\n c\n-d\n+D\n e\n",
+		},
+		{
+			name:         "splits hunk at cut context",
+			content:      "@@ -1,7 +1,7 @@ type T struct\n-a\n+A\n b\n c\n d\n e\n-f\n+F\n",
+			contextLines: 1,
+			expected:     "@@ -1,2 +1,2 @@ type T struct\n-a\n+A\n b\n@@ -5,2 +5,2 @@\n e\n-f\n+F\n",
+		},
+		{
+			name:         "negative keeps all context",
+			content:      "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+			contextLines: -1,
+			expected:     "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:         "keeps no-newline marker with its line",
+			content:      "@@ -1,3 +1,3 @@\n a\n b\n-c\n\\ No newline at end of file\n+C\n\\ No newline at end of file\n",
+			contextLines: 0,
+			expected:     "@@ -3,1 +3,1 @@\n-c\n\\ No newline at end of file\n+C\n\\ No newline at end of file\n",
+		},
+		{
+			name:           "drops whitespace-only hunk",
+			content:        "@@ -1,1 +1,1 @@\n-if x {\n+if  x  {\n@@ -9,1 +9,1 @@\n-a\n+b\n",
+			contextLines:   3,
+			dropWhitespace: true,
+			expected:       "@@ -9,1 +9,1 @@\n-a\n+b\n",
+		},
+		{
+			name:           "notes whitespace-only file",
+			content:        header + "@@ -1,1 +1,1 @@\n-\tx := 1\n+    x := 1\n",
+			contextLines:   3,
+			dropWhitespace: true,
+			expected:       "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n(whitespace-only changes)\n",
+		},
+		{
+			name:         "keeps whitespace-only hunk when disabled",
+			content:      "@@ -1,1 +1,1 @@\n-if x {\n+if  x  {\n",
+			contextLines: 3,
+			expected:     "@@ -1,1 +1,1 @@\n-if x {\n+if  x  {\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minifyDiff(tt.content, tt.contextLines, tt.dropWhitespace); got != tt.expected {
+				t.Errorf("minifyDiff() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProcess_Minify(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "main.go", Additions: 1, Deletions: 1, Content: "index 1234567..89abcde 100644\n@@ -1,7 +1,7 @@\n a\n b\n c\n-d\n+D\n e\n f\n g\n"},
+		{FilePath: "vendor/github.com/x/y/y.go", Additions: 10, Deletions: 2, Content: strings.Repeat("+vendored\n", 10)},
+		{FilePath: "deps/lib.c", Additions: 1, Content: "@@ -0,0 +1 @@\n+int x;\n"},
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		result, err := NewProcessor().Process(context.Background(), chunks)
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+		original := make(map[string]string)
+		for _, chunk := range chunks {
+			original[chunk.FilePath] = chunk.Content
+		}
+		for _, chunk := range result.Chunks {
+			if chunk.Content != original[chunk.FilePath] {
+				t.Errorf("%s: content changed with minification disabled", chunk.FilePath)
+			}
+		}
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		p := NewProcessorWithConfig(ProcessorConfig{Minify: MinifyConfig{
+			Enabled:        true,
+			ContextLines:   1,
+			VendorPatterns: []string{"deps/**"},
+		}})
+		result, err := p.Process(context.Background(), chunks)
+		if err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+
+		expected := map[string]string{
+			"main.go":                    "@@ -3,3 +3,3 @@\n c\n-d\n+D\n e\n",
+			"vendor/github.com/x/y/y.go": "vendor/github.com/x/y/y.go: vendored dependency (+10/-2 lines, content omitted)\n",
+			"deps/lib.c":                 "deps/lib.c: vendored dependency (+1/-0 lines, content omitted)\n",
+		}
+		for _, chunk := range result.Chunks {
+			if chunk.Content != expected[chunk.FilePath] {
+				t.Errorf("%s: content = %q, want %q", chunk.FilePath, chunk.Content, expected[chunk.FilePath])
+			}
+		}
+
+		original := 0
+		for _, chunk := range chunks {
+			original += len(chunk.Content)
+		}
+		if result.TotalSize >= original {
+			t.Errorf("TotalSize = %d, want less than %d", result.TotalSize, original)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apperrors "github.com/gitsage/gitsage/internal/pkg/errors"
+)
+
+// Pipeline phases, in the order they run. Within a phase, the built-in
+// stages run first, then the registered ones in the order they were added.
+const (
+	// PhaseFilter drops and describes files: lock files, symlinks,
+	// submodules. Chunks are sorted by path weight and path at its end.
+	PhaseFilter = "filter"
+	// PhaseRedact removes content that must not leave the machine. It has
+	// no built-in stages.
+	PhaseRedact = "redact"
+	// PhaseSummarize shortens content: generated and formatting-only files
+	// and, if enabled, minification.
+	PhaseSummarize = "summarize"
+	// PhaseGroup sizes the diff and, when it needs chunking, summarizes
+	// large files and groups the chunks.
+	PhaseGroup = "group"
+)
+
+// phases lists the pipeline phases in order.
+var phases = []string{PhaseFilter, PhaseRedact, PhaseSummarize, PhaseGroup}
+
+// StageFunc transforms the diff in a pipeline stage. Stages before the group
+// phase see only Chunks set.
+type StageFunc func(ctx context.Context, diff *ProcessedDiff) error
+
+// Stage is a named step of the processing pipeline.
+type Stage struct {
+	Name  string
+	Phase string
+	Run   StageFunc
+}
+
+// stageRegistry holds the stages added with RegisterStage.
+var stageRegistry = struct {
+	sync.RWMutex
+	stages []Stage
+}{}
+
+// builtinStages are the names of the stages every processor runs.
+var builtinStages = map[string]bool{
+	"lockfiles": true, "entries": true, "sort": true,
+	"generated": true, "formatting": true, "minify": true,
+	"chunking": true,
+}
+
+// RegisterStage adds a stage, e.g. a company-specific filter, to the
+// pipeline of every processor, from its next Process call on. Names must be
+// unique and cannot replace a built-in stage.
+func RegisterStage(stage Stage) error {
+	if stage.Name == "" || stage.Run == nil {
+		return fmt.Errorf("stage name and function are required")
+	}
+	if !validPhase(stage.Phase) {
+		return fmt.Errorf("unknown phase %q for stage %s (valid: filter, redact, summarize, group)", stage.Phase, stage.Name)
+	}
+	if builtinStages[stage.Name] {
+		return fmt.Errorf("stage %s is built in", stage.Name)
+	}
+
+	stageRegistry.Lock()
+	defer stageRegistry.Unlock()
+	for _, registered := range stageRegistry.stages {
+		if registered.Name == stage.Name {
+			return fmt.Errorf("stage %s is already registered", stage.Name)
+		}
+	}
+	stageRegistry.stages = append(stageRegistry.stages, stage)
+	return nil
+}
+
+// validPhase reports whether phase is one of the pipeline phases.
+func validPhase(phase string) bool {
+	for _, p := range phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// newPipeline returns the built-in stages of p followed by the registered
+// ones, ordered by phase.
+func (p *DefaultProcessor) newPipeline() []Stage {
+	chunkStage := func(name, phase string, fn func(*ProcessedDiff)) Stage {
+		return Stage{Name: name, Phase: phase, Run: func(_ context.Context, diff *ProcessedDiff) error {
+			fn(diff)
+			return nil
+		}}
+	}
+
+	stages := []Stage{
+		chunkStage("lockfiles", PhaseFilter, func(d *ProcessedDiff) { d.Chunks = p.filterLockFiles(d.Chunks) }),
+		chunkStage("entries", PhaseFilter, func(d *ProcessedDiff) { d.Chunks = p.describeEntries(d.Chunks) }),
+		// Prompts, cache keys and chunk groups must not depend on parsing order
+		chunkStage("sort", PhaseFilter, func(d *ProcessedDiff) { d.Chunks = SortChunksByWeight(d.Chunks, p.config.PathWeights) }),
+		chunkStage("generated", PhaseSummarize, func(d *ProcessedDiff) { d.Chunks = p.summarizeGeneratedFiles(d.Chunks) }),
+		chunkStage("formatting", PhaseSummarize, func(d *ProcessedDiff) { d.Chunks = p.handleFormattingFiles(d.Chunks) }),
+	}
+	if p.config.Minify.Enabled {
+		stages = append(stages, chunkStage("minify", PhaseSummarize, func(d *ProcessedDiff) { d.Chunks = p.minify(d.Chunks) }))
+	}
+	stages = append(stages, chunkStage("chunking", PhaseGroup, p.applyChunking))
+
+	stageRegistry.RLock()
+	stages = append(stages, stageRegistry.stages...)
+	stageRegistry.RUnlock()
+
+	// Stable, so that stages keep their order within a phase
+	ordered := make([]Stage, 0, len(stages))
+	for _, phase := range phases {
+		for _, stage := range stages {
+			if stage.Phase == phase {
+				ordered = append(ordered, stage)
+			}
+		}
+	}
+	return ordered
+}
+
+// runPipeline runs the stages over the diff, logging how long each took.
+func runPipeline(ctx context.Context, stages []Stage, diff *ProcessedDiff) error {
+	for _, stage := range stages {
+		start := time.Now()
+		if err := stage.Run(ctx, diff); err != nil {
+			return fmt.Errorf("diff processing stage %s failed: %w", stage.Name, err)
+		}
+		apperrors.Debug("Diff stage %s/%s: %d files in %v", stage.Phase, stage.Name, len(diff.Chunks), time.Since(start))
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Package processor provides diff processing functionality for GitSage.
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// isolateStages restores the registered stages when the test ends.
+func isolateStages(t *testing.T) {
+	t.Helper()
+	stageRegistry.Lock()
+	saved := stageRegistry.stages
+	stageRegistry.Unlock()
+	t.Cleanup(func() {
+		stageRegistry.Lock()
+		stageRegistry.stages = saved
+		stageRegistry.Unlock()
+	})
+}
+
+func TestRegisterStage_Validation(t *testing.T) {
+	isolateStages(t)
+	noop := func(ctx context.Context, diff *ProcessedDiff) error { return nil }
+
+	tests := []struct {
+		name  string
+		stage Stage
+	}{
+		{"no name", Stage{Phase: PhaseFilter, Run: noop}},
+		{"no function", Stage{Name: "test-no-func", Phase: PhaseFilter}},
+		{"unknown phase", Stage{Name: "test-bad-phase", Phase: "upload", Run: noop}},
+		{"built in", Stage{Name: "minify", Phase: PhaseSummarize, Run: noop}},
+	}
+	for _, tt := range tests {
+		if err := RegisterStage(tt.stage); err == nil {
+			t.Errorf("%s: RegisterStage() should fail", tt.name)
+		}
+	}
+
+	if err := RegisterStage(Stage{Name: "test-duplicate", Phase: PhaseGroup, Run: noop}); err != nil {
+		t.Fatalf("RegisterStage() error = %v", err)
+	}
+	if err := RegisterStage(Stage{Name: "test-duplicate", Phase: PhaseGroup, Run: noop}); err == nil {
+		t.Error("RegisterStage() of a registered name should fail")
+	}
+}
+
+func TestProcess_RegisteredStages(t *testing.T) {
+	isolateStages(t)
+	var order []string
+	mustRegister := func(stage Stage) {
+		t.Helper()
+		if err := RegisterStage(stage); err != nil {
+			t.Fatalf("RegisterStage() error = %v", err)
+		}
+	}
+	mustRegister(Stage{Name: "test-redact-tokens", Phase: PhaseRedact, Run: func(ctx context.Context, diff *ProcessedDiff) error {
+		order = append(order, "redact")
+		for i := range diff.Chunks {
+			diff.Chunks[i].Content = strings.ReplaceAll(diff.Chunks[i].Content, "TOKEN=abc123", "TOKEN=***")
+		}
+		return nil
+	}})
+	mustRegister(Stage{Name: "test-drop-internal", Phase: PhaseFilter, Run: func(ctx context.Context, diff *ProcessedDiff) error {
+		order = append(order, "filter")
+		kept := diff.Chunks[:0]
+		for _, chunk := range diff.Chunks {
+			if !strings.HasPrefix(chunk.FilePath, "internal-only/") {
+				kept = append(kept, chunk)
+			}
+		}
+		diff.Chunks = kept
+		return nil
+	}})
+	mustRegister(Stage{Name: "test-fail", Phase: PhaseSummarize, Run: func(ctx context.Context, diff *ProcessedDiff) error {
+		for _, chunk := range diff.Chunks {
+			if chunk.FilePath == "test-fail.go" {
+				return errors.New("rejected")
+			}
+		}
+		return nil
+	}})
+
+	p := NewProcessor()
+	result, err := p.Process(context.Background(), []git.DiffChunk{
+		{FilePath: "internal-only/notes.txt", Content: "+secret plans"},
+		{FilePath: "config.env", Content: "+TOKEN=abc123"},
+	})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if strings.Join(order, ",") != "filter,redact" {
+		t.Errorf("stages ran in order %v, want filter then redact", order)
+	}
+	if len(result.Chunks) != 1 || result.Chunks[0].Content != "+TOKEN=***" {
+		t.Errorf("Chunks = %+v, want only the redacted config.env", result.Chunks)
+	}
+	if result.TotalSize != len("+TOKEN=***") {
+		t.Errorf("TotalSize = %d, want the size after redaction", result.TotalSize)
+	}
+
+	_, err = p.Process(context.Background(), []git.DiffChunk{{FilePath: "test-fail.go", Content: "+x"}})
+	if err == nil || !strings.Contains(err.Error(), "test-fail") {
+		t.Errorf("Process() error = %v, want the failing stage named", err)
+	}
+}
@@ -4,6 +4,7 @@ package processor
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/gitsage/gitsage/internal/pkg/git"
@@ -50,6 +51,20 @@ type ProcessorConfig struct {
 	DiffSizeThreshold int // Size in bytes that triggers chunking
 	MaxChunkSize      int // Maximum size per chunk in bytes
 	MaxConcurrent     int // Maximum concurrent AI calls for chunk processing
+	// SummarizeLockFiles replaces lock file diffs with a one-line package
+	// summary instead of dropping them.
+	SummarizeLockFiles bool
+	// GeneratedPatterns are path globs of generated files, in addition to
+	// DefaultGeneratedPatterns.
+	GeneratedPatterns []string
+	// FormattingMode handles files whose changes are whitespace only:
+	// FormattingSummarize (the default), FormattingExclude or FormattingKeep.
+	FormattingMode string
+	// Minify configures the pass that strips diff noise before sizing.
+	Minify MinifyConfig
+	// PathWeights order the files: high-weight files come first, low-weight
+	// files last. The first matching rule applies.
+	PathWeights []PathWeight
 }
 
 // DefaultProcessor implements the DiffProcessor interface.
@@ -64,6 +79,7 @@ func NewProcessor() *DefaultProcessor {
 			DiffSizeThreshold: DefaultDiffSizeThreshold,
 			MaxChunkSize:      DefaultMaxChunkSize,
 			MaxConcurrent:     DefaultMaxConcurrent,
+			GeneratedPatterns: DefaultGeneratedPatterns,
 		},
 	}
 }
@@ -80,53 +96,71 @@ func NewProcessorWithConfig(config ProcessorConfig) *DefaultProcessor {
 	if config.MaxConcurrent <= 0 {
 		config.MaxConcurrent = DefaultMaxConcurrent
 	}
+	config.GeneratedPatterns = append(append([]string{}, DefaultGeneratedPatterns...), config.GeneratedPatterns...)
+	config.Minify.VendorPatterns = append(append([]string{}, DefaultVendorPatterns...), config.Minify.VendorPatterns...)
 	return &DefaultProcessor{config: config}
 }
 
-// Process processes the diff chunks by filtering lock files, calculating size,
-// and applying chunking strategy if needed.
+// Process runs the diff chunks through the processing pipeline: filtering
+// (or summarizing) lock files and describing symlink and submodule changes,
+// then any redaction stages, summarizing generated and formatting-only files
+// and minifying the rest if enabled, and finally calculating the size and
+// applying the chunking strategy if needed. See RegisterStage.
 func (p *DefaultProcessor) Process(ctx context.Context, chunks []git.DiffChunk) (*ProcessedDiff, error) {
-	// Step 1: Filter out lock files
-	filteredChunks := p.filterLockFiles(chunks)
-
-	// Step 2: Calculate total size
-	totalSize := p.calculateTotalSize(filteredChunks)
-
-	// Step 3: Determine if chunking is required
-	requiresChunking := totalSize > p.config.DiffSizeThreshold
-
-	result := &ProcessedDiff{
-		Chunks:           filteredChunks,
-		TotalSize:        totalSize,
-		RequiresChunking: requiresChunking,
+	result := &ProcessedDiff{Chunks: chunks}
+	if err := runPipeline(ctx, p.newPipeline(), result); err != nil {
+		return nil, err
 	}
+	return result, nil
+}
 
-	// Step 4: Apply chunking strategy if needed
-	if requiresChunking {
-		// Process large files - replace content with summary for files exceeding max chunk size
-		result.Chunks = p.processLargeFiles(filteredChunks)
+// applyChunking calculates the total size and, when it exceeds the
+// threshold, summarizes large files, groups the chunks for parallel
+// processing and summarizes the changes.
+func (p *DefaultProcessor) applyChunking(result *ProcessedDiff) {
+	result.TotalSize = p.calculateTotalSize(result.Chunks)
+	result.RequiresChunking = result.TotalSize > p.config.DiffSizeThreshold
+	if !result.RequiresChunking {
+		return
+	}
 
-		// Group chunks for parallel processing
-		result.ChunkGroups = p.groupChunks(result.Chunks)
+	// Process large files - replace content with summary for files exceeding max chunk size
+	result.Chunks = p.processLargeFiles(result.Chunks)
 
-		// Generate overall summary
-		result.Summary = p.generateSummary(result.Chunks)
-	}
+	// Group chunks for parallel processing
+	result.ChunkGroups = p.groupChunks(result.Chunks)
 
-	return result, nil
+	// Generate overall summary
+	result.Summary = p.generateSummary(result.Chunks)
 }
 
-// filterLockFiles removes lock files from the chunks.
+// filterLockFiles removes lock files from the chunks, or replaces their
+// content with a package summary if SummarizeLockFiles is set.
 func (p *DefaultProcessor) filterLockFiles(chunks []git.DiffChunk) []git.DiffChunk {
 	filtered := make([]git.DiffChunk, 0, len(chunks))
 	for _, chunk := range chunks {
-		if !chunk.IsLockFile {
+		switch {
+		case !chunk.IsLockFile:
+			filtered = append(filtered, chunk)
+		case p.config.SummarizeLockFiles:
+			chunk.Content = summarizeLockFile(&chunk)
 			filtered = append(filtered, chunk)
 		}
 	}
 	return filtered
 }
 
+// SortChunks returns a copy of chunks ordered by file path. The sort is stable,
+// so chunks with the same path keep their relative order.
+func SortChunks(chunks []git.DiffChunk) []git.DiffChunk {
+	sorted := make([]git.DiffChunk, len(chunks))
+	copy(sorted, chunks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].FilePath < sorted[j].FilePath
+	})
+	return sorted
+}
+
 // calculateTotalSize calculates the total size of all chunk contents in bytes.
 func (p *DefaultProcessor) calculateTotalSize(chunks []git.DiffChunk) int {
 	total := 0
@@ -167,6 +201,10 @@ func (p *DefaultProcessor) generateFileSummary(chunk *git.DiffChunk) string {
 		sb.WriteString(fmt.Sprintf("Renamed from: %s\n", chunk.OldPath))
 	}
 
+	if !chunk.ModeChange.IsZero() {
+		sb.WriteString(fmt.Sprintf("Mode: %s\n", chunk.ModeChange.Describe()))
+	}
+
 	return sb.String()
 }
 
@@ -228,11 +266,19 @@ func (p *DefaultProcessor) generateSummary(chunks []git.DiffChunk) string {
 			changeSymbol = "D"
 		case git.ChangeTypeRenamed:
 			changeSymbol = "R"
+		case git.ChangeTypeSymlink:
+			changeSymbol = "L"
+		case git.ChangeTypeSubmodule:
+			changeSymbol = "S"
 		}
 
 		sb.WriteString(fmt.Sprintf("  [%s] %s (+%d/-%d)\n",
 			changeSymbol, chunk.FilePath, chunk.Additions, chunk.Deletions))
 
+		if !chunk.ModeChange.IsZero() {
+			sb.WriteString(fmt.Sprintf("      (%s)\n", chunk.ModeChange.Describe()))
+		}
+
 		if chunk.OldPath != "" {
 			sb.WriteString(fmt.Sprintf("      (renamed from %s)\n", chunk.OldPath))
 		}
@@ -38,6 +38,48 @@ func TestFilterLockFiles(t *testing.T) {
 	}
 }
 
+func TestSortChunks(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "src/b.go", Content: "first"},
+		{FilePath: "README.md"},
+		{FilePath: "src/a.go"},
+		{FilePath: "src/b.go", Content: "second"},
+	}
+
+	sorted := SortChunks(chunks)
+
+	want := []string{"README.md", "src/a.go", "src/b.go", "src/b.go"}
+	for i, chunk := range sorted {
+		if chunk.FilePath != want[i] {
+			t.Errorf("sorted[%d] = %s, want %s", i, chunk.FilePath, want[i])
+		}
+	}
+	// Equal paths keep their order
+	if sorted[2].Content != "first" || sorted[3].Content != "second" {
+		t.Error("SortChunks should be stable")
+	}
+	// The input is left untouched
+	if chunks[0].FilePath != "src/b.go" {
+		t.Error("SortChunks should not modify its input")
+	}
+}
+
+func TestProcess_OrdersChunksByPath(t *testing.T) {
+	p := NewProcessor()
+
+	result, err := p.Process(context.Background(), []git.DiffChunk{
+		{FilePath: "z.go"},
+		{FilePath: "a.go"},
+	})
+	if err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	if result.Chunks[0].FilePath != "a.go" || result.Chunks[1].FilePath != "z.go" {
+		t.Errorf("Expected chunks ordered by path, got %s, %s", result.Chunks[0].FilePath, result.Chunks[1].FilePath)
+	}
+}
+
 func TestCalculateTotalSize(t *testing.T) {
 	p := NewProcessor()
 
@@ -299,6 +341,25 @@ func TestRenamedFileInSummary(t *testing.T) {
 	}
 }
 
+func TestModeChangeInSummary(t *testing.T) {
+	p := NewProcessor()
+
+	chunk := git.DiffChunk{
+		FilePath:   "scripts/build.sh",
+		ModeChange: git.ModeChange{OldMode: "100644", NewMode: "100755"},
+	}
+
+	summary := p.generateSummary([]git.DiffChunk{chunk})
+	if !strings.Contains(summary, "(made executable)") {
+		t.Errorf("Summary should describe the mode change, got:\n%s", summary)
+	}
+
+	fileSummary := p.generateFileSummary(&chunk)
+	if !strings.Contains(fileSummary, "Mode: made executable") {
+		t.Errorf("File summary should describe the mode change, got:\n%s", fileSummary)
+	}
+}
+
 func TestBinaryFileInSummary(t *testing.T) {
 	config := ProcessorConfig{
 		DiffSizeThreshold: 10,
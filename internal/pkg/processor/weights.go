@@ -0,0 +1,82 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// Path weights, which order files in prompts: high-weight files come first
+// and so survive when the diff is trimmed to fit the model.
+const (
+	WeightHigh   = "high"
+	WeightNormal = "normal"
+	WeightLow    = "low"
+)
+
+// weightRanks orders the weights, highest first.
+var weightRanks = map[string]int{WeightHigh: 0, WeightNormal: 1, WeightLow: 2}
+
+// PathWeight gives the files matching a path glob a weight.
+type PathWeight struct {
+	Path   string
+	Weight string
+}
+
+// ParseWeight parses a path weight. An empty name yields WeightNormal.
+func ParseWeight(name string) (string, error) {
+	switch weight := strings.ToLower(strings.TrimSpace(name)); weight {
+	case "":
+		return WeightNormal, nil
+	case WeightHigh, WeightNormal, WeightLow:
+		return weight, nil
+	default:
+		return "", fmt.Errorf("unknown weight %q (valid: high, normal, low)", name)
+	}
+}
+
+// ValidatePathWeights checks that every rule has a path and a known weight.
+func ValidatePathWeights(weights []PathWeight) error {
+	for i, w := range weights {
+		if strings.TrimSpace(w.Path) == "" {
+			return fmt.Errorf("rule %d has no path", i+1)
+		}
+		if _, err := path.Match(strings.TrimSuffix(w.Path, "/**"), ""); err != nil {
+			return fmt.Errorf("invalid path %q: %w", w.Path, err)
+		}
+		if _, err := ParseWeight(w.Weight); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i+1, w.Path, err)
+		}
+	}
+	return nil
+}
+
+// weightRank returns the rank of the first rule matching filePath; files
+// no rule matches are normal.
+func weightRank(weights []PathWeight, filePath string) int {
+	for _, w := range weights {
+		if MatchPattern(w.Path, filePath) {
+			// Invalid weights are rejected when the config is loaded
+			if weight, err := ParseWeight(w.Weight); err == nil {
+				return weightRanks[weight]
+			}
+		}
+	}
+	return weightRanks[WeightNormal]
+}
+
+// SortChunksByWeight returns a copy of chunks ordered by path weight, highest
+// first, and then by file path. Without weights it is SortChunks.
+func SortChunksByWeight(chunks []git.DiffChunk, weights []PathWeight) []git.DiffChunk {
+	sorted := SortChunks(chunks)
+	if len(weights) == 0 {
+		return sorted
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return weightRank(weights, sorted[i].FilePath) < weightRank(weights, sorted[j].FilePath)
+	})
+	return sorted
+}
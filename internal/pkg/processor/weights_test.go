@@ -0,0 +1,93 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestParseWeight(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"", WeightNormal, false},
+		{"high", WeightHigh, false},
+		{" Low ", WeightLow, false},
+		{"normal", WeightNormal, false},
+		{"urgent", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWeight(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWeight(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWeight(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePathWeights(t *testing.T) {
+	if err := ValidatePathWeights([]PathWeight{{Path: "internal/**", Weight: "high"}, {Path: "*.md", Weight: "low"}}); err != nil {
+		t.Errorf("ValidatePathWeights() error = %v", err)
+	}
+	for _, weights := range [][]PathWeight{
+		{{Path: "", Weight: "high"}},
+		{{Path: "docs/**", Weight: "urgent"}},
+		{{Path: "[docs/**", Weight: "low"}},
+	} {
+		if err := ValidatePathWeights(weights); err == nil {
+			t.Errorf("ValidatePathWeights(%+v) should fail", weights)
+		}
+	}
+}
+
+func TestSortChunksByWeight(t *testing.T) {
+	chunks := []git.DiffChunk{
+		{FilePath: "docs/guide.md"},
+		{FilePath: "README.md"},
+		{FilePath: "cmd/main.go"},
+		{FilePath: "internal/core/engine.go"},
+		{FilePath: "internal/api/handler.go"},
+	}
+	weights := []PathWeight{
+		{Path: "internal/**", Weight: WeightHigh},
+		{Path: "docs/**", Weight: WeightLow},
+		{Path: "*.md", Weight: WeightLow},
+	}
+
+	got := SortChunksByWeight(chunks, weights)
+	want := []string{"internal/api/handler.go", "internal/core/engine.go", "cmd/main.go", "README.md", "docs/guide.md"}
+	for i, path := range want {
+		if got[i].FilePath != path {
+			t.Errorf("position %d = %s, want %s", i, got[i].FilePath, path)
+		}
+	}
+	if chunks[0].FilePath != "docs/guide.md" {
+		t.Error("SortChunksByWeight() should not modify its input")
+	}
+
+	// Without weights, files are ordered by path
+	if got := SortChunksByWeight(chunks, nil); got[0].FilePath != "README.md" {
+		t.Errorf("SortChunksByWeight(nil)[0] = %s, want README.md", got[0].FilePath)
+	}
+}
+
+func TestProcess_PathWeights(t *testing.T) {
+	p := NewProcessorWithConfig(ProcessorConfig{PathWeights: []PathWeight{{Path: "b.go", Weight: WeightHigh}}})
+	result, err := p.Process(context.Background(), []git.DiffChunk{
+		{FilePath: "a.go", Content: "+a"},
+		{FilePath: "b.go", Content: "+b"},
+	})
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if result.Chunks[0].FilePath != "b.go" {
+		t.Errorf("first file = %s, want the high-weight b.go", result.Chunks[0].FilePath)
+	}
+}
@@ -0,0 +1,47 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// lookupHost resolves a host name; a variable to allow mocking in tests.
+var lookupHost = net.DefaultResolver.LookupNetIP
+
+// CheckLocalEndpoint returns an error unless the endpoint is on this machine
+// or a private network: localhost, a loopback, private or link-local
+// address, or a host name all of whose addresses are such addresses.
+func CheckLocalEndpoint(ctx context.Context, endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return fmt.Errorf("cannot tell where %q is", endpoint)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return nil
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = lookupHost(ctx, "ip", host); err != nil {
+		return fmt.Errorf("cannot resolve %s to check that it is local: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if !isLocalAddr(addr.Unmap()) {
+			return fmt.Errorf("%s is not on this machine or a private network (%s)", host, addr)
+		}
+	}
+	return nil
+}
+
+// isLocalAddr reports whether the address is a loopback, private or
+// link-local one.
+func isLocalAddr(addr netip.Addr) bool {
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast()
+}
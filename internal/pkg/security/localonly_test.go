@@ -0,0 +1,55 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestCheckLocalEndpoint(t *testing.T) {
+	hosts := map[string][]netip.Addr{
+		"ollama.lan":     {netip.MustParseAddr("192.168.1.5")},
+		"api.openai.com": {netip.MustParseAddr("162.159.140.245")},
+		"split.example":  {netip.MustParseAddr("10.0.0.7"), netip.MustParseAddr("203.0.113.9")},
+	}
+	original := lookupHost
+	lookupHost = func(_ context.Context, _, host string) ([]netip.Addr, error) {
+		if addrs, ok := hosts[host]; ok {
+			return addrs, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	defer func() { lookupHost = original }()
+
+	tests := []struct {
+		endpoint string
+		local    bool
+	}{
+		{"http://localhost:11434", true},
+		{"http://ollama.localhost:11434", true},
+		{"http://127.0.0.1:11434", true},
+		{"http://[::1]:8080/v1", true},
+		{"http://10.1.2.3:8000/v1", true},
+		{"http://172.16.0.4/v1", true},
+		{"http://192.168.1.20:11434", true},
+		{"http://169.254.10.1:11434", true},
+		{"http://[fd00::1]:11434", true},
+		{"http://ollama.lan:11434", true},
+		{"https://api.openai.com/v1", false},
+		{"http://split.example", false},
+		{"http://8.8.8.8:11434", false},
+		{"http://100.64.0.1:11434", false},
+		{"http://unknown.example", false},
+		{"not a url", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			err := CheckLocalEndpoint(context.Background(), tt.endpoint)
+			if (err == nil) != tt.local {
+				t.Errorf("CheckLocalEndpoint(%q) error = %v, want local %v", tt.endpoint, err, tt.local)
+			}
+		})
+	}
+}
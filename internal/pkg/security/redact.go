@@ -0,0 +1,50 @@
+package security
+
+import (
+	"regexp"
+	"strings"
+)
+
+// homeDirPattern matches the home directory of any user, e.g. /home/alice,
+// /Users/alice or C:\Users\alice.
+var homeDirPattern = regexp.MustCompile(`(?:/home|/Users|[A-Za-z]:\\Users)[/\\][^/\\\s"'` + "`" + `]+`)
+
+// PathRedactor rewrites local paths in text sent to AI providers, so that
+// prompts do not reveal the directory layout or user name of the machine.
+type PathRedactor struct {
+	replacements []pathReplacement
+}
+
+// pathReplacement replaces a directory and the paths below it.
+type pathReplacement struct {
+	pattern *regexp.Regexp
+	with    string
+}
+
+// NewPathRedactor creates a redactor that replaces the repository root with
+// "." and the home directory with "~". Either may be empty.
+func NewPathRedactor(homeDir, repoRoot string) *PathRedactor {
+	r := &PathRedactor{}
+	// The repository is usually inside the home directory, so it goes first
+	for _, dir := range []struct{ path, with string }{{repoRoot, "."}, {homeDir, "~"}} {
+		path := strings.TrimRight(dir.path, `/\`)
+		if path == "" {
+			continue
+		}
+		// Match the directory itself, not a sibling sharing its prefix
+		r.replacements = append(r.replacements, pathReplacement{
+			pattern: regexp.MustCompile(regexp.QuoteMeta(path) + `([^A-Za-z0-9._-]|$)`),
+			with:    dir.with + "${1}",
+		})
+	}
+	return r
+}
+
+// Redact returns text with the repository root replaced by ".", the home
+// directory by "~" and the home directories of other users by "~" as well.
+func (r *PathRedactor) Redact(text string) string {
+	for _, replacement := range r.replacements {
+		text = replacement.pattern.ReplaceAllString(text, replacement.with)
+	}
+	return homeDirPattern.ReplaceAllString(text, "~")
+}
@@ -0,0 +1,61 @@
+package security
+
+import (
+	"testing"
+)
+
+func TestPathRedactor_Redact(t *testing.T) {
+	r := NewPathRedactor("/home/alice", "/home/alice/src/gitsage")
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "file in the repository",
+			input:    "panic at /home/alice/src/gitsage/internal/app/service.go:42",
+			expected: "panic at ./internal/app/service.go:42",
+		},
+		{
+			name:     "repository root",
+			input:    `cwd: "/home/alice/src/gitsage"`,
+			expected: `cwd: "."`,
+		},
+		{
+			name:     "sibling of the repository",
+			input:    "/home/alice/src/gitsage-old/main.go",
+			expected: "~/src/gitsage-old/main.go",
+		},
+		{
+			name:     "home directory",
+			input:    "+CONFIG=/home/alice/.config/app.yaml",
+			expected: "+CONFIG=~/.config/app.yaml",
+		},
+		{
+			name:     "other user",
+			input:    "/Users/bob/go/bin and C:\\Users\\carol\\AppData",
+			expected: "~/go/bin and ~\\AppData",
+		},
+		{
+			name:     "relative paths are kept",
+			input:    "internal/app/service.go",
+			expected: "internal/app/service.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := r.Redact(tt.input); result != tt.expected {
+				t.Errorf("Redact(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPathRedactor_EmptyDirs(t *testing.T) {
+	r := NewPathRedactor("", "")
+	if result := r.Redact("/opt/build/main.go"); result != "/opt/build/main.go" {
+		t.Errorf("Redact() = %q, expected the path unchanged", result)
+	}
+}
@@ -12,6 +12,7 @@ var APIKeyFormat = map[string]*regexp.Regexp{
 	"openai":   regexp.MustCompile(`^sk-[a-zA-Z0-9]{20,}$`),
 	"deepseek": regexp.MustCompile(`^sk-[a-zA-Z0-9]{20,}$`),
 	"ollama":   nil, // Ollama doesn't require API key
+	"mock":     nil, // Neither does the offline mock provider
 }
 
 // MaskAPIKey masks an API key, showing only the last 4 characters.
@@ -26,8 +27,8 @@ func MaskAPIKey(key string) string {
 // ValidateAPIKeyFormat validates the format of an API key for a given provider.
 // Returns nil if the key format is valid, or an error describing the issue.
 func ValidateAPIKeyFormat(provider, apiKey string) error {
-	// Ollama doesn't require API key
-	if provider == "ollama" {
+	// Ollama and the mock provider don't require API key
+	if provider == "ollama" || provider == "mock" {
 		return nil
 	}
 
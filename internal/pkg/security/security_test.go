@@ -78,6 +78,12 @@ func TestValidateAPIKeyFormat(t *testing.T) {
 			apiKey:   "",
 			wantErr:  false,
 		},
+		{
+			name:     "mock no key required",
+			provider: "mock",
+			apiKey:   "",
+			wantErr:  false,
+		},
 		{
 			name:     "valid deepseek key",
 			provider: "deepseek",
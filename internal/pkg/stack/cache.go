@@ -0,0 +1,53 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cacheEntry is a detected stack together with the manifest signature it was
+// detected from.
+type cacheEntry struct {
+	Signature string `json:"signature"`
+	Stack     *Stack `json:"stack"`
+}
+
+// Load returns the stack of the repository at root. The stack is cached in
+// cachePath and detected again only when a manifest is added, removed or
+// modified. An empty cachePath disables the cache.
+func Load(root, cachePath string) *Stack {
+	signature := manifestSignature(root)
+	if cachePath != "" {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			var entry cacheEntry
+			if json.Unmarshal(data, &entry) == nil && entry.Signature == signature && entry.Stack != nil {
+				return entry.Stack
+			}
+		}
+	}
+
+	detected := Detect(root)
+	if cachePath != "" {
+		if data, err := json.Marshal(cacheEntry{Signature: signature, Stack: detected}); err == nil {
+			_ = os.WriteFile(cachePath, data, 0644)
+		}
+	}
+	return detected
+}
+
+// manifestSignature identifies the manifests of the repository at root by
+// name, size and modification time.
+func manifestSignature(root string) string {
+	var sb strings.Builder
+	for _, name := range Manifests {
+		info, err := os.Stat(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", name, info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
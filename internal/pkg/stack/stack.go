@@ -0,0 +1,248 @@
+// Package stack detects the languages, frameworks and tools a repository uses
+// from its manifest files, so prompts can use the project's terminology.
+package stack
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Stack is the technology stack of a repository.
+type Stack struct {
+	Languages  []string `json:"languages,omitempty"`
+	Frameworks []string `json:"frameworks,omitempty"`
+	Tools      []string `json:"tools,omitempty"`
+}
+
+// IsZero reports whether nothing was detected.
+func (s *Stack) IsZero() bool {
+	return s == nil || len(s.Languages) == 0 && len(s.Frameworks) == 0 && len(s.Tools) == 0
+}
+
+// Summary returns a one-line description of the stack, e.g.
+// "Languages: Go. Frameworks: Gin, GORM. Tools: Docker." It is empty if
+// nothing was detected.
+func (s *Stack) Summary() string {
+	if s.IsZero() {
+		return ""
+	}
+	var parts []string
+	if len(s.Languages) > 0 {
+		parts = append(parts, "Languages: "+strings.Join(s.Languages, ", ")+".")
+	}
+	if len(s.Frameworks) > 0 {
+		parts = append(parts, "Frameworks: "+strings.Join(s.Frameworks, ", ")+".")
+	}
+	if len(s.Tools) > 0 {
+		parts = append(parts, "Tools: "+strings.Join(s.Tools, ", ")+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// Manifests are the files, relative to the repository root, the stack is
+// detected from.
+var Manifests = []string{
+	"go.mod",
+	"package.json",
+	"tsconfig.json",
+	"pyproject.toml",
+	"requirements.txt",
+	"Cargo.toml",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"Dockerfile",
+	"docker-compose.yml",
+	"docker-compose.yaml",
+	"compose.yaml",
+}
+
+// goFrameworks maps Go module paths to framework names.
+var goFrameworks = []struct{ module, name string }{
+	{"github.com/gin-gonic/gin", "Gin"},
+	{"github.com/labstack/echo", "Echo"},
+	{"github.com/gofiber/fiber", "Fiber"},
+	{"github.com/go-chi/chi", "chi"},
+	{"github.com/spf13/cobra", "Cobra"},
+	{"gorm.io/gorm", "GORM"},
+	{"google.golang.org/grpc", "gRPC"},
+	{"github.com/charmbracelet/bubbletea", "Bubble Tea"},
+	{"k8s.io/client-go", "Kubernetes client-go"},
+}
+
+// nodeFrameworks maps npm package names to framework names.
+var nodeFrameworks = []struct{ pkg, name string }{
+	{"next", "Next.js"},
+	{"react", "React"},
+	{"vue", "Vue"},
+	{"@angular/core", "Angular"},
+	{"svelte", "Svelte"},
+	{"express", "Express"},
+	{"@nestjs/core", "NestJS"},
+	{"electron", "Electron"},
+}
+
+// pythonFrameworks maps Python package names to framework names.
+var pythonFrameworks = []struct{ pkg, name string }{
+	{"django", "Django"},
+	{"flask", "Flask"},
+	{"fastapi", "FastAPI"},
+}
+
+// rustFrameworks maps crate names to framework names.
+var rustFrameworks = []struct{ crate, name string }{
+	{"actix-web", "Actix Web"},
+	{"axum", "Axum"},
+	{"rocket", "Rocket"},
+	{"tokio", "Tokio"},
+}
+
+// Detect detects the stack of the repository at root from its manifest files.
+// Unreadable or malformed manifests are skipped.
+func Detect(root string) *Stack {
+	s := &Stack{}
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+
+	if gomod := read("go.mod"); gomod != "" {
+		s.addLanguage("Go")
+		modules := goModules(gomod)
+		for _, f := range goFrameworks {
+			if slices.ContainsFunc(modules, func(m string) bool { return m == f.module || strings.HasPrefix(m, f.module+"/") }) {
+				s.addFramework(f.name)
+			}
+		}
+	}
+
+	if pkg := read("package.json"); pkg != "" {
+		deps := nodeDependencies(pkg)
+		if deps["typescript"] || read("tsconfig.json") != "" {
+			s.addLanguage("TypeScript")
+		} else {
+			s.addLanguage("JavaScript")
+		}
+		for _, f := range nodeFrameworks {
+			if deps[f.pkg] {
+				s.addFramework(f.name)
+			}
+		}
+	}
+
+	if python := read("pyproject.toml") + read("requirements.txt"); python != "" {
+		s.addLanguage("Python")
+		for _, f := range pythonFrameworks {
+			if containsWord(python, f.pkg) {
+				s.addFramework(f.name)
+			}
+		}
+	}
+
+	if cargo := read("Cargo.toml"); cargo != "" {
+		s.addLanguage("Rust")
+		for _, f := range rustFrameworks {
+			if containsWord(cargo, f.crate) {
+				s.addFramework(f.name)
+			}
+		}
+	}
+
+	if java := read("pom.xml") + read("build.gradle") + read("build.gradle.kts"); java != "" {
+		if containsWord(java, "kotlin") {
+			s.addLanguage("Kotlin")
+		} else {
+			s.addLanguage("Java")
+		}
+		if strings.Contains(java, "spring-boot") {
+			s.addFramework("Spring Boot")
+		}
+	}
+
+	if read("Dockerfile") != "" {
+		s.addTool("Docker")
+	}
+	if read("docker-compose.yml")+read("docker-compose.yaml")+read("compose.yaml") != "" {
+		s.addTool("Docker Compose")
+	}
+	return s
+}
+
+func (s *Stack) addLanguage(name string) {
+	if !slices.Contains(s.Languages, name) {
+		s.Languages = append(s.Languages, name)
+	}
+}
+
+func (s *Stack) addFramework(name string) {
+	if !slices.Contains(s.Frameworks, name) {
+		s.Frameworks = append(s.Frameworks, name)
+	}
+}
+
+func (s *Stack) addTool(name string) {
+	if !slices.Contains(s.Tools, name) {
+		s.Tools = append(s.Tools, name)
+	}
+}
+
+// goModules returns the module paths required by a go.mod file.
+func goModules(gomod string) []string {
+	var modules []string
+	inRequire := false
+	scanner := bufio.NewScanner(strings.NewReader(gomod))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "require (":
+			inRequire = true
+		case inRequire && line == ")":
+			inRequire = false
+		case inRequire:
+			if fields := strings.Fields(line); len(fields) > 0 {
+				modules = append(modules, fields[0])
+			}
+		case strings.HasPrefix(line, "require "):
+			if fields := strings.Fields(line); len(fields) > 1 {
+				modules = append(modules, fields[1])
+			}
+		}
+	}
+	return modules
+}
+
+// nodeDependencies returns the names of the dependencies and dev dependencies
+// of a package.json file.
+func nodeDependencies(pkg string) map[string]bool {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	deps := make(map[string]bool)
+	if err := json.Unmarshal([]byte(pkg), &manifest); err != nil {
+		return deps
+	}
+	for name := range manifest.Dependencies {
+		deps[name] = true
+	}
+	for name := range manifest.DevDependencies {
+		deps[name] = true
+	}
+	return deps
+}
+
+// containsWord reports whether text mentions a package name as a whole word,
+// case-insensitively.
+func containsWord(text, name string) bool {
+	pattern := fmt.Sprintf(`(?i)(^|[^\w-])%s($|[^\w-])`, regexp.QuoteMeta(name))
+	return regexp.MustCompile(pattern).MatchString(text)
+}
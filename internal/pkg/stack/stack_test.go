@@ -0,0 +1,104 @@
+package stack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0644))
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected *Stack
+	}{
+		{
+			name: "go with frameworks",
+			files: map[string]string{
+				"go.mod":     "module example.com/app\n\ngo 1.22\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgorm.io/gorm v1.25.0 // indirect\n)\n\nrequire github.com/spf13/cobra v1.8.0\n",
+				"Dockerfile": "FROM golang:1.22\n",
+			},
+			expected: &Stack{Languages: []string{"Go"}, Frameworks: []string{"Gin", "Cobra", "GORM"}, Tools: []string{"Docker"}},
+		},
+		{
+			name: "typescript react",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"react": "^18.0.0", "react-dom": "^18.0.0"}, "devDependencies": {"typescript": "^5.0.0"}}`,
+			},
+			expected: &Stack{Languages: []string{"TypeScript"}, Frameworks: []string{"React"}},
+		},
+		{
+			name: "javascript without frameworks",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"lodash": "^4.0.0"}}`,
+			},
+			expected: &Stack{Languages: []string{"JavaScript"}},
+		},
+		{
+			name: "python",
+			files: map[string]string{
+				"requirements.txt": "Django==4.2\nflask-cors==4.0\n",
+			},
+			expected: &Stack{Languages: []string{"Python"}, Frameworks: []string{"Django"}},
+		},
+		{
+			name: "malformed package.json",
+			files: map[string]string{
+				"package.json": "{",
+			},
+			expected: &Stack{Languages: []string{"JavaScript"}},
+		},
+		{
+			name:     "nothing",
+			files:    map[string]string{"README.md": "# app\n"},
+			expected: &Stack{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tt.files)
+			assert.Equal(t, tt.expected, Detect(root))
+		})
+	}
+}
+
+func TestStack_Summary(t *testing.T) {
+	s := &Stack{Languages: []string{"Go"}, Frameworks: []string{"Gin", "GORM"}, Tools: []string{"Docker"}}
+	assert.Equal(t, "Languages: Go. Frameworks: Gin, GORM. Tools: Docker.", s.Summary())
+	assert.Equal(t, "Languages: Go.", (&Stack{Languages: []string{"Go"}}).Summary())
+	assert.Empty(t, (&Stack{}).Summary())
+}
+
+func TestLoad_Cache(t *testing.T) {
+	root := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "stack.json")
+	writeFiles(t, root, map[string]string{"go.mod": "module example.com/app\n"})
+
+	assert.Equal(t, []string{"Go"}, Load(root, cachePath).Languages)
+	require.FileExists(t, cachePath)
+
+	// A cached stack is used while the manifests are unchanged
+	require.NoError(t, os.WriteFile(cachePath, []byte(`{"signature":"`+manifestSignature(root)+`","stack":{"languages":["Cached"]}}`), 0644))
+	assert.Equal(t, []string{"Cached"}, Load(root, cachePath).Languages)
+
+	// Changing a manifest detects the stack again
+	writeFiles(t, root, map[string]string{"go.mod": "module example.com/app\n\nrequire github.com/gin-gonic/gin v1.9.1\n"})
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "go.mod"), later, later))
+	got := Load(root, cachePath)
+	assert.Equal(t, []string{"Go"}, got.Languages)
+	assert.Equal(t, []string{"Gin"}, got.Frameworks)
+}
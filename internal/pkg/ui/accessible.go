@@ -0,0 +1,342 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// AccessibleManager implements Manager with plain, numbered line prompts read
+// from stdin. It never animates or moves the cursor, so every prompt and
+// answer can be followed with a screen reader.
+type AccessibleManager struct {
+	*DefaultManager
+
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewAccessibleManager creates a new AccessibleManager.
+// Colors are always disabled since they carry no meaning for screen readers.
+func NewAccessibleManager(editor string, autoAccept bool) *AccessibleManager {
+	return &AccessibleManager{
+		DefaultManager: NewDefaultManager(false, editor, autoAccept),
+		in:             bufio.NewReader(os.Stdin),
+		out:            os.Stdout,
+	}
+}
+
+// readLine prints the prompt and reads one line of input.
+func (m *AccessibleManager) readLine(prompt string) (string, error) {
+	fmt.Fprint(m.out, prompt)
+
+	line, err := m.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// DisplayMessage prints the generated commit message.
+func (m *AccessibleManager) DisplayMessage(message *ai.GenerateResponse) error {
+	if message == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+
+	fmt.Fprintln(m.out, m.renderMessage(message))
+	return nil
+}
+
+// DisplayComparison prints the previous and new message one after the other,
+// since side-by-side columns are read line by line across both messages.
+func (m *AccessibleManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	if previous == nil || current == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+
+	fmt.Fprintln(m.out)
+	fmt.Fprintln(m.out, i18n.T("ui.compare.previous_message"))
+	fmt.Fprintln(m.out, m.formatMessageForEdit(previous))
+	fmt.Fprintln(m.out)
+	fmt.Fprintln(m.out, i18n.T("ui.compare.new_message"))
+	fmt.Fprintln(m.out, m.formatMessageForEdit(current))
+	fmt.Fprintln(m.out)
+	return nil
+}
+
+// ShowError prints an error message.
+func (m *AccessibleManager) ShowError(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintln(m.out, m.renderError(err))
+}
+
+// ShowSuccess prints a success message.
+func (m *AccessibleManager) ShowSuccess(message string) {
+	fmt.Fprintln(m.out, m.renderSuccess(message))
+}
+
+// ShowInfo prints plain informational text.
+func (m *AccessibleManager) ShowInfo(message string) {
+	fmt.Fprintln(m.out, strings.TrimRight(message, "\n"))
+}
+
+// ShowSpinner returns a spinner that prints one line per update.
+func (m *AccessibleManager) ShowSpinner(text string) Spinner {
+	return newPlainSpinner(text)
+}
+
+// ShowProgressSpinner returns a progress spinner that prints one line per update.
+func (m *AccessibleManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	return newPlainProgressSpinner(text, total)
+}
+
+// ShowDiff prints the staged diff as plain text.
+func (m *AccessibleManager) ShowDiff(diff string) error {
+	fmt.Fprintln(m.out, strings.TrimRight(diff, "\n"))
+	return nil
+}
+
+// PromptAction lists the actions as numbered lines and reads the chosen number.
+// If autoAccept is enabled, returns ActionAccept immediately.
+func (m *AccessibleManager) PromptAction() (Action, error) {
+	if m.autoAccept {
+		return ActionAccept, nil
+	}
+
+	choices := newActionSelectModel(m.keys).choices
+	labels := make([]string, len(choices))
+	for i, choice := range choices {
+		labels[i] = fmt.Sprintf("%s - %s", choice.label, choice.desc)
+	}
+
+	idx, err := m.promptNumber(i18n.T("ui.action.title"), labels, false)
+	if err != nil {
+		return ActionCancel, err
+	}
+	return choices[idx].action, nil
+}
+
+// PromptConfirm asks a yes/no question. An empty answer means no, since some
+// questions change files or send the diff away.
+// If autoAccept is enabled, returns true immediately.
+func (m *AccessibleManager) PromptConfirm(message string) (bool, error) {
+	if m.autoAccept {
+		return true, nil
+	}
+
+	for {
+		answer, err := m.readLine(message + " " + i18n.T("ui.accessible.yes_no") + ": ")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, nil
+		case "", "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(m.out, i18n.T("ui.accessible.answer_yes_no"))
+	}
+}
+
+// SelectAttempt lists the attempts as numbered lines and reads the chosen number.
+// An empty answer keeps the current message and returns -1.
+func (m *AccessibleManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	if m.autoAccept || len(attempts) == 0 {
+		return len(attempts) - 1, nil
+	}
+
+	labels := attemptLabels(attempts)
+	labels[len(labels)-1] += " " + i18n.T("ui.attempt.current")
+	return m.promptNumber(i18n.T("ui.attempt.title"), labels, true)
+}
+
+// SelectCandidate prints the message of each provider one after the other,
+// then lists the providers as numbered lines and reads the chosen number.
+// An empty answer returns -1. If autoAccept is enabled, the first candidate is returned.
+func (m *AccessibleManager) SelectCandidate(candidates []Candidate) (int, error) {
+	if m.autoAccept || len(candidates) == 0 {
+		return 0, nil
+	}
+
+	for _, candidate := range candidates {
+		fmt.Fprintln(m.out)
+		fmt.Fprintln(m.out, i18n.T("ui.candidate.message", candidate.Provider))
+		fmt.Fprintln(m.out, m.formatMessageForEdit(candidate.Message))
+	}
+	fmt.Fprintln(m.out)
+	return m.promptNumber(i18n.T("ui.candidate.title"), candidateLabels(candidates), true)
+}
+
+// SelectFiles prints the files with their selection and reads numbers to
+// toggle until an empty answer confirms the selection.
+// If autoAccept is enabled, the suggested selection is returned.
+func (m *AccessibleManager) SelectFiles(files []FileOption) ([]string, error) {
+	if m.autoAccept || len(files) == 0 {
+		return selectedPaths(files), nil
+	}
+
+	options, err := m.promptToggles(i18n.T("ui.files.title"), "ui.accessible.toggle_files", files)
+	if err != nil {
+		return nil, err
+	}
+	return selectedPaths(options), nil
+}
+
+// SelectBullets prints the bullets of the message body with their selection
+// and reads numbers to toggle until an empty answer keeps the checked ones.
+// If autoAccept is enabled, all bullets are kept.
+func (m *AccessibleManager) SelectBullets(bullets []string) ([]bool, error) {
+	if m.autoAccept || len(bullets) == 0 {
+		return keptBullets(bulletOptions(bullets)), nil
+	}
+
+	options, err := m.promptToggles(i18n.T("ui.bullets.title"), "ui.accessible.toggle_bullets", bulletOptions(bullets))
+	if err != nil {
+		return nil, err
+	}
+	return keptBullets(options), nil
+}
+
+// promptToggles prints the options with their selection and reads numbers to
+// toggle until an empty answer confirms the selection. promptKey is the
+// message asking for the numbers.
+func (m *AccessibleManager) promptToggles(title, promptKey string, files []FileOption) ([]FileOption, error) {
+	options := make([]FileOption, len(files))
+	copy(options, files)
+
+	for {
+		fmt.Fprintln(m.out, title)
+		for i, file := range options {
+			check := "[ ]"
+			if file.Selected {
+				check = "[x]"
+			}
+			fmt.Fprintf(m.out, "%d. %s %s\n", i+1, check, fileLabel(file))
+		}
+
+		answer, err := m.readLine(i18n.T(promptKey, len(options)))
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return options, nil
+		}
+
+		for _, field := range strings.Fields(strings.ReplaceAll(answer, ",", " ")) {
+			n, err := strconv.Atoi(field)
+			if err != nil || n < 1 || n > len(options) {
+				fmt.Fprintln(m.out, i18n.T("ui.accessible.invalid_choice", field))
+				continue
+			}
+			options[n-1].Selected = !options[n-1].Selected
+		}
+	}
+}
+
+// promptNumber prints a numbered list and reads a choice until it is valid.
+// If allowEmpty is set, an empty answer returns -1.
+func (m *AccessibleManager) promptNumber(title string, labels []string, allowEmpty bool) (int, error) {
+	fmt.Fprintln(m.out, title)
+	for i, label := range labels {
+		fmt.Fprintf(m.out, "%d. %s\n", i+1, label)
+	}
+
+	prompt := i18n.T("ui.accessible.enter_number", len(labels))
+	if allowEmpty {
+		prompt = i18n.T("ui.accessible.enter_number_back", len(labels))
+	}
+
+	for {
+		answer, err := m.readLine(prompt)
+		if err != nil {
+			return -1, err
+		}
+		if answer == "" && allowEmpty {
+			return -1, nil
+		}
+
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 1 && n <= len(labels) {
+			return n - 1, nil
+		}
+		fmt.Fprintln(m.out, i18n.T("ui.accessible.invalid_choice", answer))
+	}
+}
+
+// EditMessage lets the user modify the commit message. The configured external
+// editor is used if available; otherwise the new message is read line by line.
+// A period right away keeps the message; blank lines before it clear the
+// message, which cancels the commit as in the inline editor.
+func (m *AccessibleManager) EditMessage(message *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	if message == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+
+	editContent := m.formatMessageForEdit(message)
+
+	if editor := m.getEditor(); editor != "" {
+		edited, err := m.editWithExternalEditor(editor, editContent)
+		if err == nil {
+			return m.parseEditedMessage(edited), nil
+		}
+		fmt.Fprintln(m.out, i18n.T("ui.accessible.external_failed"))
+	}
+
+	fmt.Fprintln(m.out, i18n.T("ui.accessible.current_message"))
+	fmt.Fprintln(m.out, editContent)
+	fmt.Fprintln(m.out, i18n.T("ui.accessible.edit_instructions"))
+	fmt.Fprintln(m.out, i18n.T("ui.accessible.edit_keep"))
+	fmt.Fprintln(m.out, i18n.T("ui.accessible.edit_cancel"))
+
+	var lines []string
+	for {
+		line, err := m.in.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "." {
+			break
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("failed to read input: %w", err)
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			break
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return message, nil
+	}
+	return m.parseEditedMessage(strings.Join(lines, "\n")), nil
+}
+
+// EditSubject prints the current subject and reads the new one. An empty
+// answer keeps the current subject.
+func (m *AccessibleManager) EditSubject(subject string) (string, error) {
+	fmt.Fprintln(m.out, i18n.T("ui.accessible.current_subject"))
+	fmt.Fprintln(m.out, subject)
+
+	answer, err := m.readLine(i18n.T("ui.accessible.new_subject"))
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return subject, nil
+	}
+	return answer, nil
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// newTestAccessibleManager returns an AccessibleManager reading the given input.
+func newTestAccessibleManager(input string) (*AccessibleManager, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	m := NewAccessibleManager("", false)
+	m.in = bufio.NewReader(strings.NewReader(input))
+	m.out = out
+	return m, out
+}
+
+func TestAccessibleManager_PromptAction(t *testing.T) {
+	t.Run("numbered choice", func(t *testing.T) {
+		m, out := newTestAccessibleManager("2\n")
+		action, err := m.PromptAction()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if action != ActionEdit {
+			t.Errorf("action = %v, want %v", action, ActionEdit)
+		}
+		if !strings.Contains(out.String(), "1. Accept - Commit with this message") {
+			t.Errorf("output should list numbered actions:\n%s", out.String())
+		}
+		if strings.Contains(out.String(), "\x1b[") {
+			t.Error("output must not contain ANSI escape sequences")
+		}
+	})
+
+	t.Run("invalid input is asked again", func(t *testing.T) {
+		m, out := newTestAccessibleManager("x\n99\n1\n")
+		action, err := m.PromptAction()
+		if err != nil || action != ActionAccept {
+			t.Errorf("PromptAction() = %v, %v; want accept, nil", action, err)
+		}
+		if strings.Count(out.String(), "Invalid choice") != 2 {
+			t.Errorf("expected two invalid choice notices:\n%s", out.String())
+		}
+	})
+
+	t.Run("end of input", func(t *testing.T) {
+		m, _ := newTestAccessibleManager("")
+		if _, err := m.PromptAction(); err == nil {
+			t.Error("expected error on end of input")
+		}
+	})
+
+	t.Run("auto accept", func(t *testing.T) {
+		m, out := newTestAccessibleManager("")
+		m.autoAccept = true
+		if action, err := m.PromptAction(); err != nil || action != ActionAccept {
+			t.Errorf("PromptAction() = %v, %v; want accept, nil", action, err)
+		}
+		if out.Len() != 0 {
+			t.Error("auto accept should not print a prompt")
+		}
+	})
+}
+
+func TestAccessibleManager_PromptConfirm(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"\n", false},
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"maybe\nno\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSpace(tt.input), func(t *testing.T) {
+			m, _ := newTestAccessibleManager(tt.input)
+			got, err := m.PromptConfirm("Push?")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("PromptConfirm() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAccessibleManager_SelectAttempt(t *testing.T) {
+	attempts := []*ai.GenerateResponse{
+		{Subject: "feat: first"},
+		{Subject: "feat: second"},
+	}
+
+	m, out := newTestAccessibleManager("1\n")
+	idx, err := m.SelectAttempt(attempts)
+	if err != nil || idx != 0 {
+		t.Errorf("SelectAttempt() = %d, %v; want 0, nil", idx, err)
+	}
+	if !strings.Contains(out.String(), "2. feat: second (current)") {
+		t.Errorf("output should mark the current attempt:\n%s", out.String())
+	}
+
+	m, _ = newTestAccessibleManager("\n")
+	if idx, _ := m.SelectAttempt(attempts); idx != -1 {
+		t.Errorf("empty answer should go back, got %d", idx)
+	}
+}
+
+func TestAccessibleManager_SelectFiles(t *testing.T) {
+	files := []FileOption{
+		{Path: "main.go", Status: "modified", Selected: true},
+		{Path: "notes.txt~", Status: "untracked"},
+	}
+
+	m, out := newTestAccessibleManager("1 2\n9\n\n")
+	selected, err := m.SelectFiles(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(selected) != 1 || selected[0] != "notes.txt~" {
+		t.Errorf("SelectFiles() = %v, want [notes.txt~]", selected)
+	}
+	if !strings.Contains(out.String(), "2. [ ] notes.txt~ (untracked)") {
+		t.Errorf("output should list the files with their selection:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `"9"`) {
+		t.Errorf("output should reject out of range numbers:\n%s", out.String())
+	}
+}
+
+func TestAccessibleManager_EditMessage(t *testing.T) {
+	t.Setenv("EDITOR", "")
+	t.Setenv("VISUAL", "")
+	original := &ai.GenerateResponse{Subject: "feat: original"}
+
+	t.Run("reads lines until a period", func(t *testing.T) {
+		m, _ := newTestAccessibleManager("fix: edited\n\nNew body\n.\nignored\n")
+		edited, err := m.EditMessage(original)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if edited.Subject != "fix: edited" || edited.Body != "New body" {
+			t.Errorf("edited = %+v", edited)
+		}
+	})
+
+	t.Run("period alone keeps the message", func(t *testing.T) {
+		m, _ := newTestAccessibleManager(".\n")
+		edited, err := m.EditMessage(original)
+		if err != nil || edited != original {
+			t.Errorf("EditMessage() = %+v, %v; want original", edited, err)
+		}
+	})
+
+	t.Run("empty message cancels", func(t *testing.T) {
+		m, _ := newTestAccessibleManager("\n.\n")
+		edited, err := m.EditMessage(original)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if edited == original || edited.Subject != "" || edited.Body != "" {
+			t.Errorf("EditMessage() = %+v, want an empty message", edited)
+		}
+	})
+}
+
+func TestAccessibleManager_SelectBullets(t *testing.T) {
+	m, out := newTestAccessibleManager("2\n\n")
+	keep, err := m.SelectBullets([]string{"- auth: refresh tokens", "- update tests"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keep) != 2 || !keep[0] || keep[1] {
+		t.Errorf("SelectBullets() = %v, want [true false]", keep)
+	}
+	if !strings.Contains(out.String(), "2. [x] - update tests") {
+		t.Errorf("output should list the bullets checked:\n%s", out.String())
+	}
+}
+
+func TestAccessibleManager_EditSubject(t *testing.T) {
+	m, out := newTestAccessibleManager("feat(auth): add login form\n")
+	subject, err := m.EditSubject("feat: add login")
+	if err != nil || subject != "feat(auth): add login form" {
+		t.Errorf("EditSubject() = %q, %v", subject, err)
+	}
+	if !strings.Contains(out.String(), "feat: add login") {
+		t.Error("the current subject should be shown")
+	}
+
+	// An empty answer keeps the subject
+	m, _ = newTestAccessibleManager("\n")
+	if subject, err := m.EditSubject("feat: add login"); err != nil || subject != "feat: add login" {
+		t.Errorf("EditSubject() = %q, %v; want the current subject", subject, err)
+	}
+}
+
+func TestAccessibleManager_DisplayComparison(t *testing.T) {
+	m, out := newTestAccessibleManager("")
+	err := m.DisplayComparison(
+		&ai.GenerateResponse{Subject: "feat: first"},
+		&ai.GenerateResponse{Subject: "feat: second"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := out.String()
+	if strings.Index(got, "feat: first") > strings.Index(got, "feat: second") {
+		t.Errorf("previous message should be printed before the new one:\n%s", got)
+	}
+}
+
+func TestAccessibleManager_ShowInfo(t *testing.T) {
+	m, out := newTestAccessibleManager("")
+	m.ShowInfo("line one\nline two\n")
+
+	if got := out.String(); got != "line one\nline two\n" {
+		t.Errorf("ShowInfo() printed %q", got)
+	}
+}
+
+func TestAccessibleManager_PlainSpinners(t *testing.T) {
+	m, _ := newTestAccessibleManager("")
+	if _, ok := m.ShowSpinner("test").(*plainSpinner); !ok {
+		t.Error("ShowSpinner() should return a plain spinner")
+	}
+	if _, ok := m.ShowProgressSpinner("test", 2).(*plainProgressSpinner); !ok {
+		t.Error("ShowProgressSpinner() should return a plain progress spinner")
+	}
+}
@@ -0,0 +1,50 @@
+// Package ui provides user interface components for GitSage.
+package ui
+
+// SelectBullets lets the user toggle off bullets of the message body in a
+// checklist. All bullets start checked. Returns which bullets to keep, or nil
+// if the user backs out. If autoAccept is enabled, all bullets are kept.
+func (m *DefaultManager) SelectBullets(bullets []string) ([]bool, error) {
+	if m.autoAccept || len(bullets) == 0 {
+		return keptBullets(bulletOptions(bullets)), nil
+	}
+
+	p := newProgram(newBulletSelectModel(bullets, m.keys))
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	result := finalModel.(fileSelectModel)
+	if result.cancelled {
+		return nil, nil
+	}
+	return keptBullets(result.files), nil
+}
+
+// newBulletSelectModel returns the checklist of body bullets, all checked.
+func newBulletSelectModel(bullets []string, keys KeyMap) fileSelectModel {
+	m := newFileSelectModel(bulletOptions(bullets), keys)
+	m.titleKey = "ui.bullets.title"
+	m.helpKey = "ui.bullets.help"
+	return m
+}
+
+// bulletOptions returns the bullets as checked options.
+func bulletOptions(bullets []string) []FileOption {
+	options := make([]FileOption, len(bullets))
+	for i, bullet := range bullets {
+		options[i] = FileOption{Path: bullet, Selected: true}
+	}
+	return options
+}
+
+// keptBullets returns which of the options are checked.
+func keptBullets(options []FileOption) []bool {
+	keep := make([]bool, len(options))
+	for i, option := range options {
+		keep[i] = option.Selected
+	}
+	return keep
+}
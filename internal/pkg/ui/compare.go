@@ -0,0 +1,355 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// compareColumnWidth is the width of each column in the side-by-side comparison.
+const compareColumnWidth = 38
+
+// DisplayComparison shows the previous and the regenerated message side by side,
+// highlighting words that were removed from the previous attempt and added in the new one.
+func (m *DefaultManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	if previous == nil || current == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+
+	fmt.Println(m.renderComparison(previous, current))
+	return nil
+}
+
+// renderComparison renders the side-by-side comparison block.
+func (m *DefaultManager) renderComparison(previous, current *ai.GenerateResponse) string {
+	left, right := m.highlightWordDiff(
+		m.formatMessageForEdit(previous),
+		m.formatMessageForEdit(current),
+	)
+
+	column := lipgloss.NewStyle().Width(compareColumnWidth)
+	leftColumn := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.footer.Render(i18n.T("ui.compare.previous")),
+		column.Render(left),
+	)
+	rightColumn := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.subject.Render(i18n.T("ui.compare.new")),
+		column.Render(right),
+	)
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.title.Render(i18n.T("ui.compare.title")))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", compareColumnWidth*2+4))
+	sb.WriteString("\n")
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, "    ", rightColumn))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", compareColumnWidth*2+4))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// highlightWordDiff computes a word-level diff between two texts and returns both
+// texts with removed words styled in the first and added words styled in the second.
+func (m *DefaultManager) highlightWordDiff(previous, current string) (string, string) {
+	a, b := splitWords(previous), splitWords(current)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var left, right strings.Builder
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			left.WriteString(a[i])
+			right.WriteString(b[j])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			left.WriteString(renderToken(m.styles.removed, a[i]))
+			i++
+		default:
+			right.WriteString(renderToken(m.styles.added, b[j]))
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		left.WriteString(renderToken(m.styles.removed, a[i]))
+	}
+	for ; j < len(b); j++ {
+		right.WriteString(renderToken(m.styles.added, b[j]))
+	}
+
+	return left.String(), right.String()
+}
+
+// renderToken styles a word token; whitespace is kept as-is so line breaks survive.
+func renderToken(style lipgloss.Style, token string) string {
+	if strings.TrimSpace(token) == "" {
+		return token
+	}
+	return style.Render(token)
+}
+
+// splitWords splits text into alternating word and whitespace tokens.
+// Joining the tokens yields the original text.
+func splitWords(text string) []string {
+	var tokens []string
+	start := 0
+	prevSpace := false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if i > 0 && space != prevSpace {
+			tokens = append(tokens, text[start:i])
+			start = i
+		}
+		prevSpace = space
+	}
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+	return tokens
+}
+
+// SelectAttempt lets the user pick one of the generated attempts.
+// Returns the index of the chosen attempt, or -1 if the user backs out.
+// If autoAccept is enabled, the latest attempt is returned immediately.
+func (m *DefaultManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	if m.autoAccept || len(attempts) == 0 {
+		return len(attempts) - 1, nil
+	}
+
+	model := newAttemptSelectModel(attemptLabels(attempts), m.keys)
+	p := newProgram(model)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return -1, err
+	}
+
+	result := finalModel.(attemptSelectModel)
+	return result.selected, nil
+}
+
+// attemptLabels returns the subject line of each attempt for display in the picker.
+func attemptLabels(attempts []*ai.GenerateResponse) []string {
+	labels := make([]string, len(attempts))
+	for i, attempt := range attempts {
+		subject := attempt.Subject
+		if subject == "" && attempt.RawText != "" {
+			subject = strings.SplitN(attempt.RawText, "\n", 2)[0]
+		}
+		labels[i] = subject
+	}
+	return labels
+}
+
+// Candidate is a message generated by one of the providers being compared.
+type Candidate struct {
+	// Provider is the name of the provider that generated the message.
+	Provider string
+	Message  *ai.GenerateResponse
+}
+
+// SelectCandidate shows the candidates side by side and lets the user pick one.
+// Returns the index of the chosen candidate, or -1 if the user backs out.
+// If autoAccept is enabled, the first candidate is returned immediately.
+func (m *DefaultManager) SelectCandidate(candidates []Candidate) (int, error) {
+	if m.autoAccept || len(candidates) == 0 {
+		return 0, nil
+	}
+
+	fmt.Println(m.renderCandidates(candidates))
+
+	model := newCandidateSelectModel(candidateLabels(candidates), m.keys)
+	p := newProgram(model)
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return -1, err
+	}
+
+	result := finalModel.(attemptSelectModel)
+	return result.selected, nil
+}
+
+// renderCandidates renders the candidates in columns, one per provider.
+func (m *DefaultManager) renderCandidates(candidates []Candidate) string {
+	column := lipgloss.NewStyle().Width(compareColumnWidth)
+	columns := make([]string, 0, 2*len(candidates))
+	for i, candidate := range candidates {
+		if i > 0 {
+			columns = append(columns, "    ")
+		}
+		columns = append(columns, lipgloss.JoinVertical(lipgloss.Left,
+			m.styles.subject.Render(fmt.Sprintf("%d. %s", i+1, candidate.Provider)),
+			column.Render(m.formatMessageForEdit(candidate.Message)),
+		))
+	}
+	width := compareColumnWidth*len(candidates) + 4*(len(candidates)-1)
+
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.title.Render(i18n.T("ui.candidate.heading")))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", width))
+	sb.WriteString("\n")
+	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", width))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// candidateLabels returns the provider and subject line of each candidate for the picker.
+func candidateLabels(candidates []Candidate) []string {
+	messages := make([]*ai.GenerateResponse, len(candidates))
+	for i, candidate := range candidates {
+		messages[i] = candidate.Message
+	}
+	labels := attemptLabels(messages)
+	for i, candidate := range candidates {
+		labels[i] = candidate.Provider + ": " + labels[i]
+	}
+	return labels
+}
+
+// attemptSelectModel is the Bubble Tea model for choosing an earlier attempt,
+// or one of the candidates of compared providers.
+type attemptSelectModel struct {
+	labels   []string
+	title    string
+	current  int // Index marked as the current message; -1 for none
+	cursor   int
+	selected int
+	done     bool
+	keys     KeyMap
+}
+
+func newAttemptSelectModel(labels []string, keys KeyMap) attemptSelectModel {
+	return attemptSelectModel{
+		labels:   labels,
+		title:    i18n.T("ui.attempt.title"),
+		current:  len(labels) - 1,
+		cursor:   len(labels) - 1, // Start on the current attempt
+		selected: -1,
+		keys:     keys,
+	}
+}
+
+func newCandidateSelectModel(labels []string, keys KeyMap) attemptSelectModel {
+	return attemptSelectModel{
+		labels:   labels,
+		title:    i18n.T("ui.candidate.title"),
+		current:  -1,
+		selected: -1,
+		keys:     keys,
+	}
+}
+
+func (m attemptSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m attemptSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		k := msg.String()
+		switch {
+		case k == "ctrl+c", k == "esc", key.Matches(msg, m.keys.Quit):
+			m.selected = -1
+			m.done = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.labels)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.Select):
+			m.selected = m.cursor
+			m.done = true
+			return m, tea.Quit
+		case len(k) == 1 && k >= "1" && k <= "9":
+			if idx := int(k[0] - '1'); idx < len(m.labels) {
+				m.selected = idx
+				m.done = true
+				return m, tea.Quit
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m attemptSelectModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("212"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(m.title))
+	sb.WriteString("\n\n")
+
+	for i, label := range m.labels {
+		cursor := "  "
+		style := normalStyle
+		if m.cursor == i {
+			cursor = "▸ "
+			style = selectedStyle
+		}
+
+		sb.WriteString(fmt.Sprintf("%s%d. %s", cursor, i+1, style.Render(label)))
+		if i == m.current {
+			sb.WriteString(descStyle.Render(" " + i18n.T("ui.attempt.current")))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(descStyle.Render(i18n.T(
+		"ui.attempt.help",
+		keyLabel(m.keys.Up), keyLabel(m.keys.Down), keyLabel(m.keys.Select),
+	)))
+
+	return sb.String()
+}
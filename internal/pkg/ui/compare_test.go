@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"feat: add", []string{"feat:", " ", "add"}},
+		{"fix\n\n- 修复 bug", []string{"fix", "\n\n", "-", " ", "修复", " ", "bug"}},
+		{"  padded ", []string{"  ", "padded", " "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := splitWords(tt.input)
+			if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("splitWords(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+			if strings.Join(got, "") != tt.input {
+				t.Errorf("tokens do not reassemble to the input")
+			}
+		})
+	}
+}
+
+func TestHighlightWordDiff(t *testing.T) {
+	m := NewDefaultManager(false, "", false)
+	// Mark changes visibly so the test does not depend on terminal colors
+	m.styles.removed = lipgloss.NewStyle().Transform(func(s string) string { return "[-" + s + "]" })
+	m.styles.added = lipgloss.NewStyle().Transform(func(s string) string { return "[+" + s + "]" })
+
+	tests := []struct {
+		name          string
+		previous      string
+		current       string
+		expectedLeft  string
+		expectedRight string
+	}{
+		{
+			name:          "identical",
+			previous:      "feat: add login",
+			current:       "feat: add login",
+			expectedLeft:  "feat: add login",
+			expectedRight: "feat: add login",
+		},
+		{
+			name:          "word replaced",
+			previous:      "feat: add login",
+			current:       "feat: add signup",
+			expectedLeft:  "feat: add [-login]",
+			expectedRight: "feat: add [+signup]",
+		},
+		{
+			name:          "body appended",
+			previous:      "fix: typo",
+			current:       "fix: typo\n\nCorrect spelling",
+			expectedLeft:  "fix: typo",
+			expectedRight: "fix: typo\n\n[+Correct] [+spelling]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := m.highlightWordDiff(tt.previous, tt.current)
+			if left != tt.expectedLeft {
+				t.Errorf("left = %q, want %q", left, tt.expectedLeft)
+			}
+			if right != tt.expectedRight {
+				t.Errorf("right = %q, want %q", right, tt.expectedRight)
+			}
+		})
+	}
+}
+
+func TestRenderComparison(t *testing.T) {
+	m := NewDefaultManager(false, "", false)
+	out := m.renderComparison(
+		&ai.GenerateResponse{Subject: "feat: first"},
+		&ai.GenerateResponse{Subject: "feat: second"},
+	)
+
+	for _, want := range []string{"Previous", "New", "feat: first", "feat: second"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderComparison() missing %q:\n%s", want, out)
+		}
+	}
+
+	if err := m.DisplayComparison(nil, &ai.GenerateResponse{}); err == nil {
+		t.Error("DisplayComparison() should reject nil messages")
+	}
+}
+
+func TestAttemptSelectModel(t *testing.T) {
+	labels := []string{"feat: first", "feat: second", "feat: third"}
+
+	t.Run("starts on the current attempt", func(t *testing.T) {
+		m := newAttemptSelectModel(labels, DefaultKeyMap())
+		if m.cursor != 2 {
+			t.Errorf("cursor = %d, want 2", m.cursor)
+		}
+		if !strings.Contains(m.View(), "(current)") {
+			t.Error("View() should mark the current attempt")
+		}
+	})
+
+	t.Run("enter selects the highlighted attempt", func(t *testing.T) {
+		m := newAttemptSelectModel(labels, DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("k"))
+		updated, _ = updated.Update(keyMsg("enter"))
+		if got := updated.(attemptSelectModel).selected; got != 1 {
+			t.Errorf("selected = %d, want 1", got)
+		}
+	})
+
+	t.Run("number keys quick select", func(t *testing.T) {
+		m := newAttemptSelectModel(labels, DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("1"))
+		if got := updated.(attemptSelectModel).selected; got != 0 {
+			t.Errorf("selected = %d, want 0", got)
+		}
+
+		updated, _ = m.Update(keyMsg("9"))
+		if updated.(attemptSelectModel).done {
+			t.Error("out of range number should be ignored")
+		}
+	})
+
+	t.Run("esc backs out", func(t *testing.T) {
+		m := newAttemptSelectModel(labels, DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("esc"))
+		result := updated.(attemptSelectModel)
+		if !result.done || result.selected != -1 {
+			t.Errorf("selected = %d, want -1", result.selected)
+		}
+	})
+}
+
+func TestAttemptLabels(t *testing.T) {
+	labels := attemptLabels([]*ai.GenerateResponse{
+		{Subject: "feat: subject"},
+		{RawText: "fix: from raw text\n\nbody"},
+	})
+	if labels[0] != "feat: subject" || labels[1] != "fix: from raw text" {
+		t.Errorf("attemptLabels() = %q", labels)
+	}
+}
+
+func TestCandidateSelect(t *testing.T) {
+	candidates := []Candidate{
+		{Provider: "openai", Message: &ai.GenerateResponse{Subject: "feat: add login"}},
+		{Provider: "ollama", Message: &ai.GenerateResponse{RawText: "feat: add login form\n\nbody"}},
+	}
+
+	labels := candidateLabels(candidates)
+	if labels[0] != "openai: feat: add login" || labels[1] != "ollama: feat: add login form" {
+		t.Errorf("candidateLabels() = %q", labels)
+	}
+
+	m := newCandidateSelectModel(labels, DefaultKeyMap())
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+	if strings.Contains(m.View(), "(current)") {
+		t.Error("View() should not mark a candidate as current")
+	}
+	updated, _ := m.Update(keyMsg("2"))
+	if got := updated.(attemptSelectModel).selected; got != 1 {
+		t.Errorf("selected = %d, want 1", got)
+	}
+}
+
+func TestRenderCandidates(t *testing.T) {
+	m := NewDefaultManager(false, "", false)
+	out := m.renderCandidates([]Candidate{
+		{Provider: "openai", Message: &ai.GenerateResponse{Subject: "feat: add login"}},
+		{Provider: "ollama", Message: &ai.GenerateResponse{Subject: "feat: add login form"}},
+	})
+
+	for _, want := range []string{"1. openai", "2. ollama", "feat: add login", "feat: add login form"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderCandidates() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,388 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// Default editor size used until the terminal reports its dimensions.
+const (
+	defaultConfigWidth  = 80
+	defaultConfigHeight = 24
+)
+
+// configChromeHeight is the number of lines taken by the title, the
+// description and error of the selected key and the help line.
+const configChromeHeight = 8
+
+// RunConfigEditor runs the full-screen configuration editor. Each confirmed
+// change is checked like "config set" and written right away.
+func RunConfigEditor(cfgMgr *config.ViperManager) error {
+	if LegacyConsole() {
+		return errLegacyConsole
+	}
+
+	model := newConfigEditorModel(config.Keys(), cfgMgr.List(), cfgMgr.Set)
+	p := newProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to run config editor: %w", err)
+	}
+	return nil
+}
+
+// configField is a key of the editor and its current value.
+type configField struct {
+	info  config.KeyInfo
+	value string
+}
+
+// section returns the section of the key, e.g. "provider" for provider.model.
+func (f configField) section() string {
+	section, _, _ := strings.Cut(f.info.Key, ".")
+	return section
+}
+
+// name returns the key within its section, e.g. "model" for provider.model.
+func (f configField) name() string {
+	_, name, _ := strings.Cut(f.info.Key, ".")
+	return name
+}
+
+// secret reports whether the value is masked, as "config list" does.
+func (f configField) secret() bool {
+	return strings.HasSuffix(f.info.Key, ".api_key")
+}
+
+// display returns the value as shown in the list.
+func (f configField) display() string {
+	if f.secret() && f.value != "" {
+		return config.MaskAPIKey(f.value)
+	}
+	return f.value
+}
+
+// Modes of the config editor.
+const (
+	configBrowse = iota
+	configInput
+	configPick
+)
+
+// configEditorModel is the Bubble Tea model of the configuration editor. Keys
+// are listed by section; Enter toggles a boolean, opens a picker of the
+// accepted values or an input for the others, and q or Esc quits.
+type configEditorModel struct {
+	fields []configField
+	save   func(key, value string) error
+	cursor int
+	offset int
+	width  int
+	height int
+	mode   int
+	input  textinput.Model
+	choice int
+	err    error
+	status string
+	done   bool
+}
+
+func newConfigEditorModel(keys []config.KeyInfo, settings map[string]interface{}, save func(key, value string) error) configEditorModel {
+	fields := make([]configField, 0, len(keys))
+	for _, info := range keys {
+		fields = append(fields, configField{info: info, value: settingValue(settings, info.Key)})
+	}
+	return configEditorModel{
+		fields: fields,
+		save:   save,
+		width:  defaultConfigWidth,
+		height: defaultConfigHeight,
+	}
+}
+
+// settingValue returns the value of a dotted key in the nested settings,
+// with lists joined by commas as "config set" takes them.
+func settingValue(settings map[string]interface{}, key string) string {
+	var value interface{} = settings
+	for _, part := range strings.Split(key, ".") {
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = section[part]
+	}
+
+	switch v := value.(type) {
+	case nil, map[string]interface{}:
+		return ""
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		return strings.Join(items, ",")
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (m configEditorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m configEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch m.mode {
+		case configInput:
+			return m.updateInput(msg)
+		case configPick:
+			return m.updatePick(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateBrowse handles the keys of the list.
+func (m configEditorModel) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		m.done = true
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, len(m.fields)-1)
+	case "pgup":
+		m.cursor = max(m.cursor-m.bodyHeight(), 0)
+	case "pgdown":
+		m.cursor = min(m.cursor+m.bodyHeight(), len(m.fields)-1)
+	case "g", "home":
+		m.cursor = 0
+	case "G", "end":
+		m.cursor = len(m.fields) - 1
+	case "enter", " ":
+		return m.startEdit()
+	}
+	m.err, m.status = nil, ""
+	m.scroll()
+	return m, nil
+}
+
+// startEdit edits the selected key: a boolean is toggled, a key with
+// accepted values gets a picker and any other an input.
+func (m configEditorModel) startEdit() (tea.Model, tea.Cmd) {
+	m.err, m.status = nil, ""
+	field := m.fields[m.cursor]
+
+	switch {
+	case field.info.Type == config.TypeBool:
+		value := "true"
+		if field.value == "true" {
+			value = "false"
+		}
+		m.commit(value)
+		return m, nil
+
+	case len(field.info.Values) > 0:
+		m.mode = configPick
+		m.choice = 0
+		for i, value := range field.info.Values {
+			if strings.EqualFold(value, field.value) {
+				m.choice = i
+			}
+		}
+		return m, nil
+	}
+
+	m.mode = configInput
+	m.input = textinput.New()
+	m.input.Prompt = "› "
+	m.input.CharLimit = 0
+	m.input.SetValue(field.value)
+	if field.secret() {
+		m.input.EchoMode = textinput.EchoPassword
+	}
+	m.input.CursorEnd()
+	m.input.Focus()
+	return m, textinput.Blink
+}
+
+// updateInput handles the keys of the value input, validating the value as
+// it is typed.
+func (m configEditorModel) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode, m.err = configBrowse, nil
+		return m, nil
+	case "enter":
+		if m.err == nil {
+			m.commit(m.input.Value())
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.err = config.ValidateSetting(m.fields[m.cursor].info.Key, m.input.Value())
+	return m, cmd
+}
+
+// updatePick handles the keys of the picker of accepted values.
+func (m configEditorModel) updatePick(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	values := m.fields[m.cursor].info.Values
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = configBrowse
+	case "left", "h", "up", "k":
+		m.choice = (m.choice + len(values) - 1) % len(values)
+	case "right", "l", "down", "j", "tab":
+		m.choice = (m.choice + 1) % len(values)
+	case "enter", " ":
+		m.commit(values[m.choice])
+	}
+	return m, nil
+}
+
+// commit writes the value of the selected key, returning to the list once
+// it is saved and staying in the edit otherwise.
+func (m *configEditorModel) commit(value string) {
+	field := &m.fields[m.cursor]
+	err := config.ValidateSetting(field.info.Key, value)
+	if err == nil {
+		err = m.save(field.info.Key, value)
+	}
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	field.value = value
+	m.mode, m.err = configBrowse, nil
+	m.status = i18n.T("ui.config.saved", field.info.Key, field.display())
+}
+
+// bodyHeight returns the number of lines of the list.
+func (m configEditorModel) bodyHeight() int {
+	return max(m.height-configChromeHeight, 1)
+}
+
+// lines returns the lines of the list, a header before the keys of each
+// section, and the line of the selected key.
+func (m configEditorModel) lines() ([]string, int) {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("212"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	unsetStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	width := 0
+	for _, field := range m.fields {
+		width = max(width, len(field.name()))
+	}
+
+	var lines []string
+	selected := 0
+	section := ""
+	for i, field := range m.fields {
+		if field.section() != section {
+			section = field.section()
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, headerStyle.Render(section))
+		}
+
+		marker := "  "
+		if i == m.cursor {
+			marker = cursorStyle.Render("> ")
+			selected = len(lines)
+		}
+
+		value := valueStyle.Render(field.display())
+		switch {
+		case i == m.cursor && m.mode == configInput:
+			value = m.input.View()
+		case i == m.cursor && m.mode == configPick:
+			value = cursorStyle.Render(fmt.Sprintf("‹ %s ›", field.info.Values[m.choice]))
+		case field.value == "":
+			value = unsetStyle.Render(i18n.T("ui.config.unset"))
+		}
+		lines = append(lines, fmt.Sprintf("%s%-*s  %s", marker, width, field.name(), value))
+	}
+	return lines, selected
+}
+
+// scroll moves the list so the selected key is visible.
+func (m *configEditorModel) scroll() {
+	_, selected := m.lines()
+	height := m.bodyHeight()
+	switch {
+	case selected < m.offset:
+		m.offset = selected
+		// Keep the section header in view with its first key
+		if m.offset > 0 && m.cursor > 0 && m.fields[m.cursor-1].section() != m.fields[m.cursor].section() {
+			m.offset--
+		}
+	case selected >= m.offset+height:
+		m.offset = selected - height + 1
+	}
+}
+
+func (m configEditorModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	statusStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+
+	lines, _ := m.lines()
+	end := min(m.offset+m.bodyHeight(), len(lines))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(i18n.T("ui.config.title")))
+	sb.WriteString("\n\n")
+	sb.WriteString(strings.Join(lines[m.offset:end], "\n"))
+	for i := end - m.offset; i < m.bodyHeight(); i++ {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n\n")
+
+	field := m.fields[m.cursor]
+	sb.WriteString(descStyle.Render(fmt.Sprintf("%s: %s", field.info.Key, field.info.Description)))
+	sb.WriteString("\n")
+	switch {
+	case m.err != nil:
+		sb.WriteString(errorStyle.Render(m.err.Error()))
+	case m.status != "":
+		sb.WriteString(statusStyle.Render(m.status))
+	}
+	sb.WriteString("\n\n")
+
+	switch m.mode {
+	case configInput:
+		sb.WriteString(descStyle.Render(i18n.T("ui.config.help_input")))
+	case configPick:
+		sb.WriteString(descStyle.Render(i18n.T("ui.config.help_pick")))
+	default:
+		sb.WriteString(descStyle.Render(i18n.T("ui.config.help")))
+	}
+	return sb.String()
+}
@@ -0,0 +1,165 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/gitsage/gitsage/internal/pkg/config"
+)
+
+var testConfigKeys = []config.KeyInfo{
+	{Key: "provider.name", Type: config.TypeString, Values: []string{"openai", "deepseek", "ollama"}, Description: "AI provider"},
+	{Key: "provider.api_key", Type: config.TypeString, Description: "API key of the provider"},
+	{Key: "provider.max_tokens", Type: config.TypeInt, Description: "Maximum tokens of a reply"},
+	{Key: "cache.enabled", Type: config.TypeBool, Description: "Cache responses"},
+	{Key: "git.exclude_patterns", Type: config.TypeList, Description: "Globs of files left out of the diff"},
+}
+
+var testConfigSettings = map[string]interface{}{
+	"provider": map[string]interface{}{
+		"name":       "deepseek",
+		"api_key":    "sk-secret1234",
+		"max_tokens": 500,
+	},
+	"cache": map[string]interface{}{"enabled": true},
+	"git":   map[string]interface{}{"exclude_patterns": []interface{}{"*.lock", "dist/**"}},
+}
+
+// newTestConfigEditor returns a config editor recording what it saves.
+func newTestConfigEditor(saved map[string]string) configEditorModel {
+	return newConfigEditorModel(testConfigKeys, testConfigSettings, func(key, value string) error {
+		saved[key] = value
+		return nil
+	})
+}
+
+// pressKeys sends the keys to the config editor in order.
+func pressKeys(m configEditorModel, keys ...string) configEditorModel {
+	for _, k := range keys {
+		updated, _ := m.Update(keyMsg(k))
+		m = updated.(configEditorModel)
+	}
+	return m
+}
+
+func TestSettingValue(t *testing.T) {
+	tests := map[string]string{
+		"provider.name":        "deepseek",
+		"provider.max_tokens":  "500",
+		"cache.enabled":        "true",
+		"git.exclude_patterns": "*.lock,dist/**",
+		"provider.model":       "",
+		"provider":             "",
+		"cache.enabled.extra":  "",
+	}
+
+	for key, want := range tests {
+		if got := settingValue(testConfigSettings, key); got != want {
+			t.Errorf("settingValue(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestConfigEditorModel(t *testing.T) {
+	t.Run("lists keys by section and masks secrets", func(t *testing.T) {
+		view := newTestConfigEditor(map[string]string{}).View()
+		for _, want := range []string{"provider", "name", "deepseek", "*********1234", "*.lock,dist/**", "AI provider"} {
+			if !strings.Contains(view, want) {
+				t.Errorf("View() missing %q:\n%s", want, view)
+			}
+		}
+		if strings.Contains(view, "sk-secret") {
+			t.Errorf("View() shows the API key:\n%s", view)
+		}
+	})
+
+	t.Run("enter toggles a boolean", func(t *testing.T) {
+		saved := map[string]string{}
+		m := pressKeys(newTestConfigEditor(saved), "j", "j", "j", "enter")
+		if saved["cache.enabled"] != "false" {
+			t.Errorf("saved = %v, want cache.enabled=false", saved)
+		}
+		if m.mode != configBrowse || !strings.Contains(m.View(), "Set cache.enabled = false") {
+			t.Errorf("toggling should save and stay in the list:\n%s", m.View())
+		}
+	})
+
+	t.Run("picker cycles through the accepted values", func(t *testing.T) {
+		saved := map[string]string{}
+		m := pressKeys(newTestConfigEditor(saved), "enter")
+		if m.mode != configPick || !strings.Contains(m.View(), "‹ deepseek ›") {
+			t.Fatalf("enter should open the picker on the current value:\n%s", m.View())
+		}
+
+		m = pressKeys(m, "right", "right", "enter")
+		if saved["provider.name"] != "openai" {
+			t.Errorf("saved = %v, want provider.name=openai", saved)
+		}
+
+		m = pressKeys(m, "enter", "esc")
+		if m.mode != configBrowse || len(saved) != 1 {
+			t.Errorf("Esc should leave the picker without saving, saved = %v", saved)
+		}
+	})
+
+	t.Run("input validates as it is typed", func(t *testing.T) {
+		saved := map[string]string{}
+		m := pressKeys(newTestConfigEditor(saved), "j", "j", "enter")
+		if m.fields[m.cursor].info.Key != "provider.max_tokens" || m.mode != configInput {
+			t.Fatalf("enter should edit %s", m.fields[m.cursor].info.Key)
+		}
+
+		m = pressKeys(m, "x", "enter")
+		if m.err == nil || len(saved) != 0 || m.mode != configInput {
+			t.Errorf("an invalid value should not be saved, err = %v, saved = %v", m.err, saved)
+		}
+		if !strings.Contains(m.View(), "must be a whole number") {
+			t.Errorf("View() should show the validation error:\n%s", m.View())
+		}
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = pressKeys(updated.(configEditorModel), "0", "enter")
+		if saved["provider.max_tokens"] != "5000" || m.mode != configBrowse {
+			t.Errorf("saved = %v, want provider.max_tokens=5000", saved)
+		}
+	})
+
+	t.Run("save errors keep the edit open", func(t *testing.T) {
+		m := newConfigEditorModel(testConfigKeys, testConfigSettings, func(key, value string) error {
+			return errors.New("read-only file")
+		})
+		m = pressKeys(m, "j", "j", "j", "enter")
+		if m.fields[m.cursor].value != "true" || !strings.Contains(m.View(), "read-only file") {
+			t.Errorf("a failed save should keep the value and show the error:\n%s", m.View())
+		}
+	})
+
+	t.Run("scrolls to keep the selected key visible", func(t *testing.T) {
+		m := newTestConfigEditor(map[string]string{})
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: configChromeHeight + 3})
+		m = pressKeys(updated.(configEditorModel), "G")
+		if !strings.Contains(m.View(), "exclude_patterns") {
+			t.Errorf("G should scroll to the last key:\n%s", m.View())
+		}
+		if strings.Contains(m.View(), "max_tokens") {
+			t.Errorf("the first keys should scroll out of view:\n%s", m.View())
+		}
+
+		m = pressKeys(m, "g")
+		if !strings.Contains(m.View(), "provider") {
+			t.Errorf("g should scroll back to the top:\n%s", m.View())
+		}
+	})
+
+	t.Run("q quits", func(t *testing.T) {
+		m := newTestConfigEditor(map[string]string{})
+		updated, cmd := m.Update(keyMsg("q"))
+		if !updated.(configEditorModel).done || cmd == nil {
+			t.Error("q should quit the editor")
+		}
+	})
+}
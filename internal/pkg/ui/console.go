@@ -0,0 +1,96 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// consoleCaps is what the console attached to stdout supports.
+type consoleCaps struct {
+	// vt is set when ANSI escape sequences are interpreted, which colors and
+	// Bubble Tea programs need.
+	vt bool
+	// unicode is set when glyphs beyond ASCII, such as arrows, box drawing and
+	// braille spinners, are shown rather than replaced by question marks.
+	unicode bool
+}
+
+var (
+	consoleOnce sync.Once
+	console     consoleCaps
+)
+
+// detectConsole prepares the console on first use and returns what it supports.
+func detectConsole() consoleCaps {
+	consoleOnce.Do(func() { console = setupConsole() })
+	return console
+}
+
+// LegacyConsole reports whether stdout is a console that prints ANSI escape
+// sequences instead of interpreting them, such as cmd.exe before Windows 10.
+// Only plain line prompts work there.
+func LegacyConsole() bool {
+	return isTerminal(os.Stdout) && !detectConsole().vt
+}
+
+// errLegacyConsole is returned by the full-screen programs on legacy consoles.
+var errLegacyConsole = errors.New("this console does not support ANSI escape sequences (Windows 10 or later is required); use 'gitsage config init' and 'gitsage config set' instead")
+
+// asciiGlyphs replaces the glyphs of the UI with ASCII look-alikes.
+var asciiGlyphs = strings.NewReplacer(
+	"↑", "^", "↓", "v", "←", "<", "→", ">",
+	"›", ">", "‹", "<", "•", "*", "…", "...",
+	"✎", "e", "↻", "r", "±", "+", "⟲", "p", "⇅", "^", "⇄", "~", "☰", "=", "×", "x",
+	"█", "#", "░", "-", "┃", "|", "│", "|", "─", "-",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+)
+
+// asciiOutput writes to a console without Unicode glyphs, replacing them
+// with ASCII ones. It embeds the file so Bubble Tea still sees a terminal.
+type asciiOutput struct {
+	*os.File
+}
+
+func (o asciiOutput) Write(p []byte) (int, error) {
+	if _, err := o.File.WriteString(asciiGlyphs.Replace(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// programOptions returns the options of a Bubble Tea program on this
+// console: the given ones, plus an output replacing the glyphs it lacks.
+func (c consoleCaps) programOptions(opts ...tea.ProgramOption) []tea.ProgramOption {
+	if !c.unicode {
+		opts = append(opts, tea.WithOutput(asciiOutput{os.Stdout}))
+	}
+	return opts
+}
+
+// newProgram creates a Bubble Tea program for the console.
+func newProgram(model tea.Model, opts ...tea.ProgramOption) *tea.Program {
+	return tea.NewProgram(model, detectConsole().programOptions(opts...)...)
+}
+
+// border returns the border of the message box.
+func (c consoleCaps) border() lipgloss.Border {
+	if !c.unicode {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// spinner returns the frames of the spinners.
+func (c consoleCaps) spinner() spinner.Spinner {
+	if !c.unicode {
+		return spinner.Line
+	}
+	return spinner.Dot
+}
@@ -0,0 +1,95 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// isASCII reports whether s holds only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAsciiGlyphs_CoverUI(t *testing.T) {
+	keys := DefaultKeyMap()
+	texts := []string{
+		i18n.T("ui.action.help", keyLabel(keys.Up), keyLabel(keys.Down), keyLabel(keys.Select), "1-4", "s", "d", "p", "r", "t", "b", "q"),
+		i18n.T("ui.files.help", keyLabel(keys.Up), keyLabel(keys.Down), keyLabel(keys.Toggle), keyLabel(keys.ToggleAll)),
+		i18n.T("ui.edit.help_vim"),
+		i18n.T("ui.diff.help"),
+		i18n.T("ui.config.help"),
+		i18n.T("ui.config.help_pick"),
+		"› ‹ deepseek › → main.go ████░░░░",
+	}
+	for _, choice := range newActionSelectModel(DefaultKeyMap()).choices {
+		texts = append(texts, choice.icon)
+	}
+
+	for _, text := range texts {
+		if got := asciiGlyphs.Replace(text); !isASCII(got) {
+			t.Errorf("asciiGlyphs left non-ASCII glyphs in %q", got)
+		}
+	}
+}
+
+func TestAsciiOutput(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	text := "↑/↓ to move • Enter to select\n"
+	n, err := asciiOutput{f}.Write([]byte(text))
+	if err != nil || n != len(text) {
+		t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(text))
+	}
+
+	written, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != "^/v to move * Enter to select\n" {
+		t.Errorf("written = %q", written)
+	}
+}
+
+func TestConsoleCaps(t *testing.T) {
+	unicode := consoleCaps{vt: true, unicode: true}
+	legacy := consoleCaps{vt: true}
+
+	if unicode.border() != lipgloss.RoundedBorder() || legacy.border() != lipgloss.ASCIIBorder() {
+		t.Error("the message box should use an ASCII border without Unicode")
+	}
+	if !isASCII(legacy.border().TopLeft + legacy.border().Top) {
+		t.Errorf("ASCII border has non-ASCII glyphs: %+v", legacy.border())
+	}
+
+	if len(unicode.spinner().Frames) != len(spinner.Dot.Frames) {
+		t.Error("spinners should use the dot frames with Unicode")
+	}
+	for _, frame := range legacy.spinner().Frames {
+		if !isASCII(frame) {
+			t.Errorf("spinner frame %q is not ASCII", frame)
+		}
+	}
+
+	if got := len(unicode.programOptions()); got != 0 {
+		t.Errorf("programOptions() = %d options, want none with Unicode", got)
+	}
+	if got := len(legacy.programOptions()); got != 1 {
+		t.Errorf("programOptions() = %d options, want the ASCII output", got)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package ui
+
+// setupConsole returns what the terminal supports. Terminals on other
+// platforms interpret escape sequences and show Unicode.
+func setupConsole() consoleCaps {
+	return consoleCaps{vt: true, unicode: true}
+}
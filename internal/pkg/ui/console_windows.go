@@ -0,0 +1,39 @@
+//go:build windows
+
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// utf8CodePage is the code page identifier of UTF-8.
+const utf8CodePage = 65001
+
+// setupConsole enables virtual terminal processing on the stdout and stderr
+// consoles, so that ANSI escape sequences are interpreted rather than
+// printed. Consoles before Windows 10 refuse it. Unicode glyphs are shown by
+// Windows Terminal and by consoles on the UTF-8 code page; the others show
+// them as question marks.
+func setupConsole() consoleCaps {
+	caps := consoleCaps{vt: true}
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		handle := windows.Handle(f.Fd())
+		var mode uint32
+		if err := windows.GetConsoleMode(handle, &mode); err != nil {
+			// Redirected to a file or pipe
+			continue
+		}
+		if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+			continue
+		}
+		if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil && f == os.Stdout {
+			caps.vt = false
+		}
+	}
+
+	codePage, err := windows.GetConsoleOutputCP()
+	caps.unicode = os.Getenv("WT_SESSION") != "" || (err == nil && codePage == utf8CodePage)
+	return caps
+}
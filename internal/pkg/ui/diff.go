@@ -0,0 +1,138 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// Default pager size used until the terminal reports its dimensions.
+const (
+	defaultDiffWidth  = 80
+	defaultDiffHeight = 24
+)
+
+// diffChromeHeight is the number of lines taken by the pager title and help line.
+const diffChromeHeight = 4
+
+// highlightDiff applies unified diff syntax highlighting line by line.
+// Colors are dropped automatically when the color profile is Ascii.
+func highlightDiff(diff string) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	hunkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
+	addStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	delStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	metaStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			lines[i] = headerStyle.Render(line)
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "),
+			strings.HasPrefix(line, "index "), strings.HasPrefix(line, "new file mode"),
+			strings.HasPrefix(line, "deleted file mode"), strings.HasPrefix(line, "rename "),
+			strings.HasPrefix(line, "similarity index"), strings.HasPrefix(line, "Binary files"):
+			lines[i] = metaStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = addStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = delStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ShowDiff shows the staged diff in a full-screen pager until the user closes it.
+func (m *DefaultManager) ShowDiff(diff string) error {
+	p := newProgram(newDiffViewModel(diff, 0, 0), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to show diff: %w", err)
+	}
+	return nil
+}
+
+// diffViewModel is a scrollable pager for the staged diff.
+type diffViewModel struct {
+	viewport viewport.Model
+	done     bool
+}
+
+func newDiffViewModel(diff string, width, height int) diffViewModel {
+	if width <= 0 {
+		width = defaultDiffWidth
+	}
+	if height <= 0 {
+		height = defaultDiffHeight
+	}
+
+	vp := viewport.New(width, max(height-diffChromeHeight, 1))
+	vp.SetContent(highlightDiff(diff))
+	return diffViewModel{viewport: vp}
+}
+
+// setSize resizes the pager to fit the terminal.
+func (m *diffViewModel) setSize(width, height int) {
+	m.viewport.Width = width
+	m.viewport.Height = max(height-diffChromeHeight, 1)
+}
+
+func (m diffViewModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m diffViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			m.done = true
+			return m, tea.Quit
+		case "g", "home":
+			m.viewport.GotoTop()
+			return m, nil
+		case "G", "end":
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m diffViewModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(i18n.T("ui.diff.title")))
+	sb.WriteString(descStyle.Render(fmt.Sprintf(" (%3.f%%)", m.viewport.ScrollPercent()*100)))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.viewport.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(descStyle.Render(i18n.T("ui.diff.help")))
+
+	return sb.String()
+}
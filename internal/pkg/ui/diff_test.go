@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-func old() {}
++func new() {}
+`
+
+func TestHighlightDiff_PreservesContent(t *testing.T) {
+	withoutColors(t)
+	got := highlightDiff(sampleDiff)
+	if got != strings.TrimRight(sampleDiff, "\n") {
+		t.Errorf("highlightDiff() altered diff text:\n%s", got)
+	}
+}
+
+func TestDiffViewModel(t *testing.T) {
+	t.Run("defaults size until the terminal reports it", func(t *testing.T) {
+		m := newDiffViewModel(sampleDiff, 0, 0)
+		if m.viewport.Width != defaultDiffWidth || m.viewport.Height != defaultDiffHeight-diffChromeHeight {
+			t.Errorf("viewport = %dx%d, want default size", m.viewport.Width, m.viewport.Height)
+		}
+
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+		m = updated.(diffViewModel)
+		if m.viewport.Width != 120 || m.viewport.Height != 40-diffChromeHeight {
+			t.Errorf("viewport = %dx%d, want 120x%d", m.viewport.Width, m.viewport.Height, 40-diffChromeHeight)
+		}
+	})
+
+	t.Run("renders the diff", func(t *testing.T) {
+		m := newDiffViewModel(sampleDiff, 80, 24)
+		view := m.View()
+		if !strings.Contains(view, "Staged Changes") || !strings.Contains(view, "+func new() {}") {
+			t.Errorf("View() missing title or diff content:\n%s", view)
+		}
+	})
+
+	t.Run("G and g jump to bottom and top", func(t *testing.T) {
+		m := newDiffViewModel(strings.Repeat(sampleDiff, 20), 80, 10)
+
+		updated, _ := m.Update(keyMsg("G"))
+		m = updated.(diffViewModel)
+		if !m.viewport.AtBottom() {
+			t.Error("G should scroll to the bottom")
+		}
+
+		updated, _ = m.Update(keyMsg("g"))
+		m = updated.(diffViewModel)
+		if !m.viewport.AtTop() {
+			t.Error("g should scroll to the top")
+		}
+	})
+
+	t.Run("q closes the pager", func(t *testing.T) {
+		m := newDiffViewModel(sampleDiff, 80, 24)
+		updated, cmd := m.Update(keyMsg("q"))
+		m = updated.(diffViewModel)
+		if !m.done || cmd == nil {
+			t.Error("q should close the pager")
+		}
+		if m.View() != "" {
+			t.Error("View() should be empty once closed")
+		}
+	})
+}
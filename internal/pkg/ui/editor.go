@@ -0,0 +1,169 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// editMessageFile is the name of the file external editors open. Editors
+// recognize git's name and highlight the subject length and comments.
+const editMessageFile = "COMMIT_EDITMSG"
+
+// commentChars are the characters that start a guide line, tried in order
+// like git's core.commentChar=auto: the first no message line starts with.
+const commentChars = "#;@!$%^&|:"
+
+// getEditor returns the external editor for messages: the configured one,
+// which the command resolves from ui.editor, $GIT_EDITOR and core.editor,
+// else $VISUAL and $EDITOR, in the order git uses them. Blank values are
+// skipped.
+func (m *DefaultManager) getEditor() string {
+	for _, editor := range []string{m.editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(editor) != "" {
+			return editor
+		}
+	}
+	return ""
+}
+
+// errNoEditor is returned for a blank editor command.
+var errNoEditor = errors.New("no editor command")
+
+// externalEditorCmd returns the command running the editor on the file at path.
+// Like git, the editor is run by the shell, so it may carry arguments such
+// as "code --wait" and be quoted. On Windows, where there is no sh, it is
+// split into arguments at spaces outside double quotes, so a path such as
+// "C:\Program Files\Notepad++\notepad++.exe" -multiInst works.
+func externalEditorCmd(editor, path string) (*exec.Cmd, error) {
+	if strings.TrimSpace(editor) == "" {
+		return nil, errNoEditor
+	}
+	if runtime.GOOS == "windows" {
+		args := splitCommandLine(editor)
+		return exec.Command(args[0], append(args[1:], path)...), nil
+	}
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, path), nil
+}
+
+// splitCommandLine splits a command line into arguments at whitespace outside
+// double quotes, removing the quotes. Backslashes are kept as they are, since
+// they separate the directories of Windows paths.
+func splitCommandLine(line string) []string {
+	var args []string
+	var arg strings.Builder
+	quoted, inArg := false, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted, inArg = !quoted, true
+		case (r == ' ' || r == '\t') && !quoted:
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args
+}
+
+// runExternalEditor writes the message followed by commented guide lines to
+// a temporary COMMIT_EDITMSG file, opens the editor on it with run, and
+// returns the saved message with the comment lines stripped.
+func runExternalEditor(editor, content string, run func(*exec.Cmd) error) (string, error) {
+	dir, err := os.MkdirTemp("", "gitsage-edit-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, editMessageFile)
+	comment := commentChar(content)
+	if err := os.WriteFile(path, []byte(withEditGuide(content, comment)), 0600); err != nil {
+		return "", fmt.Errorf("failed to write to temp file: %w", err)
+	}
+
+	cmd, err := externalEditorCmd(editor, path)
+	if err != nil {
+		return "", err
+	}
+	if err := run(cmd); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return stripComments(string(edited), comment), nil
+}
+
+// commentChar returns the character starting the guide lines: "#" unless a
+// line of the message starts with it, as the "#123" issue footer does.
+func commentChar(content string) string {
+	for _, c := range commentChars {
+		used := false
+		for _, line := range strings.Split(content, "\n") {
+			if strings.HasPrefix(strings.TrimSpace(line), string(c)) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return string(c)
+		}
+	}
+	return "#"
+}
+
+// withEditGuide returns the message followed by the editing guide, each of
+// its lines commented out with comment.
+func withEditGuide(content, comment string) string {
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(content, "\n"))
+	sb.WriteString("\n\n")
+	for _, line := range strings.Split(i18n.T("ui.edit.guide", comment), "\n") {
+		sb.WriteString(strings.TrimRight(comment+" "+line, " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// stripComments cleans up an edited message like "git stripspace
+// --strip-comments": lines starting with comment are removed, trailing
+// whitespace is trimmed, runs of blank lines are collapsed into one and
+// leading and trailing blank lines are dropped.
+func stripComments(text, comment string) string {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, comment) {
+			continue
+		}
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
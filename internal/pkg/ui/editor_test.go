@@ -0,0 +1,150 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommentChar(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"feat: add login", "#"},
+		{"fix: handle empty body\n\n#123", ";"},
+		{"#1\n; note\n@team", "!"},
+	}
+
+	for _, tt := range tests {
+		if got := commentChar(tt.content); got != tt.want {
+			t.Errorf("commentChar(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestWithEditGuide(t *testing.T) {
+	got := withEditGuide("feat: add login\n", "#")
+
+	if !strings.HasPrefix(got, "feat: add login\n\n# ") {
+		t.Errorf("guide should follow the message after a blank line:\n%s", got)
+	}
+	if !strings.Contains(got, "'#'") {
+		t.Errorf("guide should name the comment char:\n%s", got)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n")[2:] {
+		if !strings.HasPrefix(line, "#") {
+			t.Errorf("guide line %q is not commented out", line)
+		}
+	}
+	if stripped := stripComments(got, "#"); stripped != "feat: add login" {
+		t.Errorf("stripComments(withEditGuide()) = %q, want the message back", stripped)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		comment string
+		want    string
+	}{
+		{
+			name:    "comments and blank lines",
+			text:    "\n\nfeat: add login  \n# guide\n\n\n\n- add form\t\n\n# more\n",
+			comment: "#",
+			want:    "feat: add login\n\n- add form",
+		},
+		{
+			name:    "other comment char keeps hash lines",
+			text:    "fix: handle empty body\n\n#123\n; guide\r\n",
+			comment: ";",
+			want:    "fix: handle empty body\n\n#123",
+		},
+		{
+			name:    "only comments",
+			text:    "# guide\n#\n",
+			comment: "#",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripComments(tt.text, tt.comment); got != tt.want {
+				t.Errorf("stripComments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunExternalEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake editor is a shell script")
+	}
+
+	// The fake editor records the file it was given and appends a body line
+	script := filepath.Join(t.TempDir(), "editor")
+	seen := filepath.Join(t.TempDir(), "seen")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncp \"$2\" \""+seen+"\"\nprintf -- '- %s\\n' \"$1\" >> \"$2\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(cmd *exec.Cmd) error { return cmd.Run() }
+	edited, err := runExternalEditor(script+" body", "feat: add login\n\n#42", run)
+	if err != nil {
+		t.Fatalf("runExternalEditor() error = %v", err)
+	}
+	if edited != "feat: add login\n\n#42\n\n- body" {
+		t.Errorf("edited = %q", edited)
+	}
+
+	opened, err := os.ReadFile(seen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(opened), "\n; ") {
+		t.Errorf("guide should use ';' when a line starts with '#':\n%s", opened)
+	}
+
+	failure := errors.New("boom")
+	if _, err := runExternalEditor(script, "feat: x", func(*exec.Cmd) error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("error = %v, want it to wrap the editor failure", err)
+	}
+}
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"notepad", []string{"notepad"}},
+		{"code --wait", []string{"code", "--wait"}},
+		{`"C:\Program Files\Notepad++\notepad++.exe" -multiInst -nosession`, []string{`C:\Program Files\Notepad++\notepad++.exe`, "-multiInst", "-nosession"}},
+		{`  C:\Tools\vim.exe  "" `, []string{`C:\Tools\vim.exe`, ""}},
+		{"   ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := splitCommandLine(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestExternalEditorCmd_Blank(t *testing.T) {
+	for _, editor := range []string{"", "  \t"} {
+		if _, err := externalEditorCmd(editor, "COMMIT_EDITMSG"); !errors.Is(err, errNoEditor) {
+			t.Errorf("externalEditorCmd(%q) error = %v, want errNoEditor", editor, err)
+		}
+	}
+	if _, err := runExternalEditor(" ", "feat: x", func(*exec.Cmd) error { return nil }); !errors.Is(err, errNoEditor) {
+		t.Errorf("runExternalEditor() error = %v, want errNoEditor", err)
+	}
+}
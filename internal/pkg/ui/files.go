@@ -0,0 +1,168 @@
+// Package ui provides user interface components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// FileOption is a file offered for staging.
+type FileOption struct {
+	Path string
+	// Status is a short description shown next to the path, e.g. "untracked".
+	Status string
+	// Selected marks the file as selected initially.
+	Selected bool
+}
+
+// selectedPaths returns the paths of the selected options.
+func selectedPaths(files []FileOption) []string {
+	var paths []string
+	for _, file := range files {
+		if file.Selected {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths
+}
+
+// fileLabel returns the display label of a file option.
+func fileLabel(file FileOption) string {
+	if file.Status == "" {
+		return file.Path
+	}
+	return fmt.Sprintf("%s (%s)", file.Path, file.Status)
+}
+
+// SelectFiles lets the user choose which files to stage. Files start with their
+// suggested selection. Returns nil if the user cancels. If autoAccept is
+// enabled, the suggested selection is returned.
+func (m *DefaultManager) SelectFiles(files []FileOption) ([]string, error) {
+	if m.autoAccept || len(files) == 0 {
+		return selectedPaths(files), nil
+	}
+
+	p := newProgram(newFileSelectModel(files, m.keys))
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	result := finalModel.(fileSelectModel)
+	if result.cancelled {
+		return nil, nil
+	}
+	return selectedPaths(result.files), nil
+}
+
+// fileSelectModel is the Bubble Tea model for choosing files to stage. It
+// also serves as the checklist of body bullets, with its own title and help.
+type fileSelectModel struct {
+	files     []FileOption
+	cursor    int
+	done      bool
+	cancelled bool
+	keys      KeyMap
+	titleKey  string
+	helpKey   string
+}
+
+func newFileSelectModel(files []FileOption, keys KeyMap) fileSelectModel {
+	// Copy so the caller's suggestions are left untouched
+	options := make([]FileOption, len(files))
+	copy(options, files)
+	return fileSelectModel{files: options, keys: keys, titleKey: "ui.files.title", helpKey: "ui.files.help"}
+}
+
+func (m fileSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m fileSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		k := msg.String()
+		switch {
+		case k == "ctrl+c", k == "esc", key.Matches(msg, m.keys.Quit):
+			m.cancelled = true
+			m.done = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.files)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, m.keys.Toggle):
+			if len(m.files) > 0 {
+				m.files[m.cursor].Selected = !m.files[m.cursor].Selected
+			}
+		case key.Matches(msg, m.keys.ToggleAll):
+			// Select all unless everything is selected already
+			all := len(selectedPaths(m.files)) < len(m.files)
+			for i := range m.files {
+				m.files[i].Selected = all
+			}
+		case k == "enter", key.Matches(msg, m.keys.Select):
+			m.done = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m fileSelectModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	selectedStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("212"))
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("252"))
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(i18n.T(m.titleKey)))
+	sb.WriteString("\n\n")
+
+	for i, file := range m.files {
+		cursor := "  "
+		style := normalStyle
+		if m.cursor == i {
+			cursor = "▸ "
+			style = selectedStyle
+		}
+
+		check := "[ ]"
+		if file.Selected {
+			check = "[x]"
+		}
+		sb.WriteString(fmt.Sprintf("%s%s %s\n", cursor, check, style.Render(fileLabel(file))))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(descStyle.Render(i18n.T(
+		m.helpKey,
+		keyLabel(m.keys.Up), keyLabel(m.keys.Down), keyLabel(m.keys.Toggle), keyLabel(m.keys.ToggleAll),
+	)))
+
+	return sb.String()
+}
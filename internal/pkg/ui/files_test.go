@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testFileOptions() []FileOption {
+	return []FileOption{
+		{Path: "main.go", Status: "modified", Selected: true},
+		{Path: "notes.txt~", Status: "untracked"},
+	}
+}
+
+func TestFileSelectModel(t *testing.T) {
+	withoutColors(t)
+
+	t.Run("enter confirms the suggested selection", func(t *testing.T) {
+		m := newFileSelectModel(testFileOptions(), DefaultKeyMap())
+		if !strings.Contains(m.View(), "[x] main.go (modified)") {
+			t.Errorf("View() should mark preselected files:\n%s", m.View())
+		}
+
+		updated, _ := m.Update(keyMsg("enter"))
+		result := updated.(fileSelectModel)
+		if !result.done || result.cancelled {
+			t.Fatal("enter should confirm the selection")
+		}
+		if got := selectedPaths(result.files); !reflect.DeepEqual(got, []string{"main.go"}) {
+			t.Errorf("selected = %v, want [main.go]", got)
+		}
+	})
+
+	t.Run("space toggles the highlighted file", func(t *testing.T) {
+		options := testFileOptions()
+		m := newFileSelectModel(options, DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("j"))
+		updated, _ = updated.Update(keyMsg(" "))
+		updated, _ = updated.Update(keyMsg("k"))
+		updated, _ = updated.Update(keyMsg("x"))
+
+		if got := selectedPaths(updated.(fileSelectModel).files); !reflect.DeepEqual(got, []string{"notes.txt~"}) {
+			t.Errorf("selected = %v, want [notes.txt~]", got)
+		}
+		if options[0].Selected != true || options[1].Selected != false {
+			t.Error("the caller's options should be left untouched")
+		}
+	})
+
+	t.Run("toggle all selects then clears everything", func(t *testing.T) {
+		m := newFileSelectModel(testFileOptions(), DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("a"))
+		if got := selectedPaths(updated.(fileSelectModel).files); len(got) != 2 {
+			t.Errorf("selected = %v, want all files", got)
+		}
+		updated, _ = updated.Update(keyMsg("a"))
+		if got := selectedPaths(updated.(fileSelectModel).files); len(got) != 0 {
+			t.Errorf("selected = %v, want none", got)
+		}
+	})
+
+	t.Run("esc cancels", func(t *testing.T) {
+		m := newFileSelectModel(testFileOptions(), DefaultKeyMap())
+		updated, _ := m.Update(keyMsg("esc"))
+		if !updated.(fileSelectModel).cancelled {
+			t.Error("esc should cancel")
+		}
+	})
+}
+
+func TestNonInteractiveManager_SelectFiles(t *testing.T) {
+	m := NewNonInteractiveManager(false)
+	selected, err := m.SelectFiles(testFileOptions())
+	if err != nil || !reflect.DeepEqual(selected, []string{"main.go"}) {
+		t.Errorf("SelectFiles() = %v, %v; want [main.go], nil", selected, err)
+	}
+}
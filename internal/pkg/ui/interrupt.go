@@ -0,0 +1,66 @@
+// Package ui provides terminal user interface components for GitSage.
+package ui
+
+import "sync"
+
+var (
+	interruptMu      sync.Mutex
+	interruptHandler func()
+)
+
+// SetInterruptHandler registers the function called when the user presses
+// Ctrl+C while a spinner or idle session owns the terminal. Bubble Tea puts
+// the terminal in raw mode, so the key never reaches the process as SIGINT;
+// the handler is how the command learns it should cancel. Pass nil to clear it.
+func SetInterruptHandler(handler func()) {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+	interruptHandler = handler
+}
+
+// notifyInterrupt calls the registered interrupt handler, if any.
+func notifyInterrupt() {
+	interruptMu.Lock()
+	handler := interruptHandler
+	interruptMu.Unlock()
+
+	if handler != nil {
+		handler()
+	}
+}
+
+var (
+	stopMu      sync.Mutex
+	stopHandler func()
+)
+
+// SetStopHandler registers the function called when the user presses "s"
+// while a progress spinner is shown, asking the work in progress to finish
+// early with what it has. Progress spinners only offer the key while a
+// handler is registered. Pass nil to clear it.
+func SetStopHandler(handler func()) {
+	stopMu.Lock()
+	defer stopMu.Unlock()
+	stopHandler = handler
+}
+
+// stopAvailable reports whether a stop handler is registered.
+func stopAvailable() bool {
+	stopMu.Lock()
+	defer stopMu.Unlock()
+	return stopHandler != nil
+}
+
+// notifyStop calls the registered stop handler, if any, and reports whether
+// there was one.
+func notifyStop() bool {
+	stopMu.Lock()
+	handler := stopHandler
+	stopMu.Unlock()
+
+	if handler == nil {
+		return false
+	}
+	handler()
+	return true
+}
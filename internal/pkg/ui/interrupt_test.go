@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSpinnerCtrlCNotifiesInterrupt(t *testing.T) {
+	called := 0
+	SetInterruptHandler(func() { called++ })
+	defer SetInterruptHandler(nil)
+
+	model, cmd := spinnerModel{}.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if called != 1 {
+		t.Errorf("interrupt handler called %d times, want 1", called)
+	}
+	if !model.(spinnerModel).quitting || cmd == nil {
+		t.Error("spinner should quit on Ctrl+C")
+	}
+}
+
+func TestNotifyInterruptWithoutHandler(t *testing.T) {
+	SetInterruptHandler(nil)
+	notifyInterrupt() // must not panic
+}
+
+func TestProgressStopKey(t *testing.T) {
+	sKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}
+
+	// Without a handler the key does nothing
+	SetStopHandler(nil)
+	model, _ := progressModel{}.Update(sKey)
+	if model.(progressModel).stopping {
+		t.Error("progress should not stop without a stop handler")
+	}
+
+	called := 0
+	SetStopHandler(func() { called++ })
+	defer SetStopHandler(nil)
+
+	model, cmd := progressModel{}.Update(sKey)
+	model, _ = model.Update(sKey)
+	if called != 1 {
+		t.Errorf("stop handler called %d times, want 1", called)
+	}
+	if !model.(progressModel).stopping || model.(progressModel).quitting || cmd != nil {
+		t.Error("progress should keep running and show that it is stopping")
+	}
+}
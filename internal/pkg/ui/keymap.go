@@ -0,0 +1,150 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// KeyMap defines the key bindings used by the action selector and the prompts.
+// Ctrl+C always cancels regardless of the configured bindings.
+type KeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Select key.Binding
+	Quit   key.Binding
+
+	// File selection toggles
+	Toggle    key.Binding
+	ToggleAll key.Binding
+
+	// Quick select keys of the action selector
+	Accept      key.Binding
+	Edit        key.Binding
+	EditSubject key.Binding
+	Regenerate  key.Binding
+	Cancel      key.Binding
+	ViewDiff    key.Binding
+	PickAttempt key.Binding
+	Refresh     key.Binding
+	Translate   key.Binding
+	PickBullets key.Binding
+
+	// Confirm prompt answers
+	Yes key.Binding
+	No  key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:          key.NewBinding(key.WithKeys("up", "k")),
+		Down:        key.NewBinding(key.WithKeys("down", "j")),
+		Left:        key.NewBinding(key.WithKeys("left", "h")),
+		Right:       key.NewBinding(key.WithKeys("right", "l")),
+		Select:      key.NewBinding(key.WithKeys("enter", " ")),
+		Quit:        key.NewBinding(key.WithKeys("q")),
+		Toggle:      key.NewBinding(key.WithKeys(" ", "x")),
+		ToggleAll:   key.NewBinding(key.WithKeys("a")),
+		Accept:      key.NewBinding(key.WithKeys("1")),
+		Edit:        key.NewBinding(key.WithKeys("2")),
+		EditSubject: key.NewBinding(key.WithKeys("s")),
+		Regenerate:  key.NewBinding(key.WithKeys("3")),
+		Cancel:      key.NewBinding(key.WithKeys("4")),
+		ViewDiff:    key.NewBinding(key.WithKeys("d")),
+		PickAttempt: key.NewBinding(key.WithKeys("p")),
+		Refresh:     key.NewBinding(key.WithKeys("r")),
+		Translate:   key.NewBinding(key.WithKeys("t")),
+		PickBullets: key.NewBinding(key.WithKeys("b")),
+		Yes:         key.NewBinding(key.WithKeys("y", "Y")),
+		No:          key.NewBinding(key.WithKeys("n")),
+	}
+}
+
+// NewKeyMap returns the default key map with the given overrides applied.
+// Override names match the ui.keybindings config keys (e.g. "accept", "view_diff");
+// each override replaces all default keys of that binding.
+func NewKeyMap(overrides map[string][]string) (KeyMap, error) {
+	keys := DefaultKeyMap()
+	bindings := keys.byName()
+
+	for name, values := range overrides {
+		binding, ok := bindings[strings.ToLower(name)]
+		if !ok {
+			return keys, fmt.Errorf("unknown key binding %q (valid: %s)", name, strings.Join(keyBindingNames(), ", "))
+		}
+		if len(values) == 0 {
+			return keys, fmt.Errorf("key binding %q must have at least one key", name)
+		}
+		binding.SetKeys(values...)
+	}
+
+	return keys, nil
+}
+
+// byName returns the bindings keyed by their config name.
+func (k *KeyMap) byName() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":           &k.Up,
+		"down":         &k.Down,
+		"left":         &k.Left,
+		"right":        &k.Right,
+		"select":       &k.Select,
+		"quit":         &k.Quit,
+		"toggle":       &k.Toggle,
+		"toggle_all":   &k.ToggleAll,
+		"accept":       &k.Accept,
+		"edit":         &k.Edit,
+		"edit_subject": &k.EditSubject,
+		"regenerate":   &k.Regenerate,
+		"cancel":       &k.Cancel,
+		"view_diff":    &k.ViewDiff,
+		"pick_attempt": &k.PickAttempt,
+		"refresh":      &k.Refresh,
+		"translate":    &k.Translate,
+		"pick_bullets": &k.PickBullets,
+		"yes":          &k.Yes,
+		"no":           &k.No,
+	}
+}
+
+// keyBindingNames returns the sorted config names of all bindings.
+func keyBindingNames() []string {
+	var k KeyMap
+	names := make([]string, 0, len(k.byName()))
+	for name := range k.byName() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyLabel returns a short human readable label for a binding, e.g. "↑/k".
+func keyLabel(b key.Binding) string {
+	labels := make([]string, 0, len(b.Keys()))
+	for _, k := range b.Keys() {
+		switch k {
+		case "up":
+			k = "↑"
+		case "down":
+			k = "↓"
+		case "left":
+			k = "←"
+		case "right":
+			k = "→"
+		case "enter":
+			k = "Enter"
+		case " ":
+			k = "Space"
+		case "esc":
+			k = "Esc"
+		}
+		labels = append(labels, k)
+	}
+	return strings.Join(labels, "/")
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+func TestNewKeyMap(t *testing.T) {
+	t.Run("no overrides returns defaults", func(t *testing.T) {
+		keys, err := NewKeyMap(nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !key.Matches(keyMsg("1"), keys.Accept) || !key.Matches(keyMsg("k"), keys.Up) {
+			t.Error("default bindings should be kept")
+		}
+	})
+
+	t.Run("override replaces default keys", func(t *testing.T) {
+		keys, err := NewKeyMap(map[string][]string{"accept": {"a"}, "VIEW_DIFF": {"v"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !key.Matches(keyMsg("a"), keys.Accept) || key.Matches(keyMsg("1"), keys.Accept) {
+			t.Errorf("accept keys = %v, want [a]", keys.Accept.Keys())
+		}
+		if !key.Matches(keyMsg("v"), keys.ViewDiff) {
+			t.Errorf("view_diff keys = %v, want [v]", keys.ViewDiff.Keys())
+		}
+	})
+
+	t.Run("unknown binding", func(t *testing.T) {
+		_, err := NewKeyMap(map[string][]string{"launch": {"x"}})
+		if err == nil || !strings.Contains(err.Error(), "unknown key binding") {
+			t.Errorf("err = %v, want unknown key binding error", err)
+		}
+	})
+
+	t.Run("empty binding", func(t *testing.T) {
+		_, err := NewKeyMap(map[string][]string{"accept": {}})
+		if err == nil {
+			t.Error("expected error for empty binding")
+		}
+	})
+}
+
+func TestKeyLabel(t *testing.T) {
+	if got := keyLabel(key.NewBinding(key.WithKeys("up", "k"))); got != "↑/k" {
+		t.Errorf("keyLabel() = %q, want %q", got, "↑/k")
+	}
+	if got := keyLabel(key.NewBinding(key.WithKeys("enter", " "))); got != "Enter/Space" {
+		t.Errorf("keyLabel() = %q, want %q", got, "Enter/Space")
+	}
+}
+
+func TestActionSelectModel_CustomKeys(t *testing.T) {
+	keys, err := NewKeyMap(map[string][]string{"accept": {"a"}, "down": {"n"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := newActionSelectModel(keys)
+	if !strings.Contains(m.View(), "↑/k n to move") {
+		t.Errorf("help line should reflect configured keys:\n%s", m.View())
+	}
+
+	// Default "j" no longer moves the cursor
+	updated, _ := m.Update(keyMsg("j"))
+	if updated.(actionSelectModel).cursor != 0 {
+		t.Error("unbound key should not move the cursor")
+	}
+
+	updated, _ = m.Update(keyMsg("a"))
+	if result := updated.(actionSelectModel); !result.done || result.selected != ActionAccept {
+		t.Errorf("selected = %v, want accept", result.selected)
+	}
+}
+
+func TestActionSelectModel_Refresh(t *testing.T) {
+	m := newActionSelectModel(DefaultKeyMap())
+	if !strings.Contains(m.View(), "r refresh") {
+		t.Errorf("help line should list the refresh key:\n%s", m.View())
+	}
+
+	updated, _ := m.Update(keyMsg("r"))
+	if result := updated.(actionSelectModel); !result.done || result.selected != ActionRefresh {
+		t.Errorf("selected = %v, want refresh", result.selected)
+	}
+}
+
+func TestActionSelectModel_Translate(t *testing.T) {
+	m := newActionSelectModel(DefaultKeyMap())
+	if !strings.Contains(m.View(), "t translate") {
+		t.Errorf("help line should list the translate key:\n%s", m.View())
+	}
+
+	updated, _ := m.Update(keyMsg("t"))
+	if result := updated.(actionSelectModel); !result.done || result.selected != ActionTranslate {
+		t.Errorf("selected = %v, want translate", result.selected)
+	}
+}
+
+func TestConfirmModel_CustomKeys(t *testing.T) {
+	keys, err := NewKeyMap(map[string][]string{"yes": {"o"}, "no": {"x"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m := newConfirmModel("Continue?", keys)
+	updated, _ := m.Update(keyMsg("y"))
+	if updated.(confirmModel).done {
+		t.Error("default y should be unbound")
+	}
+
+	updated, _ = m.Update(keyMsg("o"))
+	if result := updated.(confirmModel); !result.done || !result.confirmed {
+		t.Error("configured yes key should confirm")
+	}
+
+	// Ctrl+C always cancels
+	updated, _ = m.Update(keyMsg("ctrl+c"))
+	if result := updated.(confirmModel); !result.done || result.confirmed {
+		t.Error("ctrl+c should always cancel")
+	}
+}
@@ -0,0 +1,196 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+// logStatWidth is the width git gives the --stat block when the output is
+// not a terminal, and the width the preview is laid out in.
+const logStatWidth = 80
+
+// logDateLayout is git's default date format.
+const logDateLayout = "Mon Jan 2 15:04:05 2006 -0700"
+
+// CommitPreview is a commit that is about to be made.
+type CommitPreview struct {
+	Author  git.Ident
+	Message string
+	Stats   *git.DiffStats
+}
+
+// FormatCommitPreview renders the commit as "git log -1 --stat" would show it
+// once made. The commit has no hash yet, so the header says it is pending.
+func FormatCommitPreview(p CommitPreview) string {
+	var sb strings.Builder
+	sb.WriteString("commit (pending)\n")
+	fmt.Fprintf(&sb, "Author: %s <%s>\n", p.Author.Name, p.Author.Email)
+	fmt.Fprintf(&sb, "Date:   %s\n", p.Author.When.Format(logDateLayout))
+	sb.WriteString("\n")
+	// Like git, blank lines of the message are indented too
+	for _, line := range strings.Split(strings.TrimRight(p.Message, "\n"), "\n") {
+		sb.WriteString("    " + line + "\n")
+	}
+	if p.Stats != nil {
+		sb.WriteString("\n")
+		sb.WriteString(FormatDiffStat(p.Stats.Chunks, logStatWidth))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// FormatDiffStat renders the chunks like "git diff --stat" in the given
+// width: one line per file with its graph of changes, then the summary. Names
+// and graphs are shortened as git does when the lines do not fit.
+func FormatDiffStat(chunks []git.DiffChunk, width int) string {
+	names := make([]string, len(chunks))
+	maxLen, maxChange, binary := 0, 0, false
+	insertions, deletions := 0, 0
+	for i, chunk := range chunks {
+		names[i] = chunk.FilePath
+		if chunk.OldPath != "" && chunk.OldPath != chunk.FilePath {
+			names[i] = renameLabel(chunk.OldPath, chunk.FilePath)
+		}
+		maxLen = max(maxLen, lipgloss.Width(names[i]))
+		if chunk.IsBinary {
+			binary = true
+			continue
+		}
+		maxChange = max(maxChange, chunk.Additions+chunk.Deletions)
+		insertions += chunk.Additions
+		deletions += chunk.Deletions
+	}
+
+	numberWidth := len(strconv.Itoa(maxChange))
+	if binary {
+		numberWidth = max(numberWidth, len("Bin"))
+	}
+
+	// Give the graph at most 3/8 of the width, and the names the rest
+	nameWidth, graphWidth := maxLen, maxChange
+	if nameWidth+numberWidth+6+graphWidth > width {
+		if limit := width*3/8 - numberWidth - 6; graphWidth > limit {
+			graphWidth = max(limit, 6)
+		}
+		if rest := width - numberWidth - 6 - graphWidth; nameWidth > rest {
+			nameWidth = rest
+		} else {
+			graphWidth = width - numberWidth - 6 - nameWidth
+		}
+	}
+
+	var sb strings.Builder
+	for i, chunk := range chunks {
+		sb.WriteString(" " + truncateStatName(names[i], nameWidth) + " | ")
+		if chunk.IsBinary {
+			fmt.Fprintf(&sb, "%*s\n", numberWidth, "Bin")
+			continue
+		}
+		added, deleted := chunk.Additions, chunk.Deletions
+		fmt.Fprintf(&sb, "%*d", numberWidth, added+deleted)
+		if added+deleted > 0 {
+			if graphWidth <= maxChange {
+				total := scaleStat(added+deleted, graphWidth, maxChange)
+				if total < 2 && added > 0 && deleted > 0 {
+					total = 2
+				}
+				if added < deleted {
+					added = scaleStat(added, graphWidth, maxChange)
+					deleted = total - added
+				} else {
+					deleted = scaleStat(deleted, graphWidth, maxChange)
+					added = total - deleted
+				}
+			}
+			sb.WriteString(" " + strings.Repeat("+", added) + strings.Repeat("-", deleted))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(diffStatSummary(len(chunks), insertions, deletions))
+	return sb.String()
+}
+
+// truncateStatName pads the name to width, or cuts its start to fit,
+// continuing after "..." from the next directory where possible.
+func truncateStatName(name string, width int) string {
+	prefix := ""
+	if lipgloss.Width(name) > width {
+		prefix = "..."
+		keep := max(width-len(prefix), 0)
+		runes := []rune(name)
+		for len(runes) > 0 && lipgloss.Width(string(runes)) > keep {
+			runes = runes[1:]
+		}
+		name = string(runes)
+		if slash := strings.Index(name, "/"); slash >= 0 {
+			name = name[slash:]
+		}
+		width -= len(prefix)
+	}
+	return prefix + name + strings.Repeat(" ", max(width-lipgloss.Width(name), 0))
+}
+
+// renameLabel shows a rename as git does, factoring out the common leading
+// and trailing directories, e.g. "pkg/{old => new}/file.go".
+func renameLabel(oldPath, newPath string) string {
+	// Common prefix, up to and including its last slash
+	pfx := 0
+	for i := 0; i < len(oldPath) && i < len(newPath) && oldPath[i] == newPath[i]; i++ {
+		if oldPath[i] == '/' {
+			pfx = i + 1
+		}
+	}
+
+	// Common suffix, from a slash, not overlapping the prefix
+	sfx := 0
+	for i, j := len(oldPath)-1, len(newPath)-1; i >= pfx && j >= pfx && oldPath[i] == newPath[j]; i, j = i-1, j-1 {
+		if oldPath[i] == '/' {
+			sfx = len(oldPath) - i
+		}
+	}
+
+	if pfx+sfx == 0 {
+		return oldPath + " => " + newPath
+	}
+	oldMid := oldPath[pfx:max(len(oldPath)-sfx, pfx)]
+	newMid := newPath[pfx:max(len(newPath)-sfx, pfx)]
+	return oldPath[:pfx] + "{" + oldMid + " => " + newMid + "}" + oldPath[len(oldPath)-sfx:]
+}
+
+// scaleStat scales a number of changed lines to a graph of the width, where
+// maxChange fills it. Any change gets at least one column.
+func scaleStat(lines, width, maxChange int) int {
+	if lines == 0 {
+		return 0
+	}
+	return 1 + lines*(width-1)/maxChange
+}
+
+// diffStatSummary returns git's summary line, e.g.
+// " 2 files changed, 10 insertions(+), 3 deletions(-)".
+func diffStatSummary(files, insertions, deletions int) string {
+	if files == 0 {
+		return " 0 files changed"
+	}
+	summary := fmt.Sprintf(" %d %s changed", files, plural(files, "file"))
+	if insertions > 0 || deletions == 0 {
+		summary += fmt.Sprintf(", %d %s(+)", insertions, plural(insertions, "insertion"))
+	}
+	if deletions > 0 || insertions == 0 {
+		summary += fmt.Sprintf(", %d %s(-)", deletions, plural(deletions, "deletion"))
+	}
+	return summary
+}
+
+// plural returns the word, with an "s" unless n is 1.
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
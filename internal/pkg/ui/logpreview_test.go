@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+)
+
+func TestFormatDiffStat(t *testing.T) {
+	// Laid out as "git log --stat" shows the same changes
+	chunks := []git.DiffChunk{
+		{FilePath: "bin.dat", IsBinary: true},
+		{FilePath: "docs/a.md", Additions: 2, Deletions: 2},
+		{FilePath: "internal/pkg/verylongdirectoryname/anotherlongdirectory/some_really_long_file_name_here.go", Additions: 20, Deletions: 50},
+		{FilePath: "lib/sub/old.txt", OldPath: "old.txt", ChangeType: git.ChangeTypeRenamed},
+	}
+	want := "" +
+		" bin.dat                                            | Bin\n" +
+		" docs/a.md                                          |   4 +-\n" +
+		" .../some_really_long_file_name_here.go             |  70 ++++++---------------\n" +
+		" old.txt => lib/sub/old.txt                         |   0\n" +
+		" 4 files changed, 22 insertions(+), 52 deletions(-)"
+
+	if got := FormatDiffStat(chunks, logStatWidth); got != want {
+		t.Errorf("FormatDiffStat() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatDiffStat_Summary(t *testing.T) {
+	tests := []struct {
+		chunks []git.DiffChunk
+		want   string
+	}{
+		{[]git.DiffChunk{{FilePath: "a.go", Additions: 1}}, " a.go | 1 +\n 1 file changed, 1 insertion(+)"},
+		{[]git.DiffChunk{{FilePath: "a.go", Deletions: 3}}, " a.go | 3 ---\n 1 file changed, 3 deletions(-)"},
+		{nil, " 0 files changed"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDiffStat(tt.chunks, logStatWidth); got != tt.want {
+			t.Errorf("FormatDiffStat() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRenameLabel(t *testing.T) {
+	tests := []struct {
+		oldPath, newPath, want string
+	}{
+		{"pkg/a/file.go", "pkg/b/file.go", "pkg/{a => b}/file.go"},
+		{"pkg/old.go", "pkg/new.go", "pkg/{old.go => new.go}"},
+		{"a/file.go", "file.go", "a/file.go => file.go"},
+		{"old.txt", "new.txt", "old.txt => new.txt"},
+	}
+
+	for _, tt := range tests {
+		if got := renameLabel(tt.oldPath, tt.newPath); got != tt.want {
+			t.Errorf("renameLabel(%q, %q) = %q, want %q", tt.oldPath, tt.newPath, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCommitPreview(t *testing.T) {
+	got := FormatCommitPreview(CommitPreview{
+		Author:  git.Ident{Name: "Jane Doe", Email: "jane@example.com", When: time.Date(2026, 10, 15, 17, 7, 12, 0, time.UTC)},
+		Message: "feat: add login\n\nUse tokens.",
+		Stats:   &git.DiffStats{Chunks: []git.DiffChunk{{FilePath: "auth.go", Additions: 2}}},
+	})
+	want := "commit (pending)\n" +
+		"Author: Jane Doe <jane@example.com>\n" +
+		"Date:   Thu Oct 15 17:07:12 2026 +0000\n" +
+		"\n" +
+		"    feat: add login\n" +
+		"    \n" +
+		"    Use tokens.\n" +
+		"\n" +
+		" auth.go | 2 ++\n" +
+		" 1 file changed, 2 insertions(+)"
+
+	if got != want {
+		t.Errorf("FormatCommitPreview() =\n%s\nwant\n%s", got, want)
+	}
+}
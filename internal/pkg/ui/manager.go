@@ -7,8 +7,8 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
-	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,6 +16,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 )
 
 // Action represents a user action in the interactive UI.
@@ -26,6 +27,12 @@ const (
 	ActionEdit
 	ActionRegenerate
 	ActionCancel
+	ActionViewDiff
+	ActionPickAttempt
+	ActionRefresh
+	ActionTranslate
+	ActionEditSubject
+	ActionPickBullets
 )
 
 // String returns the string representation of an Action.
@@ -39,6 +46,18 @@ func (a Action) String() string {
 		return "regenerate"
 	case ActionCancel:
 		return "cancel"
+	case ActionViewDiff:
+		return "view_diff"
+	case ActionPickAttempt:
+		return "pick_attempt"
+	case ActionRefresh:
+		return "refresh"
+	case ActionTranslate:
+		return "translate"
+	case ActionEditSubject:
+		return "edit_subject"
+	case ActionPickBullets:
+		return "pick_bullets"
 	default:
 		return "unknown"
 	}
@@ -64,19 +83,30 @@ type Manager interface {
 	DisplayMessage(message *ai.GenerateResponse) error
 	PromptAction() (Action, error)
 	EditMessage(message *ai.GenerateResponse) (*ai.GenerateResponse, error)
+	EditSubject(subject string) (string, error)
 	ShowSpinner(text string) Spinner
 	ShowProgressSpinner(text string, total int) ProgressSpinner
 	ShowError(err error)
 	ShowSuccess(message string)
+	ShowInfo(message string)
 	PromptConfirm(message string) (bool, error)
+	ShowDiff(diff string) error
+	DisplayComparison(previous, current *ai.GenerateResponse) error
+	SelectAttempt(attempts []*ai.GenerateResponse) (int, error)
+	SelectCandidate(candidates []Candidate) (int, error)
+	SelectFiles(files []FileOption) ([]string, error)
+	SelectBullets(bullets []string) ([]bool, error)
 }
 
 // DefaultManager implements the Manager interface using charmbracelet libraries.
 type DefaultManager struct {
-	colorEnabled bool
-	editor       string
-	autoAccept   bool
-	styles       *styles
+	colorEnabled  bool
+	editor        string
+	autoAccept    bool
+	plainProgress bool
+	keys          KeyMap
+	vimMode       bool
+	styles        *styles
 }
 
 // styles holds the lipgloss styles for UI rendering.
@@ -89,15 +119,19 @@ type styles struct {
 	errorStyle lipgloss.Style
 	info       lipgloss.Style
 	border     lipgloss.Style
+	added      lipgloss.Style
+	removed    lipgloss.Style
 }
 
 // NewDefaultManager creates a new DefaultManager with the specified options.
 // If autoAccept is true, prompts will automatically accept without user input.
 func NewDefaultManager(colorEnabled bool, editor string, autoAccept bool) *DefaultManager {
 	m := &DefaultManager{
-		colorEnabled: colorEnabled,
-		editor:       editor,
-		autoAccept:   autoAccept,
+		colorEnabled:  colorEnabled,
+		editor:        editor,
+		autoAccept:    autoAccept,
+		plainProgress: usePlainProgress(),
+		keys:          DefaultKeyMap(),
 	}
 	m.initStyles()
 	return m
@@ -115,6 +149,8 @@ func (m *DefaultManager) initStyles() {
 			errorStyle: lipgloss.NewStyle(),
 			info:       lipgloss.NewStyle(),
 			border:     lipgloss.NewStyle(),
+			added:      lipgloss.NewStyle(),
+			removed:    lipgloss.NewStyle(),
 		}
 		return
 	}
@@ -141,10 +177,16 @@ func (m *DefaultManager) initStyles() {
 		info: lipgloss.NewStyle().
 			Foreground(lipgloss.Color("39")),
 		border: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(detectConsole().border()).
 			BorderForeground(lipgloss.Color("62")).
 			Padding(1, 2).
 			Width(80),
+		added: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("42")).
+			Underline(true),
+		removed: lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196")).
+			Strikethrough(true),
 	}
 }
 
@@ -154,9 +196,19 @@ func (m *DefaultManager) DisplayMessage(message *ai.GenerateResponse) error {
 		return fmt.Errorf("message cannot be nil")
 	}
 
-	fmt.Println()
-	fmt.Println(m.styles.title.Render("Generated Commit Message"))
-	fmt.Println(strings.Repeat("-", 50))
+	fmt.Println(m.renderMessage(message))
+	return nil
+}
+
+// renderMessage renders the commit message block shown to the user.
+func (m *DefaultManager) renderMessage(message *ai.GenerateResponse) string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString(m.styles.title.Render(i18n.T("ui.message.title")))
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("-", 50))
+	sb.WriteString("\n")
 
 	// Subject line
 	subject := message.Subject
@@ -167,24 +219,27 @@ func (m *DefaultManager) DisplayMessage(message *ai.GenerateResponse) error {
 			subject = lines[0]
 		}
 	}
-	fmt.Println(m.styles.subject.Render(subject))
+	sb.WriteString(m.styles.subject.Render(subject))
+	sb.WriteString("\n")
 
 	// Body
 	if message.Body != "" {
-		fmt.Println()
-		fmt.Println(m.styles.body.Render(message.Body))
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.body.Render(message.Body))
+		sb.WriteString("\n")
 	}
 
 	// Footer
 	if message.Footer != "" {
-		fmt.Println()
-		fmt.Println(m.styles.footer.Render(message.Footer))
+		sb.WriteString("\n")
+		sb.WriteString(m.styles.footer.Render(message.Footer))
+		sb.WriteString("\n")
 	}
 
-	fmt.Println(strings.Repeat("-", 50))
-	fmt.Println()
+	sb.WriteString(strings.Repeat("-", 50))
+	sb.WriteString("\n")
 
-	return nil
+	return sb.String()
 }
 
 // PromptAction prompts the user to select an action using Bubble Tea.
@@ -194,8 +249,8 @@ func (m *DefaultManager) PromptAction() (Action, error) {
 		return ActionAccept, nil
 	}
 
-	model := newActionSelectModel()
-	p := tea.NewProgram(model)
+	model := newActionSelectModel(m.keys)
+	p := newProgram(model)
 
 	finalModel, err := p.Run()
 	if err != nil {
@@ -212,6 +267,7 @@ type actionSelectModel struct {
 	cursor   int
 	selected Action
 	done     bool
+	keys     KeyMap
 }
 
 type actionChoice struct {
@@ -221,16 +277,23 @@ type actionChoice struct {
 	desc   string
 }
 
-func newActionSelectModel() actionSelectModel {
+func newActionSelectModel(keys KeyMap) actionSelectModel {
 	return actionSelectModel{
 		choices: []actionChoice{
-			{ActionAccept, "Accept", "›", "Commit with this message"},
-			{ActionEdit, "Edit", "•", "Modify the message"},
-			{ActionRegenerate, "Regenerate", "↻", "Generate a new message"},
-			{ActionCancel, "Cancel", "×", "Abort without committing"},
+			{ActionAccept, i18n.T("ui.action.accept"), "›", i18n.T("ui.action.accept.desc")},
+			{ActionEdit, i18n.T("ui.action.edit"), "•", i18n.T("ui.action.edit.desc")},
+			{ActionEditSubject, i18n.T("ui.action.edit_subject"), "✎", i18n.T("ui.action.edit_subject.desc")},
+			{ActionRegenerate, i18n.T("ui.action.regenerate"), "↻", i18n.T("ui.action.regenerate.desc")},
+			{ActionViewDiff, i18n.T("ui.action.view_diff"), "±", i18n.T("ui.action.view_diff.desc")},
+			{ActionPickAttempt, i18n.T("ui.action.pick_attempt"), "⟲", i18n.T("ui.action.pick_attempt.desc")},
+			{ActionRefresh, i18n.T("ui.action.refresh"), "⇅", i18n.T("ui.action.refresh.desc")},
+			{ActionTranslate, i18n.T("ui.action.translate"), "⇄", i18n.T("ui.action.translate.desc")},
+			{ActionPickBullets, i18n.T("ui.action.pick_bullets"), "☰", i18n.T("ui.action.pick_bullets.desc")},
+			{ActionCancel, i18n.T("ui.action.cancel"), "×", i18n.T("ui.action.cancel.desc")},
 		},
 		cursor:   0,
 		selected: ActionCancel,
+		keys:     keys,
 	}
 }
 
@@ -241,44 +304,53 @@ func (m actionSelectModel) Init() tea.Cmd {
 func (m actionSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			m.selected = ActionCancel
-			m.done = true
-			return m, tea.Quit
-		case "up", "k":
+		if msg.String() == "ctrl+c" || key.Matches(msg, m.keys.Quit) {
+			return m.choose(ActionCancel)
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Up):
 			if m.cursor > 0 {
 				m.cursor--
 			}
-		case "down", "j":
+		case key.Matches(msg, m.keys.Down):
 			if m.cursor < len(m.choices)-1 {
 				m.cursor++
 			}
-		case "enter", " ":
-			m.selected = m.choices[m.cursor].action
-			m.done = true
-			return m, tea.Quit
-		case "1":
-			m.selected = ActionAccept
-			m.done = true
-			return m, tea.Quit
-		case "2":
-			m.selected = ActionEdit
-			m.done = true
-			return m, tea.Quit
-		case "3":
-			m.selected = ActionRegenerate
-			m.done = true
-			return m, tea.Quit
-		case "4":
-			m.selected = ActionCancel
-			m.done = true
-			return m, tea.Quit
+		case key.Matches(msg, m.keys.Select):
+			return m.choose(m.choices[m.cursor].action)
+		case key.Matches(msg, m.keys.Accept):
+			return m.choose(ActionAccept)
+		case key.Matches(msg, m.keys.Edit):
+			return m.choose(ActionEdit)
+		case key.Matches(msg, m.keys.EditSubject):
+			return m.choose(ActionEditSubject)
+		case key.Matches(msg, m.keys.Regenerate):
+			return m.choose(ActionRegenerate)
+		case key.Matches(msg, m.keys.Cancel):
+			return m.choose(ActionCancel)
+		case key.Matches(msg, m.keys.ViewDiff):
+			return m.choose(ActionViewDiff)
+		case key.Matches(msg, m.keys.PickAttempt):
+			return m.choose(ActionPickAttempt)
+		case key.Matches(msg, m.keys.Refresh):
+			return m.choose(ActionRefresh)
+		case key.Matches(msg, m.keys.Translate):
+			return m.choose(ActionTranslate)
+		case key.Matches(msg, m.keys.PickBullets):
+			return m.choose(ActionPickBullets)
 		}
 	}
 	return m, nil
 }
 
+// choose records the selected action and ends the prompt.
+func (m actionSelectModel) choose(action Action) (tea.Model, tea.Cmd) {
+	m.selected = action
+	m.done = true
+	return m, tea.Quit
+}
+
 func (m actionSelectModel) View() string {
 	if m.done {
 		return ""
@@ -299,7 +371,7 @@ func (m actionSelectModel) View() string {
 		Foreground(lipgloss.Color("245"))
 
 	var sb strings.Builder
-	sb.WriteString(titleStyle.Render("What would you like to do?"))
+	sb.WriteString(titleStyle.Render(i18n.T("ui.action.title")))
 	sb.WriteString("\n\n")
 
 	for i, choice := range m.choices {
@@ -317,7 +389,16 @@ func (m actionSelectModel) View() string {
 	}
 
 	sb.WriteString("\n")
-	sb.WriteString(descStyle.Render("↑/↓ or j/k to move • Enter to select • 1-4 quick select • q to cancel"))
+	sb.WriteString(descStyle.Render(i18n.T(
+		"ui.action.help",
+		keyLabel(m.keys.Up), keyLabel(m.keys.Down), keyLabel(m.keys.Select),
+		strings.Join([]string{
+			keyLabel(m.keys.Accept), keyLabel(m.keys.Edit),
+			keyLabel(m.keys.Regenerate), keyLabel(m.keys.Cancel),
+		}, ","),
+		keyLabel(m.keys.EditSubject), keyLabel(m.keys.ViewDiff), keyLabel(m.keys.PickAttempt), keyLabel(m.keys.Refresh),
+		keyLabel(m.keys.Translate), keyLabel(m.keys.PickBullets), keyLabel(m.keys.Quit),
+	)))
 
 	return sb.String()
 }
@@ -339,7 +420,7 @@ func (m *DefaultManager) EditMessage(message *ai.GenerateResponse) (*ai.Generate
 			return m.parseEditedMessage(edited), nil
 		}
 		// Fall back to inline editor if external editor fails
-		fmt.Println(m.styles.info.Render("External editor not available, using inline editor..."))
+		fmt.Println(m.styles.info.Render(i18n.T("ui.edit.external_failed")))
 	}
 
 	// Use huh text area for inline editing
@@ -380,72 +461,34 @@ func (m *DefaultManager) formatMessageForEdit(message *ai.GenerateResponse) stri
 	return builder.String()
 }
 
-// getEditor returns the editor to use for editing messages.
-func (m *DefaultManager) getEditor() string {
-	if m.editor != "" {
-		return m.editor
-	}
-
-	// Check environment variables
-	if editor := os.Getenv("EDITOR"); editor != "" {
-		return editor
-	}
-	if editor := os.Getenv("VISUAL"); editor != "" {
-		return editor
-	}
-
-	return ""
-}
-
 // editWithExternalEditor opens an external editor for editing.
 func (m *DefaultManager) editWithExternalEditor(editor, content string) (string, error) {
-	// Create a temporary file
-	tmpFile, err := os.CreateTemp("", "gitsage-commit-*.txt")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-	defer os.Remove(tmpPath)
-
-	// Write content to temp file
-	if _, err := tmpFile.WriteString(content); err != nil {
-		tmpFile.Close()
-		return "", fmt.Errorf("failed to write to temp file: %w", err)
-	}
-	tmpFile.Close()
-
-	// Open editor
-	cmd := exec.Command(editor, tmpPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("editor failed: %w", err)
-	}
-
-	// Read edited content
-	edited, err := os.ReadFile(tmpPath)
-	if err != nil {
-		return "", fmt.Errorf("failed to read edited file: %w", err)
-	}
-
-	return string(edited), nil
+	return runExternalEditor(editor, content, func(cmd *exec.Cmd) error {
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	})
 }
 
-// editWithInlineEditor uses huh text area for inline editing.
+// editWithInlineEditor uses huh text area for inline editing,
+// or the modal inline editor when vim mode is enabled.
 func (m *DefaultManager) editWithInlineEditor(content string) (string, error) {
+	if m.vimMode {
+		return m.editWithVimEditor(content)
+	}
+
 	edited := content
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewText().
-				Title("Edit Commit Message").
-				Description("Edit below. Press Ctrl+D or Tab then Enter to save. Ctrl+C or Esc to cancel.").
+				Title(i18n.T("ui.edit.title")).
+				Description(i18n.T("ui.edit.description")).
 				Value(&edited).
 				CharLimit(0), // No limit
 		),
-	)
+	).WithProgramOptions(detectConsole().programOptions()...)
 
 	if err := form.Run(); err != nil {
 		return "", err
@@ -454,6 +497,22 @@ func (m *DefaultManager) editWithInlineEditor(content string) (string, error) {
 	return edited, nil
 }
 
+// editWithVimEditor runs the vim-style inline editor as its own program.
+func (m *DefaultManager) editWithVimEditor(content string) (string, error) {
+	p := newProgram(inlineEditModel{editor: newInlineEditor(content, true)})
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	result := finalModel.(inlineEditModel)
+	if !result.saved {
+		return "", errEditCancelled
+	}
+	return result.editor.Value(), nil
+}
+
 // parseEditedMessage parses the edited text back into a GenerateResponse.
 func (m *DefaultManager) parseEditedMessage(edited string) *ai.GenerateResponse {
 	edited = strings.TrimSpace(edited)
@@ -487,12 +546,20 @@ func (m *DefaultManager) parseEditedMessage(edited string) *ai.GenerateResponse
 }
 
 // ShowSpinner creates and returns a spinner for loading states.
+// On dumb terminals and in CI, a plain line-per-update spinner is used instead.
 func (m *DefaultManager) ShowSpinner(text string) Spinner {
+	if m.plainProgress {
+		return newPlainSpinner(text)
+	}
 	return newBubbleSpinner(text)
 }
 
 // ShowProgressSpinner creates a spinner with progress tracking.
+// On dumb terminals and in CI, a plain line-per-update progress is used instead.
 func (m *DefaultManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	if m.plainProgress {
+		return newPlainProgressSpinner(text, total)
+	}
 	return newBubbleProgressSpinner(text, total)
 }
 
@@ -501,9 +568,12 @@ func (m *DefaultManager) ShowError(err error) {
 	if err == nil {
 		return
 	}
-	fmt.Println()
-	fmt.Println(m.styles.errorStyle.Render("Error: " + err.Error()))
-	fmt.Println()
+	fmt.Println(m.renderError(err))
+}
+
+// renderError renders an error message with surrounding blank lines.
+func (m *DefaultManager) renderError(err error) string {
+	return "\n" + m.styles.errorStyle.Render(i18n.T("ui.error", err.Error())) + "\n"
 }
 
 // PromptConfirm prompts the user for a yes/no confirmation using Bubble Tea.
@@ -513,8 +583,8 @@ func (m *DefaultManager) PromptConfirm(message string) (bool, error) {
 		return true, nil
 	}
 
-	model := newConfirmModel(message)
-	p := tea.NewProgram(model)
+	model := newConfirmModel(message, m.keys)
+	p := newProgram(model)
 
 	finalModel, err := p.Run()
 	if err != nil {
@@ -531,12 +601,14 @@ type confirmModel struct {
 	cursor    int // 0 = Yes, 1 = No
 	confirmed bool
 	done      bool
+	keys      KeyMap
 }
 
-func newConfirmModel(message string) confirmModel {
+func newConfirmModel(message string, keys KeyMap) confirmModel {
 	return confirmModel{
 		message: message,
 		cursor:  0, // Default to Yes
+		keys:    keys,
 	}
 }
 
@@ -547,20 +619,20 @@ func (m confirmModel) Init() tea.Cmd {
 func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q", "n":
+		switch {
+		case msg.String() == "ctrl+c", key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.No):
 			m.confirmed = false
 			m.done = true
 			return m, tea.Quit
-		case "y", "Y":
+		case key.Matches(msg, m.keys.Yes):
 			m.confirmed = true
 			m.done = true
 			return m, tea.Quit
-		case "left", "h":
+		case key.Matches(msg, m.keys.Left):
 			m.cursor = 0
-		case "right", "l":
+		case key.Matches(msg, m.keys.Right):
 			m.cursor = 1
-		case "enter", " ":
+		case key.Matches(msg, m.keys.Select):
 			m.confirmed = m.cursor == 0
 			m.done = true
 			return m, tea.Quit
@@ -597,24 +669,33 @@ func (m confirmModel) View() string {
 		noStyle = selectedStyle
 	}
 
-	sb.WriteString(yesStyle.Render("[Y]es"))
+	sb.WriteString(yesStyle.Render(i18n.T("ui.confirm.yes", keyLabel(m.keys.Yes))))
 	sb.WriteString(" / ")
-	sb.WriteString(noStyle.Render("[N]o"))
+	sb.WriteString(noStyle.Render(i18n.T("ui.confirm.no", keyLabel(m.keys.No))))
 
 	return sb.String()
 }
 
 // ShowSuccess displays a success message to the user.
 func (m *DefaultManager) ShowSuccess(message string) {
-	fmt.Println()
-	fmt.Println(m.styles.success.Render("[OK] " + message))
-	fmt.Println()
+	fmt.Println(m.renderSuccess(message))
+}
+
+// ShowInfo displays plain informational text.
+func (m *DefaultManager) ShowInfo(message string) {
+	fmt.Println(strings.TrimRight(message, "\n"))
+}
+
+// renderSuccess renders a success message with surrounding blank lines.
+func (m *DefaultManager) renderSuccess(message string) string {
+	return "\n" + m.styles.success.Render("[OK] "+message) + "\n"
 }
 
 // bubbleSpinner implements Spinner using Bubble Tea.
 type bubbleSpinner struct {
 	text    string
 	program *tea.Program
+	done    chan struct{}
 	model   *spinnerModel
 	mu      sync.Mutex
 }
@@ -646,6 +727,12 @@ func (m spinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spinnerQuitMsg:
 		m.quitting = true
 		return m, tea.Quit
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			notifyInterrupt()
+			m.quitting = true
+			return m, tea.Quit
+		}
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -663,7 +750,7 @@ func (m spinnerModel) View() string {
 
 func newBubbleSpinner(text string) *bubbleSpinner {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = detectConsole().spinner()
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	model := &spinnerModel{
@@ -681,9 +768,12 @@ func (s *bubbleSpinner) Start() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.program = tea.NewProgram(s.model)
+	s.program = newProgram(s.model)
+	s.done = make(chan struct{})
+	program, done := s.program, s.done
 	go func() {
-		_, _ = s.program.Run()
+		_, _ = program.Run()
+		close(done)
 	}()
 }
 
@@ -692,8 +782,10 @@ func (s *bubbleSpinner) Stop() {
 	defer s.mu.Unlock()
 
 	if s.program != nil {
+		// Wait for the program to exit so the terminal is restored
+		// before anything else is printed
 		s.program.Send(spinnerQuitMsg{})
-		time.Sleep(50 * time.Millisecond)
+		<-s.done
 	}
 }
 
@@ -714,6 +806,7 @@ type bubbleProgressSpinner struct {
 	current     int
 	currentFile string
 	program     *tea.Program
+	done        chan struct{}
 	mu          sync.Mutex
 }
 
@@ -725,6 +818,7 @@ type progressModel struct {
 	total       int
 	current     int
 	currentFile string
+	stopping    bool
 	quitting    bool
 }
 
@@ -756,6 +850,18 @@ func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case progressQuitMsg:
 		m.quitting = true
 		return m, tea.Quit
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			notifyInterrupt()
+			m.quitting = true
+			return m, tea.Quit
+		case "s":
+			if !m.stopping && notifyStop() {
+				m.stopping = true
+			}
+			return m, nil
+		}
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -796,6 +902,14 @@ func (m progressModel) View() string {
 		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Render(file))
 	}
 
+	// Offer to stop early while someone is listening for it
+	hint := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	if m.stopping {
+		sb.WriteString(hint.Render(" " + i18n.T("ui.progress.stopping")))
+	} else if stopAvailable() {
+		sb.WriteString(hint.Render(" " + i18n.T("ui.progress.stop_hint")))
+	}
+
 	return sb.String()
 }
 
@@ -811,7 +925,7 @@ func (s *bubbleProgressSpinner) Start() {
 	defer s.mu.Unlock()
 
 	sp := spinner.New()
-	sp.Spinner = spinner.Dot
+	sp.Spinner = detectConsole().spinner()
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
 	prog := progress.New(
@@ -828,9 +942,12 @@ func (s *bubbleProgressSpinner) Start() {
 		current:  0,
 	}
 
-	s.program = tea.NewProgram(model)
+	s.program = newProgram(model)
+	s.done = make(chan struct{})
+	program, done := s.program, s.done
 	go func() {
-		_, _ = s.program.Run()
+		_, _ = program.Run()
+		close(done)
 	}()
 }
 
@@ -839,8 +956,10 @@ func (s *bubbleProgressSpinner) Stop() {
 	defer s.mu.Unlock()
 
 	if s.program != nil {
+		// Wait for the program to exit so the terminal is restored
+		// before anything else is printed
 		s.program.Send(progressQuitMsg{})
-		time.Sleep(50 * time.Millisecond)
+		<-s.done
 	}
 }
 
@@ -904,16 +1023,21 @@ func (s *bubbleProgressSpinner) SetCurrentFile(file string) {
 	}
 }
 
-// NonInteractiveManager implements Manager for non-interactive mode (--yes flag).
+// NonInteractiveManager implements Manager when no TTY is attached. Its prompts
+// accept, so NewManager only returns it unwrapped with --yes.
 type NonInteractiveManager struct {
-	colorEnabled bool
-	styles       *styles
+	colorEnabled  bool
+	tty           bool
+	plainProgress bool
+	styles        *styles
 }
 
 // NewNonInteractiveManager creates a new NonInteractiveManager.
 func NewNonInteractiveManager(colorEnabled bool) *NonInteractiveManager {
 	m := &NonInteractiveManager{
-		colorEnabled: colorEnabled,
+		colorEnabled:  colorEnabled,
+		tty:           isTerminal(os.Stdout),
+		plainProgress: usePlainProgress(),
 	}
 	m.initStyles()
 	return m
@@ -996,22 +1120,58 @@ func (m *NonInteractiveManager) EditMessage(message *ai.GenerateResponse) (*ai.G
 	return message, nil
 }
 
-// ShowSpinner returns an animated spinner even in non-interactive mode for progress visibility.
+// EditSubject returns the original subject unchanged in non-interactive mode.
+func (m *NonInteractiveManager) EditSubject(subject string) (string, error) {
+	return subject, nil
+}
+
+// SelectBullets keeps all bullets in non-interactive mode.
+func (m *NonInteractiveManager) SelectBullets(bullets []string) ([]bool, error) {
+	return keptBullets(bulletOptions(bullets)), nil
+}
+
+// ShowSpinner returns an animated spinner for progress visibility.
+// On dumb terminals and in CI, progress is written as plain lines to stderr.
+// Otherwise, when stdout is not a terminal, a no-op spinner keeps redirected output clean.
 func (m *NonInteractiveManager) ShowSpinner(text string) Spinner {
+	if m.plainProgress {
+		return newPlainSpinner(text)
+	}
+	if !m.tty {
+		return &noopSpinner{}
+	}
 	return newBubbleSpinner(text)
 }
 
-// ShowProgressSpinner returns an animated progress spinner in non-interactive mode.
+// ShowProgressSpinner returns an animated progress spinner.
+// On dumb terminals and in CI, progress is written as plain lines to stderr.
+// Otherwise, when stdout is not a terminal, a no-op spinner keeps redirected output clean.
 func (m *NonInteractiveManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	if m.plainProgress {
+		return newPlainProgressSpinner(text, total)
+	}
+	if !m.tty {
+		return &noopSpinner{}
+	}
 	return newBubbleProgressSpinner(text, total)
 }
 
+// noopSpinner implements ProgressSpinner without producing any output.
+type noopSpinner struct{}
+
+func (s *noopSpinner) Start()                     {}
+func (s *noopSpinner) Stop()                      {}
+func (s *noopSpinner) UpdateText(text string)     {}
+func (s *noopSpinner) SetTotal(total int)         {}
+func (s *noopSpinner) SetCurrent(current int)     {}
+func (s *noopSpinner) SetCurrentFile(file string) {}
+
 // ShowError displays an error message.
 func (m *NonInteractiveManager) ShowError(err error) {
 	if err == nil {
 		return
 	}
-	fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+	fmt.Fprintln(os.Stderr, i18n.T("ui.error", err.Error()))
 }
 
 // ShowSuccess displays a success message.
@@ -1019,7 +1179,38 @@ func (m *NonInteractiveManager) ShowSuccess(message string) {
 	fmt.Println(message)
 }
 
+// ShowInfo displays plain informational text.
+func (m *NonInteractiveManager) ShowInfo(message string) {
+	fmt.Println(strings.TrimRight(message, "\n"))
+}
+
 // PromptConfirm always returns true in non-interactive mode.
 func (m *NonInteractiveManager) PromptConfirm(message string) (bool, error) {
 	return true, nil
 }
+
+// ShowDiff writes the diff as-is; there is no pager in non-interactive mode.
+func (m *NonInteractiveManager) ShowDiff(diff string) error {
+	fmt.Println(strings.TrimRight(diff, "\n"))
+	return nil
+}
+
+// DisplayComparison shows only the current message; there is nothing to compare without a user.
+func (m *NonInteractiveManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	return m.DisplayMessage(current)
+}
+
+// SelectFiles returns the suggested selection in non-interactive mode.
+func (m *NonInteractiveManager) SelectFiles(files []FileOption) ([]string, error) {
+	return selectedPaths(files), nil
+}
+
+// SelectAttempt always keeps the current attempt in non-interactive mode.
+func (m *NonInteractiveManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	return len(attempts) - 1, nil
+}
+
+// SelectCandidate always keeps the first candidate in non-interactive mode.
+func (m *NonInteractiveManager) SelectCandidate(candidates []Candidate) (int, error) {
+	return 0, nil
+}
@@ -2,9 +2,11 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 )
 
 func TestActionString(t *testing.T) {
@@ -16,6 +18,12 @@ func TestActionString(t *testing.T) {
 		{ActionEdit, "edit"},
 		{ActionRegenerate, "regenerate"},
 		{ActionCancel, "cancel"},
+		{ActionViewDiff, "view_diff"},
+		{ActionPickAttempt, "pick_attempt"},
+		{ActionRefresh, "refresh"},
+		{ActionTranslate, "translate"},
+		{ActionEditSubject, "edit_subject"},
+		{ActionPickBullets, "pick_bullets"},
 		{Action(99), "unknown"},
 	}
 
@@ -171,6 +179,24 @@ func TestGetEditor(t *testing.T) {
 	}
 }
 
+func TestGetEditor_EnvOrder(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	t.Setenv("EDITOR", "vi")
+	m := NewDefaultManager(true, "", false)
+	if got := m.getEditor(); got != "code --wait" {
+		t.Errorf("getEditor() = %q, want $VISUAL before $EDITOR", got)
+	}
+
+	t.Setenv("VISUAL", "")
+	if got := m.getEditor(); got != "vi" {
+		t.Errorf("getEditor() = %q, want %q", got, "vi")
+	}
+
+	if got := NewDefaultManager(true, "  ", false).getEditor(); got != "vi" {
+		t.Errorf("getEditor() = %q, want a blank editor skipped", got)
+	}
+}
+
 func TestNewDefaultManager(t *testing.T) {
 	t.Run("with colors enabled", func(t *testing.T) {
 		m := NewDefaultManager(true, "vim", false)
@@ -226,8 +252,10 @@ func TestNonInteractiveManager(t *testing.T) {
 		}
 	})
 
-	t.Run("ShowSpinner returns animated spinner", func(t *testing.T) {
+	t.Run("ShowSpinner returns animated spinner on a terminal", func(t *testing.T) {
 		m := NewNonInteractiveManager(true)
+		m.tty = true
+		m.plainProgress = false
 		spinner := m.ShowSpinner("test")
 		if spinner == nil {
 			t.Error("ShowSpinner() returned nil")
@@ -242,8 +270,10 @@ func TestNonInteractiveManager(t *testing.T) {
 		spinner.Stop()
 	})
 
-	t.Run("ShowProgressSpinner returns animated progress spinner", func(t *testing.T) {
+	t.Run("ShowProgressSpinner returns animated progress spinner on a terminal", func(t *testing.T) {
 		m := NewNonInteractiveManager(true)
+		m.tty = true
+		m.plainProgress = false
 		spinner := m.ShowProgressSpinner("test", 10)
 		if spinner == nil {
 			t.Error("ShowProgressSpinner() returned nil")
@@ -326,3 +356,17 @@ func TestShowErrorNil(t *testing.T) {
 	// Should not panic
 	m.ShowError(nil)
 }
+
+func TestActionSelectModelLocalized(t *testing.T) {
+	if err := i18n.SetLanguage(i18n.Chinese); err != nil {
+		t.Fatalf("SetLanguage() error = %v", err)
+	}
+	defer func() { _ = i18n.SetLanguage(i18n.English) }()
+
+	view := newActionSelectModel(DefaultKeyMap()).View()
+	for _, want := range []string{"您想要做什么？", "接受", "重新生成", "取消"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// NotifyTimeout is how long the notification command may take.
+const NotifyTimeout = 5 * time.Second
+
+// Notify shows a native desktop notification: with notify-send on Linux and
+// the BSDs, osascript on macOS and a tray balloon on Windows. It fails if the
+// platform's notification command is not available.
+func Notify(title, message string) error {
+	name, args := notifyCommand(runtime.GOOS, title, message)
+	if _, err := exec.LookPath(name); err != nil {
+		return fmt.Errorf("desktop notifications need %s: %w", name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), NotifyTimeout)
+	defer cancel()
+	if output, err := exec.CommandContext(ctx, name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// notifyCommand returns the command showing a notification on goos.
+func notifyCommand(goos, title, message string) (string, []string) {
+	switch goos {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		return "osascript", []string{"-e", script}
+	case "windows":
+		script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms; `+
+			`$n = New-Object System.Windows.Forms.NotifyIcon; `+
+			`$n.Icon = [System.Drawing.SystemIcons]::Information; $n.Visible = $true; `+
+			`$n.ShowBalloonTip(5000, %s, %s, 'Info'); Start-Sleep -Seconds 5; $n.Dispose()`,
+			powerShellString(title), powerShellString(message))
+		return "powershell", []string{"-NoProfile", "-NonInteractive", "-Command", script}
+	default:
+		return "notify-send", []string{"--app-name=GitSage", title, message}
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// powerShellString quotes s as a PowerShell verbatim string literal.
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
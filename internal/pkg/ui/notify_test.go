@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNotifyCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		name     string
+		contains string
+	}{
+		{"linux", "notify-send", "Say \"hi\""},
+		{"darwin", "osascript", `display notification "Say \"hi\"" with title "GitSage"`},
+		{"windows", "powershell", `ShowBalloonTip(5000, 'GitSage', 'Say "hi"', 'Info')`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := notifyCommand(tt.goos, "GitSage", `Say "hi"`)
+			if name != tt.name {
+				t.Errorf("command = %s, want %s", name, tt.name)
+			}
+			if last := args[len(args)-1]; !strings.Contains(last, tt.contains) {
+				t.Errorf("last argument %q does not contain %q", last, tt.contains)
+			}
+		})
+	}
+}
+
+func TestPowerShellString(t *testing.T) {
+	if got := powerShellString("it's"); got != "'it''s'" {
+		t.Errorf("powerShellString() = %s", got)
+	}
+}
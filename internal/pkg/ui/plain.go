@@ -0,0 +1,138 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ciEnvVars lists environment variables set by common CI systems.
+var ciEnvVars = []string{
+	"CI",
+	"GITHUB_ACTIONS",
+	"GITLAB_CI",
+	"BUILDKITE",
+	"JENKINS_URL",
+	"TEAMCITY_VERSION",
+}
+
+// usePlainProgress reports whether progress should be rendered as plain lines.
+// This is the case for dumb terminals (TERM=dumb) and CI environments, where
+// cursor control sequences used by animated spinners produce artifacts in logs.
+func usePlainProgress() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return true
+	}
+	for _, name := range ciEnvVars {
+		if v := os.Getenv(name); v != "" && v != "false" && v != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// plainSpinner implements Spinner by writing one line per update.
+// Output goes to stderr so stdout stays clean for the generated message.
+type plainSpinner struct {
+	text string
+	out  io.Writer
+	mu   sync.Mutex
+}
+
+func newPlainSpinner(text string) *plainSpinner {
+	return &plainSpinner{
+		text: text,
+		out:  os.Stderr,
+	}
+}
+
+func (s *plainSpinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.out, "%s\n", s.text)
+}
+
+func (s *plainSpinner) Stop() {}
+
+func (s *plainSpinner) UpdateText(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if text == s.text {
+		return
+	}
+	s.text = text
+	fmt.Fprintf(s.out, "%s\n", text)
+}
+
+// plainProgressSpinner implements ProgressSpinner by writing one line per update.
+type plainProgressSpinner struct {
+	text        string
+	total       int
+	current     int
+	currentFile string
+	out         io.Writer
+	mu          sync.Mutex
+}
+
+func newPlainProgressSpinner(text string, total int) *plainProgressSpinner {
+	return &plainProgressSpinner{
+		text:  text,
+		total: total,
+		out:   os.Stderr,
+	}
+}
+
+// printLine writes the current progress state. Caller must hold the lock.
+func (s *plainProgressSpinner) printLine() {
+	if s.currentFile != "" {
+		fmt.Fprintf(s.out, "[%d/%d] %s: %s\n", s.current, s.total, s.text, s.currentFile)
+		return
+	}
+	fmt.Fprintf(s.out, "[%d/%d] %s\n", s.current, s.total, s.text)
+}
+
+func (s *plainProgressSpinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.printLine()
+}
+
+func (s *plainProgressSpinner) Stop() {}
+
+func (s *plainProgressSpinner) UpdateText(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.text = text
+	s.printLine()
+}
+
+func (s *plainProgressSpinner) SetTotal(total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total = total
+}
+
+func (s *plainProgressSpinner) SetCurrent(current int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if current == s.current {
+		return
+	}
+	s.current = current
+	s.printLine()
+}
+
+func (s *plainProgressSpinner) SetCurrentFile(file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if file == s.currentFile {
+		return
+	}
+	s.currentFile = file
+	s.printLine()
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestUsePlainProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"dumb terminal", map[string]string{"TERM": "dumb"}, true},
+		{"CI environment", map[string]string{"CI": "true"}, true},
+		{"GitHub Actions", map[string]string{"GITHUB_ACTIONS": "true"}, true},
+		{"CI explicitly false", map[string]string{"CI": "false"}, false},
+		{"regular terminal", map[string]string{"TERM": "xterm-256color"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TERM", "")
+			for _, name := range ciEnvVars {
+				t.Setenv(name, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			if got := usePlainProgress(); got != tt.expected {
+				t.Errorf("usePlainProgress() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPlainSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	s := newPlainSpinner("Generating commit message...")
+	s.out = &buf
+
+	s.Start()
+	s.UpdateText("Generating commit message...")
+	s.UpdateText("Almost done...")
+	s.Stop()
+
+	expected := "Generating commit message...\nAlmost done...\n"
+	if buf.String() != expected {
+		t.Errorf("output = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestPlainProgressSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	s := newPlainProgressSpinner("Analyzing files", 2)
+	s.out = &buf
+
+	s.Start()
+	s.SetCurrentFile("a.go")
+	s.SetCurrent(1)
+	s.SetCurrent(1) // duplicate updates are not printed
+	s.SetCurrentFile("b.go")
+	s.SetCurrent(2)
+	s.Stop()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"[0/2] Analyzing files",
+		"[0/2] Analyzing files: a.go",
+		"[1/2] Analyzing files: a.go",
+		"[1/2] Analyzing files: b.go",
+		"[2/2] Analyzing files: b.go",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d lines, want %d: %q", len(lines), len(expected), buf.String())
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], expected[i])
+		}
+	}
+}
+
+func TestDefaultManager_PlainProgress(t *testing.T) {
+	m := NewDefaultManager(false, "", false)
+	m.plainProgress = true
+
+	if _, ok := m.ShowSpinner("test").(*plainSpinner); !ok {
+		t.Error("ShowSpinner() should return a plain spinner in plain progress mode")
+	}
+	if _, ok := m.ShowProgressSpinner("test", 1).(*plainProgressSpinner); !ok {
+		t.Error("ShowProgressSpinner() should return a plain progress spinner in plain progress mode")
+	}
+}
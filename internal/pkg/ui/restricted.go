@@ -0,0 +1,124 @@
+// Package ui provides terminal user interface components for GitSage.
+package ui
+
+import (
+	"errors"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// ErrInputRequired is returned by prompts when --no-input forbids asking the
+// user, or when no terminal is attached to ask them.
+var ErrInputRequired = errors.New("input required but --no-input is set or no terminal is attached (pass --yes to accept the generated message)")
+
+// restrictedManager wraps a Manager for scripts: with quiet set, spinners and
+// success messages are dropped; with noInput set, every prompt fails instead
+// of waiting for the user, except accepting the message when autoAccept is set.
+// Errors, the generated message and requested output are always shown.
+type restrictedManager struct {
+	Manager
+	quiet      bool
+	noInput    bool
+	autoAccept bool
+}
+
+// PromptAction accepts when auto-accept is set and fails otherwise with --no-input.
+func (m *restrictedManager) PromptAction() (Action, error) {
+	if !m.noInput {
+		return m.Manager.PromptAction()
+	}
+	if m.autoAccept {
+		return ActionAccept, nil
+	}
+	return ActionCancel, ErrInputRequired
+}
+
+// EditMessage fails with --no-input.
+func (m *restrictedManager) EditMessage(message *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	if m.noInput {
+		return nil, ErrInputRequired
+	}
+	return m.Manager.EditMessage(message)
+}
+
+// EditSubject fails with --no-input.
+func (m *restrictedManager) EditSubject(subject string) (string, error) {
+	if m.noInput {
+		return "", ErrInputRequired
+	}
+	return m.Manager.EditSubject(subject)
+}
+
+// PromptConfirm fails with --no-input.
+func (m *restrictedManager) PromptConfirm(message string) (bool, error) {
+	if m.noInput {
+		return false, ErrInputRequired
+	}
+	return m.Manager.PromptConfirm(message)
+}
+
+// SelectAttempt fails with --no-input.
+func (m *restrictedManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	if m.noInput {
+		return -1, ErrInputRequired
+	}
+	return m.Manager.SelectAttempt(attempts)
+}
+
+// SelectCandidate keeps the first candidate when auto-accept is set and fails
+// otherwise with --no-input.
+func (m *restrictedManager) SelectCandidate(candidates []Candidate) (int, error) {
+	if !m.noInput {
+		return m.Manager.SelectCandidate(candidates)
+	}
+	if m.autoAccept {
+		return 0, nil
+	}
+	return -1, ErrInputRequired
+}
+
+// SelectFiles fails with --no-input.
+func (m *restrictedManager) SelectFiles(files []FileOption) ([]string, error) {
+	if m.noInput {
+		return nil, ErrInputRequired
+	}
+	return m.Manager.SelectFiles(files)
+}
+
+// SelectBullets fails with --no-input.
+func (m *restrictedManager) SelectBullets(bullets []string) ([]bool, error) {
+	if m.noInput {
+		return nil, ErrInputRequired
+	}
+	return m.Manager.SelectBullets(bullets)
+}
+
+// ShowSpinner returns a no-op spinner in quiet mode.
+func (m *restrictedManager) ShowSpinner(text string) Spinner {
+	if m.quiet {
+		return &noopSpinner{}
+	}
+	return m.Manager.ShowSpinner(text)
+}
+
+// ShowProgressSpinner returns a no-op spinner in quiet mode.
+func (m *restrictedManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	if m.quiet {
+		return &noopSpinner{}
+	}
+	return m.Manager.ShowProgressSpinner(text, total)
+}
+
+// ShowSuccess is silent in quiet mode.
+func (m *restrictedManager) ShowSuccess(message string) {
+	if !m.quiet {
+		m.Manager.ShowSuccess(message)
+	}
+}
+
+// Close closes the wrapped manager if it needs closing.
+func (m *restrictedManager) Close() {
+	if closer, ok := m.Manager.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// recordingManager is a Manager that records which methods were called.
+type recordingManager struct {
+	NonInteractiveManager
+	calls  []string
+	closed bool
+}
+
+func (m *recordingManager) PromptAction() (Action, error) {
+	m.calls = append(m.calls, "PromptAction")
+	return ActionRegenerate, nil
+}
+
+func (m *recordingManager) ShowSuccess(message string) {
+	m.calls = append(m.calls, "ShowSuccess")
+}
+
+func (m *recordingManager) ShowError(err error) {
+	m.calls = append(m.calls, "ShowError")
+}
+
+func (m *recordingManager) Close() {
+	m.closed = true
+}
+
+func TestRestrictedManager_NoInput(t *testing.T) {
+	inner := &recordingManager{}
+	m := &restrictedManager{Manager: inner, noInput: true}
+
+	if _, err := m.PromptAction(); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("PromptAction() error = %v, want ErrInputRequired", err)
+	}
+	if _, err := m.PromptConfirm("push?"); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("PromptConfirm() error = %v, want ErrInputRequired", err)
+	}
+	if _, err := m.EditMessage(&ai.GenerateResponse{Subject: "feat: x"}); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("EditMessage() error = %v, want ErrInputRequired", err)
+	}
+	if _, err := m.SelectFiles([]FileOption{{Path: "a.go", Selected: true}}); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("SelectFiles() error = %v, want ErrInputRequired", err)
+	}
+	if _, err := m.SelectAttempt([]*ai.GenerateResponse{{Subject: "feat: x"}}); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("SelectAttempt() error = %v, want ErrInputRequired", err)
+	}
+	if len(inner.calls) != 0 {
+		t.Errorf("wrapped manager should not be prompted, got %v", inner.calls)
+	}
+
+	// With --yes the message is accepted without asking
+	m.autoAccept = true
+	if action, err := m.PromptAction(); err != nil || action != ActionAccept {
+		t.Errorf("PromptAction() = %v, %v; want ActionAccept", action, err)
+	}
+}
+
+func TestRestrictedManager_Quiet(t *testing.T) {
+	inner := &recordingManager{}
+	m := &restrictedManager{Manager: inner, quiet: true}
+
+	m.ShowSuccess("done")
+	m.ShowError(errors.New("boom"))
+	if _, ok := m.ShowSpinner("working").(*noopSpinner); !ok {
+		t.Error("ShowSpinner() should return a no-op spinner")
+	}
+	if _, ok := m.ShowProgressSpinner("working", 3).(*noopSpinner); !ok {
+		t.Error("ShowProgressSpinner() should return a no-op spinner")
+	}
+	if action, _ := m.PromptAction(); action != ActionRegenerate {
+		t.Errorf("PromptAction() should reach the wrapped manager, got %v", action)
+	}
+
+	if want := []string{"ShowError", "PromptAction"}; len(inner.calls) != len(want) || inner.calls[0] != want[0] || inner.calls[1] != want[1] {
+		t.Errorf("calls = %v, want %v", inner.calls, want)
+	}
+
+	m.Close()
+	if !inner.closed {
+		t.Error("Close() should close the wrapped manager")
+	}
+}
+
+func TestNewManager_Restricted(t *testing.T) {
+	// Without a TTY only --yes leaves the manager unwrapped
+	stubTerminal(t, false)
+	if _, ok := NewManager(Options{AutoAccept: true}).(*restrictedManager); ok {
+		t.Error("NewManager() should not wrap with --yes and without --quiet or --no-input")
+	}
+	if _, ok := NewManager(Options{}).(*restrictedManager); !ok {
+		t.Error("NewManager() should wrap without a TTY and --yes")
+	}
+	if _, ok := NewManager(Options{Quiet: true}).(*restrictedManager); !ok {
+		t.Error("NewManager() should wrap with Quiet")
+	}
+	if _, ok := NewManager(Options{NoInput: true}).(*restrictedManager); !ok {
+		t.Error("NewManager() should wrap with NoInput")
+	}
+}
@@ -0,0 +1,869 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// ErrSessionClosed is returned when a prompt is issued after the UI session has ended
+// (for example because the user pressed Ctrl+C while work was in progress).
+var ErrSessionClosed = errors.New("ui session closed")
+
+// errEditCancelled is returned when the user cancels the inline editor.
+var errEditCancelled = errors.New("edit cancelled")
+
+// SessionManager implements Manager using a single persistent Bubble Tea program
+// for the whole flow (spinner → message display → action select → edit).
+// Compared to DefaultManager, which spawns a new program per prompt/spinner,
+// this avoids flicker, keystrokes being swallowed between prompts, and the
+// race-prone Start/Stop goroutine pattern. Call Close when the flow is done.
+type SessionManager struct {
+	*DefaultManager
+
+	mu      sync.Mutex
+	program *tea.Program
+	done    chan struct{}
+}
+
+// NewSessionManager creates a new SessionManager with the specified options.
+// The underlying Bubble Tea program is started lazily on first use.
+func NewSessionManager(colorEnabled bool, editor string, autoAccept bool) *SessionManager {
+	return &SessionManager{
+		DefaultManager: NewDefaultManager(colorEnabled, editor, autoAccept),
+	}
+}
+
+// start returns the running program, starting it if needed.
+func (m *SessionManager) start() (*tea.Program, chan struct{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.program == nil {
+		m.program = newProgram(newSessionModel(m.keys, m.vimMode))
+		m.done = make(chan struct{})
+
+		p, done := m.program, m.done
+		go func() {
+			_, _ = p.Run()
+			close(done)
+		}()
+	}
+	return m.program, m.done
+}
+
+// send delivers a message to the running program.
+// Returns false if the program has already exited.
+func (m *SessionManager) send(msg tea.Msg) (chan struct{}, bool) {
+	p, done := m.start()
+	select {
+	case <-done:
+		return done, false
+	default:
+	}
+	p.Send(msg)
+	return done, true
+}
+
+// println prints text above the live area of the program.
+// Falls back to stdout if the program has exited.
+func (m *SessionManager) println(text string) {
+	if _, ok := m.send(sessionPrintMsg{text: text}); !ok {
+		fmt.Println(text)
+	}
+}
+
+// Close stops the underlying program and restores the terminal.
+// It is safe to call Close multiple times; the next UI call starts a new program.
+func (m *SessionManager) Close() {
+	m.mu.Lock()
+	p, done := m.program, m.done
+	m.program, m.done = nil, nil
+	m.mu.Unlock()
+
+	if p == nil {
+		return
+	}
+	p.Quit()
+	<-done
+}
+
+// DisplayMessage displays the generated commit message above the live area.
+func (m *SessionManager) DisplayMessage(message *ai.GenerateResponse) error {
+	if message == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+
+	m.println(m.renderMessage(message))
+	return nil
+}
+
+// DisplayComparison displays the previous and new message side by side above the live area.
+func (m *SessionManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	if previous == nil || current == nil {
+		return fmt.Errorf("message cannot be nil")
+	}
+
+	m.println(m.renderComparison(previous, current))
+	return nil
+}
+
+// ShowError displays an error message above the live area.
+func (m *SessionManager) ShowError(err error) {
+	if err == nil {
+		return
+	}
+	m.println(m.renderError(err))
+}
+
+// ShowSuccess displays a success message above the live area.
+func (m *SessionManager) ShowSuccess(message string) {
+	m.println(m.renderSuccess(message))
+}
+
+// ShowInfo displays plain informational text above the live area.
+func (m *SessionManager) ShowInfo(message string) {
+	m.println(strings.TrimRight(message, "\n"))
+}
+
+// ShowSpinner returns a spinner rendered inside the session program.
+func (m *SessionManager) ShowSpinner(text string) Spinner {
+	return &sessionSpinner{manager: m, text: text}
+}
+
+// ShowProgressSpinner returns a progress spinner rendered inside the session program.
+func (m *SessionManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	return &sessionProgressSpinner{manager: m, text: text, total: total}
+}
+
+// PromptAction prompts the user to select an action inside the session program.
+// If autoAccept is enabled, returns ActionAccept immediately.
+func (m *SessionManager) PromptAction() (Action, error) {
+	if m.autoAccept {
+		return ActionAccept, nil
+	}
+
+	reply := make(chan Action, 1)
+	done, ok := m.send(sessionActionMsg{reply: reply})
+	if !ok {
+		return ActionCancel, ErrSessionClosed
+	}
+
+	select {
+	case action := <-reply:
+		return action, nil
+	case <-done:
+		return ActionCancel, ErrSessionClosed
+	}
+}
+
+// SelectAttempt lets the user pick one of the generated attempts inside the session program.
+// Returns -1 if the user backs out. If autoAccept is enabled, the latest attempt is returned.
+func (m *SessionManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	if m.autoAccept || len(attempts) == 0 {
+		return len(attempts) - 1, nil
+	}
+
+	reply := make(chan int, 1)
+	done, ok := m.send(sessionAttemptMsg{labels: attemptLabels(attempts), reply: reply})
+	if !ok {
+		return -1, ErrSessionClosed
+	}
+
+	select {
+	case selected := <-reply:
+		return selected, nil
+	case <-done:
+		return -1, ErrSessionClosed
+	}
+}
+
+// SelectCandidate displays the candidates side by side above the live area and
+// lets the user pick one inside the session program. Returns -1 if the user
+// backs out. If autoAccept is enabled, the first candidate is returned.
+func (m *SessionManager) SelectCandidate(candidates []Candidate) (int, error) {
+	if m.autoAccept || len(candidates) == 0 {
+		return 0, nil
+	}
+
+	m.println(m.renderCandidates(candidates))
+
+	reply := make(chan int, 1)
+	done, ok := m.send(sessionAttemptMsg{labels: candidateLabels(candidates), candidates: true, reply: reply})
+	if !ok {
+		return -1, ErrSessionClosed
+	}
+
+	select {
+	case selected := <-reply:
+		return selected, nil
+	case <-done:
+		return -1, ErrSessionClosed
+	}
+}
+
+// SelectFiles lets the user choose which files to stage inside the session program.
+// Returns nil if the user cancels. If autoAccept is enabled, the suggested selection is returned.
+func (m *SessionManager) SelectFiles(files []FileOption) ([]string, error) {
+	if m.autoAccept || len(files) == 0 {
+		return selectedPaths(files), nil
+	}
+
+	reply := make(chan []string, 1)
+	done, ok := m.send(sessionFilesMsg{files: files, reply: reply})
+	if !ok {
+		return nil, ErrSessionClosed
+	}
+
+	select {
+	case selected := <-reply:
+		return selected, nil
+	case <-done:
+		return nil, ErrSessionClosed
+	}
+}
+
+// SelectBullets lets the user toggle off bullets of the message body inside
+// the session program. Returns nil if the user backs out. If autoAccept is
+// enabled, all bullets are kept.
+func (m *SessionManager) SelectBullets(bullets []string) ([]bool, error) {
+	if m.autoAccept || len(bullets) == 0 {
+		return keptBullets(bulletOptions(bullets)), nil
+	}
+
+	reply := make(chan []bool, 1)
+	done, ok := m.send(sessionBulletsMsg{bullets: bullets, reply: reply})
+	if !ok {
+		return nil, ErrSessionClosed
+	}
+
+	select {
+	case keep := <-reply:
+		return keep, nil
+	case <-done:
+		return nil, ErrSessionClosed
+	}
+}
+
+// PromptConfirm prompts the user for a yes/no confirmation inside the session program.
+// If autoAccept is enabled, returns true immediately.
+func (m *SessionManager) PromptConfirm(message string) (bool, error) {
+	if m.autoAccept {
+		return true, nil
+	}
+
+	reply := make(chan bool, 1)
+	done, ok := m.send(sessionConfirmMsg{message: message, reply: reply})
+	if !ok {
+		return false, ErrSessionClosed
+	}
+
+	select {
+	case confirmed := <-reply:
+		return confirmed, nil
+	case <-done:
+		return false, ErrSessionClosed
+	}
+}
+
+// ShowDiff shows the staged diff in a full-screen pager inside the session program.
+func (m *SessionManager) ShowDiff(diff string) error {
+	reply := make(chan struct{}, 1)
+	done, ok := m.send(sessionDiffMsg{content: diff, reply: reply})
+	if !ok {
+		return ErrSessionClosed
+	}
+
+	select {
+	case <-reply:
+		return nil
+	case <-done:
+		return ErrSessionClosed
+	}
+}
+
+// EditMessage lets the user modify the commit message.
+// External editors are run through the session program so the terminal is
+// released and restored cleanly; otherwise an inline editor is shown.
+func (m *SessionManager) EditMessage(message *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	if message == nil {
+		return nil, fmt.Errorf("message cannot be nil")
+	}
+
+	editContent := m.formatMessageForEdit(message)
+
+	if editor := m.getEditor(); editor != "" {
+		edited, err := m.editWithSessionEditor(editor, editContent)
+		if err == nil {
+			return m.parseEditedMessage(edited), nil
+		}
+		if errors.Is(err, ErrSessionClosed) {
+			return nil, err
+		}
+		m.println(m.styles.info.Render(i18n.T("ui.edit.external_failed")))
+	}
+
+	reply := make(chan editResult, 1)
+	done, ok := m.send(sessionEditMsg{content: editContent, reply: reply})
+	if !ok {
+		return nil, ErrSessionClosed
+	}
+
+	select {
+	case r := <-reply:
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to edit message: %w", r.err)
+		}
+		return m.parseEditedMessage(r.content), nil
+	case <-done:
+		return nil, ErrSessionClosed
+	}
+}
+
+// EditSubject lets the user change the subject line in a single-line input
+// inside the session program.
+func (m *SessionManager) EditSubject(subject string) (string, error) {
+	reply := make(chan editResult, 1)
+	done, ok := m.send(sessionSubjectMsg{subject: subject, reply: reply})
+	if !ok {
+		return "", ErrSessionClosed
+	}
+
+	select {
+	case r := <-reply:
+		if r.err != nil {
+			return "", r.err
+		}
+		return r.content, nil
+	case <-done:
+		return "", ErrSessionClosed
+	}
+}
+
+// editWithSessionEditor runs an external editor on a temp file via tea.ExecProcess.
+func (m *SessionManager) editWithSessionEditor(editor, content string) (string, error) {
+	return runExternalEditor(editor, content, func(cmd *exec.Cmd) error {
+		reply := make(chan error, 1)
+		done, ok := m.send(sessionExecMsg{cmd: cmd, reply: reply})
+		if !ok {
+			return ErrSessionClosed
+		}
+
+		select {
+		case err := <-reply:
+			return err
+		case <-done:
+			return ErrSessionClosed
+		}
+	})
+}
+
+// sessionSpinner implements Spinner by driving the session program.
+type sessionSpinner struct {
+	manager *SessionManager
+	text    string
+	mu      sync.Mutex
+}
+
+func (s *sessionSpinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.manager.send(sessionSpinnerMsg{text: s.text})
+}
+
+func (s *sessionSpinner) Stop() {
+	s.manager.send(sessionStopMsg{})
+}
+
+func (s *sessionSpinner) UpdateText(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.text = text
+	s.manager.send(sessionSpinnerMsg{text: text})
+}
+
+// sessionProgressSpinner implements ProgressSpinner by driving the session program.
+type sessionProgressSpinner struct {
+	manager     *SessionManager
+	text        string
+	total       int
+	current     int
+	currentFile string
+	mu          sync.Mutex
+}
+
+// update sends the current progress state. Caller must hold the lock.
+func (s *sessionProgressSpinner) update() {
+	s.manager.send(sessionProgressMsg{
+		text:        s.text,
+		total:       s.total,
+		current:     s.current,
+		currentFile: s.currentFile,
+	})
+}
+
+func (s *sessionProgressSpinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.update()
+}
+
+func (s *sessionProgressSpinner) Stop() {
+	s.manager.send(sessionStopMsg{})
+}
+
+func (s *sessionProgressSpinner) UpdateText(text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.text = text
+	s.update()
+}
+
+func (s *sessionProgressSpinner) SetTotal(total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total = total
+	s.update()
+}
+
+func (s *sessionProgressSpinner) SetCurrent(current int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = current
+	s.update()
+}
+
+func (s *sessionProgressSpinner) SetCurrentFile(file string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentFile = file
+	s.update()
+}
+
+// sessionMode is the current state of the session program.
+type sessionMode int
+
+const (
+	sessionIdle sessionMode = iota
+	sessionSpinning
+	sessionProgress
+	sessionAction
+	sessionConfirm
+	sessionEdit
+	sessionSubject
+	sessionDiff
+	sessionAttempt
+	sessionFiles
+	sessionBullets
+)
+
+// Messages sent from SessionManager to the session program.
+type (
+	sessionPrintMsg   struct{ text string }
+	sessionSpinnerMsg struct{ text string }
+	sessionStopMsg    struct{}
+
+	sessionProgressMsg struct {
+		text        string
+		total       int
+		current     int
+		currentFile string
+	}
+
+	sessionActionMsg struct{ reply chan Action }
+
+	sessionConfirmMsg struct {
+		message string
+		reply   chan bool
+	}
+
+	sessionEditMsg struct {
+		content string
+		reply   chan editResult
+	}
+
+	sessionSubjectMsg struct {
+		subject string
+		reply   chan editResult
+	}
+
+	sessionAttemptMsg struct {
+		labels     []string
+		candidates bool
+		reply      chan int
+	}
+
+	sessionFilesMsg struct {
+		files []FileOption
+		reply chan []string
+	}
+
+	sessionBulletsMsg struct {
+		bullets []string
+		reply   chan []bool
+	}
+
+	sessionDiffMsg struct {
+		content string
+		reply   chan struct{}
+	}
+
+	sessionExecMsg struct {
+		cmd   *exec.Cmd
+		reply chan error
+	}
+)
+
+// editResult is the outcome of an inline edit.
+type editResult struct {
+	content string
+	err     error
+}
+
+// sessionModel is the Bubble Tea model for the persistent session program.
+type sessionModel struct {
+	mode sessionMode
+
+	spinner     spinner.Model
+	progress    progress.Model
+	text        string
+	total       int
+	current     int
+	currentFile string
+	stopping    bool
+
+	action       actionSelectModel
+	actionReply  chan Action
+	confirm      confirmModel
+	confirmReply chan bool
+	editor       inlineEditor
+	editReply    chan editResult
+	subject      subjectInputModel
+	subjectReply chan editResult
+	diff         diffViewModel
+	diffReply    chan struct{}
+	attempt      attemptSelectModel
+	attemptReply chan int
+	files        fileSelectModel
+	filesReply   chan []string
+	bulletsReply chan []bool
+
+	// Last reported terminal size, used to fit the diff pager
+	width  int
+	height int
+
+	keys    KeyMap
+	vimMode bool
+}
+
+func newSessionModel(keys KeyMap, vimMode bool) sessionModel {
+	sp := spinner.New()
+	sp.Spinner = detectConsole().spinner()
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+
+	return sessionModel{
+		spinner: sp,
+		progress: progress.New(
+			progress.WithDefaultGradient(),
+			progress.WithWidth(20),
+			progress.WithoutPercentage(),
+		),
+		keys:    keys,
+		vimMode: vimMode,
+	}
+}
+
+func (m sessionModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m sessionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case sessionPrintMsg:
+		return m, tea.Println(strings.TrimSuffix(msg.text, "\n"))
+
+	case sessionSpinnerMsg:
+		m.text = msg.text
+		if m.mode != sessionSpinning {
+			m.mode = sessionSpinning
+			return m, m.spinner.Tick
+		}
+		return m, nil
+
+	case sessionProgressMsg:
+		m.text = msg.text
+		m.total = msg.total
+		m.current = msg.current
+		m.currentFile = msg.currentFile
+		if m.mode != sessionProgress {
+			m.mode = sessionProgress
+			m.stopping = false
+			return m, m.spinner.Tick
+		}
+		return m, nil
+
+	case sessionStopMsg:
+		if m.mode == sessionSpinning || m.mode == sessionProgress {
+			m.mode = sessionIdle
+		}
+		return m, nil
+
+	case sessionActionMsg:
+		m.mode = sessionAction
+		m.action = newActionSelectModel(m.keys)
+		m.actionReply = msg.reply
+		return m, nil
+
+	case sessionConfirmMsg:
+		m.mode = sessionConfirm
+		m.confirm = newConfirmModel(msg.message, m.keys)
+		m.confirmReply = msg.reply
+		return m, nil
+
+	case sessionEditMsg:
+		m.mode = sessionEdit
+		m.editor = newInlineEditor(msg.content, m.vimMode)
+		m.editReply = msg.reply
+		return m, m.editor.Focus()
+
+	case sessionSubjectMsg:
+		m.mode = sessionSubject
+		m.subject = newSubjectInputModel(msg.subject)
+		m.subjectReply = msg.reply
+		return m, m.subject.Init()
+
+	case sessionAttemptMsg:
+		m.mode = sessionAttempt
+		if msg.candidates {
+			m.attempt = newCandidateSelectModel(msg.labels, m.keys)
+		} else {
+			m.attempt = newAttemptSelectModel(msg.labels, m.keys)
+		}
+		m.attemptReply = msg.reply
+		return m, nil
+
+	case sessionFilesMsg:
+		m.mode = sessionFiles
+		m.files = newFileSelectModel(msg.files, m.keys)
+		m.filesReply = msg.reply
+		return m, nil
+
+	case sessionBulletsMsg:
+		m.mode = sessionBullets
+		m.files = newBulletSelectModel(msg.bullets, m.keys)
+		m.bulletsReply = msg.reply
+		return m, nil
+
+	case sessionDiffMsg:
+		m.mode = sessionDiff
+		m.diff = newDiffViewModel(msg.content, m.width, m.height)
+		m.diffReply = msg.reply
+		return m, tea.EnterAltScreen
+
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.mode == sessionDiff {
+			m.diff.setSize(msg.Width, msg.Height)
+		}
+		return m, nil
+
+	case sessionExecMsg:
+		reply := msg.reply
+		return m, tea.ExecProcess(msg.cmd, func(err error) tea.Msg {
+			reply <- err
+			return nil
+		})
+
+	case spinner.TickMsg:
+		if m.mode != sessionSpinning && m.mode != sessionProgress {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	switch m.mode {
+	case sessionEdit:
+		var cmd tea.Cmd
+		m.editor, cmd, _ = m.editor.Update(msg)
+		return m, cmd
+	case sessionSubject:
+		updated, cmd := m.subject.Update(msg)
+		m.subject = updated.(subjectInputModel)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// handleKey routes key presses to the active prompt.
+func (m sessionModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.mode {
+	case sessionAction:
+		updated, _ := m.action.Update(msg)
+		m.action = updated.(actionSelectModel)
+		if m.action.done {
+			m.mode = sessionIdle
+			m.actionReply <- m.action.selected
+		}
+		return m, nil
+
+	case sessionConfirm:
+		updated, _ := m.confirm.Update(msg)
+		m.confirm = updated.(confirmModel)
+		if m.confirm.done {
+			m.mode = sessionIdle
+			m.confirmReply <- m.confirm.confirmed
+		}
+		return m, nil
+
+	case sessionAttempt:
+		updated, _ := m.attempt.Update(msg)
+		m.attempt = updated.(attemptSelectModel)
+		if m.attempt.done {
+			m.mode = sessionIdle
+			m.attemptReply <- m.attempt.selected
+		}
+		return m, nil
+
+	case sessionFiles:
+		updated, _ := m.files.Update(msg)
+		m.files = updated.(fileSelectModel)
+		if m.files.done {
+			m.mode = sessionIdle
+			if m.files.cancelled {
+				m.filesReply <- nil
+			} else {
+				m.filesReply <- selectedPaths(m.files.files)
+			}
+		}
+		return m, nil
+
+	case sessionBullets:
+		updated, _ := m.files.Update(msg)
+		m.files = updated.(fileSelectModel)
+		if m.files.done {
+			m.mode = sessionIdle
+			if m.files.cancelled {
+				m.bulletsReply <- nil
+			} else {
+				m.bulletsReply <- keptBullets(m.files.files)
+			}
+		}
+		return m, nil
+
+	case sessionDiff:
+		updated, cmd := m.diff.Update(msg)
+		m.diff = updated.(diffViewModel)
+		if m.diff.done {
+			m.mode = sessionIdle
+			m.diffReply <- struct{}{}
+			return m, tea.ExitAltScreen
+		}
+		return m, cmd
+
+	case sessionSubject:
+		updated, cmd := m.subject.Update(msg)
+		m.subject = updated.(subjectInputModel)
+		if m.subject.done {
+			m.mode = sessionIdle
+			if m.subject.cancelled {
+				m.subjectReply <- editResult{err: errEditCancelled}
+			} else {
+				m.subjectReply <- editResult{content: m.subject.input.Value()}
+			}
+			// The input quits its own program, not the session
+			return m, nil
+		}
+		return m, cmd
+
+	case sessionEdit:
+		switch k := msg.String(); {
+		case k == "ctrl+d":
+			return m.finishEdit(editResult{content: m.editor.Value()})
+		case k == "ctrl+c", k == "esc" && !m.editor.vim:
+			// In vim mode Esc returns to normal mode instead
+			return m.finishEdit(editResult{err: errEditCancelled})
+		}
+
+		var cmd tea.Cmd
+		var command editorCommand
+		m.editor, cmd, command = m.editor.Update(msg)
+		switch command {
+		case editorSave:
+			return m.finishEdit(editResult{content: m.editor.Value()})
+		case editorQuit:
+			return m.finishEdit(editResult{err: errEditCancelled})
+		}
+		return m, cmd
+	}
+
+	if m.mode == sessionProgress && msg.String() == "s" {
+		if !m.stopping && notifyStop() {
+			m.stopping = true
+		}
+		return m, nil
+	}
+
+	// No prompt active: Ctrl+C cancels the command and ends the session,
+	// pending prompts return ErrSessionClosed
+	if msg.String() == "ctrl+c" {
+		notifyInterrupt()
+		return m, tea.Interrupt
+	}
+	return m, nil
+}
+
+// finishEdit ends inline editing and replies with the result.
+func (m sessionModel) finishEdit(result editResult) (tea.Model, tea.Cmd) {
+	m.mode = sessionIdle
+	m.editReply <- result
+	return m, nil
+}
+
+func (m sessionModel) View() string {
+	switch m.mode {
+	case sessionSpinning:
+		return fmt.Sprintf("%s %s", m.spinner.View(), m.text)
+	case sessionProgress:
+		return progressModel{
+			spinner:     m.spinner,
+			progress:    m.progress,
+			text:        m.text,
+			total:       m.total,
+			current:     m.current,
+			currentFile: m.currentFile,
+			stopping:    m.stopping,
+		}.View()
+	case sessionAction:
+		return m.action.View()
+	case sessionConfirm:
+		return m.confirm.View()
+	case sessionAttempt:
+		return m.attempt.View()
+	case sessionFiles, sessionBullets:
+		return m.files.View()
+	case sessionDiff:
+		return m.diff.View()
+	case sessionEdit:
+		return renderEditView(m.editor)
+	case sessionSubject:
+		return m.subject.View()
+	default:
+		return ""
+	}
+}
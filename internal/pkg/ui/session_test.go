@@ -0,0 +1,321 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+c":
+		return tea.KeyMsg{Type: tea.KeyCtrlC}
+	case "ctrl+d":
+		return tea.KeyMsg{Type: tea.KeyCtrlD}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func updateSession(m sessionModel, msg tea.Msg) (sessionModel, tea.Cmd) {
+	updated, cmd := m.Update(msg)
+	return updated.(sessionModel), cmd
+}
+
+func TestSessionModel_ActionPrompt(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan Action, 1)
+
+	m, _ = updateSession(m, sessionActionMsg{reply: reply})
+	if m.mode != sessionAction {
+		t.Fatalf("mode = %v, want sessionAction", m.mode)
+	}
+	if !strings.Contains(m.View(), "What would you like to do?") {
+		t.Error("View() should render the action prompt")
+	}
+
+	m, _ = updateSession(m, keyMsg("j"))
+	m, cmd := updateSession(m, keyMsg("enter"))
+	if cmd != nil {
+		t.Error("selecting an action must not quit the session program")
+	}
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after selection", m.mode)
+	}
+
+	select {
+	case action := <-reply:
+		if action != ActionEdit {
+			t.Errorf("selected action = %v, want %v", action, ActionEdit)
+		}
+	default:
+		t.Fatal("no action was replied")
+	}
+
+	// d is a quick key for viewing the diff
+	m, _ = updateSession(m, sessionActionMsg{reply: reply})
+	_, _ = updateSession(m, keyMsg("d"))
+	if action := <-reply; action != ActionViewDiff {
+		t.Errorf("selected action = %v, want %v", action, ActionViewDiff)
+	}
+}
+
+func TestSessionModel_ConfirmPrompt(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan bool, 1)
+
+	m, _ = updateSession(m, sessionConfirmMsg{message: "Push to remote?", reply: reply})
+	if !strings.Contains(m.View(), "Push to remote?") {
+		t.Error("View() should render the confirm message")
+	}
+
+	m, _ = updateSession(m, keyMsg("n"))
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after answer", m.mode)
+	}
+	if confirmed := <-reply; confirmed {
+		t.Error("pressing n should reply false")
+	}
+}
+
+func TestSessionModel_InlineEdit(t *testing.T) {
+	t.Run("save with ctrl+d", func(t *testing.T) {
+		m := newSessionModel(DefaultKeyMap(), false)
+		reply := make(chan editResult, 1)
+
+		m, _ = updateSession(m, sessionEditMsg{content: "feat: original", reply: reply})
+		if m.mode != sessionEdit {
+			t.Fatalf("mode = %v, want sessionEdit", m.mode)
+		}
+
+		m, _ = updateSession(m, keyMsg("!"))
+		m, _ = updateSession(m, keyMsg("ctrl+d"))
+
+		r := <-reply
+		if r.err != nil {
+			t.Fatalf("unexpected error: %v", r.err)
+		}
+		if r.content != "feat: original!" {
+			t.Errorf("content = %q, want %q", r.content, "feat: original!")
+		}
+	})
+
+	t.Run("cancel with esc", func(t *testing.T) {
+		m := newSessionModel(DefaultKeyMap(), false)
+		reply := make(chan editResult, 1)
+
+		m, _ = updateSession(m, sessionEditMsg{content: "feat: original", reply: reply})
+		_, _ = updateSession(m, keyMsg("esc"))
+
+		r := <-reply
+		if !errors.Is(r.err, errEditCancelled) {
+			t.Errorf("err = %v, want errEditCancelled", r.err)
+		}
+	})
+}
+
+func TestSessionModel_SubjectInput(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan editResult, 1)
+
+	m, _ = updateSession(m, sessionSubjectMsg{subject: "feat: add login", reply: reply})
+	if m.mode != sessionSubject {
+		t.Fatalf("mode = %v, want sessionSubject", m.mode)
+	}
+	if !strings.Contains(m.View(), "15 characters") {
+		t.Errorf("View() should count the characters, got %q", m.View())
+	}
+
+	m, _ = updateSession(m, keyMsg(" form"))
+	m, cmd := updateSession(m, keyMsg("enter"))
+	if cmd != nil {
+		t.Error("saving the subject must not quit the session program")
+	}
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after saving", m.mode)
+	}
+	if r := <-reply; r.err != nil || r.content != "feat: add login form" {
+		t.Errorf("reply = %+v, want the edited subject", r)
+	}
+
+	m, _ = updateSession(m, sessionSubjectMsg{subject: "feat: add login", reply: reply})
+	_, _ = updateSession(m, keyMsg("esc"))
+	if r := <-reply; !errors.Is(r.err, errEditCancelled) {
+		t.Errorf("err = %v, want errEditCancelled", r.err)
+	}
+}
+
+func TestSessionModel_VimEdit(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), true)
+	reply := make(chan editResult, 1)
+
+	m, _ = updateSession(m, sessionEditMsg{content: "feat", reply: reply})
+	m, _ = updateSession(m, keyMsg("A"))
+	m, _ = updateSession(m, keyMsg("!"))
+
+	// Esc leaves insert mode instead of cancelling the edit
+	m, _ = updateSession(m, keyMsg("esc"))
+	if m.mode != sessionEdit {
+		t.Fatal("esc should not cancel the edit in vim mode")
+	}
+
+	m, _ = updateSession(m, keyMsg("Z"))
+	_, _ = updateSession(m, keyMsg("Z"))
+
+	r := <-reply
+	if r.err != nil || r.content != "feat!" {
+		t.Errorf("edit result = %q, %v; want %q, nil", r.content, r.err, "feat!")
+	}
+}
+
+func TestSessionModel_DiffPager(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	m, _ = updateSession(m, tea.WindowSizeMsg{Width: 100, Height: 30})
+
+	reply := make(chan struct{}, 1)
+	m, cmd := updateSession(m, sessionDiffMsg{content: sampleDiff, reply: reply})
+	if m.mode != sessionDiff || cmd == nil {
+		t.Fatal("diff message should switch to the pager on the alternate screen")
+	}
+	if m.diff.viewport.Width != 100 {
+		t.Errorf("pager width = %d, want terminal width 100", m.diff.viewport.Width)
+	}
+
+	m, _ = updateSession(m, keyMsg("q"))
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after closing the pager", m.mode)
+	}
+	select {
+	case <-reply:
+	default:
+		t.Fatal("closing the pager should reply")
+	}
+}
+
+func TestSessionModel_AttemptPicker(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan int, 1)
+
+	m, _ = updateSession(m, sessionAttemptMsg{labels: []string{"feat: first", "feat: second"}, reply: reply})
+	if !strings.Contains(m.View(), "feat: first") {
+		t.Error("View() should list the attempts")
+	}
+
+	m, _ = updateSession(m, keyMsg("1"))
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after selection", m.mode)
+	}
+	if selected := <-reply; selected != 0 {
+		t.Errorf("selected = %d, want 0", selected)
+	}
+}
+
+func TestSessionModel_FilePicker(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan []string, 1)
+
+	m, _ = updateSession(m, sessionFilesMsg{files: []FileOption{{Path: "main.go"}}, reply: reply})
+	if !strings.Contains(m.View(), "[ ] main.go") {
+		t.Errorf("View() should list the files:\n%s", m.View())
+	}
+
+	m, _ = updateSession(m, keyMsg(" "))
+	m, _ = updateSession(m, keyMsg("enter"))
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after confirming", m.mode)
+	}
+	if selected := <-reply; len(selected) != 1 || selected[0] != "main.go" {
+		t.Errorf("selected = %v, want [main.go]", selected)
+	}
+
+	m, _ = updateSession(m, sessionFilesMsg{files: []FileOption{{Path: "main.go", Selected: true}}, reply: reply})
+	m, _ = updateSession(m, keyMsg("esc"))
+	if selected := <-reply; selected != nil {
+		t.Errorf("selected = %v, want nil after cancelling", selected)
+	}
+}
+
+func TestSessionModel_BulletPicker(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	reply := make(chan []bool, 1)
+	bullets := []string{"- auth: refresh tokens", "- update tests"}
+
+	m, _ = updateSession(m, sessionBulletsMsg{bullets: bullets, reply: reply})
+	if view := m.View(); !strings.Contains(view, "[x] - update tests") || !strings.Contains(view, "Uncheck the bullets") {
+		t.Errorf("View() should list the bullets checked:\n%s", view)
+	}
+
+	m, _ = updateSession(m, keyMsg("j"))
+	m, _ = updateSession(m, keyMsg(" "))
+	m, _ = updateSession(m, keyMsg("enter"))
+	if m.mode != sessionIdle {
+		t.Errorf("mode = %v, want sessionIdle after confirming", m.mode)
+	}
+	if keep := <-reply; len(keep) != 2 || !keep[0] || keep[1] {
+		t.Errorf("keep = %v, want [true false]", keep)
+	}
+
+	m, _ = updateSession(m, sessionBulletsMsg{bullets: bullets, reply: reply})
+	_, _ = updateSession(m, keyMsg("esc"))
+	if keep := <-reply; keep != nil {
+		t.Errorf("keep = %v, want nil after backing out", keep)
+	}
+}
+
+func TestSessionModel_SpinnerLifecycle(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+
+	m, cmd := updateSession(m, sessionSpinnerMsg{text: "Generating commit message..."})
+	if cmd == nil {
+		t.Error("starting the spinner should schedule a tick")
+	}
+	if !strings.Contains(m.View(), "Generating commit message...") {
+		t.Error("View() should render the spinner text")
+	}
+
+	m, _ = updateSession(m, sessionProgressMsg{text: "Analyzing files", total: 3, current: 1})
+	if !strings.Contains(m.View(), "1/3") {
+		t.Errorf("View() = %q, want progress counter", m.View())
+	}
+
+	m, _ = updateSession(m, sessionStopMsg{})
+	if m.mode != sessionIdle || m.View() != "" {
+		t.Error("stopping should return to idle with an empty view")
+	}
+}
+
+func TestSessionModel_CtrlCWhileIdle(t *testing.T) {
+	m := newSessionModel(DefaultKeyMap(), false)
+	_, cmd := updateSession(m, keyMsg("ctrl+c"))
+	if cmd == nil {
+		t.Fatal("ctrl+c while idle should interrupt the session")
+	}
+	if _, ok := cmd().(tea.InterruptMsg); !ok {
+		t.Error("ctrl+c while idle should produce an InterruptMsg")
+	}
+}
+
+func TestSessionManager_AutoAccept(t *testing.T) {
+	// Auto-accept must not start the Bubble Tea program
+	m := NewSessionManager(false, "", true)
+
+	action, err := m.PromptAction()
+	if err != nil || action != ActionAccept {
+		t.Errorf("PromptAction() = %v, %v; want accept, nil", action, err)
+	}
+
+	confirmed, err := m.PromptConfirm("Push?")
+	if err != nil || !confirmed {
+		t.Errorf("PromptConfirm() = %v, %v; want true, nil", confirmed, err)
+	}
+
+	if m.program != nil {
+		t.Error("program should not be started in auto-accept mode")
+	}
+	m.Close()
+}
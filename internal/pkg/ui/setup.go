@@ -1,16 +1,24 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
 )
 
 // RunInteractiveSetup runs the interactive setup wizard using Bubble Tea (huh).
+// Legacy consoles cannot run it; the configuration is then created with
+// "config init" and "config set".
 func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
-	fmt.Println("No configuration found. Let's set up GitSage!")
+	if LegacyConsole() {
+		return errLegacyConsole
+	}
+
+	fmt.Println(i18n.T("setup.welcome"))
 	fmt.Println()
 
 	// Initialize config file directory structure
@@ -21,15 +29,16 @@ func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
 	var provider string
 
 	// Stage 1: Select Provider
-	err := huh.NewSelect[string]().
-		Title("Select AI Provider").
-		Options(
-			huh.NewOption("OpenAI", "openai"),
-			huh.NewOption("DeepSeek", "deepseek"),
-			huh.NewOption("Ollama (Local)", "ollama"),
-		).
-		Value(&provider).
-		Run()
+	err := huh.NewForm(huh.NewGroup(
+		huh.NewSelect[string]().
+			Title(i18n.T("setup.provider.title")).
+			Options(
+				huh.NewOption("OpenAI", "openai"),
+				huh.NewOption("DeepSeek", "deepseek"),
+				huh.NewOption(i18n.T("setup.provider.ollama"), "ollama"),
+			).
+			Value(&provider),
+	)).WithShowHelp(false).WithProgramOptions(detectConsole().programOptions()...).Run()
 	if err != nil {
 		return err
 	}
@@ -56,13 +65,13 @@ func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
 	if provider != "ollama" {
 		fields = append(fields,
 			huh.NewInput().
-				Title("API Key").
-				Description("Enter your API key").
+				Title(i18n.T("setup.api_key.title")).
+				Description(i18n.T("setup.api_key.desc")).
 				Value(&apiKey).
 				EchoMode(huh.EchoModePassword).
 				Validate(func(s string) error {
 					if len(strings.TrimSpace(s)) < 5 {
-						return fmt.Errorf("api key too short")
+						return errors.New(i18n.T("setup.api_key.too_short"))
 					}
 					return nil
 				}),
@@ -71,12 +80,12 @@ func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
 
 	fields = append(fields,
 		huh.NewInput().
-			Title("Model Name").
-			Description("Model to use").
+			Title(i18n.T("setup.model.title")).
+			Description(i18n.T("setup.model.desc")).
 			Value(&model).
 			Validate(func(s string) error {
 				if strings.TrimSpace(s) == "" {
-					return fmt.Errorf("model name cannot be empty")
+					return errors.New(i18n.T("setup.model.empty"))
 				}
 				return nil
 			}),
@@ -85,13 +94,13 @@ func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
 	if provider == "ollama" || provider == "deepseek" {
 		fields = append(fields,
 			huh.NewInput().
-				Title("API Endpoint").
-				Description("Optional custom endpoint").
+				Title(i18n.T("setup.endpoint.title")).
+				Description(i18n.T("setup.endpoint.desc")).
 				Value(&endpoint),
 		)
 	}
 
-	err = huh.NewForm(huh.NewGroup(fields...)).Run()
+	err = huh.NewForm(huh.NewGroup(fields...)).WithProgramOptions(detectConsole().programOptions()...).Run()
 	if err != nil {
 		return err
 	}
@@ -127,8 +136,9 @@ func RunInteractiveSetup(cfgMgr *config.ViperManager) error {
 		// Non-critical
 	}
 
-	fmt.Printf("\nConfiguration saved to %s\n", cfgMgr.GetConfigPath())
-	fmt.Println("Setup complete! You can now use GitSage.")
+	fmt.Println()
+	fmt.Println(i18n.T("setup.saved", cfgMgr.GetConfigPath()))
+	fmt.Println(i18n.T("setup.complete"))
 	fmt.Println()
 
 	return nil
@@ -0,0 +1,95 @@
+// Package ui provides terminal user interface components for GitSage.
+package ui
+
+import (
+	"errors"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// ErrNoTerminal is returned by the prompts of a SilentManager.
+var ErrNoTerminal = errors.New("no terminal to prompt the user with")
+
+// SilentManager implements Manager without any terminal output or input, for
+// GitSage embedded in other programs. Output is discarded and every prompt
+// fails with ErrNoTerminal.
+type SilentManager struct{}
+
+// NewSilentManager creates a new SilentManager.
+func NewSilentManager() *SilentManager {
+	return &SilentManager{}
+}
+
+// DisplayMessage discards the message.
+func (m *SilentManager) DisplayMessage(message *ai.GenerateResponse) error {
+	return nil
+}
+
+// PromptAction fails with ErrNoTerminal.
+func (m *SilentManager) PromptAction() (Action, error) {
+	return ActionCancel, ErrNoTerminal
+}
+
+// EditMessage fails with ErrNoTerminal.
+func (m *SilentManager) EditMessage(message *ai.GenerateResponse) (*ai.GenerateResponse, error) {
+	return nil, ErrNoTerminal
+}
+
+// SelectBullets fails with ErrNoTerminal.
+func (m *SilentManager) SelectBullets(bullets []string) ([]bool, error) {
+	return nil, ErrNoTerminal
+}
+
+// EditSubject fails with ErrNoTerminal.
+func (m *SilentManager) EditSubject(subject string) (string, error) {
+	return "", ErrNoTerminal
+}
+
+// ShowSpinner returns a no-op spinner.
+func (m *SilentManager) ShowSpinner(text string) Spinner {
+	return &noopSpinner{}
+}
+
+// ShowProgressSpinner returns a no-op spinner.
+func (m *SilentManager) ShowProgressSpinner(text string, total int) ProgressSpinner {
+	return &noopSpinner{}
+}
+
+// ShowError discards the error.
+func (m *SilentManager) ShowError(err error) {}
+
+// ShowSuccess discards the message.
+func (m *SilentManager) ShowSuccess(message string) {}
+
+// ShowInfo discards the message.
+func (m *SilentManager) ShowInfo(message string) {}
+
+// PromptConfirm fails with ErrNoTerminal.
+func (m *SilentManager) PromptConfirm(message string) (bool, error) {
+	return false, ErrNoTerminal
+}
+
+// ShowDiff discards the diff.
+func (m *SilentManager) ShowDiff(diff string) error {
+	return nil
+}
+
+// DisplayComparison discards the messages.
+func (m *SilentManager) DisplayComparison(previous, current *ai.GenerateResponse) error {
+	return nil
+}
+
+// SelectAttempt fails with ErrNoTerminal.
+func (m *SilentManager) SelectAttempt(attempts []*ai.GenerateResponse) (int, error) {
+	return -1, ErrNoTerminal
+}
+
+// SelectCandidate keeps the first candidate.
+func (m *SilentManager) SelectCandidate(candidates []Candidate) (int, error) {
+	return 0, nil
+}
+
+// SelectFiles fails with ErrNoTerminal.
+func (m *SilentManager) SelectFiles(files []FileOption) ([]string, error) {
+	return nil, ErrNoTerminal
+}
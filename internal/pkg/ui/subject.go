@@ -0,0 +1,90 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// EditSubject lets the user change the subject line in a single-line input
+// pre-filled with subject, without opening the editor.
+func (m *DefaultManager) EditSubject(subject string) (string, error) {
+	p := newProgram(newSubjectInputModel(subject))
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", err
+	}
+
+	result := finalModel.(subjectInputModel)
+	if result.cancelled {
+		return "", errEditCancelled
+	}
+	return result.input.Value(), nil
+}
+
+// subjectInputModel is the Bubble Tea model for editing the subject line.
+// Enter saves it; Esc and Ctrl+C cancel.
+type subjectInputModel struct {
+	input     textinput.Model
+	done      bool
+	cancelled bool
+}
+
+func newSubjectInputModel(subject string) subjectInputModel {
+	input := textinput.New()
+	input.Prompt = "› "
+	input.CharLimit = 0
+	input.SetValue(subject)
+	input.CursorEnd()
+	input.Focus()
+	return subjectInputModel{input: input}
+}
+
+func (m subjectInputModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m subjectInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "enter":
+			m.done = true
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			m.done, m.cancelled = true, true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m subjectInputModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("39"))
+
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("245"))
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(i18n.T("ui.subject.title")))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.input.View())
+	sb.WriteString("\n\n")
+	sb.WriteString(descStyle.Render(i18n.T("ui.subject.help", utf8.RuneCountInString(m.input.Value()))))
+	return sb.String()
+}
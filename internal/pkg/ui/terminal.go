@@ -0,0 +1,125 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// isTerminal reports whether the given file is attached to a terminal.
+// Tests replace it to run the same way with or without one.
+var isTerminal = isCharDevice
+
+// isCharDevice reports whether the given file is a character device, as terminals are.
+func isCharDevice(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// IsInteractive reports whether both stdin and stdout are attached to a terminal.
+// Bubble Tea programs should only be launched when this returns true.
+func IsInteractive() bool {
+	return isTerminal(os.Stdin) && isTerminal(os.Stdout)
+}
+
+// ColorEnabled resolves whether colored output should be used.
+// Priority: NO_COLOR > CLICOLOR_FORCE > configured value (only when stdout is a
+// TTY that interprets escape sequences).
+// See https://no-color.org and https://bixense.com/clicolors.
+func ColorEnabled(configured bool) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return true
+	}
+	return configured && isTerminal(os.Stdout) && detectConsole().vt
+}
+
+// applyColorProfile aligns the lipgloss renderer with the resolved color setting.
+// Without this, lipgloss would drop colors on redirected output even when forced,
+// or keep them when NO_COLOR is set on a real terminal.
+func applyColorProfile(colorEnabled bool) {
+	if !colorEnabled {
+		lipgloss.SetColorProfile(termenv.Ascii)
+		return
+	}
+	if !isTerminal(os.Stdout) {
+		lipgloss.SetColorProfile(termenv.ANSI256)
+	}
+}
+
+// Options configures the Manager returned by NewManager.
+type Options struct {
+	ColorEnabled bool
+	Editor       string
+	// AutoAccept makes prompts accept without user input.
+	AutoAccept bool
+	// KeyMap configures the interactive prompts; the zero value uses DefaultKeyMap.
+	KeyMap KeyMap
+	// VimMode enables vim-style modal editing in the inline editor.
+	VimMode bool
+	// Accessible replaces Bubble Tea widgets with numbered line prompts for screen readers.
+	Accessible bool
+	// Quiet drops spinners and success messages; errors are still shown.
+	Quiet bool
+	// NoInput makes prompts fail with ErrInputRequired instead of asking the user.
+	NoInput bool
+}
+
+// NewManager creates the appropriate Manager for the current terminal.
+// When stdin/stdout are not attached to a terminal (pipes, redirects, CI),
+// the NonInteractiveManager is returned so no Bubble Tea program is launched;
+// it answers prompts only with AutoAccept.
+// In accessible mode, and on legacy consoles where Bubble Tea programs cannot
+// run, an AccessibleManager is returned. On capable terminals a
+// SessionManager runs the whole flow in one program; dumb terminals fall back
+// to the DefaultManager with plain progress output.
+// With Quiet or NoInput set, the manager is wrapped to honor them. Without a
+// terminal nobody can answer, so prompts fail as with NoInput unless
+// AutoAccept answers for the user.
+// Callers should Close the returned manager if it implements interface{ Close() }.
+func NewManager(opts Options) Manager {
+	m := newTerminalManager(opts)
+	noInput := opts.NoInput || (!IsInteractive() && !opts.AutoAccept)
+	if opts.Quiet || noInput {
+		return &restrictedManager{Manager: m, quiet: opts.Quiet, noInput: noInput, autoAccept: opts.AutoAccept}
+	}
+	return m
+}
+
+// newTerminalManager creates the Manager matching the terminal's capabilities.
+func newTerminalManager(opts Options) Manager {
+	colorEnabled := ColorEnabled(opts.ColorEnabled) && !opts.Accessible
+	applyColorProfile(colorEnabled)
+
+	keys := opts.KeyMap
+	if len(keys.Up.Keys()) == 0 {
+		keys = DefaultKeyMap()
+	}
+
+	if !IsInteractive() {
+		return NewNonInteractiveManager(colorEnabled)
+	}
+	if opts.Accessible || LegacyConsole() {
+		m := NewAccessibleManager(opts.Editor, opts.AutoAccept)
+		m.keys = keys
+		return m
+	}
+	if usePlainProgress() {
+		m := NewDefaultManager(colorEnabled, opts.Editor, opts.AutoAccept)
+		m.keys, m.vimMode = keys, opts.VimMode
+		return m
+	}
+	m := NewSessionManager(colorEnabled, opts.Editor, opts.AutoAccept)
+	m.keys, m.vimMode = keys, opts.VimMode
+	return m
+}
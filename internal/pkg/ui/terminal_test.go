@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// stubTerminal makes every file look attached to a terminal or not until the test ends.
+func stubTerminal(t *testing.T, attached bool) {
+	t.Helper()
+	orig := isTerminal
+	isTerminal = func(*os.File) bool { return attached }
+	t.Cleanup(func() { isTerminal = orig })
+}
+
+// withoutColors makes lipgloss render without escape sequences until the test
+// ends, as it does when the tests do not run in a terminal.
+func withoutColors(t *testing.T) {
+	t.Helper()
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	t.Cleanup(func() { lipgloss.SetColorProfile(profile) })
+}
+
+func TestColorEnabled(t *testing.T) {
+	stubTerminal(t, false)
+
+	tests := []struct {
+		name       string
+		noColor    string
+		forceColor string
+		configured bool
+		expected   bool
+	}{
+		{"NO_COLOR disables color", "1", "", true, false},
+		{"NO_COLOR wins over CLICOLOR_FORCE", "1", "1", true, false},
+		{"CLICOLOR_FORCE enables color", "", "1", false, true},
+		{"CLICOLOR_FORCE=0 is ignored", "", "0", false, false},
+		// Stdout is not a terminal, so configured color is dropped
+		{"configured color without TTY", "", "", true, false},
+		{"color disabled in config", "", "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("CLICOLOR_FORCE", tt.forceColor)
+
+			if got := ColorEnabled(tt.configured); got != tt.expected {
+				t.Errorf("ColorEnabled(%v) = %v, want %v", tt.configured, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsCharDevice(t *testing.T) {
+	if isCharDevice(nil) {
+		t.Error("isCharDevice(nil) should be false")
+	}
+
+	f, err := os.CreateTemp(t.TempDir(), "tty-*")
+	if err != nil {
+		t.Fatalf("failed to create temp file: %v", err)
+	}
+	defer f.Close()
+
+	if isCharDevice(f) {
+		t.Error("regular file should not be detected as a terminal")
+	}
+}
+
+func TestNewManager_NonInteractive(t *testing.T) {
+	// Without a TTY the non-interactive manager must be selected
+	stubTerminal(t, false)
+	m := NewManager(Options{ColorEnabled: true, AutoAccept: true})
+	if _, ok := m.(*NonInteractiveManager); !ok {
+		t.Errorf("NewManager() = %T, want *NonInteractiveManager", m)
+	}
+	if action, err := m.PromptAction(); err != nil || action != ActionAccept {
+		t.Errorf("PromptAction() = %v, %v; want ActionAccept with AutoAccept", action, err)
+	}
+}
+
+func TestNewManager_NonInteractiveWithoutAutoAccept(t *testing.T) {
+	// Without a TTY nobody can answer, so prompts fail unless --yes is given
+	stubTerminal(t, false)
+	m := NewManager(Options{})
+	if _, err := m.PromptAction(); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("PromptAction() error = %v, want ErrInputRequired", err)
+	}
+	if _, err := m.PromptConfirm("push?"); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("PromptConfirm() error = %v, want ErrInputRequired", err)
+	}
+}
+
+func TestNewManager_Interactive(t *testing.T) {
+	stubTerminal(t, true)
+	m := NewManager(Options{Accessible: true})
+	if _, ok := m.(*AccessibleManager); !ok {
+		t.Errorf("NewManager() = %T, want *AccessibleManager on a terminal", m)
+	}
+}
+
+func TestNonInteractiveManager_NoTTYSpinner(t *testing.T) {
+	m := NewNonInteractiveManager(false)
+	m.tty = false
+	m.plainProgress = false
+
+	if _, ok := m.ShowSpinner("test").(*noopSpinner); !ok {
+		t.Error("ShowSpinner() should return a no-op spinner without a TTY")
+	}
+	if _, ok := m.ShowProgressSpinner("test", 3).(*noopSpinner); !ok {
+		t.Error("ShowProgressSpinner() should return a no-op spinner without a TTY")
+	}
+}
@@ -0,0 +1,242 @@
+// Package ui provides interactive terminal UI components for GitSage.
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/gitsage/gitsage/internal/pkg/i18n"
+)
+
+// editorCommand is a request from the inline editor to end editing.
+type editorCommand int
+
+const (
+	editorNone editorCommand = iota
+	editorSave
+	editorQuit
+)
+
+// inlineEditor wraps a textarea with an optional vim-style modal editing layer.
+// In vim mode the editor starts in normal mode; normal-mode keys are translated
+// into the equivalent textarea key presses.
+type inlineEditor struct {
+	textarea textarea.Model
+	vim      bool
+	insert   bool
+	pending  string // First key of a two-key command such as dd or gg
+}
+
+func newInlineEditor(content string, vim bool) inlineEditor {
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetWidth(80)
+	ta.SetHeight(10)
+	ta.SetValue(content)
+
+	return inlineEditor{
+		textarea: ta,
+		vim:      vim,
+		insert:   !vim,
+	}
+}
+
+// Focus focuses the underlying textarea.
+func (e *inlineEditor) Focus() tea.Cmd {
+	return e.textarea.Focus()
+}
+
+// Value returns the edited text.
+func (e inlineEditor) Value() string {
+	return e.textarea.Value()
+}
+
+// Update handles a message and reports whether the user asked to save or quit.
+func (e inlineEditor) Update(msg tea.Msg) (inlineEditor, tea.Cmd, editorCommand) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok || !e.vim || e.insert {
+		if ok && e.vim && keyMsg.Type == tea.KeyEsc {
+			// Like vim, leaving insert mode puts the cursor on the last inserted character
+			e.insert = false
+			if e.textarea.LineInfo().ColumnOffset > 0 {
+				e.send(tea.KeyLeft)
+			}
+			return e, nil, editorNone
+		}
+		var cmd tea.Cmd
+		e.textarea, cmd = e.textarea.Update(msg)
+		return e, cmd, editorNone
+	}
+
+	return e.normalMode(keyMsg)
+}
+
+// normalMode handles a key press in vim normal mode.
+func (e inlineEditor) normalMode(msg tea.KeyMsg) (inlineEditor, tea.Cmd, editorCommand) {
+	k := msg.String()
+
+	if e.pending != "" {
+		seq := e.pending + k
+		e.pending = ""
+		switch seq {
+		case "gg":
+			e.send(tea.KeyCtrlHome)
+		case "dd":
+			e.deleteLine()
+		case "ZZ":
+			return e, nil, editorSave
+		case "ZQ":
+			return e, nil, editorQuit
+		}
+		return e, nil, editorNone
+	}
+
+	switch k {
+	case "g", "d", "Z":
+		e.pending = k
+	case "h", "left":
+		e.send(tea.KeyLeft)
+	case "l", "right":
+		e.send(tea.KeyRight)
+	case "j", "down":
+		e.send(tea.KeyDown)
+	case "k", "up":
+		e.send(tea.KeyUp)
+	case "w":
+		e.sendAlt(tea.KeyRight)
+	case "b":
+		e.sendAlt(tea.KeyLeft)
+	case "0", "home":
+		e.send(tea.KeyHome)
+	case "$", "end":
+		e.send(tea.KeyEnd)
+	case "G":
+		e.send(tea.KeyCtrlEnd)
+	case "x":
+		e.send(tea.KeyDelete)
+	case "D":
+		e.send(tea.KeyCtrlK)
+	case "i":
+		e.insert = true
+	case "a":
+		e.send(tea.KeyRight)
+		e.insert = true
+	case "A":
+		e.send(tea.KeyEnd)
+		e.insert = true
+	case "I":
+		e.send(tea.KeyHome)
+		e.insert = true
+	case "o":
+		e.send(tea.KeyEnd)
+		e.textarea.InsertRune('\n')
+		e.insert = true
+	case "O":
+		e.send(tea.KeyHome)
+		e.textarea.InsertRune('\n')
+		e.textarea.CursorUp()
+		e.insert = true
+	}
+	return e, nil, editorNone
+}
+
+// deleteLine removes the current line including its line break.
+func (e *inlineEditor) deleteLine() {
+	last := e.textarea.Line() == e.textarea.LineCount()-1
+	e.send(tea.KeyHome)
+	e.send(tea.KeyCtrlK)
+	switch {
+	case !last:
+		e.send(tea.KeyDelete)
+	case e.textarea.Line() > 0:
+		e.send(tea.KeyBackspace)
+	}
+}
+
+// send feeds a synthetic key press to the textarea.
+func (e *inlineEditor) send(t tea.KeyType) {
+	e.textarea, _ = e.textarea.Update(tea.KeyMsg{Type: t})
+}
+
+// sendAlt feeds a synthetic alt+key press to the textarea.
+func (e *inlineEditor) sendAlt(t tea.KeyType) {
+	e.textarea, _ = e.textarea.Update(tea.KeyMsg{Type: t, Alt: true})
+}
+
+// View renders the editor with the current vim mode, if enabled.
+func (e inlineEditor) View() string {
+	if !e.vim {
+		return e.textarea.View()
+	}
+
+	modeStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("245"))
+	mode := i18n.T("ui.edit.mode_normal")
+	if e.insert {
+		mode = i18n.T("ui.edit.mode_insert")
+	}
+	return e.textarea.View() + "\n" + modeStyle.Render(mode)
+}
+
+// help returns the key help line for the editor.
+func (e inlineEditor) help() string {
+	if e.vim {
+		return i18n.T("ui.edit.help_vim")
+	}
+	return i18n.T("ui.edit.help")
+}
+
+// inlineEditModel is a standalone Bubble Tea program around inlineEditor,
+// used by DefaultManager when vim mode is enabled.
+type inlineEditModel struct {
+	editor    inlineEditor
+	saved     bool
+	cancelled bool
+}
+
+func (m inlineEditModel) Init() tea.Cmd {
+	return m.editor.Focus()
+}
+
+func (m inlineEditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "ctrl+d":
+			m.saved = true
+			return m, tea.Quit
+		case "ctrl+c":
+			m.cancelled = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	var command editorCommand
+	m.editor, cmd, command = m.editor.Update(msg)
+	switch command {
+	case editorSave:
+		m.saved = true
+		return m, tea.Quit
+	case editorQuit:
+		m.cancelled = true
+		return m, tea.Quit
+	}
+	return m, cmd
+}
+
+func (m inlineEditModel) View() string {
+	if m.saved || m.cancelled {
+		return ""
+	}
+	return renderEditView(m.editor)
+}
+
+// renderEditView renders the inline editor with its title and help line.
+func renderEditView(e inlineEditor) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
+	return titleStyle.Render(i18n.T("ui.edit.title")) + "\n\n" +
+		e.View() + "\n\n" +
+		descStyle.Render(e.help())
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+// typeKeys feeds a sequence of single-key presses to the editor.
+func typeKeys(e inlineEditor, keys ...string) (inlineEditor, editorCommand) {
+	var command editorCommand
+	for _, k := range keys {
+		e, _, command = e.Update(keyMsg(k))
+	}
+	return e, command
+}
+
+func TestInlineEditor_NoVim(t *testing.T) {
+	e := newInlineEditor("feat", false)
+	e.Focus()
+
+	e, _ = typeKeys(e, "x")
+	if e.Value() != "featx" {
+		t.Errorf("Value() = %q, want %q", e.Value(), "featx")
+	}
+	if strings.Contains(e.View(), "NORMAL") {
+		t.Error("mode indicator should only be shown in vim mode")
+	}
+}
+
+func TestInlineEditor_Vim(t *testing.T) {
+	t.Run("starts in normal mode", func(t *testing.T) {
+		e := newInlineEditor("feat", true)
+		e.Focus()
+
+		e, _ = typeKeys(e, "q", "r")
+		if e.Value() != "feat" {
+			t.Errorf("normal mode should not insert text, got %q", e.Value())
+		}
+		if !strings.Contains(e.View(), "-- NORMAL --") {
+			t.Error("View() should show normal mode")
+		}
+	})
+
+	t.Run("insert and escape", func(t *testing.T) {
+		e := newInlineEditor("feat", true)
+		e.Focus()
+
+		e, _ = typeKeys(e, "0", "i", "!", "esc", "x")
+		if e.Value() != "feat" {
+			t.Errorf("Value() = %q, want %q", e.Value(), "feat")
+		}
+	})
+
+	t.Run("append at end of line", func(t *testing.T) {
+		e := newInlineEditor("feat", true)
+		e.Focus()
+
+		e, _ = typeKeys(e, "0", "A", ":")
+		if e.Value() != "feat:" {
+			t.Errorf("Value() = %q, want %q", e.Value(), "feat:")
+		}
+	})
+
+	t.Run("open line below", func(t *testing.T) {
+		e := newInlineEditor("subject", true)
+		e.Focus()
+
+		e, _ = typeKeys(e, "o", "b", "o", "d", "y")
+		if e.Value() != "subject\nbody" {
+			t.Errorf("Value() = %q, want %q", e.Value(), "subject\nbody")
+		}
+	})
+
+	t.Run("dd deletes the current line", func(t *testing.T) {
+		e := newInlineEditor("one\ntwo\nthree", true)
+		e.Focus()
+
+		e, _ = typeKeys(e, "g", "g", "j", "d", "d")
+		if e.Value() != "one\nthree" {
+			t.Errorf("Value() = %q, want %q", e.Value(), "one\nthree")
+		}
+
+		e, _ = typeKeys(e, "G", "d", "d")
+		if e.Value() != "one" {
+			t.Errorf("Value() = %q, want %q", e.Value(), "one")
+		}
+	})
+
+	t.Run("ZZ saves and ZQ quits", func(t *testing.T) {
+		e := newInlineEditor("feat", true)
+		e.Focus()
+
+		if _, command := typeKeys(e, "Z", "Z"); command != editorSave {
+			t.Errorf("ZZ command = %v, want editorSave", command)
+		}
+		if _, command := typeKeys(e, "Z", "Q"); command != editorQuit {
+			t.Errorf("ZQ command = %v, want editorQuit", command)
+		}
+	})
+}
+
+func TestInlineEditModel(t *testing.T) {
+	m := inlineEditModel{editor: newInlineEditor("feat", true)}
+	m.Init()
+
+	updated, cmd := m.Update(keyMsg("ctrl+d"))
+	if result := updated.(inlineEditModel); !result.saved || cmd == nil {
+		t.Error("ctrl+d should save and quit")
+	}
+
+	updated, _ = m.Update(keyMsg("Z"))
+	updated, _ = updated.Update(keyMsg("Q"))
+	if result := updated.(inlineEditModel); !result.cancelled {
+		t.Error("ZQ should cancel")
+	}
+}
@@ -0,0 +1,156 @@
+// Package gitsage generates commit messages from diffs for Go programs that
+// embed GitSage. It uses the same providers, prompts and diff processing as
+// the gitsage command, without a terminal or configuration files:
+//
+//	gen, err := gitsage.New(gitsage.Options{Provider: "openai", APIKey: key})
+//	if err != nil {
+//		return err
+//	}
+//	msg, err := gen.GenerateStaged(ctx, repoDir, gitsage.Request{})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(msg)
+//
+// Custom AI backends are added with RegisterProvider, and custom diff
+// processing, such as filters, with RegisterDiffStage.
+package gitsage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gitsage/gitsage/internal/app"
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+	"github.com/gitsage/gitsage/internal/pkg/config"
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+	"github.com/gitsage/gitsage/internal/pkg/ui"
+)
+
+// Options configures a Generator. Zero values select the provider's defaults.
+type Options struct {
+	// Provider is "openai" (the default), "deepseek", "ollama", "mock" (no
+	// network, for tests and demos) or a name passed to RegisterProvider.
+	Provider    string
+	APIKey      string
+	Model       string
+	Endpoint    string
+	Temperature float32
+	MaxTokens   int
+	// Preset is the body detail: "minimal", "standard" (the default) or "detailed".
+	Preset string
+}
+
+// Request describes the change to write a commit message for.
+type Request struct {
+	// Diff is the output of git diff for the change, without colors.
+	Diff string
+	// RecentCommits are subjects of the latest commits on the branch, most
+	// recent first, to match their style.
+	RecentCommits []string
+	// CommitTemplate is a commit message template the message must follow.
+	CommitTemplate string
+	// Context is the developer's explanation of why the change was made.
+	Context string
+	// Type and Scope are the Conventional Commits type and scope the message
+	// must use, if set.
+	Type  string
+	Scope string
+}
+
+// Message is a generated commit message.
+type Message struct {
+	Subject string
+	Body    string
+	Footer  string
+}
+
+// String returns the message as passed to git commit: the subject, body and
+// footer separated by blank lines.
+func (m *Message) String() string {
+	parts := []string{m.Subject}
+	if m.Body != "" {
+		parts = append(parts, m.Body)
+	}
+	if m.Footer != "" {
+		parts = append(parts, m.Footer)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// Generator generates commit messages with one provider. It is safe for use
+// by one goroutine at a time.
+type Generator struct {
+	service *app.CommitService
+}
+
+// New creates a Generator for the provider in opts.
+func New(opts Options) (*Generator, error) {
+	if _, err := ai.ParsePreset(opts.Preset); err != nil {
+		return nil, err
+	}
+
+	cfg := &config.Config{
+		Provider: config.ProviderConfig{
+			Name:        opts.Provider,
+			APIKey:      opts.APIKey,
+			Model:       opts.Model,
+			Endpoint:    opts.Endpoint,
+			Temperature: opts.Temperature,
+			MaxTokens:   opts.MaxTokens,
+		},
+		Generation: config.GenerationConfig{Preset: opts.Preset},
+	}
+
+	provider, err := ai.NewProvider(&cfg.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+
+	return &Generator{
+		service: app.NewCommitService(nil, provider, processor.NewProcessor(), ui.NewSilentManager(), nil, cfg),
+	}, nil
+}
+
+// Generate writes a commit message for req.Diff.
+func (g *Generator) Generate(ctx context.Context, req Request) (*Message, error) {
+	chunks, err := git.ParseDiff(strings.NewReader(req.Diff))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse diff: %w", err)
+	}
+	return g.generate(ctx, chunks, req)
+}
+
+// GenerateStaged writes a commit message for the changes staged in the
+// repository at dir, in place of req.Diff.
+func (g *Generator) GenerateStaged(ctx context.Context, dir string, req Request) (*Message, error) {
+	chunks, err := git.NewClientWithWorkDir(dir).GetStagedDiff(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	return g.generate(ctx, chunks, req)
+}
+
+// generate writes a commit message for the parsed changes.
+func (g *Generator) generate(ctx context.Context, chunks []git.DiffChunk, req Request) (*Message, error) {
+	response, err := g.service.GenerateMessage(ctx, &app.MessageRequest{
+		Chunks:         chunks,
+		RecentCommits:  req.RecentCommits,
+		CommitTemplate: req.CommitTemplate,
+		Context:        req.Context,
+		Intent:         ai.Intent{Type: req.Type, Scope: req.Scope},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &Message{Subject: response.Subject, Body: response.Body, Footer: response.Footer}
+	if msg.Subject == "" {
+		// Unstructured replies are used as they are
+		msg.Subject, msg.Body, _ = strings.Cut(strings.TrimSpace(response.RawText), "\n")
+		msg.Body = strings.TrimSpace(msg.Body)
+	}
+	return msg, nil
+}
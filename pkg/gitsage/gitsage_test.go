@@ -0,0 +1,248 @@
+package gitsage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/auth/token.go b/auth/token.go
+index 1111111..2222222 100644
+--- a/auth/token.go
++++ b/auth/token.go
+@@ -1,2 +1,3 @@
+ package auth
++// Refresh renews an expired token.
+ func Refresh() {}
+`
+
+// recordingProvider replies with a fixed message and records the prompts it gets.
+type recordingProvider struct {
+	config  ProviderConfig
+	reply   string
+	err     error
+	prompts []*Prompt
+}
+
+func (p *recordingProvider) Complete(ctx context.Context, prompt *Prompt) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	return p.reply, p.err
+}
+
+// registerRecording registers a recordingProvider under a name unique to the test.
+func registerRecording(t *testing.T, reply string) (string, *recordingProvider) {
+	t.Helper()
+	name := "recording-" + strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-"))
+	provider := &recordingProvider{reply: reply}
+	err := RegisterProvider(name, func(cfg ProviderConfig) (Provider, error) {
+		provider.config = cfg
+		return provider, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterProvider() error = %v", err)
+	}
+	return name, provider
+}
+
+func TestGenerate(t *testing.T) {
+	name, provider := registerRecording(t, "fix(auth): document token refresh\n\nExplain when tokens are renewed.")
+
+	gen, err := New(Options{Provider: name, Model: "local-model"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	msg, err := gen.Generate(context.Background(), Request{
+		Diff:          sampleDiff,
+		RecentCommits: []string{"feat(auth): add login"},
+		Context:       "reviewers asked when refresh runs",
+		Scope:         "auth",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if msg.Subject != "fix(auth): document token refresh" || msg.Body != "Explain when tokens are renewed." {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if got := msg.String(); got != "fix(auth): document token refresh\n\nExplain when tokens are renewed." {
+		t.Errorf("String() = %q", got)
+	}
+
+	if len(provider.prompts) != 1 {
+		t.Fatalf("expected 1 prompt, got %d", len(provider.prompts))
+	}
+	prompt := provider.prompts[0]
+	for _, want := range []string{"auth/token.go", "feat(auth): add login", "reviewers asked when refresh runs"} {
+		if !strings.Contains(prompt.User, want) {
+			t.Errorf("expected the prompt to contain %q", want)
+		}
+	}
+	if prompt.System == "" {
+		t.Error("expected a system prompt")
+	}
+	if prompt.Model != "local-model" || prompt.Temperature == 0 || prompt.MaxTokens == 0 {
+		t.Errorf("expected the configured model and default limits, got %+v", prompt)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	name, provider := registerRecording(t, "")
+
+	gen, err := New(Options{Provider: name})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := gen.Generate(context.Background(), Request{}); err == nil {
+		t.Error("expected an error for an empty diff")
+	}
+	if _, err := gen.Generate(context.Background(), Request{Diff: sampleDiff, Type: "not a type"}); err == nil {
+		t.Error("expected an error for an invalid type")
+	}
+
+	provider.err = errors.New("backend unavailable")
+	if _, err := gen.Generate(context.Background(), Request{Diff: sampleDiff}); err == nil || !strings.Contains(err.Error(), "backend unavailable") {
+		t.Errorf("expected the provider error, got %v", err)
+	}
+}
+
+func TestGenerateStaged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	name, provider := registerRecording(t, "feat: add greeting")
+
+	dir := t.TempDir()
+	for _, args := range [][]string{{"init"}, {"config", "user.email", "test@example.com"}, {"config", "user.name", "Test User"}} {
+		runGit(t, dir, args...)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", "hello.txt")
+
+	gen, err := New(Options{Provider: name})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	msg, err := gen.GenerateStaged(context.Background(), dir, Request{})
+	if err != nil {
+		t.Fatalf("GenerateStaged() error = %v", err)
+	}
+
+	if msg.String() != "feat: add greeting" {
+		t.Errorf("unexpected message: %q", msg.String())
+	}
+	if !strings.Contains(provider.prompts[0].User, "hello.txt") {
+		t.Error("expected the staged file in the prompt")
+	}
+}
+
+// budgetProvider is a recordingProvider reporting a small context window.
+type budgetProvider struct {
+	recordingProvider
+}
+
+func (p *budgetProvider) Capabilities() Capabilities {
+	return Capabilities{MaxContextTokens: 64}
+}
+
+func TestGenerate_Capabilities(t *testing.T) {
+	provider := &budgetProvider{recordingProvider{reply: "docs: document token refresh"}}
+	name := "budget-provider"
+	if err := RegisterProvider(name, func(ProviderConfig) (Provider, error) { return provider, nil }); err != nil {
+		t.Fatalf("RegisterProvider() error = %v", err)
+	}
+
+	gen, err := New(Options{Provider: name})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := gen.Generate(context.Background(), Request{Diff: sampleDiff}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// A diff that does not fit the 64-token window is summarized before the
+	// message is generated
+	if len(provider.prompts) < 2 {
+		t.Errorf("expected the diff summarized before generating, got %d prompts", len(provider.prompts))
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(Options{Provider: "no-such-provider"}); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+	if _, err := New(Options{Provider: "openai"}); err == nil {
+		t.Error("expected an error without an API key")
+	}
+
+	name, _ := registerRecording(t, "")
+	if _, err := New(Options{Provider: name, Preset: "verbose"}); err == nil {
+		t.Error("expected an error for an invalid preset")
+	}
+}
+
+func TestRegisterProvider_Invalid(t *testing.T) {
+	factory := func(ProviderConfig) (Provider, error) { return &recordingProvider{}, nil }
+
+	if err := RegisterProvider("openai", factory); err == nil {
+		t.Error("expected built-in providers to be protected")
+	}
+	if err := RegisterProvider("no-factory", nil); err == nil {
+		t.Error("expected an error without a factory")
+	}
+}
+
+// runGit runs a git command in dir and fails the test on error.
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+func TestRegisterDiffStage(t *testing.T) {
+	// The stage stays registered; it only touches files under secret/
+	err := RegisterDiffStage("test-drop-secret", PhaseFilter, func(ctx context.Context, files []File) ([]File, error) {
+		var kept []File
+		for _, file := range files {
+			if !strings.HasPrefix(file.Path, "secret/") {
+				kept = append(kept, file)
+			}
+		}
+		return kept, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterDiffStage() error = %v", err)
+	}
+	if err := RegisterDiffStage("test-bad-phase", "upload", func(ctx context.Context, files []File) ([]File, error) { return files, nil }); err == nil {
+		t.Error("expected an error for an unknown phase")
+	}
+
+	name, provider := registerRecording(t, "docs(auth): document token refresh")
+	gen, err := New(Options{Provider: name})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	secretDiff := sampleDiff + `diff --git a/secret/plan.md b/secret/plan.md
+index 1111111..2222222 100644
+--- a/secret/plan.md
++++ b/secret/plan.md
+@@ -1 +1,2 @@
+ # Plan
++Acquire the competitor
+`
+	if _, err := gen.Generate(context.Background(), Request{Diff: secretDiff}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if prompt := provider.prompts[0].User; strings.Contains(prompt, "secret/plan.md") || !strings.Contains(prompt, "auth/token.go") {
+		t.Errorf("expected only auth/token.go in the prompt:\n%s", prompt)
+	}
+}
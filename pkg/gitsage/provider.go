@@ -0,0 +1,164 @@
+package gitsage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gitsage/gitsage/internal/pkg/ai"
+)
+
+// ProviderConfig is the configuration a provider is created with, taken
+// from Options.
+type ProviderConfig struct {
+	APIKey      string
+	Model       string
+	Endpoint    string
+	Temperature float32
+	MaxTokens   int
+}
+
+// Prompt is a request rendered by GitSage for a provider to complete.
+type Prompt struct {
+	System string
+	User   string
+	// Model and Temperature are the configured values, with GitSage's
+	// defaults applied. MaxTokens is the configured limit on the reply.
+	Model       string
+	Temperature float32
+	MaxTokens   int
+}
+
+// Provider is an AI backend added with RegisterProvider. Complete returns
+// the model's reply to the prompt, which GitSage parses into a Message.
+type Provider interface {
+	Complete(ctx context.Context, prompt *Prompt) (string, error)
+}
+
+// Capabilities describes the limits and features of a provider's model.
+type Capabilities struct {
+	// MaxContextTokens is the model's context window; zero if unknown. The
+	// diff sent in a single request is sized from it.
+	MaxContextTokens int
+	Streaming        bool
+	JSONMode         bool
+	// InputCostPer1K and OutputCostPer1K are the prices in USD per 1,000 tokens.
+	InputCostPer1K  float64
+	OutputCostPer1K float64
+}
+
+// CapabilityReporter is implemented by providers that know the capabilities
+// of their model. Providers that do not are sent diffs of a conservative size.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// ProviderFactory creates a provider from its configuration.
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+// RegisterProvider makes a provider available as Options.Provider under name.
+// It is typically called from an init function. Built-in providers cannot be
+// replaced, and a name can only be registered once.
+func RegisterProvider(name string, factory ProviderFactory) error {
+	if factory == nil {
+		return errors.New("provider factory is required")
+	}
+	return ai.RegisterProvider(name, func(cfg ai.ProviderConfig) (ai.Provider, error) {
+		if cfg.Temperature == 0 {
+			cfg.Temperature = ai.DefaultTemperature
+		}
+		if cfg.MaxTokens == 0 {
+			cfg.MaxTokens = ai.DefaultMaxTokens
+		}
+
+		provider, err := factory(ProviderConfig{
+			APIKey:      cfg.APIKey,
+			Model:       cfg.Model,
+			Endpoint:    cfg.Endpoint,
+			Temperature: cfg.Temperature,
+			MaxTokens:   cfg.MaxTokens,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &providerAdapter{name: name, provider: provider, config: cfg, promptTemplate: ai.NewPromptTemplate()}, nil
+	})
+}
+
+// providerAdapter renders prompts for a registered Provider and parses its
+// replies, implementing ai.Provider.
+type providerAdapter struct {
+	name           string
+	provider       Provider
+	config         ai.ProviderConfig
+	promptTemplate *ai.PromptTemplate
+}
+
+// GenerateCommitMessage renders the request and parses the provider's reply.
+func (p *providerAdapter) GenerateCommitMessage(ctx context.Context, req *ai.GenerateRequest) (*ai.GenerateResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	if len(req.DiffChunks) == 0 && req.CustomPrompt == "" {
+		return nil, errors.New("no diff chunks provided")
+	}
+
+	systemPrompt, userPrompt, err := p.promptTemplate.BuildPrompts(req, !p.Capabilities().FitsContext(req, p.config.MaxTokens))
+	if err != nil {
+		return nil, fmt.Errorf("failed to render prompt: %w", err)
+	}
+
+	prompt := &Prompt{
+		System:      systemPrompt,
+		User:        userPrompt,
+		Model:       p.config.Model,
+		Temperature: p.config.Temperature,
+		MaxTokens:   p.config.MaxTokens,
+	}
+	if req.Model != "" {
+		prompt.Model = req.Model
+	}
+	if req.Temperature != 0 {
+		prompt.Temperature = req.Temperature
+	}
+
+	rawText, err := p.provider.Complete(ctx, prompt)
+	if err != nil {
+		return nil, err
+	}
+	if rawText == "" {
+		return nil, errors.New("no response from AI provider")
+	}
+	return ai.ParseCommitMessage(rawText).ToGenerateResponse(rawText), nil
+}
+
+// Name returns the registered name.
+func (p *providerAdapter) Name() string {
+	return p.name
+}
+
+// Capabilities returns the capabilities reported by the provider, if any.
+func (p *providerAdapter) Capabilities() ai.Capabilities {
+	reporter, ok := p.provider.(CapabilityReporter)
+	if !ok {
+		return ai.Capabilities{}
+	}
+	c := reporter.Capabilities()
+	return ai.Capabilities{
+		MaxContextTokens: c.MaxContextTokens,
+		Streaming:        c.Streaming,
+		JSONMode:         c.JSONMode,
+		InputCostPer1K:   c.InputCostPer1K,
+		OutputCostPer1K:  c.OutputCostPer1K,
+	}
+}
+
+// ValidateConfig accepts any configuration; the factory validates it.
+func (p *providerAdapter) ValidateConfig(config ai.ProviderConfig) error {
+	return nil
+}
+
+// SetPromptTemplate replaces the prompt template.
+func (p *providerAdapter) SetPromptTemplate(pt *ai.PromptTemplate) {
+	p.promptTemplate = pt
+}
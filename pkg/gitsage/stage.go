@@ -0,0 +1,88 @@
+package gitsage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gitsage/gitsage/internal/pkg/git"
+	"github.com/gitsage/gitsage/internal/pkg/processor"
+)
+
+// Diff processing phases, in the order they run. Registered stages run after
+// the built-in stages of their phase.
+const (
+	// PhaseFilter drops files; lock files are already removed.
+	PhaseFilter = processor.PhaseFilter
+	// PhaseRedact removes content that must not be sent to the provider.
+	PhaseRedact = processor.PhaseRedact
+	// PhaseSummarize shortens content; generated and formatting-only files
+	// are already summarized.
+	PhaseSummarize = processor.PhaseSummarize
+	// PhaseGroup runs after the diff was sized and, if it is large, split
+	// into groups; changes to the files no longer affect the grouping.
+	PhaseGroup = processor.PhaseGroup
+)
+
+// File is a changed file as seen by a diff stage.
+type File struct {
+	Path string
+	// OldPath is the previous path of a renamed file.
+	OldPath   string
+	Additions int
+	Deletions int
+	// Content is the file's part of the diff, or a summary of it.
+	Content string
+	Binary  bool
+
+	chunk git.DiffChunk
+}
+
+// DiffStage transforms the changed files before they are sent to the
+// provider and returns the files to keep, e.g. a company-specific filter.
+type DiffStage func(ctx context.Context, files []File) ([]File, error)
+
+// RegisterDiffStage adds a stage to the diff processing of every generation
+// that starts afterwards. It is typically called from an init function.
+// Names must be unique, and phase is one of the Phase constants.
+func RegisterDiffStage(name, phase string, stage DiffStage) error {
+	if stage == nil {
+		return errors.New("diff stage function is required")
+	}
+	return processor.RegisterStage(processor.Stage{
+		Name:  name,
+		Phase: phase,
+		Run: func(ctx context.Context, diff *processor.ProcessedDiff) error {
+			files := make([]File, len(diff.Chunks))
+			for i, chunk := range diff.Chunks {
+				files[i] = File{
+					Path:      chunk.FilePath,
+					OldPath:   chunk.OldPath,
+					Additions: chunk.Additions,
+					Deletions: chunk.Deletions,
+					Content:   chunk.Content,
+					Binary:    chunk.IsBinary,
+					chunk:     chunk,
+				}
+			}
+
+			files, err := stage(ctx, files)
+			if err != nil {
+				return err
+			}
+
+			chunks := make([]git.DiffChunk, len(files))
+			for i, file := range files {
+				chunk := file.chunk
+				chunk.FilePath = file.Path
+				chunk.OldPath = file.OldPath
+				chunk.Additions = file.Additions
+				chunk.Deletions = file.Deletions
+				chunk.Content = file.Content
+				chunk.IsBinary = file.Binary
+				chunks[i] = chunk
+			}
+			diff.Chunks = chunks
+			return nil
+		},
+	})
+}